@@ -10,6 +10,7 @@ import (
 	"github.com/leg100/otf/internal/agent"
 	"github.com/leg100/otf/internal/daemon"
 	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/run"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -95,6 +96,9 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringVar(&cfg.OIDC.ClientID, "oidc-client-id", "", "OIDC client ID")
 	cmd.Flags().StringVar(&cfg.OIDC.ClientSecret, "oidc-client-secret", "", "OIDC client secret")
 
+	cmd.Flags().DurationVar(&cfg.PhaseTimeouts.Plan, "plan-timeout", run.DefaultPlanTimeout, "Maximum duration of a plan, unless overridden by the workspace.")
+	cmd.Flags().DurationVar(&cfg.PhaseTimeouts.Apply, "apply-timeout", run.DefaultApplyTimeout, "Maximum duration of an apply, unless overridden by the workspace.")
+
 	cmd.Flags().BoolVar(&cfg.RestrictOrganizationCreation, "restrict-org-creation", false, "Restrict organization creation capability to site admin role")
 
 	cmd.Flags().StringVar(&cfg.GoogleIAPConfig.Audience, "google-jwt-audience", "", "The Google JWT audience claim for validation. If unspecified then validation is skipped")
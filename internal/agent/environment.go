@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
@@ -33,6 +34,9 @@ type environment struct {
 	out       io.WriteCloser       // captures CLI process output
 	variables []*variable.Variable // terraform workspace variables
 
+	structuredOutput bool          // invoke terraform with -json and capture its output
+	phaseTimeout     time.Duration // maximum duration of the phase
+
 	*executor // executes processes
 	*runner   // execute sequence of steps
 	*workdir  // working directory fs for workspace
@@ -62,6 +66,7 @@ func newEnvironment(
 	token, err := agent.CreateRunToken(ctx, tokens.CreateRunTokenOptions{
 		Organization: &ws.Organization,
 		RunID:        &run.ID,
+		WorkspaceID:  &run.WorkspaceID,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "creating registry session")
@@ -87,14 +92,16 @@ func newEnvironment(
 	})
 
 	env := &environment{
-		Logger:     logger,
-		Client:     agent,
-		Downloader: agent,
-		out:        writer,
-		workdir:    wd,
-		variables:  variables,
-		ctx:        ctx,
-		runner:     &runner{out: writer},
+		Logger:           logger,
+		Client:           agent,
+		Downloader:       agent,
+		out:              writer,
+		workdir:          wd,
+		variables:        variables,
+		structuredOutput: ws.StructuredRunOutputEnabled,
+		phaseTimeout:     run.PhaseTimeout,
+		ctx:              ctx,
+		runner:           &runner{out: writer},
 		executor: &executor{
 			Config:              agent.Config,
 			TerraformPathFinder: agent.TerraformPathFinder,
@@ -151,3 +158,10 @@ func (e *environment) cancel(force bool) {
 	e.runner.cancel(force)
 	e.executor.cancel(force)
 }
+
+// timeout forcefully terminates execution upon the phase exceeding its
+// timeout.
+func (e *environment) timeout(after time.Duration) {
+	e.runner.timeout(after)
+	e.executor.cancel(true)
+}
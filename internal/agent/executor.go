@@ -40,6 +40,7 @@ type (
 
 		// options
 		redirectStdout   *string
+		pipeStdout       io.Writer
 		sandboxIfEnabled bool
 	}
 
@@ -61,6 +62,13 @@ func redirectStdout(dst string) executionOption {
 	}
 }
 
+// pipeStdout pipes stdout to the writer rather than to the output.
+func pipeStdout(w io.Writer) executionOption {
+	return func(e *execution) {
+		e.pipeStdout = w
+	}
+}
+
 // execute executes a process.
 func (e *executor) execute(args []string, opts ...executionOption) error {
 	exe := execution{
@@ -103,6 +111,8 @@ func (e *execution) execute(args []string) error {
 		}
 		defer dst.Close()
 		cmd.Stdout = dst
+	} else if e.pipeStdout != nil {
+		cmd.Stdout = e.pipeStdout
 	} else {
 		cmd.Stdout = e.out
 	}
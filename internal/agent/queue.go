@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"sync"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+// queue is a priority queue of queued runs, safe for concurrent use. Runs are
+// ordered according to run.SortQueue.
+type queue struct {
+	mu sync.Mutex
+	// runs in the order they were added to the queue
+	runs []*run.Run
+	// changed is signaled whenever the queue is changed
+	changed chan struct{}
+}
+
+func newQueue() *queue {
+	return &queue{changed: make(chan struct{}, 1)}
+}
+
+// push adds a run to the queue, replacing any existing entry for the same run.
+func (q *queue) push(r *run.Run) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.runs = append(q.without(r.ID), r)
+	q.signal()
+}
+
+// remove removes the run with the given ID from the queue.
+func (q *queue) remove(runID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.runs = q.without(runID)
+	q.signal()
+}
+
+// removeEntry removes the run from the queue, but only if it has not since
+// been replaced by a newer entry for the same run.
+func (q *queue) removeEntry(r *run.Run) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, existing := range q.runs {
+		if existing == r {
+			q.runs = append(q.runs[:i:i], q.runs[i+1:]...)
+			q.signal()
+			return
+		}
+	}
+}
+
+// reset removes all runs from the queue.
+func (q *queue) reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.runs = nil
+	q.signal()
+}
+
+// head returns the run at the head of the queue, or nil if the queue is empty.
+func (q *queue) head() *run.Run {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.runs) == 0 {
+		return nil
+	}
+	sorted := append([]*run.Run(nil), q.runs...)
+	run.SortQueue(sorted)
+	return sorted[0]
+}
+
+func (q *queue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.runs)
+}
+
+// without returns the queued runs excluding the run with the given ID. The
+// caller must hold the lock.
+func (q *queue) without(runID string) []*run.Run {
+	runs := make([]*run.Run, 0, len(q.runs))
+	for _, r := range q.runs {
+		if r.ID != runID {
+			runs = append(runs, r)
+		}
+	}
+	return runs
+}
+
+// signal notifies a listener that the queue has changed, without blocking if
+// a notification is already pending. The caller must hold the lock.
+func (q *queue) signal() {
+	select {
+	case q.changed <- struct{}{}:
+	default:
+	}
+}
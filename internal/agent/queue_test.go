@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	plan := &run.Run{ID: "run-plan", Status: internal.RunPlanQueued}
+	speculative := &run.Run{ID: "run-speculative", Status: internal.RunPlanQueued, PlanOnly: true}
+	apply := &run.Run{ID: "run-apply", Status: internal.RunApplyQueued}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, newQueue().head())
+	})
+
+	t.Run("highest priority at head", func(t *testing.T) {
+		q := newQueue()
+		q.push(speculative)
+		q.push(plan)
+		assert.Equal(t, plan, q.head())
+		q.push(apply)
+		assert.Equal(t, apply, q.head())
+	})
+
+	t.Run("replace existing entry", func(t *testing.T) {
+		q := newQueue()
+		q.push(plan)
+		applyQueued := &run.Run{ID: "run-plan", Status: internal.RunApplyQueued}
+		q.push(applyQueued)
+		assert.Equal(t, 1, q.len())
+		assert.Equal(t, applyQueued, q.head())
+
+		// removing the stale entry should have no effect
+		q.removeEntry(plan)
+		assert.Equal(t, 1, q.len())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		q := newQueue()
+		q.push(plan)
+		q.push(apply)
+		q.remove(apply.ID)
+		assert.Equal(t, plan, q.head())
+	})
+}
+
+func TestSpooler_dispatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	spooler := newSpooler(nil, logr.Discard(), Config{})
+	speculative := &run.Run{ID: "run-speculative", Status: internal.RunPlanQueued, PlanOnly: true}
+	plan := &run.Run{ID: "run-plan", Status: internal.RunPlanQueued}
+	apply := &run.Run{ID: "run-apply", Status: internal.RunApplyQueued}
+
+	// queue runs in reverse order of priority before any worker is ready
+	spooler.queue.push(speculative)
+	spooler.queue.push(plan)
+	spooler.queue.push(apply)
+	go spooler.dispatch(ctx)
+
+	assert.Equal(t, apply, <-spooler.getRun())
+	assert.Equal(t, plan, <-spooler.getRun())
+	assert.Equal(t, speculative, <-spooler.getRun())
+}
@@ -15,7 +15,8 @@ import (
 	"gopkg.in/cenkalti/backoff.v1"
 )
 
-// spoolerCapacity is the max number of queued runs the spooler can store
+// spoolerCapacity is the max number of cancelation requests the spooler can
+// store
 const spoolerCapacity = 100
 
 var _ spooler = (*spoolerDaemon)(nil)
@@ -32,9 +33,11 @@ type (
 	}
 
 	// spoolerDaemon implements Spooler, receiving runs with either a queued plan or
-	// apply, and converting them into spooled jobs.
+	// apply, and converting them into spooled jobs. Jobs are handed out in
+	// order of priority rather than in the order in which they are received.
 	spoolerDaemon struct {
-		queue         chan *run.Run    // Queue of queued jobs
+		queue         *queue           // Queue of queued jobs
+		runs          chan *run.Run    // Jobs handed out to workers
 		cancelations  chan cancelation // Queue of cancelation requests
 		client.Client                  // Application for retrieving queued runs
 		logr.Logger
@@ -50,7 +53,8 @@ type (
 // newSpooler populates a Spooler with queued runs
 func newSpooler(app client.Client, logger logr.Logger, cfg Config) *spoolerDaemon {
 	return &spoolerDaemon{
-		queue:        make(chan *run.Run, spoolerCapacity),
+		queue:        newQueue(),
+		runs:         make(chan *run.Run),
 		cancelations: make(chan cancelation, spoolerCapacity),
 		Client:       app,
 		Logger:       logger,
@@ -60,6 +64,8 @@ func newSpooler(app client.Client, logger logr.Logger, cfg Config) *spoolerDaemo
 
 // start starts the spooler
 func (s *spoolerDaemon) start(ctx context.Context) error {
+	go s.dispatch(ctx)
+
 	op := func() error {
 		return s.reinitialize(ctx)
 	}
@@ -71,7 +77,31 @@ func (s *spoolerDaemon) start(ctx context.Context) error {
 
 // getRun returns a channel of queued runs
 func (s *spoolerDaemon) getRun() <-chan *run.Run {
-	return s.queue
+	return s.runs
+}
+
+// dispatch hands out the run at the head of the queue to the next worker
+// ready to receive it, re-evaluating the head whenever the queue changes, e.g.
+// a higher priority run has been queued in the meantime.
+func (s *spoolerDaemon) dispatch(ctx context.Context) {
+	for {
+		head := s.queue.head()
+		if head == nil {
+			select {
+			case <-s.queue.changed:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case s.runs <- head:
+			s.queue.removeEntry(head)
+		case <-s.queue.changed:
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // getCancelation returns a channel of cancelation requests
@@ -101,6 +131,10 @@ func (s *spoolerDaemon) reinitialize(ctx context.Context) error {
 
 	s.V(2).Info("retrieved queued runs", "total", len(existing))
 
+	// discard runs spooled prior to any reconnection, which may since have
+	// been dequeued.
+	s.queue.reset()
+
 	// spool existing runs in reverse order; ListRuns returns runs newest first,
 	// whereas we want oldest first.
 	for i := len(existing) - 1; i >= 0; i-- {
@@ -137,8 +171,13 @@ func (s *spoolerDaemon) handleRun(event pubsub.EventType, run *run.Run) {
 	}
 
 	if run.Queued() {
-		s.queue <- run
-	} else if run.Status == internal.RunCanceled {
+		s.queue.push(run)
+		return
+	}
+	// run is no longer queued (if it ever was)
+	s.queue.remove(run.ID)
+
+	if run.Status == internal.RunCanceled {
 		s.cancelations <- cancelation{Run: run}
 	} else if run.Status == internal.RunForceCanceled {
 		s.cancelations <- cancelation{Run: run, Forceful: true}
@@ -16,11 +16,11 @@ func TestSpooler(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// run[1-2] are in the DB; run[3-5] are events
-	run1 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunPlanQueued}
-	run2 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunPlanQueued}
-	run3 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunPlanQueued}
-	run4 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunCanceled}
-	run5 := &run.Run{ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunForceCanceled}
+	run1 := &run.Run{ID: "run-1", ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunPlanQueued}
+	run2 := &run.Run{ID: "run-2", ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunPlanQueued}
+	run3 := &run.Run{ID: "run-3", ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunPlanQueued}
+	run4 := &run.Run{ID: "run-4", ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunCanceled}
+	run5 := &run.Run{ID: "run-5", ExecutionMode: workspace.RemoteExecutionMode, Status: internal.RunForceCanceled}
 	db := []*run.Run{run1, run2}
 	events := make(chan pubsub.Event, 3)
 	events <- pubsub.Event{Payload: run3}
@@ -34,6 +34,7 @@ func TestSpooler(t *testing.T) {
 	)
 	errch := make(chan error)
 	go func() { errch <- spooler.reinitialize(ctx) }()
+	go spooler.dispatch(ctx)
 
 	// expect to receive runs from DB in reverse order
 	assert.Equal(t, run2, <-spooler.getRun())
@@ -124,7 +125,7 @@ func TestSpooler_handleEvent(t *testing.T) {
 			spooler.handleEvent(tt.event)
 
 			if tt.wantRun {
-				assert.NotNil(t, <-spooler.getRun())
+				assert.Equal(t, 1, spooler.queue.len())
 			} else if tt.wantCancelation {
 				assert.NotNil(t, <-spooler.getCancelation())
 			} else if tt.wantForceCancelation {
@@ -133,7 +134,7 @@ func TestSpooler_handleEvent(t *testing.T) {
 					assert.True(t, got.Forceful)
 				}
 			} else {
-				assert.Equal(t, 0, len(spooler.queue))
+				assert.Equal(t, 0, spooler.queue.len())
 				assert.Equal(t, 0, len(spooler.cancelations))
 			}
 		})
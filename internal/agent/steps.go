@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/leg100/otf/internal"
@@ -34,9 +36,14 @@ type (
 	}
 
 	runner struct {
+		out io.WriteCloser // for writing out error message to user
+
+		// mu guards the fields below, which are written by whichever
+		// goroutine cancels or times out execution.
+		mu         sync.Mutex
 		cancelFunc context.CancelFunc // Cancel context func for currently running func
 		canceled   bool               // Whether cancelation has been requested
-		out        io.WriteCloser     // for writing out error message to user
+		timedOut   time.Duration      // Non-zero if terminated upon exceeding timeout
 	}
 )
 
@@ -53,6 +60,11 @@ func buildSteps(env *environment, run *run.Run) (steps []step) {
 	switch run.Phase() {
 	case internal.PlanPhase:
 		steps = append(steps, bldr.terraformInit)
+		if run.Test {
+			// test runs execute the tests in lieu of a plan
+			steps = append(steps, bldr.terraformTest)
+			break
+		}
 		steps = append(steps, bldr.terraformPlan)
 		steps = append(steps, bldr.convertPlanToJSON)
 		steps = append(steps, bldr.uploadPlan)
@@ -72,31 +84,75 @@ func buildSteps(env *environment, run *run.Run) (steps []step) {
 
 func (r *runner) processSteps(ctx context.Context, steps []step) error {
 	ctx, cancel := context.WithCancel(ctx)
+	r.mu.Lock()
 	r.cancelFunc = cancel
+	r.mu.Unlock()
 
 	for _, s := range steps {
-		if r.canceled {
+		canceled, timedOut := r.status()
+		if timedOut > 0 {
+			return r.writeError(timeoutError(timedOut))
+		}
+		if canceled {
 			return fmt.Errorf("execution canceled")
 		}
 		if err := s(ctx); err != nil {
-			// write error message to output
-			errbuilder := strings.Builder{}
-			errbuilder.WriteRune('\n')
-
-			red := color.New(color.FgHiRed)
-			red.EnableColor() // force color on non-tty output
-			red.Fprint(&errbuilder, "Error: ")
-
-			errbuilder.WriteString(err.Error())
-			errbuilder.WriteRune('\n')
-			fmt.Fprint(r.out, errbuilder.String())
-			return err
+			if _, timedOut := r.status(); timedOut > 0 {
+				// the step failed because it was terminated
+				err = timeoutError(timedOut)
+			}
+			return r.writeError(err)
 		}
 	}
 	return nil
 }
 
+// status reports whether cancelation has been requested and, if execution has
+// been terminated upon exceeding a timeout, the timeout.
+func (r *runner) status() (canceled bool, timedOut time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.canceled, r.timedOut
+}
+
+// writeError writes the error message to the output and returns the error.
+func (r *runner) writeError(err error) error {
+	errbuilder := strings.Builder{}
+	errbuilder.WriteRune('\n')
+
+	red := color.New(color.FgHiRed)
+	red.EnableColor() // force color on non-tty output
+	red.Fprint(&errbuilder, "Error: ")
+
+	errbuilder.WriteString(err.Error())
+	errbuilder.WriteRune('\n')
+	fmt.Fprint(r.out, errbuilder.String())
+	return err
+}
+
+func timeoutError(after time.Duration) error {
+	return fmt.Errorf("phase exceeded timeout of %s and was terminated", after)
+}
+
 func (r *runner) cancel(force bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.doCancel(force)
+}
+
+// timeout forcefully cancels execution upon exceeding the given timeout.
+func (r *runner) timeout(after time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timedOut = after
+	r.doCancel(true)
+}
+
+// doCancel cancels execution; the caller must hold the lock.
+func (r *runner) doCancel(force bool) {
 	r.canceled = true
 
 	// cancel func only if forced and there is a context to cancel
@@ -172,7 +228,7 @@ func (b *stepsBuilder) terraformPlan(ctx context.Context) error {
 		args = append(args, "-destroy")
 	}
 	args = append(args, "-out="+planFilename)
-	return b.executeTerraform(args)
+	return b.executeTerraformPhase(ctx, args)
 }
 
 func (b *stepsBuilder) terraformApply(ctx context.Context) (err error) {
@@ -208,7 +264,45 @@ func (b *stepsBuilder) terraformApply(ctx context.Context) (err error) {
 		args = append(args, "-destroy")
 	}
 	args = append(args, planFilename)
-	return b.executeTerraform(args)
+	return b.executeTerraformPhase(ctx, args)
+}
+
+// executeTerraformPhase executes the terraform command for the run phase. If
+// structured output is enabled then the command is invoked with -json and its
+// machine-readable output is uploaded once the command finishes, regardless of
+// whether it succeeds.
+func (b *stepsBuilder) executeTerraformPhase(ctx context.Context, args []string) (err error) {
+	if !b.structuredOutput {
+		return b.executeTerraform(args)
+	}
+	out := &structuredOutputWriter{logs: b.out}
+	defer func() {
+		if flushErr := out.flush(); flushErr != nil {
+			err = errors.Join(err, flushErr)
+		}
+		if uploadErr := b.UploadStructuredOutput(ctx, b.ID, b.Phase(), out.captured.Bytes()); uploadErr != nil {
+			err = errors.Join(err, fmt.Errorf("uploading structured output: %w", uploadErr))
+		}
+	}()
+	// -json must follow the subcommand
+	args = append([]string{args[0], "-json"}, args[1:]...)
+	return b.executeTerraform(args, pipeStdout(out))
+}
+
+// terraformTest executes the configuration's tests. The command is always
+// invoked with -json so that its results can be uploaded, which happens
+// regardless of whether the tests pass.
+func (b *stepsBuilder) terraformTest(ctx context.Context) (err error) {
+	out := &structuredOutputWriter{logs: b.out}
+	defer func() {
+		if flushErr := out.flush(); flushErr != nil {
+			err = errors.Join(err, flushErr)
+		}
+		if uploadErr := b.UploadTestResults(ctx, b.ID, out.captured.Bytes()); uploadErr != nil {
+			err = errors.Join(err, fmt.Errorf("uploading test results: %w", uploadErr))
+		}
+	}()
+	return b.executeTerraform([]string{"test", "-json"}, pipeStdout(out))
 }
 
 func (b *stepsBuilder) convertPlanToJSON(ctx context.Context) error {
@@ -272,6 +366,7 @@ func (b *stepsBuilder) uploadState(ctx context.Context) error {
 	_, err = b.CreateStateVersion(ctx, state.CreateStateVersionOptions{
 		WorkspaceID: &b.WorkspaceID,
 		State:       statefile,
+		RunID:       &b.ID,
 	})
 	return err
 }
@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nopWriteCloser struct {
+	bytes.Buffer
+}
+
+func (*nopWriteCloser) Close() error { return nil }
+
+func TestRunner_Timeout(t *testing.T) {
+	var (
+		out     = &nopWriteCloser{}
+		r       = &runner{out: out}
+		started = make(chan struct{})
+		ran     bool
+	)
+	steps := []step{
+		// blocks until terminated
+		func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		// should never be run
+		func(context.Context) error {
+			ran = true
+			return nil
+		},
+	}
+	go func() {
+		<-started
+		r.timeout(time.Hour)
+	}()
+
+	err := r.processSteps(context.Background(), steps)
+	assert.EqualError(t, err, "phase exceeded timeout of 1h0m0s and was terminated")
+	assert.Contains(t, out.String(), "phase exceeded timeout of 1h0m0s")
+	assert.False(t, ran)
+}
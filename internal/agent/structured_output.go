@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+// structuredOutputWriter captures the machine-readable output of a terraform
+// command whilst writing a human-readable rendering of each event to the
+// logs.
+type structuredOutputWriter struct {
+	logs     io.Writer
+	captured bytes.Buffer
+	partial  []byte // incomplete line awaiting the remainder
+}
+
+func (w *structuredOutputWriter) Write(p []byte) (int, error) {
+	w.captured.Write(p)
+	w.partial = append(w.partial, p...)
+
+	// render complete lines in one write to avoid sending a chunk of logs per
+	// event
+	var rendered bytes.Buffer
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if err := run.RenderEvent(&rendered, w.partial[:i]); err != nil {
+			return 0, err
+		}
+		w.partial = append(w.partial[:0], w.partial[i+1:]...)
+	}
+	if rendered.Len() > 0 {
+		if _, err := w.logs.Write(rendered.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// flush renders any trailing incomplete line.
+func (w *structuredOutputWriter) flush() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	var rendered bytes.Buffer
+	if err := run.RenderEvent(&rendered, w.partial); err != nil {
+		return err
+	}
+	w.partial = nil
+	_, err := w.logs.Write(rendered.Bytes())
+	return err
+}
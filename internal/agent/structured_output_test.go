@@ -0,0 +1,27 @@
+package agent
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredOutputWriter(t *testing.T) {
+	var logs bytes.Buffer
+	w := &structuredOutputWriter{logs: &logs}
+
+	// write events split across writes
+	_, err := w.Write([]byte(`{"@message":"random_pet.pet: Creating...","type":"apply_start"}` + "\n" + `{"@message":"Apply com`))
+	require.NoError(t, err)
+	assert.Equal(t, "random_pet.pet: Creating...\n", logs.String())
+
+	_, err = w.Write([]byte(`plete!","type":"change_summary"}`))
+	require.NoError(t, err)
+	require.NoError(t, w.flush())
+	assert.Equal(t, "random_pet.pet: Creating...\nApply complete!\n", logs.String())
+
+	// captured output is unaltered
+	assert.Equal(t, `{"@message":"random_pet.pet: Creating...","type":"apply_start"}`+"\n"+`{"@message":"Apply complete!","type":"change_summary"}`, w.captured.String())
+}
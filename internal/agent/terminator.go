@@ -1,10 +1,15 @@
 package agent
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
-// cancelable is something that is cancelable, either forcefully or gracefully.
+// cancelable is something that is cancelable, either forcefully or gracefully,
+// or that can be terminated upon exceeding its timeout.
 type cancelable interface {
 	cancel(force bool)
+	timeout(after time.Duration)
 }
 
 // terminator handles canceling items using their ID
@@ -43,3 +48,14 @@ func (t *terminator) cancel(id string, force bool) {
 		job.cancel(force)
 	}
 }
+
+// timeout terminates the item with the given ID, which has exceeded its
+// timeout.
+func (t *terminator) timeout(id string, after time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if job, ok := t.mapping[id]; ok {
+		job.timeout(after)
+	}
+}
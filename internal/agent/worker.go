@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/run"
@@ -56,6 +57,15 @@ func (w *worker) handle(ctx context.Context, r *run.Run) {
 	w.checkIn(r.ID, env)
 	defer w.checkOut(r.ID)
 
+	// Terminate the run if the phase exceeds its timeout
+	if env.phaseTimeout > 0 {
+		timer := time.AfterFunc(env.phaseTimeout, func() {
+			log.Info("phase exceeded timeout; terminating", "timeout", env.phaseTimeout)
+			w.timeout(r.ID, env.phaseTimeout)
+		})
+		defer timer.Stop()
+	}
+
 	var finishOptions run.PhaseFinishOptions
 
 	log.Info("executing phase")
@@ -5,11 +5,15 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/inventory"
 	"github.com/leg100/otf/internal/logr"
 	"github.com/leg100/otf/internal/notifications"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtask"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/vcsprovider"
@@ -25,12 +29,16 @@ type (
 		organization.OrganizationService
 		state.StateService
 		workspace.WorkspaceService
+		project.ProjectService
 		configversion.ConfigurationVersionService
 		auth.AuthService
 		tokens.TokensService
 		variable.VariableService
 		notifications.NotificationService
 		vcsprovider.VCSProviderService
+		runtask.RunTaskService
+		inventory.InventoryService
+		stateop.StateOperationService
 
 		marshaler
 		// for verifying and generating signed urls
@@ -44,6 +52,7 @@ type (
 		organization.OrganizationService
 		state.StateService
 		workspace.WorkspaceService
+		project.ProjectService
 		configversion.ConfigurationVersionService
 		auth.AuthService
 		auth.TeamService
@@ -51,6 +60,9 @@ type (
 		variable.VariableService
 		notifications.NotificationService
 		vcsprovider.VCSProviderService
+		runtask.RunTaskService
+		inventory.InventoryService
+		stateop.StateOperationService
 
 		*surl.Signer
 
@@ -62,6 +74,7 @@ func New(opts Options) *api {
 	return &api{
 		OrganizationService:         opts.OrganizationService,
 		WorkspaceService:            opts.WorkspaceService,
+		ProjectService:              opts.ProjectService,
 		RunService:                  opts.RunService,
 		StateService:                opts.StateService,
 		ConfigurationVersionService: opts.ConfigurationVersionService,
@@ -71,6 +84,9 @@ func New(opts Options) *api {
 		VariableService:             opts.VariableService,
 		NotificationService:         opts.NotificationService,
 		VCSProviderService:          opts.VCSProviderService,
+		RunTaskService:              opts.RunTaskService,
+		InventoryService:            opts.InventoryService,
+		StateOperationService:       opts.StateOperationService,
 		marshaler: &jsonapiMarshaler{
 			OrganizationService:         opts.OrganizationService,
 			WorkspaceService:            opts.WorkspaceService,
@@ -88,8 +104,13 @@ func (a *api) AddHandlers(r *mux.Router) {
 	a.addOrganizationHandlers(r)
 	a.addRunHandlers(r)
 	a.addWorkspaceHandlers(r)
+	a.addProjectHandlers(r)
 	a.addStateHandlers(r)
+	a.addStateRetentionHandlers(r)
+	a.addInventoryHandlers(r)
+	a.addStateOperationHandlers(r)
 	a.addTagHandlers(r)
+	a.addRemoteStateConsumerHandlers(r)
 	a.addConfigHandlers(r)
 	a.addUserHandlers(r)
 	a.addTeamHandlers(r)
@@ -99,4 +120,7 @@ func (a *api) AddHandlers(r *mux.Router) {
 	a.addNotificationHandlers(r)
 	a.addOrganizationMembershipHandlers(r)
 	a.addOAuthClientHandlers(r)
+	a.addRunTaskHandlers(r)
+	a.addRunApprovalHandlers(r)
+	a.addCommentHandlers(r)
 }
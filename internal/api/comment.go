@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+)
+
+func (a *api) addCommentHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/runs/{id}/comments", a.createComment).Methods("POST")
+	r.HandleFunc("/runs/{id}/comments", a.listComments).Methods("GET")
+	r.HandleFunc("/comments/{id}", a.getComment).Methods("GET")
+}
+
+func (a *api) createComment(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.CommentCreateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	comment, err := a.AddComment(r.Context(), runID, params.Body)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, comment, withCode(http.StatusCreated))
+}
+
+func (a *api) listComments(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	comments, err := a.ListComments(r.Context(), runID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, comments)
+}
+
+func (a *api) getComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	comment, err := a.GetComment(r.Context(), commentID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, comment)
+}
@@ -0,0 +1,16 @@
+package api
+
+import (
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/run"
+)
+
+func (m *jsonapiMarshaler) toComment(from *run.Comment) *types.Comment {
+	return &types.Comment{
+		ID:        from.ID,
+		Body:      from.Body,
+		Author:    from.Author,
+		CreatedAt: from.CreatedAt,
+		Run:       &types.Run{ID: from.RunID},
+	}
+}
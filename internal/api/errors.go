@@ -6,26 +6,69 @@ import (
 
 	"github.com/DataDog/jsonapi"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
+	"github.com/leg100/otf/internal/workspace"
 )
 
 var codes = map[error]int{
-	internal.ErrResourceNotFound:         http.StatusNotFound,
-	internal.ErrAccessNotPermitted:       http.StatusForbidden,
-	internal.ErrUploadTooLarge:           http.StatusUnprocessableEntity,
-	internal.ErrInvalidTerraformVersion:  http.StatusUnprocessableEntity,
-	internal.ErrResourceAlreadyExists:    http.StatusConflict,
-	internal.ErrWorkspaceAlreadyLocked:   http.StatusConflict,
-	internal.ErrWorkspaceAlreadyUnlocked: http.StatusConflict,
-	internal.ErrWorkspaceLockedByRun:     http.StatusConflict,
-	internal.ErrRunDiscardNotAllowed:     http.StatusConflict,
-	internal.ErrRunCancelNotAllowed:      http.StatusConflict,
-	internal.ErrRunForceCancelNotAllowed: http.StatusConflict,
+	internal.ErrResourceNotFound:                   http.StatusNotFound,
+	internal.ErrAccessNotPermitted:                 http.StatusForbidden,
+	internal.ErrUploadTooLarge:                     http.StatusUnprocessableEntity,
+	internal.ErrInvalidTerraformVersion:            http.StatusUnprocessableEntity,
+	internal.ErrInvalidConcurrencyLimit:            http.StatusUnprocessableEntity,
+	internal.ErrInvalidPhaseTimeout:                http.StatusUnprocessableEntity,
+	internal.ErrInvalidAutoDiscardTTL:              http.StatusUnprocessableEntity,
+	internal.ErrInvalidAutoDestroyAt:               http.StatusUnprocessableEntity,
+	internal.ErrInvalidAutoDestroyActivityDuration: http.StatusUnprocessableEntity,
+	internal.ErrResourceAlreadyExists:              http.StatusConflict,
+	internal.ErrWorkspaceAlreadyLocked:             http.StatusConflict,
+	internal.ErrSavedPlanStale:                     http.StatusConflict,
+	internal.ErrWorkspaceAlreadyUnlocked:           http.StatusConflict,
+	internal.ErrWorkspaceLockedByRun:               http.StatusConflict,
+	internal.ErrWorkspaceLockedByPullRequest:       http.StatusConflict,
+	internal.ErrRunDiscardNotAllowed:               http.StatusConflict,
+	internal.ErrRunCancelNotAllowed:                http.StatusConflict,
+	internal.ErrRunForceCancelNotAllowed:           http.StatusConflict,
+	internal.ErrRunApprovalRequired:                http.StatusConflict,
+	internal.ErrRunRejected:                        http.StatusConflict,
+	internal.ErrRunApprovalNotAllowed:              http.StatusConflict,
+	internal.ErrRunSelfApproval:                    http.StatusForbidden,
+	internal.ErrEmptyRunComment:                    http.StatusUnprocessableEntity,
+	internal.ErrRemoteStateConsumerSelf:            http.StatusUnprocessableEntity,
+	internal.ErrDefaultProjectDelete:               http.StatusConflict,
+	internal.ErrProjectNotEmpty:                    http.StatusConflict,
+	internal.ErrInvalidProviderVersion:             http.StatusUnprocessableEntity,
+	state.ErrDiffWorkspaceMismatch:                 http.StatusUnprocessableEntity,
+	state.ErrCurrentVersionDeletionAttempt:         http.StatusConflict,
+	state.ErrPinnedVersionDeletionAttempt:          http.StatusConflict,
+	state.ErrInvalidRetentionPolicy:                http.StatusUnprocessableEntity,
+	stateop.ErrInvalidAddress:                      http.StatusUnprocessableEntity,
+	stateop.ErrAddressNotFound:                     http.StatusUnprocessableEntity,
+	stateop.ErrAddressAlreadyExists:                http.StatusConflict,
+	stateop.ErrMoveTypeMismatch:                    http.StatusUnprocessableEntity,
+	stateop.ErrMoveToSameAddress:                   http.StatusUnprocessableEntity,
+	stateop.ErrMoveResourceToInstance:              http.StatusUnprocessableEntity,
+	stateop.ErrTaintDataResource:                   http.StatusUnprocessableEntity,
+	stateop.ErrDestinationRequired:                 http.StatusUnprocessableEntity,
+	stateop.ErrReasonRequired:                      http.StatusUnprocessableEntity,
+	stateop.ErrInvalidOperationKind:                http.StatusUnprocessableEntity,
+	stateop.ErrUserRequired:                        http.StatusForbidden,
+	workspace.ErrInvalidLockExpiry:                 http.StatusUnprocessableEntity,
+	workspace.ErrWorkspaceHasResources:             http.StatusConflict,
 }
 
 func lookupHTTPCode(err error) int {
 	if v, ok := codes[err]; ok {
 		return v
 	}
+	// errors may be wrapped with further detail, e.g. the address of a
+	// resource that cannot be found.
+	for target, code := range codes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
 	return http.StatusInternalServerError
 }
 
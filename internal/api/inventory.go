@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/inventory"
+)
+
+func (a *api) addInventoryHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/resources", a.searchResources).Methods("GET")
+}
+
+func (a *api) searchResources(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params inventory.SearchOptions
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	page, err := a.SearchResources(r.Context(), org, params)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, page)
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/inventory"
+)
+
+func (m *jsonapiMarshaler) toInventoryResource(from *inventory.Resource) *types.InventoryResource {
+	return &types.InventoryResource{
+		ID:              from.ID,
+		Address:         from.Address,
+		Module:          from.Module,
+		Type:            from.Type,
+		Name:            from.Name,
+		Provider:        from.Provider,
+		ProviderVersion: from.ProviderVersion,
+		RemoteID:        from.RemoteID,
+		WorkspaceName:   from.WorkspaceName,
+		Workspace:       &types.Workspace{ID: from.WorkspaceID},
+		StateVersion:    &types.StateVersion{ID: from.StateVersionID},
+	}
+}
@@ -11,11 +11,15 @@ import (
 	"github.com/leg100/otf/internal/api/types"
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/inventory"
 	"github.com/leg100/otf/internal/notifications"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtask"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/otf/internal/workspace"
@@ -119,6 +123,8 @@ func (m *jsonapiMarshaler) convert(r *http.Request, v any) (any, []jsonapi.Marsh
 		payload = (*types.Entitlements)(&v)
 	case *workspace.Workspace:
 		payload, opts, err = m.toWorkspace(v, r)
+	case *project.Project:
+		payload = m.toProject(v)
 	case *run.Run:
 		payload, opts, err = m.toRun(v, r)
 	case *configversion.ConfigurationVersion:
@@ -133,6 +139,12 @@ func (m *jsonapiMarshaler) convert(r *http.Request, v any) (any, []jsonapi.Marsh
 		payload, opts, err = m.toState(v, r)
 	case *state.Output:
 		payload = m.toOutput(v, false)
+	case *state.RetentionPolicy:
+		payload = m.toStateRetentionPolicy(v)
+	case *inventory.Resource:
+		payload = m.toInventoryResource(v)
+	case *stateop.Operation:
+		payload = m.toStateOperation(v)
 	case *auth.User:
 		payload = m.toUser(v)
 	case *auth.Team:
@@ -141,6 +153,20 @@ func (m *jsonapiMarshaler) convert(r *http.Request, v any) (any, []jsonapi.Marsh
 		payload = m.toTag(v)
 	case *vcsprovider.VCSProvider:
 		payload = m.toOAuthClient(v)
+	case *runtask.RunTask:
+		payload = m.toRunTask(v)
+	case *runtask.WorkspaceRunTask:
+		payload = m.toWorkspaceRunTask(v)
+	case *runtask.TaskResult:
+		payload = m.toTaskResult(v)
+	case *run.Approval:
+		payload = m.toRunApproval(v)
+	case *workspace.ApprovalPolicy:
+		payload = m.toApprovalPolicy(v)
+	case *workspace.LockEvent:
+		payload = m.toWorkspaceLockEvent(v)
+	case *run.Comment:
+		payload = m.toComment(v)
 	default:
 		return nil, nil, fmt.Errorf("cannot marshal unknown type: %T", v)
 	}
@@ -20,7 +20,7 @@ func TestMarshaler_writeResponse(t *testing.T) {
 		})
 
 		got := w.Body.String()
-		want := `{"data":{"id":"acmeco","type":"organizations","attributes":{"allow-force-delete-workspaces":false,"assessments-enforced":false,"collaborator-auth-policy":"","cost-estimation-enabled":false,"created-at":"0001-01-01T00:00:00Z","email":"","external-id":"","owners-team-saml-role-id":"","permissions":{"can-create-team":false,"can-create-workspace":true,"can-create-workspace-migration":false,"can-destroy":true,"can-traverse":false,"can-update":true,"can-update-api-token":false,"can-update-oauth":false,"can-update-sentinel":false},"saml-enabled":false,"send-passing-statuses-for-untriggered-speculative-plans":false,"session-remember":null,"session-timeout":null,"trial-expires-at":"0001-01-01T00:00:00Z","two-factor-conformant":false}}}`
+		want := `{"data":{"id":"acmeco","type":"organizations","attributes":{"allow-force-delete-workspaces":false,"assessments-enforced":false,"collaborator-auth-policy":"","cost-estimation-enabled":false,"created-at":"0001-01-01T00:00:00Z","email":"","external-id":"","max-concurrent-runs":0,"max-concurrent-speculative-runs":0,"owners-team-saml-role-id":"","permissions":{"can-create-team":false,"can-create-workspace":true,"can-create-workspace-migration":false,"can-destroy":true,"can-traverse":false,"can-update":true,"can-update-api-token":false,"can-update-oauth":false,"can-update-sentinel":false},"saml-enabled":false,"send-passing-statuses-for-untriggered-speculative-plans":false,"session-remember":null,"session-timeout":null,"trial-expires-at":"0001-01-01T00:00:00Z","two-factor-conformant":false}}}`
 		assert.Equal(t, want, got)
 	})
 
@@ -33,7 +33,7 @@ func TestMarshaler_writeResponse(t *testing.T) {
 		})
 
 		got := w.Body.String()
-		want := `{"data":[{"id":"acmeco","type":"organizations","attributes":{"allow-force-delete-workspaces":false,"assessments-enforced":false,"collaborator-auth-policy":"","cost-estimation-enabled":false,"created-at":"0001-01-01T00:00:00Z","email":"","external-id":"","owners-team-saml-role-id":"","permissions":{"can-create-team":false,"can-create-workspace":true,"can-create-workspace-migration":false,"can-destroy":true,"can-traverse":false,"can-update":true,"can-update-api-token":false,"can-update-oauth":false,"can-update-sentinel":false},"saml-enabled":false,"send-passing-statuses-for-untriggered-speculative-plans":false,"session-remember":null,"session-timeout":null,"trial-expires-at":"0001-01-01T00:00:00Z","two-factor-conformant":false}}]}`
+		want := `{"data":[{"id":"acmeco","type":"organizations","attributes":{"allow-force-delete-workspaces":false,"assessments-enforced":false,"collaborator-auth-policy":"","cost-estimation-enabled":false,"created-at":"0001-01-01T00:00:00Z","email":"","external-id":"","max-concurrent-runs":0,"max-concurrent-speculative-runs":0,"owners-team-saml-role-id":"","permissions":{"can-create-team":false,"can-create-workspace":true,"can-create-workspace-migration":false,"can-destroy":true,"can-traverse":false,"can-update":true,"can-update-api-token":false,"can-update-oauth":false,"can-update-sentinel":false},"saml-enabled":false,"send-passing-statuses-for-untriggered-speculative-plans":false,"session-remember":null,"session-timeout":null,"trial-expires-at":"0001-01-01T00:00:00Z","two-factor-conformant":false}}]}`
 		assert.Equal(t, want, got)
 	})
 
@@ -49,7 +49,7 @@ func TestMarshaler_writeResponse(t *testing.T) {
 		})
 
 		got := w.Body.String()
-		want := `{"data":[{"id":"acmeco","type":"organizations","attributes":{"allow-force-delete-workspaces":false,"assessments-enforced":false,"collaborator-auth-policy":"","cost-estimation-enabled":false,"created-at":"0001-01-01T00:00:00Z","email":"","external-id":"","owners-team-saml-role-id":"","permissions":{"can-create-team":false,"can-create-workspace":true,"can-create-workspace-migration":false,"can-destroy":true,"can-traverse":false,"can-update":true,"can-update-api-token":false,"can-update-oauth":false,"can-update-sentinel":false},"saml-enabled":false,"send-passing-statuses-for-untriggered-speculative-plans":false,"session-remember":null,"session-timeout":null,"trial-expires-at":"0001-01-01T00:00:00Z","two-factor-conformant":false}}],"meta":{"pagination":{"current-page":0,"prev-page":null,"next-page":null,"total-pages":0,"total-count":0}}}`
+		want := `{"data":[{"id":"acmeco","type":"organizations","attributes":{"allow-force-delete-workspaces":false,"assessments-enforced":false,"collaborator-auth-policy":"","cost-estimation-enabled":false,"created-at":"0001-01-01T00:00:00Z","email":"","external-id":"","max-concurrent-runs":0,"max-concurrent-speculative-runs":0,"owners-team-saml-role-id":"","permissions":{"can-create-team":false,"can-create-workspace":true,"can-create-workspace-migration":false,"can-destroy":true,"can-traverse":false,"can-update":true,"can-update-api-token":false,"can-update-oauth":false,"can-update-sentinel":false},"saml-enabled":false,"send-passing-statuses-for-untriggered-speculative-plans":false,"session-remember":null,"session-timeout":null,"trial-expires-at":"0001-01-01T00:00:00Z","two-factor-conformant":false}}],"meta":{"pagination":{"current-page":0,"prev-page":null,"next-page":null,"total-pages":0,"total-count":0}}}`
 		assert.Equal(t, want, got)
 	})
 }
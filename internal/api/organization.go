@@ -32,13 +32,15 @@ func (a *api) createOrganization(w http.ResponseWriter, r *http.Request) {
 	}
 
 	org, err := a.CreateOrganization(r.Context(), organization.CreateOptions{
-		Name:                       opts.Name,
-		Email:                      opts.Email,
-		CollaboratorAuthPolicy:     (*string)(opts.CollaboratorAuthPolicy),
-		CostEstimationEnabled:      opts.CostEstimationEnabled,
-		SessionRemember:            opts.SessionRemember,
-		SessionTimeout:             opts.SessionTimeout,
-		AllowForceDeleteWorkspaces: opts.AllowForceDeleteWorkspaces,
+		Name:                         opts.Name,
+		Email:                        opts.Email,
+		CollaboratorAuthPolicy:       (*string)(opts.CollaboratorAuthPolicy),
+		CostEstimationEnabled:        opts.CostEstimationEnabled,
+		SessionRemember:              opts.SessionRemember,
+		SessionTimeout:               opts.SessionTimeout,
+		AllowForceDeleteWorkspaces:   opts.AllowForceDeleteWorkspaces,
+		MaxConcurrentRuns:            opts.MaxConcurrentRuns,
+		MaxConcurrentSpeculativeRuns: opts.MaxConcurrentSpeculativeRuns,
 	})
 	if err != nil {
 		Error(w, err)
@@ -93,12 +95,14 @@ func (a *api) updateOrganization(w http.ResponseWriter, r *http.Request) {
 	}
 
 	org, err := a.UpdateOrganization(r.Context(), name, organization.UpdateOptions{
-		Name:                   opts.Name,
-		Email:                  opts.Email,
-		CollaboratorAuthPolicy: (*string)(opts.CollaboratorAuthPolicy),
-		CostEstimationEnabled:  opts.CostEstimationEnabled,
-		SessionRemember:        opts.SessionRemember,
-		SessionTimeout:         opts.SessionTimeout,
+		Name:                         opts.Name,
+		Email:                        opts.Email,
+		CollaboratorAuthPolicy:       (*string)(opts.CollaboratorAuthPolicy),
+		CostEstimationEnabled:        opts.CostEstimationEnabled,
+		SessionRemember:              opts.SessionRemember,
+		SessionTimeout:               opts.SessionTimeout,
+		MaxConcurrentRuns:            opts.MaxConcurrentRuns,
+		MaxConcurrentSpeculativeRuns: opts.MaxConcurrentSpeculativeRuns,
 	})
 	if err != nil {
 		Error(w, err)
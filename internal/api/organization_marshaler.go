@@ -7,14 +7,16 @@ import (
 
 func (m *jsonapiMarshaler) toOrganization(from *organization.Organization) *types.Organization {
 	to := &types.Organization{
-		Name:                       from.Name,
-		CreatedAt:                  from.CreatedAt,
-		ExternalID:                 from.ID,
-		Permissions:                &types.DefaultOrganizationPermissions,
-		SessionRemember:            from.SessionRemember,
-		SessionTimeout:             from.SessionTimeout,
-		AllowForceDeleteWorkspaces: from.AllowForceDeleteWorkspaces,
-		CostEstimationEnabled:      from.CostEstimationEnabled,
+		Name:                         from.Name,
+		CreatedAt:                    from.CreatedAt,
+		ExternalID:                   from.ID,
+		Permissions:                  &types.DefaultOrganizationPermissions,
+		SessionRemember:              from.SessionRemember,
+		SessionTimeout:               from.SessionTimeout,
+		AllowForceDeleteWorkspaces:   from.AllowForceDeleteWorkspaces,
+		CostEstimationEnabled:        from.CostEstimationEnabled,
+		MaxConcurrentRuns:            from.MaxConcurrentRuns,
+		MaxConcurrentSpeculativeRuns: from.MaxConcurrentSpeculativeRuns,
 	}
 	if from.Email != nil {
 		to.Email = *from.Email
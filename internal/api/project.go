@@ -0,0 +1,125 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/project"
+	"github.com/leg100/otf/internal/resource"
+)
+
+func (a *api) addProjectHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/projects", a.createProject).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/projects", a.listProjects).Methods("GET")
+	r.HandleFunc("/projects/{id}", a.getProject).Methods("GET")
+	r.HandleFunc("/projects/{id}", a.updateProject).Methods("PATCH")
+	r.HandleFunc("/projects/{id}", a.deleteProject).Methods("DELETE")
+}
+
+func (a *api) createProject(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.ProjectCreateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	p, err := a.CreateProject(r.Context(), project.CreateOptions{
+		Organization: org,
+		Name:         params.Name,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, p, withCode(http.StatusCreated))
+}
+
+func (a *api) listProjects(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.ProjectListOptions
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	page, err := a.ListProjects(r.Context(), project.ListOptions{
+		Organization: org,
+		Search:       params.Query,
+		PageOptions:  resource.PageOptions(params.ListOptions),
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, page)
+}
+
+func (a *api) getProject(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	p, err := a.GetProject(r.Context(), id)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, p)
+}
+
+func (a *api) updateProject(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.ProjectUpdateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	p, err := a.UpdateProject(r.Context(), id, project.UpdateOptions{
+		Name: params.Name,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, p)
+}
+
+func (a *api) deleteProject(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if _, err := a.DeleteProject(r.Context(), id); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
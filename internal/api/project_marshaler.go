@@ -0,0 +1,16 @@
+package api
+
+import (
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/project"
+)
+
+func (m *jsonapiMarshaler) toProject(from *project.Project) *types.Project {
+	return &types.Project{
+		ID:   from.ID,
+		Name: from.Name,
+		Organization: &types.Organization{
+			Name: from.Organization,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	addRemoteStateConsumers remoteStateConsumerOperation = iota
+	removeRemoteStateConsumers
+	replaceRemoteStateConsumers
+)
+
+type remoteStateConsumerOperation int
+
+func (a *api) addRemoteStateConsumerHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/relationships/remote-state-consumers", a.listRemoteStateConsumers).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/relationships/remote-state-consumers", a.addRemoteStateConsumers).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/relationships/remote-state-consumers", a.replaceRemoteStateConsumers).Methods("PATCH")
+	r.HandleFunc("/workspaces/{workspace_id}/relationships/remote-state-consumers", a.removeRemoteStateConsumers).Methods("DELETE")
+}
+
+func (a *api) listRemoteStateConsumers(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.ListOptions
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	consumers, err := a.ListRemoteStateConsumers(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, resource.NewPage(consumers, resource.PageOptions(params), nil))
+}
+
+func (a *api) addRemoteStateConsumers(w http.ResponseWriter, r *http.Request) {
+	a.alterRemoteStateConsumers(w, r, addRemoteStateConsumers)
+}
+
+func (a *api) removeRemoteStateConsumers(w http.ResponseWriter, r *http.Request) {
+	a.alterRemoteStateConsumers(w, r, removeRemoteStateConsumers)
+}
+
+func (a *api) replaceRemoteStateConsumers(w http.ResponseWriter, r *http.Request) {
+	a.alterRemoteStateConsumers(w, r, replaceRemoteStateConsumers)
+}
+
+func (a *api) alterRemoteStateConsumers(w http.ResponseWriter, r *http.Request, op remoteStateConsumerOperation) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params []*types.Workspace
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+	consumerIDs := make([]string, len(params))
+	for i, ws := range params {
+		consumerIDs[i] = ws.ID
+	}
+
+	switch op {
+	case addRemoteStateConsumers:
+		err = a.AddRemoteStateConsumers(r.Context(), workspaceID, consumerIDs)
+	case removeRemoteStateConsumers:
+		err = a.RemoveRemoteStateConsumers(r.Context(), workspaceID, consumerIDs)
+	case replaceRemoteStateConsumers:
+		err = a.ReplaceRemoteStateConsumers(r.Context(), workspaceID, consumerIDs)
+	default:
+		err = errors.New("unknown remote state consumer operation")
+	}
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
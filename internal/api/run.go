@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -41,6 +42,10 @@ func (a *api) addRunHandlers(r *mux.Router) {
 	r.HandleFunc("/runs/{id}/planfile", a.uploadPlanFile).Methods("PUT")
 	r.HandleFunc("/runs/{id}/lockfile", a.getLockFile).Methods("GET")
 	r.HandleFunc("/runs/{id}/lockfile", a.uploadLockFile).Methods("PUT")
+	r.HandleFunc("/runs/{id}/structured-output", a.getStructuredOutput).Methods("GET")
+	r.HandleFunc("/runs/{id}/structured-output", a.uploadStructuredOutput).Methods("PUT")
+	r.HandleFunc("/runs/{id}/test-results", a.uploadTestResults).Methods("PUT")
+	r.HandleFunc("/runs/{id}/plan-diff", a.getPlanDiff).Methods("GET")
 
 	// Plan routes
 	r.HandleFunc("/plans/{plan_id}", a.getPlan).Methods("GET")
@@ -73,6 +78,8 @@ func (a *api) createRun(w http.ResponseWriter, r *http.Request) {
 		TargetAddrs:      params.TargetAddrs,
 		ReplaceAddrs:     params.ReplaceAddrs,
 		PlanOnly:         params.PlanOnly,
+		SavePlan:         params.SavePlan,
+		Test:             params.Test,
 		Source:           run.SourceAPI,
 		AllowEmptyApply:  params.AllowEmptyApply,
 		TerraformVersion: params.TerraformVersion,
@@ -187,9 +194,21 @@ func (a *api) listRuns(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *api) getRunQueue(w http.ResponseWriter, r *http.Request) {
-	a.listRunsWithOptions(w, r, run.ListOptions{
-		Statuses: []internal.RunStatus{internal.RunPlanQueued, internal.RunApplyQueued},
-	})
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	page, err := a.ListQueue(r.Context(), params.Organization, params.PageOptions)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, page)
 }
 
 func (a *api) listRunsWithOptions(w http.ResponseWriter, r *http.Request, opts run.ListOptions) {
@@ -353,6 +372,78 @@ func (a *api) uploadLockFile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
+func (a *api) getStructuredOutput(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	opts := run.StructuredOutputOptions{}
+	if err := decode.Query(&opts, r.URL.Query()); err != nil {
+		Error(w, err)
+		return
+	}
+
+	output, err := a.GetStructuredOutput(r.Context(), id, opts.Phase)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if _, err := w.Write(output); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *api) uploadStructuredOutput(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	opts := run.StructuredOutputOptions{}
+	if err := decode.Query(&opts, r.URL.Query()); err != nil {
+		Error(w, err)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, r.Body); err != nil {
+		Error(w, err)
+		return
+	}
+
+	err = a.UploadStructuredOutput(r.Context(), id, opts.Phase, buf.Bytes())
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *api) uploadTestResults(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, r.Body); err != nil {
+		Error(w, err)
+		return
+	}
+
+	if err := a.UploadTestResults(r.Context(), id, buf.Bytes()); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // These endpoints implement the documented plan API:
 //
 // https://www.terraform.io/cloud-docs/api-docs/plans#retrieve-the-json-execution-plan
@@ -400,6 +491,34 @@ func (a *api) getPlanJSON(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getPlanDiff returns a resource-level diff of a run's plan, optionally
+// filtered by the query parameters. The diff is returned as plain JSON rather
+// than JSON:API.
+func (a *api) getPlanDiff(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var filter run.PlanDiffFilter
+	if err := decode.Query(&filter, r.URL.Query()); err != nil {
+		Error(w, err)
+		return
+	}
+
+	diff, err := a.GetPlanDiff(r.Context(), id)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(diff.Filter(filter)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (a *api) getApply(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("apply_id", r)
 	if err != nil {
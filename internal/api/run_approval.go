@@ -0,0 +1,116 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+func (a *api) addRunApprovalHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/runs/{id}/approvals", a.createRunApproval).Methods("POST")
+	r.HandleFunc("/runs/{id}/approvals", a.listRunApprovals).Methods("GET")
+
+	r.HandleFunc("/workspaces/{workspace_id}/approval-policy", a.getApprovalPolicy).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/approval-policy", a.updateApprovalPolicy).Methods("PATCH")
+}
+
+func (a *api) createRunApproval(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.RunApprovalCreateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+	var comment string
+	if params.Comment != nil {
+		comment = *params.Comment
+	}
+
+	var approval *run.Approval
+	switch run.ApprovalDecision(params.Decision) {
+	case run.ApprovedDecision:
+		approval, err = a.ApproveRun(r.Context(), runID, comment)
+	case run.RejectedDecision:
+		approval, err = a.RejectRun(r.Context(), runID, comment)
+	default:
+		err = &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: "decision must be either approved or rejected",
+		}
+	}
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, approval, withCode(http.StatusCreated))
+}
+
+func (a *api) listRunApprovals(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	approvals, err := a.ListApprovals(r.Context(), runID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, approvals)
+}
+
+func (a *api) getApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	policy, err := a.GetApprovalPolicy(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, policy)
+}
+
+func (a *api) updateApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.ApprovalPolicyUpdateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	policy, err := a.SetApprovalPolicy(r.Context(), workspaceID, workspace.SetApprovalPolicyOptions{
+		Required:      params.Required,
+		Teams:         params.Teams,
+		ExcludeAuthor: params.ExcludeAuthor,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, policy)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+func (m *jsonapiMarshaler) toRunApproval(from *run.Approval) *types.RunApproval {
+	return &types.RunApproval{
+		ID:        from.ID,
+		Decision:  string(from.Decision),
+		Comment:   from.Comment,
+		Username:  from.Username,
+		CreatedAt: from.CreatedAt,
+		Run:       &types.Run{ID: from.RunID},
+	}
+}
+
+func (m *jsonapiMarshaler) toApprovalPolicy(from *workspace.ApprovalPolicy) *types.ApprovalPolicy {
+	return &types.ApprovalPolicy{
+		ID:            from.WorkspaceID,
+		Required:      from.Required,
+		Teams:         from.Teams,
+		ExcludeAuthor: from.ExcludeAuthor,
+		Workspace:     &types.Workspace{ID: from.WorkspaceID},
+	}
+}
@@ -50,6 +50,10 @@ func (m *jsonapiMarshaler) toRun(from *run.Run, r *http.Request) (*types.Run, []
 			timestamps.PlannedAt = &rst.Timestamp
 		case internal.RunPlannedAndFinished:
 			timestamps.PlannedAndFinishedAt = &rst.Timestamp
+		case internal.RunPlannedAndSaved:
+			timestamps.PlannedAndSavedAt = &rst.Timestamp
+		case internal.RunConfirmed:
+			timestamps.ConfirmedAt = &rst.Timestamp
 		case internal.RunApplyQueued:
 			timestamps.ApplyQueuedAt = &rst.Timestamp
 		case internal.RunApplying:
@@ -94,13 +98,18 @@ func (m *jsonapiMarshaler) toRun(from *run.Run, r *http.Request) (*types.Run, []
 		Message:                from.Message,
 		Permissions:            perms,
 		PlanOnly:               from.PlanOnly,
-		PositionInQueue:        0,
+		PositionInQueue:        from.PositionInQueue,
+		QueuedByLimit:          from.QueuedByLimit,
 		Refresh:                from.Refresh,
 		RefreshOnly:            from.RefreshOnly,
 		ReplaceAddrs:           from.ReplaceAddrs,
+		SavePlan:               from.SavePlan,
 		Source:                 string(from.Source),
 		Status:                 string(from.Status),
 		StatusTimestamps:       &timestamps,
+		StatusReason:           from.StatusReason(),
+		Test:                   from.Test,
+		PhaseTimeout:           int(from.PhaseTimeout.Seconds()),
 		TargetAddrs:            from.TargetAddrs,
 		TerraformVersion:       from.TerraformVersion,
 		// Relations
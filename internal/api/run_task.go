@@ -0,0 +1,273 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtask"
+)
+
+func (a *api) addRunTaskHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/tasks", a.createRunTask).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/tasks", a.listRunTasks).Methods("GET")
+	r.HandleFunc("/tasks/{id}", a.getRunTask).Methods("GET")
+	r.HandleFunc("/tasks/{id}", a.updateRunTask).Methods("PATCH")
+	r.HandleFunc("/tasks/{id}", a.deleteRunTask).Methods("DELETE")
+
+	r.HandleFunc("/workspaces/{workspace_id}/tasks", a.attachRunTask).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks", a.listWorkspaceRunTasks).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks/{id}", a.getWorkspaceRunTask).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks/{id}", a.updateWorkspaceRunTask).Methods("PATCH")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks/{id}", a.detachRunTask).Methods("DELETE")
+
+	r.HandleFunc("/runs/{run_id}/task-results", a.listTaskResults).Methods("GET")
+	r.HandleFunc("/task-results/{id}", a.getTaskResult).Methods("GET")
+}
+
+func (a *api) createRunTask(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.RunTaskCreateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	task, err := a.CreateRunTask(r.Context(), runtask.CreateOptions{
+		Organization: org,
+		Name:         params.Name,
+		URL:          params.URL,
+		Description:  params.Description,
+		HMACKey:      params.HMACKey,
+		Enabled:      params.Enabled,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, task, withCode(http.StatusCreated))
+}
+
+func (a *api) listRunTasks(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	tasks, err := a.ListRunTasks(r.Context(), org)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, tasks)
+}
+
+func (a *api) getRunTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	task, err := a.GetRunTask(r.Context(), id)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, task)
+}
+
+func (a *api) updateRunTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.RunTaskUpdateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	task, err := a.UpdateRunTask(r.Context(), id, runtask.UpdateOptions{
+		Name:        params.Name,
+		URL:         params.URL,
+		Description: params.Description,
+		HMACKey:     params.HMACKey,
+		Enabled:     params.Enabled,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, task)
+}
+
+func (a *api) deleteRunTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if _, err := a.DeleteRunTask(r.Context(), id); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) attachRunTask(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.WorkspaceRunTaskCreateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+	if params.RunTask == nil {
+		Error(w, &internal.MissingParameterError{Parameter: "task"})
+		return
+	}
+
+	opts := runtask.AttachOptions{
+		RunTaskID:        params.RunTask.ID,
+		EnforcementLevel: runtask.EnforcementLevel(params.EnforcementLevel),
+	}
+	if params.Stage != nil {
+		opts.Stage = run.TaskStage(*params.Stage)
+	}
+	wrt, err := a.AttachRunTask(r.Context(), workspaceID, opts)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, wrt, withCode(http.StatusCreated))
+}
+
+func (a *api) listWorkspaceRunTasks(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	tasks, err := a.ListWorkspaceRunTasks(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, tasks)
+}
+
+func (a *api) getWorkspaceRunTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	wrt, err := a.GetWorkspaceRunTask(r.Context(), id)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, wrt)
+}
+
+func (a *api) updateWorkspaceRunTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.WorkspaceRunTaskUpdateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	var opts runtask.UpdateAttachmentOptions
+	if params.Stage != nil {
+		opts.Stage = (*run.TaskStage)(params.Stage)
+	}
+	if params.EnforcementLevel != nil {
+		opts.EnforcementLevel = (*runtask.EnforcementLevel)(params.EnforcementLevel)
+	}
+	wrt, err := a.UpdateWorkspaceRunTask(r.Context(), id, opts)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, wrt)
+}
+
+func (a *api) detachRunTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if _, err := a.DetachRunTask(r.Context(), id); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) listTaskResults(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	results, err := a.ListTaskResults(r.Context(), runID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, results)
+}
+
+func (a *api) getTaskResult(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	result, err := a.GetTaskResult(r.Context(), id)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, result)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/runtask"
+)
+
+func (m *jsonapiMarshaler) toRunTask(from *runtask.RunTask) *types.RunTask {
+	return &types.RunTask{
+		ID:          from.ID,
+		Name:        from.Name,
+		URL:         from.URL,
+		Description: from.Description,
+		Category:    "task",
+		Enabled:     from.Enabled,
+		Organization: &types.Organization{
+			Name: from.Organization,
+		},
+	}
+}
+
+func (m *jsonapiMarshaler) toWorkspaceRunTask(from *runtask.WorkspaceRunTask) *types.WorkspaceRunTask {
+	return &types.WorkspaceRunTask{
+		ID:               from.ID,
+		EnforcementLevel: string(from.EnforcementLevel),
+		Stage:            string(from.Stage),
+		RunTask:          m.toRunTask(from.RunTask),
+		Workspace: &types.Workspace{
+			ID: from.WorkspaceID,
+		},
+	}
+}
+
+func (m *jsonapiMarshaler) toTaskResult(from *runtask.TaskResult) *types.TaskResult {
+	return &types.TaskResult{
+		ID:                            from.ID,
+		Status:                        string(from.Status),
+		Message:                       from.Message,
+		URL:                           from.URL,
+		TaskID:                        from.TaskID,
+		TaskName:                      from.TaskName,
+		TaskURL:                       from.TaskURL,
+		Stage:                         string(from.Stage),
+		WorkspaceTaskEnforcementLevel: string(from.EnforcementLevel),
+		CreatedAt:                     from.CreatedAt,
+		UpdatedAt:                     from.UpdatedAt,
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"crypto/md5"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -37,6 +38,11 @@ func (a *api) addStateHandlers(r *mux.Router) {
 
 	// specific to OTF
 	r.HandleFunc("/workspaces/{workspace_id}/state-versions", a.listVersions).Methods("GET")
+	r.HandleFunc("/state-versions/{id}/diff", a.diffVersions).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/resource-history", a.listResourceChanges).Methods("GET")
+	r.HandleFunc("/state-versions/{id}/actions/pin", a.pinVersion).Methods("POST")
+	r.HandleFunc("/state-versions/{id}/actions/unpin", a.unpinVersion).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/state-versions/prunable", a.listPrunableVersions).Methods("GET")
 }
 
 func (a *api) createVersion(w http.ResponseWriter, r *http.Request) {
@@ -82,11 +88,15 @@ func (a *api) createVersion(w http.ResponseWriter, r *http.Request) {
 	// send different values for each and expect the serial in the create
 	// options to take precedence, without error. We've opted to support that
 	// behaviour.
-	sv, err := a.CreateStateVersion(r.Context(), state.CreateStateVersionOptions{
+	createOpts := state.CreateStateVersionOptions{
 		WorkspaceID: internal.String(workspaceID),
 		State:       decoded,
 		Serial:      opts.Serial,
-	})
+	}
+	if opts.Run != nil {
+		createOpts.RunID = &opts.Run.ID
+	}
+	sv, err := a.CreateStateVersion(r.Context(), createOpts)
 	if err != nil {
 		Error(w, err)
 		return
@@ -172,6 +182,50 @@ func (a *api) deleteVersion(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (a *api) pinVersion(w http.ResponseWriter, r *http.Request) {
+	versionID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	sv, err := a.PinStateVersion(r.Context(), versionID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, sv)
+}
+
+func (a *api) unpinVersion(w http.ResponseWriter, r *http.Request) {
+	versionID, err := decode.Param("id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	sv, err := a.UnpinStateVersion(r.Context(), versionID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, sv)
+}
+
+// listPrunableVersions lists the state versions that the workspace's
+// retention policy would prune, without pruning them.
+func (a *api) listPrunableVersions(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	versions, err := a.ListPrunableStateVersions(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, versions)
+}
+
 func (a *api) rollbackVersion(w http.ResponseWriter, r *http.Request) {
 	opts := types.RollbackStateVersionOptions{}
 	if err := unmarshal(r.Body, &opts); err != nil {
@@ -269,3 +323,55 @@ func (a *api) getOutput(w http.ResponseWriter, r *http.Request) {
 
 	a.writeResponse(w, r, out)
 }
+
+// diffVersions compares a state version with an earlier state version,
+// specified with the "from" query parameter, or with its preceding state
+// version if unspecified. The diff is returned as plain JSON rather than
+// JSON:API.
+func (a *api) diffVersions(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID   string `schema:"id,required"`
+		From string `schema:"from"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	diff, err := a.DiffStateVersions(r.Context(), params.From, params.ID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(diff); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// listResourceChanges lists the changes made to a resource by a workspace's
+// state versions. The changes are returned as plain JSON rather than JSON:API.
+func (a *api) listResourceChanges(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Address     string `schema:"address,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	changes, err := a.ListResourceChanges(r.Context(), params.WorkspaceID, params.Address)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(changes); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -24,10 +24,14 @@ func (m *jsonapiMarshaler) toState(from *state.Version, r *http.Request) (*types
 		ResourcesProcessed: true,
 		StateVersion:       state.Version,
 		TerraformVersion:   state.TerraformVersion,
+		Pinned:             from.Pinned,
 	}
 	for _, out := range from.Outputs {
 		to.Outputs = append(to.Outputs, &types.StateVersionOutput{ID: out.ID})
 	}
+	if from.RunID != nil {
+		to.Run = &types.Run{ID: *from.RunID}
+	}
 
 	// Support including related resources:
 	//
@@ -62,3 +66,19 @@ func (*jsonapiMarshaler) toOutput(from *state.Output, scrubSensitive bool) *type
 	}
 	return to
 }
+
+func (m *jsonapiMarshaler) toStateRetentionPolicy(from *state.RetentionPolicy) *types.StateRetentionPolicy {
+	to := &types.StateRetentionPolicy{
+		KeepLast: from.KeepLast,
+		KeepDays: from.KeepDays,
+	}
+	if from.Organization != nil {
+		to.ID = *from.Organization
+		to.Organization = &types.Organization{Name: *from.Organization}
+	}
+	if from.WorkspaceID != nil {
+		to.ID = *from.WorkspaceID
+		to.Workspace = &types.Workspace{ID: *from.WorkspaceID}
+	}
+	return to
+}
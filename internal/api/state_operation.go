@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/stateop"
+)
+
+func (a *api) addStateOperationHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/state-operations", a.createStateOperation).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/state-operations", a.listStateOperations).Methods("GET")
+}
+
+func (a *api) createStateOperation(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.StateOperationCreateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	op, err := a.CreateStateOperation(r.Context(), workspaceID, stateop.CreateOptions{
+		Kind:        stateop.Kind(params.Kind),
+		Address:     params.Address,
+		Destination: params.Destination,
+		Reason:      params.Reason,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, op, withCode(http.StatusCreated))
+}
+
+func (a *api) listStateOperations(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	ops, err := a.ListStateOperations(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, ops)
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/stateop"
+)
+
+func (m *jsonapiMarshaler) toStateOperation(from *stateop.Operation) *types.StateOperation {
+	to := &types.StateOperation{
+		ID:          from.ID,
+		Kind:        string(from.Kind),
+		Address:     from.Address,
+		Destination: from.Destination,
+		Reason:      from.Reason,
+		Author:      from.Author,
+		CreatedAt:   from.CreatedAt,
+		Workspace:   &types.Workspace{ID: from.WorkspaceID},
+	}
+	if from.StateVersionID != nil {
+		to.StateVersion = &types.StateVersion{ID: *from.StateVersionID}
+	}
+	if from.PreviousStateVersionID != nil {
+		to.PreviousStateVersion = &types.StateVersion{ID: *from.PreviousStateVersionID}
+	}
+	return to
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/api/types"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/state"
+)
+
+func (a *api) addStateRetentionHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/state-retention-policy", a.getOrganizationRetentionPolicy).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/state-retention-policy", a.updateOrganizationRetentionPolicy).Methods("PATCH")
+	r.HandleFunc("/organizations/{organization_name}/state-retention-policy", a.deleteOrganizationRetentionPolicy).Methods("DELETE")
+
+	r.HandleFunc("/workspaces/{workspace_id}/state-retention-policy", a.getWorkspaceRetentionPolicy).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/state-retention-policy", a.updateWorkspaceRetentionPolicy).Methods("PATCH")
+	r.HandleFunc("/workspaces/{workspace_id}/state-retention-policy", a.deleteWorkspaceRetentionPolicy).Methods("DELETE")
+}
+
+func (a *api) getOrganizationRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	policy, err := a.GetOrganizationStateRetentionPolicy(r.Context(), organization)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, policy)
+}
+
+func (a *api) updateOrganizationRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.StateRetentionPolicyUpdateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	policy, err := a.SetOrganizationStateRetentionPolicy(r.Context(), organization, state.SetRetentionPolicyOptions{
+		KeepLast: params.KeepLast,
+		KeepDays: params.KeepDays,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, policy)
+}
+
+func (a *api) deleteOrganizationRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if err := a.DeleteOrganizationStateRetentionPolicy(r.Context(), organization); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) getWorkspaceRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	policy, err := a.GetWorkspaceStateRetentionPolicy(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, policy)
+}
+
+func (a *api) updateWorkspaceRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.StateRetentionPolicyUpdateOptions
+	if err := unmarshal(r.Body, &params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	policy, err := a.SetWorkspaceStateRetentionPolicy(r.Context(), workspaceID, state.SetRetentionPolicyOptions{
+		KeepLast: params.KeepLast,
+		KeepDays: params.KeepDays,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	a.writeResponse(w, r, policy)
+}
+
+func (a *api) deleteWorkspaceRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	if err := a.DeleteWorkspaceStateRetentionPolicy(r.Context(), workspaceID); err != nil {
+		Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
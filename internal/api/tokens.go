@@ -38,6 +38,7 @@ func (a *api) createRunToken(w http.ResponseWriter, r *http.Request) {
 	token, err := a.CreateRunToken(r.Context(), tokens.CreateRunTokenOptions{
 		Organization: opts.Organization,
 		RunID:        opts.RunID,
+		WorkspaceID:  opts.WorkspaceID,
 	})
 	if err != nil {
 		Error(w, err)
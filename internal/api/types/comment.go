@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// Comment represents a comment on a run.
+type Comment struct {
+	ID        string    `jsonapi:"primary,comments"`
+	Body      string    `jsonapi:"attribute" json:"body"`
+	Author    string    `jsonapi:"attribute" json:"author"`
+	CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+
+	Run *Run `jsonapi:"relationship" json:"run"`
+}
+
+// CommentCreateOptions represents the options for creating a comment on a
+// run.
+type CommentCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,comments"`
+
+	// Required: The body of the comment
+	Body string `jsonapi:"attribute" json:"body"`
+}
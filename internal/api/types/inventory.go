@@ -0,0 +1,25 @@
+package types
+
+// InventoryResource is a resource managed by a workspace, as recorded in the
+// workspace's current state version.
+type InventoryResource struct {
+	ID              string  `jsonapi:"primary,inventory-resources"`
+	Address         string  `jsonapi:"attribute" json:"address"`
+	Module          string  `jsonapi:"attribute" json:"module"`
+	Type            string  `jsonapi:"attribute" json:"type"`
+	Name            string  `jsonapi:"attribute" json:"name"`
+	Provider        string  `jsonapi:"attribute" json:"provider"`
+	ProviderVersion *string `jsonapi:"attribute" json:"provider-version"`
+	RemoteID        *string `jsonapi:"attribute" json:"remote-id"`
+	WorkspaceName   string  `jsonapi:"attribute" json:"workspace-name"`
+
+	// Relations
+	Workspace    *Workspace    `jsonapi:"relationship" json:"workspace"`
+	StateVersion *StateVersion `jsonapi:"relationship" json:"state-version"`
+}
+
+// InventoryResourceList represents a list of inventory resources.
+type InventoryResourceList struct {
+	*Pagination
+	Items []*InventoryResource
+}
@@ -13,9 +13,15 @@ const (
 	NotificationTriggerApplying              NotificationTriggerType = "run:applying"
 	NotificationTriggerCompleted             NotificationTriggerType = "run:completed"
 	NotificationTriggerErrored               NotificationTriggerType = "run:errored"
+	NotificationTriggerApproved              NotificationTriggerType = "run:approved"
+	NotificationTriggerRejected              NotificationTriggerType = "run:rejected"
+	NotificationTriggerCommented             NotificationTriggerType = "run:commented"
 	NotificationTriggerAssessmentDrifted     NotificationTriggerType = "assessment:drifted"
 	NotificationTriggerAssessmentFailed      NotificationTriggerType = "assessment:failed"
 	NotificationTriggerAssessmentCheckFailed NotificationTriggerType = "assessment:check_failure"
+	NotificationTriggerAutoDestroyReminder   NotificationTriggerType = "workspace:auto_destroy_reminder"
+	NotificationTriggerLocked                NotificationTriggerType = "workspace:locked"
+	NotificationTriggerUnlocked              NotificationTriggerType = "workspace:unlocked"
 )
 
 // NotificationDestinationType represents the destination type of the
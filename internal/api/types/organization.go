@@ -32,6 +32,11 @@ type Organization struct {
 	// On those TFE versions, safe delete does not exist, so ALL deletes will be force deletes.
 	AllowForceDeleteWorkspaces bool `jsonapi:"attribute" json:"allow-force-delete-workspaces"`
 
+	// OTF-specific: maximum number of runs, and separately speculative plans,
+	// that can be in flight at any one time. Zero means there is no limit.
+	MaxConcurrentRuns            int `jsonapi:"attribute" json:"max-concurrent-runs"`
+	MaxConcurrentSpeculativeRuns int `jsonapi:"attribute" json:"max-concurrent-speculative-runs"`
+
 	// Relations
 	// DefaultProject *Project `jsonapi:"relation,default-project"`
 }
@@ -86,6 +91,12 @@ type OrganizationCreateOptions struct {
 
 	// Optional: AllowForceDeleteWorkspaces toggles behavior of allowing workspace admins to delete workspaces with resources under management.
 	AllowForceDeleteWorkspaces *bool `jsonapi:"attribute" json:"allow-force-delete-workspaces,omitempty"`
+
+	// Optional: Maximum number of runs that can be in flight at any one time. Zero means there is no limit.
+	MaxConcurrentRuns *int `jsonapi:"attribute" json:"max-concurrent-runs,omitempty"`
+
+	// Optional: Maximum number of speculative plans that can be in flight at any one time. Zero means there is no limit.
+	MaxConcurrentSpeculativeRuns *int `jsonapi:"attribute" json:"max-concurrent-speculative-runs,omitempty"`
 }
 
 // OrganizationUpdateOptions represents the options for updating an organization.
@@ -125,6 +136,12 @@ type OrganizationUpdateOptions struct {
 
 	// Optional: AllowForceDeleteWorkspaces toggles behavior of allowing workspace admins to delete workspaces with resources under management.
 	AllowForceDeleteWorkspaces *bool `jsonapi:"attribute" json:"allow-force-delete-workspaces,omitempty"`
+
+	// Optional: Maximum number of runs that can be in flight at any one time. Zero means there is no limit.
+	MaxConcurrentRuns *int `jsonapi:"attribute" json:"max-concurrent-runs,omitempty"`
+
+	// Optional: Maximum number of speculative plans that can be in flight at any one time. Zero means there is no limit.
+	MaxConcurrentSpeculativeRuns *int `jsonapi:"attribute" json:"max-concurrent-speculative-runs,omitempty"`
 }
 
 // Entitlements represents the entitlements of an organization. Unlike TFE/TFC,
@@ -142,6 +159,10 @@ type Entitlements struct {
 	StateStorage          bool   `jsonapi:"attribute" json:"state-storage"`
 	Teams                 bool   `jsonapi:"attribute" json:"teams"`
 	VCSIntegrations       bool   `jsonapi:"attribute" json:"vcs-integrations"`
+
+	// OTF-specific: concurrency limits; zero means there is no limit.
+	MaxConcurrentRuns            int `jsonapi:"attribute" json:"max-concurrent-runs"`
+	MaxConcurrentSpeculativeRuns int `jsonapi:"attribute" json:"max-concurrent-speculative-runs"`
 }
 
 // AuthPolicyType represents an authentication policy type.
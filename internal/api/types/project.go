@@ -0,0 +1,42 @@
+package types
+
+// Project represents a TFE project.
+type Project struct {
+	ID   string `jsonapi:"primary,projects"`
+	Name string `jsonapi:"attribute" json:"name"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relationship" json:"organization"`
+}
+
+// ProjectListOptions represents the options for listing projects.
+type ProjectListOptions struct {
+	ListOptions
+
+	// Optional: A query string to search projects by names.
+	Query string `schema:"q,omitempty"`
+}
+
+// ProjectCreateOptions represents the options for creating a project.
+type ProjectCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,projects"`
+
+	// Required: A name to identify the project.
+	Name *string `jsonapi:"attribute" json:"name"`
+}
+
+// ProjectUpdateOptions represents the options for updating a project.
+type ProjectUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,projects"`
+
+	// Optional: A name to identify the project.
+	Name *string `jsonapi:"attribute" json:"name,omitempty"`
+}
@@ -20,9 +20,11 @@ type Run struct {
 	Permissions            *RunPermissions      `jsonapi:"attribute" json:"permissions"`
 	PlanOnly               bool                 `jsonapi:"attribute" json:"plan-only"`
 	PositionInQueue        int                  `jsonapi:"attribute" json:"position-in-queue"`
+	QueuedByLimit          bool                 `jsonapi:"attribute" json:"queued-by-limit"`
 	Refresh                bool                 `jsonapi:"attribute" json:"refresh"`
 	RefreshOnly            bool                 `jsonapi:"attribute" json:"refresh-only"`
 	ReplaceAddrs           []string             `jsonapi:"attribute" json:"replace-addrs,omitempty"`
+	SavePlan               bool                 `jsonapi:"attribute" json:"save-plan"`
 	Source                 string               `jsonapi:"attribute" json:"source"`
 	Status                 string               `jsonapi:"attribute" json:"status"`
 	StatusTimestamps       *RunStatusTimestamps `jsonapi:"attribute" json:"status-timestamps"`
@@ -30,6 +32,18 @@ type Run struct {
 	TerraformVersion       string               `jsonapi:"attribute" json:"terraform-version"`
 	Variables              []RunVariable        `jsonapi:"attribute" json:"variables"`
 
+	// OTF-specific: explains why the run transitioned to its current status,
+	// e.g. why it was discarded.
+	StatusReason string `jsonapi:"attribute" json:"status-reason,omitempty"`
+
+	// OTF-specific: whether the run executes the configuration's tests with
+	// terraform test rather than planning changes.
+	Test bool `jsonapi:"attribute" json:"test"`
+
+	// OTF-specific: the maximum duration in seconds of the phase, populated
+	// only in the response to an agent starting a phase.
+	PhaseTimeout int `jsonapi:"attribute" json:"phase-timeout,omitempty"`
+
 	// Relations
 	Apply                *Apply                `jsonapi:"relationship" json:"apply"`
 	ConfigurationVersion *ConfigurationVersion `jsonapi:"relationship" json:"configuration-version"`
@@ -54,6 +68,7 @@ type RunStatusTimestamps struct {
 	PlanQueueableAt      *time.Time `json:"plan-queueable-at,omitempty"`
 	PlanQueuedAt         *time.Time `json:"plan-queued-at,omitempty"`
 	PlannedAndFinishedAt *time.Time `json:"planned-and-finished-at,omitempty"`
+	PlannedAndSavedAt    *time.Time `json:"planned-and-saved-at,omitempty"`
 	PlannedAt            *time.Time `json:"planned-at,omitempty"`
 	PlanningAt           *time.Time `json:"planning-at,omitempty"`
 	PolicyCheckedAt      *time.Time `json:"policy-checked-at,omitempty"`
@@ -101,6 +116,16 @@ type RunCreateOptions struct {
 	// PlanOnly specifies if this is a speculative, plan-only run that Terraform cannot apply.
 	PlanOnly *bool `jsonapi:"attribute" json:"plan-only,omitempty"`
 
+	// SavePlan specifies whether to create a saved plan, which can be applied
+	// later. Saved-plan runs perform their plan phase without locking the
+	// workspace, and are not applied until confirmed.
+	SavePlan *bool `jsonapi:"attribute" json:"save-plan,omitempty"`
+
+	// OTF-specific: Test specifies whether the run executes the
+	// configuration's tests with terraform test rather than planning changes.
+	// A test run is always plan-only.
+	Test *bool `jsonapi:"attribute" json:"test,omitempty"`
+
 	// Specifies if this plan is a destroy plan, which will destroy all
 	// provisioned resources.
 	IsDestroy *bool `jsonapi:"attribute" json:"is-destroy,omitempty"`
@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+// RunApproval represents a decision by a user to approve or reject a run.
+type RunApproval struct {
+	ID        string    `jsonapi:"primary,run-approvals"`
+	Decision  string    `jsonapi:"attribute" json:"decision"`
+	Comment   string    `jsonapi:"attribute" json:"comment"`
+	Username  string    `jsonapi:"attribute" json:"username"`
+	CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+
+	Run *Run `jsonapi:"relationship" json:"run"`
+}
+
+// RunApprovalCreateOptions represents the options for approving or rejecting
+// a run.
+type RunApprovalCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,run-approvals"`
+
+	// Required: Either "approved" or "rejected"
+	Decision string `jsonapi:"attribute" json:"decision"`
+
+	// Optional: A comment explaining the decision
+	Comment *string `jsonapi:"attribute" json:"comment,omitempty"`
+}
+
+// ApprovalPolicy represents the approvals a workspace requires before a run
+// can be applied.
+type ApprovalPolicy struct {
+	ID            string   `jsonapi:"primary,approval-policies"`
+	Required      int      `jsonapi:"attribute" json:"approvals-required"`
+	Teams         []string `jsonapi:"attribute" json:"teams"`
+	ExcludeAuthor bool     `jsonapi:"attribute" json:"exclude-author"`
+
+	Workspace *Workspace `jsonapi:"relationship" json:"workspace"`
+}
+
+// ApprovalPolicyUpdateOptions represents the options for updating a
+// workspace's approval policy.
+type ApprovalPolicyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,approval-policies"`
+
+	// Optional: The number of approvals required before a run can be applied
+	Required *int `jsonapi:"attribute" json:"approvals-required,omitempty"`
+
+	// Optional: The names of teams whose members can approve runs
+	Teams []string `jsonapi:"attribute" json:"teams,omitempty"`
+
+	// Optional: Whether to prohibit the author of a run from approving it
+	ExcludeAuthor *bool `jsonapi:"attribute" json:"exclude-author,omitempty"`
+}
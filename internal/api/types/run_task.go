@@ -0,0 +1,131 @@
+package types
+
+import "time"
+
+// RunTask represents a TFE run task.
+type RunTask struct {
+	ID          string  `jsonapi:"primary,tasks"`
+	Name        string  `jsonapi:"attribute" json:"name"`
+	URL         string  `jsonapi:"attribute" json:"url"`
+	Description string  `jsonapi:"attribute" json:"description"`
+	Category    string  `jsonapi:"attribute" json:"category"`
+	HMACKey     *string `jsonapi:"attribute" json:"hmac-key,omitempty"`
+	Enabled     bool    `jsonapi:"attribute" json:"enabled"`
+
+	Organization *Organization `jsonapi:"relationship" json:"organization"`
+}
+
+// RunTaskCreateOptions represents the set of options for creating a run task.
+type RunTaskCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,tasks"`
+
+	// Required: The name of the run task
+	Name string `jsonapi:"attribute" json:"name"`
+
+	// Required: The URL to send a run task payload
+	URL string `jsonapi:"attribute" json:"url"`
+
+	// Required: Must be "task"
+	Category string `jsonapi:"attribute" json:"category"`
+
+	// Optional: Description of the task
+	Description *string `jsonapi:"attribute" json:"description,omitempty"`
+
+	// Optional: An HMAC key to verify the run task
+	HMACKey *string `jsonapi:"attribute" json:"hmac-key,omitempty"`
+
+	// Optional: Whether the task should be enabled
+	Enabled *bool `jsonapi:"attribute" json:"enabled,omitempty"`
+}
+
+// RunTaskUpdateOptions represents the set of options for updating an
+// organization's run task.
+type RunTaskUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,tasks"`
+
+	// Optional: The name of the run task
+	Name *string `jsonapi:"attribute" json:"name,omitempty"`
+
+	// Optional: The URL to send a run task payload
+	URL *string `jsonapi:"attribute" json:"url,omitempty"`
+
+	// Optional: Must be "task"
+	Category *string `jsonapi:"attribute" json:"category,omitempty"`
+
+	// Optional: Description of the task
+	Description *string `jsonapi:"attribute" json:"description,omitempty"`
+
+	// Optional: An HMAC key to verify the run task
+	HMACKey *string `jsonapi:"attribute" json:"hmac-key,omitempty"`
+
+	// Optional: Whether the task should be enabled
+	Enabled *bool `jsonapi:"attribute" json:"enabled,omitempty"`
+}
+
+// WorkspaceRunTask represents a run task attached to a workspace.
+type WorkspaceRunTask struct {
+	ID               string `jsonapi:"primary,workspace-tasks"`
+	EnforcementLevel string `jsonapi:"attribute" json:"enforcement-level"`
+	Stage            string `jsonapi:"attribute" json:"stage"`
+
+	RunTask   *RunTask   `jsonapi:"relationship" json:"task"`
+	Workspace *Workspace `jsonapi:"relationship" json:"workspace"`
+}
+
+// WorkspaceRunTaskCreateOptions represents the set of options for attaching a
+// run task to a workspace.
+type WorkspaceRunTaskCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,workspace-tasks"`
+
+	// Required: The enforcement level for a run task
+	EnforcementLevel string `jsonapi:"attribute" json:"enforcement-level"`
+
+	// Optional: The stage to run the task in; defaults to post_plan
+	Stage *string `jsonapi:"attribute" json:"stage,omitempty"`
+
+	// Required: The run task to attach to the workspace
+	RunTask *RunTask `jsonapi:"relationship" json:"task"`
+}
+
+// WorkspaceRunTaskUpdateOptions represents the set of options for updating a
+// workspace run task.
+type WorkspaceRunTaskUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,workspace-tasks"`
+
+	// Optional: The enforcement level for a run task
+	EnforcementLevel *string `jsonapi:"attribute" json:"enforcement-level,omitempty"`
+
+	// Optional: The stage to run the task in
+	Stage *string `jsonapi:"attribute" json:"stage,omitempty"`
+}
+
+// TaskResult represents the result of a run task invoked for a run.
+type TaskResult struct {
+	ID                            string    `jsonapi:"primary,task-results"`
+	Status                        string    `jsonapi:"attribute" json:"status"`
+	Message                       string    `jsonapi:"attribute" json:"message"`
+	URL                           string    `jsonapi:"attribute" json:"url"`
+	TaskID                        string    `jsonapi:"attribute" json:"task-id"`
+	TaskName                      string    `jsonapi:"attribute" json:"task-name"`
+	TaskURL                       string    `jsonapi:"attribute" json:"task-url"`
+	Stage                         string    `jsonapi:"attribute" json:"stage"`
+	WorkspaceTaskEnforcementLevel string    `jsonapi:"attribute" json:"workspace-task-enforcement-level"`
+	CreatedAt                     time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt                     time.Time `jsonapi:"attribute" json:"updated-at"`
+}
@@ -11,4 +11,7 @@ type CreateRunTokenOptions struct {
 
 	// RunID is the ID of the run for which the token is being created.
 	RunID *string `jsonapi:"attribute" json:"run_id"`
+
+	// WorkspaceID is the ID of the run's workspace.
+	WorkspaceID *string `jsonapi:"attribute" json:"workspace_id"`
 }
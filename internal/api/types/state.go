@@ -12,9 +12,11 @@ type StateVersion struct {
 	ResourcesProcessed bool      `jsonapi:"attribute" json:"resources-processed"`
 	StateVersion       int       `jsonapi:"attribute" json:"state-version"`
 	TerraformVersion   string    `jsonapi:"attribute" json:"terraform-version"`
+	Pinned             bool      `jsonapi:"attribute" json:"pinned"`
 
 	// Relations
 	Outputs []*StateVersionOutput `jsonapi:"relationship" json:"outputs"`
+	Run     *Run                  `jsonapi:"relationship" json:"run,omitempty"`
 }
 
 // StateVersionList is a list of state versions suitable for marshaling into
@@ -52,7 +54,7 @@ type StateVersionCreateVersionOptions struct {
 	// cause data loss, so USE WITH CAUTION!
 	Force *bool `jsonapi:"attribute" json:"force"`
 	// Specifies the run to associate the state with.
-	// Run *Run `jsonapi:"relationship" json:"run,omitempty"`
+	Run *Run `jsonapi:"relationship" json:"run,omitempty"`
 }
 
 // RollbackStateVersionOptions are options for rolling back a state version
@@ -65,3 +67,32 @@ type RollbackStateVersionOptions struct {
 	// Specifies state version to rollback to. Only its ID is specified.
 	RollbackStateVersion *StateVersion `jsonapi:"relationship" json:"state-version"`
 }
+
+// StateRetentionPolicy determines which of a workspace's state versions are
+// kept. A policy belongs to either an organization or a workspace, and its ID
+// is the name of the organization or the ID of the workspace respectively.
+type StateRetentionPolicy struct {
+	ID       string `jsonapi:"primary,state-retention-policies"`
+	KeepLast *int   `jsonapi:"attribute" json:"keep-last"`
+	KeepDays *int   `jsonapi:"attribute" json:"keep-days"`
+
+	Organization *Organization `jsonapi:"relationship" json:"organization,omitempty"`
+	Workspace    *Workspace    `jsonapi:"relationship" json:"workspace,omitempty"`
+}
+
+// StateRetentionPolicyUpdateOptions represents the options for setting a
+// state retention policy. Both options replace any existing values, and
+// omitting both keeps all state versions.
+type StateRetentionPolicyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,state-retention-policies"`
+
+	// Optional: The number of most recent state versions to keep
+	KeepLast *int `jsonapi:"attribute" json:"keep-last,omitempty"`
+
+	// Optional: The number of days for which to keep state versions
+	KeepDays *int `jsonapi:"attribute" json:"keep-days,omitempty"`
+}
@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// StateOperation represents an operation performed on a workspace's state.
+type StateOperation struct {
+	ID          string    `jsonapi:"primary,state-operations"`
+	Kind        string    `jsonapi:"attribute" json:"kind"`
+	Address     string    `jsonapi:"attribute" json:"address"`
+	Destination *string   `jsonapi:"attribute" json:"destination"`
+	Reason      string    `jsonapi:"attribute" json:"reason"`
+	Author      string    `jsonapi:"attribute" json:"author"`
+	CreatedAt   time.Time `jsonapi:"attribute" json:"created-at"`
+
+	// Relations
+	Workspace            *Workspace    `jsonapi:"relationship" json:"workspace"`
+	StateVersion         *StateVersion `jsonapi:"relationship" json:"state-version"`
+	PreviousStateVersion *StateVersion `jsonapi:"relationship" json:"previous-state-version"`
+}
+
+// StateOperationCreateOptions represents the options for performing an
+// operation on a workspace's state.
+type StateOperationCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,state-operations"`
+
+	// Required: The kind of operation: move, remove or taint.
+	Kind string `jsonapi:"attribute" json:"kind"`
+
+	// Required: The address of the resource or resource instance.
+	Address string `jsonapi:"attribute" json:"address"`
+
+	// Optional: The address to move the resource to. Required for moves.
+	Destination *string `jsonapi:"attribute" json:"destination,omitempty"`
+
+	// Required: The reason for performing the operation.
+	Reason string `jsonapi:"attribute" json:"reason"`
+}
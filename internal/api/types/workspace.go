@@ -26,6 +26,7 @@ type Workspace struct {
 	FileTriggersEnabled        bool                  `jsonapi:"attribute" json:"file-triggers-enabled"`
 	GlobalRemoteState          bool                  `jsonapi:"attribute" json:"global-remote-state"`
 	Locked                     bool                  `jsonapi:"attribute" json:"locked"`
+	LockReason                 string                `jsonapi:"attribute" json:"lock-reason"`
 	MigrationEnvironment       string                `jsonapi:"attribute" json:"migration-environment"`
 	Name                       string                `jsonapi:"attribute" json:"name"`
 	Operations                 bool                  `jsonapi:"attribute" json:"operations"`
@@ -49,10 +50,92 @@ type Workspace struct {
 	RunsCount                  int                   `jsonapi:"attribute" json:"workspace-kpis-runs-count"`
 	TagNames                   []string              `jsonapi:"attribute" json:"tag-names"`
 
+	// OTF-specific: maximum durations in seconds of the plan and apply phases;
+	// zero means the site-wide default applies.
+	PlanTimeout  int `jsonapi:"attribute" json:"plan-timeout"`
+	ApplyTimeout int `jsonapi:"attribute" json:"apply-timeout"`
+
+	// OTF-specific: period in seconds after which a run awaiting confirmation
+	// is discarded; zero disables auto-discard.
+	AutoDiscardTTL int `jsonapi:"attribute" json:"auto-discard-ttl"`
+	// OTF-specific: whether VCS-triggered runs supersede older pending runs
+	// for the same branch.
+	SupersedeRuns bool `jsonapi:"attribute" json:"supersede-runs"`
+
+	// Time at which a destroy run is scheduled; nil if none is scheduled.
+	AutoDestroyAt *time.Time `jsonapi:"attribute" json:"auto-destroy-at"`
+	// Period of inactivity, in the form <n>h or <n>d, after which a destroy
+	// run is scheduled; nil if disabled.
+	AutoDestroyActivityDuration *string `jsonapi:"attribute" json:"auto-destroy-activity-duration"`
+	// OTF-specific: whether scheduled destroy runs are automatically applied.
+	AutoApplyDestroy bool `jsonapi:"attribute" json:"auto-apply-destroy"`
+	// OTF-specific: whether the workspace is offered as a template from which
+	// new workspaces are cloned.
+	Template bool `jsonapi:"attribute" json:"template"`
+
+	// OTF-specific: time at which the workspace was locked; nil if unlocked.
+	LockedAt *time.Time `jsonapi:"attribute" json:"locked-at"`
+	// OTF-specific: time after which the lock is released automatically; nil
+	// if unlocked or the lock does not expire.
+	LockExpiresAt *time.Time `jsonapi:"attribute" json:"lock-expires-at"`
+
 	// Relations
 	CurrentRun   *Run                  `jsonapi:"relationship" json:"current-run"`
 	Organization *Organization         `jsonapi:"relationship" json:"organization"`
 	Outputs      []*StateVersionOutput `jsonapi:"relationship" json:"outputs"`
+	Project      *Project              `jsonapi:"relationship" json:"project"`
+}
+
+// WorkspaceLockOptions represents the options for locking a workspace.
+type WorkspaceLockOptions struct {
+	// Specifies the reason for locking the workspace.
+	Reason *string `json:"reason,omitempty"`
+	// OTF-specific: time after which the lock is released automatically.
+	ExpiresAt *time.Time `json:"expires-at,omitempty"`
+}
+
+// WorkspaceCloneOptions is an OTF-specific set of options for cloning a
+// workspace. The clone inherits the workspace's settings and VCS connection;
+// the remaining options select what else is copied.
+type WorkspaceCloneOptions struct {
+	// Required: name of the new workspace.
+	Name *string `json:"name"`
+	// Copy non-sensitive variables.
+	Variables bool `json:"variables,omitempty"`
+	// Copy sensitive variables.
+	SensitiveVariables bool `json:"sensitive-variables,omitempty"`
+	// Copy team permissions.
+	Permissions bool `json:"permissions,omitempty"`
+	// Copy notification configurations.
+	Notifications bool `json:"notifications,omitempty"`
+	// Copy tags.
+	Tags bool `json:"tags,omitempty"`
+}
+
+// WorkspaceLockEvent is an OTF-specific record of a change to a workspace's
+// lock.
+type WorkspaceLockEvent struct {
+	ID        string    `jsonapi:"primary,workspace-lock-events"`
+	CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+	// Action is one of locked, unlocked, force_unlocked or expired.
+	Action string `jsonapi:"attribute" json:"action"`
+	// LockKind is the kind of entity holding the lock: user or run.
+	LockKind string `jsonapi:"attribute" json:"lock-kind"`
+	// Holder is the username or run ID holding the lock.
+	Holder string `jsonapi:"attribute" json:"holder"`
+	// Actor is the username or run ID that performed the action; nil if the
+	// lock expired.
+	Actor  *string `jsonapi:"attribute" json:"actor"`
+	Reason string  `jsonapi:"attribute" json:"reason"`
+
+	// Relations
+	Workspace *Workspace `jsonapi:"relationship" json:"workspace"`
+}
+
+// WorkspaceLockEventList represents a list of workspace lock events.
+type WorkspaceLockEventList struct {
+	*Pagination
+	Items []*WorkspaceLockEvent
 }
 
 // WorkspaceList represents a list of workspaces.
@@ -111,6 +194,9 @@ type WorkspaceListOptions struct {
 	// Optional: A filter string to list all the workspaces linked to a given project id in the organization.
 	ProjectID string `schema:"filter[project][id],omitempty"`
 
+	// Optional: OTF-specific: list only workspaces marked as templates.
+	Template bool `schema:"filter[template],omitempty"`
+
 	// Optional: A list of relations to include. See available resources https://developer.hashicorp.com/terraform/cloud-docs/api-docs/workspaces#available-related-resources
 	// Include []WSIncludeOpt `url:"include,omitempty"`
 }
@@ -206,6 +292,35 @@ type WorkspaceCreateOptions struct {
 	// to decide whether to trigger a run or not.
 	TriggerPatterns []string `jsonapi:"attribute" json:"trigger-patterns,omitempty"`
 
+	// Optional: OTF-specific: maximum durations in seconds of the plan and
+	// apply phases of the workspace's runs. Zero means the site-wide default
+	// applies.
+	PlanTimeout  *int `jsonapi:"attribute" json:"plan-timeout,omitempty"`
+	ApplyTimeout *int `jsonapi:"attribute" json:"apply-timeout,omitempty"`
+
+	// Optional: OTF-specific: period in seconds after which a run awaiting
+	// confirmation is automatically discarded. Zero disables auto-discard.
+	AutoDiscardTTL *int `jsonapi:"attribute" json:"auto-discard-ttl,omitempty"`
+
+	// Optional: OTF-specific: whether VCS-triggered runs supersede older
+	// pending runs for the same branch.
+	SupersedeRuns *bool `jsonapi:"attribute" json:"supersede-runs,omitempty"`
+
+	// Optional: Time at which to queue a destroy run.
+	AutoDestroyAt *time.Time `jsonapi:"attribute" json:"auto-destroy-at,omitempty"`
+
+	// Optional: Period of inactivity, in the form <n>h or <n>d, after which
+	// to queue a destroy run.
+	AutoDestroyActivityDuration *string `jsonapi:"attribute" json:"auto-destroy-activity-duration,omitempty"`
+
+	// Optional: OTF-specific: whether to automatically apply scheduled
+	// destroy runs.
+	AutoApplyDestroy *bool `jsonapi:"attribute" json:"auto-apply-destroy,omitempty"`
+
+	// Optional: OTF-specific: whether to offer the workspace as a template
+	// from which new workspaces are cloned.
+	Template *bool `jsonapi:"attribute" json:"template,omitempty"`
+
 	// Settings for the workspace's VCS repository. If omitted, the workspace is
 	// created without a VCS repo. If included, you must specify at least the
 	// oauth-token-id and identifier keys below.
@@ -219,6 +334,10 @@ type WorkspaceCreateOptions struct {
 	// A list of tags to attach to the workspace. If the tag does not already
 	// exist, it is created and added to the workspace.
 	Tags []*Tag `jsonapi:"relationship" json:"tags,omitempty"`
+
+	// Associated Project with the workspace. If not provided, default project
+	// of the organization will be assigned to the workspace.
+	Project *Project `jsonapi:"relationship" json:"project,omitempty"`
 }
 
 // WorkspaceUpdateOptions represents the options for updating a workspace.
@@ -294,6 +413,36 @@ type WorkspaceUpdateOptions struct {
 	// to decide whether to trigger a run or not.
 	TriggerPatterns []string `jsonapi:"attribute" json:"trigger-patterns,omitempty"`
 
+	// Optional: OTF-specific: maximum durations in seconds of the plan and
+	// apply phases of the workspace's runs. Zero means the site-wide default
+	// applies.
+	PlanTimeout  *int `jsonapi:"attribute" json:"plan-timeout,omitempty"`
+	ApplyTimeout *int `jsonapi:"attribute" json:"apply-timeout,omitempty"`
+
+	// Optional: OTF-specific: period in seconds after which a run awaiting
+	// confirmation is automatically discarded. Zero disables auto-discard.
+	AutoDiscardTTL *int `jsonapi:"attribute" json:"auto-discard-ttl,omitempty"`
+
+	// Optional: OTF-specific: whether VCS-triggered runs supersede older
+	// pending runs for the same branch.
+	SupersedeRuns *bool `jsonapi:"attribute" json:"supersede-runs,omitempty"`
+
+	// Optional: Time at which to queue a destroy run. Specify null to cancel
+	// a scheduled destroy.
+	AutoDestroyAt NullableTime `jsonapi:"attribute" json:"auto-destroy-at,omitempty"`
+
+	// Optional: Period of inactivity, in the form <n>h or <n>d, after which
+	// to queue a destroy run. Specify null to disable.
+	AutoDestroyActivityDuration NullableString `jsonapi:"attribute" json:"auto-destroy-activity-duration,omitempty"`
+
+	// Optional: OTF-specific: whether to automatically apply scheduled
+	// destroy runs.
+	AutoApplyDestroy *bool `jsonapi:"attribute" json:"auto-apply-destroy,omitempty"`
+
+	// Optional: OTF-specific: whether to offer the workspace as a template
+	// from which new workspaces are cloned.
+	Template *bool `jsonapi:"attribute" json:"template,omitempty"`
+
 	// To delete a workspace's existing VCS repo, specify null instead of an
 	// object. To modify a workspace's existing VCS repo, include whichever of
 	// the keys below you wish to modify. To add a new VCS repo to a workspace
@@ -306,6 +455,10 @@ type WorkspaceUpdateOptions struct {
 	// the environment when multiple environments exist within the same
 	// repository.
 	WorkingDirectory *string `jsonapi:"attribute" json:"working-directory,omitempty"`
+
+	// Associated Project with the workspace. If provided, the workspace is
+	// moved to the project.
+	Project *Project `jsonapi:"relationship" json:"project,omitempty"`
 }
 
 func (opts *WorkspaceUpdateOptions) Validate() error {
@@ -365,3 +518,61 @@ func (o *VCSRepoOptionsJSON) UnmarshalJSON(data []byte) error {
 	o.Valid = true
 	return nil
 }
+
+// NullableTime is a time attribute that differentiates between having been
+// explicitly set to null, and omitted.
+type NullableTime struct {
+	Time time.Time
+
+	Valid bool `json:"-"`
+	Set   bool `json:"-"`
+}
+
+func (t NullableTime) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time)
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if string(data) == "null" {
+		t.Valid = false
+		return nil
+	}
+	if err := json.Unmarshal(data, &t.Time); err != nil {
+		return err
+	}
+	t.Valid = true
+	return nil
+}
+
+// NullableString is a string attribute that differentiates between having
+// been explicitly set to null, and omitted.
+type NullableString struct {
+	String string
+
+	Valid bool `json:"-"`
+	Set   bool `json:"-"`
+}
+
+func (s NullableString) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.String)
+}
+
+func (s *NullableString) UnmarshalJSON(data []byte) error {
+	s.Set = true
+	if string(data) == "null" {
+		s.Valid = false
+		return nil
+	}
+	if err := json.Unmarshal(data, &s.String); err != nil {
+		return err
+	}
+	s.Valid = true
+	return nil
+}
@@ -1,8 +1,13 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -13,6 +18,11 @@ import (
 	"github.com/leg100/otf/internal/workspace"
 )
 
+var errInvalidActivityDuration = &internal.HTTPError{
+	Code:    http.StatusUnprocessableEntity,
+	Message: "auto-destroy activity duration must be a positive number of hours or days, e.g. 12h or 7d",
+}
+
 type (
 	// byWorkspaceName are parameters used when looking up a workspace by
 	// name
@@ -30,13 +40,18 @@ func (a *api) addWorkspaceHandlers(r *mux.Router) {
 	r.HandleFunc("/organizations/{organization_name}/workspaces/{workspace_name}", a.getWorkspaceByName).Methods("GET")
 	r.HandleFunc("/organizations/{organization_name}/workspaces/{workspace_name}", a.updateWorkspaceByName).Methods("PATCH")
 	r.HandleFunc("/organizations/{organization_name}/workspaces/{workspace_name}", a.deleteWorkspaceByName).Methods("DELETE")
+	r.HandleFunc("/organizations/{organization_name}/workspaces/{workspace_name}/actions/safe-delete", a.safeDeleteWorkspaceByName).Methods("POST")
 
 	r.HandleFunc("/workspaces/{workspace_id}", a.updateWorkspaceByID).Methods("PATCH")
 	r.HandleFunc("/workspaces/{workspace_id}", a.getWorkspace).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}", a.deleteWorkspace).Methods("DELETE")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/safe-delete", a.safeDeleteWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/destroy-and-delete", a.destroyAndDeleteWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/clone", a.cloneWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/lock", a.lockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/unlock", a.unlockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/force-unlock", a.forceUnlockWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/lock-events", a.listWorkspaceLockEvents).Methods("GET")
 }
 
 func (a *api) createWorkspace(w http.ResponseWriter, r *http.Request) {
@@ -68,9 +83,27 @@ func (a *api) createWorkspace(w http.ResponseWriter, r *http.Request) {
 		TriggerPrefixes:            params.TriggerPrefixes,
 		TriggerPatterns:            params.TriggerPatterns,
 		WorkingDirectory:           params.WorkingDirectory,
+		PlanTimeout:                secondsToDuration(params.PlanTimeout),
+		ApplyTimeout:               secondsToDuration(params.ApplyTimeout),
+		AutoDiscardTTL:             secondsToDuration(params.AutoDiscardTTL),
+		SupersedeRuns:              params.SupersedeRuns,
+		AutoDestroyAt:              params.AutoDestroyAt,
+		AutoApplyDestroy:           params.AutoApplyDestroy,
+		Template:                   params.Template,
 		// convert from json:api structs to tag specs
 		Tags: toTagSpecs(params.Tags),
 	}
+	if params.Project != nil {
+		opts.ProjectID = &params.Project.ID
+	}
+	if params.AutoDestroyActivityDuration != nil {
+		d, err := parseActivityDuration(*params.AutoDestroyActivityDuration)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+		opts.AutoDestroyActivityDuration = &d
+	}
 	// Always trigger runs if neither trigger patterns nor tags regex are set
 	if len(params.TriggerPatterns) == 0 && (params.VCSRepo == nil || params.VCSRepo.TagsRegex == nil) {
 		opts.AlwaysTrigger = internal.Bool(true)
@@ -153,12 +186,17 @@ func (a *api) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wsl, err := a.ListWorkspaces(r.Context(), workspace.ListOptions{
-		Search:       params.Search,
-		Organization: &organization,
-		PageOptions:  resource.PageOptions(params.ListOptions),
-		Tags:         internal.SplitCSV(params.Tags),
-	})
+	opts := workspace.ListOptions{
+		Search:        params.Search,
+		Organization:  &organization,
+		PageOptions:   resource.PageOptions(params.ListOptions),
+		Tags:          internal.SplitCSV(params.Tags),
+		TemplatesOnly: params.Template,
+	}
+	if params.ProjectID != "" {
+		opts.ProjectID = &params.ProjectID
+	}
+	wsl, err := a.ListWorkspaces(r.Context(), opts)
 	if err != nil {
 		Error(w, err)
 		return
@@ -206,7 +244,19 @@ func (a *api) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ws, err := a.LockWorkspace(r.Context(), id, nil)
+	// the options are sent as plain json and are optional, so permit an empty
+	// body.
+	var params types.WorkspaceLockOptions
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	}
+	opts := workspace.LockOptions{ExpiresAt: params.ExpiresAt}
+	if params.Reason != nil {
+		opts.Reason = *params.Reason
+	}
+
+	ws, err := a.LockWorkspace(r.Context(), id, nil, opts)
 	if err != nil {
 		Error(w, err)
 		return
@@ -215,6 +265,24 @@ func (a *api) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 	a.writeResponse(w, r, ws)
 }
 
+func (a *api) listWorkspaceLockEvents(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	page, err := a.ListWorkspaceLockEvents(r.Context(), params.WorkspaceID, params.PageOptions)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, page)
+}
+
 func (a *api) unlockWorkspace(w http.ResponseWriter, r *http.Request) {
 	a.unlock(w, r, false)
 }
@@ -258,6 +326,85 @@ func (a *api) deleteWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (a *api) safeDeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	_, err = a.SafeDeleteWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) safeDeleteWorkspaceByName(w http.ResponseWriter, r *http.Request) {
+	var params byWorkspaceName
+	if err := decode.All(&params, r); err != nil {
+		Error(w, err)
+		return
+	}
+
+	ws, err := a.GetWorkspaceByName(r.Context(), params.Organization, params.Name)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	_, err = a.SafeDeleteWorkspace(r.Context(), ws.ID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) cloneWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	var params types.WorkspaceCloneOptions
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		Error(w, err)
+		return
+	}
+
+	ws, err := a.CloneWorkspace(r.Context(), workspaceID, workspace.CloneOptions{
+		Name:               params.Name,
+		Variables:          params.Variables,
+		SensitiveVariables: params.SensitiveVariables,
+		Permissions:        params.Permissions,
+		Notifications:      params.Notifications,
+		Tags:               params.Tags,
+	})
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, ws, withCode(http.StatusCreated))
+}
+
+// destroyAndDeleteWorkspace queues a destroy run, responding with the run,
+// and deletes the workspace once the run has been applied.
+func (a *api) destroyAndDeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	run, err := a.DestroyAndDeleteWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	a.writeResponse(w, r, run, withCode(http.StatusCreated))
+}
+
 func (a *api) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceID string) {
 	params := types.WorkspaceUpdateOptions{}
 	if err := unmarshal(r.Body, &params); err != nil {
@@ -283,6 +430,33 @@ func (a *api) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 		TriggerPrefixes:            params.TriggerPrefixes,
 		TriggerPatterns:            params.TriggerPatterns,
 		WorkingDirectory:           params.WorkingDirectory,
+		PlanTimeout:                secondsToDuration(params.PlanTimeout),
+		ApplyTimeout:               secondsToDuration(params.ApplyTimeout),
+		AutoDiscardTTL:             secondsToDuration(params.AutoDiscardTTL),
+		SupersedeRuns:              params.SupersedeRuns,
+		AutoApplyDestroy:           params.AutoApplyDestroy,
+		Template:                   params.Template,
+	}
+	if params.Project != nil {
+		opts.ProjectID = &params.Project.ID
+	}
+	if params.AutoDestroyAt.Set {
+		// a zero time cancels a scheduled destroy
+		opts.AutoDestroyAt = &params.AutoDestroyAt.Time
+	}
+	if params.AutoDestroyActivityDuration.Set {
+		var (
+			d   time.Duration
+			err error
+		)
+		if params.AutoDestroyActivityDuration.Valid {
+			d, err = parseActivityDuration(params.AutoDestroyActivityDuration.String)
+			if err != nil {
+				Error(w, err)
+				return
+			}
+		}
+		opts.AutoDestroyActivityDuration = &d
 	}
 
 	// If file-triggers-enabled is set to false and tags regex is unspecified
@@ -338,3 +512,42 @@ func (a *api) unlock(w http.ResponseWriter, r *http.Request, force bool) {
 
 	a.writeResponse(w, r, ws)
 }
+
+// secondsToDuration converts an optional number of seconds into an optional
+// duration.
+func secondsToDuration(seconds *int) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+	return internal.Duration(time.Duration(*seconds) * time.Second)
+}
+
+// parseActivityDuration parses a period of inactivity in the form <n>h or
+// <n>d, i.e. a number of hours or days.
+func parseActivityDuration(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, errInvalidActivityDuration
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 1 {
+		return 0, errInvalidActivityDuration
+	}
+	switch s[len(s)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	default:
+		return 0, errInvalidActivityDuration
+	}
+}
+
+// formatActivityDuration formats a period of inactivity in the form <n>d if
+// it is a whole number of days, otherwise <n>h.
+func formatActivityDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours%24 == 0 {
+		return fmt.Sprintf("%dd", hours/24)
+	}
+	return fmt.Sprintf("%dh", hours)
+}
@@ -61,14 +61,30 @@ func (m *jsonapiMarshaler) toWorkspace(from *workspace.Workspace, r *http.Reques
 		TriggerPrefixes:            from.TriggerPrefixes,
 		TriggerPatterns:            from.TriggerPatterns,
 		WorkingDirectory:           from.WorkingDirectory,
+		PlanTimeout:                int(from.PlanTimeout.Seconds()),
+		ApplyTimeout:               int(from.ApplyTimeout.Seconds()),
+		AutoDiscardTTL:             int(from.AutoDiscardTTL.Seconds()),
+		SupersedeRuns:              from.SupersedeRuns,
+		AutoDestroyAt:              from.AutoDestroyAt,
+		AutoApplyDestroy:           from.AutoApplyDestroy,
+		Template:                   from.Template,
 		TagNames:                   from.Tags,
 		UpdatedAt:                  from.UpdatedAt,
 		Organization:               &types.Organization{Name: from.Organization},
+		Project:                    &types.Project{ID: from.ProjectID},
 		Outputs:                    []*types.StateVersionOutput{},
 	}
 	if len(from.TriggerPrefixes) > 0 || len(from.TriggerPatterns) > 0 {
 		to.FileTriggersEnabled = true
 	}
+	if from.Lock != nil {
+		to.LockReason = from.Lock.Reason
+		to.LockedAt = &from.Lock.LockedAt
+		to.LockExpiresAt = from.Lock.ExpiresAt
+	}
+	if from.AutoDestroyActivityDuration > 0 {
+		to.AutoDestroyActivityDuration = internal.String(formatActivityDuration(from.AutoDestroyActivityDuration))
+	}
 	if from.LatestRun != nil {
 		to.CurrentRun = &types.Run{ID: from.LatestRun.ID}
 	}
@@ -144,3 +160,16 @@ func (m *jsonapiMarshaler) toWorkspace(from *workspace.Workspace, r *http.Reques
 	opts := []jsonapi.MarshalOption{jsonapi.MarshalInclude(included...)}
 	return to, opts, nil
 }
+
+func (m *jsonapiMarshaler) toWorkspaceLockEvent(from *workspace.LockEvent) *types.WorkspaceLockEvent {
+	return &types.WorkspaceLockEvent{
+		ID:        from.ID,
+		CreatedAt: from.CreatedAt,
+		Action:    string(from.Action),
+		LockKind:  from.Kind.String(),
+		Holder:    from.Holder,
+		Actor:     from.Actor,
+		Reason:    from.Reason,
+		Workspace: &types.Workspace{ID: from.WorkspaceID},
+	}
+}
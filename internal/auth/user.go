@@ -165,14 +165,19 @@ func (u *User) CanAccessWorkspace(action rbac.Action, policy internal.WorkspaceP
 	if u.CanAccessOrganization(action, policy.Organization) {
 		return true
 	}
-	// fallback to checking finer-grained workspace perms
+	// fallback to checking finer-grained workspace and project perms
 	for _, team := range u.Teams {
 		if team.Organization != policy.Organization {
 			continue
 		}
 		for _, perm := range policy.Permissions {
-			if team.Name == perm.Team {
-				return perm.Role.IsAllowed(action)
+			if team.Name == perm.Team && perm.Role.IsAllowed(action) {
+				return true
+			}
+		}
+		for _, perm := range policy.ProjectPermissions {
+			if team.Name == perm.Team && perm.Role.IsAllowed(action) {
+				return true
 			}
 		}
 	}
@@ -3,6 +3,7 @@ package auth
 import (
 	"testing"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +54,57 @@ func TestUser_Organizations(t *testing.T) {
 	assert.Contains(t, want, "big-tobacco")
 	assert.Contains(t, want, "big-pharma")
 }
+
+func TestUser_CanAccessWorkspace(t *testing.T) {
+	u := User{
+		Teams: []*Team{
+			{
+				Name:         "devops",
+				Organization: "acme-corp",
+			},
+		},
+	}
+
+	t.Run("workspace permission", func(t *testing.T) {
+		policy := internal.WorkspacePolicy{
+			Organization: "acme-corp",
+			Permissions: []internal.WorkspacePermission{
+				{Team: "devops", Role: rbac.WorkspaceWriteRole},
+			},
+		}
+		assert.True(t, u.CanAccessWorkspace(rbac.ApplyRunAction, policy))
+	})
+
+	t.Run("project permission", func(t *testing.T) {
+		policy := internal.WorkspacePolicy{
+			Organization: "acme-corp",
+			ProjectPermissions: []internal.WorkspacePermission{
+				{Team: "devops", Role: rbac.WorkspaceWriteRole},
+			},
+		}
+		assert.True(t, u.CanAccessWorkspace(rbac.ApplyRunAction, policy))
+	})
+
+	t.Run("project permission grants more than workspace permission", func(t *testing.T) {
+		policy := internal.WorkspacePolicy{
+			Organization: "acme-corp",
+			Permissions: []internal.WorkspacePermission{
+				{Team: "devops", Role: rbac.WorkspaceReadRole},
+			},
+			ProjectPermissions: []internal.WorkspacePermission{
+				{Team: "devops", Role: rbac.WorkspaceAdminRole},
+			},
+		}
+		assert.True(t, u.CanAccessWorkspace(rbac.DeleteWorkspaceAction, policy))
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		policy := internal.WorkspacePolicy{
+			Organization: "acme-corp",
+			ProjectPermissions: []internal.WorkspacePermission{
+				{Team: "devops", Role: rbac.WorkspaceReadRole},
+			},
+		}
+		assert.False(t, u.CanAccessWorkspace(rbac.ApplyRunAction, policy))
+	})
+}
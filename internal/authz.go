@@ -32,10 +32,15 @@ type WorkspacePolicy struct {
 	Organization string
 	WorkspaceID  string
 	Permissions  []WorkspacePermission
+	// ProjectPermissions are permissions granted on the workspace's project,
+	// which apply to all workspaces in the project.
+	ProjectPermissions []WorkspacePermission
 
 	// Whether workspace permits its state to be consumed by all workspaces in
 	// the organization.
 	GlobalRemoteState bool
+	// IDs of workspaces explicitly permitted to consume the workspace's state.
+	RemoteStateConsumers []string
 }
 
 // WorkspacePermission binds a role to a team.
@@ -49,6 +49,7 @@ func (a *CLI) Run(ctx context.Context, args []string, out io.Writer) error {
 	cmd.AddCommand(a.runCommand())
 	cmd.AddCommand(a.agentCommand())
 	cmd.AddCommand(a.stateCommand())
+	cmd.AddCommand(a.resourceCommand())
 
 	if err := cmdutil.SetFlagsFromEnvVariables(cmd.Flags()); err != nil {
 		return errors.Wrap(err, "failed to populate config from environment vars")
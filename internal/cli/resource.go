@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/leg100/otf/internal/inventory"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/spf13/cobra"
+)
+
+func (a *CLI) resourceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Short: "Resource inventory",
+	}
+
+	cmd.AddCommand(a.resourceSearchCommand())
+
+	return cmd
+}
+
+func (a *CLI) resourceSearchCommand() *cobra.Command {
+	var (
+		organization string
+		opts         inventory.SearchOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:           "search [query]",
+		Short:         "Search resources managed by an organization's workspaces",
+		Long:          "Search resources managed by an organization's workspaces. The optional query matches resources whose address or the value of one of their attributes contains the query.",
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Search = args[0]
+			}
+			list, err := resource.ListAll(func(pageOpts resource.PageOptions) (*resource.Page[*inventory.Resource], error) {
+				opts.PageOptions = pageOpts
+				return a.SearchResources(cmd.Context(), organization, opts)
+			})
+			if err != nil {
+				return fmt.Errorf("searching resources: %w", err)
+			}
+			if len(list) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No resources found")
+				return nil
+			}
+			for _, res := range list {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s", res.WorkspaceName, res.Address, res.Provider)
+				if res.ProviderVersion != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), " v%s", *res.ProviderVersion)
+				}
+				if res.RemoteID != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "\t%s", *res.RemoteID)
+				}
+				fmt.Fprintln(cmd.OutOrStdout())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "Name of the organization to search")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&opts.Type, "type", "", "Filter by resource type, e.g. aws_s3_bucket")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "Filter by provider name or source address, e.g. aws")
+	cmd.Flags().StringVar(&opts.Module, "module", "", "Filter by module address, e.g. module.vpc")
+	cmd.Flags().StringVar(&opts.ProviderVersionBelow, "provider-version-below", "", "Filter by resources managed with a provider older than the given version, e.g. 5.0")
+	cmd.Flags().StringSliceVar(&opts.Tags, "tag", nil, "Filter by workspace tag; may be specified more than once")
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLI_ResourceSearch(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *CLI
+		want string
+	}{
+		{
+			"two resources",
+			fakeApp(withResources(
+				&inventory.Resource{
+					WorkspaceName:   "dev",
+					Address:         "aws_s3_bucket.logs",
+					Provider:        "registry.terraform.io/hashicorp/aws",
+					ProviderVersion: internal.String("4.67.0"),
+					RemoteID:        internal.String("acme-logs"),
+				},
+				&inventory.Resource{
+					WorkspaceName: "prod",
+					Address:       "random_pet.name",
+					Provider:      "registry.terraform.io/hashicorp/random",
+				},
+			)),
+			"dev\taws_s3_bucket.logs\tregistry.terraform.io/hashicorp/aws v4.67.0\tacme-logs\nprod\trandom_pet.name\tregistry.terraform.io/hashicorp/random\n",
+		},
+		{
+			"no resources",
+			fakeApp(),
+			"No resources found\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.app.resourceSearchCommand()
+
+			cmd.SetArgs([]string{"acme", "--organization", "acme-corp", "--provider", "aws"})
+			got := bytes.Buffer{}
+			cmd.SetOut(&got)
+			require.NoError(t, cmd.Execute())
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
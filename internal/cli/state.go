@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +25,13 @@ func (a *CLI) stateCommand() *cobra.Command {
 	cmd.AddCommand(a.stateListCommand())
 	cmd.AddCommand(a.stateDeleteCommand())
 	cmd.AddCommand(a.stateDownloadCommand())
+	cmd.AddCommand(a.stateDiffCommand())
+	cmd.AddCommand(a.stateMoveCommand())
+	cmd.AddCommand(a.stateRemoveCommand())
+	cmd.AddCommand(a.stateTaintCommand())
+	cmd.AddCommand(a.statePinCommand())
+	cmd.AddCommand(a.stateUnpinCommand())
+	cmd.AddCommand(a.statePrunableCommand())
 
 	return cmd
 }
@@ -63,6 +73,9 @@ func (a *CLI) stateListCommand() *cobra.Command {
 				if current.ID == sv.ID {
 					fmt.Fprintf(out, " (current)")
 				}
+				if sv.Pinned {
+					fmt.Fprintf(out, " (pinned)")
+				}
 				fmt.Fprintln(out)
 			}
 			return nil
@@ -117,6 +130,70 @@ func (a *CLI) stateDownloadCommand() *cobra.Command {
 	}
 }
 
+func (a *CLI) stateDiffCommand() *cobra.Command {
+	var from string
+	cmd := &cobra.Command{
+		Use:           "diff [id]",
+		Short:         "Show differences between state versions",
+		Long:          "Show differences between a state version and an earlier state version. If --from is not specified then the state version is compared with its preceding state version.",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			diff, err := a.DiffStateVersions(cmd.Context(), from, args[0])
+			if err != nil {
+				return err
+			}
+			printStateDiff(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "ID of earlier state version to compare with")
+
+	return cmd
+}
+
+// printStateDiff prints a state diff in a format similar to a terraform plan.
+func printStateDiff(w io.Writer, diff *state.Diff) {
+	if diff.Empty() {
+		fmt.Fprintln(w, "No differences found")
+		return
+	}
+	symbols := map[state.DiffAction]string{
+		state.DiffAdded:   "+",
+		state.DiffRemoved: "-",
+		state.DiffChanged: "~",
+	}
+	for _, rd := range diff.Resources {
+		fmt.Fprintf(w, "%s %s\n", symbols[rd.Action], rd.Address)
+		for _, attr := range rd.Attributes {
+			switch rd.Action {
+			case state.DiffAdded:
+				fmt.Fprintf(w, "    %s: %s\n", attr.Path, attr.After)
+			case state.DiffRemoved:
+				fmt.Fprintf(w, "    %s: %s\n", attr.Path, attr.Before)
+			default:
+				fmt.Fprintf(w, "    %s: %s => %s\n", attr.Path, attr.Before, attr.After)
+			}
+		}
+	}
+	if len(diff.Outputs) > 0 {
+		fmt.Fprintln(w, "Outputs:")
+		for _, od := range diff.Outputs {
+			switch od.Action {
+			case state.DiffAdded:
+				fmt.Fprintf(w, "%s %s: %s\n", symbols[od.Action], od.Name, od.After)
+			case state.DiffRemoved:
+				fmt.Fprintf(w, "%s %s: %s\n", symbols[od.Action], od.Name, od.Before)
+			default:
+				fmt.Fprintf(w, "%s %s: %s => %s\n", symbols[od.Action], od.Name, od.Before, od.After)
+			}
+		}
+	}
+	fmt.Fprintf(w, "\n%d added, %d changed, %d removed\n", diff.Count(state.DiffAdded), diff.Count(state.DiffChanged), diff.Count(state.DiffRemoved))
+}
+
 func (a *CLI) stateRollbackCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:           "rollback [id]",
@@ -134,3 +211,156 @@ func (a *CLI) stateRollbackCommand() *cobra.Command {
 		},
 	}
 }
+
+func (a *CLI) statePinCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "pin [id]",
+		Short:         "Pin a state version, exempting it from retention policies and preventing its deletion",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := a.PinStateVersion(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Pinned state version: %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func (a *CLI) stateUnpinCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "unpin [id]",
+		Short:         "Unpin a state version",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := a.UnpinStateVersion(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Unpinned state version: %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func (a *CLI) statePrunableCommand() *cobra.Command {
+	var opts state.StateVersionListOptions
+	cmd := &cobra.Command{
+		Use:           "prunable",
+		Short:         "List state versions that the workspace's retention policy would prune",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workspace, err := a.GetWorkspaceByName(cmd.Context(), opts.Organization, opts.Workspace)
+			if err != nil {
+				return err
+			}
+			versions, err := a.ListPrunableStateVersions(cmd.Context(), workspace.ID)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if len(versions) == 0 {
+				fmt.Fprintln(out, "No state versions would be pruned")
+				return nil
+			}
+			for _, sv := range versions {
+				fmt.Fprintf(out, "%s (serial %d, created %s)\n", sv.ID, sv.Serial, sv.CreatedAt.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Organization, "organization", "", "Name of the organization the workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Name of the workspace")
+	cmd.MarkFlagRequired("workspace")
+
+	return cmd
+}
+
+func (a *CLI) stateMoveCommand() *cobra.Command {
+	return a.stateOperationCommand(&cobra.Command{
+		Use:   "mv [source] [destination]",
+		Short: "Move a resource to a different address",
+		Args:  cobra.ExactArgs(2),
+	}, stateop.MoveKind)
+}
+
+func (a *CLI) stateRemoveCommand() *cobra.Command {
+	return a.stateOperationCommand(&cobra.Command{
+		Use:   "rm [address]",
+		Short: "Remove a resource from state",
+		Args:  cobra.ExactArgs(1),
+	}, stateop.RemoveKind)
+}
+
+func (a *CLI) stateTaintCommand() *cobra.Command {
+	return a.stateOperationCommand(&cobra.Command{
+		Use:   "taint [address]",
+		Short: "Mark a resource as tainted, forcing it to be replaced on the next apply",
+		Args:  cobra.ExactArgs(1),
+	}, stateop.TaintKind)
+}
+
+// stateOperationCommand completes a command that performs an operation on a
+// workspace's current state.
+func (a *CLI) stateOperationCommand(cmd *cobra.Command, kind stateop.Kind) *cobra.Command {
+	var (
+		organization string
+		workspace    string
+		reason       string
+	)
+
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ws, err := a.GetWorkspaceByName(cmd.Context(), organization, workspace)
+		if err != nil {
+			return err
+		}
+		opts := stateop.CreateOptions{
+			Kind:    kind,
+			Address: args[0],
+			Reason:  reason,
+		}
+		if kind == stateop.MoveKind {
+			opts.Destination = &args[1]
+		}
+		op, err := a.CreateStateOperation(cmd.Context(), ws.ID, opts)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		switch kind {
+		case stateop.MoveKind:
+			fmt.Fprintf(out, "Moved %s to %s\n", op.Address, *op.Destination)
+		case stateop.RemoveKind:
+			fmt.Fprintf(out, "Removed %s\n", op.Address)
+		case stateop.TaintKind:
+			fmt.Fprintf(out, "Tainted %s\n", op.Address)
+		}
+		fmt.Fprintf(out, "Created state version: %s\n", *op.StateVersionID)
+		if op.PreviousStateVersionID != nil {
+			fmt.Fprintf(out, "To revert, run: otf state rollback %s\n", *op.PreviousStateVersionID)
+		}
+		return nil
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "Name of the organization the workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Name of the workspace")
+	cmd.MarkFlagRequired("workspace")
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason for the operation, recorded in the workspace's state history")
+	cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
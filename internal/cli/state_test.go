@@ -2,7 +2,9 @@ package cli
 
 import (
 	"bytes"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/state"
@@ -29,14 +31,14 @@ func TestCLI_State(t *testing.T) {
 					withStateVersionList(resource.NewPage(
 						[]*state.Version{
 							{ID: "sv-3"},
-							{ID: "sv-2"},
+							{ID: "sv-2", Pinned: true},
 							{ID: "sv-1"},
 						},
 						resource.PageOptions{},
 						nil,
 					)),
 				),
-				"sv-3 (current)\nsv-2\nsv-1\n",
+				"sv-3 (current)\nsv-2 (pinned)\nsv-1\n",
 			},
 			{
 				"zero state versions",
@@ -70,6 +72,70 @@ func TestCLI_State(t *testing.T) {
 		assert.Equal(t, want, got.String())
 	})
 
+	t.Run("pin", func(t *testing.T) {
+		cmd := fakeApp().statePinCommand()
+
+		cmd.SetArgs([]string{"sv-123"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "Pinned state version: sv-123\n", got.String())
+	})
+
+	t.Run("unpin", func(t *testing.T) {
+		cmd := fakeApp().stateUnpinCommand()
+
+		cmd.SetArgs([]string{"sv-123"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "Unpinned state version: sv-123\n", got.String())
+	})
+
+	t.Run("prunable", func(t *testing.T) {
+		created := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+		tests := []struct {
+			name string
+			app  *CLI
+			want string
+		}{
+			{
+				"two prunable state versions",
+				fakeApp(
+					withWorkspaces(&workspace.Workspace{ID: "ws-123"}),
+					withStateVersionList(resource.NewPage(
+						[]*state.Version{
+							{ID: "sv-2", Serial: 2, CreatedAt: created},
+							{ID: "sv-1", Serial: 1, CreatedAt: created},
+						},
+						resource.PageOptions{},
+						nil,
+					)),
+				),
+				"sv-2 (serial 2, created 2023-09-01T12:00:00Z)\nsv-1 (serial 1, created 2023-09-01T12:00:00Z)\n",
+			},
+			{
+				"nothing to prune",
+				fakeApp(withWorkspaces(&workspace.Workspace{ID: "ws-123"})),
+				"No state versions would be pruned\n",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cmd := tt.app.statePrunableCommand()
+
+				cmd.SetArgs([]string{"--organization", "acme-corp", "--workspace", "dev"})
+				got := bytes.Buffer{}
+				cmd.SetOut(&got)
+				require.NoError(t, cmd.Execute())
+
+				assert.Equal(t, tt.want, got.String())
+			})
+		}
+	})
+
 	t.Run("download", func(t *testing.T) {
 		want := testutils.ReadFile(t, "./testdata/terraform.tfstate")
 		cmd := fakeApp(withState(want)).stateDownloadCommand()
@@ -82,6 +148,56 @@ func TestCLI_State(t *testing.T) {
 		assert.JSONEq(t, string(want), got.String())
 	})
 
+	t.Run("diff", func(t *testing.T) {
+		diff := &state.Diff{
+			FromID: "sv-1",
+			ToID:   "sv-2",
+			Resources: []*state.ResourceDiff{
+				{
+					Address:    "random_pet.pet",
+					Action:     state.DiffChanged,
+					Attributes: []*state.AttributeDiff{{Path: "length", Before: "2", After: "3"}},
+				},
+				{
+					Address:    "aws_iam_role.x",
+					Action:     state.DiffAdded,
+					Attributes: []*state.AttributeDiff{{Path: "name", After: "x"}},
+				},
+			},
+			Outputs: []*state.OutputDiff{
+				{Name: "password", Action: state.DiffChanged, Before: "(sensitive value)", After: "(sensitive value)"},
+			},
+		}
+		cmd := fakeApp(withStateDiff(diff)).stateDiffCommand()
+
+		cmd.SetArgs([]string{"sv-2", "--from", "sv-1"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := `~ random_pet.pet
+    length: 2 => 3
++ aws_iam_role.x
+    name: x
+Outputs:
+~ password: (sensitive value) => (sensitive value)
+
+1 added, 1 changed, 0 removed
+`
+		assert.Equal(t, want, got.String())
+	})
+
+	t.Run("diff without differences", func(t *testing.T) {
+		cmd := fakeApp(withStateDiff(&state.Diff{ToID: "sv-2"})).stateDiffCommand()
+
+		cmd.SetArgs([]string{"sv-2"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "No differences found\n", got.String())
+	})
+
 	t.Run("rollback", func(t *testing.T) {
 		sv := &state.Version{ID: "sv-456"}
 		cmd := fakeApp(withStateVersion(sv)).stateRollbackCommand()
@@ -93,4 +209,36 @@ func TestCLI_State(t *testing.T) {
 
 		assert.Equal(t, "Successfully rolled back state\n", got.String())
 	})
+
+	t.Run("mv", func(t *testing.T) {
+		cmd := fakeApp(withWorkspaces(&workspace.Workspace{ID: "ws-123"})).stateMoveCommand()
+
+		cmd.SetArgs([]string{"aws_instance.web", "aws_instance.app", "--organization", "acme-corp", "--workspace", "dev", "--reason", "renamed"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := "Moved aws_instance.web to aws_instance.app\nCreated state version: sv-2\nTo revert, run: otf state rollback sv-1\n"
+		assert.Equal(t, want, got.String())
+	})
+
+	t.Run("rm", func(t *testing.T) {
+		cmd := fakeApp(withWorkspaces(&workspace.Workspace{ID: "ws-123"})).stateRemoveCommand()
+
+		cmd.SetArgs([]string{"aws_instance.web[0]", "--organization", "acme-corp", "--workspace", "dev", "--reason", "managed elsewhere"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := "Removed aws_instance.web[0]\nCreated state version: sv-2\nTo revert, run: otf state rollback sv-1\n"
+		assert.Equal(t, want, got.String())
+	})
+
+	t.Run("taint requires reason", func(t *testing.T) {
+		cmd := fakeApp(withWorkspaces(&workspace.Workspace{ID: "ws-123"})).stateTaintCommand()
+
+		cmd.SetArgs([]string{"aws_instance.web", "--organization", "acme-corp", "--workspace", "dev"})
+		cmd.SetOut(io.Discard)
+		assert.Error(t, cmd.Execute())
+	})
 }
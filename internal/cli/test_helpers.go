@@ -6,10 +6,12 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/client"
+	"github.com/leg100/otf/internal/inventory"
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/workspace"
@@ -24,6 +26,9 @@ type (
 		stateVersion     *state.Version
 		stateVersionList *resource.Page[*state.Version]
 		state            []byte
+		stateDiff        *state.Diff
+		resources        []*inventory.Resource
+		lockEvents       []*workspace.LockEvent
 		agentToken       []byte
 		tarball          []byte
 		client.Client
@@ -82,6 +87,24 @@ func withState(state []byte) fakeOption {
 	}
 }
 
+func withStateDiff(diff *state.Diff) fakeOption {
+	return func(c *fakeClient) {
+		c.stateDiff = diff
+	}
+}
+
+func withLockEvents(events ...*workspace.LockEvent) fakeOption {
+	return func(c *fakeClient) {
+		c.lockEvents = events
+	}
+}
+
+func withResources(resources ...*inventory.Resource) fakeOption {
+	return func(c *fakeClient) {
+		c.resources = resources
+	}
+}
+
 func withAgentToken(token []byte) fakeOption {
 	return func(c *fakeClient) {
 		c.agentToken = token
@@ -151,10 +174,18 @@ func (f *fakeClient) UpdateWorkspace(ctx context.Context, workspaceID string, op
 	return f.workspaces[0], nil
 }
 
-func (f *fakeClient) LockWorkspace(context.Context, string, *string) (*workspace.Workspace, error) {
+func (f *fakeClient) CloneWorkspace(ctx context.Context, workspaceID string, opts workspace.CloneOptions) (*workspace.Workspace, error) {
+	return &workspace.Workspace{ID: "ws-clone", Name: *opts.Name, Organization: f.workspaces[0].Organization}, nil
+}
+
+func (f *fakeClient) LockWorkspace(context.Context, string, *string, workspace.LockOptions) (*workspace.Workspace, error) {
 	return f.workspaces[0], nil
 }
 
+func (f *fakeClient) ListWorkspaceLockEvents(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*workspace.LockEvent], error) {
+	return resource.NewPage(f.lockEvents, opts, nil), nil
+}
+
 func (f *fakeClient) UnlockWorkspace(context.Context, string, *string, bool) (*workspace.Workspace, error) {
 	return f.workspaces[0], nil
 }
@@ -193,3 +224,38 @@ func (f *fakeClient) RollbackStateVersion(ctx context.Context, svID string) (*st
 func (f *fakeClient) DownloadState(ctx context.Context, svID string) ([]byte, error) {
 	return f.state, nil
 }
+
+func (f *fakeClient) DiffStateVersions(ctx context.Context, fromID, toID string) (*state.Diff, error) {
+	return f.stateDiff, nil
+}
+
+func (f *fakeClient) SearchResources(ctx context.Context, organization string, opts inventory.SearchOptions) (*resource.Page[*inventory.Resource], error) {
+	return resource.NewPage(f.resources, opts.PageOptions, nil), nil
+}
+
+func (f *fakeClient) PinStateVersion(ctx context.Context, svID string) (*state.Version, error) {
+	return &state.Version{ID: svID, Pinned: true}, nil
+}
+
+func (f *fakeClient) UnpinStateVersion(ctx context.Context, svID string) (*state.Version, error) {
+	return &state.Version{ID: svID}, nil
+}
+
+func (f *fakeClient) ListPrunableStateVersions(ctx context.Context, workspaceID string) ([]*state.Version, error) {
+	if f.stateVersionList == nil {
+		return nil, nil
+	}
+	return f.stateVersionList.Items, nil
+}
+
+func (f *fakeClient) CreateStateOperation(ctx context.Context, workspaceID string, opts stateop.CreateOptions) (*stateop.Operation, error) {
+	return &stateop.Operation{
+		WorkspaceID:            workspaceID,
+		Kind:                   opts.Kind,
+		Address:                opts.Address,
+		Destination:            opts.Destination,
+		Reason:                 opts.Reason,
+		StateVersionID:         internal.String("sv-2"),
+		PreviousStateVersionID: internal.String("sv-1"),
+	}, nil
+}
@@ -3,7 +3,9 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/workspace"
 	"github.com/spf13/cobra"
@@ -20,6 +22,8 @@ func (a *CLI) workspaceCommand() *cobra.Command {
 	cmd.AddCommand(a.workspaceEditCommand())
 	cmd.AddCommand(a.workspaceLockCommand())
 	cmd.AddCommand(a.workspaceUnlockCommand())
+	cmd.AddCommand(a.workspaceLockHistoryCommand())
+	cmd.AddCommand(a.workspaceCloneCommand())
 
 	return cmd
 }
@@ -135,7 +139,11 @@ func (a *CLI) workspaceEditCommand() *cobra.Command {
 }
 
 func (a *CLI) workspaceLockCommand() *cobra.Command {
-	var organization string
+	var (
+		organization string
+		reason       string
+		expiresIn    time.Duration
+	)
 
 	cmd := &cobra.Command{
 		Use:           "lock [name]",
@@ -144,13 +152,15 @@ func (a *CLI) workspaceLockCommand() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			workspace := args[0]
-
-			ws, err := a.GetWorkspaceByName(cmd.Context(), organization, workspace)
+			ws, err := a.GetWorkspaceByName(cmd.Context(), organization, args[0])
 			if err != nil {
 				return err
 			}
-			ws, err = a.LockWorkspace(cmd.Context(), ws.ID, nil)
+			opts := workspace.LockOptions{Reason: reason}
+			if expiresIn > 0 {
+				opts.ExpiresAt = internal.Time(time.Now().Add(expiresIn))
+			}
+			ws, err = a.LockWorkspace(cmd.Context(), ws.ID, nil, opts)
 			if err != nil {
 				return err
 			}
@@ -162,6 +172,8 @@ func (a *CLI) workspaceLockCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&organization, "organization", "", "Organization workspace belongs to")
 	cmd.MarkFlagRequired("organization")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason for locking the workspace")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "Release the lock automatically after this duration, e.g. 2h")
 
 	return cmd
 }
@@ -202,3 +214,84 @@ func (a *CLI) workspaceUnlockCommand() *cobra.Command {
 
 	return cmd
 }
+
+func (a *CLI) workspaceLockHistoryCommand() *cobra.Command {
+	var organization string
+
+	cmd := &cobra.Command{
+		Use:           "lock-history [name]",
+		Short:         "Show the history of a workspace's lock",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := a.GetWorkspaceByName(cmd.Context(), organization, args[0])
+			if err != nil {
+				return err
+			}
+			events, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*workspace.LockEvent], error) {
+				return a.ListWorkspaceLockEvents(cmd.Context(), ws.ID, opts)
+			})
+			if err != nil {
+				return fmt.Errorf("listing lock events: %w", err)
+			}
+			out := cmd.OutOrStdout()
+			for _, event := range events {
+				fmt.Fprintf(out, "%s %s %s lock held by %s", event.CreatedAt.Format(time.RFC3339), event.Action, event.Kind, event.Holder)
+				if event.Actor != nil && *event.Actor != event.Holder {
+					fmt.Fprintf(out, " by %s", *event.Actor)
+				}
+				if event.Reason != "" {
+					fmt.Fprintf(out, ": %s", event.Reason)
+				}
+				fmt.Fprintln(out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "Organization workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+
+	return cmd
+}
+
+func (a *CLI) workspaceCloneCommand() *cobra.Command {
+	var (
+		organization string
+		opts         workspace.CloneOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:           "clone [name] [new-name]",
+		Short:         "Clone a workspace",
+		Long:          "Create a new workspace with the same settings and VCS connection as an existing workspace, optionally copying its variables, team permissions, notifications and tags.",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := a.GetWorkspaceByName(cmd.Context(), organization, args[0])
+			if err != nil {
+				return err
+			}
+			opts.Name = &args[1]
+			clone, err := a.CloneWorkspace(cmd.Context(), ws.ID, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully cloned workspace %s to %s (%s)\n", ws.Name, clone.Name, clone.ID)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "Organization workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+	cmd.Flags().BoolVar(&opts.Variables, "variables", false, "Copy non-sensitive variables")
+	cmd.Flags().BoolVar(&opts.SensitiveVariables, "sensitive-variables", false, "Copy sensitive variables")
+	cmd.Flags().BoolVar(&opts.Permissions, "permissions", false, "Copy team permissions")
+	cmd.Flags().BoolVar(&opts.Notifications, "notifications", false, "Copy notification configurations")
+	cmd.Flags().BoolVar(&opts.Tags, "tags", false, "Copy tags")
+
+	return cmd
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
@@ -130,3 +131,35 @@ func TestWorkspaceUnlock(t *testing.T) {
 		assert.EqualError(t, err, "required flag(s) \"organization\" not set")
 	})
 }
+
+func TestWorkspaceLockHistory(t *testing.T) {
+	ws := &workspace.Workspace{ID: "ws-123"}
+	bob := "bob"
+	createdAt := time.Date(2023, 9, 18, 12, 0, 0, 0, time.UTC)
+	app := fakeApp(withWorkspaces(ws), withLockEvents(
+		&workspace.LockEvent{CreatedAt: createdAt, Action: workspace.LockEventExpired, Kind: workspace.UserLock, Holder: bob, Reason: "upgrading"},
+		&workspace.LockEvent{CreatedAt: createdAt, Action: workspace.LockEventLocked, Kind: workspace.UserLock, Holder: bob, Actor: &bob, Reason: "upgrading"},
+	))
+
+	cmd := app.workspaceLockHistoryCommand()
+	cmd.SetArgs([]string{"dev", "--organization", "acme-corp"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+	want := `2023-09-18T12:00:00Z expired user lock held by bob: upgrading
+2023-09-18T12:00:00Z locked user lock held by bob: upgrading
+`
+	assert.Equal(t, want, got.String())
+}
+
+func TestWorkspaceClone(t *testing.T) {
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme-corp"}
+	app := fakeApp(withWorkspaces(ws))
+
+	cmd := app.workspaceCloneCommand()
+	cmd.SetArgs([]string{"dev", "staging", "--organization", "acme-corp", "--variables", "--tags"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Successfully cloned workspace dev to staging (ws-clone)\n", got.String())
+}
@@ -9,12 +9,14 @@ import (
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/inventory"
 	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/workspace"
@@ -37,6 +39,7 @@ type (
 		GetWorkspaceByName(ctx context.Context, organization, workspace string) (*workspace.Workspace, error)
 		ListWorkspaces(ctx context.Context, opts workspace.ListOptions) (*resource.Page[*workspace.Workspace], error)
 		UpdateWorkspace(ctx context.Context, workspaceID string, opts workspace.UpdateOptions) (*workspace.Workspace, error)
+		CloneWorkspace(ctx context.Context, workspaceID string, opts workspace.CloneOptions) (*workspace.Workspace, error)
 
 		ListVariables(ctx context.Context, workspaceID string) ([]*variable.Variable, error)
 
@@ -49,6 +52,10 @@ type (
 		GetLockFile(ctx context.Context, id string) ([]byte, error)
 		UploadLockFile(ctx context.Context, id string, lockFile []byte) error
 
+		UploadStructuredOutput(ctx context.Context, id string, phase internal.PhaseType, output []byte) error
+
+		UploadTestResults(ctx context.Context, id string, output []byte) error
+
 		ListRuns(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error)
 		GetRun(ctx context.Context, id string) (*run.Run, error)
 
@@ -66,6 +73,13 @@ type (
 		DeleteStateVersion(ctx context.Context, svID string) error
 		DownloadState(ctx context.Context, svID string) ([]byte, error)
 		ListStateVersions(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*state.Version], error)
+		DiffStateVersions(ctx context.Context, fromID, toID string) (*state.Diff, error)
+		PinStateVersion(ctx context.Context, svID string) (*state.Version, error)
+		UnpinStateVersion(ctx context.Context, svID string) (*state.Version, error)
+		ListPrunableStateVersions(ctx context.Context, workspaceID string) ([]*state.Version, error)
+		CreateStateOperation(ctx context.Context, workspaceID string, opts stateop.CreateOptions) (*stateop.Operation, error)
+
+		SearchResources(ctx context.Context, organization string, opts inventory.SearchOptions) (*resource.Page[*inventory.Resource], error)
 
 		CreateUser(ctx context.Context, username string, opts ...auth.NewUserOption) (*auth.User, error)
 		DeleteUser(ctx context.Context, username string) error
@@ -89,6 +103,8 @@ type (
 		tokens.TokensService
 		variable.VariableService
 		state.StateService
+		inventory.InventoryService
+		stateop.StateOperationService
 		workspace.WorkspaceService
 		internal.HostnameService
 		configversion.ConfigurationVersionService
@@ -101,6 +117,8 @@ type (
 		http.Config
 
 		*stateClient
+		*inventoryClient
+		*stateOperationClient
 		*configClient
 		*variableClient
 		*authClient
@@ -111,15 +129,17 @@ type (
 		*logsClient
 	}
 
-	stateClient        = state.Client
-	configClient       = configversion.Client
-	variableClient     = variable.Client
-	authClient         = auth.Client
-	tokensClient       = tokens.Client
-	organizationClient = organization.Client
-	workspaceClient    = workspace.Client
-	runClient          = run.Client
-	logsClient         = logs.Client
+	stateClient          = state.Client
+	inventoryClient      = inventory.Client
+	stateOperationClient = stateop.Client
+	configClient         = configversion.Client
+	variableClient       = variable.Client
+	authClient           = auth.Client
+	tokensClient         = tokens.Client
+	organizationClient   = organization.Client
+	workspaceClient      = workspace.Client
+	runClient            = run.Client
+	logsClient           = logs.Client
 )
 
 // New constructs a client that uses the http to remotely invoke OTF
@@ -131,15 +151,17 @@ func New(config http.Config) (*remoteClient, error) {
 	}
 
 	return &remoteClient{
-		Client:             httpClient,
-		stateClient:        &stateClient{JSONAPIClient: httpClient},
-		configClient:       &configClient{JSONAPIClient: httpClient},
-		variableClient:     &variableClient{JSONAPIClient: httpClient},
-		authClient:         &authClient{JSONAPIClient: httpClient},
-		tokensClient:       &tokensClient{JSONAPIClient: httpClient},
-		organizationClient: &organizationClient{JSONAPIClient: httpClient},
-		workspaceClient:    &workspaceClient{JSONAPIClient: httpClient},
-		runClient:          &runClient{JSONAPIClient: httpClient, Config: config},
-		logsClient:         &logsClient{JSONAPIClient: httpClient},
+		Client:               httpClient,
+		stateClient:          &stateClient{JSONAPIClient: httpClient},
+		inventoryClient:      &inventoryClient{JSONAPIClient: httpClient},
+		stateOperationClient: &stateOperationClient{JSONAPIClient: httpClient},
+		configClient:         &configClient{JSONAPIClient: httpClient},
+		variableClient:       &variableClient{JSONAPIClient: httpClient},
+		authClient:           &authClient{JSONAPIClient: httpClient},
+		tokensClient:         &tokensClient{JSONAPIClient: httpClient},
+		organizationClient:   &organizationClient{JSONAPIClient: httpClient},
+		workspaceClient:      &workspaceClient{JSONAPIClient: httpClient},
+		runClient:            &runClient{JSONAPIClient: httpClient, Config: config},
+		logsClient:           &logsClient{JSONAPIClient: httpClient},
 	}, nil
 }
@@ -25,6 +25,14 @@ type (
 		ListTags(ctx context.Context, opts ListTagsOptions) ([]string, error)
 		// ListPullRequestFiles returns the paths of files that are modified in the pull request
 		ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error)
+		// CreatePullRequestComment adds a comment to a pull request, returning
+		// the provider's unique ID for the comment.
+		CreatePullRequestComment(ctx context.Context, opts CreatePullRequestCommentOptions) (string, error)
+		// UpdatePullRequestComment replaces the body of an existing comment on
+		// a pull request.
+		UpdatePullRequestComment(ctx context.Context, opts UpdatePullRequestCommentOptions) error
+		// GetPullRequest retrieves a pull request
+		GetPullRequest(ctx context.Context, repo string, pull int) (PullRequest, error)
 		// GetCommit retrieves commit from the repo with the given git ref
 		GetCommit(ctx context.Context, repo, ref string) (Commit, error)
 	}
@@ -90,6 +98,31 @@ type (
 		Description string
 	}
 
+	// CreatePullRequestCommentOptions are options for commenting on a pull
+	// request
+	CreatePullRequestCommentOptions struct {
+		Repo        string // <owner>/<repo>
+		PullRequest int    // pull request number
+		Body        string // markdown
+	}
+
+	// UpdatePullRequestCommentOptions are options for updating a comment on a
+	// pull request
+	UpdatePullRequestCommentOptions struct {
+		ID string // provider's comment ID
+		CreatePullRequestCommentOptions
+	}
+
+	// PullRequest is a pull request, or a merge request in the case of gitlab.
+	PullRequest struct {
+		Number     int
+		Title      string
+		URL        string // web URL
+		HeadBranch string // branch containing the changes
+		HeadSHA    string // latest commit on the head branch
+		Open       bool
+	}
+
 	Repository struct {
 		Path          string
 		DefaultBranch string
@@ -8,6 +8,7 @@ const (
 	VCSEventTypePull VCSEventType = iota
 	VCSEventTypePush
 	VCSEventTypeTag
+	VCSEventTypeComment
 
 	VCSActionCreated VCSAction = iota
 	VCSActionDeleted
@@ -47,6 +48,10 @@ type (
 		SenderAvatarURL string
 		SenderHTMLURL   string
 
+		// Comment is the body of a comment. Only applicable to Comment event
+		// types, which are comments made on pull requests.
+		Comment string
+
 		// Paths of files that have been added/modified/removed. Only applicable
 		// to Push and Tag events types.
 		Paths []string
@@ -11,6 +11,7 @@ import (
 	"github.com/leg100/otf/internal/github"
 	"github.com/leg100/otf/internal/gitlab"
 	"github.com/leg100/otf/internal/inmem"
+	"github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/tokens"
 )
 
@@ -37,6 +38,7 @@ type Config struct {
 	DisableScheduler             bool
 	RestrictOrganizationCreation bool
 	SiteAdmins                   []string
+	PhaseTimeouts                run.PhaseTimeouts
 
 	tokens.GoogleIAPConfig
 }
@@ -47,6 +49,12 @@ func ApplyDefaults(cfg *Config) {
 			Concurrency: agent.DefaultConcurrency,
 		}
 	}
+	if cfg.PhaseTimeouts.Plan == 0 {
+		cfg.PhaseTimeouts.Plan = run.DefaultPlanTimeout
+	}
+	if cfg.PhaseTimeouts.Apply == 0 {
+		cfg.PhaseTimeouts.Apply = run.DefaultApplyTimeout
+	}
 	if cfg.CacheConfig == nil {
 		cfg.CacheConfig = &inmem.CacheConfig{}
 	}
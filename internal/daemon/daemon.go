@@ -20,18 +20,23 @@ import (
 	"github.com/leg100/otf/internal/disco"
 	"github.com/leg100/otf/internal/http"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/httpbackend"
 	"github.com/leg100/otf/internal/inmem"
+	"github.com/leg100/otf/internal/inventory"
 	"github.com/leg100/otf/internal/loginserver"
 	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/module"
 	"github.com/leg100/otf/internal/notifications"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/repo"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtask"
 	"github.com/leg100/otf/internal/scheduler"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/stateop"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/vcsprovider"
@@ -53,6 +58,9 @@ type (
 		variable.VariableService
 		vcsprovider.VCSProviderService
 		state.StateService
+		inventory.InventoryService
+		stateop.StateOperationService
+		project.ProjectService
 		workspace.WorkspaceService
 		module.ModuleService
 		internal.HostnameService
@@ -61,11 +69,13 @@ type (
 		repo.RepoService
 		logs.LogsService
 		notifications.NotificationService
+		runtask.RunTaskService
 
 		Handlers []internal.Handlers
 
 		agent        process
 		cloudService *inmem.CloudService
+		signer       internal.Signer
 	}
 
 	process interface {
@@ -162,6 +172,14 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		VCSProviderService:  vcsProviderService,
 	})
 
+	projectService := project.NewService(project.Options{
+		Logger:              logger,
+		DB:                  db,
+		Renderer:            renderer,
+		OrganizationService: orgService,
+		TeamService:         authService,
+	})
+
 	workspaceService := workspace.NewService(workspace.Options{
 		Logger:              logger,
 		DB:                  db,
@@ -171,6 +189,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		TeamService:         authService,
 		OrganizationService: orgService,
 		VCSProviderService:  vcsProviderService,
+		ProjectService:      projectService,
 	})
 	configService := configversion.NewService(configversion.Options{
 		Logger:              logger,
@@ -179,6 +198,34 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		Cache:               cache,
 		Signer:              signer,
 	})
+	stateService := state.NewService(state.Options{
+		Logger:              logger,
+		DB:                  db,
+		WorkspaceAuthorizer: workspaceService,
+		Cache:               cache,
+		Renderer:            renderer,
+	})
+	inventoryService := inventory.NewService(inventory.Options{
+		Logger:       logger,
+		DB:           db,
+		Renderer:     renderer,
+		StateService: stateService,
+	})
+	stateOperationService := stateop.NewService(stateop.Options{
+		Logger:              logger,
+		DB:                  db,
+		Renderer:            renderer,
+		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+		StateService:        stateService,
+	})
+	variableService := variable.NewService(variable.Options{
+		Logger:              logger,
+		DB:                  db,
+		Renderer:            renderer,
+		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+	})
 	runService := run.NewService(run.Options{
 		Logger:                      logger,
 		DB:                          db,
@@ -188,10 +235,23 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		WorkspaceService:            workspaceService,
 		ConfigurationVersionService: configService,
 		VCSProviderService:          vcsProviderService,
+		StateService:                stateService,
+		UserService:                 authService,
+		TeamService:                 authService,
+		PhaseTimeouts:               cfg.PhaseTimeouts,
 		Broker:                      broker,
 		Cache:                       cache,
 		Subscriber:                  repoService,
 	})
+	runTaskService := runtask.NewService(runtask.Options{
+		Logger:              logger,
+		DB:                  db,
+		Renderer:            renderer,
+		Verifier:            signer,
+		WorkspaceAuthorizer: workspaceService,
+		RunService:          runService,
+		WorkspaceService:    workspaceService,
+	})
 	logsService := logs.NewService(logs.Options{
 		Logger:        logger,
 		DB:            db,
@@ -208,20 +268,10 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		VCSProviderService: vcsProviderService,
 		Signer:             signer,
 		RepoService:        repoService,
-	})
-	stateService := state.NewService(state.Options{
-		Logger:              logger,
-		DB:                  db,
-		WorkspaceAuthorizer: workspaceService,
-		Cache:               cache,
-		Renderer:            renderer,
-	})
-	variableService := variable.NewService(variable.Options{
-		Logger:              logger,
-		DB:                  db,
-		Renderer:            renderer,
-		WorkspaceAuthorizer: workspaceService,
-		WorkspaceService:    workspaceService,
+
+		RunService:                  runService,
+		ConfigurationVersionService: configService,
+		WorkspaceService:            workspaceService,
 	})
 
 	agent, err := agent.NewAgent(
@@ -233,6 +283,8 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 			OrganizationService:         orgService,
 			VariableService:             variableService,
 			StateService:                stateService,
+			InventoryService:            inventoryService,
+			StateOperationService:       stateOperationService,
 			HostnameService:             hostnameService,
 			ConfigurationVersionService: configService,
 			RunService:                  runService,
@@ -278,8 +330,11 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 
 	api := api.New(api.Options{
 		WorkspaceService:            workspaceService,
+		ProjectService:              projectService,
 		OrganizationService:         orgService,
 		StateService:                stateService,
+		InventoryService:            inventoryService,
+		StateOperationService:       stateOperationService,
 		RunService:                  runService,
 		ConfigurationVersionService: configService,
 		AuthService:                 authService,
@@ -288,6 +343,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		VariableService:             variableService,
 		NotificationService:         notificationService,
 		VCSProviderService:          vcsProviderService,
+		RunTaskService:              runTaskService,
 		Signer:                      signer,
 		MaxConfigSize:               cfg.MaxConfigSize,
 	})
@@ -295,18 +351,26 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 	handlers := []internal.Handlers{
 		authService,
 		tokensService,
+		projectService,
 		workspaceService,
 		stateService,
+		inventoryService,
+		stateOperationService,
 		orgService,
 		variableService,
 		vcsProviderService,
 		moduleService,
 		runService,
+		runTaskService,
 		logsService,
 		repoService,
 		authenticatorService,
 		loginServer,
 		disco.Service{},
+		&httpbackend.Handlers{
+			WorkspaceService: workspaceService,
+			StateService:     stateService,
+		},
 		api,
 	}
 
@@ -317,10 +381,13 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		AuthService:                 authService,
 		TokensService:               tokensService,
 		WorkspaceService:            workspaceService,
+		ProjectService:              projectService,
 		OrganizationService:         orgService,
 		VariableService:             variableService,
 		VCSProviderService:          vcsProviderService,
 		StateService:                stateService,
+		InventoryService:            inventoryService,
+		StateOperationService:       stateOperationService,
 		ModuleService:               moduleService,
 		HostnameService:             hostnameService,
 		ConfigurationVersionService: configService,
@@ -328,10 +395,12 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		LogsService:                 logsService,
 		RepoService:                 repoService,
 		NotificationService:         notificationService,
+		RunTaskService:              runTaskService,
 		Broker:                      broker,
 		DB:                          db,
 		agent:                       agent,
 		cloudService:                cloudService,
+		signer:                      signer,
 	}, nil
 }
 
@@ -391,13 +460,125 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 			Exclusive:      true,
 			DB:             d.DB,
 			LockID:         internal.Int64(run.ReporterLockID),
-			System: &run.Reporter{
+			System: run.NewReporter(run.ReporterOptions{
 				Logger:                      d.Logger.WithValues("component", "reporter"),
 				VCSProviderService:          d.VCSProviderService,
 				Subscriber:                  d.Broker,
 				HostnameService:             d.HostnameService,
 				ConfigurationVersionService: d.ConfigurationVersionService,
 				WorkspaceService:            d.WorkspaceService,
+				RunService:                  d.RunService,
+				DB:                          d.DB,
+			}),
+		},
+		{
+			Name:           "reaper",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(run.ReaperLockID),
+			System: &run.Reaper{
+				Logger:           d.Logger.WithValues("component", "reaper"),
+				RunService:       d.RunService,
+				WorkspaceService: d.WorkspaceService,
+				Logs:             d.LogsService,
+				Timeouts:         d.PhaseTimeouts,
+				Grace:            run.DefaultReaperGrace,
+				Interval:         run.DefaultReaperInterval,
+			},
+		},
+		{
+			Name:           "auto-destroyer",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(run.AutoDestroyerLockID),
+			System: &run.AutoDestroyer{
+				Logger:           d.Logger.WithValues("component", "auto-destroyer"),
+				RunService:       d.RunService,
+				WorkspaceService: d.WorkspaceService,
+				Interval:         run.DefaultAutoDestroyInterval,
+			},
+		},
+		{
+			Name:           "state pruner",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(state.PrunerLockID),
+			System: state.NewPruner(state.PrunerOptions{
+				Logger: d.Logger.WithValues("component", "state-pruner"),
+				DB:     d.DB,
+			}),
+		},
+		{
+			Name:           "inventory backfiller",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(inventory.BackfillerLockID),
+			System: inventory.NewBackfiller(inventory.BackfillerOptions{
+				Logger:       d.Logger.WithValues("component", "inventory-backfiller"),
+				DB:           d.DB,
+				StateService: d.StateService,
+			}),
+		},
+		{
+			Name:           "workspace lock expirer",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(workspace.LockExpirerLockID),
+			System: workspace.NewLockExpirer(workspace.LockExpirerOptions{
+				Logger: d.Logger.WithValues("component", "lock-expirer"),
+				DB:     d.DB,
+			}),
+		},
+		{
+			Name:           "preview reaper",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(run.PreviewReaperLockID),
+			System: &run.PreviewReaper{
+				Logger:             d.Logger.WithValues("component", "preview-reaper"),
+				Subscriber:         d.Broker,
+				WorkspaceService:   d.WorkspaceService,
+				VCSProviderService: d.VCSProviderService,
+			},
+		},
+		{
+			Name:           "workspace deleter",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(run.WorkspaceDeleterLockID),
+			System: run.NewWorkspaceDeleter(run.WorkspaceDeleterOptions{
+				Logger:           d.Logger.WithValues("component", "workspace-deleter"),
+				Subscriber:       d.Broker,
+				RunService:       d.RunService,
+				WorkspaceService: d.WorkspaceService,
+				DB:               d.DB,
+			}),
+		},
+		{
+			Name:           "module tester",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(module.TesterLockID),
+			System: &module.Tester{
+				Logger:        d.Logger.WithValues("component", "module-tester"),
+				Subscriber:    d.Broker,
+				ModuleService: d.ModuleService,
 			},
 		},
 		{
@@ -423,6 +604,24 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 				Subscriber:       d.Broker,
 				HostnameService:  d.HostnameService,
 				WorkspaceService: d.WorkspaceService,
+				RunService:       d.RunService,
+				DB:               d.DB,
+			}),
+		},
+		{
+			Name:           "run task dispatcher",
+			BackoffRestart: true,
+			Logger:         d.Logger,
+			Exclusive:      true,
+			DB:             d.DB,
+			LockID:         internal.Int64(runtask.LockID),
+			System: runtask.NewDispatcher(runtask.DispatcherOptions{
+				Logger:           d.Logger,
+				Subscriber:       d.Broker,
+				RunService:       d.RunService,
+				WorkspaceService: d.WorkspaceService,
+				HostnameService:  d.HostnameService,
+				Signer:           d.signer,
 				DB:               d.DB,
 			}),
 		},
@@ -45,6 +45,30 @@ var (
 	ErrStatusTimestampNotFound = errors.New("corresponding status timestamp not found")
 
 	ErrInvalidRepo = errors.New("repository path is invalid")
+
+	// ErrInvalidConcurrencyLimit is returned when a limit on the number of
+	// concurrent runs is negative.
+	ErrInvalidConcurrencyLimit = errors.New("concurrency limit cannot be negative")
+
+	// ErrInvalidPhaseTimeout is returned when a timeout for a run phase is
+	// negative.
+	ErrInvalidPhaseTimeout = errors.New("phase timeout cannot be negative")
+
+	// ErrInvalidAutoDiscardTTL is returned when the period after which
+	// planned runs are automatically discarded is negative.
+	ErrInvalidAutoDiscardTTL = errors.New("auto-discard TTL cannot be negative")
+
+	// ErrInvalidAutoDestroyAt is returned when a workspace is scheduled to be
+	// destroyed at a time that has already passed.
+	ErrInvalidAutoDestroyAt = errors.New("auto-destroy time must be in the future")
+
+	// ErrInvalidAutoDestroyActivityDuration is returned when the period of
+	// inactivity after which a workspace is destroyed is negative.
+	ErrInvalidAutoDestroyActivityDuration = errors.New("auto-destroy activity duration cannot be negative")
+
+	// ErrSavedPlanStale is returned when applying a saved plan for which the
+	// workspace's state has since changed.
+	ErrSavedPlanStale = errors.New("saved plan is stale: state has changed since the plan was created")
 )
 
 // Workspace errors
@@ -52,10 +76,23 @@ var (
 	ErrWorkspaceAlreadyLocked         = errors.New("workspace already locked")
 	ErrWorkspaceLockedByDifferentUser = errors.New("workspace locked by different user")
 	ErrWorkspaceLockedByRun           = errors.New("workspace is locked by Run")
+	ErrWorkspaceLockedByPullRequest   = errors.New("workspace is locked by pull request")
 	ErrWorkspaceAlreadyUnlocked       = errors.New("workspace already unlocked")
 	ErrWorkspaceUnlockDenied          = errors.New("unauthorized to unlock workspace")
 	ErrWorkspaceInvalidLock           = errors.New("invalid workspace lock")
 	ErrUnsupportedTerraformVersion    = errors.New("unsupported terraform version")
+	ErrRemoteStateConsumerSelf        = errors.New("a workspace cannot consume its own state")
+)
+
+// Project errors
+var (
+	ErrDefaultProjectDelete = errors.New("the default project cannot be deleted")
+	ErrProjectNotEmpty      = errors.New("project contains workspaces; move or delete them first")
+)
+
+// Inventory errors
+var (
+	ErrInvalidProviderVersion = errors.New("provider version must be a numeric version, e.g. 5.0")
 )
 
 // Run errors
@@ -63,6 +100,11 @@ var (
 	ErrRunDiscardNotAllowed     = errors.New("run was not paused for confirmation or priority; discard not allowed")
 	ErrRunCancelNotAllowed      = errors.New("run was not planning or applying; cancel not allowed")
 	ErrRunForceCancelNotAllowed = errors.New("run was not planning or applying, has not been canceled non-forcefully, or the cool-off period has not yet passed")
+	ErrRunApprovalRequired      = errors.New("run requires further approvals before it can be applied")
+	ErrRunRejected              = errors.New("run has been rejected; apply not allowed")
+	ErrRunApprovalNotAllowed    = errors.New("run is not awaiting approval; approval not allowed")
+	ErrRunSelfApproval          = errors.New("the author of a run cannot approve or reject their own run")
+	ErrEmptyRunComment          = errors.New("run comment body cannot be empty")
 	//
 	ErrPhaseAlreadyStarted = errors.New("phase already started")
 )
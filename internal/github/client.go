@@ -206,6 +206,8 @@ func (g *Client) CreateWebhook(ctx context.Context, opts cloud.CreateWebhookOpti
 			events = append(events, "push")
 		case cloud.VCSEventTypePull:
 			events = append(events, "pull_request")
+		case cloud.VCSEventTypeComment:
+			events = append(events, "issue_comment")
 		}
 	}
 
@@ -242,6 +244,8 @@ func (g *Client) UpdateWebhook(ctx context.Context, id string, opts cloud.Update
 			events = append(events, "push")
 		case cloud.VCSEventTypePull:
 			events = append(events, "pull_request")
+		case cloud.VCSEventTypeComment:
+			events = append(events, "issue_comment")
 		}
 	}
 
@@ -286,6 +290,8 @@ func (g *Client) GetWebhook(ctx context.Context, opts cloud.GetWebhookOptions) (
 			events = append(events, cloud.VCSEventTypePush)
 		case "pull_request":
 			events = append(events, cloud.VCSEventTypePull)
+		case "issue_comment":
+			events = append(events, cloud.VCSEventTypeComment)
 		}
 	}
 
@@ -407,6 +413,68 @@ listloop:
 	return files, nil
 }
 
+func (g *Client) CreatePullRequestComment(ctx context.Context, opts cloud.CreatePullRequestCommentOptions) (string, error) {
+	owner, name, found := strings.Cut(opts.Repo, "/")
+	if !found {
+		return "", fmt.Errorf("malformed identifier: %s", opts.Repo)
+	}
+
+	// a pull request is an issue as far as comments are concerned
+	comment, _, err := g.client.Issues.CreateComment(ctx, owner, name, opts.PullRequest, &github.IssueComment{
+		Body: internal.String(opts.Body),
+	})
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(comment.GetID(), 10), nil
+}
+
+func (g *Client) UpdatePullRequestComment(ctx context.Context, opts cloud.UpdatePullRequestCommentOptions) error {
+	owner, name, found := strings.Cut(opts.Repo, "/")
+	if !found {
+		return fmt.Errorf("malformed identifier: %s", opts.Repo)
+	}
+
+	id, err := strconv.ParseInt(opts.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	_, resp, err := g.client.Issues.EditComment(ctx, owner, name, id, &github.IssueComment{
+		Body: internal.String(opts.Body),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return internal.ErrResourceNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (g *Client) GetPullRequest(ctx context.Context, repo string, pull int) (cloud.PullRequest, error) {
+	owner, name, found := strings.Cut(repo, "/")
+	if !found {
+		return cloud.PullRequest{}, fmt.Errorf("malformed identifier: %s", repo)
+	}
+
+	pr, resp, err := g.client.PullRequests.Get(ctx, owner, name, pull)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return cloud.PullRequest{}, internal.ErrResourceNotFound
+		}
+		return cloud.PullRequest{}, err
+	}
+	return cloud.PullRequest{
+		Number:     pr.GetNumber(),
+		Title:      pr.GetTitle(),
+		URL:        pr.GetHTMLURL(),
+		HeadBranch: pr.GetHead().GetRef(),
+		HeadSHA:    pr.GetHead().GetSHA(),
+		Open:       pr.GetState() == "open",
+	}, nil
+}
+
 func (g *Client) GetCommit(ctx context.Context, repo, ref string) (cloud.Commit, error) {
 	owner, name, found := strings.Cut(repo, "/")
 	if !found {
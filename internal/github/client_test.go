@@ -77,6 +77,43 @@ func TestCreateWebhook(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPullRequestComment(t *testing.T) {
+	ctx := context.Background()
+
+	srv, cfg := NewTestServer(t,
+		WithRepo("acme/terraform"),
+		WithPullRequest("2"),
+	)
+	client, err := NewClient(ctx, cloud.ClientOptions{
+		Hostname:            cfg.Hostname,
+		SkipTLSVerification: true,
+		Credentials: cloud.Credentials{
+			OAuthToken: &oauth2.Token{AccessToken: "fake-token"},
+		},
+	})
+	require.NoError(t, err)
+
+	id, err := client.CreatePullRequestComment(ctx, cloud.CreatePullRequestCommentOptions{
+		Repo:        "acme/terraform",
+		PullRequest: 2,
+		Body:        "planned",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "123", id)
+	assert.Equal(t, "planned", srv.GetComment(t, ctx).GetBody())
+
+	err = client.UpdatePullRequestComment(ctx, cloud.UpdatePullRequestCommentOptions{
+		ID: id,
+		CreatePullRequestCommentOptions: cloud.CreatePullRequestCommentOptions{
+			Repo:        "acme/terraform",
+			PullRequest: 2,
+			Body:        "planned again",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "planned again", srv.GetComment(t, ctx).GetBody())
+}
+
 // newTestServerClient creates a github server for testing purposes and
 // returns a client configured to access the server.
 func newTestServerClient(t *testing.T, opts ...TestServerOption) *Client {
@@ -117,6 +117,27 @@ func handle(r *http.Request, secret string) (*cloud.VCSEvent, error) {
 		// constructed instead
 		to.CommitURL = event.GetRepo().GetHTMLURL() + "/commit/" + to.CommitSHA
 
+		return &to, nil
+	case *github.IssueCommentEvent:
+		// only new comments on pull requests are of interest; github
+		// considers a pull request to be a type of issue.
+		if event.GetAction() != "created" || !event.GetIssue().IsPullRequest() {
+			return nil, nil
+		}
+		to.Type = cloud.VCSEventTypeComment
+		to.Action = cloud.VCSActionCreated
+		to.Comment = event.GetComment().GetBody()
+		to.PullRequestNumber = event.GetIssue().GetNumber()
+		to.PullRequestURL = event.GetIssue().GetHTMLURL()
+		to.PullRequestTitle = event.GetIssue().GetTitle()
+		to.DefaultBranch = event.GetRepo().GetDefaultBranch()
+
+		to.SenderUsername = event.GetSender().GetLogin()
+		to.SenderAvatarURL = event.GetSender().GetAvatarURL()
+		to.SenderHTMLURL = event.GetSender().GetHTMLURL()
+
+		// the event contains neither the head branch nor the head commit of
+		// the pull request; it is left to the receiver to retrieve them.
 		return &to, nil
 	default:
 		return nil, nil
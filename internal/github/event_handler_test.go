@@ -76,6 +76,24 @@ func TestEventHandler(t *testing.T) {
 				SenderHTMLURL:     "https://github.com/leg100",
 			},
 		},
+		{
+			"pull request comment",
+			"issue_comment",
+			"./testdata/github_pull_comment.json",
+			&cloud.VCSEvent{
+				Cloud:             cloud.Github,
+				Type:              cloud.VCSEventTypeComment,
+				Action:            cloud.VCSActionCreated,
+				Comment:           "otf apply dev",
+				DefaultBranch:     "master",
+				PullRequestNumber: 2,
+				PullRequestURL:    "https://github.com/leg100/otf-workspaces/pull/2",
+				PullRequestTitle:  "pr-2",
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://avatars.githubusercontent.com/u/75728?v=4",
+				SenderHTMLURL:     "https://github.com/leg100",
+			},
+		},
 		{
 			"tag pushed",
 			"push",
@@ -35,6 +35,8 @@ type (
 	TestServer struct {
 		// status updates received from otfd
 		statuses chan *github.StatusEvent
+		// pull request comments created or updated by otfd
+		comments chan *github.IssueComment
 
 		// webhook created/updated/deleted events channel
 		WebhookEvents chan webhookEvent
@@ -82,6 +84,7 @@ func NewTestServer(t *testing.T, opts ...TestServerOption) (*TestServer, cloud.C
 	srv := TestServer{
 		testdb:        &testdb{},
 		statuses:      make(chan *github.StatusEvent, 999),
+		comments:      make(chan *github.IssueComment, 999),
 		WebhookEvents: make(chan webhookEvent, 999),
 	}
 	for _, o := range opts {
@@ -250,6 +253,41 @@ func NewTestServer(t *testing.T, opts ...TestServerOption) (*TestServer, cloud.C
 			srv.statuses <- &commit
 			w.WriteHeader(http.StatusCreated)
 		})
+		// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#create-an-issue-comment
+		mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/issues/"+srv.pullNumber+"/comments", func(w http.ResponseWriter, r *http.Request) {
+			var comment github.IssueComment
+			if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			comment.ID = github.Int64(123)
+			srv.comments <- &comment
+			out, err := json.Marshal(&comment)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write(out)
+		})
+		// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#update-an-issue-comment
+		mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/issues/comments/123", func(w http.ResponseWriter, r *http.Request) {
+			var comment github.IssueComment
+			if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			comment.ID = github.Int64(123)
+			srv.comments <- &comment
+			out, err := json.Marshal(&comment)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			w.Header().Add("Content-Type", "application/json")
+			w.Write(out)
+		})
 		// https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#list-pull-requests-files
 		mux.HandleFunc("/api/v3/repos/"+*srv.repo+"/pulls/"+srv.pullNumber+"/files", func(w http.ResponseWriter, r *http.Request) {
 			var commits []*github.CommitFile
@@ -403,3 +441,20 @@ func (s *TestServer) GetStatus(t *testing.T, ctx context.Context) *github.Status
 	}
 	return nil
 }
+
+// GetComment retrieves a pull request comment off the queue, timing out after
+// 10 seconds if nothing is on the queue.
+func (s *TestServer) GetComment(t *testing.T, ctx context.Context) *github.IssueComment {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	select {
+	case comment := <-s.comments:
+		return comment
+	case <-ctx.Done():
+		t.Fatalf("github server: waiting to receive pull request comment: %s", ctx.Err().Error())
+	}
+	return nil
+}
@@ -161,6 +161,8 @@ func (g *Client) CreateWebhook(ctx context.Context, opts cloud.CreateWebhookOpti
 			addOpts.PushEvents = internal.Bool(true)
 		case cloud.VCSEventTypePull:
 			addOpts.MergeRequestsEvents = internal.Bool(true)
+		case cloud.VCSEventTypeComment:
+			addOpts.NoteEvents = internal.Bool(true)
 		}
 	}
 
@@ -188,6 +190,8 @@ func (g *Client) UpdateWebhook(ctx context.Context, id string, opts cloud.Update
 			editOpts.PushEvents = internal.Bool(true)
 		case cloud.VCSEventTypePull:
 			editOpts.MergeRequestsEvents = internal.Bool(true)
+		case cloud.VCSEventTypeComment:
+			editOpts.NoteEvents = internal.Bool(true)
 		}
 	}
 
@@ -219,6 +223,9 @@ func (g *Client) GetWebhook(ctx context.Context, opts cloud.GetWebhookOptions) (
 	if hook.MergeRequestsEvents {
 		events = append(events, cloud.VCSEventTypePull)
 	}
+	if hook.NoteEvents {
+		events = append(events, cloud.VCSEventTypeComment)
+	}
 
 	return cloud.Webhook{
 		ID:       strconv.Itoa(id),
@@ -246,6 +253,52 @@ func (g *Client) ListPullRequestFiles(ctx context.Context, repo string, pull int
 	return nil, nil
 }
 
+func (g *Client) CreatePullRequestComment(ctx context.Context, opts cloud.CreatePullRequestCommentOptions) (string, error) {
+	note, _, err := g.client.Notes.CreateMergeRequestNote(opts.Repo, opts.PullRequest, &gitlab.CreateMergeRequestNoteOptions{
+		Body: internal.String(opts.Body),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(note.ID), nil
+}
+
+func (g *Client) UpdatePullRequestComment(ctx context.Context, opts cloud.UpdatePullRequestCommentOptions) error {
+	id, err := strconv.Atoi(opts.ID)
+	if err != nil {
+		return err
+	}
+
+	_, resp, err := g.client.Notes.UpdateMergeRequestNote(opts.Repo, opts.PullRequest, id, &gitlab.UpdateMergeRequestNoteOptions{
+		Body: internal.String(opts.Body),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return internal.ErrResourceNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (g *Client) GetPullRequest(ctx context.Context, repo string, pull int) (cloud.PullRequest, error) {
+	mr, resp, err := g.client.MergeRequests.GetMergeRequest(repo, pull, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return cloud.PullRequest{}, internal.ErrResourceNotFound
+		}
+		return cloud.PullRequest{}, err
+	}
+	return cloud.PullRequest{
+		Number:     mr.IID,
+		Title:      mr.Title,
+		URL:        mr.WebURL,
+		HeadBranch: mr.SourceBranch,
+		HeadSHA:    mr.SHA,
+		Open:       mr.State == "opened",
+	}, nil
+}
+
 func (g *Client) GetCommit(ctx context.Context, repo, ref string) (cloud.Commit, error) {
 	return cloud.Commit{}, nil
 }
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -59,7 +60,73 @@ func handle(r *http.Request, secret string) (*cloud.VCSEvent, error) {
 			DefaultBranch: event.Project.DefaultBranch,
 		}, nil
 	case *gitlab.MergeEvent:
+		// only opening, closing or merging a merge request is of interest:
+		// opening a merge request spawns preview workspaces, and closing or
+		// merging it destroys them and releases any locks held on its
+		// behalf.
+		to := cloud.VCSEvent{
+			Cloud:             cloud.Gitlab,
+			Type:              cloud.VCSEventTypePull,
+			PullRequestNumber: event.ObjectAttributes.IID,
+			PullRequestURL:    event.ObjectAttributes.URL,
+			PullRequestTitle:  event.ObjectAttributes.Title,
+			Branch:            event.ObjectAttributes.SourceBranch,
+			CommitSHA:         event.ObjectAttributes.LastCommit.ID,
+			DefaultBranch:     event.Project.DefaultBranch,
+		}
+		switch event.ObjectAttributes.Action {
+		case "open", "reopen":
+			to.Action = cloud.VCSActionCreated
+		case "close":
+			to.Action = cloud.VCSActionDeleted
+		case "merge":
+			to.Action = cloud.VCSActionMerged
+		default:
+			return nil, nil
+		}
+		return &to, nil
+	case *gitlab.MergeCommentEvent:
+		// only newly created comments are of interest; in particular, an
+		// edited comment must not trigger a command a second time.
+		if event.ObjectAttributes.System || !noteCreated(payload, event) {
+			return nil, nil
+		}
+		// strip the note's anchor from its URL to get the merge request URL
+		mrURL, _, _ := strings.Cut(event.ObjectAttributes.URL, "#")
+		to := cloud.VCSEvent{
+			Cloud:             cloud.Gitlab,
+			Type:              cloud.VCSEventTypeComment,
+			Action:            cloud.VCSActionCreated,
+			Comment:           event.ObjectAttributes.Note,
+			PullRequestNumber: event.MergeRequest.IID,
+			PullRequestURL:    mrURL,
+			PullRequestTitle:  event.MergeRequest.Title,
+			Branch:            event.MergeRequest.SourceBranch,
+			CommitSHA:         event.MergeRequest.LastCommit.ID,
+			DefaultBranch:     event.Project.DefaultBranch,
+		}
+		if event.User != nil {
+			to.SenderUsername = event.User.Username
+			to.SenderAvatarURL = event.User.AvatarURL
+		}
+		return &to, nil
 	}
 
 	return nil, nil
 }
+
+// noteCreated determines whether a note event is for a newly created note
+// rather than an edited note. The go-gitlab library doesn't expose the event
+// action so it is decoded separately. Older versions of GitLab don't send the
+// action, in which case a note is deemed new if it has never been updated.
+func noteCreated(payload []byte, event *gitlab.MergeCommentEvent) bool {
+	var note struct {
+		ObjectAttributes struct {
+			Action string `json:"action"`
+		} `json:"object_attributes"`
+	}
+	if err := json.Unmarshal(payload, &note); err == nil && note.ObjectAttributes.Action != "" {
+		return note.ObjectAttributes.Action == "create"
+	}
+	return event.ObjectAttributes.UpdatedAt == event.ObjectAttributes.CreatedAt
+}
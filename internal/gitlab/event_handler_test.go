@@ -0,0 +1,113 @@
+package gitlab
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/leg100/otf/internal/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandler_MergeRequestNote(t *testing.T) {
+	note, err := os.ReadFile("./testdata/gitlab_merge_request_note.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		// replacements to make to the note payload
+		replace []string
+		want    *cloud.VCSEvent
+	}{
+		{
+			name: "created",
+			want: &cloud.VCSEvent{
+				Cloud:             cloud.Gitlab,
+				Type:              cloud.VCSEventTypeComment,
+				Action:            cloud.VCSActionCreated,
+				Comment:           "otf apply dev",
+				PullRequestNumber: 1,
+				PullRequestURL:    "https://gitlab.com/leg100/otf-workspaces/-/merge_requests/1",
+				PullRequestTitle:  "pr-1",
+				Branch:            "pr-1",
+				CommitSHA:         "067e2b4c6394b3dad3c0ec89ffc428ab60ae7e5d",
+				DefaultBranch:     "master",
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://gitlab.com/uploads/-/system/user/avatar/1/avatar.png",
+			},
+		},
+		{
+			name:    "edited",
+			replace: []string{`"action": "create"`, `"action": "update"`},
+		},
+		{
+			name: "edited without action",
+			replace: []string{
+				`"action": "create",`, ``,
+				`"updated_at": "2023-09-22 10:15:30 UTC"`, `"updated_at": "2023-09-22 10:20:00 UTC"`,
+			},
+		},
+		{
+			name:    "system note",
+			replace: []string{`"system": false`, `"system": true`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := strings.NewReplacer(tt.replace...).Replace(string(note))
+			r := httptest.NewRequest("POST", "/", bytes.NewBufferString(payload))
+			r.Header.Add("X-Gitlab-Event", "Note Hook")
+			r.Header.Add("X-Gitlab-Token", "secret")
+
+			got, err := handle(r, "secret")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventHandler_MergeRequest(t *testing.T) {
+	mr, err := os.ReadFile("./testdata/gitlab_merge_request.json")
+	require.NoError(t, err)
+
+	want := func(action cloud.VCSAction) *cloud.VCSEvent {
+		return &cloud.VCSEvent{
+			Cloud:             cloud.Gitlab,
+			Type:              cloud.VCSEventTypePull,
+			Action:            action,
+			PullRequestNumber: 1,
+			PullRequestURL:    "https://gitlab.com/leg100/otf-workspaces/-/merge_requests/1",
+			PullRequestTitle:  "pr-1",
+			Branch:            "pr-1",
+			CommitSHA:         "067e2b4c6394b3dad3c0ec89ffc428ab60ae7e5d",
+			DefaultBranch:     "master",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		action string
+		want   *cloud.VCSEvent
+	}{
+		{"opened", "open", want(cloud.VCSActionCreated)},
+		{"reopened", "reopen", want(cloud.VCSActionCreated)},
+		{"closed", "close", want(cloud.VCSActionDeleted)},
+		{"merged", "merge", want(cloud.VCSActionMerged)},
+		{"approved", "approved", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := strings.Replace(string(mr), `"action": "open"`, `"action": "`+tt.action+`"`, 1)
+			r := httptest.NewRequest("POST", "/", bytes.NewBufferString(payload))
+			r.Header.Add("X-Gitlab-Event", "Merge Request Hook")
+			r.Header.Add("X-Gitlab-Token", "secret")
+
+			got, err := handle(r, "secret")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
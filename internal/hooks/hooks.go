@@ -5,17 +5,23 @@ import (
 	"context"
 	"sync"
 
-	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
 )
 
-// Listener is a function that can listen and react to a hook event
-type Listener[T any] func(ctx context.Context, event T) error
+type (
+	// Listener is a function that can listen and react to a hook event
+	Listener[T any] func(ctx context.Context, event T) error
+
+	// DB wraps dispatches in a transaction
+	DB interface {
+		Tx(context.Context, func(context.Context, pggen.Querier) error) error
+	}
+)
 
 // Hook is a mechanism which supports the ability to dispatch data to arbitrary listener callbacks
 type Hook[T any] struct {
 	// db for wrapping dispatch in a transaction
-	db *sql.DB
+	db DB
 
 	// before stores the functions which will be invoked before the hook action
 	// occurs
@@ -30,7 +36,7 @@ type Hook[T any] struct {
 }
 
 // NewHook creates a new Hook
-func NewHook[T any](db *sql.DB) *Hook[T] {
+func NewHook[T any](db DB) *Hook[T] {
 	return &Hook[T]{
 		db:     db,
 		before: make([]Listener[T], 0),
@@ -43,17 +43,26 @@ func init() {
 	funcmap["lockWorkspacePath"] = LockWorkspace
 	funcmap["unlockWorkspacePath"] = UnlockWorkspace
 	funcmap["forceUnlockWorkspacePath"] = ForceUnlockWorkspace
+	funcmap["lockHistoryWorkspacePath"] = LockHistoryWorkspace
 	funcmap["setPermissionWorkspacePath"] = SetPermissionWorkspace
 	funcmap["unsetPermissionWorkspacePath"] = UnsetPermissionWorkspace
+	funcmap["setApprovalPolicyWorkspacePath"] = SetApprovalPolicyWorkspace
 	funcmap["watchWorkspacePath"] = WatchWorkspace
 	funcmap["connectWorkspacePath"] = ConnectWorkspace
 	funcmap["disconnectWorkspacePath"] = DisconnectWorkspace
 	funcmap["startRunWorkspacePath"] = StartRunWorkspace
+	funcmap["destroyAndDeleteWorkspacePath"] = DestroyAndDeleteWorkspace
+	funcmap["cloneWorkspacePath"] = CloneWorkspace
 	funcmap["setupConnectionProviderWorkspacePath"] = SetupConnectionProviderWorkspace
 	funcmap["setupConnectionRepoWorkspacePath"] = SetupConnectionRepoWorkspace
 	funcmap["createTagWorkspacePath"] = CreateTagWorkspace
 	funcmap["deleteTagWorkspacePath"] = DeleteTagWorkspace
 	funcmap["stateWorkspacePath"] = StateWorkspace
+	funcmap["addRemoteStateConsumerWorkspacePath"] = AddRemoteStateConsumerWorkspace
+	funcmap["removeRemoteStateConsumerWorkspacePath"] = RemoveRemoteStateConsumerWorkspace
+	funcmap["stateDiffWorkspacePath"] = StateDiffWorkspace
+	funcmap["resourceHistoryWorkspacePath"] = ResourceHistoryWorkspace
+	funcmap["stateOperationsWorkspacePath"] = StateOperationsWorkspace
 
 	funcmap["runsPath"] = Runs
 	funcmap["createRunPath"] = CreateRun
@@ -68,6 +77,12 @@ func init() {
 	funcmap["retryRunPath"] = RetryRun
 	funcmap["tailRunPath"] = TailRun
 	funcmap["widgetRunPath"] = WidgetRun
+	funcmap["taskResultsRunPath"] = TaskResultsRun
+	funcmap["planDiffRunPath"] = PlanDiffRun
+	funcmap["approveRunPath"] = ApproveRun
+	funcmap["rejectRunPath"] = RejectRun
+	funcmap["commentRunPath"] = CommentRun
+	funcmap["watchCommentsRunPath"] = WatchCommentsRun
 
 	funcmap["variablesPath"] = Variables
 	funcmap["createVariablePath"] = CreateVariable
@@ -77,6 +92,14 @@ func init() {
 	funcmap["updateVariablePath"] = UpdateVariable
 	funcmap["deleteVariablePath"] = DeleteVariable
 
+	funcmap["workspaceRunTasksPath"] = WorkspaceRunTasks
+	funcmap["createWorkspaceRunTaskPath"] = CreateWorkspaceRunTask
+	funcmap["newWorkspaceRunTaskPath"] = NewWorkspaceRunTask
+	funcmap["workspaceRunTaskPath"] = WorkspaceRunTask
+	funcmap["editWorkspaceRunTaskPath"] = EditWorkspaceRunTask
+	funcmap["updateWorkspaceRunTaskPath"] = UpdateWorkspaceRunTask
+	funcmap["deleteWorkspaceRunTaskPath"] = DeleteWorkspaceRunTask
+
 	funcmap["agentTokensPath"] = AgentTokens
 	funcmap["createAgentTokenPath"] = CreateAgentToken
 	funcmap["newAgentTokenPath"] = NewAgentToken
@@ -115,6 +138,14 @@ func init() {
 	funcmap["updateVCSProviderPath"] = UpdateVCSProvider
 	funcmap["deleteVCSProviderPath"] = DeleteVCSProvider
 
+	funcmap["vcsUserMappingsPath"] = VCSUserMappings
+	funcmap["createVCSUserMappingPath"] = CreateVCSUserMapping
+	funcmap["newVCSUserMappingPath"] = NewVCSUserMapping
+	funcmap["vcsUserMappingPath"] = VCSUserMapping
+	funcmap["editVCSUserMappingPath"] = EditVCSUserMapping
+	funcmap["updateVCSUserMappingPath"] = UpdateVCSUserMapping
+	funcmap["deleteVCSUserMappingPath"] = DeleteVCSUserMapping
+
 	funcmap["modulesPath"] = Modules
 	funcmap["createModulePath"] = CreateModule
 	funcmap["newModulePath"] = NewModule
@@ -122,6 +153,26 @@ func init() {
 	funcmap["editModulePath"] = EditModule
 	funcmap["updateModulePath"] = UpdateModule
 	funcmap["deleteModulePath"] = DeleteModule
+
+	funcmap["runTasksPath"] = RunTasks
+	funcmap["createRunTaskPath"] = CreateRunTask
+	funcmap["newRunTaskPath"] = NewRunTask
+	funcmap["runTaskPath"] = RunTask
+	funcmap["editRunTaskPath"] = EditRunTask
+	funcmap["updateRunTaskPath"] = UpdateRunTask
+	funcmap["deleteRunTaskPath"] = DeleteRunTask
+
+	funcmap["projectsPath"] = Projects
+	funcmap["createProjectPath"] = CreateProject
+	funcmap["newProjectPath"] = NewProject
+	funcmap["projectPath"] = Project
+	funcmap["editProjectPath"] = EditProject
+	funcmap["updateProjectPath"] = UpdateProject
+	funcmap["deleteProjectPath"] = DeleteProject
+	funcmap["setPermissionProjectPath"] = SetPermissionProject
+	funcmap["unsetPermissionProjectPath"] = UnsetPermissionProject
+
+	funcmap["resourcesPath"] = Resources
 }
 
 func FuncMap() template.FuncMap { return funcmap }
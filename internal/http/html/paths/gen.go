@@ -155,12 +155,18 @@ var specs = []controllerSpec{
 					{
 						name: "force-unlock",
 					},
+					{
+						name: "lock-history",
+					},
 					{
 						name: "set-permission",
 					},
 					{
 						name: "unset-permission",
 					},
+					{
+						name: "set-approval-policy",
+					},
 					{
 						name: "watch",
 					},
@@ -173,6 +179,12 @@ var specs = []controllerSpec{
 					{
 						name: "start-run",
 					},
+					{
+						name: "destroy-and-delete",
+					},
+					{
+						name: "clone",
+					},
 					{
 						name: "setup-connection-provider",
 					},
@@ -188,6 +200,21 @@ var specs = []controllerSpec{
 					{
 						name: "state",
 					},
+					{
+						name: "add-remote-state-consumer",
+					},
+					{
+						name: "remove-remote-state-consumer",
+					},
+					{
+						name: "state-diff",
+					},
+					{
+						name: "resource-history",
+					},
+					{
+						name: "state-operations",
+					},
 				},
 				nested: []controllerSpec{
 					{
@@ -212,12 +239,34 @@ var specs = []controllerSpec{
 							{
 								name: "widget",
 							},
+							{
+								name: "task-results",
+							},
+							{
+								name: "plan-diff",
+							},
+							{
+								name: "approve",
+							},
+							{
+								name: "reject",
+							},
+							{
+								name: "comment",
+							},
+							{
+								name: "watch-comments",
+							},
 						},
 					},
 					{
 						Name:           "variable",
 						controllerType: resourcePath,
 					},
+					{
+						Name:           "workspace_run_task",
+						controllerType: resourcePath,
+					},
 				},
 			},
 			{
@@ -265,11 +314,46 @@ var specs = []controllerSpec{
 				controllerType: resourcePath,
 				camel:          "VCSProvider",
 				lowerCamel:     "vcsProvider",
+				nested: []controllerSpec{
+					{
+						Name:           "vcs_user_mapping",
+						controllerType: resourcePath,
+						camel:          "VCSUserMapping",
+						lowerCamel:     "vcsUserMapping",
+					},
+				},
 			},
 			{
 				Name:           "module",
 				controllerType: resourcePath,
 			},
+			{
+				Name:           "run_task",
+				controllerType: resourcePath,
+			},
+			{
+				Name:           "project",
+				controllerType: resourcePath,
+				actions: []action{
+					{
+						name: "set-permission",
+					},
+					{
+						name: "unset-permission",
+					},
+				},
+			},
+			{
+				Name:               "resource",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				actions: []action{
+					{
+						name:       "list",
+						collection: true,
+					},
+				},
+			},
 		},
 	},
 }
@@ -55,3 +55,27 @@ func TailRun(run string) string {
 func WidgetRun(run string) string {
 	return fmt.Sprintf("/app/runs/%s/widget", run)
 }
+
+func TaskResultsRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/task-results", run)
+}
+
+func PlanDiffRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/plan-diff", run)
+}
+
+func ApproveRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/approve", run)
+}
+
+func RejectRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/reject", run)
+}
+
+func CommentRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/comment", run)
+}
+
+func WatchCommentsRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/watch-comments", run)
+}
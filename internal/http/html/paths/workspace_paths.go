@@ -44,6 +44,10 @@ func ForceUnlockWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/force-unlock", workspace)
 }
 
+func LockHistoryWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/lock-history", workspace)
+}
+
 func SetPermissionWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/set-permission", workspace)
 }
@@ -52,6 +56,10 @@ func UnsetPermissionWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/unset-permission", workspace)
 }
 
+func SetApprovalPolicyWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/set-approval-policy", workspace)
+}
+
 func WatchWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/watch", workspace)
 }
@@ -68,6 +76,14 @@ func StartRunWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/start-run", workspace)
 }
 
+func DestroyAndDeleteWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/destroy-and-delete", workspace)
+}
+
+func CloneWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/clone", workspace)
+}
+
 func SetupConnectionProviderWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/setup-connection-provider", workspace)
 }
@@ -87,3 +103,23 @@ func DeleteTagWorkspace(workspace string) string {
 func StateWorkspace(workspace string) string {
 	return fmt.Sprintf("/app/workspaces/%s/state", workspace)
 }
+
+func AddRemoteStateConsumerWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/add-remote-state-consumer", workspace)
+}
+
+func RemoveRemoteStateConsumerWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/remove-remote-state-consumer", workspace)
+}
+
+func StateDiffWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/state-diff", workspace)
+}
+
+func ResourceHistoryWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/resource-history", workspace)
+}
+
+func StateOperationsWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/state-operations", workspace)
+}
@@ -24,6 +24,9 @@ import (
 const (
 	ModuleV1Prefix = "/v1/modules/"
 	APIPrefixV2    = "/api/v2/"
+	// HTTPBackendSuffix is the suffix of API paths implementing terraform's
+	// http backend.
+	HTTPBackendSuffix = "/http-backend"
 
 	// shutdownTimeout is the time given for outstanding requests to finish
 	// before shutdown.
@@ -0,0 +1,251 @@
+// Package httpbackend implements terraform's http backend protocol, permitting
+// the state of an OTF workspace to be managed using the http backend:
+//
+// https://developer.hashicorp.com/terraform/language/settings/backends/http
+package httpbackend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+var codes = map[error]int{
+	internal.ErrResourceNotFound:               http.StatusNotFound,
+	internal.ErrAccessNotPermitted:             http.StatusForbidden,
+	internal.ErrWorkspaceAlreadyLocked:         http.StatusLocked,
+	internal.ErrWorkspaceLockedByRun:           http.StatusLocked,
+	internal.ErrWorkspaceLockedByDifferentUser: http.StatusLocked,
+	internal.ErrWorkspaceAlreadyUnlocked:       http.StatusConflict,
+	state.ErrSerialLessThanCurrent:             http.StatusConflict,
+	state.ErrSerialMD5Mismatch:                 http.StatusConflict,
+	errLockIDMismatch:                          http.StatusConflict,
+}
+
+// errLockIDMismatch is returned when the lock ID supplied by terraform does not
+// match the ID of the lock held on the workspace.
+var errLockIDMismatch = errors.New("lock ID does not match the existing lock")
+
+type (
+	Handlers struct {
+		workspace.WorkspaceService
+		state.StateService
+	}
+
+	// lockInfo is the subset of terraform's lock info that OTF uses. Terraform
+	// sends it when locking and unlocking a workspace, and expects it in
+	// response to a conflicting lock, reporting the ID to the user, who can
+	// pass it to `terraform force-unlock`.
+	lockInfo struct {
+		ID        string
+		Operation string
+		Who       string
+		Info      string
+	}
+)
+
+// AddHandlers adds handlers for the http backend. A workspace can be
+// referenced either by its ID or by its organization and name.
+func (h *Handlers) AddHandlers(r *mux.Router) {
+	r = otfhttp.APIRouter(r)
+
+	for _, path := range []string{
+		"/workspaces/{workspace_id}" + otfhttp.HTTPBackendSuffix,
+		"/organizations/{organization_name}/workspaces/{workspace_name}" + otfhttp.HTTPBackendSuffix,
+	} {
+		r.HandleFunc(path, h.getState).Methods("GET")
+		r.HandleFunc(path, h.updateState).Methods("POST")
+		r.HandleFunc(path, h.lock).Methods("LOCK")
+		r.HandleFunc(path, h.unlock).Methods("UNLOCK")
+	}
+}
+
+func (h *Handlers) getState(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.getWorkspace(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	file, err := h.DownloadCurrentState(r.Context(), ws.ID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// workspace has no state yet
+		w.WriteHeader(http.StatusNoContent)
+		return
+	} else if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-type", "application/json")
+	w.Write(file)
+}
+
+func (h *Handlers) updateState(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.getWorkspace(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// state cannot be updated whilst someone else holds the lock.
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if ws.Locked() && !ws.LockedBy(subject) {
+		writeLockError(w, ws, internal.ErrWorkspaceAlreadyLocked)
+		return
+	}
+	// terraform passes the ID of the lock it holds, which must match the
+	// lock's ID.
+	if id := r.URL.Query().Get("ID"); ws.Locked() && id != "" && id != lockID(ws) {
+		writeLockError(w, ws, errLockIDMismatch)
+		return
+	}
+
+	file, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	_, err = h.CreateStateVersion(r.Context(), state.CreateStateVersionOptions{
+		WorkspaceID: &ws.ID,
+		State:       file,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) lock(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.getWorkspace(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	info, err := decodeLockInfo(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	opts := workspace.LockOptions{ExternalID: info.ID}
+	if info.Operation != "" {
+		opts.Reason = fmt.Sprintf("terraform %s", info.Operation)
+		if info.Who != "" {
+			opts.Reason += fmt.Sprintf(" by %s", info.Who)
+		}
+	}
+	if _, err := h.LockWorkspace(r.Context(), ws.ID, nil, opts); err != nil {
+		writeLockError(w, ws, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) unlock(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.getWorkspace(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	info, err := decodeLockInfo(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if info.ID != "" && ws.Locked() && info.ID != lockID(ws) {
+		writeLockError(w, ws, errLockIDMismatch)
+		return
+	}
+
+	_, err = h.UnlockWorkspace(r.Context(), ws.ID, nil, false)
+	if errors.Is(err, internal.ErrWorkspaceLockedByDifferentUser) && info.ID == "" {
+		// `terraform force-unlock` sends no lock info, in which case the
+		// user's lock is forcefully released, subject to them possessing
+		// the permission to do so.
+		_, err = h.UnlockWorkspace(r.Context(), ws.ID, nil, true)
+	}
+	if err != nil {
+		writeLockError(w, ws, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) getWorkspace(r *http.Request) (*workspace.Workspace, error) {
+	var params struct {
+		ID           string `schema:"workspace_id"`
+		Organization string `schema:"organization_name"`
+		Name         string `schema:"workspace_name"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		return nil, err
+	}
+	if params.ID != "" {
+		return h.GetWorkspace(r.Context(), params.ID)
+	}
+	return h.GetWorkspaceByName(r.Context(), params.Organization, params.Name)
+}
+
+// decodeLockInfo decodes the lock info terraform sends in the body of a lock
+// or unlock request. The body is empty when force unlocking.
+func decodeLockInfo(r *http.Request) (lockInfo, error) {
+	var info lockInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil && !errors.Is(err, io.EOF) {
+		return lockInfo{}, fmt.Errorf("decoding lock info: %w", err)
+	}
+	return info, nil
+}
+
+// lockID returns the ID of the lock held on the workspace: the ID assigned by
+// terraform if it acquired the lock, otherwise the workspace ID.
+func lockID(ws *workspace.Workspace) string {
+	if ws.Lock != nil && ws.Lock.ExternalID != "" {
+		return ws.Lock.ExternalID
+	}
+	return ws.ID
+}
+
+// writeLockError writes an error in response to a lock conflict. If the
+// workspace is locked then terraform expects the response body to contain
+// information on the existing lock.
+func writeLockError(w http.ResponseWriter, ws *workspace.Workspace, err error) {
+	code := lookupHTTPCode(err)
+	if code != http.StatusLocked && code != http.StatusConflict {
+		writeError(w, err)
+		return
+	}
+	info := lockInfo{ID: lockID(ws), Info: err.Error()}
+	if ws.Lock != nil {
+		info.Who = ws.Lock.Holder()
+	}
+	w.Header().Set("Content-type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(info)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), lookupHTTPCode(err))
+}
+
+func lookupHTTPCode(err error) int {
+	for target, code := range codes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,313 @@
+package httpbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers(t *testing.T) {
+	ctx := internal.AddSubjectToContext(context.Background(), &auth.User{Username: "bobby"})
+
+	t.Run("get state", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, &fakeStateService{current: []byte(`{"serial":1}`)})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, `{"serial":1}`, w.Body.String())
+	})
+
+	t.Run("get state by name", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme-corp"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, &fakeStateService{current: []byte(`{"serial":1}`)})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/v2/organizations/acme-corp/workspaces/dev/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, `{"serial":1}`, w.Body.String())
+	})
+
+	t.Run("get missing state", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 204, w.Code)
+	})
+
+	t.Run("update state", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		states := &fakeStateService{}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, states)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v2/workspaces/ws-123/http-backend", bytes.NewBufferString(`{"serial":2}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		require.NotNil(t, states.created)
+		assert.Equal(t, "ws-123", *states.created.WorkspaceID)
+		assert.Equal(t, `{"serial":2}`, string(states.created.State))
+	})
+
+	t.Run("update state locked by user", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("bobby", workspace.UserLock, workspace.LockOptions{}))
+		states := &fakeStateService{}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, states)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v2/workspaces/ws-123/http-backend", bytes.NewBufferString(`{"serial":2}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		assert.NotNil(t, states.created)
+	})
+
+	t.Run("update state locked by another user", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("alice", workspace.UserLock, workspace.LockOptions{}))
+		states := &fakeStateService{}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, states)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v2/workspaces/ws-123/http-backend", bytes.NewBufferString(`{"serial":2}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 423, w.Code)
+		assert.Nil(t, states.created)
+	})
+
+	t.Run("update state with lock ID", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("bobby", workspace.UserLock, workspace.LockOptions{ExternalID: "lock-1"}))
+		states := &fakeStateService{}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, states)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v2/workspaces/ws-123/http-backend?ID=lock-1", bytes.NewBufferString(`{"serial":2}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		assert.NotNil(t, states.created)
+	})
+
+	t.Run("update state with mismatching lock ID", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("bobby", workspace.UserLock, workspace.LockOptions{ExternalID: "lock-1"}))
+		states := &fakeStateService{}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, states)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v2/workspaces/ws-123/http-backend?ID=lock-2", bytes.NewBufferString(`{"serial":2}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 409, w.Code)
+		assert.Nil(t, states.created)
+		var info lockInfo
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+		assert.Equal(t, "lock-1", info.ID)
+		assert.Equal(t, "bobby", info.Who)
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("LOCK", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("lock with lock info", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		workspaces := &fakeWorkspaceService{ws: ws}
+		r := newTestRouter(workspaces, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		body := bytes.NewBufferString(`{"ID":"lock-1","Operation":"OperationTypeApply","Who":"bobby@laptop"}`)
+		req := httptest.NewRequest("LOCK", "/api/v2/workspaces/ws-123/http-backend", body)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		require.NotNil(t, workspaces.locked)
+		assert.Equal(t, "lock-1", workspaces.locked.ExternalID)
+		assert.Equal(t, "terraform OperationTypeApply by bobby@laptop", workspaces.locked.Reason)
+	})
+
+	t.Run("lock workspace locked by terraform", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("alice", workspace.UserLock, workspace.LockOptions{ExternalID: "lock-1"}))
+		r := newTestRouter(&fakeWorkspaceService{ws: ws, lockErr: internal.ErrWorkspaceAlreadyLocked}, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("LOCK", "/api/v2/workspaces/ws-123/http-backend", bytes.NewBufferString(`{"ID":"lock-2"}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 423, w.Code)
+		var info lockInfo
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+		assert.Equal(t, "lock-1", info.ID)
+		assert.Equal(t, "alice", info.Who)
+	})
+
+	t.Run("lock already locked workspace", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws, lockErr: internal.ErrWorkspaceAlreadyLocked}, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("LOCK", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 423, w.Code)
+		var info lockInfo
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+		assert.Equal(t, "ws-123", info.ID)
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws}, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("UNLOCK", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("unlock with lock info", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("bobby", workspace.UserLock, workspace.LockOptions{ExternalID: "lock-1"}))
+		workspaces := &fakeWorkspaceService{ws: ws}
+		r := newTestRouter(workspaces, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("UNLOCK", "/api/v2/workspaces/ws-123/http-backend", bytes.NewBufferString(`{"ID":"lock-1"}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, []bool{false}, workspaces.unlocked)
+	})
+
+	t.Run("unlock with mismatching lock ID", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("bobby", workspace.UserLock, workspace.LockOptions{ExternalID: "lock-1"}))
+		workspaces := &fakeWorkspaceService{ws: ws}
+		r := newTestRouter(workspaces, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("UNLOCK", "/api/v2/workspaces/ws-123/http-backend", bytes.NewBufferString(`{"ID":"lock-2"}`))
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 409, w.Code)
+		assert.Empty(t, workspaces.unlocked)
+	})
+
+	t.Run("force unlock another user's lock", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		require.NoError(t, ws.Enlock("alice", workspace.UserLock, workspace.LockOptions{ExternalID: "lock-1"}))
+		workspaces := &fakeWorkspaceService{ws: ws, lockErr: internal.ErrWorkspaceLockedByDifferentUser, forceOK: true}
+		r := newTestRouter(workspaces, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("UNLOCK", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, []bool{false, true}, workspaces.unlocked)
+	})
+
+	t.Run("unlock another user's lock", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		r := newTestRouter(&fakeWorkspaceService{ws: ws, lockErr: internal.ErrWorkspaceLockedByDifferentUser}, &fakeStateService{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("UNLOCK", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+
+		assert.Equal(t, 423, w.Code)
+	})
+}
+
+type (
+	fakeWorkspaceService struct {
+		ws      *workspace.Workspace
+		lockErr error
+		// forceOK permits force unlocking the workspace regardless of lockErr
+		forceOK bool
+
+		locked   *workspace.LockOptions
+		unlocked []bool // force parameter of each unlock call
+
+		workspace.Service
+	}
+
+	fakeStateService struct {
+		current []byte
+		created *state.CreateStateVersionOptions
+
+		state.Service
+	}
+)
+
+func newTestRouter(workspaces *fakeWorkspaceService, states *fakeStateService) *mux.Router {
+	h := &Handlers{WorkspaceService: workspaces, StateService: states}
+	r := mux.NewRouter()
+	h.AddHandlers(r)
+	return r
+}
+
+func (f *fakeWorkspaceService) GetWorkspace(context.Context, string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
+
+func (f *fakeWorkspaceService) GetWorkspaceByName(context.Context, string, string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
+
+func (f *fakeWorkspaceService) LockWorkspace(_ context.Context, _ string, _ *string, opts workspace.LockOptions) (*workspace.Workspace, error) {
+	f.locked = &opts
+	return f.ws, f.lockErr
+}
+
+func (f *fakeWorkspaceService) UnlockWorkspace(_ context.Context, _ string, _ *string, force bool) (*workspace.Workspace, error) {
+	f.unlocked = append(f.unlocked, force)
+	if force && f.forceOK {
+		return f.ws, nil
+	}
+	return f.ws, f.lockErr
+}
+
+func (f *fakeStateService) DownloadCurrentState(context.Context, string) ([]byte, error) {
+	if f.current == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.current, nil
+}
+
+func (f *fakeStateService) CreateStateVersion(_ context.Context, opts state.CreateStateVersionOptions) (*state.Version, error) {
+	f.created = &opts
+	return &state.Version{}, nil
+}
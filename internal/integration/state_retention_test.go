@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_StateRetention(t *testing.T) {
+	integrationTest(t)
+
+	t.Run("pinned version cannot be deleted", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		sv := svc.createStateVersion(t, ctx, ws)
+		_ = svc.createStateVersion(t, ctx, ws)
+
+		pinned, err := svc.PinStateVersion(ctx, sv.ID)
+		require.NoError(t, err)
+		assert.True(t, pinned.Pinned)
+
+		err = svc.DeleteStateVersion(ctx, sv.ID)
+		assert.Equal(t, state.ErrPinnedVersionDeletionAttempt, err)
+
+		_, err = svc.UnpinStateVersion(ctx, sv.ID)
+		require.NoError(t, err)
+
+		err = svc.DeleteStateVersion(ctx, sv.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("organization policy", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+
+		_, err := svc.GetOrganizationStateRetentionPolicy(ctx, org.Name)
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+
+		want, err := svc.SetOrganizationStateRetentionPolicy(ctx, org.Name, state.SetRetentionPolicyOptions{
+			KeepLast: internal.Int(10),
+		})
+		require.NoError(t, err)
+
+		got, err := svc.GetOrganizationStateRetentionPolicy(ctx, org.Name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		err = svc.DeleteOrganizationStateRetentionPolicy(ctx, org.Name)
+		require.NoError(t, err)
+	})
+
+	t.Run("list prunable versions", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, org)
+		sv1 := svc.createStateVersion(t, ctx, ws)
+		sv2 := svc.createStateVersion(t, ctx, ws)
+		sv3 := svc.createStateVersion(t, ctx, ws)
+		_ = svc.createStateVersion(t, ctx, ws)
+
+		// without a policy nothing is prunable
+		got, err := svc.ListPrunableStateVersions(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		_, err = svc.SetOrganizationStateRetentionPolicy(ctx, org.Name, state.SetRetentionPolicyOptions{
+			KeepLast: internal.Int(1),
+		})
+		require.NoError(t, err)
+		_, err = svc.PinStateVersion(ctx, sv2.ID)
+		require.NoError(t, err)
+
+		got, err = svc.ListPrunableStateVersions(ctx, ws.ID)
+		require.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, sv3.ID, got[0].ID)
+			assert.Equal(t, sv1.ID, got[1].ID)
+		}
+
+		// workspace policy overrides organization policy
+		_, err = svc.SetWorkspaceStateRetentionPolicy(ctx, ws.ID, state.SetRetentionPolicyOptions{
+			KeepLast: internal.Int(2),
+		})
+		require.NoError(t, err)
+
+		got, err = svc.ListPrunableStateVersions(ctx, ws.ID)
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, sv1.ID, got[0].ID)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtask"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_WorkspaceClone(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+	source, err := svc.CreateWorkspace(ctx, workspace.CreateOptions{
+		Name:             internal.String("dev"),
+		Organization:     internal.String(org.Name),
+		AutoApply:        internal.Bool(true),
+		WorkingDirectory: internal.String("envs/dev"),
+		Template:         internal.Bool(true),
+		Tags:             []workspace.TagSpec{{Name: "foo"}, {Name: "bar"}},
+	})
+	require.NoError(t, err)
+	team := svc.createTeam(t, ctx, org)
+	err = svc.SetPermission(ctx, source.ID, team.Name, rbac.WorkspacePlanRole)
+	require.NoError(t, err)
+	_ = svc.createNotificationConfig(t, ctx, source)
+	_ = svc.createVariable(t, ctx, source)
+	_, err = svc.CreateVariable(ctx, source.ID, variable.CreateVariableOptions{
+		Key:       internal.String("secret"),
+		Value:     internal.String("topsecret"),
+		Category:  variable.VariableCategoryPtr(variable.CategoryEnv),
+		Sensitive: internal.Bool(true),
+	})
+	require.NoError(t, err)
+	_, err = svc.SetApprovalPolicy(ctx, source.ID, workspace.SetApprovalPolicyOptions{
+		Required:      internal.Int(2),
+		Teams:         []string{team.Name},
+		ExcludeAuthor: internal.Bool(true),
+	})
+	require.NoError(t, err)
+	task, err := svc.CreateRunTask(ctx, runtask.CreateOptions{
+		Organization: org.Name,
+		Name:         "checkov",
+		URL:          "https://checkov.example.com",
+	})
+	require.NoError(t, err)
+	_, err = svc.AttachRunTask(ctx, source.ID, runtask.AttachOptions{
+		RunTaskID:        task.ID,
+		Stage:            run.PrePlanTaskStage,
+		EnforcementLevel: runtask.MandatoryEnforcement,
+	})
+	require.NoError(t, err)
+
+	t.Run("list templates", func(t *testing.T) {
+		_ = svc.createWorkspace(t, ctx, org)
+
+		got, err := svc.ListWorkspaces(ctx, workspace.ListOptions{
+			Organization:  internal.String(org.Name),
+			TemplatesOnly: true,
+		})
+		require.NoError(t, err)
+		if assert.Equal(t, 1, len(got.Items)) {
+			assert.Equal(t, source.ID, got.Items[0].ID)
+		}
+	})
+
+	t.Run("clone settings only", func(t *testing.T) {
+		clone, err := svc.CloneWorkspace(ctx, source.ID, workspace.CloneOptions{
+			Name: internal.String("settings-only"),
+		})
+		require.NoError(t, err)
+
+		assert.True(t, clone.AutoApply)
+		assert.Equal(t, "envs/dev", clone.WorkingDirectory)
+		assert.False(t, clone.Template)
+		assert.Empty(t, clone.Tags)
+
+		variables, err := svc.ListVariables(ctx, clone.ID)
+		require.NoError(t, err)
+		assert.Empty(t, variables)
+
+		configs, err := svc.ListNotificationConfigurations(ctx, clone.ID)
+		require.NoError(t, err)
+		assert.Empty(t, configs)
+
+		policy, err := svc.GetPolicy(ctx, clone.ID)
+		require.NoError(t, err)
+		assert.Empty(t, policy.Permissions)
+	})
+
+	t.Run("clone approval policy", func(t *testing.T) {
+		clone, err := svc.CloneWorkspace(ctx, source.ID, workspace.CloneOptions{
+			Name: internal.String("approval-policy"),
+		})
+		require.NoError(t, err)
+
+		policy, err := svc.GetApprovalPolicy(ctx, clone.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, policy.Required)
+		assert.Equal(t, []string{team.Name}, policy.Teams)
+		assert.True(t, policy.ExcludeAuthor)
+	})
+
+	t.Run("clone run tasks", func(t *testing.T) {
+		clone, err := svc.CloneWorkspace(ctx, source.ID, workspace.CloneOptions{
+			Name: internal.String("run-tasks"),
+		})
+		require.NoError(t, err)
+
+		tasks, err := svc.ListWorkspaceRunTasks(ctx, clone.ID)
+		require.NoError(t, err)
+		if assert.Equal(t, 1, len(tasks)) {
+			assert.Equal(t, task.ID, tasks[0].RunTask.ID)
+			assert.Equal(t, run.PrePlanTaskStage, tasks[0].Stage)
+			assert.Equal(t, runtask.MandatoryEnforcement, tasks[0].EnforcementLevel)
+		}
+	})
+
+	t.Run("clone everything but sensitive variables", func(t *testing.T) {
+		clone, err := svc.CloneWorkspace(ctx, source.ID, workspace.CloneOptions{
+			Name:          internal.String("everything"),
+			Variables:     true,
+			Permissions:   true,
+			Notifications: true,
+			Tags:          true,
+		})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"foo", "bar"}, clone.Tags)
+
+		variables, err := svc.ListVariables(ctx, clone.ID)
+		require.NoError(t, err)
+		if assert.Equal(t, 1, len(variables)) {
+			assert.False(t, variables[0].Sensitive)
+		}
+
+		configs, err := svc.ListNotificationConfigurations(ctx, clone.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(configs))
+
+		policy, err := svc.GetPolicy(ctx, clone.ID)
+		require.NoError(t, err)
+		if assert.Equal(t, 1, len(policy.Permissions)) {
+			assert.Equal(t, team.Name, policy.Permissions[0].Team)
+			assert.Equal(t, rbac.WorkspacePlanRole, policy.Permissions[0].Role)
+		}
+	})
+
+	t.Run("clone sensitive variables", func(t *testing.T) {
+		clone, err := svc.CloneWorkspace(ctx, source.ID, workspace.CloneOptions{
+			Name:               internal.String("sensitive"),
+			SensitiveVariables: true,
+		})
+		require.NoError(t, err)
+
+		variables, err := svc.ListVariables(ctx, clone.ID)
+		require.NoError(t, err)
+		if assert.Equal(t, 1, len(variables)) {
+			assert.Equal(t, "secret", variables[0].Key)
+			assert.Equal(t, "topsecret", variables[0].Value)
+		}
+	})
+
+	t.Run("clone with existing name", func(t *testing.T) {
+		_, err := svc.CloneWorkspace(ctx, source.ID, workspace.CloneOptions{
+			Name: internal.String("dev"),
+		})
+		assert.ErrorIs(t, err, internal.ErrResourceAlreadyExists)
+	})
+}
@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_WorkspaceSafeDelete(t *testing.T) {
+	integrationTest(t)
+
+	t.Run("delete workspace without state", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+
+		_, err := svc.SafeDeleteWorkspace(ctx, ws.ID)
+		require.NoError(t, err)
+
+		_, err = svc.GetWorkspace(ctx, ws.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+
+	t.Run("refuse to delete workspace with resources", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		_ = svc.createStateVersion(t, ctx, ws)
+
+		_, err := svc.SafeDeleteWorkspace(ctx, ws.ID)
+		assert.Equal(t, workspace.ErrWorkspaceHasResources, err)
+
+		// force deletion
+		_, err = svc.DeleteWorkspace(ctx, ws.ID)
+		require.NoError(t, err)
+	})
+}
+
+// TestIntegration_WorkspaceDestroyAndDelete demonstrates destroying a
+// workspace's resources and then deleting the workspace.
+func TestIntegration_WorkspaceDestroyAndDelete(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	ws, err := daemon.CreateWorkspace(ctx, workspace.CreateOptions{
+		Name:         internal.String(t.Name()),
+		Organization: internal.String(org.Name),
+		AutoApply:    internal.Bool(true),
+	})
+	require.NoError(t, err)
+
+	// create a resource
+	root := t.TempDir()
+	err = os.WriteFile(filepath.Join(root, "main.tf"), []byte(`resource "random_pet" "cat" {}`), 0o777)
+	require.NoError(t, err)
+	tarball, err := internal.Pack(root)
+	require.NoError(t, err)
+	cv := daemon.createConfigurationVersion(t, ctx, ws, nil)
+	err = daemon.UploadConfig(ctx, cv.ID, tarball)
+	require.NoError(t, err)
+	_ = daemon.createRun(t, ctx, ws, cv)
+	for event := range daemon.sub {
+		if r, ok := event.Payload.(*run.Run); ok {
+			if r.Status == internal.RunApplied {
+				break
+			}
+			require.False(t, r.Done(), "run unexpectedly finished with status %s", r.Status)
+		}
+	}
+
+	// workspace cannot be safely deleted whilst it manages a resource
+	_, err = daemon.SafeDeleteWorkspace(ctx, ws.ID)
+	require.Equal(t, workspace.ErrWorkspaceHasResources, err)
+
+	// destroy resource and wait for workspace to be deleted
+	_, err = daemon.DestroyAndDeleteWorkspace(ctx, ws.ID)
+	require.NoError(t, err)
+	for event := range daemon.sub {
+		if deleted, ok := event.Payload.(*workspace.Workspace); ok && event.Type == pubsub.DeletedEvent {
+			assert.Equal(t, ws.ID, deleted.ID)
+			break
+		}
+		if r, ok := event.Payload.(*run.Run); ok {
+			require.NotContains(t, []internal.RunStatus{internal.RunErrored, internal.RunCanceled, internal.RunDiscarded}, r.Status)
+		}
+	}
+}
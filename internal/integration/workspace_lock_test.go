@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_WorkspaceLock(t *testing.T) {
+	integrationTest(t)
+
+	t.Run("lock with reason and expiry", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		expiry := internal.CurrentTimestamp().Add(time.Hour)
+
+		_, err := svc.LockWorkspace(ctx, ws.ID, nil, workspace.LockOptions{
+			Reason:    "upgrading providers",
+			ExpiresAt: &expiry,
+		})
+		require.NoError(t, err)
+
+		got, err := svc.GetWorkspace(ctx, ws.ID)
+		require.NoError(t, err)
+		require.True(t, got.Locked())
+		assert.Equal(t, "upgrading providers", got.Lock.Reason)
+		assert.Equal(t, expiry, *got.Lock.ExpiresAt)
+		assert.False(t, got.Lock.LockedAt.IsZero())
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		expiry := internal.CurrentTimestamp().Add(-time.Hour)
+
+		_, err := svc.LockWorkspace(ctx, ws.ID, nil, workspace.LockOptions{ExpiresAt: &expiry})
+		assert.Equal(t, workspace.ErrInvalidLockExpiry, err)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		user, _ := internal.SubjectFromContext(ctx)
+
+		_, err := svc.LockWorkspace(ctx, ws.ID, nil, workspace.LockOptions{Reason: "testing"})
+		require.NoError(t, err)
+		_, err = svc.UnlockWorkspace(ctx, ws.ID, nil, true)
+		require.NoError(t, err)
+
+		got, err := svc.ListWorkspaceLockEvents(ctx, ws.ID, resource.PageOptions{})
+		require.NoError(t, err)
+		var actions []workspace.LockEventAction
+		for _, event := range got.Items {
+			actions = append(actions, event.Action)
+			assert.Equal(t, workspace.UserLock, event.Kind)
+			assert.Equal(t, user.String(), event.Holder)
+			assert.Equal(t, "testing", event.Reason)
+		}
+		// events may share a timestamp so don't rely on their order
+		assert.ElementsMatch(t, []workspace.LockEventAction{
+			workspace.LockEventLocked,
+			workspace.LockEventForceUnlocked,
+		}, actions)
+	})
+}
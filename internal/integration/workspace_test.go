@@ -392,7 +392,7 @@ func TestWorkspace(t *testing.T) {
 		svc, org, ctx := setup(t, nil)
 		ws := svc.createWorkspace(t, ctx, org)
 
-		got, err := svc.LockWorkspace(ctx, ws.ID, nil)
+		got, err := svc.LockWorkspace(ctx, ws.ID, nil, workspace.LockOptions{})
 		require.NoError(t, err)
 		assert.True(t, got.Locked())
 
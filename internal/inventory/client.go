@@ -0,0 +1,49 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/api/types"
+	"github.com/leg100/otf/internal/resource"
+)
+
+type Client struct {
+	internal.JSONAPIClient
+}
+
+func (c *Client) SearchResources(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Resource], error) {
+	u := fmt.Sprintf("organizations/%s/resources", url.QueryEscape(organization))
+	req, err := c.NewRequest("GET", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &types.InventoryResourceList{}
+	if err := c.Do(ctx, req, list); err != nil {
+		return nil, err
+	}
+
+	page := resource.Page[*Resource]{
+		Pagination: (*resource.Pagination)(list.Pagination),
+	}
+	for _, from := range list.Items {
+		page.Items = append(page.Items, &Resource{
+			ID:              from.ID,
+			Organization:    organization,
+			WorkspaceID:     from.Workspace.ID,
+			WorkspaceName:   from.WorkspaceName,
+			StateVersionID:  from.StateVersion.ID,
+			Address:         from.Address,
+			Module:          from.Module,
+			Type:            from.Type,
+			Name:            from.Name,
+			Provider:        from.Provider,
+			ProviderVersion: from.ProviderVersion,
+			RemoteID:        from.RemoteID,
+		})
+	}
+	return &page, nil
+}
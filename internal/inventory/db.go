@@ -0,0 +1,180 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is an inventory database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// pgrow is a database row for an inventory resource
+	pgrow struct {
+		InventoryResourceID pgtype.Text `json:"inventory_resource_id"`
+		WorkspaceID         pgtype.Text `json:"workspace_id"`
+		StateVersionID      pgtype.Text `json:"state_version_id"`
+		Address             pgtype.Text `json:"address"`
+		Module              pgtype.Text `json:"module"`
+		Type                pgtype.Text `json:"type"`
+		Name                pgtype.Text `json:"name"`
+		Provider            pgtype.Text `json:"provider"`
+		ProviderVersion     pgtype.Text `json:"provider_version"`
+		RemoteID            pgtype.Text `json:"remote_id"`
+		AttributeValues     []string    `json:"attribute_values"`
+		WorkspaceName       pgtype.Text `json:"workspace_name"`
+		OrganizationName    pgtype.Text `json:"organization_name"`
+	}
+)
+
+func (r pgrow) toResource() *Resource {
+	res := &Resource{
+		ID:             r.InventoryResourceID.String,
+		Organization:   r.OrganizationName.String,
+		WorkspaceID:    r.WorkspaceID.String,
+		WorkspaceName:  r.WorkspaceName.String,
+		StateVersionID: r.StateVersionID.String,
+		Address:        r.Address.String,
+		Module:         r.Module.String,
+		Type:           r.Type.String,
+		Name:           r.Name.String,
+		Provider:       r.Provider.String,
+		values:         r.AttributeValues,
+	}
+	if r.ProviderVersion.Status == pgtype.Present {
+		res.ProviderVersion = &r.ProviderVersion.String
+	}
+	if r.RemoteID.Status == pgtype.Present {
+		res.RemoteID = &r.RemoteID.String
+	}
+	return res
+}
+
+// replace replaces a workspace's resources with those of the given state
+// version, and records the state version as indexed.
+func (db *pgdb) replace(ctx context.Context, workspaceID, stateVersionID string, resources []*Resource) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteInventoryResourcesByWorkspaceID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		for _, r := range resources {
+			values := r.values
+			if values == nil {
+				values = []string{}
+			}
+			_, err := q.InsertInventoryResource(ctx, pggen.InsertInventoryResourceParams{
+				InventoryResourceID: sql.String(r.ID),
+				WorkspaceID:         sql.String(r.WorkspaceID),
+				StateVersionID:      sql.String(r.StateVersionID),
+				Address:             sql.String(r.Address),
+				Module:              sql.String(r.Module),
+				Type:                sql.String(r.Type),
+				Name:                sql.String(r.Name),
+				Provider:            sql.String(r.Provider),
+				ProviderVersion:     sql.StringPtr(r.ProviderVersion),
+				RemoteID:            sql.StringPtr(r.RemoteID),
+				AttributeValues:     values,
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		if _, err := q.UpsertInventoryIndex(ctx, sql.String(workspaceID), sql.String(stateVersionID)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// getLockFile retrieves the dependency lock file of a run; nil is returned if
+// the run has no lock file.
+func (db *pgdb) getLockFile(ctx context.Context, runID string) ([]byte, error) {
+	lockFile, err := db.Conn(ctx).GetLockFileByID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return lockFile, nil
+}
+
+func (db *pgdb) search(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Resource], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	// an unset filter matches everything
+	like := func(s string) pgtype.Text {
+		if s == "" {
+			return sql.String("%")
+		}
+		return sql.String(s)
+	}
+	var below pgtype.Text
+	if opts.ProviderVersionBelow != "" {
+		below = sql.String(opts.ProviderVersionBelow)
+	} else {
+		below = sql.NullString()
+	}
+	tags := opts.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	q.FindInventoryResourcesBatch(batch, pggen.FindInventoryResourcesParams{
+		OrganizationName:     sql.String(organization),
+		Type:                 like(opts.Type),
+		Module:               like(opts.Module),
+		Provider:             like(opts.Provider),
+		Search:               sql.String(opts.Search),
+		ProviderVersionBelow: below,
+		Tags:                 tags,
+		Limit:                opts.GetLimit(),
+		Offset:               opts.GetOffset(),
+	})
+	q.CountInventoryResourcesBatch(batch, pggen.CountInventoryResourcesParams{
+		OrganizationName:     sql.String(organization),
+		Type:                 like(opts.Type),
+		Module:               like(opts.Module),
+		Provider:             like(opts.Provider),
+		Search:               sql.String(opts.Search),
+		ProviderVersionBelow: below,
+		Tags:                 tags,
+	})
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindInventoryResourcesScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountInventoryResourcesScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*Resource, len(rows))
+	for i, r := range rows {
+		items[i] = pgrow(r).toResource()
+	}
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
+}
+
+// listUnindexedStateVersionIDs lists the IDs of the current state versions of
+// workspaces that have no indexed resources.
+func (db *pgdb) listUnindexedStateVersionIDs(ctx context.Context) ([]string, error) {
+	rows, err := db.Conn(ctx).FindUnindexedStateVersionIDs(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
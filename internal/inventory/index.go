@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/state"
+)
+
+// BackfillerLockID is a unique ID guaranteeing only one backfiller on a
+// cluster is running at any time.
+const BackfillerLockID int64 = 179366396344335606
+
+type (
+	// indexer indexes the resources in state versions.
+	indexer struct {
+		logr.Logger
+
+		db *pgdb
+	}
+
+	// Backfiller indexes the resources of workspaces whose current state
+	// version has not been indexed, i.e. state versions created before the
+	// inventory was introduced, or whose indexing failed.
+	Backfiller struct {
+		logr.Logger
+
+		state.StateService
+
+		db      *pgdb
+		indexer *indexer
+	}
+
+	BackfillerOptions struct {
+		logr.Logger
+		*sql.DB
+
+		state.StateService
+	}
+)
+
+// index replaces a workspace's resources with those in its new current state
+// version. The index is updated within its own transaction, which is nested
+// within any transaction in the context, ensuring a failure to index does not
+// abort the latter.
+func (i *indexer) index(ctx context.Context, sv *state.Version) error {
+	return i.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		// State does not record provider versions, so they are instead taken
+		// from the dependency lock file of the run that created the state
+		// version.
+		var versions map[string]string
+		if sv.RunID != nil {
+			lockFile, err := i.db.getLockFile(ctx, *sv.RunID)
+			if err != nil {
+				return err
+			}
+			if lockFile != nil {
+				versions, err = parseLockFile(lockFile)
+				if err != nil {
+					// proceed without provider versions rather than fail
+					// the indexing of the state version.
+					i.Error(err, "parsing lock file", "run", *sv.RunID)
+				}
+			}
+		}
+
+		resources, err := newResources(sv, versions)
+		if err != nil {
+			return err
+		}
+		if err := i.db.replace(ctx, sv.WorkspaceID, sv.ID, resources); err != nil {
+			return err
+		}
+		i.V(9).Info("indexed resources", "workspace", sv.WorkspaceID, "state_version", sv.ID, "count", len(resources))
+		return nil
+	})
+}
+
+func NewBackfiller(opts BackfillerOptions) *Backfiller {
+	db := &pgdb{opts.DB}
+	return &Backfiller{
+		Logger:       opts.Logger,
+		StateService: opts.StateService,
+		db:           db,
+		indexer:      &indexer{Logger: opts.Logger, db: db},
+	}
+}
+
+// Start indexes unindexed state versions and then returns. Should be invoked
+// in a go routine.
+func (b *Backfiller) Start(ctx context.Context) error {
+	ids, err := b.db.listUnindexedStateVersionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		sv, err := b.GetStateVersion(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := b.indexer.index(ctx, sv); err != nil {
+			// carry on with other state versions
+			b.Error(err, "indexing resources", "workspace", sv.WorkspaceID, "state_version", sv.ID)
+		}
+	}
+	b.V(1).Info("backfilled resource inventory", "state_versions", len(ids))
+	return nil
+}
@@ -0,0 +1,154 @@
+// Package inventory maintains an inventory of the resources managed by each
+// organization's workspaces, permitting resources to be searched across
+// workspaces.
+package inventory
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/state"
+)
+
+// versionRegex matches the version with which a provider version is compared
+// when searching, which is compared numerically component by component.
+var versionRegex = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+type (
+	// Resource is an instance of a resource managed by a workspace, indexed
+	// from the workspace's current state version.
+	Resource struct {
+		ID             string
+		Organization   string
+		WorkspaceID    string
+		WorkspaceName  string
+		StateVersionID string
+		// Address of the resource instance, e.g. module.vpc.aws_subnet.private[0]
+		Address string
+		// Module address, e.g. module.vpc; empty if the resource belongs to the
+		// root module.
+		Module string
+		Type   string
+		Name   string
+		// Provider source address, e.g. registry.terraform.io/hashicorp/aws
+		Provider string
+		// ProviderVersion is the version of the provider recorded in the
+		// dependency lock file of the run that created the state version; nil
+		// if unknown.
+		ProviderVersion *string
+		// RemoteID is the value of the resource's id attribute, which is
+		// usually the provider's identifier for the resource, e.g. the name of
+		// an S3 bucket; nil if the resource has no id attribute.
+		RemoteID *string
+
+		// values of the resource's top-level, non-sensitive, string attributes,
+		// against which searches are matched.
+		values []string
+	}
+
+	// SearchOptions are options for searching an organization's resources.
+	SearchOptions struct {
+		// Search matches resources whose address or the value of one of
+		// their attributes contains the search string.
+		Search string `schema:"search[query],omitempty"`
+		// Type filters resources by type, e.g. aws_s3_bucket.
+		Type string `schema:"filter[type],omitempty"`
+		// Provider filters resources by provider, either its source address,
+		// e.g. registry.terraform.io/hashicorp/aws, or its name, e.g. aws.
+		Provider string `schema:"filter[provider],omitempty"`
+		// Module filters resources by module address, e.g. module.vpc.
+		Module string `schema:"filter[module],omitempty"`
+		// ProviderVersionBelow filters resources by those managed with a
+		// provider version older than the given version, e.g. 5.0.
+		ProviderVersionBelow string `schema:"filter[provider-version-below],omitempty"`
+		// Tags filters resources by those belonging to workspaces with all of
+		// the given tags.
+		Tags []string `schema:"search[tags],omitempty"`
+
+		resource.PageOptions
+	}
+
+	// lockFile is terraform's dependency lock file, .terraform.lock.hcl
+	lockFile struct {
+		Providers []struct {
+			Source  string   `hcl:"source,label"`
+			Version string   `hcl:"version"`
+			Remain  hcl.Body `hcl:",remain"`
+		} `hcl:"provider,block"`
+	}
+)
+
+// ProviderName returns the name of the resource's provider, e.g. aws
+func (r *Resource) ProviderName() string {
+	return r.Provider[strings.LastIndex(r.Provider, "/")+1:]
+}
+
+func (opts SearchOptions) validate() error {
+	if opts.ProviderVersionBelow != "" && !versionRegex.MatchString(opts.ProviderVersionBelow) {
+		return internal.ErrInvalidProviderVersion
+	}
+	return nil
+}
+
+// newResources constructs the inventory of resources managed by a state
+// version. Data sources are not managed and are skipped. Provider versions are
+// looked up by provider source address.
+func newResources(sv *state.Version, providerVersions map[string]string) ([]*Resource, error) {
+	f, err := sv.File()
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	for _, res := range f.Resources {
+		if res.Mode == "data" {
+			continue
+		}
+		for _, inst := range res.Instances {
+			r := &Resource{
+				ID:             internal.NewID("res"),
+				WorkspaceID:    sv.WorkspaceID,
+				StateVersionID: sv.ID,
+				Address:        res.InstanceAddress(inst),
+				Module:         res.Module,
+				Type:           res.Type,
+				Name:           res.Name,
+				Provider:       res.ProviderSource(),
+			}
+			if version, ok := providerVersions[r.Provider]; ok {
+				r.ProviderVersion = &version
+			}
+			for k, v := range inst.Attributes {
+				s, ok := v.(string)
+				if !ok || s == "" || inst.Sensitive(k) {
+					continue
+				}
+				if k == "id" {
+					r.RemoteID = &s
+				}
+				r.values = append(r.values, s)
+			}
+			sort.Strings(r.values)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// parseLockFile parses a dependency lock file, returning the version of each
+// provider keyed by provider source address.
+func parseLockFile(b []byte) (map[string]string, error) {
+	var lf lockFile
+	if err := hclsimple.Decode(".terraform.lock.hcl", b, nil, &lf); err != nil {
+		return nil, err
+	}
+	versions := make(map[string]string, len(lf.Providers))
+	for _, p := range lf.Providers {
+		versions[p.Source] = p.Version
+	}
+	return versions, nil
+}
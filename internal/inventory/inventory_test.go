@@ -0,0 +1,102 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResources(t *testing.T) {
+	sv := &state.Version{
+		ID:          "sv-123",
+		WorkspaceID: "ws-123",
+		State: []byte(`{
+			"resources": [
+				{
+					"mode": "managed",
+					"type": "aws_s3_bucket",
+					"name": "logs",
+					"module": "module.storage",
+					"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+					"instances": [
+						{
+							"index_key": 0,
+							"attributes": {"id": "acme-logs", "arn": "arn:aws:s3:::acme-logs", "policy": "secret", "force_destroy": false},
+							"sensitive_attributes": [[{"type": "get_attr", "value": "policy"}]]
+						}
+					]
+				},
+				{
+					"mode": "data",
+					"type": "aws_caller_identity",
+					"name": "current",
+					"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+					"instances": [{"attributes": {"id": "123456789012"}}]
+				},
+				{
+					"mode": "managed",
+					"type": "random_pet",
+					"name": "name",
+					"provider": "provider[\"registry.terraform.io/hashicorp/random\"]",
+					"instances": [{"attributes": {"length": 2}}]
+				}
+			]
+		}`),
+	}
+
+	got, err := newResources(sv, map[string]string{
+		"registry.terraform.io/hashicorp/aws": "4.67.0",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+
+	// the data source is skipped
+	assert.Equal(t, "module.storage.aws_s3_bucket.logs[0]", got[0].Address)
+	assert.Equal(t, "module.storage", got[0].Module)
+	assert.Equal(t, "registry.terraform.io/hashicorp/aws", got[0].Provider)
+	assert.Equal(t, "aws", got[0].ProviderName())
+	assert.Equal(t, internal.String("4.67.0"), got[0].ProviderVersion)
+	assert.Equal(t, internal.String("acme-logs"), got[0].RemoteID)
+	// sensitive and non-string attributes are not searchable
+	assert.Equal(t, []string{"acme-logs", "arn:aws:s3:::acme-logs"}, got[0].values)
+
+	assert.Equal(t, "random_pet.name", got[1].Address)
+	assert.Nil(t, got[1].ProviderVersion)
+	assert.Nil(t, got[1].RemoteID)
+	assert.Nil(t, got[1].values)
+}
+
+func TestParseLockFile(t *testing.T) {
+	got, err := parseLockFile([]byte(`
+# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "4.67.0"
+  constraints = "~> 4.0"
+  hashes = [
+    "h1:dCRc4GqsyfqHEMjgtlM1EympBcgTmcTkWaJmtd91+KA=",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.5.1"
+}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"registry.terraform.io/hashicorp/aws":    "4.67.0",
+		"registry.terraform.io/hashicorp/random": "3.5.1",
+	}, got)
+}
+
+func TestSearchOptions_Validate(t *testing.T) {
+	assert.NoError(t, SearchOptions{}.validate())
+	assert.NoError(t, SearchOptions{ProviderVersionBelow: "5"}.validate())
+	assert.NoError(t, SearchOptions{ProviderVersionBelow: "5.0.1"}.validate())
+	assert.Equal(t, internal.ErrInvalidProviderVersion, SearchOptions{ProviderVersionBelow: "v5"}.validate())
+	assert.Equal(t, internal.ErrInvalidProviderVersion, SearchOptions{ProviderVersionBelow: "5.0-beta"}.validate())
+}
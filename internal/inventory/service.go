@@ -0,0 +1,93 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/state"
+)
+
+type (
+	InventoryService = Service
+
+	Service interface {
+		// SearchResources searches the resources managed by an
+		// organization's workspaces.
+		SearchResources(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Resource], error)
+	}
+
+	service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+		db           *pgdb
+		indexer      *indexer
+		web          *webHandlers
+	}
+
+	Options struct {
+		*sql.DB
+		html.Renderer
+		logr.Logger
+
+		state.StateService
+	}
+)
+
+func NewService(opts Options) *service {
+	db := &pgdb{opts.DB}
+	svc := service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           db,
+		indexer:      &indexer{Logger: opts.Logger, db: db},
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+	}
+
+	// Whenever a workspace's current state version changes, re-index the
+	// workspace's resources. The listener is invoked within the transaction
+	// creating the state version, and a failure to index is not allowed to
+	// fail its creation: the error is logged and the workspace is instead
+	// re-indexed by the backfiller the next time otfd starts, or upon its
+	// next state version, whichever comes first.
+	opts.StateService.AfterCreateStateVersion(func(ctx context.Context, sv *state.Version) error {
+		if err := svc.indexer.index(ctx, sv); err != nil {
+			svc.Error(err, "indexing resources", "workspace", sv.WorkspaceID, "state_version", sv.ID)
+		}
+		return nil
+	})
+
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+}
+
+func (s *service) SearchResources(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Resource], error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.SearchResourcesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	page, err := s.db.search(ctx, organization, opts)
+	if err != nil {
+		s.Error(err, "searching resources", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("searched resources", "organization", organization, "count", len(page.Items), "subject", subject)
+	return page, nil
+}
@@ -0,0 +1,64 @@
+package inventory
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/resource"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/resources", h.search).Methods("GET")
+}
+
+func (h *webHandlers) search(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		SearchOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	// the search form always submits a tag input, which is empty unless the
+	// user fills it in.
+	tags := params.Tags[:0]
+	for _, t := range params.Tags {
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	params.Tags = tags
+
+	page, err := h.svc.SearchResources(r.Context(), params.Organization, params.SearchOptions)
+	if errors.Is(err, internal.ErrInvalidProviderVersion) {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("resource_list.tmpl", w, struct {
+		organization.OrganizationPage
+		*resource.Page[*Resource]
+		SearchOptions
+	}{
+		OrganizationPage: organization.NewPage(r, "resources", params.Organization),
+		Page:             page,
+		SearchOptions:    params.SearchOptions,
+	})
+}
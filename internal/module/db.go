@@ -27,6 +27,8 @@ type (
 		Provider         pgtype.Text            `json:"provider"`
 		Status           pgtype.Text            `json:"status"`
 		OrganizationName pgtype.Text            `json:"organization_name"`
+		TestWorkspaceID  pgtype.Text            `json:"test_workspace_id"`
+		TestsRequired    bool                   `json:"tests_required"`
 		ModuleConnection *pggen.RepoConnections `json:"module_connection"`
 		Webhook          *pggen.Webhooks        `json:"webhook"`
 		Versions         []pggen.ModuleVersions `json:"versions"`
@@ -54,6 +56,15 @@ func (db *pgdb) updateModuleStatus(ctx context.Context, moduleID string, status
 	return nil
 }
 
+func (db *pgdb) updateModuleTestSettings(ctx context.Context, moduleID string, workspaceID *string, required bool) error {
+	_, err := db.Conn(ctx).UpdateModuleTestSettingsByID(ctx, pggen.UpdateModuleTestSettingsByIDParams{
+		TestWorkspaceID: sql.StringPtr(workspaceID),
+		TestsRequired:   required,
+		ModuleID:        sql.String(moduleID),
+	})
+	return sql.Error(err)
+}
+
 func (db *pgdb) listModules(ctx context.Context, opts ListModulesOptions) ([]*Module, error) {
 	rows, err := db.Conn(ctx).ListModulesByOrganization(ctx, sql.String(opts.Organization))
 	if err != nil {
@@ -135,6 +146,19 @@ func (db *pgdb) getModuleByVersionID(ctx context.Context, versionID string) (*Mo
 	return moduleRow(row).toModule(), nil
 }
 
+func (db *pgdb) getModuleByTestRunID(ctx context.Context, runID string) (*Module, error) {
+	row, err := db.Conn(ctx).FindModuleByTestRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return moduleRow(row).toModule(), nil
+}
+
+func (db *pgdb) updateModuleVersionTestRun(ctx context.Context, versionID, runID string) error {
+	_, err := db.Conn(ctx).UpdateModuleVersionTestRunByID(ctx, sql.String(runID), sql.String(versionID))
+	return sql.Error(err)
+}
+
 func (db *pgdb) deleteModuleVersion(ctx context.Context, versionID string) error {
 	_, err := db.Conn(ctx).DeleteModuleVersionByID(ctx, sql.String(versionID))
 	return sql.Error(err)
@@ -156,13 +180,17 @@ func (db *pgdb) getTarball(ctx context.Context, versionID string) ([]byte, error
 // UnmarshalModuleRow unmarshals a database row into a module
 func (row moduleRow) toModule() *Module {
 	module := &Module{
-		ID:           row.ModuleID.String,
-		CreatedAt:    row.CreatedAt.Time.UTC(),
-		UpdatedAt:    row.UpdatedAt.Time.UTC(),
-		Name:         row.Name.String,
-		Provider:     row.Provider.String,
-		Status:       ModuleStatus(row.Status.String),
-		Organization: row.OrganizationName.String,
+		ID:            row.ModuleID.String,
+		CreatedAt:     row.CreatedAt.Time.UTC(),
+		UpdatedAt:     row.UpdatedAt.Time.UTC(),
+		Name:          row.Name.String,
+		Provider:      row.Provider.String,
+		Status:        ModuleStatus(row.Status.String),
+		Organization:  row.OrganizationName.String,
+		TestsRequired: row.TestsRequired,
+	}
+	if row.TestWorkspaceID.Status == pgtype.Present {
+		module.TestWorkspaceID = &row.TestWorkspaceID.String
 	}
 	if row.ModuleConnection != nil {
 		module.Connection = &repo.Connection{
@@ -173,7 +201,7 @@ func (row moduleRow) toModule() *Module {
 	// versions are always maintained in descending order
 	sort.Sort(byVersion(row.Versions))
 	for i := len(row.Versions) - 1; i >= 0; i-- {
-		module.Versions = append(module.Versions, ModuleVersion{
+		modver := ModuleVersion{
 			ID:          row.Versions[i].ModuleVersionID.String,
 			Version:     row.Versions[i].Version.String,
 			CreatedAt:   row.Versions[i].CreatedAt.Time.UTC(),
@@ -181,7 +209,11 @@ func (row moduleRow) toModule() *Module {
 			ModuleID:    row.Versions[i].ModuleID.String,
 			Status:      ModuleVersionStatus(row.Versions[i].Status.String),
 			StatusError: row.Versions[i].StatusError.String,
-		})
+		}
+		if row.Versions[i].TestRunID.Status == pgtype.Present {
+			modver.TestRunID = &row.Versions[i].TestRunID.String
+		}
+		module.Versions = append(module.Versions, modver)
 	}
 	return module
 }
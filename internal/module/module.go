@@ -24,6 +24,8 @@ const (
 	ModuleVersionStatusRegIngressReqFailed ModuleVersionStatus = "reg_ingress_req_failed"
 	ModuleVersionStatusRegIngressing       ModuleVersionStatus = "reg_ingressing"
 	ModuleVersionStatusRegIngressFailed    ModuleVersionStatus = "reg_ingress_failed"
+	ModuleVersionStatusTesting             ModuleVersionStatus = "testing"
+	ModuleVersionStatusTestsFailed         ModuleVersionStatus = "tests_failed"
 	ModuleVersionStatusOK                  ModuleVersionStatus = "ok"
 )
 
@@ -40,6 +42,13 @@ type (
 		Status       ModuleStatus
 		Versions     []ModuleVersion  // versions sorted in descending order
 		Connection   *repo.Connection // optional vcs repo connection
+		// TestWorkspaceID is the ID of the workspace in which the tests of
+		// each new version are run. Nil if versions are not tested.
+		TestWorkspaceID *string
+		// TestsRequired specifies whether a version's tests must pass before
+		// the version is made available. Otherwise a version is made
+		// available regardless of the outcome of its tests.
+		TestsRequired bool
 	}
 
 	ModuleStatus string
@@ -52,6 +61,9 @@ type (
 		UpdatedAt   time.Time
 		Status      ModuleVersionStatus
 		StatusError string
+		// TestRunID is the ID of the run testing the version. Nil if the
+		// version has not been tested.
+		TestRunID *string
 		// TODO: download counters
 	}
 
@@ -77,6 +89,12 @@ type (
 		ModuleID string
 		Version  string
 	}
+	UpdateOptions struct {
+		// TestWorkspaceID sets the workspace in which versions are tested.
+		// An empty string disables testing.
+		TestWorkspaceID *string
+		TestsRequired   *bool
+	}
 	UpdateModuleVersionStatusOptions struct {
 		ID     string
 		Status ModuleVersionStatus
@@ -158,3 +176,14 @@ func (m *Module) Latest() *ModuleVersion {
 	}
 	return nil
 }
+
+// versionByTestRunID retrieves the version tested by the given run. If there
+// is no such version, nil is returned.
+func (m *Module) versionByTestRunID(runID string) *ModuleVersion {
+	for _, modver := range m.Versions {
+		if modver.TestRunID != nil && *modver.TestRunID == runID {
+			return &modver
+		}
+	}
+	return nil
+}
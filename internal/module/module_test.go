@@ -3,6 +3,7 @@ package module
 import (
 	"testing"
 
+	"github.com/leg100/otf/internal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,3 +25,19 @@ func TestModule(t *testing.T) {
 		assert.Equal(t, &modver2, mod.Version("v2"))
 	})
 }
+
+func TestModule_Testing(t *testing.T) {
+	modver1 := ModuleVersion{Version: "v1", Status: ModuleVersionStatusOK, TestRunID: internal.String("run-1")}
+	modver2 := ModuleVersion{Version: "v2", Status: ModuleVersionStatusTestsFailed, TestRunID: internal.String("run-2")}
+	modver3 := ModuleVersion{Version: "v3", Status: ModuleVersionStatusTesting, TestRunID: internal.String("run-3")}
+	mod := &Module{Versions: []ModuleVersion{modver3, modver2, modver1}}
+
+	t.Run("latest skips untested and failed versions", func(t *testing.T) {
+		assert.Equal(t, &modver1, mod.Latest())
+	})
+
+	t.Run("version by test run", func(t *testing.T) {
+		assert.Equal(t, &modver3, mod.versionByTestRunID("run-3"))
+		assert.Nil(t, mod.versionByTestRunID("run-4"))
+	})
+}
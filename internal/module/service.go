@@ -2,6 +2,7 @@ package module
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,14 +11,17 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/cloud"
+	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/repo"
+	"github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
 	"github.com/leg100/otf/internal/vcsprovider"
+	"github.com/leg100/otf/internal/workspace"
 	"github.com/leg100/surl"
 )
 
@@ -34,6 +38,8 @@ type (
 		GetModule(ctx context.Context, opts GetModuleOptions) (*Module, error)
 		GetModuleByID(ctx context.Context, id string) (*Module, error)
 		GetModuleByRepoID(ctx context.Context, repoID uuid.UUID) (*Module, error)
+		// UpdateModule updates the settings for testing a module's versions.
+		UpdateModule(ctx context.Context, id string, opts UpdateOptions) (*Module, error)
 		DeleteModule(ctx context.Context, id string) (*Module, error)
 		GetModuleInfo(ctx context.Context, versionID string) (*TerraformModule, error)
 
@@ -43,6 +49,7 @@ type (
 		downloadVersion(ctx context.Context, versionID string) ([]byte, error)
 
 		updateModuleStatus(ctx context.Context, module *Module, status ModuleStatus) (*Module, error)
+		handleTestRun(ctx context.Context, run *run.Run) error
 	}
 
 	service struct {
@@ -50,8 +57,11 @@ type (
 		logr.Logger
 		*publisher
 
-		db   *pgdb
-		repo repo.Service
+		db         *pgdb
+		repo       repo.Service
+		runs       run.Service
+		configs    configversion.Service
+		workspaces workspace.Service
 
 		organization internal.Authorizer
 
@@ -68,6 +78,9 @@ type (
 		*surl.Signer
 		html.Renderer
 		repo.RepoService
+		RunService                  run.Service
+		ConfigurationVersionService configversion.Service
+		WorkspaceService            workspace.Service
 	}
 )
 
@@ -78,6 +91,9 @@ func NewService(opts Options) *service {
 		organization:       &organization.Authorizer{Logger: opts.Logger},
 		db:                 &pgdb{opts.DB},
 		repo:               opts.RepoService,
+		runs:               opts.RunService,
+		configs:            opts.ConfigurationVersionService,
+		workspaces:         opts.WorkspaceService,
 	}
 
 	svc.api = &api{
@@ -88,6 +104,7 @@ func NewService(opts Options) *service {
 		HostnameService:    opts.HostnameService,
 		Renderer:           opts.Renderer,
 		VCSProviderService: opts.VCSProviderService,
+		WorkspaceService:   opts.WorkspaceService,
 		svc:                &svc,
 	}
 	publisher := &publisher{
@@ -297,6 +314,45 @@ func (s *service) GetModuleByRepoID(ctx context.Context, id uuid.UUID) (*Module,
 	return s.db.getModuleByWebhookID(ctx, id)
 }
 
+func (s *service) UpdateModule(ctx context.Context, id string, opts UpdateOptions) (*Module, error) {
+	module, err := s.db.getModuleByID(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving module", "id", id)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateModuleAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TestWorkspaceID != nil {
+		if *opts.TestWorkspaceID == "" {
+			module.TestWorkspaceID = nil
+		} else {
+			ws, err := s.workspaces.GetWorkspace(ctx, *opts.TestWorkspaceID)
+			if err != nil {
+				return nil, err
+			}
+			// tests must be run within the module's own organization
+			if ws.Organization != module.Organization {
+				return nil, internal.ErrResourceNotFound
+			}
+			module.TestWorkspaceID = &ws.ID
+		}
+	}
+	if opts.TestsRequired != nil {
+		module.TestsRequired = *opts.TestsRequired
+	}
+
+	if err := s.db.updateModuleTestSettings(ctx, module.ID, module.TestWorkspaceID, module.TestsRequired); err != nil {
+		s.Error(err, "updating module", "subject", subject, "module", module)
+		return nil, err
+	}
+	s.V(1).Info("updated module", "subject", subject, "module", module)
+	return module, nil
+}
+
 func (s *service) DeleteModule(ctx context.Context, id string) (*Module, error) {
 	module, err := s.db.getModuleByID(ctx, id)
 	if err != nil {
@@ -386,6 +442,13 @@ func (s *service) uploadVersion(ctx context.Context, versionID string, tarball [
 		})
 	}
 
+	// versions of a module with a test workspace are only made available once
+	// they've been tested.
+	status := ModuleVersionStatusOK
+	if module.TestWorkspaceID != nil {
+		status = ModuleVersionStatusTesting
+	}
+
 	// save tarball, set status, and make it the latest version
 	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		if err := s.db.saveTarball(ctx, versionID, tarball); err != nil {
@@ -393,7 +456,7 @@ func (s *service) uploadVersion(ctx context.Context, versionID string, tarball [
 		}
 		err = s.db.updateModuleVersionStatus(ctx, UpdateModuleVersionStatusOptions{
 			ID:     versionID,
-			Status: ModuleVersionStatusOK,
+			Status: status,
 		})
 		if err != nil {
 			return err
@@ -412,6 +475,92 @@ func (s *service) uploadVersion(ctx context.Context, versionID string, tarball [
 	}
 
 	s.V(0).Info("uploaded module version", "module_version", versionID)
+
+	if status == ModuleVersionStatusTesting {
+		if err := s.testVersion(ctx, module, versionID, tarball); err != nil {
+			s.Error(err, "starting module version tests", "module_version", versionID)
+			return s.finishTest(ctx, module, versionID, fmt.Errorf("starting tests: %w", err))
+		}
+	}
+	return nil
+}
+
+// testVersion creates a run in the module's test workspace that tests the
+// contents of the module version.
+func (s *service) testVersion(ctx context.Context, module *Module, versionID string, tarball []byte) error {
+	// the module's publisher is not necessarily permitted to create runs in the
+	// test workspace.
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "module-tester"})
+
+	// record the run on the version in the same transaction in which the run is
+	// created, so that the outcome of the run cannot be reported before the
+	// version is known to be tested by it.
+	return s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		cv, err := s.configs.CreateConfigurationVersion(ctx, *module.TestWorkspaceID, configversion.ConfigurationVersionCreateOptions{
+			Speculative: internal.Bool(true),
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.configs.UploadConfig(ctx, cv.ID, tarball); err != nil {
+			return err
+		}
+		testRun, err := s.runs.CreateRun(ctx, *module.TestWorkspaceID, run.CreateOptions{
+			ConfigurationVersionID: &cv.ID,
+			Test:                   internal.Bool(true),
+			Message:                internal.String(fmt.Sprintf("Testing %s/%s module version", module.Name, module.Provider)),
+		})
+		if err != nil {
+			return err
+		}
+		return s.db.updateModuleVersionTestRun(ctx, versionID, testRun.ID)
+	})
+}
+
+// handleTestRun updates the status of the module version tested by the run,
+// once the run has finished.
+func (s *service) handleTestRun(ctx context.Context, r *run.Run) error {
+	if !r.Test || !r.Done() {
+		return nil
+	}
+	module, err := s.db.getModuleByTestRunID(ctx, r.ID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// run is not testing a module version
+		return nil
+	} else if err != nil {
+		return err
+	}
+	modver := module.versionByTestRunID(r.ID)
+	if modver == nil || modver.Status != ModuleVersionStatusTesting {
+		// outcome already reported
+		return nil
+	}
+	var testErr error
+	if r.Status != internal.RunPlannedAndFinished {
+		testErr = fmt.Errorf("test run %s: %s", r.ID, r.Status)
+	}
+	return s.finishTest(ctx, module, modver.ID, testErr)
+}
+
+// finishTest sets the status of a module version that has been tested. A
+// version that failed its tests is still made available unless the module
+// requires its tests to pass.
+func (s *service) finishTest(ctx context.Context, module *Module, versionID string, testErr error) error {
+	opts := UpdateModuleVersionStatusOptions{
+		ID:     versionID,
+		Status: ModuleVersionStatusOK,
+	}
+	if testErr != nil {
+		opts.Error = testErr.Error()
+		if module.TestsRequired {
+			opts.Status = ModuleVersionStatusTestsFailed
+		}
+	}
+	if err := s.db.updateModuleVersionStatus(ctx, opts); err != nil {
+		s.Error(err, "updating tested module version", "module_version", versionID)
+		return err
+	}
+	s.V(0).Info("tested module version", "module_version", versionID, "status", opts.Status, "error", opts.Error)
 	return nil
 }
 
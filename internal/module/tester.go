@@ -0,0 +1,52 @@
+package module
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+)
+
+// TesterLockID is a unique ID guaranteeing only one tester on a cluster is
+// running at any time.
+const TesterLockID int64 = 179366396344335600
+
+type (
+	// Tester makes module versions available once their tests have finished,
+	// subject to the outcome of the tests.
+	Tester struct {
+		logr.Logger
+		pubsub.Subscriber
+		ModuleService
+	}
+)
+
+// Start starts the tester daemon. Should be invoked in a go routine.
+func (t *Tester) Start(ctx context.Context) error {
+	// Unsubscribe whenever exiting this routine.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe to run events
+	sub, err := t.Subscribe(ctx, "module-tester-")
+	if err != nil {
+		return err
+	}
+
+	for event := range sub {
+		r, ok := event.Payload.(*run.Run)
+		if !ok {
+			// Skip non-run events
+			continue
+		}
+		if event.Type == pubsub.DeletedEvent {
+			// Skip deleted run events
+			continue
+		}
+		if err := t.handleTestRun(ctx, r); err != nil {
+			t.Error(err, "handling test run", "run", r.ID)
+		}
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/vcsprovider"
+	"github.com/leg100/otf/internal/workspace"
 )
 
 const (
@@ -31,6 +32,7 @@ type (
 		html.Renderer
 		vcsprovider.VCSProviderService
 		internal.HostnameService
+		WorkspaceService workspace.Service
 
 		svc Service
 	}
@@ -45,6 +47,8 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/organizations/{organization_name}/modules/new", h.new).Methods("GET")
 	r.HandleFunc("/organizations/{organization_name}/modules/create", h.publish).Methods("POST")
 	r.HandleFunc("/modules/{module_id}", h.get).Methods("GET")
+	r.HandleFunc("/modules/{module_id}/edit", h.edit).Methods("GET")
+	r.HandleFunc("/modules/{module_id}/update", h.update).Methods("POST")
 	r.HandleFunc("/modules/{module_id}/delete", h.delete).Methods("POST")
 }
 
@@ -99,22 +103,27 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 	} else {
 		modver = module.Latest()
 	}
-	if modver == nil {
-		// TODO: set flash and render
+	if params.Version != nil && modver == nil {
 		h.Error(w, "no version found", http.StatusNotFound)
 		return
 	}
 
-	modinfo, err := h.svc.GetModuleInfo(r.Context(), modver.ID)
-	if err != nil {
-		h.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	var readme template.HTML
-	switch module.Status {
-	case ModuleStatusSetupComplete:
-		readme = html.MarkdownToHTML(modinfo.readme)
+	// a module may have no version available yet, e.g. if its versions are
+	// still being tested.
+	var (
+		modinfo *TerraformModule
+		readme  template.HTML
+	)
+	if modver != nil {
+		modinfo, err = h.svc.GetModuleInfo(r.Context(), modver.ID)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		switch module.Status {
+		case ModuleStatusSetupComplete:
+			readme = html.MarkdownToHTML(modinfo.readme)
+		}
 	}
 
 	h.Render("module_get.tmpl", w, struct {
@@ -134,6 +143,85 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *webHandlers) edit(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("module_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	module, err := h.svc.GetModuleByID(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var testWorkspace string
+	if module.TestWorkspaceID != nil {
+		ws, err := h.WorkspaceService.GetWorkspace(r.Context(), *module.TestWorkspaceID)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		testWorkspace = ws.Name
+	}
+
+	h.Render("module_edit.tmpl", w, struct {
+		organization.OrganizationPage
+		Module        *Module
+		TestWorkspace string
+	}{
+		OrganizationPage: organization.NewPage(r, "edit | "+module.Name, module.Organization),
+		Module:           module,
+		TestWorkspace:    testWorkspace,
+	})
+}
+
+func (h *webHandlers) update(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID            string `schema:"module_id,required"`
+		TestWorkspace string `schema:"test_workspace"`
+		TestsRequired bool   `schema:"tests_required"` // form checkbox can only be true/false, not nil
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	module, err := h.svc.GetModuleByID(r.Context(), params.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// an empty workspace name disables testing
+	var testWorkspaceID string
+	if params.TestWorkspace != "" {
+		ws, err := h.WorkspaceService.GetWorkspaceByName(r.Context(), module.Organization, params.TestWorkspace)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			html.FlashError(w, "no such workspace: "+params.TestWorkspace)
+			http.Redirect(w, r, paths.EditModule(module.ID), http.StatusFound)
+			return
+		} else if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		testWorkspaceID = ws.ID
+	}
+
+	module, err = h.svc.UpdateModule(r.Context(), module.ID, UpdateOptions{
+		TestWorkspaceID: &testWorkspaceID,
+		TestsRequired:   &params.TestsRequired,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "updated module: "+module.Name)
+	http.Redirect(w, r, paths.Module(module.ID), http.StatusFound)
+}
+
 func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		Step newModuleStep `schema:"step"`
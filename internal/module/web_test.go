@@ -13,6 +13,7 @@ import (
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/repo"
 	"github.com/leg100/otf/internal/vcsprovider"
+	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,6 +50,61 @@ func TestGetModule(t *testing.T) {
 	}
 }
 
+func TestGetModule_NoAvailableVersion(t *testing.T) {
+	mod := Module{
+		Connection: &repo.Connection{},
+		Status:     ModuleStatusSetupComplete,
+		Versions: []ModuleVersion{{
+			Version:   "1.0.0",
+			Status:    ModuleVersionStatusTesting,
+			TestRunID: internal.String("run-123"),
+		}},
+	}
+	h := newTestWebHandlers(t, withMod(&mod))
+
+	q := "/?module_id=mod-123"
+	r := httptest.NewRequest("GET", q, nil)
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	if !assert.Equal(t, 200, w.Code) {
+		t.Log(w.Body.String())
+	}
+	assert.Contains(t, w.Body.String(), "No version of the module is available yet.")
+	assert.Contains(t, w.Body.String(), paths.Run("run-123"))
+}
+
+func TestWeb_EditModule(t *testing.T) {
+	mod := Module{ID: "mod-123", TestWorkspaceID: internal.String("ws-123")}
+	h := newTestWebHandlers(t, withMod(&mod), withWorkspace(&workspace.Workspace{ID: "ws-123", Name: "module-tests"}))
+
+	q := "/?module_id=mod-123"
+	r := httptest.NewRequest("GET", q, nil)
+	w := httptest.NewRecorder()
+	h.edit(w, r)
+	if !assert.Equal(t, 200, w.Code) {
+		t.Log(w.Body.String())
+	}
+	assert.Contains(t, w.Body.String(), `value="module-tests"`)
+}
+
+func TestWeb_UpdateModule(t *testing.T) {
+	mod := Module{ID: "mod-123", Organization: "acme-corp"}
+	h := newTestWebHandlers(t, withMod(&mod), withWorkspace(&workspace.Workspace{ID: "ws-123", Name: "module-tests"}))
+
+	q := "/?module_id=mod-123&test_workspace=module-tests&tests_required=true"
+	r := httptest.NewRequest("POST", q, nil)
+	w := httptest.NewRecorder()
+	h.update(w, r)
+	if assert.Equal(t, 302, w.Code) {
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		assert.Equal(t, paths.Module(mod.ID), redirect.Path)
+	}
+	got := h.svc.(*fakeWebServices).updated
+	assert.Equal(t, "ws-123", *got.TestWorkspaceID)
+	assert.True(t, *got.TestsRequired)
+}
+
 func TestNewModule_Connect(t *testing.T) {
 	h := newTestWebHandlers(t, withVCSProviders(
 		&vcsprovider.VCSProvider{},
@@ -137,6 +193,7 @@ func newTestWebHandlers(t *testing.T, opts ...testWebOption) *webHandlers {
 		Renderer:           renderer,
 		VCSProviderService: &svc,
 		HostnameService:    &svc,
+		WorkspaceService:   &fakeWorkspaceService{ws: svc.ws},
 		svc:                &svc,
 	}
 }
@@ -167,6 +224,12 @@ func withRepos(repos ...string) testWebOption {
 	}
 }
 
+func withWorkspace(ws *workspace.Workspace) testWebOption {
+	return func(svc *fakeWebServices) {
+		svc.ws = ws
+	}
+}
+
 func withHostname(hostname string) testWebOption {
 	return func(svc *fakeWebServices) {
 		svc.hostname = hostname
@@ -179,6 +242,8 @@ type fakeWebServices struct {
 	vcsprovs []*vcsprovider.VCSProvider
 	repos    []string
 	hostname string
+	ws       *workspace.Workspace
+	updated  UpdateOptions
 
 	Service
 	internal.HostnameService
@@ -194,6 +259,11 @@ func (f *fakeWebServices) GetModuleByID(context.Context, string) (*Module, error
 	return f.mod, nil
 }
 
+func (f *fakeWebServices) UpdateModule(_ context.Context, _ string, opts UpdateOptions) (*Module, error) {
+	f.updated = opts
+	return f.mod, nil
+}
+
 func (f *fakeWebServices) DeleteModule(context.Context, string) (*Module, error) {
 	return f.mod, nil
 }
@@ -231,3 +301,17 @@ type fakeModulesCloudClient struct {
 func (f *fakeModulesCloudClient) ListRepositories(ctx context.Context, opts cloud.ListRepositoriesOptions) ([]string, error) {
 	return f.repos, nil
 }
+
+type fakeWorkspaceService struct {
+	ws *workspace.Workspace
+
+	workspace.Service
+}
+
+func (f *fakeWorkspaceService) GetWorkspace(context.Context, string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
+
+func (f *fakeWorkspaceService) GetWorkspaceByName(context.Context, string, string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
@@ -32,9 +32,11 @@ type (
 	genericNotificationPayload struct {
 		Message      string
 		Trigger      Trigger
-		RunStatus    internal.RunStatus
+		RunStatus    internal.RunStatus `json:",omitempty"`
 		RunUpdatedAt time.Time
 		RunUpdatedBy string
+		// only set for auto-destroy reminders
+		AutoDestroyAt *time.Time `json:",omitempty"`
 	}
 
 	genericClient struct {
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 )
 
 var _ client = (*slackClient)(nil)
@@ -35,24 +34,36 @@ func newSlackClient(cfg *Config) (*slackClient, error) {
 }
 
 func (c *slackClient) Publish(ctx context.Context, n *notification) error {
-	data, err := json.Marshal(slackMessage{
-		Blocks: []slackBlock{
-			{
-				Type: "section",
-				Text: &slackBlock{
-					Type: "mrkdwn",
-					Text: fmt.Sprintf("Run notification for <%s|%s/%s>", n.runURL(), n.workspace.Organization, n.workspace.Name),
-				},
+	heading := fmt.Sprintf("Workspace notification for <%s|%s/%s>", n.workspaceURL(), n.workspace.Organization, n.workspace.Name)
+	if n.run != nil {
+		heading = fmt.Sprintf("Run notification for <%s|%s/%s>", n.runURL(), n.workspace.Organization, n.workspace.Name)
+	}
+	blocks := []slackBlock{
+		{
+			Type: "section",
+			Text: &slackBlock{
+				Type: "mrkdwn",
+				Text: heading,
 			},
-			{
-				Type: "section",
-				Text: &slackBlock{
-					Type: "mrkdwn",
-					Text: fmt.Sprintf("*run %s*", strings.ReplaceAll(string(n.run.Status), "_", " ")),
-				},
+		},
+		{
+			Type: "section",
+			Text: &slackBlock{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("*%s*", n.summary()),
 			},
 		},
-	})
+	}
+	if msg := n.message(); msg != "" {
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackBlock{
+				Type: "plain_text",
+				Text: msg,
+			},
+		})
+	}
+	data, err := json.Marshal(slackMessage{Blocks: blocks})
 	if err != nil {
 		return err
 	}
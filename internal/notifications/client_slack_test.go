@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackClient_Publish(t *testing.T) {
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme-corp"}
+	deadline := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		notification *notification
+		wantHeading  string
+		wantSummary  string
+	}{
+		{
+			name: "auto-destroy reminder",
+			notification: &notification{
+				workspace:     ws,
+				autoDestroyAt: &deadline,
+				trigger:       TriggerAutoDestroyReminder,
+			},
+			wantHeading: "Workspace notification for <https://otf.example.com/app/workspaces/ws-123|acme-corp/dev>",
+			wantSummary: "*workspace scheduled to be destroyed at Fri, 01 Sep 2023 12:00:00 UTC*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan slackMessage, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var msg slackMessage
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+				received <- msg
+			}))
+			t.Cleanup(srv.Close)
+
+			cfg := newTestConfig(t, "ws-123", DestinationSlack, srv.URL, tt.notification.trigger)
+			client, err := newSlackClient(cfg)
+			require.NoError(t, err)
+
+			tt.notification.config = cfg
+			tt.notification.hostname = "otf.example.com"
+			err = client.Publish(context.Background(), tt.notification)
+			require.NoError(t, err)
+
+			msg := <-received
+			require.GreaterOrEqual(t, len(msg.Blocks), 2)
+			assert.Equal(t, tt.wantHeading, msg.Blocks[0].Text.(map[string]any)["text"])
+			assert.Equal(t, tt.wantSummary, msg.Blocks[1].Text.(map[string]any)["text"])
+		})
+	}
+}
@@ -27,6 +27,18 @@ const (
 	TriggerApplying       Trigger = "run:applying"
 	TriggerCompleted      Trigger = "run:completed"
 	TriggerErrored        Trigger = "run:errored"
+	TriggerApproved       Trigger = "run:approved"
+	TriggerRejected       Trigger = "run:rejected"
+	TriggerCommented      Trigger = "run:commented"
+
+	// TriggerAutoDestroyReminder is triggered ahead of a workspace's
+	// scheduled destruction.
+	TriggerAutoDestroyReminder Trigger = "workspace:auto_destroy_reminder"
+	// TriggerLocked is triggered when a user locks a workspace.
+	TriggerLocked Trigger = "workspace:locked"
+	// TriggerUnlocked is triggered when a user unlocks a workspace, whether
+	// by force or not, and when a lock expires.
+	TriggerUnlocked Trigger = "workspace:unlocked"
 )
 
 var (
@@ -194,6 +206,18 @@ func (c *Config) matchTrigger(r *run.Run) (Trigger, bool) {
 	return "", false
 }
 
+// matchApprovalTrigger determines whether the config has a trigger that
+// matches the given approval decision
+func (c *Config) matchApprovalTrigger(a *run.Approval) (Trigger, bool) {
+	switch a.Decision {
+	case run.ApprovedDecision:
+		return TriggerApproved, c.hasTrigger(TriggerApproved)
+	case run.RejectedDecision:
+		return TriggerRejected, c.hasTrigger(TriggerRejected)
+	}
+	return "", false
+}
+
 func (c *Config) hasTrigger(t Trigger) bool {
 	return slices.Contains(c.Triggers, t)
 }
@@ -206,7 +230,13 @@ func validTriggers(triggers []Trigger) error {
 			TriggerNeedsAttention,
 			TriggerApplying,
 			TriggerCompleted,
-			TriggerErrored:
+			TriggerErrored,
+			TriggerApproved,
+			TriggerRejected,
+			TriggerCommented,
+			TriggerAutoDestroyReminder,
+			TriggerLocked,
+			TriggerUnlocked:
 		default:
 			return ErrInvalidTrigger
 		}
@@ -3,7 +3,10 @@
 package notifications
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/run"
@@ -14,30 +17,64 @@ import (
 // notification furnishes information for sending a notification to a third
 // party.
 type notification struct {
-	workspace *workspace.Workspace
-	run       *run.Run
-	trigger   Trigger
-	config    *Config
-	hostname  string
+	workspace     *workspace.Workspace
+	run           *run.Run             // not set for workspace triggers
+	approval      *run.Approval        // only set for approval triggers
+	comment       *run.Comment         // only set for comment triggers
+	autoDestroyAt *time.Time           // only set for auto-destroy reminders
+	lockEvent     *workspace.LockEvent // only set for lock triggers
+	trigger       Trigger
+	config        *Config
+	hostname      string
 }
 
 func (n *notification) LogValue() slog.Value {
-	attrs := []slog.Attr{
-		slog.String("run", n.run.ID),
+	var attrs []slog.Attr
+	if n.run != nil {
+		attrs = append(attrs, slog.String("run", n.run.ID))
+	}
+	attrs = append(attrs,
 		slog.String("workspace_id", n.workspace.ID),
 		slog.String("trigger", string(n.trigger)),
 		slog.String("destination", string(n.config.DestinationType)),
-	}
+	)
 	return slog.GroupValue(attrs...)
 }
 
 // genericPayload converts a notification into a format suitable for the generic
 // and GCP-pubsub destination types.
 func (n *notification) genericPayload() (*GenericPayload, error) {
+	if n.run == nil {
+		return &GenericPayload{
+			PayloadVersion:   1,
+			WorkspaceID:      n.workspace.ID,
+			WorkspaceName:    n.workspace.Name,
+			OrganizationName: n.workspace.Organization,
+			Notifications: []genericNotificationPayload{{
+				Message:       n.summary(),
+				Trigger:       n.trigger,
+				AutoDestroyAt: n.autoDestroyAt,
+			}},
+		}, nil
+	}
 	runUpdatedAt, err := n.run.StatusTimestamp(n.run.Status)
 	if err != nil {
 		return nil, err
 	}
+	updates := genericNotificationPayload{
+		Message:      n.message(),
+		Trigger:      n.trigger,
+		RunStatus:    n.run.Status,
+		RunUpdatedAt: runUpdatedAt,
+	}
+	if n.approval != nil {
+		updates.RunUpdatedAt = n.approval.CreatedAt
+		updates.RunUpdatedBy = n.approval.Username
+	}
+	if n.comment != nil {
+		updates.RunUpdatedAt = n.comment.CreatedAt
+		updates.RunUpdatedBy = n.comment.Author
+	}
 	return &GenericPayload{
 		PayloadVersion:              1,
 		NotificationConfigurationID: "",
@@ -47,17 +84,68 @@ func (n *notification) genericPayload() (*GenericPayload, error) {
 		WorkspaceID:                 n.workspace.ID,
 		WorkspaceName:               n.workspace.Name,
 		OrganizationName:            n.workspace.Organization,
-		Notifications: []genericNotificationPayload{
-			{
-				Trigger:      n.trigger,
-				RunStatus:    n.run.Status,
-				RunUpdatedAt: runUpdatedAt,
-			},
-		},
+		Notifications:               []genericNotificationPayload{updates},
 	}, nil
 }
 
+// summary provides a short human-readable description of the event
+// triggering the notification.
+func (n *notification) summary() string {
+	if n.autoDestroyAt != nil {
+		return fmt.Sprintf("workspace scheduled to be destroyed at %s", n.autoDestroyAt.Format(time.RFC1123))
+	}
+	if n.lockEvent != nil {
+		return n.lockSummary()
+	}
+	if n.approval != nil {
+		return fmt.Sprintf("run %s by %s", n.approval.Decision, n.approval.Username)
+	}
+	if n.comment != nil {
+		return fmt.Sprintf("run commented on by %s", n.comment.Author)
+	}
+	return "run " + strings.ReplaceAll(string(n.run.Status), "_", " ")
+}
+
+// message returns the free-form text accompanying the notification, e.g. the
+// body of a comment; it is empty if there is none.
+func (n *notification) message() string {
+	switch {
+	case n.approval != nil:
+		return n.approval.Comment
+	case n.comment != nil:
+		return n.comment.Body
+	case n.lockEvent != nil:
+		return n.lockEvent.Reason
+	default:
+		return ""
+	}
+}
+
+func (n *notification) lockSummary() string {
+	e := n.lockEvent
+	switch e.Action {
+	case workspace.LockEventLocked:
+		return "workspace locked by " + e.Holder
+	case workspace.LockEventExpired:
+		return fmt.Sprintf("workspace lock held by %s expired", e.Holder)
+	case workspace.LockEventForceUnlocked:
+		return fmt.Sprintf("workspace lock held by %s force unlocked by %s", e.Holder, *e.Actor)
+	default:
+		return "workspace unlocked by " + *e.Actor
+	}
+}
+
+func (n *notification) workspaceURL() string {
+	u := &url.URL{Scheme: "https", Host: n.hostname, Path: paths.Workspace(n.workspace.ID)}
+	return u.String()
+}
+
+// runURL returns the URL of the run, or of the workspace if the notification
+// does not concern a run.
 func (n *notification) runURL() string {
+	if n.run == nil {
+		return n.workspaceURL()
+	}
 	u := &url.URL{Scheme: "https", Host: n.hostname, Path: paths.Run(n.run.ID)}
 	return u.String()
 }
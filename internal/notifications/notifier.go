@@ -3,6 +3,7 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/logr"
@@ -16,6 +17,15 @@ import (
 // time.
 const LockID int64 = 5577006791947779411
 
+const (
+	// AutoDestroyReminderPeriod is the period before a workspace's scheduled
+	// destruction during which a reminder is sent.
+	AutoDestroyReminderPeriod = 12 * time.Hour
+	// autoDestroyReminderInterval is the interval between checks for
+	// workspaces due a reminder.
+	autoDestroyReminderInterval = time.Minute
+)
+
 type (
 	// Notifier relays run events onto interested parties
 	Notifier struct {
@@ -23,9 +33,15 @@ type (
 		pubsub.Subscriber
 		workspace.WorkspaceService // for retrieving workspace name
 		internal.HostnameService   // for including a link in the notification
+		run.RunService             // for retrieving the run an approval belongs to
 
 		*cache
 		db *pgdb
+
+		// auto-destroy deadlines for which a reminder has been sent, keyed by
+		// workspace ID. Not persisted, so a reminder may be sent again
+		// following a restart.
+		reminded map[string]time.Time
 	}
 
 	NotifierOptions struct {
@@ -33,6 +49,7 @@ type (
 		pubsub.Subscriber
 		workspace.WorkspaceService // for retrieving workspace name
 		internal.HostnameService   // for including a link in the notification
+		run.RunService             // for retrieving the run an approval belongs to
 		*sql.DB
 	}
 )
@@ -43,7 +60,9 @@ func NewNotifier(opts NotifierOptions) *Notifier {
 		Subscriber:       opts.Subscriber,
 		WorkspaceService: opts.WorkspaceService,
 		HostnameService:  opts.HostnameService,
+		RunService:       opts.RunService,
 		db:               &pgdb{opts.DB},
+		reminded:         make(map[string]time.Time),
 	}
 }
 
@@ -66,19 +85,37 @@ func (s *Notifier) Start(ctx context.Context) error {
 	}
 	s.cache = cache
 
+	ticker := time.NewTicker(autoDestroyReminderInterval)
+	defer ticker.Stop()
+
 	// block on handling events
-	for event := range sub {
-		if err := s.handle(ctx, event); err != nil {
-			s.Error(err, "handling event", "event", event.Type)
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return nil
+			}
+			if err := s.handle(ctx, event); err != nil {
+				s.Error(err, "handling event", "event", event.Type)
+			}
+		case <-ticker.C:
+			if err := s.remindAutoDestroy(ctx, time.Now()); err != nil {
+				s.Error(err, "sending auto-destroy reminders")
+			}
 		}
 	}
-	return nil
 }
 
 func (s *Notifier) handle(ctx context.Context, event pubsub.Event) error {
 	switch payload := event.Payload.(type) {
 	case *run.Run:
 		return s.handleRun(ctx, payload)
+	case *run.Approval:
+		return s.handleApproval(ctx, payload)
+	case *run.Comment:
+		return s.handleComment(ctx, payload)
+	case *workspace.LockEvent:
+		return s.handleLockEvent(ctx, payload, event.Type)
 	case *Config:
 		return s.handleConfig(ctx, payload, event.Type)
 	default:
@@ -107,13 +144,101 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 		// ignore queued events
 		return nil
 	}
+	return s.publish(ctx, r.WorkspaceID, notification{run: r}, func(cfg *Config) (Trigger, bool) {
+		return cfg.matchTrigger(r)
+	})
+}
+
+func (s *Notifier) handleApproval(ctx context.Context, a *run.Approval) error {
+	r, err := s.GetRun(ctx, a.RunID)
+	if err != nil {
+		return err
+	}
+	return s.publish(ctx, r.WorkspaceID, notification{run: r, approval: a}, func(cfg *Config) (Trigger, bool) {
+		return cfg.matchApprovalTrigger(a)
+	})
+}
+
+func (s *Notifier) handleComment(ctx context.Context, c *run.Comment) error {
+	r, err := s.GetRun(ctx, c.RunID)
+	if err != nil {
+		return err
+	}
+	return s.publish(ctx, r.WorkspaceID, notification{run: r, comment: c}, func(cfg *Config) (Trigger, bool) {
+		return TriggerCommented, cfg.hasTrigger(TriggerCommented)
+	})
+}
+
+func (s *Notifier) handleLockEvent(ctx context.Context, e *workspace.LockEvent, eventType pubsub.EventType) error {
+	if eventType != pubsub.CreatedEvent {
+		return nil
+	}
+	if e.ByRun() {
+		// ignore runs locking and unlocking workspaces, which occurs for
+		// every run
+		return nil
+	}
+	trigger := TriggerUnlocked
+	if e.Action == workspace.LockEventLocked {
+		trigger = TriggerLocked
+	}
+	return s.publish(ctx, e.WorkspaceID, notification{lockEvent: e}, func(cfg *Config) (Trigger, bool) {
+		return trigger, cfg.hasTrigger(trigger)
+	})
+}
 
+// remindAutoDestroy notifies those workspaces with an auto-destroy reminder
+// trigger whose scheduled destruction falls within the reminder period as of
+// now.
+func (s *Notifier) remindAutoDestroy(ctx context.Context, now time.Time) error {
+	// determine workspaces that want reminders
+	s.mu.Lock()
+	workspaceIDs := make(map[string]struct{})
+	for _, cfg := range s.configs {
+		if cfg.Enabled && cfg.hasTrigger(TriggerAutoDestroyReminder) {
+			workspaceIDs[cfg.WorkspaceID] = struct{}{}
+		}
+	}
+	s.mu.Unlock()
+
+	for id := range workspaceIDs {
+		ws, err := s.GetWorkspace(ctx, id)
+		if err != nil {
+			return err
+		}
+		deadline, err := run.AutoDestroyDeadline(ctx, s.RunService, ws)
+		if err != nil {
+			return err
+		}
+		if deadline == nil || now.After(*deadline) || now.Before(deadline.Add(-AutoDestroyReminderPeriod)) {
+			continue
+		}
+		if reminded, ok := s.reminded[id]; ok && reminded.Equal(*deadline) {
+			continue
+		}
+		err = s.publish(ctx, id, notification{workspace: ws, autoDestroyAt: deadline}, func(cfg *Config) (Trigger, bool) {
+			return TriggerAutoDestroyReminder, cfg.hasTrigger(TriggerAutoDestroyReminder)
+		})
+		if err != nil {
+			return err
+		}
+		s.reminded[id] = *deadline
+	}
+	return nil
+}
+
+// publish sends the notification to each enabled config for the workspace
+// with a trigger matched by the match func. The caller populates the
+// notification with the run and any approval or comment, or with the
+// workspace for workspace triggers, and the remaining fields are populated
+// for each config.
+func (s *Notifier) publish(ctx context.Context, workspaceID string, tmpl notification, match func(*Config) (Trigger, bool)) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	var ws *workspace.Workspace
+	ws := tmpl.workspace
 	for _, cfg := range s.configs {
-		if cfg.WorkspaceID != r.WorkspaceID {
+		if cfg.WorkspaceID != workspaceID {
 			// skip configs for other workspaces
 			continue
 		}
@@ -125,7 +250,7 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 			// skip config with no triggers
 			continue
 		}
-		trigger, matches := cfg.matchTrigger(r)
+		trigger, matches := match(cfg)
 		if !matches {
 			// skip config with no matching trigger
 			continue
@@ -138,7 +263,7 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 		// (b) add workspace info to run itself
 		if ws == nil {
 			var err error
-			ws, err = s.GetWorkspace(ctx, r.WorkspaceID)
+			ws, err = s.GetWorkspace(ctx, workspaceID)
 			if err != nil {
 				return err
 			}
@@ -148,15 +273,13 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 			// should never happen
 			return fmt.Errorf("client not found for url: %s", *cfg.URL)
 		}
-		msg := &notification{
-			run:       r,
-			workspace: ws,
-			trigger:   trigger,
-			config:    cfg,
-			hostname:  s.Hostname(),
-		}
+		msg := tmpl
+		msg.workspace = ws
+		msg.trigger = trigger
+		msg.config = cfg
+		msg.hostname = s.Hostname()
 		s.V(3).Info("publishing notification", "notification", msg)
-		if err := client.Publish(ctx, msg); err != nil {
+		if err := client.Publish(ctx, &msg); err != nil {
 			return err
 		}
 	}
@@ -2,11 +2,16 @@ package notifications
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +105,75 @@ func TestNotifier_handleRun_multiple(t *testing.T) {
 	assert.Equal(t, planningRun, <-published)
 }
 
+func TestNotifier_handleApproval(t *testing.T) {
+	ctx := context.Background()
+	plannedRun := &run.Run{
+		Status:      internal.RunPlanned,
+		WorkspaceID: "ws-123",
+	}
+
+	tests := []struct {
+		name          string
+		decision      run.ApprovalDecision
+		trigger       Trigger
+		wantPublished bool
+	}{
+		{"approved", run.ApprovedDecision, TriggerApproved, true},
+		{"rejected", run.RejectedDecision, TriggerRejected, true},
+		{"mis-matching trigger", run.RejectedDecision, TriggerApproved, false},
+		{"run trigger does not match approval", run.ApprovedDecision, TriggerNeedsAttention, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := make(chan *run.Run, 100)
+			cfg := newTestConfig(t, "ws-123", DestinationGeneric, "", tt.trigger)
+			notifier := newTestNotifier(t, &fakeFactory{published}, cfg)
+			notifier.RunService = &fakeRunService{run: plannedRun}
+
+			err := notifier.handleApproval(ctx, &run.Approval{Decision: tt.decision})
+			require.NoError(t, err)
+			if tt.wantPublished {
+				assert.Equal(t, plannedRun, <-published)
+			} else {
+				assert.Equal(t, 0, len(published))
+			}
+		})
+	}
+}
+
+func TestNotifier_handleComment(t *testing.T) {
+	ctx := context.Background()
+	plannedRun := &run.Run{
+		Status:      internal.RunPlanned,
+		WorkspaceID: "ws-123",
+	}
+
+	tests := []struct {
+		name          string
+		trigger       Trigger
+		wantPublished bool
+	}{
+		{"matching trigger", TriggerCommented, true},
+		{"mis-matching trigger", TriggerNeedsAttention, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := make(chan *run.Run, 100)
+			cfg := newTestConfig(t, "ws-123", DestinationGeneric, "", tt.trigger)
+			notifier := newTestNotifier(t, &fakeFactory{published}, cfg)
+			notifier.RunService = &fakeRunService{run: plannedRun}
+
+			err := notifier.handleComment(ctx, &run.Comment{Body: "lgtm"})
+			require.NoError(t, err)
+			if tt.wantPublished {
+				assert.Equal(t, plannedRun, <-published)
+			} else {
+				assert.Equal(t, 0, len(published))
+			}
+		})
+	}
+}
+
 func TestNotifier_handleConfig(t *testing.T) {
 	ctx := context.Background()
 	notifier := newTestNotifier(t, &fakeFactory{})
@@ -125,3 +199,167 @@ func TestNotifier_handleConfig(t *testing.T) {
 	assert.Len(t, notifier.cache.configs, 0)
 	assert.Len(t, notifier.cache.clients, 0)
 }
+
+func TestNotifier_remindAutoDestroy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	deadline := now.Add(time.Hour)
+	ws := &workspace.Workspace{
+		ID:               "ws-123",
+		AutoDestroyAt:    &deadline,
+		AllowDestroyPlan: true,
+		LatestRun:        &workspace.LatestRun{ID: "run-123", Status: internal.RunApplied},
+	}
+	applied := &run.Run{
+		ID:               "run-123",
+		Status:           internal.RunApplied,
+		StatusTimestamps: []run.StatusTimestamp{{Status: internal.RunApplied, Timestamp: now.Add(-time.Hour)}},
+	}
+	destroyed := &run.Run{
+		ID:               "run-123",
+		Status:           internal.RunApplied,
+		IsDestroy:        true,
+		StatusTimestamps: []run.StatusTimestamp{{Status: internal.RunApplied, Timestamp: now.Add(-time.Hour)}},
+	}
+	noDestroyPlans := *ws
+	noDestroyPlans.AllowDestroyPlan = false
+	noRuns := *ws
+	noRuns.LatestRun = nil
+
+	tests := []struct {
+		name          string
+		ws            *workspace.Workspace
+		latest        *run.Run
+		now           time.Time
+		trigger       Trigger
+		wantPublished bool
+	}{
+		{"within reminder period", ws, applied, now, TriggerAutoDestroyReminder, true},
+		{"before reminder period", ws, applied, deadline.Add(-AutoDestroyReminderPeriod - time.Minute), TriggerAutoDestroyReminder, false},
+		{"after deadline", ws, applied, deadline.Add(time.Minute), TriggerAutoDestroyReminder, false},
+		{"mis-matching trigger", ws, applied, now, TriggerPlanning, false},
+		{"destroy plans not permitted", &noDestroyPlans, applied, now, TriggerAutoDestroyReminder, false},
+		{"latest run is a destroy", ws, destroyed, now, TriggerAutoDestroyReminder, false},
+		{"no runs", &noRuns, nil, now, TriggerAutoDestroyReminder, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := make(chan *run.Run, 100)
+			cfg := newTestConfig(t, "ws-123", DestinationGeneric, "", tt.trigger)
+			notifier := newTestNotifier(t, &fakeFactory{published}, cfg)
+			notifier.WorkspaceService = &fakeWorkspaceService{ws: tt.ws}
+			notifier.RunService = &fakeRunService{run: tt.latest}
+
+			err := notifier.remindAutoDestroy(ctx, tt.now)
+			require.NoError(t, err)
+			if tt.wantPublished {
+				assert.Equal(t, 1, len(published))
+			} else {
+				assert.Equal(t, 0, len(published))
+			}
+
+			// reminder should only be sent once per deadline
+			err = notifier.remindAutoDestroy(ctx, tt.now)
+			require.NoError(t, err)
+			if tt.wantPublished {
+				assert.Equal(t, 1, len(published))
+			}
+		})
+	}
+}
+
+func TestNotifier_handleLockEvent(t *testing.T) {
+	ctx := context.Background()
+	ws := &workspace.Workspace{ID: "ws-123"}
+	bob := "bob"
+	runID := "run-123"
+
+	tests := []struct {
+		name          string
+		event         *workspace.LockEvent
+		trigger       Trigger
+		wantPublished bool
+	}{
+		{
+			"user locked workspace",
+			&workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventLocked, Kind: workspace.UserLock, Holder: bob, Actor: &bob},
+			TriggerLocked,
+			true,
+		},
+		{
+			"user unlocked workspace",
+			&workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventUnlocked, Kind: workspace.UserLock, Holder: bob, Actor: &bob},
+			TriggerUnlocked,
+			true,
+		},
+		{
+			"user lock expired",
+			&workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventExpired, Kind: workspace.UserLock, Holder: bob},
+			TriggerUnlocked,
+			true,
+		},
+		{
+			"user force unlocked run lock",
+			&workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventForceUnlocked, Kind: workspace.RunLock, Holder: runID, Actor: &bob},
+			TriggerUnlocked,
+			true,
+		},
+		{
+			"run locked workspace",
+			&workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventLocked, Kind: workspace.RunLock, Holder: runID, Actor: &runID},
+			TriggerLocked,
+			false,
+		},
+		{
+			"mis-matching trigger",
+			&workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventLocked, Kind: workspace.UserLock, Holder: bob, Actor: &bob},
+			TriggerUnlocked,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := make(chan *run.Run, 100)
+			cfg := newTestConfig(t, "ws-123", DestinationGeneric, "", tt.trigger)
+			notifier := newTestNotifier(t, &fakeFactory{published}, cfg)
+			notifier.WorkspaceService = &fakeWorkspaceService{ws: ws}
+
+			err := notifier.handleLockEvent(ctx, tt.event, pubsub.CreatedEvent)
+			require.NoError(t, err)
+			if tt.wantPublished {
+				assert.Equal(t, 1, len(published))
+			} else {
+				assert.Equal(t, 0, len(published))
+			}
+		})
+	}
+}
+
+// TestNotifier_handleLockEvent_Slack tests publishing lock events, which do
+// not concern a run, to a real slack client.
+func TestNotifier_handleLockEvent_Slack(t *testing.T) {
+	ctx := context.Background()
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme-corp"}
+	bob := "bob"
+
+	received := make(chan slackMessage, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg slackMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received <- msg
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t, "ws-123", DestinationSlack, srv.URL, TriggerLocked)
+	notifier := newTestNotifier(t, &defaultFactory{}, cfg)
+	notifier.WorkspaceService = &fakeWorkspaceService{ws: ws}
+
+	event := &workspace.LockEvent{WorkspaceID: "ws-123", Action: workspace.LockEventLocked, Kind: workspace.UserLock, Holder: bob, Actor: &bob}
+	err := notifier.handleLockEvent(ctx, event, pubsub.CreatedEvent)
+	require.NoError(t, err)
+
+	msg := <-received
+	require.GreaterOrEqual(t, len(msg.Blocks), 2)
+	assert.Equal(t, "Workspace notification for <https:///app/workspaces/ws-123|acme-corp/dev>", msg.Blocks[0].Text.(map[string]any)["text"])
+	assert.Equal(t, "*workspace locked by bob*", msg.Blocks[1].Text.(map[string]any)["text"])
+}
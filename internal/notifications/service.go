@@ -53,6 +53,8 @@ func NewService(opts Options) *service {
 	}
 	// Register with broker so that it can relay events
 	opts.Register("notification_configurations", svc.db)
+	// Copy notification configurations to cloned workspaces
+	opts.WorkspaceService.AfterCloneWorkspace(svc.cloneConfigs)
 	return &svc
 }
 
@@ -136,3 +138,29 @@ func (s *service) DeleteNotificationConfiguration(ctx context.Context, id string
 	s.Info("deleted notification config", "config", nc, "subject", subject)
 	return nil
 }
+
+// cloneConfigs copies notification configurations from a workspace to its
+// clone, if requested.
+func (s *service) cloneConfigs(ctx context.Context, event *workspace.CloneEvent) error {
+	if !event.Notifications {
+		return nil
+	}
+	configs, err := s.ListNotificationConfigurations(ctx, event.Source.ID)
+	if err != nil {
+		return err
+	}
+	for _, nc := range configs {
+		_, err := s.CreateNotificationConfiguration(ctx, event.Clone.ID, CreateConfigOptions{
+			DestinationType: nc.DestinationType,
+			Enabled:         &nc.Enabled,
+			Name:            &nc.Name,
+			Token:           &nc.Token,
+			Triggers:        nc.Triggers,
+			URL:             nc.URL,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package notifications
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/leg100/otf/internal"
@@ -17,11 +18,18 @@ type (
 		configs []*Config
 	}
 	fakeWorkspaceService struct {
+		ws *workspace.Workspace
+
 		workspace.WorkspaceService
 	}
 	fakeHostnameService struct {
 		internal.HostnameService
 	}
+	fakeRunService struct {
+		run *run.Run
+
+		run.RunService
+	}
 	// fakeFactory makes fake clients
 	fakeFactory struct {
 		published chan *run.Run
@@ -36,7 +44,9 @@ func newTestNotifier(t *testing.T, f clientFactory, configs ...*Config) *Notifie
 		Logger:           logr.Discard(),
 		WorkspaceService: &fakeWorkspaceService{},
 		HostnameService:  &fakeHostnameService{},
+		RunService:       &fakeRunService{},
 		cache:            newTestCache(t, f, configs...),
+		reminded:         make(map[string]time.Time),
 	}
 }
 
@@ -69,11 +79,15 @@ func (db *fakeCacheDB) listAll(context.Context) ([]*Config, error) {
 }
 
 func (db *fakeWorkspaceService) GetWorkspace(context.Context, string) (*workspace.Workspace, error) {
-	return nil, nil
+	return db.ws, nil
 }
 
 func (db *fakeHostnameService) Hostname() string { return "" }
 
+func (f *fakeRunService) GetRun(context.Context, string) (*run.Run, error) {
+	return f.run, nil
+}
+
 func (f *fakeFactory) newClient(cfg *Config) (client, error) {
 	return &fakeClient{f.published}, nil
 }
@@ -19,16 +19,18 @@ type (
 	}
 
 	row struct {
-		OrganizationID             pgtype.Text        `json:"organization_id"`
-		CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-		UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-		Name                       pgtype.Text        `json:"name"`
-		SessionRemember            pgtype.Int4        `json:"session_remember"`
-		SessionTimeout             pgtype.Int4        `json:"session_timeout"`
-		Email                      pgtype.Text        `json:"email"`
-		CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
-		AllowForceDeleteWorkspaces bool               `json:"allow_force_delete_workspaces"`
-		CostEstimationEnabled      bool               `json:"cost_estimation_enabled"`
+		OrganizationID               pgtype.Text        `json:"organization_id"`
+		CreatedAt                    pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
+		Name                         pgtype.Text        `json:"name"`
+		SessionRemember              pgtype.Int4        `json:"session_remember"`
+		SessionTimeout               pgtype.Int4        `json:"session_timeout"`
+		Email                        pgtype.Text        `json:"email"`
+		CollaboratorAuthPolicy       pgtype.Text        `json:"collaborator_auth_policy"`
+		AllowForceDeleteWorkspaces   bool               `json:"allow_force_delete_workspaces"`
+		CostEstimationEnabled        bool               `json:"cost_estimation_enabled"`
+		MaxConcurrentRuns            pgtype.Int4        `json:"max_concurrent_runs"`
+		MaxConcurrentSpeculativeRuns pgtype.Int4        `json:"max_concurrent_speculative_runs"`
 	}
 
 	// dbListOptions represents the options for listing organizations via the
@@ -64,15 +66,17 @@ func (db *pgdb) update(ctx context.Context, name string, fn func(*Organization)
 			return err
 		}
 		_, err = q.UpdateOrganizationByName(ctx, pggen.UpdateOrganizationByNameParams{
-			Name:                       sql.String(name),
-			NewName:                    sql.String(org.Name),
-			Email:                      sql.StringPtr(org.Email),
-			CollaboratorAuthPolicy:     sql.StringPtr(org.CollaboratorAuthPolicy),
-			CostEstimationEnabled:      org.CostEstimationEnabled,
-			SessionRemember:            sql.Int4Ptr(org.SessionRemember),
-			SessionTimeout:             sql.Int4Ptr(org.SessionTimeout),
-			UpdatedAt:                  sql.Timestamptz(org.UpdatedAt),
-			AllowForceDeleteWorkspaces: org.AllowForceDeleteWorkspaces,
+			Name:                         sql.String(name),
+			NewName:                      sql.String(org.Name),
+			Email:                        sql.StringPtr(org.Email),
+			CollaboratorAuthPolicy:       sql.StringPtr(org.CollaboratorAuthPolicy),
+			CostEstimationEnabled:        org.CostEstimationEnabled,
+			SessionRemember:              sql.Int4Ptr(org.SessionRemember),
+			SessionTimeout:               sql.Int4Ptr(org.SessionTimeout),
+			UpdatedAt:                    sql.Timestamptz(org.UpdatedAt),
+			AllowForceDeleteWorkspaces:   org.AllowForceDeleteWorkspaces,
+			MaxConcurrentRuns:            sql.Int4(org.MaxConcurrentRuns),
+			MaxConcurrentSpeculativeRuns: sql.Int4(org.MaxConcurrentSpeculativeRuns),
 		})
 		if err != nil {
 			return err
@@ -136,12 +140,14 @@ func (db *pgdb) delete(ctx context.Context, name string) error {
 // organization.
 func (r row) toOrganization() *Organization {
 	org := &Organization{
-		ID:                         r.OrganizationID.String,
-		CreatedAt:                  r.CreatedAt.Time.UTC(),
-		UpdatedAt:                  r.UpdatedAt.Time.UTC(),
-		Name:                       r.Name.String,
-		AllowForceDeleteWorkspaces: r.AllowForceDeleteWorkspaces,
-		CostEstimationEnabled:      r.CostEstimationEnabled,
+		ID:                           r.OrganizationID.String,
+		CreatedAt:                    r.CreatedAt.Time.UTC(),
+		UpdatedAt:                    r.UpdatedAt.Time.UTC(),
+		Name:                         r.Name.String,
+		AllowForceDeleteWorkspaces:   r.AllowForceDeleteWorkspaces,
+		CostEstimationEnabled:        r.CostEstimationEnabled,
+		MaxConcurrentRuns:            int(r.MaxConcurrentRuns.Int),
+		MaxConcurrentSpeculativeRuns: int(r.MaxConcurrentSpeculativeRuns.Int),
 	}
 	if r.SessionRemember.Status == pgtype.Present {
 		sessionRememberInt := int(r.SessionRemember.Int)
@@ -14,13 +14,18 @@ type Entitlements struct {
 	StateStorage          bool
 	Teams                 bool
 	VCSIntegrations       bool
+
+	// Limits on the number of runs in flight at any one time; zero means
+	// there is no limit.
+	MaxConcurrentRuns            int
+	MaxConcurrentSpeculativeRuns int
 }
 
 // defaultEntitlements constructs an Entitlements struct with currently
-// supported entitlements.
-func defaultEntitlements(organizationID string) Entitlements {
+// supported entitlements, subject to the organization's concurrency limits.
+func defaultEntitlements(org *Organization) Entitlements {
 	return Entitlements{
-		ID:                    organizationID,
+		ID:                    org.ID,
 		Agents:                true,
 		AuditLogging:          true,
 		CostEstimation:        true,
@@ -31,5 +36,8 @@ func defaultEntitlements(organizationID string) Entitlements {
 		StateStorage:          true,
 		Teams:                 true,
 		VCSIntegrations:       true,
+
+		MaxConcurrentRuns:            org.MaxConcurrentRuns,
+		MaxConcurrentSpeculativeRuns: org.MaxConcurrentSpeculativeRuns,
 	}
 }
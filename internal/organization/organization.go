@@ -21,6 +21,15 @@ type (
 		UpdatedAt time.Time `json:"updated_at"`
 		Name      string    `json:"name"`
 
+		// MaxConcurrentRuns is the maximum number of non-speculative runs the
+		// organization can have in flight at any one time. Zero means there
+		// is no limit.
+		MaxConcurrentRuns int
+		// MaxConcurrentSpeculativeRuns is the maximum number of speculative
+		// plans the organization can have in flight at any one time. Zero
+		// means there is no limit.
+		MaxConcurrentSpeculativeRuns int
+
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
 		Email                      *string
@@ -37,6 +46,9 @@ type (
 		SessionRemember *int
 		SessionTimeout  *int
 
+		MaxConcurrentRuns            *int
+		MaxConcurrentSpeculativeRuns *int
+
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
 		Email                      *string
@@ -50,6 +62,9 @@ type (
 	CreateOptions struct {
 		Name *string `schema:"name,required"`
 
+		MaxConcurrentRuns            *int
+		MaxConcurrentSpeculativeRuns *int
+
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
 		Email                      *string
@@ -85,12 +100,18 @@ func NewOrganization(opts CreateOptions) (*Organization, error) {
 	if opts.CostEstimationEnabled != nil {
 		org.CostEstimationEnabled = *opts.CostEstimationEnabled
 	}
+	if err := org.setConcurrencyLimits(opts.MaxConcurrentRuns, opts.MaxConcurrentSpeculativeRuns); err != nil {
+		return nil, err
+	}
 	return &org, nil
 }
 
 func (org *Organization) String() string { return org.ID }
 
 func (org *Organization) Update(opts UpdateOptions) error {
+	if err := org.setConcurrencyLimits(opts.MaxConcurrentRuns, opts.MaxConcurrentSpeculativeRuns); err != nil {
+		return err
+	}
 	if opts.Name != nil {
 		org.Name = *opts.Name
 	}
@@ -115,3 +136,16 @@ func (org *Organization) Update(opts UpdateOptions) error {
 	org.UpdatedAt = internal.CurrentTimestamp()
 	return nil
 }
+
+func (org *Organization) setConcurrencyLimits(runs, speculative *int) error {
+	if (runs != nil && *runs < 0) || (speculative != nil && *speculative < 0) {
+		return internal.ErrInvalidConcurrencyLimit
+	}
+	if runs != nil {
+		org.MaxConcurrentRuns = *runs
+	}
+	if speculative != nil {
+		org.MaxConcurrentSpeculativeRuns = *speculative
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganization_ConcurrencyLimits(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		org, err := NewOrganization(CreateOptions{
+			Name:              internal.String("acme-corp"),
+			MaxConcurrentRuns: internal.Int(2),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, org.MaxConcurrentRuns)
+		assert.Equal(t, 0, org.MaxConcurrentSpeculativeRuns)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		org := &Organization{Name: "acme-corp", MaxConcurrentRuns: 2}
+
+		err := org.Update(UpdateOptions{MaxConcurrentSpeculativeRuns: internal.Int(4)})
+		require.NoError(t, err)
+		assert.Equal(t, 2, org.MaxConcurrentRuns)
+		assert.Equal(t, 4, org.MaxConcurrentSpeculativeRuns)
+	})
+
+	t.Run("reject negative limit", func(t *testing.T) {
+		_, err := NewOrganization(CreateOptions{
+			Name:                         internal.String("acme-corp"),
+			MaxConcurrentSpeculativeRuns: internal.Int(-1),
+		})
+		assert.Equal(t, internal.ErrInvalidConcurrencyLimit, err)
+
+		org := &Organization{Name: "acme-corp", MaxConcurrentRuns: 2}
+		err = org.Update(UpdateOptions{MaxConcurrentRuns: internal.Int(-1)})
+		assert.Equal(t, internal.ErrInvalidConcurrencyLimit, err)
+		assert.Equal(t, 2, org.MaxConcurrentRuns)
+	})
+}
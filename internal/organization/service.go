@@ -113,15 +113,17 @@ func (s *service) CreateOrganization(ctx context.Context, opts CreateOptions) (*
 
 	err = s.createHook.Dispatch(ctx, org, func(ctx context.Context) error {
 		_, err = s.db.Conn(ctx).InsertOrganization(ctx, pggen.InsertOrganizationParams{
-			ID:                     sql.String(org.ID),
-			CreatedAt:              sql.Timestamptz(org.CreatedAt),
-			UpdatedAt:              sql.Timestamptz(org.UpdatedAt),
-			Name:                   sql.String(org.Name),
-			SessionRemember:        sql.Int4Ptr(org.SessionRemember),
-			SessionTimeout:         sql.Int4Ptr(org.SessionTimeout),
-			Email:                  sql.StringPtr(org.Email),
-			CollaboratorAuthPolicy: sql.StringPtr(org.CollaboratorAuthPolicy),
-			CostEstimationEnabled:  org.CostEstimationEnabled,
+			ID:                           sql.String(org.ID),
+			CreatedAt:                    sql.Timestamptz(org.CreatedAt),
+			UpdatedAt:                    sql.Timestamptz(org.UpdatedAt),
+			Name:                         sql.String(org.Name),
+			SessionRemember:              sql.Int4Ptr(org.SessionRemember),
+			SessionTimeout:               sql.Int4Ptr(org.SessionTimeout),
+			Email:                        sql.StringPtr(org.Email),
+			CollaboratorAuthPolicy:       sql.StringPtr(org.CollaboratorAuthPolicy),
+			CostEstimationEnabled:        org.CostEstimationEnabled,
+			MaxConcurrentRuns:            sql.Int4(org.MaxConcurrentRuns),
+			MaxConcurrentSpeculativeRuns: sql.Int4(org.MaxConcurrentSpeculativeRuns),
 		})
 		return sql.Error(err)
 	})
@@ -216,7 +218,7 @@ func (s *service) GetEntitlements(ctx context.Context, organization string) (Ent
 	if err != nil {
 		return Entitlements{}, err
 	}
-	return defaultEntitlements(org.ID), nil
+	return defaultEntitlements(org), nil
 }
 
 func (s *service) restrictOrganizationCreation(ctx context.Context) (internal.Subject, error) {
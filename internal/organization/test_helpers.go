@@ -37,6 +37,18 @@ func (f *fakeService) ListOrganizations(ctx context.Context, opts ListOptions) (
 	return resource.NewPage(f.orgs, opts.PageOptions, nil), nil
 }
 
+func (f *fakeService) UpdateOrganization(ctx context.Context, name string, opts UpdateOptions) (*Organization, error) {
+	for _, org := range f.orgs {
+		if org.Name == name {
+			if err := org.Update(opts); err != nil {
+				return nil, err
+			}
+			return org, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
 func (f *fakeService) DeleteOrganization(context.Context, string) error {
 	return nil
 }
@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -166,8 +167,10 @@ func (a *web) edit(w http.ResponseWriter, r *http.Request) {
 
 func (a *web) update(w http.ResponseWriter, r *http.Request) {
 	var params struct {
-		Name        string `schema:"name,required"`
-		UpdatedName string `schema:"new_name,required"`
+		Name                         string  `schema:"name,required"`
+		UpdatedName                  *string `schema:"new_name"`
+		MaxConcurrentRuns            *int    `schema:"max_concurrent_runs"`
+		MaxConcurrentSpeculativeRuns *int    `schema:"max_concurrent_speculative_runs"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -175,8 +178,15 @@ func (a *web) update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	org, err := a.svc.UpdateOrganization(r.Context(), params.Name, UpdateOptions{
-		Name: &params.UpdatedName,
+		Name:                         params.UpdatedName,
+		MaxConcurrentRuns:            params.MaxConcurrentRuns,
+		MaxConcurrentSpeculativeRuns: params.MaxConcurrentSpeculativeRuns,
 	})
+	if errors.Is(err, internal.ErrInvalidConcurrencyLimit) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditOrganization(params.Name), http.StatusFound)
+		return
+	}
 	if err != nil {
 		a.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -125,6 +125,45 @@ func TestWeb_ListHandler(t *testing.T) {
 	})
 }
 
+func TestWeb_UpdateHandler(t *testing.T) {
+	t.Run("concurrency limits", func(t *testing.T) {
+		org := &Organization{Name: "acme-corp"}
+		svc := newFakeWeb(t, &fakeService{orgs: []*Organization{org}}, false)
+
+		form := strings.NewReader(url.Values{
+			"name":                            {"acme-corp"},
+			"max_concurrent_runs":             {"3"},
+			"max_concurrent_speculative_runs": {"5"},
+		}.Encode())
+		r := httptest.NewRequest("POST", "/?", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		svc.update(w, r)
+
+		testutils.AssertRedirect(t, w, paths.EditOrganization("acme-corp"))
+		assert.Equal(t, "acme-corp", org.Name)
+		assert.Equal(t, 3, org.MaxConcurrentRuns)
+		assert.Equal(t, 5, org.MaxConcurrentSpeculativeRuns)
+	})
+
+	t.Run("invalid concurrency limit", func(t *testing.T) {
+		org := &Organization{Name: "acme-corp"}
+		svc := newFakeWeb(t, &fakeService{orgs: []*Organization{org}}, false)
+
+		form := strings.NewReader(url.Values{
+			"name":                {"acme-corp"},
+			"max_concurrent_runs": {"-1"},
+		}.Encode())
+		r := httptest.NewRequest("POST", "/?", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		svc.update(w, r)
+
+		testutils.AssertRedirect(t, w, paths.EditOrganization("acme-corp"))
+		assert.Equal(t, 0, org.MaxConcurrentRuns)
+	})
+}
+
 func TestWeb_DeleteHandler(t *testing.T) {
 	svc := newFakeWeb(t, &fakeService{
 		orgs: []*Organization{NewTestOrganization(t)},
@@ -13,3 +13,5 @@ func UInt(i uint) *uint           { return &i }
 func Bool(b bool) *bool           { return &b }
 func Time(t time.Time) *time.Time { return &t }
 func UUID(u uuid.UUID) *uuid.UUID { return &u }
+
+func Duration(d time.Duration) *time.Duration { return &d }
@@ -0,0 +1,170 @@
+package project
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a project database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// pgrow is a database row for a project
+	pgrow struct {
+		ProjectID        pgtype.Text        `json:"project_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		Name             pgtype.Text        `json:"name"`
+		IsDefault        bool               `json:"is_default"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+)
+
+func (r pgrow) toProject() *Project {
+	return &Project{
+		ID:           r.ProjectID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Name:         r.Name.String,
+		Organization: r.OrganizationName.String,
+		Default:      r.IsDefault,
+	}
+}
+
+func (db *pgdb) create(ctx context.Context, p *Project) error {
+	_, err := db.Conn(ctx).InsertProject(ctx, pggen.InsertProjectParams{
+		ProjectID:        sql.String(p.ID),
+		CreatedAt:        sql.Timestamptz(p.CreatedAt),
+		UpdatedAt:        sql.Timestamptz(p.UpdatedAt),
+		Name:             sql.String(p.Name),
+		IsDefault:        p.Default,
+		OrganizationName: sql.String(p.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) update(ctx context.Context, id string, fn func(*Project) error) (*Project, error) {
+	var p *Project
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindProjectByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		p = pgrow(row).toProject()
+		if err := fn(p); err != nil {
+			return err
+		}
+		_, err = q.UpdateProjectByID(ctx, pggen.UpdateProjectByIDParams{
+			Name:      sql.String(p.Name),
+			UpdatedAt: sql.Timestamptz(p.UpdatedAt),
+			ProjectID: sql.String(p.ID),
+		})
+		return sql.Error(err)
+	})
+	return p, err
+}
+
+func (db *pgdb) get(ctx context.Context, id string) (*Project, error) {
+	row, err := db.Conn(ctx).FindProjectByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return pgrow(row).toProject(), nil
+}
+
+func (db *pgdb) getDefault(ctx context.Context, organization string) (*Project, error) {
+	row, err := db.Conn(ctx).FindDefaultProject(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return pgrow(row).toProject(), nil
+}
+
+func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	q.FindProjectsBatch(batch, pggen.FindProjectsParams{
+		OrganizationName: sql.String(opts.Organization),
+		Search:           sql.String(opts.Search),
+		Limit:            opts.GetLimit(),
+		Offset:           opts.GetOffset(),
+	})
+	q.CountProjectsBatch(batch, sql.String(opts.Organization), sql.String(opts.Search))
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindProjectsScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountProjectsScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*Project, len(rows))
+	for i, r := range rows {
+		items[i] = pgrow(r).toProject()
+	}
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
+}
+
+func (db *pgdb) delete(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteProjectByID(ctx, sql.String(id))
+	if err != nil {
+		err = sql.Error(err)
+		// workspaces reference their project and must be moved or deleted
+		// first.
+		var fkErr *internal.ForeignKeyError
+		if errors.As(err, &fkErr) {
+			return internal.ErrProjectNotEmpty
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *pgdb) setPermission(ctx context.Context, projectID, team string, role rbac.Role) error {
+	_, err := db.Conn(ctx).UpsertProjectPermission(ctx, pggen.UpsertProjectPermissionParams{
+		ProjectID: sql.String(projectID),
+		TeamName:  sql.String(team),
+		Role:      sql.String(role.String()),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listPermissions(ctx context.Context, projectID string) ([]internal.WorkspacePermission, error) {
+	rows, err := db.Conn(ctx).FindProjectPermissionsByProjectID(ctx, sql.String(projectID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	perms := make([]internal.WorkspacePermission, len(rows))
+	for i, r := range rows {
+		role, err := rbac.WorkspaceRoleFromString(r.Role.String)
+		if err != nil {
+			return nil, err
+		}
+		perms[i] = internal.WorkspacePermission{
+			Team:   r.Team.Name.String,
+			TeamID: r.Team.TeamID.String,
+			Role:   role,
+		}
+	}
+	return perms, nil
+}
+
+func (db *pgdb) unsetPermission(ctx context.Context, projectID, team string) error {
+	_, err := db.Conn(ctx).DeleteProjectPermissionByID(ctx, sql.String(projectID), sql.String(team))
+	return sql.Error(err)
+}
@@ -0,0 +1,111 @@
+// Package project provides projects, which group workspaces within an
+// organization.
+package project
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"golang.org/x/exp/slog"
+)
+
+// DefaultProjectName is the name of the project created for each
+// organization, to which workspaces belong unless assigned to another project.
+const DefaultProjectName = "Default Project"
+
+// project names may contain spaces, unlike most other resource names.
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9 _-]{2,39}$`)
+
+type (
+	// Project groups workspaces within an organization. Permissions granted to
+	// a team on a project apply to all the workspaces in the project.
+	Project struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Name         string
+		Organization string
+		// Default is true if this is the organization's default project.
+		Default bool
+	}
+
+	CreateOptions struct {
+		Organization string
+		Name         *string
+	}
+
+	UpdateOptions struct {
+		Name *string
+	}
+
+	// ListOptions are options for paginating and filtering a list of
+	// projects.
+	ListOptions struct {
+		Organization string
+		// Search filters projects by name.
+		Search string
+
+		resource.PageOptions
+	}
+)
+
+func newProject(opts CreateOptions) (*Project, error) {
+	if opts.Organization == "" {
+		return nil, internal.ErrRequiredOrg
+	}
+	p := &Project{
+		ID:           internal.NewID("prj"),
+		CreatedAt:    internal.CurrentTimestamp(),
+		UpdatedAt:    internal.CurrentTimestamp(),
+		Organization: opts.Organization,
+	}
+	if err := p.setName(opts.Name); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// newDefaultProject constructs the default project for an organization.
+func newDefaultProject(organization string) (*Project, error) {
+	p, err := newProject(CreateOptions{
+		Organization: organization,
+		Name:         internal.String(DefaultProjectName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.Default = true
+	return p, nil
+}
+
+func (p *Project) update(opts UpdateOptions) error {
+	if opts.Name != nil {
+		if err := p.setName(opts.Name); err != nil {
+			return err
+		}
+		p.UpdatedAt = internal.CurrentTimestamp()
+	}
+	return nil
+}
+
+func (p *Project) setName(name *string) error {
+	if name == nil {
+		return internal.ErrRequiredName
+	}
+	if !validName.MatchString(*name) {
+		return internal.ErrInvalidName
+	}
+	p.Name = *name
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+func (p *Project) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("organization", p.Organization),
+		slog.String("name", p.Name),
+	)
+}
@@ -0,0 +1,56 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateOptions
+		wantErr error
+	}{
+		{"valid", CreateOptions{Organization: "acme", Name: internal.String("networking")}, nil},
+		{"name with spaces", CreateOptions{Organization: "acme", Name: internal.String("core networking")}, nil},
+		{"missing organization", CreateOptions{Name: internal.String("networking")}, internal.ErrRequiredOrg},
+		{"missing name", CreateOptions{Organization: "acme"}, internal.ErrRequiredName},
+		{"name too short", CreateOptions{Organization: "acme", Name: internal.String("ab")}, internal.ErrInvalidName},
+		{"invalid name", CreateOptions{Organization: "acme", Name: internal.String("net/working")}, internal.ErrInvalidName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newProject(tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, *tt.opts.Name, p.Name)
+			assert.False(t, p.Default)
+		})
+	}
+}
+
+func TestNewDefaultProject(t *testing.T) {
+	p, err := newDefaultProject("acme")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProjectName, p.Name)
+	assert.True(t, p.Default)
+}
+
+func TestProject_Update(t *testing.T) {
+	p, err := newProject(CreateOptions{Organization: "acme", Name: internal.String("networking")})
+	require.NoError(t, err)
+
+	err = p.update(UpdateOptions{Name: internal.String("compute")})
+	require.NoError(t, err)
+	assert.Equal(t, "compute", p.Name)
+
+	err = p.update(UpdateOptions{Name: internal.String("!")})
+	assert.ErrorIs(t, err, internal.ErrInvalidName)
+	assert.Equal(t, "compute", p.Name)
+}
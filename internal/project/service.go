@@ -0,0 +1,234 @@
+package project
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	ProjectService = Service
+
+	Service interface {
+		CreateProject(ctx context.Context, opts CreateOptions) (*Project, error)
+		UpdateProject(ctx context.Context, id string, opts UpdateOptions) (*Project, error)
+		GetProject(ctx context.Context, id string) (*Project, error)
+		// GetDefaultProject retrieves an organization's default project.
+		GetDefaultProject(ctx context.Context, organization string) (*Project, error)
+		ListProjects(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error)
+		DeleteProject(ctx context.Context, id string) (*Project, error)
+
+		// SetProjectPermission grants a team a role on all workspaces in a
+		// project.
+		SetProjectPermission(ctx context.Context, projectID, team string, role rbac.Role) error
+		ListProjectPermissions(ctx context.Context, projectID string) ([]internal.WorkspacePermission, error)
+		UnsetProjectPermission(ctx context.Context, projectID, team string) error
+	}
+
+	service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+		db           *pgdb
+		web          *webHandlers
+	}
+
+	Options struct {
+		*sql.DB
+		html.Renderer
+		logr.Logger
+
+		organization.OrganizationService
+		auth.TeamService
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.web = &webHandlers{
+		Renderer:    opts.Renderer,
+		TeamService: opts.TeamService,
+		svc:         &svc,
+	}
+
+	// Whenever an organization is created, also create its default project.
+	opts.OrganizationService.AfterCreateOrganization(svc.createDefaultProject)
+
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+}
+
+func (s *service) CreateProject(ctx context.Context, opts CreateOptions) (*Project, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateProjectAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	p, err := newProject(opts)
+	if err != nil {
+		s.Error(err, "constructing project", "subject", subject)
+		return nil, err
+	}
+	if err := s.db.create(ctx, p); err != nil {
+		s.Error(err, "creating project", "project", p, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created project", "project", p, "subject", subject)
+	return p, nil
+}
+
+func (s *service) UpdateProject(ctx context.Context, id string, opts UpdateOptions) (*Project, error) {
+	var subject internal.Subject
+	p, err := s.db.update(ctx, id, func(p *Project) (err error) {
+		subject, err = s.organization.CanAccess(ctx, rbac.UpdateProjectAction, p.Organization)
+		if err != nil {
+			return err
+		}
+		return p.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating project", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated project", "project", p, "subject", subject)
+	return p, nil
+}
+
+func (s *service) GetProject(ctx context.Context, id string) (*Project, error) {
+	p, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving project", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetProjectAction, p.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved project", "project", p, "subject", subject)
+	return p, nil
+}
+
+func (s *service) GetDefaultProject(ctx context.Context, organization string) (*Project, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetProjectAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.db.getDefault(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving default project", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved default project", "project", p, "subject", subject)
+	return p, nil
+}
+
+func (s *service) ListProjects(ctx context.Context, opts ListOptions) (*resource.Page[*Project], error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListProjectsAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.db.list(ctx, opts)
+	if err != nil {
+		s.Error(err, "listing projects", "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed projects", "organization", opts.Organization, "count", len(page.Items), "subject", subject)
+	return page, nil
+}
+
+func (s *service) DeleteProject(ctx context.Context, id string) (*Project, error) {
+	p, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving project", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteProjectAction, p.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if p.Default {
+		return nil, internal.ErrDefaultProjectDelete
+	}
+	if err := s.db.delete(ctx, id); err != nil {
+		s.Error(err, "deleting project", "project", p, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("deleted project", "project", p, "subject", subject)
+	return p, nil
+}
+
+func (s *service) SetProjectPermission(ctx context.Context, projectID, team string, role rbac.Role) error {
+	p, err := s.db.get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.SetProjectPermissionAction, p.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.setPermission(ctx, projectID, team, role); err != nil {
+		s.Error(err, "setting project permission", "project", p, "team", team, "subject", subject)
+		return err
+	}
+	s.V(0).Info("set project permission", "project", p, "team", team, "role", role, "subject", subject)
+	return nil
+}
+
+func (s *service) ListProjectPermissions(ctx context.Context, projectID string) ([]internal.WorkspacePermission, error) {
+	p, err := s.db.get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetProjectAction, p.Organization)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := s.db.listPermissions(ctx, projectID)
+	if err != nil {
+		s.Error(err, "listing project permissions", "project", p, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed project permissions", "project", p, "subject", subject)
+	return perms, nil
+}
+
+func (s *service) UnsetProjectPermission(ctx context.Context, projectID, team string) error {
+	p, err := s.db.get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.UnsetProjectPermissionAction, p.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.unsetPermission(ctx, projectID, team); err != nil {
+		s.Error(err, "unsetting project permission", "project", p, "team", team, "subject", subject)
+		return err
+	}
+	s.V(0).Info("unset project permission", "project", p, "team", team, "subject", subject)
+	return nil
+}
+
+// createDefaultProject creates the default project for a newly created
+// organization.
+func (s *service) createDefaultProject(ctx context.Context, org *organization.Organization) error {
+	p, err := newDefaultProject(org.Name)
+	if err != nil {
+		return err
+	}
+	return s.db.create(ctx, p)
+}
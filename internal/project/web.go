@@ -0,0 +1,260 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+)
+
+type webHandlers struct {
+	html.Renderer
+	auth.TeamService
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/projects", h.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/projects/new", h.new).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/projects/create", h.create).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/edit", h.edit).Methods("GET")
+	r.HandleFunc("/projects/{project_id}/update", h.update).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/delete", h.delete).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/set-permission", h.setPermission).Methods("POST")
+	r.HandleFunc("/projects/{project_id}/unset-permission", h.unsetPermission).Methods("POST")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	page, err := h.svc.ListProjects(r.Context(), ListOptions{
+		Organization: params.Organization,
+		PageOptions:  params.PageOptions,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("project_list.tmpl", w, struct {
+		organization.OrganizationPage
+		*resource.Page[*Project]
+	}{
+		OrganizationPage: organization.NewPage(r, "projects", params.Organization),
+		Page:             page,
+	})
+}
+
+func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.Render("project_new.tmpl", w, struct {
+		organization.OrganizationPage
+	}{
+		OrganizationPage: organization.NewPage(r, "new project", org),
+	})
+}
+
+func (h *webHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string  `schema:"organization_name,required"`
+		Name         *string `schema:"name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	p, err := h.svc.CreateProject(r.Context(), CreateOptions{
+		Organization: params.Organization,
+		Name:         params.Name,
+	})
+	if err == internal.ErrResourceAlreadyExists || err == internal.ErrInvalidName {
+		html.FlashError(w, "cannot create project: "+err.Error())
+		http.Redirect(w, r, paths.NewProject(params.Organization), http.StatusFound)
+		return
+	}
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "created project: "+p.Name)
+	http.Redirect(w, r, paths.EditProject(p.ID), http.StatusFound)
+}
+
+func (h *webHandlers) edit(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("project_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	p, err := h.svc.GetProject(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	perms, err := h.svc.ListProjectPermissions(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	teams, err := h.ListTeams(r.Context(), p.Organization)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("project_edit.tmpl", w, struct {
+		organization.OrganizationPage
+		Project     *Project
+		Permissions []internal.WorkspacePermission
+		Unassigned  []*auth.Team
+		Roles       []rbac.Role
+	}{
+		OrganizationPage: organization.NewPage(r, "edit | "+p.Name, p.Organization),
+		Project:          p,
+		Permissions:      perms,
+		Unassigned:       filterUnassigned(perms, teams),
+		Roles: []rbac.Role{
+			rbac.WorkspaceReadRole,
+			rbac.WorkspacePlanRole,
+			rbac.WorkspaceWriteRole,
+			rbac.WorkspaceAdminRole,
+		},
+	})
+}
+
+func (h *webHandlers) update(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID   string  `schema:"project_id,required"`
+		Name *string `schema:"name"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	p, err := h.svc.UpdateProject(r.Context(), params.ID, UpdateOptions{
+		Name: params.Name,
+	})
+	if err == internal.ErrResourceAlreadyExists || err == internal.ErrInvalidName {
+		html.FlashError(w, "cannot update project: "+err.Error())
+		http.Redirect(w, r, paths.EditProject(params.ID), http.StatusFound)
+		return
+	}
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "updated project: "+p.Name)
+	http.Redirect(w, r, paths.EditProject(p.ID), http.StatusFound)
+}
+
+func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("project_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	p, err := h.svc.DeleteProject(r.Context(), id)
+	if err == internal.ErrDefaultProjectDelete || err == internal.ErrProjectNotEmpty {
+		html.FlashError(w, "cannot delete project: "+err.Error())
+		http.Redirect(w, r, paths.EditProject(id), http.StatusFound)
+		return
+	}
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted project: "+p.Name)
+	http.Redirect(w, r, paths.Projects(p.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) setPermission(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProjectID string `schema:"project_id,required"`
+		TeamName  string `schema:"team_name,required"`
+		Role      string `schema:"role,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	role, err := rbac.WorkspaceRoleFromString(params.Role)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = h.svc.SetProjectPermission(r.Context(), params.ProjectID, params.TeamName, role)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "updated project permissions")
+	http.Redirect(w, r, paths.EditProject(params.ProjectID), http.StatusFound)
+}
+
+func (h *webHandlers) unsetPermission(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProjectID string `schema:"project_id,required"`
+		TeamName  string `schema:"team_name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.svc.UnsetProjectPermission(r.Context(), params.ProjectID, params.TeamName)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "deleted project permission")
+	http.Redirect(w, r, paths.EditProject(params.ProjectID), http.StatusFound)
+}
+
+// filterUnassigned removes from the list of teams those that have been
+// assigned a permission on the project, along with the owners team, which
+// implicitly has admin rights on all workspaces.
+func filterUnassigned(perms []internal.WorkspacePermission, teams []*auth.Team) (unassigned []*auth.Team) {
+	assigned := make(map[string]struct{}, len(perms))
+	for _, p := range perms {
+		assigned[p.Team] = struct{}{}
+	}
+	for _, t := range teams {
+		if t.IsOwners() {
+			continue
+		}
+		if _, ok := assigned[t.Name]; !ok {
+			unassigned = append(unassigned, t)
+		}
+	}
+	return
+}
@@ -44,6 +44,8 @@ const (
 	GetRunAction
 	ListRunsAction
 	ApplyRunAction
+	ApproveRunAction
+	CommentRunAction
 	CreateRunAction
 	DiscardRunAction
 	DeleteRunAction
@@ -51,6 +53,7 @@ const (
 	EnqueuePlanAction
 	StartPhaseAction
 	FinishPhaseAction
+	FinishTaskStageAction
 	PutChunkAction
 	TailLogsAction
 
@@ -60,6 +63,12 @@ const (
 	GetLockFileAction
 	UploadLockFileAction
 
+	GetStructuredOutputAction
+	UploadStructuredOutputAction
+
+	GetTestResultsAction
+	UploadTestResultsAction
+
 	ListWorkspacesAction
 	GetWorkspaceAction
 	CreateWorkspaceAction
@@ -111,4 +120,36 @@ const (
 	ListNotificationConfigurationsAction
 	GetNotificationConfigurationAction
 	DeleteNotificationConfigurationAction
+
+	CreateRunTaskAction
+	UpdateRunTaskAction
+	ListRunTasksAction
+	GetRunTaskAction
+	DeleteRunTaskAction
+
+	CreateWorkspaceRunTaskAction
+	UpdateWorkspaceRunTaskAction
+	ListWorkspaceRunTasksAction
+	GetWorkspaceRunTaskAction
+	DeleteWorkspaceRunTaskAction
+
+	CreateProjectAction
+	UpdateProjectAction
+	ListProjectsAction
+	GetProjectAction
+	DeleteProjectAction
+	SetProjectPermissionAction
+	UnsetProjectPermissionAction
+
+	SearchResourcesAction
+
+	CreateStateOperationAction
+	ListStateOperationsAction
+
+	PinStateVersionAction
+	UnpinStateVersionAction
+
+	CreateVCSUserMappingAction
+	ListVCSUserMappingsAction
+	DeleteVCSUserMappingAction
 )
@@ -40,68 +40,100 @@ func _() {
 	_ = x[GetRunAction-29]
 	_ = x[ListRunsAction-30]
 	_ = x[ApplyRunAction-31]
-	_ = x[CreateRunAction-32]
-	_ = x[DiscardRunAction-33]
-	_ = x[DeleteRunAction-34]
-	_ = x[CancelRunAction-35]
-	_ = x[EnqueuePlanAction-36]
-	_ = x[StartPhaseAction-37]
-	_ = x[FinishPhaseAction-38]
-	_ = x[PutChunkAction-39]
-	_ = x[TailLogsAction-40]
-	_ = x[GetPlanFileAction-41]
-	_ = x[UploadPlanFileAction-42]
-	_ = x[GetLockFileAction-43]
-	_ = x[UploadLockFileAction-44]
-	_ = x[ListWorkspacesAction-45]
-	_ = x[GetWorkspaceAction-46]
-	_ = x[CreateWorkspaceAction-47]
-	_ = x[DeleteWorkspaceAction-48]
-	_ = x[SetWorkspacePermissionAction-49]
-	_ = x[UnsetWorkspacePermissionAction-50]
-	_ = x[UpdateWorkspaceAction-51]
-	_ = x[ListTagsAction-52]
-	_ = x[DeleteTagsAction-53]
-	_ = x[TagWorkspacesAction-54]
-	_ = x[AddTagsAction-55]
-	_ = x[RemoveTagsAction-56]
-	_ = x[ListWorkspaceTags-57]
-	_ = x[LockWorkspaceAction-58]
-	_ = x[UnlockWorkspaceAction-59]
-	_ = x[ForceUnlockWorkspaceAction-60]
-	_ = x[CreateStateVersionAction-61]
-	_ = x[ListStateVersionsAction-62]
-	_ = x[GetStateVersionAction-63]
-	_ = x[DeleteStateVersionAction-64]
-	_ = x[RollbackStateVersionAction-65]
-	_ = x[DownloadStateAction-66]
-	_ = x[GetStateVersionOutputAction-67]
-	_ = x[CreateConfigurationVersionAction-68]
-	_ = x[ListConfigurationVersionsAction-69]
-	_ = x[GetConfigurationVersionAction-70]
-	_ = x[DownloadConfigurationVersionAction-71]
-	_ = x[DeleteConfigurationVersionAction-72]
-	_ = x[CreateUserAction-73]
-	_ = x[ListUsersAction-74]
-	_ = x[GetUserAction-75]
-	_ = x[DeleteUserAction-76]
-	_ = x[CreateTeamAction-77]
-	_ = x[UpdateTeamAction-78]
-	_ = x[GetTeamAction-79]
-	_ = x[ListTeamsAction-80]
-	_ = x[DeleteTeamAction-81]
-	_ = x[AddTeamMembershipAction-82]
-	_ = x[RemoveTeamMembershipAction-83]
-	_ = x[CreateNotificationConfigurationAction-84]
-	_ = x[UpdateNotificationConfigurationAction-85]
-	_ = x[ListNotificationConfigurationsAction-86]
-	_ = x[GetNotificationConfigurationAction-87]
-	_ = x[DeleteNotificationConfigurationAction-88]
+	_ = x[ApproveRunAction-32]
+	_ = x[CommentRunAction-33]
+	_ = x[CreateRunAction-34]
+	_ = x[DiscardRunAction-35]
+	_ = x[DeleteRunAction-36]
+	_ = x[CancelRunAction-37]
+	_ = x[EnqueuePlanAction-38]
+	_ = x[StartPhaseAction-39]
+	_ = x[FinishPhaseAction-40]
+	_ = x[FinishTaskStageAction-41]
+	_ = x[PutChunkAction-42]
+	_ = x[TailLogsAction-43]
+	_ = x[GetPlanFileAction-44]
+	_ = x[UploadPlanFileAction-45]
+	_ = x[GetLockFileAction-46]
+	_ = x[UploadLockFileAction-47]
+	_ = x[GetStructuredOutputAction-48]
+	_ = x[UploadStructuredOutputAction-49]
+	_ = x[GetTestResultsAction-50]
+	_ = x[UploadTestResultsAction-51]
+	_ = x[ListWorkspacesAction-52]
+	_ = x[GetWorkspaceAction-53]
+	_ = x[CreateWorkspaceAction-54]
+	_ = x[DeleteWorkspaceAction-55]
+	_ = x[SetWorkspacePermissionAction-56]
+	_ = x[UnsetWorkspacePermissionAction-57]
+	_ = x[UpdateWorkspaceAction-58]
+	_ = x[ListTagsAction-59]
+	_ = x[DeleteTagsAction-60]
+	_ = x[TagWorkspacesAction-61]
+	_ = x[AddTagsAction-62]
+	_ = x[RemoveTagsAction-63]
+	_ = x[ListWorkspaceTags-64]
+	_ = x[LockWorkspaceAction-65]
+	_ = x[UnlockWorkspaceAction-66]
+	_ = x[ForceUnlockWorkspaceAction-67]
+	_ = x[CreateStateVersionAction-68]
+	_ = x[ListStateVersionsAction-69]
+	_ = x[GetStateVersionAction-70]
+	_ = x[DeleteStateVersionAction-71]
+	_ = x[RollbackStateVersionAction-72]
+	_ = x[DownloadStateAction-73]
+	_ = x[GetStateVersionOutputAction-74]
+	_ = x[CreateConfigurationVersionAction-75]
+	_ = x[ListConfigurationVersionsAction-76]
+	_ = x[GetConfigurationVersionAction-77]
+	_ = x[DownloadConfigurationVersionAction-78]
+	_ = x[DeleteConfigurationVersionAction-79]
+	_ = x[CreateUserAction-80]
+	_ = x[ListUsersAction-81]
+	_ = x[GetUserAction-82]
+	_ = x[DeleteUserAction-83]
+	_ = x[CreateTeamAction-84]
+	_ = x[UpdateTeamAction-85]
+	_ = x[GetTeamAction-86]
+	_ = x[ListTeamsAction-87]
+	_ = x[DeleteTeamAction-88]
+	_ = x[AddTeamMembershipAction-89]
+	_ = x[RemoveTeamMembershipAction-90]
+	_ = x[CreateNotificationConfigurationAction-91]
+	_ = x[UpdateNotificationConfigurationAction-92]
+	_ = x[ListNotificationConfigurationsAction-93]
+	_ = x[GetNotificationConfigurationAction-94]
+	_ = x[DeleteNotificationConfigurationAction-95]
+	_ = x[CreateRunTaskAction-96]
+	_ = x[UpdateRunTaskAction-97]
+	_ = x[ListRunTasksAction-98]
+	_ = x[GetRunTaskAction-99]
+	_ = x[DeleteRunTaskAction-100]
+	_ = x[CreateWorkspaceRunTaskAction-101]
+	_ = x[UpdateWorkspaceRunTaskAction-102]
+	_ = x[ListWorkspaceRunTasksAction-103]
+	_ = x[GetWorkspaceRunTaskAction-104]
+	_ = x[DeleteWorkspaceRunTaskAction-105]
+	_ = x[CreateProjectAction-106]
+	_ = x[UpdateProjectAction-107]
+	_ = x[ListProjectsAction-108]
+	_ = x[GetProjectAction-109]
+	_ = x[DeleteProjectAction-110]
+	_ = x[SetProjectPermissionAction-111]
+	_ = x[UnsetProjectPermissionAction-112]
+	_ = x[SearchResourcesAction-113]
+	_ = x[CreateStateOperationAction-114]
+	_ = x[ListStateOperationsAction-115]
+	_ = x[PinStateVersionAction-116]
+	_ = x[UnpinStateVersionAction-117]
+	_ = x[CreateVCSUserMappingAction-118]
+	_ = x[ListVCSUserMappingsAction-119]
+	_ = x[DeleteVCSUserMappingAction-120]
 }
 
-const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentTokenActionListAgentTokensActionDeleteAgentTokenActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateVariableActionUpdateVariableActionListVariablesActionGetVariableActionDeleteVariableActionGetRunActionListRunsActionApplyRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionEnqueuePlanActionStartPhaseActionFinishPhaseActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationAction"
+const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentTokenActionListAgentTokensActionDeleteAgentTokenActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateVariableActionUpdateVariableActionListVariablesActionGetVariableActionDeleteVariableActionGetRunActionListRunsActionApplyRunActionApproveRunActionCommentRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionEnqueuePlanActionStartPhaseActionFinishPhaseActionFinishTaskStageActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionGetStructuredOutputActionUploadStructuredOutputActionGetTestResultsActionUploadTestResultsActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateRunTaskActionUpdateRunTaskActionListRunTasksActionGetRunTaskActionDeleteRunTaskActionCreateWorkspaceRunTaskActionUpdateWorkspaceRunTaskActionListWorkspaceRunTasksActionGetWorkspaceRunTaskActionDeleteWorkspaceRunTaskActionCreateProjectActionUpdateProjectActionListProjectsActionGetProjectActionDeleteProjectActionSetProjectPermissionActionUnsetProjectPermissionActionSearchResourcesActionCreateStateOperationActionListStateOperationsActionPinStateVersionActionUnpinStateVersionActionCreateVCSUserMappingActionListVCSUserMappingsActionDeleteVCSUserMappingAction"
 
-var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 258, 279, 301, 330, 359, 379, 397, 422, 440, 457, 472, 490, 515, 535, 555, 574, 591, 611, 623, 637, 651, 666, 682, 697, 712, 729, 745, 762, 776, 790, 807, 827, 844, 864, 884, 902, 923, 944, 972, 1002, 1023, 1037, 1053, 1072, 1085, 1101, 1118, 1137, 1158, 1184, 1208, 1231, 1252, 1276, 1302, 1321, 1348, 1380, 1411, 1440, 1474, 1506, 1522, 1537, 1550, 1566, 1582, 1598, 1611, 1626, 1642, 1665, 1691, 1728, 1765, 1801, 1835, 1872}
+var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 258, 279, 301, 330, 359, 379, 397, 422, 440, 457, 472, 490, 515, 535, 555, 574, 591, 611, 623, 637, 651, 667, 683, 698, 714, 729, 744, 761, 777, 794, 815, 829, 843, 860, 880, 897, 917, 942, 970, 990, 1013, 1033, 1051, 1072, 1093, 1121, 1151, 1172, 1186, 1202, 1221, 1234, 1250, 1267, 1286, 1307, 1333, 1357, 1380, 1401, 1425, 1451, 1470, 1497, 1529, 1560, 1589, 1623, 1655, 1671, 1686, 1699, 1715, 1731, 1747, 1760, 1775, 1791, 1814, 1840, 1877, 1914, 1950, 1984, 2021, 2040, 2059, 2077, 2093, 2112, 2140, 2168, 2195, 2220, 2248, 2267, 2286, 2304, 2320, 2339, 2365, 2393, 2414, 2440, 2465, 2486, 2509, 2535, 2560, 2586}
 
 func (i Action) String() string {
 	if i < 0 || i >= Action(len(_Action_index)-1) {
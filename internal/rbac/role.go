@@ -20,6 +20,10 @@ var (
 			ListTagsAction:         true,
 			ListVCSProvidersAction: true,
 			GetVCSProviderAction:   true,
+			ListRunTasksAction:     true,
+			GetRunTaskAction:       true,
+			ListProjectsAction:     true,
+			GetProjectAction:       true,
 		},
 	}
 
@@ -30,6 +34,8 @@ var (
 		permissions: map[Action]bool{
 			ListRunsAction:                       true,
 			GetPlanFileAction:                    true,
+			GetStructuredOutputAction:            true,
+			GetTestResultsAction:                 true,
 			GetWorkspaceAction:                   true,
 			GetStateVersionAction:                true,
 			DownloadStateAction:                  true,
@@ -43,6 +49,9 @@ var (
 			TailLogsAction:                       true,
 			ListNotificationConfigurationsAction: true,
 			GetNotificationConfigurationAction:   true,
+			ListWorkspaceRunTasksAction:          true,
+			GetWorkspaceRunTaskAction:            true,
+			ListStateOperationsAction:            true,
 		},
 	}
 
@@ -52,6 +61,7 @@ var (
 		name: "plan",
 		permissions: map[Action]bool{
 			CreateRunAction:                  true,
+			CommentRunAction:                 true,
 			CreateConfigurationVersionAction: true,
 			// includes WorkspaceReadRole perms too (see below)
 		},
@@ -63,6 +73,7 @@ var (
 		name: "write",
 		permissions: map[Action]bool{
 			ApplyRunAction:                        true,
+			ApproveRunAction:                      true,
 			LockWorkspaceAction:                   true,
 			UnlockWorkspaceAction:                 true,
 			CreateVariableAction:                  true,
@@ -85,6 +96,12 @@ var (
 			DeleteWorkspaceAction:          true,
 			ForceUnlockWorkspaceAction:     true,
 			UpdateWorkspaceAction:          true,
+			CreateWorkspaceRunTaskAction:   true,
+			UpdateWorkspaceRunTaskAction:   true,
+			DeleteWorkspaceRunTaskAction:   true,
+			CreateStateOperationAction:     true,
+			PinStateVersionAction:          true,
+			UnpinStateVersionAction:        true,
 			// includes WorkspaceWriteRole perms too (see below)
 		},
 	}
@@ -99,6 +116,16 @@ var (
 			UpdateWorkspaceAction: true,
 			AddTagsAction:         true,
 			RemoveTagsAction:      true,
+			// projects group workspaces, so managing projects falls to
+			// those that manage workspaces
+			CreateProjectAction:          true,
+			UpdateProjectAction:          true,
+			DeleteProjectAction:          true,
+			SetProjectPermissionAction:   true,
+			UnsetProjectPermissionAction: true,
+			// the resource inventory spans all of an organization's
+			// workspaces
+			SearchResourcesAction: true,
 			// includes WorkspaceAdminRole perms too (see below)
 		},
 	}
@@ -108,8 +135,11 @@ var (
 	VCSManagerRole = Role{
 		name: "vcs-manager",
 		permissions: map[Action]bool{
-			CreateVCSProviderAction: true,
-			DeleteVCSProviderAction: true,
+			CreateVCSProviderAction:    true,
+			DeleteVCSProviderAction:    true,
+			CreateVCSUserMappingAction: true,
+			ListVCSUserMappingsAction:  true,
+			DeleteVCSUserMappingAction: true,
 		},
 	}
 
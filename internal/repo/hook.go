@@ -10,6 +10,7 @@ import (
 var defaultEvents = []cloud.VCSEventType{
 	cloud.VCSEventTypePush,
 	cloud.VCSEventTypePull,
+	cloud.VCSEventTypeComment,
 }
 
 // hook is a webhook for a VCS repo
@@ -20,8 +20,17 @@ const (
 	RunPlanQueued         RunStatus = "plan_queued"
 	RunPlanned            RunStatus = "planned"
 	RunPlannedAndFinished RunStatus = "planned_and_finished"
+	RunPlannedAndSaved    RunStatus = "planned_and_saved"
 	RunPlanning           RunStatus = "planning"
 
+	// Statuses in which a run awaits the results of run tasks
+	RunPrePlanRunning    RunStatus = "pre_plan_running"
+	RunPrePlanCompleted  RunStatus = "pre_plan_completed"
+	RunPostPlanRunning   RunStatus = "post_plan_running"
+	RunPostPlanCompleted RunStatus = "post_plan_completed"
+	RunPreApplyRunning   RunStatus = "pre_apply_running"
+	RunPreApplyCompleted RunStatus = "pre_apply_completed"
+
 	// OTF doesn't support cost estimation but go-tfe API tests expect this
 	// status so it is included expressly to pass the tests.
 	RunCostEstimated RunStatus = "cost_estimated"
@@ -35,6 +44,9 @@ var (
 		RunPlanQueued,
 		RunPlanned,
 		RunPlanning,
+		RunPrePlanRunning,
+		RunPostPlanRunning,
+		RunPreApplyRunning,
 	}
 	IncompleteRun = append(ActiveRun, RunPending)
 	CompletedRun  = []RunStatus{
@@ -0,0 +1,153 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+const (
+	ApprovedDecision ApprovalDecision = "approved"
+	RejectedDecision ApprovalDecision = "rejected"
+)
+
+type (
+	// ApprovalDecision is the decision made by an approver on a run.
+	ApprovalDecision string
+
+	// Approval is a decision by a user to either approve or reject a run
+	// before it is applied.
+	Approval struct {
+		ID        string           `json:"id"`
+		CreatedAt time.Time        `json:"created_at"`
+		RunID     string           `json:"run_id"`
+		Username  string           `json:"username"`
+		Decision  ApprovalDecision `json:"decision"`
+		Comment   string           `json:"comment"`
+	}
+
+	approvalService interface {
+		// ApproveRun records the approval of a run by the user in the context.
+		// If the run has auto-apply enabled and this approval provides the
+		// last of the required approvals then the apply is enqueued.
+		ApproveRun(ctx context.Context, runID, comment string) (*Approval, error)
+		// RejectRun records the rejection of a run by the user in the context.
+		// A rejected run cannot be applied.
+		RejectRun(ctx context.Context, runID, comment string) (*Approval, error)
+		// ListApprovals lists the approval decisions made on a run.
+		ListApprovals(ctx context.Context, runID string) ([]*Approval, error)
+	}
+)
+
+// newApproval constructs an approval decision by a user on a run, checking
+// the user is permitted to make the decision.
+func newApproval(run *Run, policy *workspace.ApprovalPolicy, user *auth.User, decision ApprovalDecision, comment string) (*Approval, error) {
+	if !run.AwaitingApproval() {
+		return nil, internal.ErrRunApprovalNotAllowed
+	}
+	if err := checkApprover(run, policy, user); err != nil {
+		return nil, err
+	}
+	return &Approval{
+		ID:        internal.NewID("apr"),
+		CreatedAt: internal.CurrentTimestamp(),
+		RunID:     run.ID,
+		Username:  user.Username,
+		Decision:  decision,
+		Comment:   comment,
+	}, nil
+}
+
+// checkApprover checks whether the user is permitted by the approval policy to
+// make a decision on the run.
+func checkApprover(run *Run, policy *workspace.ApprovalPolicy, user *auth.User) error {
+	if policy.ExcludeAuthor && run.CreatedBy != nil && *run.CreatedBy == user.Username {
+		return internal.ErrRunSelfApproval
+	}
+	if !policy.IsApprover(user, run.Organization) {
+		return internal.ErrAccessNotPermitted
+	}
+	for _, approval := range run.Approvals {
+		if approval.Username == user.Username {
+			// user has already made a decision
+			return internal.ErrResourceAlreadyExists
+		}
+	}
+	return nil
+}
+
+func (s *service) ApproveRun(ctx context.Context, runID, comment string) (*Approval, error) {
+	return s.decide(ctx, runID, ApprovedDecision, comment)
+}
+
+func (s *service) RejectRun(ctx context.Context, runID, comment string) (*Approval, error) {
+	return s.decide(ctx, runID, RejectedDecision, comment)
+}
+
+func (s *service) decide(ctx context.Context, runID string, decision ApprovalDecision, comment string) (*Approval, error) {
+	subject, err := s.CanAccess(ctx, rbac.ApproveRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	user, ok := subject.(*auth.User)
+	if !ok {
+		return nil, fmt.Errorf("only a user can approve or reject a run")
+	}
+
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.GetApprovalPolicy(ctx, run.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := s.db.CreateApproval(ctx, runID, func(run *Run) (*Approval, error) {
+		approval, err := newApproval(run, policy, user, decision, comment)
+		if err != nil {
+			return nil, err
+		}
+		if err := run.addApproval(approval); err != nil {
+			return nil, err
+		}
+		return approval, nil
+	})
+	if err != nil {
+		s.Error(err, "recording run approval decision", "id", runID, "decision", decision, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("recorded run approval decision", "id", runID, "decision", decision, "subject", subject)
+
+	return approval, nil
+}
+
+func (s *service) ListApprovals(ctx context.Context, runID string) ([]*Approval, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.db.ListApprovals(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing run approvals", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run approvals", "id", runID, "subject", subject)
+
+	return approvals, nil
+}
+
+// getApprovalByID implements pubsub.Getter, relaying approval decisions.
+func (s *service) getApprovalByID(ctx context.Context, approvalID string, action pubsub.DBAction) (any, error) {
+	if action == pubsub.DeleteDBAction {
+		return &Approval{ID: approvalID}, nil
+	}
+	return s.db.GetApproval(ctx, approvalID)
+}
@@ -0,0 +1,83 @@
+package run
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// approvalresult is the result of a database query for run approvals
+type approvalresult struct {
+	RunApprovalID pgtype.Text        `json:"run_approval_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	Username      pgtype.Text        `json:"username"`
+	Decision      pgtype.Text        `json:"decision"`
+	Comment       pgtype.Text        `json:"comment"`
+	RunID         pgtype.Text        `json:"run_id"`
+}
+
+func (r approvalresult) toApproval() *Approval {
+	return &Approval{
+		ID:        r.RunApprovalID.String,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+		RunID:     r.RunID.String,
+		Username:  r.Username.String,
+		Decision:  ApprovalDecision(r.Decision.String),
+		Comment:   r.Comment.String,
+	}
+}
+
+// CreateApproval locks the run and invokes fn, persisting both the returned
+// approval and any resulting changes to the run. If the approval would
+// enqueue the run then it is subject to the organization's concurrency limits.
+func (db *pgdb) CreateApproval(ctx context.Context, runID string, fn func(*Run) (*Approval, error)) (*Approval, error) {
+	var approval *Approval
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := db.UpdateStatusWithinLimit(ctx, runID, func(run *Run) (err error) {
+			approval, err = fn(run)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.InsertRunApproval(ctx, pggen.InsertRunApprovalParams{
+			RunApprovalID: sql.String(approval.ID),
+			CreatedAt:     sql.Timestamptz(approval.CreatedAt),
+			Username:      sql.String(approval.Username),
+			Decision:      sql.String(string(approval.Decision)),
+			Comment:       sql.String(approval.Comment),
+			RunID:         sql.String(approval.RunID),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+	return approval, err
+}
+
+func (db *pgdb) GetApproval(ctx context.Context, approvalID string) (*Approval, error) {
+	result, err := db.Conn(ctx).FindRunApprovalByID(ctx, sql.String(approvalID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return approvalresult(result).toApproval(), nil
+}
+
+func (db *pgdb) ListApprovals(ctx context.Context, runID string) ([]*Approval, error) {
+	// ensure run exists, returning not found if not
+	if _, err := db.Conn(ctx).FindRunByID(ctx, sql.String(runID)); err != nil {
+		return nil, sql.Error(err)
+	}
+	rows, err := db.Conn(ctx).FindRunApprovalsByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	approvals := make([]*Approval, len(rows))
+	for i, r := range rows {
+		approvals[i] = approvalresult(r).toApproval()
+	}
+	return approvals, nil
+}
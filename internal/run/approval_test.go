@@ -0,0 +1,152 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Approvals(t *testing.T) {
+	newPlannedRun := func(required int, autoApply bool) *Run {
+		return &Run{
+			Status:            internal.RunPlanned,
+			Organization:      "acme-corp",
+			CreatedBy:         internal.String("author"),
+			ApprovalsRequired: required,
+			AutoApply:         autoApply,
+		}
+	}
+	approve := func(t *testing.T, run *Run, username string, decision ApprovalDecision) {
+		policy := &workspace.ApprovalPolicy{Required: run.ApprovalsRequired}
+		approval, err := newApproval(run, policy, &auth.User{Username: username}, decision, "")
+		require.NoError(t, err)
+		require.NoError(t, run.addApproval(approval))
+	}
+
+	t.Run("no approvals required", func(t *testing.T) {
+		run := newPlannedRun(0, false)
+
+		assert.False(t, run.AwaitingApproval())
+		assert.True(t, run.Confirmable())
+		assert.NoError(t, run.EnqueueApply())
+	})
+
+	t.Run("apply blocked until approved", func(t *testing.T) {
+		run := newPlannedRun(2, false)
+		assert.True(t, run.AwaitingApproval())
+		assert.False(t, run.Confirmable())
+		assert.Equal(t, internal.ErrRunApprovalRequired, run.EnqueueApply())
+
+		approve(t, run, "alice", ApprovedDecision)
+		assert.Equal(t, 1, run.ApprovalCount())
+		assert.Equal(t, internal.ErrRunApprovalRequired, run.EnqueueApply())
+
+		approve(t, run, "bob", ApprovedDecision)
+		assert.True(t, run.Approved())
+		assert.True(t, run.Confirmable())
+		// not auto-apply so run remains in planned state
+		assert.Equal(t, internal.RunPlanned, run.Status)
+		assert.NoError(t, run.EnqueueApply())
+		assert.Equal(t, internal.RunApplyQueued, run.Status)
+	})
+
+	t.Run("auto-apply once approved", func(t *testing.T) {
+		run := newPlannedRun(1, true)
+
+		approve(t, run, "alice", ApprovedDecision)
+		assert.Equal(t, internal.RunApplyQueued, run.Status)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		run := newPlannedRun(1, true)
+
+		approve(t, run, "alice", RejectedDecision)
+		assert.True(t, run.Rejected())
+		assert.Equal(t, internal.ErrRunRejected, run.EnqueueApply())
+
+		// a subsequent approval neither applies nor permits applying the run
+		approve(t, run, "bob", ApprovedDecision)
+		assert.Equal(t, internal.RunPlanned, run.Status)
+		assert.False(t, run.Confirmable())
+		assert.Equal(t, internal.ErrRunRejected, run.EnqueueApply())
+	})
+
+	t.Run("not awaiting approval", func(t *testing.T) {
+		run := newPlannedRun(1, false)
+		run.Status = internal.RunApplied
+
+		_, err := newApproval(run, &workspace.ApprovalPolicy{}, &auth.User{Username: "alice"}, ApprovedDecision, "")
+		assert.Equal(t, internal.ErrRunApprovalNotAllowed, err)
+	})
+}
+
+func TestCheckApprover(t *testing.T) {
+	run := &Run{
+		Organization: "acme-corp",
+		CreatedBy:    internal.String("author"),
+		Approvals: []*Approval{
+			{Username: "decided", Decision: ApprovedDecision},
+		},
+	}
+	devops := &auth.Team{Name: "devops", Organization: "acme-corp"}
+	devopsElsewhere := &auth.Team{Name: "devops", Organization: "other-corp"}
+
+	tests := []struct {
+		name   string
+		policy *workspace.ApprovalPolicy
+		user   *auth.User
+		want   error
+	}{
+		{
+			"any user",
+			&workspace.ApprovalPolicy{},
+			&auth.User{Username: "alice"},
+			nil,
+		},
+		{
+			"author permitted",
+			&workspace.ApprovalPolicy{},
+			&auth.User{Username: "author"},
+			nil,
+		},
+		{
+			"author excluded",
+			&workspace.ApprovalPolicy{ExcludeAuthor: true},
+			&auth.User{Username: "author"},
+			internal.ErrRunSelfApproval,
+		},
+		{
+			"member of approving team",
+			&workspace.ApprovalPolicy{Teams: []string{"devops"}},
+			&auth.User{Username: "alice", Teams: []*auth.Team{devops}},
+			nil,
+		},
+		{
+			"not member of approving team",
+			&workspace.ApprovalPolicy{Teams: []string{"devops"}},
+			&auth.User{Username: "alice"},
+			internal.ErrAccessNotPermitted,
+		},
+		{
+			"member of team in different organization",
+			&workspace.ApprovalPolicy{Teams: []string{"devops"}},
+			&auth.User{Username: "alice", Teams: []*auth.Team{devopsElsewhere}},
+			internal.ErrAccessNotPermitted,
+		},
+		{
+			"already decided",
+			&workspace.ApprovalPolicy{},
+			&auth.User{Username: "decided"},
+			internal.ErrResourceAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checkApprover(run, tt.policy, tt.user))
+		})
+	}
+}
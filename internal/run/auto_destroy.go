@@ -0,0 +1,160 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// AutoDestroyerLockID is a unique ID guaranteeing only one auto-destroyer on
+// a cluster is running at any time.
+const AutoDestroyerLockID int64 = 179366396344335602
+
+// DefaultAutoDestroyInterval is the default interval between checks for
+// workspaces due to be destroyed.
+const DefaultAutoDestroyInterval = time.Minute
+
+type (
+	// AutoDestroyer queues destroy runs on workspaces that have reached their
+	// auto-destroy deadline, either because the time scheduled for their
+	// destruction has arrived or because they have been inactive for too long.
+	AutoDestroyer struct {
+		logr.Logger
+		RunService
+		WorkspaceService
+
+		// Interval between checks for workspaces due to be destroyed.
+		Interval time.Duration
+	}
+)
+
+// Start starts the auto-destroyer daemon. Should be invoked in a go routine.
+func (d *AutoDestroyer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.check(ctx, time.Now()); err != nil {
+			d.Error(err, "checking for workspaces to auto-destroy")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// check queues a destroy run on each workspace whose auto-destroy deadline
+// has passed as of now.
+func (d *AutoDestroyer) check(ctx context.Context, now time.Time) error {
+	workspaces, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*workspace.Workspace], error) {
+		return d.ListWorkspaces(ctx, workspace.ListOptions{PageOptions: opts})
+	})
+	if err != nil {
+		return err
+	}
+	for _, ws := range workspaces {
+		if ws.AutoDestroyAt == nil && ws.AutoDestroyActivityDuration == 0 {
+			continue
+		}
+		if err := d.destroy(ctx, ws, now); err != nil {
+			// carry on with other workspaces
+			d.Error(err, "auto-destroying workspace", "workspace", ws.ID)
+		}
+	}
+	return nil
+}
+
+func (d *AutoDestroyer) destroy(ctx context.Context, ws *workspace.Workspace, now time.Time) error {
+	deadline, latest, err := autoDestroyDeadline(ctx, d, ws)
+	if err != nil {
+		return err
+	}
+	if deadline == nil || now.Before(*deadline) {
+		return nil
+	}
+	if !ws.AllowDestroyPlan {
+		d.V(3).Info("skipping auto-destroy: workspace does not permit destroy plans", "workspace", ws.ID)
+		return nil
+	}
+	// Nothing has happened on the workspace since its last destroy (or there
+	// has never been a run), so there is nothing to destroy.
+	if latest == nil || latest.IsDestroy {
+		return d.clearAutoDestroyAt(ctx, ws)
+	}
+	msg := fmt.Sprintf("Scheduled destroy at %s", deadline.Format(time.RFC3339))
+	if ws.AutoDestroyAt == nil || !deadline.Equal(*ws.AutoDestroyAt) {
+		msg = fmt.Sprintf("Destroy after %s of inactivity", ws.AutoDestroyActivityDuration)
+	}
+	run, err := d.CreateRun(ctx, ws.ID, CreateOptions{
+		IsDestroy: internal.Bool(true),
+		AutoApply: internal.Bool(ws.AutoApplyDestroy),
+		Message:   &msg,
+	})
+	if err != nil {
+		return err
+	}
+	d.Info("queued auto-destroy run", "workspace", ws.ID, "run", run.ID, "deadline", deadline)
+	return d.clearAutoDestroyAt(ctx, ws)
+}
+
+// clearAutoDestroyAt cancels the scheduled destroy once it is no longer
+// needed.
+func (d *AutoDestroyer) clearAutoDestroyAt(ctx context.Context, ws *workspace.Workspace) error {
+	if ws.AutoDestroyAt == nil {
+		return nil
+	}
+	_, err := d.UpdateWorkspace(ctx, ws.ID, workspace.UpdateOptions{
+		AutoDestroyAt: &time.Time{},
+	})
+	return err
+}
+
+// AutoDestroyDeadline returns the time at which the workspace is due to be
+// destroyed, or nil if no destroy is scheduled or the auto-destroyer would not
+// destroy the workspace, i.e. it does not permit destroy plans or there is
+// nothing to destroy.
+func AutoDestroyDeadline(ctx context.Context, svc RunService, ws *workspace.Workspace) (*time.Time, error) {
+	deadline, latest, err := autoDestroyDeadline(ctx, svc, ws)
+	if err != nil {
+		return nil, err
+	}
+	if !ws.AllowDestroyPlan || latest == nil || latest.IsDestroy {
+		return nil, nil
+	}
+	return deadline, nil
+}
+
+// autoDestroyDeadline returns the workspace's auto-destroy deadline along
+// with its latest run, which is nil if the workspace has no runs. The workspace
+// was last active either when it was last updated or when its latest run last
+// changed status, whichever is the more recent.
+func autoDestroyDeadline(ctx context.Context, svc RunService, ws *workspace.Workspace) (*time.Time, *Run, error) {
+	lastActivity := ws.UpdatedAt
+	var latest *Run
+	if ws.LatestRun != nil {
+		var err error
+		latest, err = svc.GetRun(ctx, ws.LatestRun.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		updated, err := latest.StatusTimestamp(latest.Status)
+		if err != nil {
+			return nil, nil, err
+		}
+		if updated.After(lastActivity) {
+			lastActivity = updated
+		}
+	}
+	deadline, ok := ws.AutoDestroyDeadline(lastActivity)
+	if !ok {
+		return nil, latest, nil
+	}
+	return &deadline, latest, nil
+}
@@ -0,0 +1,183 @@
+package run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoDestroyer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	applied := &Run{
+		ID:     "run-123",
+		Status: internal.RunApplied,
+		StatusTimestamps: []StatusTimestamp{
+			{Status: internal.RunApplied, Timestamp: now.Add(-3 * time.Hour)},
+		},
+	}
+	destroyed := &Run{
+		ID:        "run-123",
+		Status:    internal.RunApplied,
+		IsDestroy: true,
+		StatusTimestamps: []StatusTimestamp{
+			{Status: internal.RunApplied, Timestamp: now.Add(-3 * time.Hour)},
+		},
+	}
+	newWorkspace := func(fn func(ws *workspace.Workspace)) *workspace.Workspace {
+		ws := &workspace.Workspace{
+			ID:               "ws-123",
+			UpdatedAt:        now.Add(-24 * time.Hour),
+			AllowDestroyPlan: true,
+			LatestRun:        &workspace.LatestRun{ID: "run-123"},
+		}
+		fn(ws)
+		return ws
+	}
+
+	tests := []struct {
+		name      string
+		ws        *workspace.Workspace
+		latest    *Run
+		wantRun   bool
+		wantClear bool
+	}{
+		{
+			name: "scheduled time not yet reached",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyAt = internal.Time(now.Add(time.Minute))
+			}),
+			latest: applied,
+		},
+		{
+			name: "scheduled time reached",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyAt = internal.Time(now.Add(-time.Minute))
+			}),
+			latest:    applied,
+			wantRun:   true,
+			wantClear: true,
+		},
+		{
+			name: "active within inactivity period",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyActivityDuration = 4 * time.Hour
+			}),
+			latest: applied,
+		},
+		{
+			name: "inactive for longer than inactivity period",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyActivityDuration = 2 * time.Hour
+			}),
+			latest:  applied,
+			wantRun: true,
+		},
+		{
+			name: "destroy plans not permitted",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyAt = internal.Time(now.Add(-time.Minute))
+				ws.AllowDestroyPlan = false
+			}),
+			latest: applied,
+		},
+		{
+			name: "already destroyed",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyAt = internal.Time(now.Add(-time.Minute))
+			}),
+			latest:    destroyed,
+			wantClear: true,
+		},
+		{
+			name: "no runs",
+			ws: newWorkspace(func(ws *workspace.Workspace) {
+				ws.AutoDestroyActivityDuration = time.Hour
+				ws.LatestRun = nil
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeAutoDestroyerServices{ws: tt.ws, latest: tt.latest}
+			d := &AutoDestroyer{
+				Logger:           logr.Discard(),
+				RunService:       svc,
+				WorkspaceService: svc,
+			}
+			err := d.check(ctx, now)
+			require.NoError(t, err)
+
+			if tt.wantRun {
+				require.NotNil(t, svc.created)
+				assert.True(t, *svc.created.IsDestroy)
+			} else {
+				assert.Nil(t, svc.created)
+			}
+			assert.Equal(t, tt.wantClear, svc.cleared)
+		})
+	}
+}
+
+func TestAutoDestroyer_AutoApply(t *testing.T) {
+	svc := &fakeAutoDestroyerServices{
+		ws: &workspace.Workspace{
+			ID:                          "ws-123",
+			AllowDestroyPlan:            true,
+			AutoApplyDestroy:            true,
+			AutoDestroyActivityDuration: time.Hour,
+			LatestRun:                   &workspace.LatestRun{ID: "run-123"},
+		},
+		latest: &Run{
+			ID:               "run-123",
+			Status:           internal.RunPending,
+			StatusTimestamps: []StatusTimestamp{{Status: internal.RunPending}},
+		},
+	}
+	d := &AutoDestroyer{Logger: logr.Discard(), RunService: svc, WorkspaceService: svc}
+	err := d.check(context.Background(), time.Now())
+	require.NoError(t, err)
+
+	require.NotNil(t, svc.created)
+	assert.True(t, *svc.created.AutoApply)
+	assert.Equal(t, "Destroy after 1h0m0s of inactivity", *svc.created.Message)
+}
+
+type fakeAutoDestroyerServices struct {
+	ws     *workspace.Workspace
+	latest *Run
+	// options for created run
+	created *CreateOptions
+	// whether scheduled destroy was cancelled
+	cleared bool
+
+	RunService
+	WorkspaceService
+}
+
+func (f *fakeAutoDestroyerServices) ListWorkspaces(context.Context, workspace.ListOptions) (*resource.Page[*workspace.Workspace], error) {
+	return resource.NewPage([]*workspace.Workspace{f.ws}, resource.PageOptions{}, nil), nil
+}
+
+func (f *fakeAutoDestroyerServices) UpdateWorkspace(ctx context.Context, workspaceID string, opts workspace.UpdateOptions) (*workspace.Workspace, error) {
+	if opts.AutoDestroyAt != nil && opts.AutoDestroyAt.IsZero() {
+		f.cleared = true
+	}
+	return f.ws, nil
+}
+
+func (f *fakeAutoDestroyerServices) GetRun(context.Context, string) (*Run, error) {
+	return f.latest, nil
+}
+
+func (f *fakeAutoDestroyerServices) CreateRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
+	f.created = &opts
+	return &Run{ID: "run-456"}, nil
+}
@@ -95,6 +95,50 @@ func (c *Client) UploadLockFile(ctx context.Context, runID string, lockfile []by
 	return nil
 }
 
+func (c *Client) GetStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error) {
+	u := fmt.Sprintf("runs/%s/structured-output", url.QueryEscape(runID))
+	req, err := c.NewRequest("GET", u, &StructuredOutputOptions{Phase: phase})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	err = c.Do(ctx, req, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *Client) UploadStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType, output []byte) error {
+	u := fmt.Sprintf("runs/%s/structured-output", url.QueryEscape(runID))
+	req, err := c.NewRequest("PUT", u, output)
+	if err != nil {
+		return err
+	}
+
+	// NewRequest() only lets us set a query or a payload but not both, so we
+	// set query here.
+	opts := &StructuredOutputOptions{Phase: phase}
+	q := url.Values{}
+	if err := http.Encoder.Encode(opts, q); err != nil {
+		return err
+	}
+	req.URL.RawQuery = q.Encode()
+
+	return c.Do(ctx, req, nil)
+}
+
+func (c *Client) UploadTestResults(ctx context.Context, runID string, output []byte) error {
+	u := fmt.Sprintf("runs/%s/test-results", url.QueryEscape(runID))
+	req, err := c.NewRequest("PUT", u, output)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
+
 func (c *Client) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
 	req, err := c.NewRequest("GET", "runs", &types.RunListOptions{
 		ListOptions:  types.ListOptions(opts.PageOptions),
@@ -0,0 +1,133 @@
+package run
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+type (
+	// Comment is a comment left on a run.
+	Comment struct {
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		RunID     string    `json:"run_id"`
+		// Author is the name of the subject that left the comment, typically
+		// a username.
+		Author string `json:"author"`
+		Body   string `json:"body"`
+	}
+
+	commentService interface {
+		// AddComment adds a comment to a run on behalf of the subject in the
+		// context.
+		AddComment(ctx context.Context, runID, body string) (*Comment, error)
+		GetComment(ctx context.Context, commentID string) (*Comment, error)
+		// ListComments lists a run's comments, oldest first.
+		ListComments(ctx context.Context, runID string) ([]*Comment, error)
+		// WatchComments returns a channel of comments added to a run. The
+		// channel is closed when the context is canceled.
+		WatchComments(ctx context.Context, runID string) (<-chan *Comment, error)
+	}
+)
+
+func newComment(runID, author, body string) (*Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, internal.ErrEmptyRunComment
+	}
+	return &Comment{
+		ID:        internal.NewID("wsc"),
+		CreatedAt: internal.CurrentTimestamp(),
+		RunID:     runID,
+		Author:    author,
+		Body:      body,
+	}, nil
+}
+
+func (s *service) AddComment(ctx context.Context, runID, body string) (*Comment, error) {
+	subject, err := s.CanAccess(ctx, rbac.CommentRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := newComment(runID, subject.String(), body)
+	if err != nil {
+		s.Error(err, "constructing run comment", "id", runID, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.CreateComment(ctx, comment); err != nil {
+		s.Error(err, "adding run comment", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("added run comment", "id", runID, "comment", comment.ID, "subject", subject)
+
+	return comment, nil
+}
+
+func (s *service) GetComment(ctx context.Context, commentID string) (*Comment, error) {
+	comment, err := s.db.GetComment(ctx, commentID)
+	if err != nil {
+		s.Error(err, "retrieving run comment", "id", commentID)
+		return nil, err
+	}
+
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, comment.RunID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved run comment", "id", commentID, "subject", subject)
+
+	return comment, nil
+}
+
+func (s *service) ListComments(ctx context.Context, runID string) ([]*Comment, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.db.ListComments(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing run comments", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run comments", "id", runID, "subject", subject)
+
+	return comments, nil
+}
+
+func (s *service) WatchComments(ctx context.Context, runID string) (<-chan *Comment, error) {
+	if _, err := s.CanAccess(ctx, rbac.WatchAction, runID); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.Subscribe(ctx, "run-comment-watch-")
+	if err != nil {
+		return nil, err
+	}
+
+	relay := make(chan *Comment)
+	go func() {
+		for ev := range sub {
+			comment, ok := ev.Payload.(*Comment)
+			if !ok || comment.RunID != runID {
+				continue
+			}
+			relay <- comment
+		}
+		close(relay)
+	}()
+	return relay, nil
+}
+
+// getCommentByID implements pubsub.Getter, relaying newly added comments.
+func (s *service) getCommentByID(ctx context.Context, commentID string, action pubsub.DBAction) (any, error) {
+	if action == pubsub.DeleteDBAction {
+		return &Comment{ID: commentID}, nil
+	}
+	return s.db.GetComment(ctx, commentID)
+}
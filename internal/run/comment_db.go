@@ -0,0 +1,66 @@
+package run
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// commentresult is the result of a database query for run comments
+type commentresult struct {
+	RunCommentID pgtype.Text        `json:"run_comment_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	Author       pgtype.Text        `json:"author"`
+	Body         pgtype.Text        `json:"body"`
+	RunID        pgtype.Text        `json:"run_id"`
+}
+
+func (r commentresult) toComment() *Comment {
+	return &Comment{
+		ID:        r.RunCommentID.String,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+		RunID:     r.RunID.String,
+		Author:    r.Author.String,
+		Body:      r.Body.String,
+	}
+}
+
+func (db *pgdb) CreateComment(ctx context.Context, comment *Comment) error {
+	_, err := db.Conn(ctx).InsertRunComment(ctx, pggen.InsertRunCommentParams{
+		RunCommentID: sql.String(comment.ID),
+		CreatedAt:    sql.Timestamptz(comment.CreatedAt),
+		Author:       sql.String(comment.Author),
+		Body:         sql.String(comment.Body),
+		RunID:        sql.String(comment.RunID),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) GetComment(ctx context.Context, commentID string) (*Comment, error) {
+	result, err := db.Conn(ctx).FindRunCommentByID(ctx, sql.String(commentID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return commentresult(result).toComment(), nil
+}
+
+func (db *pgdb) ListComments(ctx context.Context, runID string) ([]*Comment, error) {
+	// ensure run exists, returning not found if not
+	if _, err := db.Conn(ctx).FindRunByID(ctx, sql.String(runID)); err != nil {
+		return nil, sql.Error(err)
+	}
+	rows, err := db.Conn(ctx).FindRunCommentsByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	comments := make([]*Comment, len(rows))
+	for i, r := range rows {
+		comments[i] = commentresult(r).toComment()
+	}
+	return comments, nil
+}
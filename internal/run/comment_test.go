@@ -0,0 +1,24 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComment(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		comment, err := newComment("run-123", "bobby", "looks good to me")
+		require.NoError(t, err)
+		assert.Equal(t, "run-123", comment.RunID)
+		assert.Equal(t, "bobby", comment.Author)
+		assert.Equal(t, "looks good to me", comment.Body)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		_, err := newComment("run-123", "bobby", " \n")
+		assert.Equal(t, internal.ErrEmptyRunComment, err)
+	})
+}
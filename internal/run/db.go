@@ -46,6 +46,9 @@ type (
 		CreatedBy              pgtype.Text                   `json:"created_by"`
 		TerraformVersion       pgtype.Text                   `json:"terraform_version"`
 		AllowEmptyApply        bool                          `json:"allow_empty_apply"`
+		QueuedByLimit          bool                          `json:"queued_by_limit"`
+		SavePlan               bool                          `json:"save_plan"`
+		Test                   bool                          `json:"test"`
 		ExecutionMode          pgtype.Text                   `json:"execution_mode"`
 		Latest                 bool                          `json:"latest"`
 		OrganizationName       pgtype.Text                   `json:"organization_name"`
@@ -55,6 +58,9 @@ type (
 		PlanStatusTimestamps   []pggen.PhaseStatusTimestamps `json:"plan_status_timestamps"`
 		ApplyStatusTimestamps  []pggen.PhaseStatusTimestamps `json:"apply_status_timestamps"`
 		RunVariables           []pggen.RunVariables          `json:"run_variables"`
+		TaskStages             []string                      `json:"task_stages"`
+		ApprovalsRequired      pgtype.Int4                   `json:"approvals_required"`
+		RunApprovals           []pggen.RunApprovals          `json:"run_approvals"`
 	}
 )
 
@@ -74,6 +80,9 @@ func (result pgresult) toRun() *Run {
 		AutoApply:              result.AutoApply,
 		PlanOnly:               result.PlanOnly,
 		AllowEmptyApply:        result.AllowEmptyApply,
+		QueuedByLimit:          result.QueuedByLimit,
+		SavePlan:               result.SavePlan,
+		Test:                   result.Test,
 		TerraformVersion:       result.TerraformVersion.String,
 		ExecutionMode:          workspace.ExecutionMode(result.ExecutionMode.String),
 		Latest:                 result.Latest,
@@ -103,6 +112,13 @@ func (result pgresult) toRun() *Run {
 			run.Variables[i] = Variable{Key: v.Key.String, Value: v.Value.String}
 		}
 	}
+	for _, stage := range result.TaskStages {
+		run.TaskStages = append(run.TaskStages, TaskStage(stage))
+	}
+	run.ApprovalsRequired = int(result.ApprovalsRequired.Int)
+	for _, approval := range result.RunApprovals {
+		run.Approvals = append(run.Approvals, approvalresult(approval).toApproval())
+	}
 	if result.CreatedBy.Status == pgtype.Present {
 		run.CreatedBy = &result.CreatedBy.String
 	}
@@ -132,6 +148,8 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 			AutoApply:              run.AutoApply,
 			PlanOnly:               run.PlanOnly,
 			AllowEmptyApply:        run.AllowEmptyApply,
+			SavePlan:               run.SavePlan,
+			Test:                   run.Test,
 			TerraformVersion:       sql.String(run.TerraformVersion),
 			ConfigurationVersionID: sql.String(run.ConfigurationVersionID),
 			WorkspaceID:            sql.String(run.WorkspaceID),
@@ -187,6 +205,7 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 		planStatus := run.Plan.Status
 		applyStatus := run.Apply.Status
 		forceCancelAvailableAt := run.ForceCancelAvailableAt
+		queuedByLimit := run.QueuedByLimit
 
 		if err := fn(run); err != nil {
 			return err
@@ -232,11 +251,60 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 			}
 		}
 
+		if run.QueuedByLimit != queuedByLimit {
+			_, err := q.UpdateRunQueuedByLimit(ctx, run.QueuedByLimit, sql.String(run.ID))
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 	return run, err
 }
 
+// UpdateStatusWithinLimit is like UpdateStatus except fn is only permitted to
+// enqueue the run if the run's organization has not reached its limit of
+// concurrent runs. Otherwise the run is left in its current status and
+// instead marked as queued by limit.
+func (db *pgdb) UpdateStatusWithinLimit(ctx context.Context, runID string, fn func(*Run) error) (*Run, error) {
+	var run *Run
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.FindRunByID(ctx, sql.String(runID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		existing := pgresult(result).toRun()
+
+		// lock the organization to serialize the enqueuing of its runs,
+		// otherwise concurrent callers could both exceed the limit.
+		org, err := q.FindOrganizationByNameForUpdate(ctx, sql.String(existing.Organization))
+		if err != nil {
+			return sql.Error(err)
+		}
+		limit := org.MaxConcurrentRuns.Int
+		if existing.PlanOnly {
+			limit = org.MaxConcurrentSpeculativeRuns.Int
+		}
+		var limited bool
+		if limit > 0 {
+			count, err := q.CountInFlightRuns(ctx, pggen.CountInFlightRunsParams{
+				OrganizationName: sql.String(existing.Organization),
+				Statuses:         internal.ToStringSlice(inFlightStatuses),
+				PlanOnly:         existing.PlanOnly,
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+			limited = count.Int >= int64(limit)
+		}
+
+		run, err = db.UpdateStatus(ctx, runID, withinLimit(limited, fn))
+		return err
+	})
+	return run, err
+}
+
 func (db *pgdb) CreatePlanReport(ctx context.Context, runID string, resource, output Report) error {
 	_, err := db.Conn(ctx).UpdatePlannedChangesByID(ctx, pggen.UpdatePlannedChangesByIDParams{
 		RunID:                sql.String(runID),
@@ -384,6 +452,49 @@ func (db *pgdb) SetLockFile(ctx context.Context, runID string, lockFile []byte)
 	return err
 }
 
+// GetStructuredOutput retrieves the machine-readable output for a run phase
+func (db *pgdb) GetStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error) {
+	q := db.Conn(ctx)
+	switch phase {
+	case internal.PlanPhase:
+		return q.GetPlanStructuredOutputByID(ctx, sql.String(runID))
+	case internal.ApplyPhase:
+		return q.GetApplyStructuredOutputByID(ctx, sql.String(runID))
+	default:
+		return nil, fmt.Errorf("unknown phase: %s", string(phase))
+	}
+}
+
+// SetStructuredOutput writes the machine-readable output for a run phase
+func (db *pgdb) SetStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType, output []byte) error {
+	q := db.Conn(ctx)
+	switch phase {
+	case internal.PlanPhase:
+		_, err := q.UpdatePlanStructuredOutputByID(ctx, output, sql.String(runID))
+		return sql.Error(err)
+	case internal.ApplyPhase:
+		_, err := q.UpdateApplyStructuredOutputByID(ctx, output, sql.String(runID))
+		return sql.Error(err)
+	default:
+		return fmt.Errorf("unknown phase: %s", string(phase))
+	}
+}
+
+// GetTestResults retrieves the results of a test run
+func (db *pgdb) GetTestResults(ctx context.Context, runID string) ([]byte, error) {
+	results, err := db.Conn(ctx).GetPlanTestResultsByID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return results, nil
+}
+
+// SetTestResults writes the results of a test run
+func (db *pgdb) SetTestResults(ctx context.Context, runID string, results []byte) error {
+	_, err := db.Conn(ctx).UpdatePlanTestResultsByID(ctx, results, sql.String(runID))
+	return sql.Error(err)
+}
+
 // DeleteRun deletes a run from the DB
 func (db *pgdb) DeleteRun(ctx context.Context, id string) error {
 	_, err := db.Conn(ctx).DeleteRunByID(ctx, sql.String(id))
@@ -395,11 +506,16 @@ func (db *pgdb) insertRunStatusTimestamp(ctx context.Context, run *Run) error {
 	if err != nil {
 		return err
 	}
-	_, err = db.Conn(ctx).InsertRunStatusTimestamp(ctx, pggen.InsertRunStatusTimestampParams{
+	params := pggen.InsertRunStatusTimestampParams{
 		ID:        sql.String(run.ID),
 		Status:    sql.String(string(run.Status)),
 		Timestamp: sql.Timestamptz(ts),
-	})
+		Reason:    sql.NullString(),
+	}
+	if reason := run.StatusReason(); reason != "" {
+		params.Reason = sql.String(reason)
+	}
+	_, err = db.Conn(ctx).InsertRunStatusTimestamp(ctx, params)
 	return err
 }
 
@@ -422,6 +538,7 @@ func unmarshalRunStatusTimestampRows(rows []pggen.RunStatusTimestamps) (timestam
 		timestamps = append(timestamps, StatusTimestamp{
 			Status:    internal.RunStatus(ty.Status.String),
 			Timestamp: ty.Timestamp.Time.UTC(),
+			Reason:    ty.Reason.String,
 		})
 	}
 	return timestamps
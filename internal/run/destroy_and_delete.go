@@ -0,0 +1,219 @@
+package run
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// WorkspaceDeleterLockID is a unique ID guaranteeing only one workspace
+// deleter on a cluster is running at any time.
+const WorkspaceDeleterLockID int64 = 179366396344335605
+
+type (
+	destroyAndDeleteService interface {
+		// DestroyAndDeleteWorkspace queues a destroy run for the workspace and
+		// schedules the workspace to be deleted once the run has been
+		// successfully applied.
+		DestroyAndDeleteWorkspace(ctx context.Context, workspaceID string) (*Run, error)
+	}
+
+	// WorkspaceDeleter deletes workspaces scheduled for deletion once their
+	// destroy run has been applied.
+	WorkspaceDeleter struct {
+		logr.Logger
+		pubsub.Subscriber
+		RunService
+		WorkspaceService
+
+		deletions workspaceDeletionStore
+	}
+
+	// workspaceDeletionStore persists scheduled workspace deletions, each
+	// keyed by the destroy run that must first be applied.
+	workspaceDeletionStore interface {
+		isWorkspaceDeletionScheduled(ctx context.Context, runID string) (bool, error)
+		listScheduledWorkspaceDeletions(ctx context.Context) ([]string, error)
+		unscheduleWorkspaceDeletion(ctx context.Context, runID string) error
+	}
+
+	WorkspaceDeleterOptions struct {
+		logr.Logger
+		pubsub.Subscriber
+		RunService
+		WorkspaceService
+		*sql.DB
+	}
+)
+
+func (s *service) DestroyAndDeleteWorkspace(ctx context.Context, workspaceID string) (*Run, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.DeleteWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the run and schedule the deletion atomically, ensuring neither
+	// a destroy run without a scheduled deletion, nor a scheduled deletion
+	// without a destroy run.
+	msg := "Destroy resources before deleting workspace"
+	var run *Run
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		run, err = s.CreateRun(ctx, workspaceID, CreateOptions{
+			IsDestroy: internal.Bool(true),
+			Message:   &msg,
+		})
+		if err != nil {
+			return err
+		}
+		return s.db.scheduleWorkspaceDeletion(ctx, workspaceID, run.ID)
+	})
+	if err != nil {
+		s.Error(err, "scheduling workspace deletion", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("scheduled workspace deletion", "workspace", workspaceID, "run", run.ID, "subject", subject)
+	return run, nil
+}
+
+func NewWorkspaceDeleter(opts WorkspaceDeleterOptions) *WorkspaceDeleter {
+	return &WorkspaceDeleter{
+		Logger:           opts.Logger,
+		Subscriber:       opts.Subscriber,
+		RunService:       opts.RunService,
+		WorkspaceService: opts.WorkspaceService,
+		deletions:        &pgdb{opts.DB},
+	}
+}
+
+// Start starts the workspace deleter daemon. Should be invoked in a go
+// routine.
+func (d *WorkspaceDeleter) Start(ctx context.Context) error {
+	// Unsubscribe whenever exiting this routine.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe to run events
+	sub, err := d.Subscribe(ctx, "workspace-deleter-")
+	if err != nil {
+		return err
+	}
+
+	// Handle destroy runs that finished whilst the deleter was not running.
+	// This is performed after subscribing to ensure no run is missed.
+	if err := d.reconcile(ctx); err != nil {
+		return err
+	}
+
+	for event := range sub {
+		run, ok := event.Payload.(*Run)
+		if !ok {
+			// Skip non-run events
+			continue
+		}
+		if event.Type == pubsub.DeletedEvent {
+			// Skip deleted run events
+			continue
+		}
+		if err := d.handleRun(ctx, run); err != nil {
+			d.Error(err, "deleting workspace after destroy", "run", run.ID)
+		}
+	}
+	return nil
+}
+
+// reconcile handles the destroy runs of all scheduled workspace deletions.
+func (d *WorkspaceDeleter) reconcile(ctx context.Context) error {
+	runIDs, err := d.deletions.listScheduledWorkspaceDeletions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, runID := range runIDs {
+		run, err := d.GetRun(ctx, runID)
+		if err != nil {
+			d.Error(err, "retrieving destroy run for scheduled workspace deletion", "run", runID)
+			continue
+		}
+		if err := d.handleRun(ctx, run); err != nil {
+			d.Error(err, "deleting workspace after destroy", "run", run.ID)
+		}
+	}
+	return nil
+}
+
+func (d *WorkspaceDeleter) handleRun(ctx context.Context, run *Run) error {
+	if !run.IsDestroy || !run.Done() {
+		return nil
+	}
+	scheduled, err := d.deletions.isWorkspaceDeletionScheduled(ctx, run.ID)
+	if err != nil {
+		return err
+	}
+	if !scheduled {
+		return nil
+	}
+	switch run.Status {
+	case internal.RunApplied, internal.RunPlannedAndFinished:
+		// resources destroyed (or there were none to destroy)
+	default:
+		// the destroy did not complete, so the workspace is retained
+		d.Info("abandoning workspace deletion", "workspace", run.WorkspaceID, "run", run.ID, "status", run.Status)
+		return d.deletions.unscheduleWorkspaceDeletion(ctx, run.ID)
+	}
+	ws, err := d.SafeDeleteWorkspace(ctx, run.WorkspaceID)
+	if errors.Is(err, workspace.ErrWorkspaceHasResources) {
+		// the destroy left resources behind; retain the workspace rather
+		// than orphan them.
+		d.Info("abandoning workspace deletion: state still contains resources", "workspace", run.WorkspaceID, "run", run.ID)
+		return d.deletions.unscheduleWorkspaceDeletion(ctx, run.ID)
+	} else if err != nil {
+		return err
+	}
+	d.Info("deleted workspace after destroy", "workspace", ws.ID, "name", ws.Name, "run", run.ID)
+	return nil
+}
+
+func (db *pgdb) scheduleWorkspaceDeletion(ctx context.Context, workspaceID, runID string) error {
+	_, err := db.Conn(ctx).InsertWorkspaceDeletion(ctx, pggen.InsertWorkspaceDeletionParams{
+		WorkspaceID: sql.String(workspaceID),
+		RunID:       sql.String(runID),
+		CreatedAt:   sql.Timestamptz(internal.CurrentTimestamp()),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) isWorkspaceDeletionScheduled(ctx context.Context, runID string) (bool, error) {
+	_, err := db.Conn(ctx).FindWorkspaceDeletionByRunID(ctx, sql.String(runID))
+	if err != nil {
+		if errors.Is(sql.Error(err), internal.ErrResourceNotFound) {
+			return false, nil
+		}
+		return false, sql.Error(err)
+	}
+	return true, nil
+}
+
+// listScheduledWorkspaceDeletions lists the IDs of the destroy runs of all
+// scheduled workspace deletions.
+func (db *pgdb) listScheduledWorkspaceDeletions(ctx context.Context) ([]string, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceDeletions(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	runIDs := make([]string, len(rows))
+	for i, r := range rows {
+		runIDs[i] = r.RunID.String
+	}
+	return runIDs, nil
+}
+
+func (db *pgdb) unscheduleWorkspaceDeletion(ctx context.Context, runID string) error {
+	_, err := db.Conn(ctx).DeleteWorkspaceDeletionByRunID(ctx, sql.String(runID))
+	return sql.Error(err)
+}
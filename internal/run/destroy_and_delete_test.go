@@ -0,0 +1,149 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceDeleter(t *testing.T) {
+	tests := []struct {
+		name          string
+		run           *Run
+		scheduled     bool
+		hasResources  bool
+		wantDeleted   bool
+		wantScheduled bool
+	}{
+		{
+			name:        "delete workspace once destroyed",
+			run:         &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplied},
+			scheduled:   true,
+			wantDeleted: true,
+		},
+		{
+			name:        "delete workspace with nothing to destroy",
+			run:         &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunPlannedAndFinished},
+			scheduled:   true,
+			wantDeleted: true,
+		},
+		{
+			name:      "retain workspace when destroy errored",
+			run:       &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunErrored},
+			scheduled: true,
+		},
+		{
+			name:      "retain workspace when destroy discarded",
+			run:       &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunDiscarded},
+			scheduled: true,
+		},
+		{
+			name:         "retain workspace when resources remain",
+			run:          &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplied},
+			scheduled:    true,
+			hasResources: true,
+		},
+		{
+			name:          "ignore destroy that is still applying",
+			run:           &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplying},
+			scheduled:     true,
+			wantScheduled: true,
+		},
+		{
+			name: "ignore destroy not scheduled to delete workspace",
+			run:  &Run{ID: "run-123", WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplied},
+		},
+		{
+			name: "ignore non-destroy run",
+			run:  &Run{ID: "run-123", WorkspaceID: "ws-123", Status: internal.RunApplied},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeWorkspaceDeleterServices{scheduled: tt.scheduled, hasResources: tt.hasResources}
+			deleter := &WorkspaceDeleter{
+				Logger:           logr.Discard(),
+				WorkspaceService: fake,
+				deletions:        fake,
+			}
+			err := deleter.handleRun(context.Background(), tt.run)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantDeleted, fake.deleted)
+			assert.Equal(t, tt.wantScheduled, fake.scheduled)
+		})
+	}
+}
+
+func TestWorkspaceDeleter_Reconcile(t *testing.T) {
+	// destroy runs that finished whilst the deleter was not running
+	applied := &Run{ID: "run-applied", WorkspaceID: "ws-applied", IsDestroy: true, Status: internal.RunApplied}
+	applying := &Run{ID: "run-applying", WorkspaceID: "ws-applying", IsDestroy: true, Status: internal.RunApplying}
+
+	fake := &fakeWorkspaceDeleterServices{scheduled: true, scheduledRuns: []string{applied.ID, applying.ID}}
+	deleter := &WorkspaceDeleter{
+		Logger:           logr.Discard(),
+		RunService:       &fakeWorkspaceDeleterRunService{runs: []*Run{applied, applying}},
+		WorkspaceService: fake,
+		deletions:        fake,
+	}
+	err := deleter.reconcile(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ws-applied"}, fake.deletedWorkspaces)
+}
+
+type fakeWorkspaceDeleterServices struct {
+	scheduled     bool
+	scheduledRuns []string
+	hasResources  bool
+	deleted       bool
+
+	deletedWorkspaces []string
+
+	WorkspaceService
+}
+
+type fakeWorkspaceDeleterRunService struct {
+	runs []*Run
+
+	RunService
+}
+
+func (f *fakeWorkspaceDeleterRunService) GetRun(ctx context.Context, runID string) (*Run, error) {
+	for _, run := range f.runs {
+		if run.ID == runID {
+			return run, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeWorkspaceDeleterServices) SafeDeleteWorkspace(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	if f.hasResources {
+		return nil, workspace.ErrWorkspaceHasResources
+	}
+	f.deleted = true
+	f.deletedWorkspaces = append(f.deletedWorkspaces, workspaceID)
+	// deleting the workspace cascades to its scheduled deletion
+	f.scheduled = false
+	return &workspace.Workspace{ID: workspaceID}, nil
+}
+
+func (f *fakeWorkspaceDeleterServices) isWorkspaceDeletionScheduled(context.Context, string) (bool, error) {
+	return f.scheduled, nil
+}
+
+func (f *fakeWorkspaceDeleterServices) listScheduledWorkspaceDeletions(context.Context) ([]string, error) {
+	return f.scheduledRuns, nil
+}
+
+func (f *fakeWorkspaceDeleterServices) unscheduleWorkspaceDeletion(context.Context, string) error {
+	f.scheduled = false
+	return nil
+}
@@ -1,6 +1,8 @@
 package run
 
 import (
+	"time"
+
 	"github.com/DataDog/jsonapi"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/api/types"
@@ -35,6 +37,7 @@ func newFromJSONAPI(from *types.Run) *Run {
 		TargetAddrs:            from.TargetAddrs,
 		WorkspaceID:            from.Workspace.ID,
 		ConfigurationVersionID: from.ConfigurationVersion.ID,
+		PhaseTimeout:           time.Duration(from.PhaseTimeout) * time.Second,
 		// TODO: unmarshal plan and apply relations
 	}
 }
@@ -0,0 +1,52 @@
+package run
+
+import (
+	"github.com/leg100/otf/internal"
+	"golang.org/x/exp/slices"
+)
+
+// inFlightStatuses are the statuses in which a run counts towards its
+// organization's limit of concurrent runs: from the moment it is enqueued
+// until it either finishes or pauses awaiting confirmation.
+var inFlightStatuses = []internal.RunStatus{
+	internal.RunPrePlanRunning,
+	internal.RunPrePlanCompleted,
+	internal.RunPlanQueued,
+	internal.RunPlanning,
+	internal.RunPostPlanRunning,
+	internal.RunPostPlanCompleted,
+	internal.RunPreApplyRunning,
+	internal.RunPreApplyCompleted,
+	internal.RunApplyQueued,
+	internal.RunApplying,
+}
+
+// InFlight determines whether the run counts towards its organization's limit
+// of concurrent runs.
+func (r *Run) InFlight() bool {
+	return slices.Contains(inFlightStatuses, r.Status)
+}
+
+// withinLimit wraps fn, which may enqueue a run, such that if limited is true
+// the run is held back and marked as queued by limit rather than enqueued.
+// Holding back a run is subject to the same checks as enqueuing it, i.e. if fn
+// would return an error then so does the wrapper.
+func withinLimit(limited bool, fn func(*Run) error) func(*Run) error {
+	return func(r *Run) error {
+		if !limited {
+			return fn(r)
+		}
+		// invoke fn on a copy of the run to determine whether it would be
+		// enqueued
+		probe := *r
+		if err := fn(&probe); err != nil {
+			return err
+		}
+		if !probe.InFlight() {
+			// fn does not enqueue the run so invoke it on the run for real
+			return fn(r)
+		}
+		r.QueuedByLimit = true
+		return nil
+	}
+}
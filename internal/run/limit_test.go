@@ -0,0 +1,87 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithinLimit(t *testing.T) {
+	enqueuePlan := func(r *Run) error { return r.EnqueuePlan() }
+
+	t.Run("not limited", func(t *testing.T) {
+		run := &Run{Status: internal.RunPending}
+
+		require.NoError(t, withinLimit(false, enqueuePlan)(run))
+		assert.Equal(t, internal.RunPlanQueued, run.Status)
+		assert.False(t, run.QueuedByLimit)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		run := &Run{Status: internal.RunPending}
+
+		require.NoError(t, withinLimit(true, enqueuePlan)(run))
+		assert.Equal(t, internal.RunPending, run.Status)
+		assert.Empty(t, run.StatusTimestamps)
+		assert.Equal(t, PhaseStatus(""), run.Plan.Status)
+		assert.True(t, run.QueuedByLimit)
+
+		// subsequently released
+		require.NoError(t, withinLimit(false, enqueuePlan)(run))
+		assert.Equal(t, internal.RunPlanQueued, run.Status)
+		assert.False(t, run.QueuedByLimit)
+	})
+
+	t.Run("limited but cannot be enqueued", func(t *testing.T) {
+		run := &Run{Status: internal.RunPlanning}
+
+		assert.Error(t, withinLimit(true, enqueuePlan)(run))
+		assert.False(t, run.QueuedByLimit)
+	})
+
+	t.Run("limited but not enqueued", func(t *testing.T) {
+		// the first of two approvals does not enqueue an auto-apply run, and
+		// so the approval is unaffected by the limit.
+		run := &Run{
+			Status:            internal.RunPlanned,
+			CreatedBy:         internal.String("author"),
+			ApprovalsRequired: 2,
+			AutoApply:         true,
+		}
+		policy := &workspace.ApprovalPolicy{Required: 2}
+		approval, err := newApproval(run, policy, &auth.User{Username: "alice"}, ApprovedDecision, "")
+		require.NoError(t, err)
+
+		err = withinLimit(true, func(r *Run) error { return r.addApproval(approval) })(run)
+		require.NoError(t, err)
+		assert.Equal(t, internal.RunPlanned, run.Status)
+		assert.Equal(t, 1, run.ApprovalCount())
+		assert.False(t, run.QueuedByLimit)
+	})
+}
+
+func TestRun_InFlight(t *testing.T) {
+	tests := []struct {
+		status internal.RunStatus
+		want   bool
+	}{
+		{internal.RunPending, false},
+		{internal.RunPrePlanRunning, true},
+		{internal.RunPlanQueued, true},
+		{internal.RunPlanning, true},
+		{internal.RunPlanned, false},
+		{internal.RunApplyQueued, true},
+		{internal.RunApplying, true},
+		{internal.RunApplied, false},
+		{internal.RunPlannedAndFinished, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.want, (&Run{Status: tt.status}).InFlight())
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Actions as they appear in a plan diff; a replacement combines a delete and a
+// create in the plan file.
+const (
+	DiffCreate  DiffAction = "create"
+	DiffUpdate  DiffAction = "update"
+	DiffReplace DiffAction = "replace"
+	DiffDelete  DiffAction = "delete"
+	DiffRead    DiffAction = "read"
+	DiffNoop    DiffAction = "no-op"
+
+	// RootModule identifies the root module when filtering a diff
+	RootModule = "root"
+
+	sensitiveValue = "(sensitive value)"
+	unknownValue   = "(known after apply)"
+)
+
+// actionOrder is the order in which actions are listed in a diff
+var actionOrder = map[DiffAction]int{
+	DiffCreate:  0,
+	DiffUpdate:  1,
+	DiffReplace: 2,
+	DiffDelete:  3,
+	DiffRead:    4,
+	DiffNoop:    5,
+}
+
+// actionReasons are human-readable descriptions of why terraform chose an
+// action.
+var actionReasons = map[string]string{
+	"replace_because_tainted":                 "resource is tainted",
+	"replace_because_cannot_update":           "some attributes cannot be updated in-place",
+	"replace_by_request":                      "replacement was requested",
+	"replace_by_triggers":                     "replace_triggered_by references changed",
+	"delete_because_no_resource_config":       "resource is no longer in configuration",
+	"delete_because_no_module":                "containing module is no longer in configuration",
+	"delete_because_wrong_repetition":         "resource no longer uses count or for_each",
+	"delete_because_count_index":              "count index is out of range",
+	"delete_because_each_key":                 "for_each key no longer exists",
+	"read_because_config_unknown":             "configuration contains unknown values",
+	"read_because_dependency_pending":         "depends on a resource with pending changes",
+	"read_because_check_nested":               "referenced by a check block",
+	"delete_because_no_move_target":           "move target does not exist",
+	"replace_because_cannot_update_sensitive": "sensitive attributes cannot be updated in-place",
+}
+
+type (
+	DiffAction string
+
+	// PlanDiff is a resource-level diff of the changes proposed in a plan.
+	PlanDiff struct {
+		Modules []*ModuleDiff `json:"modules"`
+		// Drift are changes made to resources outside of terraform.
+		Drift []*ResourceDiff `json:"drift"`
+		// Summary is a tally of the proposed resource changes
+		Summary Report `json:"summary"`
+	}
+
+	// ModuleDiff is the diff of resources belonging to a module.
+	ModuleDiff struct {
+		// Address of module; empty for the root module
+		Address   string          `json:"address"`
+		Resources []*ResourceDiff `json:"resources"`
+	}
+
+	// ResourceDiff is the proposed change to a resource.
+	ResourceDiff struct {
+		Address       string           `json:"address"`
+		ModuleAddress string           `json:"module_address,omitempty"`
+		Type          string           `json:"type"`
+		Name          string           `json:"name"`
+		Action        DiffAction       `json:"action"`
+		ActionReason  string           `json:"action_reason,omitempty"`
+		Attributes    []*AttributeDiff `json:"attributes"`
+	}
+
+	// AttributeDiff is the change to a resource attribute. Sensitive values
+	// are masked, and unknown values are replaced with a placeholder.
+	AttributeDiff struct {
+		Path   string `json:"path"`
+		Before string `json:"before,omitempty"`
+		After  string `json:"after,omitempty"`
+		// ForcesReplacement is true if changing the attribute forces the
+		// resource to be replaced.
+		ForcesReplacement bool `json:"forces_replacement,omitempty"`
+	}
+
+	// PlanDiffFilter filters the resources in a plan diff.
+	PlanDiffFilter struct {
+		// Only include resources with this action
+		Action DiffAction `schema:"action"`
+		// Only include resources in this module; RootModule selects the root
+		// module.
+		Module string `schema:"module"`
+		// Only include resources whose address contains this string
+		Search string `schema:"search"`
+	}
+
+	// planDiffFile is the subset of the JSON plan file used to construct a
+	// diff.
+	planDiffFile struct {
+		ResourceChanges []planResourceChange `json:"resource_changes"`
+		ResourceDrift   []planResourceChange `json:"resource_drift"`
+	}
+
+	planResourceChange struct {
+		Address       string `json:"address"`
+		ModuleAddress string `json:"module_address"`
+		Mode          string `json:"mode"`
+		Type          string `json:"type"`
+		Name          string `json:"name"`
+		ActionReason  string `json:"action_reason"`
+		Change        struct {
+			Actions         []string `json:"actions"`
+			Before          any      `json:"before"`
+			After           any      `json:"after"`
+			AfterUnknown    any      `json:"after_unknown"`
+			BeforeSensitive any      `json:"before_sensitive"`
+			AfterSensitive  any      `json:"after_sensitive"`
+			ReplacePaths    [][]any  `json:"replace_paths"`
+		} `json:"change"`
+	}
+
+	// attribute is a leaf value within a resource, along with its path.
+	attribute struct {
+		path  []any
+		value any
+	}
+)
+
+// GetPlanDiff returns a resource-level diff of the changes proposed in the
+// run's plan.
+func (s *service) GetPlanDiff(ctx context.Context, runID string) (*PlanDiff, error) {
+	plan, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlanDiff(plan)
+}
+
+// NewPlanDiff constructs a diff from a JSON plan file.
+func NewPlanDiff(planJSON []byte) (*PlanDiff, error) {
+	var file planDiffFile
+	if err := json.Unmarshal(planJSON, &file); err != nil {
+		return nil, fmt.Errorf("parsing plan file: %w", err)
+	}
+
+	var diff PlanDiff
+	modules := make(map[string]*ModuleDiff)
+	for _, rc := range file.ResourceChanges {
+		rd := newResourceDiff(rc)
+		switch rd.Action {
+		case DiffNoop:
+			continue
+		case DiffCreate:
+			diff.Summary.Additions++
+		case DiffUpdate:
+			diff.Summary.Changes++
+		case DiffDelete:
+			diff.Summary.Destructions++
+		case DiffReplace:
+			diff.Summary.Additions++
+			diff.Summary.Destructions++
+		}
+		mod, ok := modules[rd.ModuleAddress]
+		if !ok {
+			mod = &ModuleDiff{Address: rd.ModuleAddress}
+			modules[rd.ModuleAddress] = mod
+			diff.Modules = append(diff.Modules, mod)
+		}
+		mod.Resources = append(mod.Resources, rd)
+	}
+	// root module first, then modules in order of address; resources are
+	// grouped by action.
+	sort.Slice(diff.Modules, func(i, j int) bool {
+		return diff.Modules[i].Address < diff.Modules[j].Address
+	})
+	for _, mod := range diff.Modules {
+		sort.SliceStable(mod.Resources, func(i, j int) bool {
+			return actionOrder[mod.Resources[i].Action] < actionOrder[mod.Resources[j].Action]
+		})
+	}
+	for _, rc := range file.ResourceDrift {
+		diff.Drift = append(diff.Drift, newResourceDiff(rc))
+	}
+	return &diff, nil
+}
+
+// Filter returns a copy of the diff including only those resources matching
+// the filter. Drift is not filtered.
+func (d *PlanDiff) Filter(filter PlanDiffFilter) *PlanDiff {
+	filtered := PlanDiff{Drift: d.Drift, Summary: d.Summary}
+	for _, mod := range d.Modules {
+		if filter.Module != "" && filter.Module != mod.Name() {
+			continue
+		}
+		var resources []*ResourceDiff
+		for _, rd := range mod.Resources {
+			if filter.Action != "" && filter.Action != rd.Action {
+				continue
+			}
+			if !strings.Contains(rd.Address, filter.Search) {
+				continue
+			}
+			resources = append(resources, rd)
+		}
+		if len(resources) > 0 {
+			filtered.Modules = append(filtered.Modules, &ModuleDiff{
+				Address:   mod.Address,
+				Resources: resources,
+			})
+		}
+	}
+	return &filtered
+}
+
+// Name returns the module address, or RootModule for the root module.
+func (m *ModuleDiff) Name() string {
+	if m.Address == "" {
+		return RootModule
+	}
+	return m.Address
+}
+
+// Reason returns a human-readable description of why terraform chose the
+// action, or an empty string if no reason was given.
+func (r *ResourceDiff) Reason() string {
+	if reason, ok := actionReasons[r.ActionReason]; ok {
+		return reason
+	}
+	return r.ActionReason
+}
+
+func newResourceDiff(rc planResourceChange) *ResourceDiff {
+	rd := &ResourceDiff{
+		Address:       rc.Address,
+		ModuleAddress: rc.ModuleAddress,
+		Type:          rc.Type,
+		Name:          rc.Name,
+		Action:        diffAction(rc.Change.Actions),
+		ActionReason:  rc.ActionReason,
+	}
+
+	before := make(map[string]attribute)
+	for _, attr := range flatten(nil, rc.Change.Before) {
+		before[pathString(attr.path)] = attr
+	}
+	after := make(map[string]attribute)
+	for _, attr := range flatten(nil, rc.Change.After) {
+		after[pathString(attr.path)] = attr
+	}
+	// unknown values are absent from after, so add their paths too.
+	for _, attr := range flatten(nil, rc.Change.AfterUnknown) {
+		if attr.value == true {
+			after[pathString(attr.path)] = attribute{path: attr.path}
+		}
+	}
+
+	paths := make(map[string][]any, len(before)+len(after))
+	for k, attr := range before {
+		paths[k] = attr.path
+	}
+	for k, attr := range after {
+		paths[k] = attr.path
+	}
+	for k, path := range paths {
+		b, inBefore := before[k]
+		a, inAfter := after[k]
+		unknown := masked(rc.Change.AfterUnknown, path)
+		if inBefore && inAfter && !unknown && renderValue(b.value, false) == renderValue(a.value, false) {
+			// only changed attributes are of interest
+			continue
+		}
+		ad := &AttributeDiff{Path: k}
+		if inBefore {
+			ad.Before = renderValue(b.value, masked(rc.Change.BeforeSensitive, path))
+		}
+		if unknown {
+			ad.After = unknownValue
+		} else if inAfter {
+			ad.After = renderValue(a.value, masked(rc.Change.AfterSensitive, path))
+		}
+		for _, rp := range rc.Change.ReplacePaths {
+			if hasPrefix(path, rp) {
+				ad.ForcesReplacement = true
+				break
+			}
+		}
+		rd.Attributes = append(rd.Attributes, ad)
+	}
+	sort.Slice(rd.Attributes, func(i, j int) bool {
+		return rd.Attributes[i].Path < rd.Attributes[j].Path
+	})
+	return rd
+}
+
+func diffAction(actions []string) DiffAction {
+	switch len(actions) {
+	case 1:
+		return DiffAction(actions[0])
+	case 2:
+		// either delete-then-create or create-then-delete
+		return DiffReplace
+	default:
+		return DiffNoop
+	}
+}
+
+// flatten walks a JSON value, returning its leaf values. Empty objects and
+// arrays are considered leaves.
+func flatten(path []any, v any) []attribute {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			break
+		}
+		var attrs []attribute
+		for k, child := range v {
+			attrs = append(attrs, flatten(appendPath(path, k), child)...)
+		}
+		return attrs
+	case []any:
+		if len(v) == 0 {
+			break
+		}
+		var attrs []attribute
+		for i, child := range v {
+			attrs = append(attrs, flatten(appendPath(path, i), child)...)
+		}
+		return attrs
+	case nil:
+		// null values are omitted
+		return nil
+	}
+	if len(path) == 0 {
+		// scalar at the top level is not an attribute
+		return nil
+	}
+	return []attribute{{path: path, value: v}}
+}
+
+// masked determines whether the value at the path is marked as true in the
+// mask, which is a JSON value mirroring the structure of the resource, with
+// true marking a sensitive (or unknown) value or a parent thereof.
+func masked(mask any, path []any) bool {
+	for _, elem := range path {
+		switch m := mask.(type) {
+		case bool:
+			return m
+		case map[string]any:
+			k, ok := elem.(string)
+			if !ok {
+				return false
+			}
+			mask = m[k]
+		case []any:
+			i, ok := elem.(int)
+			if !ok || i >= len(m) {
+				return false
+			}
+			mask = m[i]
+		default:
+			return false
+		}
+	}
+	b, ok := mask.(bool)
+	return ok && b
+}
+
+func renderValue(v any, sensitive bool) string {
+	if sensitive {
+		return sensitiveValue
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// pathString renders a path in the form tags.Name or subnets[0]
+func pathString(path []any) string {
+	var b strings.Builder
+	for _, elem := range path {
+		switch elem := elem.(type) {
+		case string:
+			if b.Len() > 0 {
+				b.WriteRune('.')
+			}
+			b.WriteString(elem)
+		case int:
+			fmt.Fprintf(&b, "[%d]", elem)
+		}
+	}
+	return b.String()
+}
+
+// hasPrefix determines whether prefix is a prefix of path. Replace paths in
+// the plan file encode indices as JSON numbers.
+func hasPrefix(path, prefix []any) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, elem := range prefix {
+		if f, ok := elem.(float64); ok {
+			elem = int(f)
+		}
+		if elem != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendPath(path []any, elem any) []any {
+	return append(path[:len(path):len(path)], elem)
+}
@@ -0,0 +1,92 @@
+package run
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlanDiff(t *testing.T) {
+	data, err := os.ReadFile("testdata/plan_diff.json")
+	require.NoError(t, err)
+
+	diff, err := NewPlanDiff(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, Report{Additions: 2, Changes: 1, Destructions: 1}, diff.Summary)
+
+	// root module is listed first, and no-op changes are omitted
+	require.Equal(t, 2, len(diff.Modules))
+	root := diff.Modules[0]
+	assert.Equal(t, RootModule, root.Name())
+	require.Equal(t, 2, len(root.Resources))
+
+	t.Run("create", func(t *testing.T) {
+		pet := root.Resources[0]
+		assert.Equal(t, DiffCreate, pet.Action)
+		assert.Equal(t, []*AttributeDiff{
+			{Path: "id", After: "(known after apply)"},
+			{Path: "length", After: "2"},
+		}, pet.Attributes)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		web := root.Resources[1]
+		assert.Equal(t, DiffReplace, web.Action)
+		assert.Equal(t, "some attributes cannot be updated in-place", web.Reason())
+		assert.Equal(t, []*AttributeDiff{
+			{Path: "ami", Before: "ami-123", After: "ami-456", ForcesReplacement: true},
+			{Path: "id", Before: "i-abc", After: "(known after apply)"},
+			{Path: "user_data", Before: "(sensitive value)", After: "(sensitive value)"},
+		}, web.Attributes)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mod := diff.Modules[1]
+		assert.Equal(t, "module.net", mod.Name())
+		require.Equal(t, 1, len(mod.Resources))
+		assert.Equal(t, []*AttributeDiff{
+			{Path: "tags.Name", Before: "private", After: "private-0"},
+			{Path: "zones[1]", Before: "b", After: "c"},
+		}, mod.Resources[0].Attributes)
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		require.Equal(t, 1, len(diff.Drift))
+		assert.Equal(t, []*AttributeDiff{
+			{Path: "tags.env", Before: "dev", After: "prod"},
+		}, diff.Drift[0].Attributes)
+	})
+}
+
+func TestPlanDiff_Filter(t *testing.T) {
+	data, err := os.ReadFile("testdata/plan_diff.json")
+	require.NoError(t, err)
+	diff, err := NewPlanDiff(data)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		filter PlanDiffFilter
+		want   []string
+	}{
+		{"none", PlanDiffFilter{}, []string{"random_pet.pet", "aws_instance.web", "module.net.aws_subnet.private[0]"}},
+		{"action", PlanDiffFilter{Action: DiffReplace}, []string{"aws_instance.web"}},
+		{"root module", PlanDiffFilter{Module: RootModule}, []string{"random_pet.pet", "aws_instance.web"}},
+		{"child module", PlanDiffFilter{Module: "module.net"}, []string{"module.net.aws_subnet.private[0]"}},
+		{"search", PlanDiffFilter{Search: "pet"}, []string{"random_pet.pet"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, mod := range diff.Filter(tt.filter).Modules {
+				for _, rd := range mod.Resources {
+					got = append(got, rd.Address)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/cloud"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// previewName returns the name of the preview workspace for a pull request.
+func previewName(template *workspace.Workspace, pull int) string {
+	return fmt.Sprintf("%s-pr-%d", template.Name, pull)
+}
+
+// createPreviews creates a preview workspace from each template for a newly
+// opened pull request, and applies the head of the pull request to it.
+func (s *Spawner) createPreviews(ctx context.Context, logger logr.Logger, event cloud.VCSEvent, workspaces []*workspace.Workspace) error {
+	var tarball []byte
+	for _, template := range workspaces {
+		if !template.PreviewTemplate {
+			continue
+		}
+		logger := logger.WithValues("template", template.ID, "pull", event.PullRequestNumber)
+
+		var n int
+		for _, ws := range workspaces {
+			if ws.Preview == nil || ws.Preview.TemplateID != template.ID {
+				continue
+			}
+			if ws.Preview.PullRequest == event.PullRequestNumber {
+				// pull request already has a preview, e.g. it was reopened.
+				n = -1
+				break
+			}
+			n++
+		}
+		if n < 0 {
+			continue
+		}
+		if n >= template.MaxPreviews {
+			logger.Info("skipping preview: maximum number of previews reached", "max", template.MaxPreviews)
+			continue
+		}
+
+		preview, err := s.clonePreview(ctx, template, event)
+		if err != nil {
+			return fmt.Errorf("creating preview workspace: %w", err)
+		}
+		logger.Info("created preview workspace", "workspace", preview.ID)
+
+		if tarball == nil {
+			client, err := s.GetVCSClient(ctx, event.VCSProviderID)
+			if err != nil {
+				return err
+			}
+			tarball, _, err = client.GetRepoTarball(ctx, cloud.GetRepoTarballOptions{
+				Repo: event.RepoPath,
+				Ref:  &event.CommitSHA,
+			})
+			if err != nil {
+				return fmt.Errorf("retrieving repo tarball: %w", err)
+			}
+		}
+		cvOpts := configversion.ConfigurationVersionCreateOptions{
+			IngressAttributes: &configversion.IngressAttributes{
+				Branch:            event.Branch,
+				CommitSHA:         event.CommitSHA,
+				CommitURL:         event.CommitURL,
+				Repo:              preview.Connection.Repo,
+				PullRequestNumber: event.PullRequestNumber,
+				PullRequestTitle:  event.PullRequestTitle,
+				PullRequestURL:    event.PullRequestURL,
+				SenderUsername:    event.SenderUsername,
+				SenderAvatarURL:   event.SenderAvatarURL,
+				SenderHTMLURL:     event.SenderHTMLURL,
+			},
+		}
+		runOpts := CreateOptions{}
+		cvOpts.Source, runOpts.Source = eventSources(event.Cloud)
+		cv, err := s.CreateConfigurationVersion(ctx, preview.ID, cvOpts)
+		if err != nil {
+			return err
+		}
+		if err := s.UploadConfig(ctx, cv.ID, tarball); err != nil {
+			return err
+		}
+		runOpts.ConfigurationVersionID = internal.String(cv.ID)
+		if _, err := s.CreateRun(ctx, preview.ID, runOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clonePreview clones a preview workspace from a template, along with its
+// variables, tags and team permissions. The preview is connected to the same
+// repo as the template but tracks the pull request's branch.
+func (s *Spawner) clonePreview(ctx context.Context, template *workspace.Workspace, event cloud.VCSEvent) (*workspace.Workspace, error) {
+	return s.CloneWorkspace(ctx, template.ID, workspace.CloneOptions{
+		Name:               internal.String(previewName(template, event.PullRequestNumber)),
+		Description:        internal.String(fmt.Sprintf("Preview of pull request #%d: %s", event.PullRequestNumber, event.PullRequestTitle)),
+		Branch:             &event.Branch,
+		Variables:          true,
+		SensitiveVariables: true,
+		Permissions:        true,
+		Tags:               true,
+		Preview: &workspace.Preview{
+			TemplateID:  template.ID,
+			PullRequest: event.PullRequestNumber,
+		},
+	})
+}
+
+// destroyPreviews queues a destroy run on each preview workspace for a pull
+// request that has been merged or closed. Once the destroy has succeeded the
+// preview reaper deletes the workspace.
+func (s *Spawner) destroyPreviews(ctx context.Context, logger logr.Logger, event cloud.VCSEvent, workspaces []*workspace.Workspace) error {
+	for _, ws := range workspaces {
+		if ws.Preview == nil || ws.Preview.PullRequest != event.PullRequestNumber {
+			continue
+		}
+		opts := CreateOptions{
+			IsDestroy: internal.Bool(true),
+			AutoApply: internal.Bool(true),
+			Message:   internal.String(fmt.Sprintf("Pull request #%d closed", event.PullRequestNumber)),
+		}
+		_, opts.Source = eventSources(event.Cloud)
+		_, err := s.CreateRun(ctx, ws.ID, opts)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			// preview has no configuration and therefore no resources to
+			// destroy
+			if _, err := s.DeleteWorkspace(ctx, ws.ID); err != nil {
+				return err
+			}
+			logger.Info("deleted preview workspace", "workspace", ws.ID)
+			continue
+		} else if err != nil {
+			return err
+		}
+		logger.Info("queued destroy of preview workspace", "workspace", ws.ID)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package run
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+)
+
+// PreviewReaperLockID is a unique ID guaranteeing only one preview reaper on
+// a cluster is running at any time.
+const PreviewReaperLockID int64 = 179366396344335601
+
+type (
+	// PreviewReaper deletes preview workspaces once their resources have been
+	// destroyed following the closure of their pull request.
+	PreviewReaper struct {
+		logr.Logger
+		pubsub.Subscriber
+		WorkspaceService
+		VCSProviderService
+	}
+)
+
+// Start starts the preview reaper daemon. Should be invoked in a go routine.
+func (r *PreviewReaper) Start(ctx context.Context) error {
+	// Unsubscribe whenever exiting this routine.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe to run events
+	sub, err := r.Subscribe(ctx, "preview-reaper-")
+	if err != nil {
+		return err
+	}
+
+	for event := range sub {
+		run, ok := event.Payload.(*Run)
+		if !ok {
+			// Skip non-run events
+			continue
+		}
+		if event.Type == pubsub.DeletedEvent {
+			// Skip deleted run events
+			continue
+		}
+		if err := r.handleRun(ctx, run); err != nil {
+			r.Error(err, "reaping preview workspace", "run", run.ID)
+		}
+	}
+	return nil
+}
+
+func (r *PreviewReaper) handleRun(ctx context.Context, run *Run) error {
+	if !run.IsDestroy {
+		return nil
+	}
+	switch run.Status {
+	case internal.RunApplied, internal.RunPlannedAndFinished:
+		// resources destroyed (or there were none to destroy)
+	default:
+		return nil
+	}
+	ws, err := r.GetWorkspace(ctx, run.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if ws.Preview == nil || ws.Connection == nil {
+		return nil
+	}
+	// a destroy run can be triggered by a user too, in which case the preview
+	// is retained for as long as its pull request remains open.
+	client, err := r.GetVCSClient(ctx, ws.Connection.VCSProviderID)
+	if err != nil {
+		return err
+	}
+	pr, err := client.GetPullRequest(ctx, ws.Connection.Repo, ws.Preview.PullRequest)
+	if err != nil {
+		return err
+	}
+	if pr.Open {
+		return nil
+	}
+	if _, err := r.DeleteWorkspace(ctx, ws.ID); err != nil {
+		return err
+	}
+	r.Info("deleted preview workspace", "workspace", ws.ID, "pull", ws.Preview.PullRequest)
+	return nil
+}
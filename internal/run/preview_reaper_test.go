@@ -0,0 +1,111 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/cloud"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewReaper(t *testing.T) {
+	preview := &workspace.Workspace{
+		ID:         "ws-123",
+		Preview:    &workspace.Preview{TemplateID: "ws-template", PullRequest: 7},
+		Connection: &workspace.Connection{Repo: "leg100/otf", VCSProviderID: "vcs-123"},
+	}
+
+	tests := []struct {
+		name   string
+		ws     *workspace.Workspace
+		run    *Run
+		open   bool
+		delete bool
+	}{
+		{
+			name:   "delete preview once destroyed",
+			ws:     preview,
+			run:    &Run{WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplied},
+			delete: true,
+		},
+		{
+			name:   "delete preview with nothing to destroy",
+			ws:     preview,
+			run:    &Run{WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunPlannedAndFinished},
+			delete: true,
+		},
+		{
+			name: "retain preview while pull request is open",
+			ws:   preview,
+			run:  &Run{WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplied},
+			open: true,
+		},
+		{
+			name: "ignore destroy that is still applying",
+			ws:   preview,
+			run:  &Run{WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplying},
+		},
+		{
+			name: "ignore non-destroy run",
+			ws:   preview,
+			run:  &Run{WorkspaceID: "ws-123", Status: internal.RunApplied},
+		},
+		{
+			name: "ignore non-preview workspace",
+			ws:   &workspace.Workspace{ID: "ws-123", Connection: &workspace.Connection{}},
+			run:  &Run{WorkspaceID: "ws-123", IsDestroy: true, Status: internal.RunApplied},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := &fakePreviewReaperServices{ws: tt.ws, open: tt.open}
+			reaper := &PreviewReaper{
+				Logger:             logr.Discard(),
+				WorkspaceService:   services,
+				VCSProviderService: services,
+			}
+			err := reaper.handleRun(context.Background(), tt.run)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.delete, services.deleted)
+		})
+	}
+}
+
+type (
+	fakePreviewReaperServices struct {
+		ws      *workspace.Workspace
+		open    bool
+		deleted bool
+
+		WorkspaceService
+		VCSProviderService
+	}
+
+	fakePreviewReaperCloudClient struct {
+		open bool
+
+		cloud.Client
+	}
+)
+
+func (f *fakePreviewReaperServices) GetWorkspace(context.Context, string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
+
+func (f *fakePreviewReaperServices) DeleteWorkspace(context.Context, string) (*workspace.Workspace, error) {
+	f.deleted = true
+	return f.ws, nil
+}
+
+func (f *fakePreviewReaperServices) GetVCSClient(context.Context, string) (cloud.Client, error) {
+	return &fakePreviewReaperCloudClient{open: f.open}, nil
+}
+
+func (f *fakePreviewReaperCloudClient) GetPullRequest(ctx context.Context, repo string, pull int) (cloud.PullRequest, error) {
+	return cloud.PullRequest{Number: pull, Open: f.open}, nil
+}
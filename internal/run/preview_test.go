@@ -0,0 +1,183 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/cloud"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawner_CreatePreview(t *testing.T) {
+	template := &workspace.Workspace{
+		ID:               "ws-template",
+		Name:             "dev",
+		Organization:     "acme-corp",
+		PreviewTemplate:  true,
+		MaxPreviews:      2,
+		TerraformVersion: "1.5.0",
+		ExecutionMode:    workspace.RemoteExecutionMode,
+		Connection: &workspace.Connection{
+			Repo:          "leg100/otf",
+			VCSProviderID: "vcs-123",
+		},
+	}
+	preview := func(id string, pull int) *workspace.Workspace {
+		return &workspace.Workspace{
+			ID:         id,
+			Preview:    &workspace.Preview{TemplateID: template.ID, PullRequest: pull},
+			Connection: &workspace.Connection{Repo: "leg100/otf"},
+		}
+	}
+	event := cloud.VCSEvent{
+		Type:              cloud.VCSEventTypePull,
+		Action:            cloud.VCSActionCreated,
+		Branch:            "feature",
+		CommitSHA:         "abc123",
+		PullRequestNumber: 7,
+	}
+
+	t.Run("create preview", func(t *testing.T) {
+		services := newFakePreviewServices(template)
+		err := services.spawner().handleWithError(logr.Discard(), event)
+		require.NoError(t, err)
+
+		// preview is cloned from template
+		require.Equal(t, 1, len(services.clones))
+		assert.Equal(t, "ws-template", services.clones[0].sourceID)
+		opts := services.clones[0].opts
+		assert.Equal(t, "dev-pr-7", *opts.Name)
+		assert.Equal(t, &workspace.Preview{TemplateID: "ws-template", PullRequest: 7}, opts.Preview)
+		assert.Equal(t, "feature", *opts.Branch)
+		assert.True(t, opts.Variables)
+		assert.True(t, opts.SensitiveVariables)
+		assert.True(t, opts.Permissions)
+		assert.True(t, opts.Tags)
+		got := services.clones[0].clone
+
+		// a run is spawned on the preview but not on the template
+		require.Equal(t, 1, len(services.runs))
+		assert.Equal(t, got.ID, services.runs[0].workspaceID)
+		require.Equal(t, 1, len(services.created))
+		assert.False(t, services.created[0].Speculative)
+		assert.Equal(t, "abc123", services.created[0].IngressAttributes.CommitSHA)
+	})
+
+	t.Run("skip when maximum previews reached", func(t *testing.T) {
+		services := newFakePreviewServices(template, preview("ws-1", 1), preview("ws-2", 2))
+		err := services.spawner().handleWithError(logr.Discard(), event)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(services.clones))
+		assert.Equal(t, 0, len(services.runs))
+	})
+
+	t.Run("skip when pull request already has preview", func(t *testing.T) {
+		services := newFakePreviewServices(template, preview("ws-1", 7))
+		err := services.spawner().handleWithError(logr.Discard(), event)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(services.clones))
+	})
+
+	t.Run("destroy preview when pull request merged", func(t *testing.T) {
+		services := newFakePreviewServices(template, preview("ws-1", 7), preview("ws-2", 8))
+		err := services.spawner().handleWithError(logr.Discard(), cloud.VCSEvent{
+			Type:              cloud.VCSEventTypePull,
+			Action:            cloud.VCSActionMerged,
+			PullRequestNumber: 7,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(services.runs))
+		assert.Equal(t, "ws-1", services.runs[0].workspaceID)
+		assert.True(t, *services.runs[0].opts.IsDestroy)
+		assert.True(t, *services.runs[0].opts.AutoApply)
+		assert.Equal(t, 0, len(services.deleted))
+	})
+
+	t.Run("delete preview without configuration when pull request closed", func(t *testing.T) {
+		services := newFakePreviewServices(template, preview("ws-1", 7))
+		services.noConfig = true
+		err := services.spawner().handleWithError(logr.Discard(), cloud.VCSEvent{
+			Type:              cloud.VCSEventTypePull,
+			Action:            cloud.VCSActionDeleted,
+			PullRequestNumber: 7,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ws-1"}, services.deleted)
+	})
+}
+
+type (
+	fakePreviewServices struct {
+		*fakeSpawnerServices
+
+		clones  []fakePreviewClone
+		runs    []fakePreviewRun
+		deleted []string
+		// whether preview workspaces lack a configuration version
+		noConfig bool
+	}
+
+	fakePreviewClone struct {
+		sourceID string
+		opts     workspace.CloneOptions
+		clone    *workspace.Workspace
+	}
+
+	fakePreviewRun struct {
+		workspaceID string
+		opts        CreateOptions
+	}
+)
+
+func newFakePreviewServices(workspaces ...*workspace.Workspace) *fakePreviewServices {
+	return &fakePreviewServices{
+		fakeSpawnerServices: &fakeSpawnerServices{
+			workspaces: workspaces,
+		},
+	}
+}
+
+func (f *fakePreviewServices) spawner() *Spawner {
+	return &Spawner{
+		ConfigurationVersionService: f,
+		WorkspaceService:            f,
+		VCSProviderService:          f,
+		RunService:                  f,
+		locks:                       &fakePullRequestLocker{},
+	}
+}
+
+func (f *fakePreviewServices) CloneWorkspace(ctx context.Context, workspaceID string, opts workspace.CloneOptions) (*workspace.Workspace, error) {
+	clone, err := workspace.NewWorkspace(workspace.CreateOptions{
+		Name:         opts.Name,
+		Organization: internal.String("acme-corp"),
+		Preview:      opts.Preview,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clone.Connection = &workspace.Connection{Repo: "leg100/otf", Branch: *opts.Branch}
+	f.clones = append(f.clones, fakePreviewClone{sourceID: workspaceID, opts: opts, clone: clone})
+	return clone, nil
+}
+
+func (f *fakePreviewServices) DeleteWorkspace(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	f.deleted = append(f.deleted, workspaceID)
+	return &workspace.Workspace{ID: workspaceID}, nil
+}
+
+func (f *fakePreviewServices) CreateRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
+	if f.noConfig && opts.ConfigurationVersionID == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	f.runs = append(f.runs, fakePreviewRun{workspaceID: workspaceID, opts: opts})
+	return &Run{ID: "run-123"}, nil
+}
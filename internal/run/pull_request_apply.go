@@ -0,0 +1,230 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/cloud"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// pullRequestLocker locks workspaces on behalf of pull requests, ensuring
+// only one pull request at a time can apply changes to a workspace.
+type pullRequestLocker interface {
+	lockForPullRequest(ctx context.Context, workspaceID, repo string, pull int, username string) (holder pullRequestLock, acquired bool, err error)
+	unlockWorkspaceForPullRequest(ctx context.Context, workspaceID string) error
+	unlockPullRequest(ctx context.Context, repo string, pull int) error
+}
+
+// pullRequestLock is a lock on a workspace held by a pull request. Whilst
+// held, only runs from the pull request can apply changes to the workspace.
+type pullRequestLock struct {
+	Repo              string
+	PullRequestNumber int
+}
+
+// heldBy determines whether the run belongs to the pull request holding the
+// lock.
+func (l pullRequestLock) heldBy(run *Run) bool {
+	attrs := run.IngressAttributes
+	if attrs == nil || !attrs.IsPullRequest {
+		return false
+	}
+	return attrs.Repo == l.Repo && attrs.PullRequestNumber == l.PullRequestNumber
+}
+
+// parseApplyCommand parses a pull request comment of the form:
+//
+//	otf apply <workspace>
+//
+// returning the name of the workspace and true if the comment is such a
+// command. Only the first line of the comment is considered.
+func parseApplyCommand(comment string) (string, bool) {
+	line, _, _ := strings.Cut(comment, "\n")
+	fields := strings.Fields(line)
+	if len(fields) != 3 || fields[0] != "otf" || fields[1] != "apply" {
+		return "", false
+	}
+	return fields[2], true
+}
+
+// handleComment handles a comment on a pull request. If the comment is an
+// apply command then a run is spawned that plans and applies the head of the
+// pull request, provided the workspace permits it and the commenter is
+// authorized to apply runs on the workspace. Otherwise the commenter is told
+// why the command was refused.
+func (s *Spawner) handleComment(ctx context.Context, logger logr.Logger, event cloud.VCSEvent) error {
+	name, ok := parseApplyCommand(event.Comment)
+	if !ok {
+		return nil
+	}
+	logger = logger.WithValues("workspace", name, "pull", event.PullRequestNumber, "sender", event.SenderUsername)
+
+	client, err := s.GetVCSClient(ctx, event.VCSProviderID)
+	if err != nil {
+		return err
+	}
+	refuse := func(format string, a ...any) error {
+		msg := fmt.Sprintf(format, a...)
+		logger.Info("refused apply command", "reason", msg)
+		_, err := client.CreatePullRequestComment(ctx, cloud.CreatePullRequestCommentOptions{
+			Repo:        event.RepoPath,
+			PullRequest: event.PullRequestNumber,
+			Body:        msg,
+		})
+		return err
+	}
+
+	workspaces, err := s.ListWorkspacesByRepoID(ctx, event.RepoID)
+	if err != nil {
+		return err
+	}
+	var ws *workspace.Workspace
+	for _, w := range workspaces {
+		if w.Name == name {
+			ws = w
+			break
+		}
+	}
+	if ws == nil {
+		return refuse("No workspace named `%s` is connected to this repository.", name)
+	}
+	if !ws.Connection.PRApply {
+		return refuse("Workspace `%s` does not permit applying from pull request comments.", name)
+	}
+
+	user, err := s.mapSender(ctx, event)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return refuse("`%s` is not mapped to an OTF user or team.", event.SenderUsername)
+	} else if err != nil {
+		return err
+	}
+	policy, err := s.GetPolicy(ctx, ws.ID)
+	if err != nil {
+		return err
+	}
+	// the site admin is deliberately excluded because it is not a VCS user.
+	if user.IsSiteAdmin() || !user.CanAccessWorkspace(rbac.ApplyRunAction, policy) {
+		return refuse("`%s` is not authorized to apply runs on workspace `%s`.", event.SenderUsername, name)
+	}
+
+	pr, err := client.GetPullRequest(ctx, event.RepoPath, event.PullRequestNumber)
+	if err != nil {
+		return fmt.Errorf("retrieving pull request: %w", err)
+	}
+	if !pr.Open {
+		return refuse("Pull request #%d is not open.", pr.Number)
+	}
+	lock := pullRequestLock{Repo: ws.Connection.Repo, PullRequestNumber: pr.Number}
+
+	// refuse to lock the workspace whilst a run from outside the pull
+	// request might yet apply changes.
+	runs, err := s.ListRuns(ctx, ListOptions{
+		WorkspaceID: &ws.ID,
+		Statuses:    internal.IncompleteRun,
+		PlanOnly:    internal.Bool(false),
+		PageOptions: resource.PageOptions{PageSize: resource.MaxPageSize},
+	})
+	if err != nil {
+		return err
+	}
+	for _, run := range runs.Items {
+		if !lock.heldBy(run) {
+			return refuse("Workspace `%s` has run %s in progress. Try again once it has finished.", name, run.ID)
+		}
+	}
+
+	holder, acquired, err := s.locks.lockForPullRequest(ctx, ws.ID, lock.Repo, lock.PullRequestNumber, user.Username)
+	if err != nil {
+		return err
+	}
+	if holder != lock {
+		return refuse("Workspace `%s` is locked by pull request #%d until it is merged or closed.", name, holder.PullRequestNumber)
+	}
+
+	if err := s.spawnPullRequestRun(ctx, logger, client, event, ws, pr, user); err != nil {
+		// don't leave the workspace locked if this command acquired the lock
+		// but failed to spawn a run.
+		if acquired {
+			if unlockErr := s.locks.unlockWorkspaceForPullRequest(ctx, ws.ID); unlockErr != nil {
+				logger.Error(unlockErr, "releasing pull request lock")
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// mapSender maps the sender of the event to the OTF user or team to which the
+// sender is mapped on the VCS provider. A team is represented as a user that is
+// solely a member of that team. Returns internal.ErrResourceNotFound if the
+// sender is not mapped.
+func (s *Spawner) mapSender(ctx context.Context, event cloud.VCSEvent) (*auth.User, error) {
+	mapping, err := s.GetUserMapping(ctx, event.VCSProviderID, event.SenderUsername)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Username != nil {
+		return s.GetUser(ctx, auth.UserSpec{Username: mapping.Username})
+	}
+	team, err := s.GetTeamByID(ctx, *mapping.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.User{Username: event.SenderUsername, Teams: []*auth.Team{team}}, nil
+}
+
+// spawnPullRequestRun spawns a run that plans and applies the head of the pull
+// request on behalf of the user.
+func (s *Spawner) spawnPullRequestRun(ctx context.Context, logger logr.Logger, client cloud.Client, event cloud.VCSEvent, ws *workspace.Workspace, pr cloud.PullRequest, user *auth.User) error {
+	tarball, _, err := client.GetRepoTarball(ctx, cloud.GetRepoTarballOptions{
+		Repo: event.RepoPath,
+		Ref:  &pr.HeadSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("retrieving repo tarball: %w", err)
+	}
+
+	// create the run on behalf of the commenter
+	ctx = internal.AddSubjectToContext(ctx, user)
+
+	cvOpts := configversion.ConfigurationVersionCreateOptions{
+		Speculative: internal.Bool(false),
+		IngressAttributes: &configversion.IngressAttributes{
+			Branch:            pr.HeadBranch,
+			CommitSHA:         pr.HeadSHA,
+			Repo:              ws.Connection.Repo,
+			IsPullRequest:     true,
+			PullRequestNumber: pr.Number,
+			PullRequestTitle:  pr.Title,
+			PullRequestURL:    pr.URL,
+			SenderUsername:    event.SenderUsername,
+			SenderAvatarURL:   event.SenderAvatarURL,
+			SenderHTMLURL:     event.SenderHTMLURL,
+		},
+	}
+	runOpts := CreateOptions{AutoApply: internal.Bool(true)}
+	cvOpts.Source, runOpts.Source = eventSources(event.Cloud)
+	cv, err := s.CreateConfigurationVersion(ctx, ws.ID, cvOpts)
+	if err != nil {
+		return err
+	}
+	if err := s.UploadConfig(ctx, cv.ID, tarball); err != nil {
+		return err
+	}
+	runOpts.ConfigurationVersionID = internal.String(cv.ID)
+	run, err := s.CreateRun(ctx, ws.ID, runOpts)
+	if err != nil {
+		return err
+	}
+	logger.Info("spawned run from apply command", "run", run.ID)
+	return nil
+}
@@ -0,0 +1,123 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/cloud"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// collapseCommentThreshold is the number of changed resources above which the
+// list of changed resources in a pull request comment is collapsed.
+const collapseCommentThreshold = 10
+
+type (
+	// commentStore persists the provider's ID for the comment a workspace has
+	// made on a pull request, so that the comment can be updated in place.
+	commentStore interface {
+		getPullRequestComment(ctx context.Context, workspaceID, repo string, pull int) (string, error)
+		setPullRequestComment(ctx context.Context, workspaceID, repo string, pull int, commentID string) error
+	}
+
+	planDiffService interface {
+		GetPlanDiff(ctx context.Context, runID string) (*PlanDiff, error)
+	}
+)
+
+// commentOnPullRequest creates or updates the workspace's comment on the pull
+// request that triggered the run.
+func (r *Reporter) commentOnPullRequest(ctx context.Context, client cloud.Client, ws *workspace.Workspace, ia *configversion.IngressAttributes, run *Run, runURL string) error {
+	var diff *PlanDiff
+	switch run.Status {
+	case internal.RunPlanned, internal.RunPlannedAndFinished, internal.RunPlannedAndSaved, internal.RunApplied:
+		var err error
+		diff, err = r.GetPlanDiff(ctx, run.ID)
+		if err != nil {
+			// the comment is still useful without the list of resources
+			r.Error(err, "retrieving plan diff for pull request comment", "run", run.ID)
+		}
+	case internal.RunPlanning, internal.RunApplying, internal.RunErrored, internal.RunCanceled, internal.RunForceCanceled, internal.RunDiscarded:
+	default:
+		// only comment on the outcome of the plan, and of the apply if
+		// there is one
+		return nil
+	}
+	body := pullRequestCommentBody(ws.Name, ia.CommitSHA, run, diff, runURL)
+
+	opts := cloud.CreatePullRequestCommentOptions{
+		Repo:        ia.Repo,
+		PullRequest: ia.PullRequestNumber,
+		Body:        body,
+	}
+	id, err := r.comments.getPullRequestComment(ctx, ws.ID, ia.Repo, ia.PullRequestNumber)
+	if err == nil {
+		err = client.UpdatePullRequestComment(ctx, cloud.UpdatePullRequestCommentOptions{
+			ID:                              id,
+			CreatePullRequestCommentOptions: opts,
+		})
+		if !errors.Is(err, internal.ErrResourceNotFound) {
+			return err
+		}
+		// comment has since been deleted, so create another
+	} else if !errors.Is(err, internal.ErrResourceNotFound) {
+		return err
+	}
+	id, err = client.CreatePullRequestComment(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return r.comments.setPullRequestComment(ctx, ws.ID, ia.Repo, ia.PullRequestNumber, id)
+}
+
+// pullRequestCommentBody renders a markdown summary of a run's plan. The diff
+// is nil if the plan has yet to finish or its changes are unavailable.
+func pullRequestCommentBody(workspaceName, commitSHA string, run *Run, diff *PlanDiff, runURL string) string {
+	var b strings.Builder
+
+	operation := "plan"
+	if !run.PlanOnly {
+		operation = "apply"
+	}
+	fmt.Fprintf(&b, "#### OTF %s for workspace `%s`\n\n", operation, workspaceName)
+	fmt.Fprintf(&b, "**Status:** %s  \n", run.Status)
+	if len(commitSHA) > 7 {
+		commitSHA = commitSHA[:7]
+	}
+	fmt.Fprintf(&b, "**Commit:** %s  \n", commitSHA)
+	if run.Plan.ResourceReport != nil {
+		fmt.Fprintf(&b, "**Plan:** %s  \n", run.Plan.ResourceReport)
+	}
+	if run.Apply.ResourceReport != nil {
+		fmt.Fprintf(&b, "**Apply:** %s  \n", run.Apply.ResourceReport)
+	}
+
+	if diff != nil {
+		var resources []*ResourceDiff
+		for _, mod := range diff.Modules {
+			resources = append(resources, mod.Resources...)
+		}
+		if len(resources) == 0 {
+			b.WriteString("\nNo changes.\n")
+		} else {
+			b.WriteString("\n<details")
+			if len(resources) <= collapseCommentThreshold {
+				b.WriteString(" open")
+			}
+			fmt.Fprintf(&b, "><summary>Changed resources (%d)</summary>\n\n", len(resources))
+			b.WriteString("| Action | Resource |\n")
+			b.WriteString("| --- | --- |\n")
+			for _, rd := range resources {
+				fmt.Fprintf(&b, "| %s | `%s` |\n", rd.Action, rd.Address)
+			}
+			b.WriteString("\n</details>\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "\n[View run in OTF](%s)\n", runURL)
+	return b.String()
+}
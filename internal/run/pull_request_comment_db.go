@@ -0,0 +1,30 @@
+package run
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+func (db *pgdb) getPullRequestComment(ctx context.Context, workspaceID, repo string, pull int) (string, error) {
+	id, err := db.Conn(ctx).FindPullRequestComment(ctx, pggen.FindPullRequestCommentParams{
+		WorkspaceID:       sql.String(workspaceID),
+		Repo:              sql.String(repo),
+		PullRequestNumber: sql.Int4(pull),
+	})
+	if err != nil {
+		return "", sql.Error(err)
+	}
+	return id.String, nil
+}
+
+func (db *pgdb) setPullRequestComment(ctx context.Context, workspaceID, repo string, pull int, commentID string) error {
+	_, err := db.Conn(ctx).UpsertPullRequestComment(ctx, pggen.UpsertPullRequestCommentParams{
+		WorkspaceID:       sql.String(workspaceID),
+		Repo:              sql.String(repo),
+		PullRequestNumber: sql.Int4(pull),
+		CommentID:         sql.String(commentID),
+	})
+	return sql.Error(err)
+}
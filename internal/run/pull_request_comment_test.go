@@ -0,0 +1,48 @@
+package run
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestCommentBody(t *testing.T) {
+	run := &Run{
+		Status: internal.RunPlannedAndFinished,
+		Plan:   Phase{ResourceReport: &Report{Additions: 1}},
+	}
+
+	t.Run("expanded", func(t *testing.T) {
+		diff := &PlanDiff{Modules: []*ModuleDiff{{Resources: []*ResourceDiff{
+			{Address: "random_pet.pet", Action: "create"},
+		}}}}
+		got := pullRequestCommentBody("dev", "abc123def456", run, diff, "https://otf-host.org/app/runs/run-123")
+
+		assert.Contains(t, got, "`dev`")
+		assert.Contains(t, got, "abc123d")
+		assert.NotContains(t, got, "abc123def456")
+		assert.Contains(t, got, "<details open>")
+		assert.Contains(t, got, "| create | `random_pet.pet` |")
+		assert.Contains(t, got, "(https://otf-host.org/app/runs/run-123)")
+	})
+
+	t.Run("collapsed", func(t *testing.T) {
+		var resources []*ResourceDiff
+		for i := 0; i < collapseCommentThreshold+1; i++ {
+			resources = append(resources, &ResourceDiff{Address: fmt.Sprintf("random_pet.pet%d", i), Action: "create"})
+		}
+		diff := &PlanDiff{Modules: []*ModuleDiff{{Resources: resources}}}
+		got := pullRequestCommentBody("dev", "abc123", run, diff, "")
+
+		assert.Contains(t, got, "<details>")
+		assert.NotContains(t, got, "<details open>")
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		got := pullRequestCommentBody("dev", "abc123", run, &PlanDiff{}, "")
+
+		assert.Contains(t, got, "No changes.")
+	})
+}
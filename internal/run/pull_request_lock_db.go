@@ -0,0 +1,60 @@
+package run
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// lockForPullRequest locks the workspace on behalf of a pull request,
+// returning the pull request holding the lock, and whether the lock was newly
+// acquired by this call.
+func (db *pgdb) lockForPullRequest(ctx context.Context, workspaceID, repo string, pull int, username string) (holder pullRequestLock, acquired bool, err error) {
+	err = db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.InsertPullRequestLock(ctx, pggen.InsertPullRequestLockParams{
+			WorkspaceID:       sql.String(workspaceID),
+			Repo:              sql.String(repo),
+			PullRequestNumber: sql.Int4(pull),
+			Username:          sql.String(username),
+		})
+		if err != nil {
+			return err
+		}
+		acquired = result.RowsAffected() == 1
+		row, err := q.FindPullRequestLockByWorkspaceID(ctx, sql.String(workspaceID))
+		if err != nil {
+			return err
+		}
+		holder = pullRequestLock{Repo: row.Repo.String, PullRequestNumber: int(row.PullRequestNumber.Int)}
+		return nil
+	})
+	if err != nil {
+		return pullRequestLock{}, false, sql.Error(err)
+	}
+	return holder, acquired, nil
+}
+
+// getPullRequestLock retrieves the pull request lock on the workspace,
+// returning internal.ErrResourceNotFound if the workspace is not locked by a
+// pull request.
+func (db *pgdb) getPullRequestLock(ctx context.Context, workspaceID string) (pullRequestLock, error) {
+	row, err := db.Conn(ctx).FindPullRequestLockByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return pullRequestLock{}, sql.Error(err)
+	}
+	return pullRequestLock{Repo: row.Repo.String, PullRequestNumber: int(row.PullRequestNumber.Int)}, nil
+}
+
+// unlockWorkspaceForPullRequest releases the pull request lock on the
+// workspace.
+func (db *pgdb) unlockWorkspaceForPullRequest(ctx context.Context, workspaceID string) error {
+	_, err := db.Conn(ctx).DeletePullRequestLockByWorkspaceID(ctx, sql.String(workspaceID))
+	return sql.Error(err)
+}
+
+// unlockPullRequest releases any workspace locks held by the pull request.
+func (db *pgdb) unlockPullRequest(ctx context.Context, repo string, pull int) error {
+	_, err := db.Conn(ctx).DeletePullRequestLocks(ctx, sql.String(repo), sql.Int4(pull))
+	return sql.Error(err)
+}
@@ -0,0 +1,142 @@
+package run
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// Priorities of queued runs in the global queue, highest priority first.
+const (
+	ApplyPriority QueuePriority = iota
+	PlanPriority
+	SpeculativePlanPriority
+)
+
+// QueuePriority is the priority of a queued run in the global queue. The lower
+// the value the higher the priority.
+type QueuePriority int
+
+// QueuePriority returns the run's priority in the global queue: applies take
+// precedence over plans, which in turn take precedence over speculative plans.
+func (r *Run) QueuePriority() QueuePriority {
+	switch {
+	case r.Status == internal.RunApplyQueued:
+		return ApplyPriority
+	case r.PlanOnly:
+		return SpeculativePlanPriority
+	default:
+		return PlanPriority
+	}
+}
+
+// queuedAt returns the time at which the run entered its current queued
+// status, falling back to its creation time if that is unknown.
+func (r *Run) queuedAt() time.Time {
+	if ts, err := r.StatusTimestamp(r.Status); err == nil {
+		return ts
+	}
+	return r.CreatedAt
+}
+
+// SortQueue sorts queued runs into the order in which they are to be executed.
+// Runs are ordered firstly by priority. Runs of equal priority are shared
+// fairly between organizations, taking turns in round-robin fashion, so that
+// an organization with many queued runs cannot starve another organization
+// with only a few. Otherwise runs are ordered by the time they were queued,
+// and failing that, their order in the given slice.
+func SortQueue(runs []*Run) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		if pi, pj := runs[i].QueuePriority(), runs[j].QueuePriority(); pi != pj {
+			return pi < pj
+		}
+		return runs[i].queuedAt().Before(runs[j].queuedAt())
+	})
+	// rank each run according to its position amongst runs of the same
+	// priority belonging to the same organization.
+	type key struct {
+		organization string
+		priority     QueuePriority
+	}
+	var (
+		ranks  = make(map[*Run]int, len(runs))
+		counts = make(map[key]int)
+	)
+	for _, r := range runs {
+		k := key{r.Organization, r.QueuePriority()}
+		ranks[r] = counts[k]
+		counts[k]++
+	}
+	// interleave organizations' runs within each priority, relying on the
+	// stable sort to retain the time-queued ordering from above.
+	sort.SliceStable(runs, func(i, j int) bool {
+		if pi, pj := runs[i].QueuePriority(), runs[j].QueuePriority(); pi != pj {
+			return pi < pj
+		}
+		return ranks[runs[i]] < ranks[runs[j]]
+	})
+}
+
+// queueOf returns the name of the queue to which a run belongs. Runs with the
+// remote execution mode share a single global queue, serviced by the agent
+// embedded in otfd, whereas runs with the agent execution mode are queued
+// separately for each organization, serviced by that organization's agents.
+func queueOf(r *Run) string {
+	if r.ExecutionMode == workspace.AgentExecutionMode {
+		return r.Organization
+	}
+	return ""
+}
+
+// organizationQueue sorts queued runs and returns those belonging to the
+// organization, setting the position of each in its queue.
+func organizationQueue(queued []*Run, organization string) []*Run {
+	SortQueue(queued)
+
+	var (
+		positions = make(map[string]int)
+		runs      []*Run
+	)
+	for _, r := range queued {
+		q := queueOf(r)
+		if r.Organization == organization {
+			r.PositionInQueue = positions[q]
+			runs = append(runs, r)
+		}
+		positions[q]++
+	}
+	return runs
+}
+
+// ListQueue lists an organization's queued runs in the order in which they are
+// to be executed, setting each run's position in the queue. A run's position
+// is relative to the runs of other organizations too, and position 0 is the
+// next run to be executed.
+func (s *service) ListQueue(ctx context.Context, organization string, opts resource.PageOptions) (*resource.Page[*Run], error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListRunsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve queued runs for all organizations in order to determine their
+	// relative positions
+	queued, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Run], error) {
+		return s.db.ListRuns(ctx, ListOptions{
+			PageOptions: opts,
+			Statuses:    []internal.RunStatus{internal.RunPlanQueued, internal.RunApplyQueued},
+		})
+	})
+	if err != nil {
+		s.Error(err, "listing run queue", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	runs := organizationQueue(queued, organization)
+	s.V(9).Info("listed run queue", "organization", organization, "count", len(runs), "subject", subject)
+
+	return resource.NewPage(runs, opts, nil), nil
+}
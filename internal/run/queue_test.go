@@ -0,0 +1,89 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortQueue(t *testing.T) {
+	now := time.Now()
+	queued := func(id, org string, status internal.RunStatus, planOnly bool, age time.Duration) *Run {
+		return &Run{
+			ID:           id,
+			Organization: org,
+			Status:       status,
+			PlanOnly:     planOnly,
+			StatusTimestamps: []StatusTimestamp{
+				{Status: status, Timestamp: now.Add(-age)},
+			},
+		}
+	}
+	ids := func(runs []*Run) (ids []string) {
+		for _, r := range runs {
+			ids = append(ids, r.ID)
+		}
+		return
+	}
+
+	t.Run("priority", func(t *testing.T) {
+		runs := []*Run{
+			queued("speculative", "acme", internal.RunPlanQueued, true, 3*time.Minute),
+			queued("plan", "acme", internal.RunPlanQueued, false, 2*time.Minute),
+			queued("apply", "acme", internal.RunApplyQueued, false, time.Minute),
+		}
+		SortQueue(runs)
+		assert.Equal(t, []string{"apply", "plan", "speculative"}, ids(runs))
+	})
+
+	t.Run("oldest first", func(t *testing.T) {
+		runs := []*Run{
+			queued("newer", "acme", internal.RunPlanQueued, false, time.Minute),
+			queued("older", "acme", internal.RunPlanQueued, false, 2*time.Minute),
+		}
+		SortQueue(runs)
+		assert.Equal(t, []string{"older", "newer"}, ids(runs))
+	})
+
+	t.Run("fair share between organizations", func(t *testing.T) {
+		runs := []*Run{
+			queued("acme-1", "acme", internal.RunPlanQueued, true, 10*time.Minute),
+			queued("acme-2", "acme", internal.RunPlanQueued, true, 9*time.Minute),
+			queued("acme-3", "acme", internal.RunPlanQueued, true, 8*time.Minute),
+			queued("initech-1", "initech", internal.RunPlanQueued, true, 2*time.Minute),
+			queued("initech-2", "initech", internal.RunPlanQueued, true, time.Minute),
+			queued("globex-apply", "globex", internal.RunApplyQueued, false, 0),
+		}
+		SortQueue(runs)
+		assert.Equal(t, []string{
+			"globex-apply",
+			"acme-1",
+			"initech-1",
+			"acme-2",
+			"initech-2",
+			"acme-3",
+		}, ids(runs))
+	})
+}
+
+func TestOrganizationQueue(t *testing.T) {
+	queued := []*Run{
+		{ID: "acme-remote", Organization: "acme", Status: internal.RunPlanQueued, ExecutionMode: workspace.RemoteExecutionMode},
+		{ID: "initech-remote", Organization: "initech", Status: internal.RunApplyQueued, ExecutionMode: workspace.RemoteExecutionMode},
+		{ID: "acme-agent", Organization: "acme", Status: internal.RunPlanQueued, ExecutionMode: workspace.AgentExecutionMode},
+	}
+
+	got := organizationQueue(queued, "acme")
+
+	if assert.Len(t, got, 2) {
+		// behind initech's apply in the shared queue for remote runs
+		assert.Equal(t, "acme-remote", got[0].ID)
+		assert.Equal(t, 1, got[0].PositionInQueue)
+		// agent runs are queued separately for each organization
+		assert.Equal(t, "acme-agent", got[1].ID)
+		assert.Equal(t, 0, got[1].PositionInQueue)
+	}
+}
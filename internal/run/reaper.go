@@ -0,0 +1,175 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+// ReaperLockID is a unique ID guaranteeing only one reaper on a cluster is running at any time.
+const ReaperLockID int64 = 179366396344335599
+
+const (
+	// DefaultReaperGrace is the default period beyond a phase's timeout after
+	// which the reaper errors the run.
+	DefaultReaperGrace = 5 * time.Minute
+	// DefaultReaperInterval is the default interval between checks for
+	// stuck runs.
+	DefaultReaperInterval = time.Minute
+)
+
+type (
+	// Reaper errors runs that are stuck in the planning or applying state,
+	// which is possible if the agent executing the run terminated unexpectedly
+	// or otherwise failed to enforce the phase's timeout. Left alone, such a
+	// run would block its workspace's queue indefinitely.
+	//
+	// The reaper also discards runs that have been awaiting confirmation for
+	// longer than their workspace's auto-discard TTL.
+	Reaper struct {
+		logr.Logger
+		RunService
+		WorkspaceService
+		Logs chunkService
+
+		// Site-wide maximum durations of plan and apply phases, unless
+		// overridden by the workspace.
+		Timeouts PhaseTimeouts
+		// Grace is the period beyond the timeout afforded to the agent to
+		// terminate the phase itself.
+		Grace time.Duration
+		// Interval between checks for stuck and stale runs.
+		Interval time.Duration
+	}
+
+	chunkService interface {
+		GetChunk(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error)
+		internal.PutChunkService
+	}
+)
+
+// Start starts the reaper daemon. Should be invoked in a go routine.
+func (r *Reaper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.reap(ctx, time.Now()); err != nil {
+			r.Error(err, "reaping stuck runs")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reap errors those runs whose current phase has exceeded its timeout plus
+// grace period as of now, and discards those runs that have been awaiting
+// confirmation for longer than their workspace's auto-discard TTL.
+func (r *Reaper) reap(ctx context.Context, now time.Time) error {
+	runs, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Run], error) {
+		return r.ListRuns(ctx, ListOptions{
+			PageOptions: opts,
+			Statuses: []internal.RunStatus{
+				internal.RunPlanning,
+				internal.RunApplying,
+				internal.RunPlanned,
+				internal.RunCostEstimated,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		switch run.Status {
+		case internal.RunPlanning, internal.RunApplying:
+			err = r.reapRun(ctx, run, now)
+		default:
+			err = r.discardStaleRun(ctx, run, now)
+		}
+		if err != nil {
+			// the run may have since moved on, so log the error and carry on
+			// with other runs.
+			r.Error(err, "reaping run", "run", run.ID)
+		}
+	}
+	return nil
+}
+
+func (r *Reaper) reapRun(ctx context.Context, run *Run, now time.Time) error {
+	started, err := run.StatusTimestamp(run.Status)
+	if err != nil {
+		return err
+	}
+	ws, err := r.GetWorkspace(ctx, run.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	phase := run.Phase()
+	timeout := r.Timeouts.forWorkspace(ws, phase)
+	if now.Before(started.Add(timeout + r.Grace)) {
+		return nil
+	}
+	msg := fmt.Sprintf("\nError: %s exceeded timeout of %s and has been terminated. The agent executing the run may have stopped unexpectedly.\n", phase, timeout)
+	if err := r.writeLogs(ctx, run.ID, phase, msg); err != nil {
+		return fmt.Errorf("writing logs: %w", err)
+	}
+	if _, err := r.FinishPhase(ctx, run.ID, phase, PhaseFinishOptions{Errored: true}); err != nil {
+		return err
+	}
+	r.Info("errored run that exceeded phase timeout", "run", run.ID, "phase", phase, "timeout", timeout)
+	return nil
+}
+
+// discardStaleRun discards a run awaiting confirmation if it has been waiting
+// for longer than its workspace's auto-discard TTL as of now.
+func (r *Reaper) discardStaleRun(ctx context.Context, run *Run, now time.Time) error {
+	since, err := run.StatusTimestamp(run.Status)
+	if err != nil {
+		return err
+	}
+	ws, err := r.GetWorkspace(ctx, run.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if ws.AutoDiscardTTL == 0 || now.Before(since.Add(ws.AutoDiscardTTL)) {
+		return nil
+	}
+	reason := fmt.Sprintf("not confirmed within %s", ws.AutoDiscardTTL)
+	if err := r.discardRun(ctx, run.ID, reason); err != nil {
+		return err
+	}
+	r.Info("discarded stale run", "run", run.ID, "ttl", ws.AutoDiscardTTL)
+	return nil
+}
+
+// writeLogs appends the message to the phase's logs and marks the logs as
+// complete. Nothing is written if the logs are already complete.
+func (r *Reaper) writeLogs(ctx context.Context, runID string, phase internal.PhaseType, msg string) error {
+	chunk, err := r.Logs.GetChunk(ctx, internal.GetChunkOptions{RunID: runID, Phase: phase})
+	if err != nil {
+		return err
+	}
+	if chunk.IsEnd() {
+		return nil
+	}
+	var data []byte
+	if chunk.NextOffset() == 0 {
+		data = append(data, internal.STX)
+	}
+	data = append(data, msg...)
+	data = append(data, internal.ETX)
+	return r.Logs.PutChunk(ctx, internal.PutChunkOptions{
+		RunID:  runID,
+		Phase:  phase,
+		Offset: chunk.NextOffset(),
+		Data:   data,
+	})
+}
@@ -0,0 +1,191 @@
+package run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaper(t *testing.T) {
+	ctx := context.Background()
+	started := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	planning := &Run{
+		ID:     "run-123",
+		Status: internal.RunPlanning,
+		StatusTimestamps: []StatusTimestamp{
+			{Status: internal.RunPlanning, Timestamp: started},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		ws        *workspace.Workspace
+		logs      []byte
+		now       time.Time
+		wantReap  bool
+		wantChunk *internal.PutChunkOptions
+	}{
+		{
+			name: "within timeout",
+			ws:   &workspace.Workspace{},
+			now:  started.Add(time.Hour),
+		},
+		{
+			name: "within grace period",
+			ws:   &workspace.Workspace{},
+			now:  started.Add(2*time.Hour + time.Minute),
+		},
+		{
+			name:     "exceeded timeout",
+			ws:       &workspace.Workspace{},
+			logs:     []byte{internal.STX, 'o', 'k'},
+			now:      started.Add(3 * time.Hour),
+			wantReap: true,
+			wantChunk: &internal.PutChunkOptions{
+				RunID:  "run-123",
+				Phase:  internal.PlanPhase,
+				Offset: 3,
+				Data:   append([]byte("\nError: plan exceeded timeout of 2h0m0s and has been terminated. The agent executing the run may have stopped unexpectedly.\n"), internal.ETX),
+			},
+		},
+		{
+			name:     "exceeded workspace timeout",
+			ws:       &workspace.Workspace{PlanTimeout: 10 * time.Minute},
+			now:      started.Add(time.Hour),
+			wantReap: true,
+			wantChunk: &internal.PutChunkOptions{
+				RunID:  "run-123",
+				Phase:  internal.PlanPhase,
+				Offset: 0,
+				Data:   append([]byte("\x02\nError: plan exceeded timeout of 10m0s and has been terminated. The agent executing the run may have stopped unexpectedly.\n"), internal.ETX),
+			},
+		},
+		{
+			name:     "exceeded timeout with completed logs",
+			ws:       &workspace.Workspace{},
+			logs:     []byte{internal.STX, 'o', 'k', internal.ETX},
+			now:      started.Add(3 * time.Hour),
+			wantReap: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := &fakeReaperRunService{runs: []*Run{planning}}
+			logs := &fakeReaperChunkService{data: tt.logs}
+			reaper := &Reaper{
+				Logger:           logr.Discard(),
+				RunService:       runs,
+				WorkspaceService: &fakeReporterWorkspaceService{ws: tt.ws},
+				Logs:             logs,
+				Timeouts:         PhaseTimeouts{Plan: 2 * time.Hour, Apply: 24 * time.Hour},
+				Grace:            5 * time.Minute,
+			}
+			require.NoError(t, reaper.reap(ctx, tt.now))
+
+			if tt.wantReap {
+				assert.Equal(t, []string{"run-123"}, runs.errored)
+			} else {
+				assert.Empty(t, runs.errored)
+			}
+			assert.Equal(t, tt.wantChunk, logs.got)
+		})
+	}
+}
+
+func TestReaper_DiscardStaleRuns(t *testing.T) {
+	ctx := context.Background()
+	planned := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := &Run{
+		ID:     "run-123",
+		Status: internal.RunPlanned,
+		StatusTimestamps: []StatusTimestamp{
+			{Status: internal.RunPlanned, Timestamp: planned},
+		},
+	}
+
+	tests := []struct {
+		name string
+		ws   *workspace.Workspace
+		now  time.Time
+		want map[string]string
+	}{
+		{
+			name: "auto-discard disabled",
+			ws:   &workspace.Workspace{},
+			now:  planned.Add(30 * 24 * time.Hour),
+		},
+		{
+			name: "within ttl",
+			ws:   &workspace.Workspace{AutoDiscardTTL: time.Hour},
+			now:  planned.Add(59 * time.Minute),
+		},
+		{
+			name: "exceeded ttl",
+			ws:   &workspace.Workspace{AutoDiscardTTL: time.Hour},
+			now:  planned.Add(time.Hour),
+			want: map[string]string{"run-123": "not confirmed within 1h0m0s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := &fakeReaperRunService{runs: []*Run{run}}
+			reaper := &Reaper{
+				Logger:           logr.Discard(),
+				RunService:       runs,
+				WorkspaceService: &fakeReporterWorkspaceService{ws: tt.ws},
+			}
+			require.NoError(t, reaper.reap(ctx, tt.now))
+
+			assert.Equal(t, tt.want, runs.discarded)
+			assert.Empty(t, runs.errored)
+		})
+	}
+}
+
+type fakeReaperRunService struct {
+	Service
+
+	runs      []*Run
+	errored   []string
+	discarded map[string]string
+}
+
+func (f *fakeReaperRunService) ListRuns(_ context.Context, opts ListOptions) (*resource.Page[*Run], error) {
+	return resource.NewPage(f.runs, opts.PageOptions, nil), nil
+}
+
+func (f *fakeReaperRunService) FinishPhase(_ context.Context, runID string, _ internal.PhaseType, opts PhaseFinishOptions) (*Run, error) {
+	if opts.Errored {
+		f.errored = append(f.errored, runID)
+	}
+	return nil, nil
+}
+
+func (f *fakeReaperRunService) discardRun(_ context.Context, runID, reason string) error {
+	if f.discarded == nil {
+		f.discarded = make(map[string]string)
+	}
+	f.discarded[runID] = reason
+	return nil
+}
+
+type fakeReaperChunkService struct {
+	data []byte
+	got  *internal.PutChunkOptions
+}
+
+func (f *fakeReaperChunkService) GetChunk(_ context.Context, opts internal.GetChunkOptions) (internal.Chunk, error) {
+	return internal.Chunk{RunID: opts.RunID, Phase: opts.Phase, Data: f.data}, nil
+}
+
+func (f *fakeReaperChunkService) PutChunk(_ context.Context, opts internal.PutChunkOptions) error {
+	f.got = &opts
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/workspace"
 )
 
@@ -27,17 +28,37 @@ type (
 		ConfigurationVersionService
 		WorkspaceService
 		internal.HostnameService
+		planDiffService
+
+		comments commentStore
 	}
 
 	ReporterOptions struct {
 		ConfigurationVersionService configversion.Service
 		WorkspaceService            workspace.Service
 		VCSProviderService          VCSProviderService
+		RunService                  Service
+		HostnameService             internal.HostnameService
+		Subscriber                  pubsub.Subscriber
+		DB                          *sql.DB
 
 		logr.Logger
 	}
 )
 
+func NewReporter(opts ReporterOptions) *Reporter {
+	return &Reporter{
+		Logger:                      opts.Logger,
+		Subscriber:                  opts.Subscriber,
+		VCSProviderService:          opts.VCSProviderService,
+		ConfigurationVersionService: opts.ConfigurationVersionService,
+		WorkspaceService:            opts.WorkspaceService,
+		HostnameService:             opts.HostnameService,
+		planDiffService:             opts.RunService,
+		comments:                    &pgdb{opts.DB},
+	}
+}
+
 // Start starts the reporter daemon. Should be invoked in a go routine.
 func (r *Reporter) Start(ctx context.Context) error {
 	// Unsubscribe whenever exiting this routine.
@@ -88,9 +109,10 @@ func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
 		description string
 	)
 	switch run.Status {
-	case internal.RunPending, internal.RunPlanQueued, internal.RunApplyQueued:
+	case internal.RunPending, internal.RunPlanQueued, internal.RunApplyQueued, internal.RunPrePlanRunning, internal.RunPrePlanCompleted:
 		status = cloud.VCSPendingStatus
-	case internal.RunPlanning, internal.RunApplying, internal.RunPlanned, internal.RunConfirmed:
+	case internal.RunPlanning, internal.RunApplying, internal.RunPlanned, internal.RunPlannedAndSaved, internal.RunConfirmed,
+		internal.RunPostPlanRunning, internal.RunPostPlanCompleted, internal.RunPreApplyRunning, internal.RunPreApplyCompleted:
 		status = cloud.VCSRunningStatus
 	case internal.RunPlannedAndFinished:
 		status = cloud.VCSSuccessStatus
@@ -122,16 +144,28 @@ func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
 		return err
 	}
 
-	return client.SetStatus(ctx, cloud.SetStatusOptions{
+	runURL := (&url.URL{
+		Scheme: "https",
+		Host:   r.Hostname(),
+		Path:   paths.Run(run.ID),
+	}).String()
+
+	err = client.SetStatus(ctx, cloud.SetStatusOptions{
 		Workspace:   ws.Name,
 		Ref:         cv.IngressAttributes.CommitSHA,
 		Repo:        cv.IngressAttributes.Repo,
 		Status:      status,
 		Description: description,
-		TargetURL: (&url.URL{
-			Scheme: "https",
-			Host:   r.Hostname(),
-			Path:   paths.Run(run.ID),
-		}).String(),
+		TargetURL:   runURL,
 	})
+	if err != nil {
+		return err
+	}
+
+	// the outcome of applying a pull request is always reported on the pull
+	// request, because the apply was requested from a pull request comment.
+	if cv.IngressAttributes.IsPullRequest && (ws.Connection.PRComments || !run.PlanOnly) {
+		return r.commentOnPullRequest(ctx, client, ws, cv.IngressAttributes, run, runURL)
+	}
+	return nil
 }
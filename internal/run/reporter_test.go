@@ -75,6 +75,92 @@ func TestReporter_HandleRun(t *testing.T) {
 	}
 }
 
+func TestReporter_PullRequestComment(t *testing.T) {
+	ctx := context.Background()
+
+	ws := &workspace.Workspace{
+		ID:         "ws-123",
+		Name:       "dev",
+		Connection: &workspace.Connection{PRComments: true},
+	}
+	cv := &configversion.ConfigurationVersion{
+		IngressAttributes: &configversion.IngressAttributes{
+			CommitSHA:         "abc123",
+			Repo:              "leg100/otf",
+			IsPullRequest:     true,
+			PullRequestNumber: 7,
+		},
+	}
+	run := &Run{ID: "run-123", Status: internal.RunPlannedAndFinished}
+
+	newReporter := func(client *fakeReporterCloudClient, comments *fakeCommentStore) *Reporter {
+		return &Reporter{
+			WorkspaceService:            &fakeReporterWorkspaceService{ws: ws},
+			ConfigurationVersionService: &fakeReporterConfigurationVersionService{cv: cv},
+			VCSProviderService:          &fakeReporterVCSProviderService{client: client},
+			HostnameService:             internal.FakeHostnameService{Host: "otf-host.org"},
+			planDiffService:             &fakePlanDiffService{},
+			comments:                    comments,
+		}
+	}
+
+	t.Run("create comment", func(t *testing.T) {
+		client := &fakeReporterCloudClient{}
+		comments := &fakeCommentStore{}
+		err := newReporter(client, comments).handleRun(ctx, run)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(client.created))
+		assert.Equal(t, "leg100/otf", client.created[0].Repo)
+		assert.Equal(t, 7, client.created[0].PullRequest)
+		assert.Equal(t, "comment-1", comments.id)
+	})
+
+	t.Run("update comment", func(t *testing.T) {
+		client := &fakeReporterCloudClient{}
+		comments := &fakeCommentStore{id: "comment-99"}
+		err := newReporter(client, comments).handleRun(ctx, run)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(client.created))
+		require.Equal(t, 1, len(client.updated))
+		assert.Equal(t, "comment-99", client.updated[0].ID)
+	})
+
+	t.Run("replace deleted comment", func(t *testing.T) {
+		client := &fakeReporterCloudClient{updateErr: internal.ErrResourceNotFound}
+		comments := &fakeCommentStore{id: "comment-99"}
+		err := newReporter(client, comments).handleRun(ctx, run)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(client.created))
+		assert.Equal(t, "comment-1", comments.id)
+	})
+
+	t.Run("skip queued run", func(t *testing.T) {
+		client := &fakeReporterCloudClient{}
+		comments := &fakeCommentStore{}
+		err := newReporter(client, comments).handleRun(ctx, &Run{ID: "run-123", Status: internal.RunPlanQueued})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(client.created))
+		assert.Equal(t, 0, len(client.updated))
+	})
+
+	t.Run("report apply even with comments disabled", func(t *testing.T) {
+		ws.Connection.PRComments = false
+		t.Cleanup(func() { ws.Connection.PRComments = true })
+
+		client := &fakeReporterCloudClient{}
+		comments := &fakeCommentStore{}
+		err := newReporter(client, comments).handleRun(ctx, &Run{ID: "run-123", Status: internal.RunApplied})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(client.created))
+		assert.Contains(t, client.created[0].Body, "OTF apply")
+	})
+}
+
 type fakeReporterConfigurationVersionService struct {
 	configversion.Service
 
@@ -98,20 +184,66 @@ func (f *fakeReporterWorkspaceService) GetWorkspace(context.Context, string) (*w
 type fakeReporterVCSProviderService struct {
 	vcsprovider.VCSProviderService
 
-	got *cloud.SetStatusOptions
+	got    *cloud.SetStatusOptions
+	client *fakeReporterCloudClient
 }
 
 func (f *fakeReporterVCSProviderService) GetVCSClient(context.Context, string) (cloud.Client, error) {
+	if f.client != nil {
+		return f.client, nil
+	}
 	return &fakeReporterCloudClient{got: f.got}, nil
 }
 
 type fakeReporterCloudClient struct {
 	cloud.Client
 
-	got *cloud.SetStatusOptions
+	got       *cloud.SetStatusOptions
+	created   []cloud.CreatePullRequestCommentOptions
+	updated   []cloud.UpdatePullRequestCommentOptions
+	updateErr error
 }
 
 func (f *fakeReporterCloudClient) SetStatus(ctx context.Context, opts cloud.SetStatusOptions) error {
-	*f.got = opts
+	if f.got != nil {
+		*f.got = opts
+	}
+	return nil
+}
+
+func (f *fakeReporterCloudClient) CreatePullRequestComment(ctx context.Context, opts cloud.CreatePullRequestCommentOptions) (string, error) {
+	f.created = append(f.created, opts)
+	return "comment-1", nil
+}
+
+func (f *fakeReporterCloudClient) UpdatePullRequestComment(ctx context.Context, opts cloud.UpdatePullRequestCommentOptions) error {
+	f.updated = append(f.updated, opts)
+	return f.updateErr
+}
+
+type fakeCommentStore struct {
+	id string
+}
+
+func (f *fakeCommentStore) getPullRequestComment(context.Context, string, string, int) (string, error) {
+	if f.id == "" {
+		return "", internal.ErrResourceNotFound
+	}
+	return f.id, nil
+}
+
+func (f *fakeCommentStore) setPullRequestComment(ctx context.Context, workspaceID, repo string, pull int, commentID string) error {
+	f.id = commentID
 	return nil
 }
+
+type fakePlanDiffService struct {
+	diff *PlanDiff
+}
+
+func (f *fakePlanDiffService) GetPlanDiff(context.Context, string) (*PlanDiff, error) {
+	if f.diff == nil {
+		return &PlanDiff{}, nil
+	}
+	return f.diff, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/workspace"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -25,6 +26,10 @@ const (
 	PlanAndApplyOperation Operation = "plan-and-apply"
 	DestroyAllOperation   Operation = "destroy-all"
 
+	PrePlanTaskStage  TaskStage = "pre_plan"
+	PostPlanTaskStage TaskStage = "post_plan"
+	PreApplyTaskStage TaskStage = "pre_apply"
+
 	// defaultRefresh specifies that the state be refreshed prior to running a
 	// plan
 	defaultRefresh = true
@@ -38,6 +43,9 @@ type (
 	// Run operation specifies the terraform execution mode.
 	Operation string
 
+	// TaskStage is a stage in a run at which run tasks are invoked.
+	TaskStage string
+
 	// Run is a terraform run.
 	Run struct {
 		ID                     string                  `json:"id"`
@@ -78,6 +86,37 @@ type (
 		// a run to enter the RunCostEstimated state, and this boolean
 		// determines whether to enter that state upon finishing a plan.
 		CostEstimationEnabled bool
+
+		// TaskStages are the stages at which run tasks are attached to the
+		// run's workspace. The run pauses at each of these stages until the
+		// results of the tasks are received.
+		TaskStages []TaskStage
+
+		// ApprovalsRequired is the number of approvals the run's workspace
+		// requires before a run can be applied.
+		ApprovalsRequired int
+		// Approvals are the decisions made by approvers on the run, in the
+		// order in which they were made.
+		Approvals []*Approval
+
+		// QueuedByLimit is true if the run is being held back from the queue
+		// because its organization has reached its limit of concurrent runs.
+		QueuedByLimit bool `json:"queued_by_limit"`
+
+		// SavePlan is true if the run's plan is saved to be applied later,
+		// as created by terraform plan -out in cloud mode. A saved plan does
+		// not occupy its workspace's queue until it is confirmed.
+		SavePlan bool `json:"save_plan"`
+
+		// Test is true if the run executes the configuration's tests with
+		// terraform test instead of planning changes. A test run is always
+		// plan-only.
+		Test bool `json:"test"`
+
+		// PhaseTimeout is the maximum duration of the phase, resolved by
+		// otfd when the phase is started. It is only populated on the run
+		// returned upon starting a phase.
+		PhaseTimeout time.Duration `json:"-"`
 	}
 
 	// List represents a list of runs.
@@ -94,6 +133,9 @@ type (
 	StatusTimestamp struct {
 		Status    internal.RunStatus
 		Timestamp time.Time
+		// Reason optionally explains why the run transitioned to the status,
+		// e.g. why it was discarded.
+		Reason string
 	}
 
 	// CreateOptions represents the options for creating a new run. See
@@ -116,7 +158,13 @@ type (
 		// PlanOnly specifies if this is a speculative, plan-only run that
 		// Terraform cannot apply. Takes precedence over whether the
 		// configuration version is marked as speculative or not.
-		PlanOnly  *bool
+		PlanOnly *bool
+		// SavePlan specifies whether the plan is to be saved and applied
+		// later rather than applied as part of the run.
+		SavePlan *bool
+		// Test specifies whether the run executes the configuration's tests
+		// rather than planning changes. Takes precedence over SavePlan.
+		Test      *bool
 		Variables []Variable
 	}
 
@@ -199,6 +247,19 @@ func newRun(ctx context.Context, org *organization.Organization, cv *configversi
 	if opts.PlanOnly != nil {
 		run.PlanOnly = *opts.PlanOnly
 	}
+	if opts.SavePlan != nil && *opts.SavePlan {
+		// a saved plan is intended to be applied and therefore cannot be a
+		// speculative plan.
+		run.SavePlan = true
+		run.PlanOnly = false
+	}
+	if opts.Test != nil && *opts.Test {
+		// tests create and destroy their own infrastructure and never alter
+		// the workspace's state, so there is nothing to apply.
+		run.Test = true
+		run.PlanOnly = true
+		run.SavePlan = false
+	}
 	return &run
 }
 
@@ -206,6 +267,20 @@ func (r *Run) Queued() bool {
 	return r.Status == internal.RunPlanQueued || r.Status == internal.RunApplyQueued
 }
 
+// UsesWorkspaceQueue determines whether the run must take its turn in its
+// workspace's queue. Speculative runs never do, and nor do saved plans until
+// they are confirmed, because until then they cannot alter state.
+func (r *Run) UsesWorkspaceQueue() bool {
+	if r.PlanOnly {
+		return false
+	}
+	if r.SavePlan {
+		_, err := r.StatusTimestamp(internal.RunConfirmed)
+		return err == nil
+	}
+	return true
+}
+
 func (r *Run) HasChanges() bool {
 	return r.Plan.HasChanges()
 }
@@ -219,11 +294,11 @@ func (r *Run) HasApply() bool {
 // Phase returns the current phase.
 func (r *Run) Phase() internal.PhaseType {
 	switch r.Status {
-	case internal.RunPending:
+	case internal.RunPending, internal.RunPrePlanRunning, internal.RunPrePlanCompleted:
 		return internal.PendingPhase
-	case internal.RunPlanQueued, internal.RunPlanning, internal.RunPlanned:
+	case internal.RunPlanQueued, internal.RunPlanning, internal.RunPlanned, internal.RunPlannedAndSaved, internal.RunPostPlanRunning, internal.RunPostPlanCompleted:
 		return internal.PlanPhase
-	case internal.RunApplyQueued, internal.RunApplying, internal.RunApplied:
+	case internal.RunConfirmed, internal.RunPreApplyRunning, internal.RunPreApplyCompleted, internal.RunApplyQueued, internal.RunApplying, internal.RunApplied:
 		return internal.ApplyPhase
 	default:
 		return internal.UnknownPhase
@@ -257,12 +332,16 @@ func (r *Run) Cancel() error {
 	r.ForceCancelAvailableAt = &tenSecondsFromNow
 
 	switch r.Status {
-	case internal.RunPending:
+	case internal.RunPending, internal.RunPrePlanRunning:
 		r.Plan.UpdateStatus(PhaseUnreachable)
 		r.Apply.UpdateStatus(PhaseUnreachable)
 	case internal.RunPlanQueued, internal.RunPlanning:
 		r.Plan.UpdateStatus(PhaseCanceled)
 		r.Apply.UpdateStatus(PhaseUnreachable)
+	case internal.RunPostPlanRunning, internal.RunPreApplyRunning:
+		r.Apply.UpdateStatus(PhaseUnreachable)
+	case internal.RunConfirmed:
+		r.Apply.UpdateStatus(PhaseUnreachable)
 	case internal.RunApplyQueued, internal.RunApplying:
 		r.Apply.UpdateStatus(PhaseCanceled)
 	}
@@ -294,11 +373,17 @@ func (r *Run) Done() bool {
 }
 
 // EnqueuePlan enqueues a plan for the run. It also sets the run as the latest
-// run for its workspace (speculative runs are ignored).
+// run for its workspace (speculative runs are ignored). If the run has
+// pre-plan run tasks then the plan is instead enqueued once the tasks have
+// completed.
 func (r *Run) EnqueuePlan() error {
 	if r.Status != internal.RunPending {
 		return fmt.Errorf("cannot enqueue run with status %s", r.Status)
 	}
+	if r.hasTaskStage(PrePlanTaskStage) {
+		r.updateStatus(internal.RunPrePlanRunning)
+		return nil
+	}
 	r.updateStatus(internal.RunPlanQueued)
 	r.Plan.UpdateStatus(PhaseQueued)
 
@@ -322,11 +407,27 @@ func (r *Run) CanAccessWorkspace(action rbac.Action, policy *internal.WorkspaceP
 
 func (r *Run) EnqueueApply() error {
 	switch r.Status {
-	case internal.RunPlanned, internal.RunCostEstimated:
+	case internal.RunPlanned, internal.RunCostEstimated, internal.RunPlannedAndSaved, internal.RunConfirmed:
 		// applyable statuses
 	default:
 		return fmt.Errorf("cannot apply run with status %s", r.Status)
 	}
+	if r.Rejected() {
+		return internal.ErrRunRejected
+	}
+	if !r.Approved() {
+		return internal.ErrRunApprovalRequired
+	}
+	if r.Status == internal.RunPlannedAndSaved {
+		// a saved plan must first wait its turn in the workspace queue,
+		// which then enqueues the apply.
+		r.updateStatus(internal.RunConfirmed)
+		return nil
+	}
+	if r.hasTaskStage(PreApplyTaskStage) {
+		r.updateStatus(internal.RunPreApplyRunning)
+		return nil
+	}
 	r.updateStatus(internal.RunApplyQueued)
 	r.Apply.UpdateStatus(PhaseQueued)
 	return nil
@@ -341,6 +442,15 @@ func (r *Run) StatusTimestamp(status internal.RunStatus) (time.Time, error) {
 	return time.Time{}, internal.ErrStatusTimestampNotFound
 }
 
+// StatusReason returns the reason the run transitioned to its current status,
+// or an empty string if no reason was recorded.
+func (r *Run) StatusReason() string {
+	if n := len(r.StatusTimestamps); n > 0 && r.StatusTimestamps[n-1].Status == r.Status {
+		return r.StatusTimestamps[n-1].Reason
+	}
+	return ""
+}
+
 // Start a run phase
 func (r *Run) Start(phase internal.PhaseType) error {
 	switch r.Status {
@@ -375,23 +485,12 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) error {
 			r.Apply.UpdateStatus(PhaseUnreachable)
 			return nil
 		}
-		// Enter RunCostEstimated state if cost estimation is enabled. OTF does
-		// not support cost estimation but enter this state only in order to
-		// satisfy the go-tfe tests.
-		if r.CostEstimationEnabled {
-			r.updateStatus(internal.RunCostEstimated)
-		} else {
-			r.updateStatus(internal.RunPlanned)
-		}
 		r.Plan.UpdateStatus(PhaseFinished)
-
-		if !r.HasChanges() || r.PlanOnly {
-			r.updateStatus(internal.RunPlannedAndFinished)
-			r.Apply.UpdateStatus(PhaseUnreachable)
-		} else if r.AutoApply {
-			return r.EnqueueApply()
+		if r.hasTaskStage(PostPlanTaskStage) {
+			r.updateStatus(internal.RunPostPlanRunning)
+			return nil
 		}
-		return nil
+		return r.afterPlan()
 	case internal.ApplyPhase:
 		if r.Status != internal.RunApplying {
 			return ErrInvalidRunStateTransition
@@ -409,18 +508,201 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) error {
 	}
 }
 
+// FinishTaskStage updates the run to reflect the run tasks for the given stage
+// having completed. If passed is false then a mandatory task has failed and the
+// run is errored.
+func (r *Run) FinishTaskStage(stage TaskStage, passed bool) error {
+	if r.Status == internal.RunCanceled {
+		// run was canceled before the tasks completed so nothing more to do.
+		return nil
+	}
+	switch stage {
+	case PrePlanTaskStage:
+		if r.Status != internal.RunPrePlanRunning {
+			return ErrInvalidRunStateTransition
+		}
+		r.updateStatus(internal.RunPrePlanCompleted)
+		if !passed {
+			r.updateStatus(internal.RunErrored)
+			r.Plan.UpdateStatus(PhaseUnreachable)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return nil
+		}
+		r.updateStatus(internal.RunPlanQueued)
+		r.Plan.UpdateStatus(PhaseQueued)
+		return nil
+	case PostPlanTaskStage:
+		if r.Status != internal.RunPostPlanRunning {
+			return ErrInvalidRunStateTransition
+		}
+		r.updateStatus(internal.RunPostPlanCompleted)
+		if !passed {
+			r.updateStatus(internal.RunErrored)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return nil
+		}
+		return r.afterPlan()
+	case PreApplyTaskStage:
+		if r.Status != internal.RunPreApplyRunning {
+			return ErrInvalidRunStateTransition
+		}
+		r.updateStatus(internal.RunPreApplyCompleted)
+		if !passed {
+			r.updateStatus(internal.RunErrored)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return nil
+		}
+		r.updateStatus(internal.RunApplyQueued)
+		r.Apply.UpdateStatus(PhaseQueued)
+		return nil
+	default:
+		return fmt.Errorf("unknown task stage: %s", stage)
+	}
+}
+
+// afterPlan determines the run's status following a successful plan.
+func (r *Run) afterPlan() error {
+	// Enter RunCostEstimated state if cost estimation is enabled. OTF does
+	// not support cost estimation but enter this state only in order to
+	// satisfy the go-tfe tests.
+	if r.CostEstimationEnabled {
+		r.updateStatus(internal.RunCostEstimated)
+	} else {
+		r.updateStatus(internal.RunPlanned)
+	}
+
+	if !r.HasChanges() || r.PlanOnly {
+		r.updateStatus(internal.RunPlannedAndFinished)
+		r.Apply.UpdateStatus(PhaseUnreachable)
+	} else if r.SavePlan {
+		// saved plans are never auto-applied
+		r.updateStatus(internal.RunPlannedAndSaved)
+	} else if r.AutoApply && r.Approved() {
+		return r.EnqueueApply()
+	}
+	return nil
+}
+
+// planStale determines whether the run's plan is stale given the time at which
+// its workspace's state last changed, i.e. whether the state changed after the
+// plan started.
+func (r *Run) planStale(stateChangedAt time.Time) bool {
+	planning, err := r.StatusTimestamp(internal.RunPlanning)
+	if err != nil {
+		// run has yet to plan
+		return false
+	}
+	return stateChangedAt.After(planning)
+}
+
+// errorStalePlan errors a confirmed saved plan whose plan has become stale.
+func (r *Run) errorStalePlan() error {
+	if r.Status != internal.RunConfirmed {
+		return ErrInvalidRunStateTransition
+	}
+	r.updateStatus(internal.RunErrored)
+	r.Apply.UpdateStatus(PhaseUnreachable)
+	return nil
+}
+
+// AwaitingApproval determines whether the run is awaiting approval
+// decisions, which is the case when its plan is awaiting confirmation.
+func (r *Run) AwaitingApproval() bool {
+	switch r.Status {
+	case internal.RunPlanned, internal.RunCostEstimated, internal.RunPlannedAndSaved:
+		return r.ApprovalsRequired > 0
+	default:
+		return false
+	}
+}
+
+// Approved determines whether the run has received the approvals required
+// for it to be applied.
+func (r *Run) Approved() bool {
+	return r.ApprovalCount() >= r.ApprovalsRequired
+}
+
+// ApprovalCount returns the number of approvals the run has received.
+func (r *Run) ApprovalCount() (n int) {
+	for _, approval := range r.Approvals {
+		if approval.Decision == ApprovedDecision {
+			n++
+		}
+	}
+	return n
+}
+
+// Rejected determines whether any approver has rejected the run.
+func (r *Run) Rejected() bool {
+	for _, approval := range r.Approvals {
+		if approval.Decision == RejectedDecision {
+			return true
+		}
+	}
+	return false
+}
+
+// addApproval adds an approval decision to the run. If the decision provides
+// the last of the required approvals, the run has not been rejected, and the
+// run is set to auto-apply, then the apply is enqueued.
+func (r *Run) addApproval(approval *Approval) error {
+	if !r.AwaitingApproval() {
+		return internal.ErrRunApprovalNotAllowed
+	}
+	r.Approvals = append(r.Approvals, approval)
+	if approval.Decision == ApprovedDecision && r.AutoApply && !r.SavePlan && r.Approved() && !r.Rejected() {
+		return r.EnqueueApply()
+	}
+	return nil
+}
+
+// TaskStage returns the run task stage the run is currently awaiting, and
+// false if it is not awaiting a stage.
+func (r *Run) TaskStage() (TaskStage, bool) {
+	switch r.Status {
+	case internal.RunPrePlanRunning:
+		return PrePlanTaskStage, true
+	case internal.RunPostPlanRunning:
+		return PostPlanTaskStage, true
+	case internal.RunPreApplyRunning:
+		return PreApplyTaskStage, true
+	default:
+		return "", false
+	}
+}
+
+func (r *Run) hasTaskStage(stage TaskStage) bool {
+	return slices.Contains(r.TaskStages, stage)
+}
+
 func (r *Run) updateStatus(status internal.RunStatus) {
 	r.Status = status
+	r.QueuedByLimit = false
 	r.StatusTimestamps = append(r.StatusTimestamps, StatusTimestamp{
 		Status:    status,
 		Timestamp: internal.CurrentTimestamp(),
 	})
 }
 
+// withReason wraps fn, which may change the status of a run, such that the
+// reason is recorded alongside the run's new status.
+func withReason(reason string, fn func(*Run) error) func(*Run) error {
+	return func(r *Run) error {
+		status := r.Status
+		if err := fn(r); err != nil {
+			return err
+		}
+		if n := len(r.StatusTimestamps); reason != "" && r.Status != status && n > 0 {
+			r.StatusTimestamps[n-1].Reason = reason
+		}
+		return nil
+	}
+}
+
 // Discardable determines whether run can be discarded.
 func (r *Run) Discardable() bool {
 	switch r.Status {
-	case internal.RunPending, internal.RunPlanned, internal.RunCostEstimated:
+	case internal.RunPending, internal.RunPlanned, internal.RunCostEstimated, internal.RunPlannedAndSaved:
 		return true
 	default:
 		return false
@@ -430,18 +712,20 @@ func (r *Run) Discardable() bool {
 // Cancelable determines whether run can be cancelled.
 func (r *Run) Cancelable() bool {
 	switch r.Status {
-	case internal.RunPending, internal.RunPlanQueued, internal.RunPlanning, internal.RunApplyQueued, internal.RunApplying:
+	case internal.RunPending, internal.RunPlanQueued, internal.RunPlanning, internal.RunConfirmed, internal.RunApplyQueued, internal.RunApplying,
+		internal.RunPrePlanRunning, internal.RunPostPlanRunning, internal.RunPreApplyRunning:
 		return true
 	default:
 		return false
 	}
 }
 
-// Confirmable determines whether run can be confirmed.
+// Confirmable determines whether run can be confirmed. A run awaiting
+// approvals cannot be confirmed until it has received them.
 func (r *Run) Confirmable() bool {
 	switch r.Status {
-	case internal.RunPlanned:
-		return true
+	case internal.RunPlanned, internal.RunPlannedAndSaved:
+		return r.Approved() && !r.Rejected()
 	default:
 		return false
 	}
@@ -3,6 +3,7 @@ package run
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/auth"
@@ -170,6 +171,87 @@ func TestRun_States(t *testing.T) {
 		require.Equal(t, PhaseErrored, run.Apply.Status)
 	})
 
+	t.Run("enqueue plan with pre-plan tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.TaskStages = []TaskStage{PrePlanTaskStage}
+
+		require.NoError(t, run.EnqueuePlan())
+
+		require.Equal(t, internal.RunPrePlanRunning, run.Status)
+		require.Equal(t, PhasePending, run.Plan.Status)
+	})
+
+	t.Run("pass pre-plan tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = internal.RunPrePlanRunning
+
+		require.NoError(t, run.FinishTaskStage(PrePlanTaskStage, true))
+
+		require.Equal(t, internal.RunPlanQueued, run.Status)
+		require.Equal(t, PhaseQueued, run.Plan.Status)
+	})
+
+	t.Run("fail pre-plan tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = internal.RunPrePlanRunning
+
+		require.NoError(t, run.FinishTaskStage(PrePlanTaskStage, false))
+
+		require.Equal(t, internal.RunErrored, run.Status)
+		require.Equal(t, PhaseUnreachable, run.Plan.Status)
+		require.Equal(t, PhaseUnreachable, run.Apply.Status)
+	})
+
+	t.Run("finish plan with post-plan tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.TaskStages = []TaskStage{PostPlanTaskStage}
+		run.Status = internal.RunPlanning
+
+		require.NoError(t, run.Finish(internal.PlanPhase, PhaseFinishOptions{}))
+
+		require.Equal(t, internal.RunPostPlanRunning, run.Status)
+		require.Equal(t, PhaseFinished, run.Plan.Status)
+	})
+
+	t.Run("pass post-plan tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = internal.RunPostPlanRunning
+		run.Plan.ResourceReport = &Report{Additions: 1}
+
+		require.NoError(t, run.FinishTaskStage(PostPlanTaskStage, true))
+
+		require.Equal(t, internal.RunPlanned, run.Status)
+		require.Equal(t, PhasePending, run.Apply.Status)
+	})
+
+	t.Run("finish wrong task stage", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = internal.RunPostPlanRunning
+
+		err := run.FinishTaskStage(PrePlanTaskStage, true)
+		require.ErrorIs(t, err, ErrInvalidRunStateTransition)
+	})
+
+	t.Run("enqueue apply with pre-apply tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.TaskStages = []TaskStage{PreApplyTaskStage}
+		run.Status = internal.RunPlanned
+
+		require.NoError(t, run.EnqueueApply())
+
+		require.Equal(t, internal.RunPreApplyRunning, run.Status)
+	})
+
+	t.Run("pass pre-apply tasks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = internal.RunPreApplyRunning
+
+		require.NoError(t, run.FinishTaskStage(PreApplyTaskStage, true))
+
+		require.Equal(t, internal.RunApplyQueued, run.Status)
+		require.Equal(t, PhaseQueued, run.Apply.Status)
+	})
+
 	t.Run("cancel run", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		err := run.Cancel()
@@ -178,6 +260,118 @@ func TestRun_States(t *testing.T) {
 	})
 }
 
+func TestRun_SavePlan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saved plan is not speculative", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{
+			PlanOnly: internal.Bool(true),
+			SavePlan: internal.Bool(true),
+		})
+
+		assert.True(t, run.SavePlan)
+		assert.False(t, run.PlanOnly)
+	})
+
+	t.Run("finish plan with changes", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{
+			AutoApply: internal.Bool(true),
+			SavePlan:  internal.Bool(true),
+		})
+		run.Status = internal.RunPlanning
+		run.Plan.ResourceReport = &Report{Additions: 1}
+
+		require.NoError(t, run.Finish(internal.PlanPhase, PhaseFinishOptions{}))
+
+		// saved plans are never auto-applied
+		assert.Equal(t, internal.RunPlannedAndSaved, run.Status)
+		assert.Equal(t, PhasePending, run.Apply.Status)
+		assert.True(t, run.Confirmable())
+		assert.True(t, run.Discardable())
+		assert.False(t, run.UsesWorkspaceQueue())
+	})
+
+	t.Run("confirm and apply", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{SavePlan: internal.Bool(true)})
+		run.updateStatus(internal.RunPlannedAndSaved)
+
+		require.NoError(t, run.EnqueueApply())
+		assert.Equal(t, internal.RunConfirmed, run.Status)
+		assert.True(t, run.UsesWorkspaceQueue())
+
+		require.NoError(t, run.EnqueueApply())
+		assert.Equal(t, internal.RunApplyQueued, run.Status)
+		assert.Equal(t, PhaseQueued, run.Apply.Status)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{SavePlan: internal.Bool(true)})
+		run.updateStatus(internal.RunPlanning)
+		planning, err := run.StatusTimestamp(internal.RunPlanning)
+		require.NoError(t, err)
+
+		assert.False(t, run.planStale(planning.Add(-time.Minute)))
+		assert.True(t, run.planStale(planning.Add(time.Minute)))
+	})
+
+	t.Run("error stale plan", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{SavePlan: internal.Bool(true)})
+		run.updateStatus(internal.RunConfirmed)
+
+		require.NoError(t, run.errorStalePlan())
+		assert.Equal(t, internal.RunErrored, run.Status)
+		assert.Equal(t, PhaseUnreachable, run.Apply.Status)
+	})
+}
+
+func TestRun_StatusReason(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("record reason", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		err := withReason("superseded by run-123", (*Run).Discard)(run)
+		require.NoError(t, err)
+
+		assert.Equal(t, internal.RunDiscarded, run.Status)
+		assert.Equal(t, "superseded by run-123", run.StatusReason())
+	})
+
+	t.Run("no reason without status change", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		err := withReason("superseded by run-123", func(*Run) error { return nil })(run)
+		require.NoError(t, err)
+
+		assert.Equal(t, internal.RunPending, run.Status)
+		assert.Equal(t, "", run.StatusReason())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = internal.RunApplied
+		err := withReason("superseded by run-123", (*Run).Discard)(run)
+		assert.Equal(t, internal.ErrRunDiscardNotAllowed, err)
+	})
+}
+
 func newTestRun(ctx context.Context, opts CreateOptions) *Run {
 	return newRun(ctx, &organization.Organization{}, &configversion.ConfigurationVersion{}, &workspace.Workspace{}, opts)
 }
+
+func TestRun_Test(t *testing.T) {
+	ctx := context.Background()
+
+	run := newTestRun(ctx, CreateOptions{
+		AutoApply: internal.Bool(true),
+		SavePlan:  internal.Bool(true),
+		Test:      internal.Bool(true),
+	})
+	assert.True(t, run.Test)
+	assert.True(t, run.PlanOnly)
+	assert.False(t, run.SavePlan)
+	assert.False(t, run.UsesWorkspaceQueue())
+
+	run.Status = internal.RunPlanning
+	require.NoError(t, run.Finish(internal.PlanPhase, PhaseFinishOptions{}))
+	assert.Equal(t, internal.RunPlannedAndFinished, run.Status)
+	assert.Equal(t, PhaseUnreachable, run.Apply.Status)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
@@ -16,6 +17,7 @@ import (
 	"github.com/leg100/otf/internal/repo"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/otf/internal/workspace"
 )
@@ -27,22 +29,34 @@ type (
 	OrganizationService         organization.Service
 	WorkspaceService            workspace.Service
 	VCSProviderService          vcsprovider.Service
+	StateService                state.Service
+	UserService                 auth.UserService
+	TeamService                 auth.TeamService
 
 	Service interface {
 		CreateRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error)
 		GetRun(ctx context.Context, id string) (*Run, error)
 		ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error)
+		// ListQueue lists an organization's queued runs in the order in which
+		// they are to be executed.
+		ListQueue(ctx context.Context, organization string, opts resource.PageOptions) (*resource.Page[*Run], error)
 		EnqueuePlan(ctx context.Context, runID string) (*Run, error)
 		// StartPhase starts a run phase.
 		StartPhase(ctx context.Context, runID string, phase internal.PhaseType, _ PhaseStartOptions) (*Run, error)
 		// FinishPhase finishes a phase. Creates a report of changes before updating the status of
 		// the run.
 		FinishPhase(ctx context.Context, runID string, phase internal.PhaseType, opts PhaseFinishOptions) (*Run, error)
+		// FinishTaskStage finishes a run task stage, either allowing the run
+		// to proceed or, if passed is false, erroring the run.
+		FinishTaskStage(ctx context.Context, runID string, stage TaskStage, passed bool) (*Run, error)
 		// GetPlanFile returns the plan file for the run.
 		GetPlanFile(ctx context.Context, runID string, format PlanFormat) ([]byte, error)
 		// UploadPlanFile persists a run's plan file. The plan format should be either
 		// be binary or json.
 		UploadPlanFile(ctx context.Context, runID string, plan []byte, format PlanFormat) error
+		// GetPlanDiff returns a resource-level diff of the changes proposed in
+		// the run's plan.
+		GetPlanDiff(ctx context.Context, runID string) (*PlanDiff, error)
 		// Watch provides access to a stream of run events. The WatchOptions filters
 		// events. Context must be cancelled to close stream.
 		//
@@ -68,10 +82,21 @@ type (
 		ForceCancelRun(ctx context.Context, runID string) error
 
 		lockFileService
+		structuredOutputService
+		testResultsService
+		approvalService
+		commentService
+		destroyAndDeleteService
 
 		internal.Authorizer // run authorizer
 
 		getLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error)
+		// discardRun discards a run, recording the reason in the run's status
+		// timeline.
+		discardRun(ctx context.Context, runID, reason string) error
+		// cancelRun cancels a run, recording the reason in the run's status
+		// timeline.
+		cancelRun(ctx context.Context, runID, reason string) (*Run, error)
 	}
 
 	service struct {
@@ -85,8 +110,10 @@ type (
 		workspace    internal.Authorizer
 		*authorizer
 
-		cache internal.Cache
-		db    *pgdb
+		cache    internal.Cache
+		db       *pgdb
+		state    StateService
+		timeouts PhaseTimeouts
 		*factory
 
 		web *webHandlers
@@ -99,6 +126,11 @@ type (
 		WorkspaceService
 		ConfigurationVersionService
 		VCSProviderService
+		StateService
+		UserService
+		TeamService
+
+		PhaseTimeouts
 
 		logr.Logger
 		internal.Cache
@@ -124,6 +156,8 @@ func NewService(opts Options) *service {
 
 	svc.cache = opts.Cache
 	svc.db = db
+	svc.state = opts.StateService
+	svc.timeouts = opts.PhaseTimeouts
 	svc.factory = &factory{
 		opts.OrganizationService,
 		opts.WorkspaceService,
@@ -143,10 +177,16 @@ func NewService(opts Options) *service {
 		WorkspaceService:            opts.WorkspaceService,
 		VCSProviderService:          opts.VCSProviderService,
 		RunService:                  &svc,
+		UserService:                 opts.UserService,
+		TeamService:                 opts.TeamService,
+		locks:                       db,
 	}
 
 	// Register with broker so that it can relay run events
 	opts.Register("runs", &svc)
+	// ...and approval decisions
+	opts.Register("run_approvals", pubsub.GetterFunc(svc.getApprovalByID))
+	opts.Register("run_comments", pubsub.GetterFunc(svc.getCommentByID))
 
 	// Subscribe run spawner to incoming vcs events
 	opts.Subscriber.Subscribe(spawner.handle)
@@ -174,6 +214,11 @@ func (s *service) CreateRun(ctx context.Context, workspaceID string, opts Create
 		return nil, err
 	}
 
+	if err := s.checkPullRequestLock(ctx, run); err != nil {
+		s.Error(err, "creating run", "workspace_id", run.WorkspaceID, "subject", subject)
+		return nil, err
+	}
+
 	if err = s.db.CreateRun(ctx, run); err != nil {
 		s.Error(err, "creating run", "id", run.ID, "workspace_id", run.WorkspaceID, "subject", subject)
 		return nil, err
@@ -256,13 +301,17 @@ func (s *service) EnqueuePlan(ctx context.Context, runID string) (*Run, error) {
 		return nil, err
 	}
 
-	run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+	run, err := s.db.UpdateStatusWithinLimit(ctx, runID, func(run *Run) error {
 		return run.EnqueuePlan()
 	})
 	if err != nil {
 		s.Error(err, "enqueuing plan", "id", runID, "subject", subject)
 		return nil, err
 	}
+	if run.QueuedByLimit {
+		s.V(0).Info("plan queued by limit", "id", runID, "subject", subject)
+		return run, nil
+	}
 	s.V(0).Info("enqueued plan", "id", runID, "subject", subject)
 
 	return run, nil
@@ -305,6 +354,17 @@ func (s *service) StartPhase(ctx context.Context, runID string, phase internal.P
 		return nil, err
 	}
 
+	// resolve the phase's timeout before starting the phase, so that the
+	// agent is never handed a started phase without its timeout.
+	current, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.GetWorkspace(ctx, current.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
 	run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
 		return run.Start(phase)
 	})
@@ -319,6 +379,7 @@ func (s *service) StartPhase(ctx context.Context, runID string, phase internal.P
 		}
 		return nil, err
 	}
+	run.PhaseTimeout = s.timeouts.forWorkspace(ws, phase)
 	s.V(0).Info("started "+string(phase), "id", runID, "subject", subject)
 	return run, nil
 }
@@ -351,6 +412,26 @@ func (s *service) FinishPhase(ctx context.Context, runID string, phase internal.
 	return run, nil
 }
 
+// FinishTaskStage finishes a run task stage.
+//
+// NOTE: this is an internal action, invoked by the run task dispatcher only.
+func (s *service) FinishTaskStage(ctx context.Context, runID string, stage TaskStage, passed bool) (*Run, error) {
+	subject, err := s.CanAccess(ctx, rbac.FinishTaskStageAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+		return run.FinishTaskStage(stage, passed)
+	})
+	if err != nil {
+		s.Error(err, "finishing task stage", "id", runID, "stage", stage, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("finished task stage", "id", runID, "stage", stage, "passed", passed, "subject", subject, "run_status", run.Status)
+	return run, nil
+}
+
 // Watch provides authenticated access to a stream of run events.
 func (s *service) Watch(ctx context.Context, opts WatchOptions) (<-chan pubsub.Event, error) {
 	var err error
@@ -409,35 +490,104 @@ func (s *service) Apply(ctx context.Context, runID string) error {
 	if err != nil {
 		return err
 	}
-	_, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+	if err := s.checkSavedPlan(ctx, runID); err != nil {
+		s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
+		return err
+	}
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
+		return err
+	}
+	if err := s.checkPullRequestLock(ctx, run); err != nil {
+		s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
+		return err
+	}
+	run, err = s.db.UpdateStatusWithinLimit(ctx, runID, func(run *Run) error {
 		return run.EnqueueApply()
 	})
 	if err != nil {
 		s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
 		return err
 	}
-
+	if run.QueuedByLimit {
+		s.V(0).Info("apply queued by limit", "id", runID, "subject", subject)
+		return nil
+	}
 	s.V(0).Info("enqueued apply", "id", runID, "subject", subject)
 
-	return err
+	return nil
+}
+
+// checkPullRequestLock returns an error if the run would apply changes to a
+// workspace that is locked by a pull request other than the run's own.
+func (s *service) checkPullRequestLock(ctx context.Context, run *Run) error {
+	if run.PlanOnly {
+		return nil
+	}
+	lock, err := s.db.getPullRequestLock(ctx, run.WorkspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("retrieving pull request lock: %w", err)
+	}
+	if lock.heldBy(run) {
+		return nil
+	}
+	return fmt.Errorf("%w: #%d", internal.ErrWorkspaceLockedByPullRequest, lock.PullRequestNumber)
+}
+
+// checkSavedPlan returns an error if the run is a saved plan that is stale,
+// i.e. its workspace's state has changed since the plan was created. A stale
+// saved plan that has already been confirmed is errored, because it can no
+// longer be applied.
+func (s *service) checkSavedPlan(ctx context.Context, runID string) error {
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if !run.SavePlan {
+		return nil
+	}
+	current, err := s.state.GetCurrentStateVersion(ctx, run.WorkspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// workspace has no state yet
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("retrieving current state version: %w", err)
+	}
+	if !run.planStale(current.CreatedAt) {
+		return nil
+	}
+	if run.Status == internal.RunConfirmed {
+		_, err := s.db.UpdateStatus(ctx, runID, withReason("state has changed since the plan was created", (*Run).errorStalePlan))
+		if err != nil {
+			return err
+		}
+	}
+	return internal.ErrSavedPlanStale
 }
 
 // DiscardRun discards the run.
 func (s *service) DiscardRun(ctx context.Context, runID string) error {
+	return s.discardRun(ctx, runID, "")
+}
+
+func (s *service) discardRun(ctx context.Context, runID, reason string) error {
 	subject, err := s.CanAccess(ctx, rbac.DiscardRunAction, runID)
 	if err != nil {
 		return err
 	}
 
-	_, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+	_, err = s.db.UpdateStatus(ctx, runID, withReason(reason, func(run *Run) error {
 		return run.Discard()
-	})
+	}))
 	if err != nil {
 		s.Error(err, "discarding run", "id", runID, "subject", subject)
 		return err
 	}
 
-	s.V(0).Info("discarded run", "id", runID, "subject", subject)
+	s.V(0).Info("discarded run", "id", runID, "reason", reason, "subject", subject)
 
 	return err
 }
@@ -445,19 +595,23 @@ func (s *service) DiscardRun(ctx context.Context, runID string) error {
 // Cancel a run. If a run is in progress then a cancelation signal will be
 // sent out.
 func (s *service) Cancel(ctx context.Context, runID string) (*Run, error) {
+	return s.cancelRun(ctx, runID, "")
+}
+
+func (s *service) cancelRun(ctx context.Context, runID, reason string) (*Run, error) {
 	subject, err := s.CanAccess(ctx, rbac.CancelRunAction, runID)
 	if err != nil {
 		return nil, err
 	}
 
-	run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
+	run, err := s.db.UpdateStatus(ctx, runID, withReason(reason, func(run *Run) (err error) {
 		return run.Cancel()
-	})
+	}))
 	if err != nil {
 		s.Error(err, "canceling run", "id", runID, "subject", subject)
 		return nil, err
 	}
-	s.V(0).Info("canceled run", "id", runID, "subject", subject)
+	s.V(0).Info("canceled run", "id", runID, "reason", reason, "subject", subject)
 	return run, nil
 }
 
@@ -542,6 +696,14 @@ func (s *service) createReports(ctx context.Context, runID string, phase interna
 }
 
 func (s *service) createPlanReports(ctx context.Context, runID string) (resources Report, outputs Report, err error) {
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return Report{}, Report{}, err
+	}
+	if run.Test {
+		// test runs report test results rather than a plan
+		return Report{}, Report{}, nil
+	}
 	plan, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
 	if err != nil {
 		return Report{}, Report{}, err
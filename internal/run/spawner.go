@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 
@@ -22,7 +23,11 @@ type (
 		WorkspaceService
 		VCSProviderService
 		RunService
+		UserService
+		TeamService
 		repo.Subscriber
+
+		locks pullRequestLocker
 	}
 )
 
@@ -46,6 +51,25 @@ func (s *Spawner) handleWithError(logger logr.Logger, event cloud.VCSEvent) erro
 	// give spawner unlimited powers
 	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "run-spawner"})
 
+	switch event.Type {
+	case cloud.VCSEventTypeComment:
+		return s.handleComment(ctx, logger, event)
+	case cloud.VCSEventTypePull:
+		// a pull request that is no longer open relinquishes its workspace
+		// locks and its preview workspaces are torn down
+		switch event.Action {
+		case cloud.VCSActionDeleted, cloud.VCSActionMerged:
+			if err := s.locks.unlockPullRequest(ctx, event.RepoPath, event.PullRequestNumber); err != nil {
+				return err
+			}
+			workspaces, err := s.ListWorkspacesByRepoID(ctx, event.RepoID)
+			if err != nil {
+				return err
+			}
+			return s.destroyPreviews(ctx, logger, event, workspaces)
+		}
+	}
+
 	// skip events other than those that create or update a ref or pull request
 	switch event.Action {
 	case cloud.VCSActionCreated, cloud.VCSActionUpdated:
@@ -62,9 +86,23 @@ func (s *Spawner) handleWithError(logger logr.Logger, event cloud.VCSEvent) erro
 		return nil
 	}
 
+	if event.Type == cloud.VCSEventTypePull && event.Action == cloud.VCSActionCreated {
+		if err := s.createPreviews(ctx, logger, event, workspaces); err != nil {
+			return err
+		}
+	}
+
 	// filter out workspaces based on info contained in the event
 	n := 0
 	for _, ws := range workspaces {
+		if ws.PreviewTemplate {
+			// templates only spawn previews; they never run themselves
+			continue
+		}
+		if ws.Preview != nil && event.Type == cloud.VCSEventTypePull {
+			// previews run on pushes to their pull request's branch
+			continue
+		}
 		switch event.Type {
 		case cloud.VCSEventTypeTag:
 			// skip workspaces with a non-nil tag regex that doesn't match the
@@ -184,14 +222,7 @@ func (s *Spawner) handleWithError(logger logr.Logger, event cloud.VCSEvent) erro
 			},
 		}
 		runOpts := CreateOptions{}
-		switch event.Cloud {
-		case cloud.Github:
-			cvOpts.Source = configversion.SourceGithub
-			runOpts.Source = SourceGithub
-		case cloud.Gitlab:
-			cvOpts.Source = configversion.SourceGitlab
-			runOpts.Source = SourceGitlab
-		}
+		cvOpts.Source, runOpts.Source = eventSources(event.Cloud)
 		cv, err := s.CreateConfigurationVersion(ctx, ws.ID, cvOpts)
 		if err != nil {
 			return err
@@ -200,14 +231,37 @@ func (s *Spawner) handleWithError(logger logr.Logger, event cloud.VCSEvent) erro
 			return err
 		}
 		runOpts.ConfigurationVersionID = internal.String(cv.ID)
-		_, err = s.CreateRun(ctx, ws.ID, runOpts)
-		if err != nil {
+		run, err := s.CreateRun(ctx, ws.ID, runOpts)
+		if errors.Is(err, internal.ErrWorkspaceLockedByPullRequest) {
+			// the workspace only accepts runs from the pull request holding
+			// the lock; skip it rather than the remaining workspaces.
+			logger.Info("skipping workspace", "workspace", ws.ID, "reason", err.Error())
+			continue
+		} else if err != nil {
 			return err
 		}
+		if ws.SupersedeRuns {
+			if err := s.supersede(ctx, logger, run); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
+// eventSources returns the configuration version and run sources
+// corresponding to the cloud from which a vcs event originates.
+func eventSources(kind cloud.Kind) (configversion.Source, Source) {
+	switch kind {
+	case cloud.Github:
+		return configversion.SourceGithub, SourceGithub
+	case cloud.Gitlab:
+		return configversion.SourceGitlab, SourceGitlab
+	default:
+		return "", ""
+	}
+}
+
 // globMatch returns true if any of the paths match any of the glob patterns.
 func globMatch(paths []string, patterns []string) bool {
 	if len(paths) == 0 || len(patterns) == 0 {
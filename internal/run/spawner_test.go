@@ -2,12 +2,18 @@ package run
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/cloud"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -181,6 +187,290 @@ func TestSpawner(t *testing.T) {
 	}
 }
 
+func TestSpawner_SkipWorkspaceLockedByPullRequest(t *testing.T) {
+	services := &fakeSpawnerServices{
+		workspaces: []*workspace.Workspace{{ID: "ws-123", Connection: &workspace.Connection{}}},
+		createErr:  internal.ErrWorkspaceLockedByPullRequest,
+	}
+	spawner := Spawner{
+		ConfigurationVersionService: services,
+		WorkspaceService:            services,
+		VCSProviderService:          services,
+		RunService:                  services,
+	}
+	err := spawner.handleWithError(logr.Discard(), cloud.VCSEvent{
+		Type:          cloud.VCSEventTypePush,
+		Action:        cloud.VCSActionCreated,
+		Branch:        "main",
+		DefaultBranch: "main",
+	})
+	require.NoError(t, err)
+
+	assert.False(t, services.spawned)
+}
+
+func TestSpawner_ApplyCommand(t *testing.T) {
+	writer := &auth.User{
+		Username: "bobby",
+		Teams:    []*auth.Team{{Name: "devs", Organization: "acme-corp"}},
+	}
+	reader := &auth.User{
+		Username: "sally",
+		Teams:    []*auth.Team{{Name: "readers", Organization: "acme-corp"}},
+	}
+	policy := internal.WorkspacePolicy{
+		Organization: "acme-corp",
+		Permissions: []internal.WorkspacePermission{
+			{Team: "devs", Role: rbac.WorkspaceWriteRole},
+			{Team: "readers", Role: rbac.WorkspaceReadRole},
+		},
+	}
+	devs := &auth.Team{ID: "team-devs", Name: "devs", Organization: "acme-corp"}
+	mappings := []*vcsprovider.UserMapping{
+		{VCSUsername: "bobby-vcs", Username: internal.String("bobby")},
+		{VCSUsername: "sally-vcs", Username: internal.String("sally")},
+		{VCSUsername: "dave-vcs", TeamID: &devs.ID, Team: &devs.Name},
+	}
+	newWorkspace := func() *workspace.Workspace {
+		return &workspace.Workspace{
+			ID:         "ws-123",
+			Name:       "dev",
+			Connection: &workspace.Connection{Repo: "leg100/otf", PRApply: true},
+		}
+	}
+	prRun := &Run{ID: "run-pr", IngressAttributes: &configversion.IngressAttributes{
+		Repo:              "leg100/otf",
+		IsPullRequest:     true,
+		PullRequestNumber: 7,
+	}}
+
+	tests := []struct {
+		name    string
+		ws      *workspace.Workspace
+		comment string
+		sender  string
+		// pull request holding the workspace lock
+		lockedBy int
+		// incomplete runs on the workspace
+		runs []*Run
+		// error returned from CreateRun
+		createErr error
+		// want spawned run
+		spawn bool
+		// want a comment refusing the command
+		refused bool
+		// want error
+		wantErr bool
+		// want pull request holding the workspace lock afterwards
+		wantLockedBy int
+	}{
+		{
+			name:         "apply",
+			ws:           newWorkspace(),
+			comment:      "otf apply dev",
+			sender:       "bobby-vcs",
+			spawn:        true,
+			wantLockedBy: 7,
+		},
+		{
+			name:         "apply as member of mapped team",
+			ws:           newWorkspace(),
+			comment:      "otf apply dev",
+			sender:       "dave-vcs",
+			spawn:        true,
+			wantLockedBy: 7,
+		},
+		{
+			name:    "ignore ordinary comment",
+			ws:      newWorkspace(),
+			comment: "looks good to me",
+			sender:  "bobby-vcs",
+		},
+		{
+			name:    "unknown workspace",
+			ws:      newWorkspace(),
+			comment: "otf apply prod",
+			sender:  "bobby-vcs",
+			refused: true,
+		},
+		{
+			name: "workspace does not permit apply from comments",
+			ws: &workspace.Workspace{
+				Name:       "dev",
+				Connection: &workspace.Connection{Repo: "leg100/otf"},
+			},
+			comment: "otf apply dev",
+			sender:  "bobby-vcs",
+			refused: true,
+		},
+		{
+			name:    "unmapped vcs user",
+			ws:      newWorkspace(),
+			comment: "otf apply dev",
+			sender:  "mallory",
+			refused: true,
+		},
+		{
+			name:    "vcs user with same name as otf user is not mapped",
+			ws:      newWorkspace(),
+			comment: "otf apply dev",
+			sender:  "bobby",
+			refused: true,
+		},
+		{
+			name:    "user lacks permission to apply",
+			ws:      newWorkspace(),
+			comment: "otf apply dev",
+			sender:  "sally-vcs",
+			refused: true,
+		},
+		{
+			name:         "workspace locked by another pull request",
+			ws:           newWorkspace(),
+			comment:      "otf apply dev",
+			sender:       "bobby-vcs",
+			lockedBy:     99,
+			refused:      true,
+			wantLockedBy: 99,
+		},
+		{
+			name:    "run from outside pull request in progress",
+			ws:      newWorkspace(),
+			comment: "otf apply dev",
+			sender:  "bobby-vcs",
+			runs:    []*Run{{ID: "run-other"}},
+			refused: true,
+		},
+		{
+			name:         "run from same pull request in progress",
+			ws:           newWorkspace(),
+			comment:      "otf apply dev",
+			sender:       "bobby-vcs",
+			lockedBy:     7,
+			runs:         []*Run{prRun},
+			spawn:        true,
+			wantLockedBy: 7,
+		},
+		{
+			name:      "release lock when run cannot be created",
+			ws:        newWorkspace(),
+			comment:   "otf apply dev",
+			sender:    "bobby-vcs",
+			createErr: errors.New("something went wrong"),
+			wantErr:   true,
+		},
+		{
+			name:         "keep lock acquired by earlier command when run cannot be created",
+			ws:           newWorkspace(),
+			comment:      "otf apply dev",
+			sender:       "bobby-vcs",
+			lockedBy:     7,
+			createErr:    errors.New("something went wrong"),
+			wantErr:      true,
+			wantLockedBy: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := &fakeSpawnerServices{
+				workspaces: []*workspace.Workspace{tt.ws},
+				users:      []*auth.User{writer, reader},
+				teams:      []*auth.Team{devs},
+				mappings:   mappings,
+				policy:     policy,
+				runs:       tt.runs,
+				createErr:  tt.createErr,
+				client:     &fakeSpawnerCloudClient{},
+			}
+			locks := &fakePullRequestLocker{holder: tt.lockedBy}
+			spawner := Spawner{
+				ConfigurationVersionService: services,
+				WorkspaceService:            services,
+				VCSProviderService:          services,
+				RunService:                  services,
+				UserService:                 services,
+				TeamService:                 services,
+				locks:                       locks,
+			}
+			err := spawner.handleWithError(logr.Discard(), cloud.VCSEvent{
+				Type:              cloud.VCSEventTypeComment,
+				Action:            cloud.VCSActionCreated,
+				RepoPath:          "leg100/otf",
+				PullRequestNumber: 7,
+				Comment:           tt.comment,
+				SenderUsername:    tt.sender,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.spawn, services.spawned)
+			assert.Equal(t, tt.refused, len(services.client.comments) > 0)
+			assert.Equal(t, tt.wantLockedBy, locks.holder)
+			if tt.spawn {
+				assert.True(t, *services.runOpts.AutoApply)
+				require.Equal(t, 1, len(services.created))
+				assert.Equal(t, "abc123", services.created[0].IngressAttributes.CommitSHA)
+				assert.False(t, services.created[0].Speculative)
+			}
+		})
+	}
+}
+
+func TestPullRequestLock_HeldBy(t *testing.T) {
+	lock := pullRequestLock{Repo: "leg100/otf", PullRequestNumber: 7}
+	newRun := func(attrs *configversion.IngressAttributes) *Run {
+		return &Run{IngressAttributes: attrs}
+	}
+
+	assert.True(t, lock.heldBy(newRun(&configversion.IngressAttributes{Repo: "leg100/otf", IsPullRequest: true, PullRequestNumber: 7})))
+	assert.False(t, lock.heldBy(newRun(&configversion.IngressAttributes{Repo: "leg100/otf", IsPullRequest: true, PullRequestNumber: 8})))
+	assert.False(t, lock.heldBy(newRun(&configversion.IngressAttributes{Repo: "leg100/other", IsPullRequest: true, PullRequestNumber: 7})))
+	assert.False(t, lock.heldBy(newRun(&configversion.IngressAttributes{Repo: "leg100/otf", Branch: "main"})))
+	assert.False(t, lock.heldBy(newRun(nil)))
+}
+
+func TestSpawner_ReleasePullRequestLock(t *testing.T) {
+	locks := &fakePullRequestLocker{holder: 7}
+	services := &fakeSpawnerServices{}
+	spawner := Spawner{WorkspaceService: services, locks: locks}
+
+	err := spawner.handleWithError(logr.Discard(), cloud.VCSEvent{
+		Type:              cloud.VCSEventTypePull,
+		Action:            cloud.VCSActionMerged,
+		RepoPath:          "leg100/otf",
+		PullRequestNumber: 7,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, locks.holder)
+}
+
+func TestParseApplyCommand(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    string
+		ok      bool
+	}{
+		{"otf apply dev", "dev", true},
+		{"  otf   apply   dev  ", "dev", true},
+		{"otf apply dev\nplease", "dev", true},
+		{"otf apply", "", false},
+		{"otf apply dev prod", "", false},
+		{"please otf apply dev", "", false},
+		{"lgtm", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			got, ok := parseApplyCommand(tt.comment)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 type fakeSpawnerServices struct {
 	// workspaces to return from stubbed ListWorkspacesByRepoID()
 	workspaces []*workspace.Workspace
@@ -190,11 +480,29 @@ type fakeSpawnerServices struct {
 	spawned bool
 	// list of file paths to return from stubbed ListPullRequestFiles()
 	pullFiles []string
+	// users to return from stubbed GetUser()
+	users []*auth.User
+	// teams to return from stubbed GetTeamByID()
+	teams []*auth.Team
+	// vcs user mappings to return from stubbed GetUserMapping()
+	mappings []*vcsprovider.UserMapping
+	// runs to return from stubbed ListRuns()
+	runs []*Run
+	// error to return from stubbed CreateRun()
+	createErr error
+	// policy to return from stubbed GetPolicy()
+	policy internal.WorkspacePolicy
+	// options for spawned run
+	runOpts CreateOptions
+	// client to return from stubbed GetVCSClient()
+	client *fakeSpawnerCloudClient
 
 	ConfigurationVersionService
 	WorkspaceService
 	VCSProviderService
 	RunService
+	UserService
+	TeamService
 }
 
 func (f *fakeSpawnerServices) ListWorkspacesByRepoID(ctx context.Context, id uuid.UUID) ([]*workspace.Workspace, error) {
@@ -214,18 +522,71 @@ func (f *fakeSpawnerServices) UploadConfig(context.Context, string, []byte) erro
 	return nil
 }
 
-func (f *fakeSpawnerServices) CreateRun(context.Context, string, CreateOptions) (*Run, error) {
+func (f *fakeSpawnerServices) CreateRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
 	f.spawned = true
-	return nil, nil
+	f.runOpts = opts
+	return &Run{ID: "run-123"}, nil
+}
+
+func (f *fakeSpawnerServices) GetUser(ctx context.Context, spec auth.UserSpec) (*auth.User, error) {
+	for _, user := range f.users {
+		if user.Username == *spec.Username {
+			return user, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeSpawnerServices) GetTeamByID(ctx context.Context, teamID string) (*auth.Team, error) {
+	for _, team := range f.teams {
+		if team.ID == teamID {
+			return team, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeSpawnerServices) GetUserMapping(ctx context.Context, providerID, vcsUsername string) (*vcsprovider.UserMapping, error) {
+	for _, mapping := range f.mappings {
+		if mapping.VCSUsername == vcsUsername {
+			return mapping, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeSpawnerServices) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
+	return resource.NewPage(f.runs, opts.PageOptions, nil), nil
+}
+
+func (f *fakeSpawnerServices) GetPolicy(context.Context, string) (internal.WorkspacePolicy, error) {
+	return f.policy, nil
 }
 
 func (f *fakeSpawnerServices) GetVCSClient(context.Context, string) (cloud.Client, error) {
+	if f.client != nil {
+		return f.client, nil
+	}
 	return &fakeSpawnerCloudClient{pullFiles: f.pullFiles}, nil
 }
 
 type fakeSpawnerCloudClient struct {
 	cloud.Client
 	pullFiles []string
+	// comments created on pull request
+	comments []cloud.CreatePullRequestCommentOptions
+}
+
+func (f *fakeSpawnerCloudClient) GetPullRequest(ctx context.Context, repo string, pull int) (cloud.PullRequest, error) {
+	return cloud.PullRequest{Number: pull, HeadBranch: "dev", HeadSHA: "abc123", Open: true}, nil
+}
+
+func (f *fakeSpawnerCloudClient) CreatePullRequestComment(ctx context.Context, opts cloud.CreatePullRequestCommentOptions) (string, error) {
+	f.comments = append(f.comments, opts)
+	return "comment-1", nil
 }
 
 func (f *fakeSpawnerCloudClient) GetRepoTarball(context.Context, cloud.GetRepoTarballOptions) ([]byte, string, error) {
@@ -235,3 +596,29 @@ func (f *fakeSpawnerCloudClient) GetRepoTarball(context.Context, cloud.GetRepoTa
 func (f *fakeSpawnerCloudClient) ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error) {
 	return f.pullFiles, nil
 }
+
+type fakePullRequestLocker struct {
+	// number of pull request holding lock; zero means unlocked
+	holder int
+}
+
+func (f *fakePullRequestLocker) lockForPullRequest(ctx context.Context, workspaceID, repo string, pull int, username string) (pullRequestLock, bool, error) {
+	var acquired bool
+	if f.holder == 0 {
+		f.holder = pull
+		acquired = true
+	}
+	return pullRequestLock{Repo: repo, PullRequestNumber: f.holder}, acquired, nil
+}
+
+func (f *fakePullRequestLocker) unlockWorkspaceForPullRequest(ctx context.Context, workspaceID string) error {
+	f.holder = 0
+	return nil
+}
+
+func (f *fakePullRequestLocker) unlockPullRequest(ctx context.Context, repo string, pull int) error {
+	if f.holder == pull {
+		f.holder = 0
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package run
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+const (
+	// Types of event emitted by terraform when run with -json. Only those
+	// events of interest are listed.
+	PlannedChangeEvent EventType = "planned_change"
+	ApplyStartEvent    EventType = "apply_start"
+	ApplyProgressEvent EventType = "apply_progress"
+	ApplyCompleteEvent EventType = "apply_complete"
+	ApplyErroredEvent  EventType = "apply_errored"
+	ChangeSummaryEvent EventType = "change_summary"
+	OutputsEvent       EventType = "outputs"
+	DiagnosticEvent    EventType = "diagnostic"
+
+	// Statuses of a resource as it progresses through a run
+	ResourcePlanned  ResourceStatus = "planned"
+	ResourceApplying ResourceStatus = "applying"
+	ResourceComplete ResourceStatus = "complete"
+	ResourceErrored  ResourceStatus = "errored"
+)
+
+type (
+	EventType      string
+	ResourceStatus string
+
+	// Event is a line of machine-readable output emitted by terraform when
+	// invoked with the -json flag.
+	//
+	// https://developer.hashicorp.com/terraform/internals/machine-readable-ui
+	Event struct {
+		Level      string                      `json:"@level"`
+		Message    string                      `json:"@message"`
+		Timestamp  time.Time                   `json:"@timestamp"`
+		Type       EventType                   `json:"type"`
+		Change     *eventChange                `json:"change,omitempty"`
+		Hook       *eventHook                  `json:"hook,omitempty"`
+		Changes    *ChangeSummary              `json:"changes,omitempty"`
+		Outputs    map[string]eventOutputValue `json:"outputs,omitempty"`
+		Diagnostic *Diagnostic                 `json:"diagnostic,omitempty"`
+
+		// Fields populated by terraform test
+		TestFile       string              `json:"@testfile,omitempty"`
+		TestRun        string              `json:"@testrun,omitempty"`
+		TestAbstract   map[string][]string `json:"test_abstract,omitempty"`
+		TestFileStatus *eventTestFile      `json:"test_file,omitempty"`
+		TestRunStatus  *eventTestRun       `json:"test_run,omitempty"`
+		TestSummary    *TestSummary        `json:"test_summary,omitempty"`
+	}
+
+	eventResource struct {
+		Addr string `json:"addr"`
+	}
+
+	eventChange struct {
+		Resource eventResource `json:"resource"`
+		Action   string        `json:"action"`
+	}
+
+	eventHook struct {
+		Resource       eventResource `json:"resource"`
+		Action         string        `json:"action"`
+		IDKey          string        `json:"id_key"`
+		IDValue        string        `json:"id_value"`
+		ElapsedSeconds float64       `json:"elapsed_seconds"`
+	}
+
+	eventOutputValue struct {
+		Sensitive bool            `json:"sensitive"`
+		Value     json.RawMessage `json:"value"`
+		Action    string          `json:"action"`
+	}
+
+	// StructuredOutput is a summary of the machine-readable output of a run
+	// phase.
+	StructuredOutput struct {
+		Resources   []*ResourceProgress
+		Diagnostics []*Diagnostic
+		Outputs     []*Output
+		Summary     *ChangeSummary
+	}
+
+	// ResourceProgress is the planned action for a resource and, if applied,
+	// the progress of the action.
+	ResourceProgress struct {
+		Address string
+		Action  string
+		Status  ResourceStatus
+		IDKey   string
+		IDValue string
+		Elapsed time.Duration
+	}
+
+	// ChangeSummary summarises the changes planned or applied.
+	ChangeSummary struct {
+		Add       int    `json:"add"`
+		Change    int    `json:"change"`
+		Remove    int    `json:"remove"`
+		Import    int    `json:"import"`
+		Operation string `json:"operation"`
+	}
+
+	// Output is a root module output value.
+	Output struct {
+		Name      string
+		Sensitive bool
+		Action    string
+		Value     json.RawMessage
+	}
+
+	// Diagnostic is a warning or error reported by terraform.
+	Diagnostic struct {
+		Severity string             `json:"severity"`
+		Summary  string             `json:"summary"`
+		Detail   string             `json:"detail"`
+		Address  string             `json:"address,omitempty"`
+		Range    *DiagnosticRange   `json:"range,omitempty"`
+		Snippet  *DiagnosticSnippet `json:"snippet,omitempty"`
+	}
+
+	DiagnosticRange struct {
+		Filename string `json:"filename"`
+		Start    struct {
+			Line   int `json:"line"`
+			Column int `json:"column"`
+		} `json:"start"`
+	}
+
+	// DiagnosticSnippet is the source code relevant to a diagnostic.
+	DiagnosticSnippet struct {
+		Context              *string `json:"context"`
+		Code                 string  `json:"code"`
+		StartLine            int     `json:"start_line"`
+		HighlightStartOffset int     `json:"highlight_start_offset"`
+		HighlightEndOffset   int     `json:"highlight_end_offset"`
+	}
+
+	// StructuredOutputOptions are options for the structured output API
+	StructuredOutputOptions struct {
+		Phase internal.PhaseType `schema:"phase,required"`
+	}
+
+	structuredOutputService interface {
+		// GetStructuredOutput returns the machine-readable output of a run
+		// phase.
+		GetStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error)
+		// UploadStructuredOutput persists the machine-readable output of a
+		// run phase.
+		UploadStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType, output []byte) error
+	}
+)
+
+func (s *service) GetStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetStructuredOutputAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.db.GetStructuredOutput(ctx, runID, phase)
+	if err != nil {
+		s.Error(err, "retrieving structured output", "id", runID, "phase", phase, "subject", subject)
+		return nil, err
+	}
+	return output, nil
+}
+
+func (s *service) UploadStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType, output []byte) error {
+	subject, err := s.CanAccess(ctx, rbac.UploadStructuredOutputAction, runID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.SetStructuredOutput(ctx, runID, phase, output); err != nil {
+		s.Error(err, "uploading structured output", "id", runID, "phase", phase, "subject", subject)
+		return err
+	}
+	s.V(1).Info("uploaded structured output", "id", runID, "phase", phase, "subject", subject)
+	return nil
+}
+
+// ParseStructuredOutput parses the machine-readable output of a run phase,
+// one event per line. Lines that are not events are skipped.
+func ParseStructuredOutput(data []byte) (*StructuredOutput, error) {
+	var (
+		so        StructuredOutput
+		resources = make(map[string]*ResourceProgress)
+	)
+	getResource := func(addr string) *ResourceProgress {
+		rp, ok := resources[addr]
+		if !ok {
+			rp = &ResourceProgress{Address: addr}
+			resources[addr] = rp
+			so.Resources = append(so.Resources, rp)
+		}
+		return rp
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var ev Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		switch ev.Type {
+		case PlannedChangeEvent:
+			if ev.Change == nil {
+				continue
+			}
+			rp := getResource(ev.Change.Resource.Addr)
+			rp.Action = ev.Change.Action
+			rp.Status = ResourcePlanned
+		case ApplyStartEvent, ApplyProgressEvent, ApplyCompleteEvent, ApplyErroredEvent:
+			if ev.Hook == nil {
+				continue
+			}
+			rp := getResource(ev.Hook.Resource.Addr)
+			rp.Action = ev.Hook.Action
+			rp.Elapsed = time.Duration(ev.Hook.ElapsedSeconds * float64(time.Second))
+			switch ev.Type {
+			case ApplyStartEvent, ApplyProgressEvent:
+				rp.Status = ResourceApplying
+			case ApplyCompleteEvent:
+				rp.Status = ResourceComplete
+				rp.IDKey = ev.Hook.IDKey
+				rp.IDValue = ev.Hook.IDValue
+			case ApplyErroredEvent:
+				rp.Status = ResourceErrored
+			}
+		case ChangeSummaryEvent:
+			so.Summary = ev.Changes
+		case OutputsEvent:
+			so.Outputs = nil
+			for name, out := range ev.Outputs {
+				so.Outputs = append(so.Outputs, &Output{
+					Name:      name,
+					Sensitive: out.Sensitive,
+					Action:    out.Action,
+					Value:     out.Value,
+				})
+			}
+			sort.Slice(so.Outputs, func(i, j int) bool {
+				return so.Outputs[i].Name < so.Outputs[j].Name
+			})
+		case DiagnosticEvent:
+			if ev.Diagnostic != nil {
+				so.Diagnostics = append(so.Diagnostics, ev.Diagnostic)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &so, nil
+}
+
+// RenderEvent writes a human-readable rendering of a line of machine-readable
+// output to w, so that the raw log remains legible. Lines that are not events
+// are written unchanged.
+func RenderEvent(w io.Writer, line []byte) error {
+	var ev Event
+	if err := json.Unmarshal(line, &ev); err != nil || ev.Type == "" {
+		_, err := fmt.Fprintf(w, "%s\n", line)
+		return err
+	}
+	if ev.Type != DiagnosticEvent || ev.Diagnostic == nil {
+		_, err := fmt.Fprintln(w, ev.Message)
+		return err
+	}
+	diag := ev.Diagnostic
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s: %s\n", capitalize(diag.Severity), diag.Summary)
+	if diag.Range != nil {
+		fmt.Fprintf(&b, "\n  on %s line %d", diag.Range.Filename, diag.Range.Start.Line)
+		if diag.Snippet != nil {
+			if diag.Snippet.Context != nil {
+				fmt.Fprintf(&b, ", in %s", *diag.Snippet.Context)
+			}
+			b.WriteString(":\n")
+			for i, line := range strings.Split(diag.Snippet.Code, "\n") {
+				fmt.Fprintf(&b, "  %d: %s\n", diag.Snippet.StartLine+i, line)
+			}
+		} else {
+			b.WriteString("\n")
+		}
+	}
+	if diag.Detail != "" {
+		fmt.Fprintf(&b, "\n%s\n", diag.Detail)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Before returns the snippet code preceding the highlighted portion.
+func (s *DiagnosticSnippet) Before() string {
+	before, _, _ := s.split()
+	return before
+}
+
+// Highlight returns the highlighted portion of the snippet code, i.e. the
+// code the diagnostic refers to.
+func (s *DiagnosticSnippet) Highlight() string {
+	_, highlight, _ := s.split()
+	return highlight
+}
+
+// After returns the snippet code following the highlighted portion.
+func (s *DiagnosticSnippet) After() string {
+	_, _, after := s.split()
+	return after
+}
+
+func (s *DiagnosticSnippet) split() (before, highlight, after string) {
+	start, end := s.HighlightStartOffset, s.HighlightEndOffset
+	if start < 0 || end > len(s.Code) || start > end {
+		return s.Code, "", ""
+	}
+	return s.Code[:start], s.Code[start:end], s.Code[end:]
+}
+
+// String renders the output value, redacting it if sensitive.
+func (o *Output) String() string {
+	if o.Sensitive {
+		return "(sensitive value)"
+	}
+	return string(o.Value)
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
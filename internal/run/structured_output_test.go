@@ -0,0 +1,80 @@
+package run
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructuredOutput(t *testing.T) {
+	data, err := os.ReadFile("testdata/apply.jsonl")
+	require.NoError(t, err)
+
+	got, err := ParseStructuredOutput(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*ResourceProgress{
+		{
+			Address: "random_pet.pet",
+			Action:  "create",
+			Status:  ResourceComplete,
+			IDKey:   "id",
+			IDValue: "dashing-toad",
+			Elapsed: 2 * time.Second,
+		},
+		{
+			Address: "null_resource.fail",
+			Action:  "create",
+			Status:  ResourceErrored,
+			Elapsed: time.Second,
+		},
+	}, got.Resources)
+	assert.Equal(t, &ChangeSummary{Add: 1, Operation: "apply"}, got.Summary)
+
+	require.Equal(t, 2, len(got.Outputs))
+	assert.Equal(t, "pet", got.Outputs[0].Name)
+	assert.Equal(t, `"dashing-toad"`, got.Outputs[0].String())
+	assert.Equal(t, "(sensitive value)", got.Outputs[1].String())
+
+	require.Equal(t, 1, len(got.Diagnostics))
+	snippet := got.Diagnostics[0].Snippet
+	require.NotNil(t, snippet)
+	assert.Equal(t, "    command = ", snippet.Before())
+	assert.Equal(t, `"exit 1"`, snippet.Highlight())
+	assert.Equal(t, "", snippet.After())
+}
+
+func TestRenderEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "message",
+			line: `{"@level":"info","@message":"random_pet.pet: Creating...","type":"apply_start"}`,
+			want: "random_pet.pet: Creating...\n",
+		},
+		{
+			name: "diagnostic",
+			line: `{"@level":"error","@message":"Error: bad","type":"diagnostic","diagnostic":{"severity":"error","summary":"bad","detail":"very bad","range":{"filename":"main.tf","start":{"line":2}},"snippet":{"code":"foo = bar","start_line":2}}}`,
+			want: "\nError: bad\n\n  on main.tf line 2:\n  2: foo = bar\n\nvery bad\n",
+		},
+		{
+			name: "not json",
+			line: "Initializing the backend...",
+			want: "Initializing the backend...\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bytes.Buffer
+			require.NoError(t, RenderEvent(&got, []byte(tt.line)))
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+// supersedableStatuses are the statuses in which a run can be superseded by a
+// newer run. Runs triggered by pushes are only superseded before they are
+// enqueued, whereas speculative plans triggered by pull requests can be
+// canceled up until they finish planning.
+var supersedableStatuses = []internal.RunStatus{
+	internal.RunPending,
+	internal.RunPlanQueued,
+	internal.RunPlanning,
+}
+
+// supersede discards or cancels the workspace's older runs that have been
+// superseded by the given VCS-triggered run.
+func (s *Spawner) supersede(ctx context.Context, logger logr.Logger, by *Run) error {
+	runs, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Run], error) {
+		return s.ListRuns(ctx, ListOptions{
+			PageOptions: opts,
+			WorkspaceID: &by.WorkspaceID,
+			Statuses:    supersedableStatuses,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("listing runs to supersede: %w", err)
+	}
+	reason := fmt.Sprintf("superseded by %s", by.ID)
+	for _, run := range superseded(runs, by) {
+		if run.Discardable() {
+			err = s.discardRun(ctx, run.ID, reason)
+		} else {
+			_, err = s.cancelRun(ctx, run.ID, reason)
+		}
+		if err != nil {
+			// the run may have since progressed beyond the point at which
+			// it can be superseded, so log the error and carry on with other
+			// runs.
+			logger.Error(err, "superseding run", "run", run.ID, "superseded_by", by.ID)
+		}
+	}
+	return nil
+}
+
+// superseded returns those runs that are superseded by the given run. A run is
+// superseded if it was triggered by an earlier event for the same branch, or in
+// the case of a pull request, if it is a speculative plan for the same pull
+// request but for a commit that is no longer the head.
+func superseded(runs []*Run, by *Run) (older []*Run) {
+	latest := by.IngressAttributes
+	if latest == nil || latest.Branch == "" {
+		return nil
+	}
+	for _, run := range runs {
+		if run.ID == by.ID || !run.CreatedAt.Before(by.CreatedAt) {
+			continue
+		}
+		attrs := run.IngressAttributes
+		if attrs == nil || attrs.Branch != latest.Branch || attrs.IsPullRequest != latest.IsPullRequest {
+			continue
+		}
+		if latest.IsPullRequest {
+			if !run.PlanOnly || attrs.PullRequestNumber != latest.PullRequestNumber || attrs.CommitSHA == latest.CommitSHA {
+				continue
+			}
+			if !run.Discardable() && !run.Cancelable() {
+				continue
+			}
+		} else if run.Status != internal.RunPending {
+			continue
+		}
+		older = append(older, run)
+	}
+	return older
+}
@@ -0,0 +1,109 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuperseded(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	push := func(id string, status internal.RunStatus, branch string) *Run {
+		return &Run{
+			ID:                id,
+			CreatedAt:         earlier,
+			Status:            status,
+			IngressAttributes: &configversion.IngressAttributes{Branch: branch, CommitSHA: "abc"},
+		}
+	}
+	pull := func(id string, status internal.RunStatus, number int, sha string) *Run {
+		return &Run{
+			ID:        id,
+			CreatedAt: earlier,
+			Status:    status,
+			PlanOnly:  true,
+			IngressAttributes: &configversion.IngressAttributes{
+				Branch:            "feature",
+				CommitSHA:         sha,
+				IsPullRequest:     true,
+				PullRequestNumber: number,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		runs []*Run
+		by   *Run
+		want []string
+	}{
+		{
+			name: "supersede pending run for same branch",
+			runs: []*Run{push("run-1", internal.RunPending, "main")},
+			by:   &Run{ID: "run-2", CreatedAt: now, IngressAttributes: &configversion.IngressAttributes{Branch: "main", CommitSHA: "def"}},
+			want: []string{"run-1"},
+		},
+		{
+			name: "skip run for different branch",
+			runs: []*Run{push("run-1", internal.RunPending, "dev")},
+			by:   &Run{ID: "run-2", CreatedAt: now, IngressAttributes: &configversion.IngressAttributes{Branch: "main", CommitSHA: "def"}},
+		},
+		{
+			name: "skip push run that has been enqueued",
+			runs: []*Run{push("run-1", internal.RunPlanQueued, "main")},
+			by:   &Run{ID: "run-2", CreatedAt: now, IngressAttributes: &configversion.IngressAttributes{Branch: "main", CommitSHA: "def"}},
+		},
+		{
+			name: "skip run not triggered by vcs",
+			runs: []*Run{{ID: "run-1", CreatedAt: earlier, Status: internal.RunPending}},
+			by:   &Run{ID: "run-2", CreatedAt: now, IngressAttributes: &configversion.IngressAttributes{Branch: "main", CommitSHA: "def"}},
+		},
+		{
+			name: "skip newer run",
+			runs: []*Run{func() *Run {
+				r := push("run-1", internal.RunPending, "main")
+				r.CreatedAt = now.Add(time.Minute)
+				return r
+			}()},
+			by: &Run{ID: "run-2", CreatedAt: now, IngressAttributes: &configversion.IngressAttributes{Branch: "main", CommitSHA: "def"}},
+		},
+		{
+			name: "supersede speculative plans for previous commits to pull request",
+			runs: []*Run{
+				pull("run-1", internal.RunPending, 7, "abc"),
+				pull("run-2", internal.RunPlanning, 7, "abc"),
+				pull("run-3", internal.RunPlanning, 8, "abc"),
+			},
+			by:   pull("run-4", internal.RunPending, 7, "def"),
+			want: []string{"run-1", "run-2"},
+		},
+		{
+			name: "skip speculative plan for head commit",
+			runs: []*Run{pull("run-1", internal.RunPlanning, 7, "def")},
+			by:   pull("run-2", internal.RunPending, 7, "def"),
+		},
+		{
+			name: "pull request does not supersede push",
+			runs: []*Run{push("run-1", internal.RunPending, "feature")},
+			by:   pull("run-2", internal.RunPending, 7, "def"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// runs created by pull() share the earlier timestamp, so bump
+			// the superseding run's timestamp.
+			tt.by.CreatedAt = now
+
+			var got []string
+			for _, run := range superseded(tt.runs, tt.by) {
+				got = append(got, run.ID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -24,8 +24,12 @@ func (f *fakeSubscriber) Subscribe(context.Context, string) (<-chan pubsub.Event
 
 type (
 	fakeWebServices struct {
-		runs []*Run
-		ws   *workspace.Workspace
+		runs             []*Run
+		ws               *workspace.Workspace
+		structuredOutput []byte
+		testResults      *TestResults
+		planDiff         *PlanDiff
+		comments         []*Comment
 
 		RunService
 		WorkspaceService
@@ -46,6 +50,30 @@ func withRuns(runs ...*Run) fakeWebServiceOption {
 	}
 }
 
+func withStructuredOutput(output []byte) fakeWebServiceOption {
+	return func(svc *fakeWebServices) {
+		svc.structuredOutput = output
+	}
+}
+
+func withTestResults(results *TestResults) fakeWebServiceOption {
+	return func(svc *fakeWebServices) {
+		svc.testResults = results
+	}
+}
+
+func withPlanDiff(diff *PlanDiff) fakeWebServiceOption {
+	return func(svc *fakeWebServices) {
+		svc.planDiff = diff
+	}
+}
+
+func withComments(comments ...*Comment) fakeWebServiceOption {
+	return func(svc *fakeWebServices) {
+		svc.comments = comments
+	}
+}
+
 func newTestWebHandlers(t *testing.T, opts ...fakeWebServiceOption) *webHandlers {
 	renderer, err := html.NewRenderer(false)
 	require.NoError(t, err)
@@ -87,6 +115,22 @@ func (f *fakeWebServices) getLogs(context.Context, string, internal.PhaseType) (
 	return nil, nil
 }
 
+func (f *fakeWebServices) GetStructuredOutput(context.Context, string, internal.PhaseType) ([]byte, error) {
+	return f.structuredOutput, nil
+}
+
+func (f *fakeWebServices) GetTestResults(context.Context, string) (*TestResults, error) {
+	return f.testResults, nil
+}
+
+func (f *fakeWebServices) GetPlanDiff(context.Context, string) (*PlanDiff, error) {
+	return f.planDiff, nil
+}
+
+func (f *fakeWebServices) ListComments(context.Context, string) ([]*Comment, error) {
+	return f.comments, nil
+}
+
 func (f *fakeWebServices) Cancel(ctx context.Context, runID string) (*Run, error) { return nil, nil }
 
 func (f *fakeWebServices) GetRun(ctx context.Context, runID string) (*Run, error) {
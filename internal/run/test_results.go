@@ -0,0 +1,215 @@
+package run
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/leg100/otf/internal/rbac"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Types of event emitted by terraform test when run with -json.
+	TestAbstractEvent EventType = "test_abstract"
+	TestFileEvent     EventType = "test_file"
+	TestRunEvent      EventType = "test_run"
+	TestSummaryEvent  EventType = "test_summary"
+
+	// Statuses of a test file, a run block within a test file, and of the
+	// tests overall.
+	TestPending TestStatus = "pending"
+	TestSkip    TestStatus = "skip"
+	TestPass    TestStatus = "pass"
+	TestFail    TestStatus = "fail"
+	TestError   TestStatus = "error"
+)
+
+type (
+	TestStatus string
+
+	// TestResults are the results of a test run, parsed from the
+	// machine-readable output of terraform test.
+	TestResults struct {
+		Files []*TestFileResult `json:"files"`
+		// Summary is nil if terraform test terminated before summarising
+		// the results.
+		Summary *TestSummary `json:"summary,omitempty"`
+	}
+
+	// TestFileResult is the result of a test file.
+	TestFileResult struct {
+		Path   string           `json:"path"`
+		Status TestStatus       `json:"status"`
+		Runs   []*TestRunResult `json:"runs"`
+		// Diagnostics reported for the file but not for any particular run
+		// block.
+		Diagnostics []*Diagnostic `json:"diagnostics,omitempty"`
+	}
+
+	// TestRunResult is the result of a run block within a test file.
+	TestRunResult struct {
+		Name        string        `json:"name"`
+		Status      TestStatus    `json:"status"`
+		Diagnostics []*Diagnostic `json:"diagnostics,omitempty"`
+	}
+
+	// TestSummary summarises the results of all test files.
+	TestSummary struct {
+		Status  TestStatus `json:"status"`
+		Passed  int        `json:"passed"`
+		Failed  int        `json:"failed"`
+		Errored int        `json:"errored"`
+		Skipped int        `json:"skipped"`
+	}
+
+	eventTestFile struct {
+		Path   string     `json:"path"`
+		Status TestStatus `json:"status"`
+	}
+
+	eventTestRun struct {
+		Path   string     `json:"path"`
+		Run    string     `json:"run"`
+		Status TestStatus `json:"status"`
+	}
+
+	testResultsService interface {
+		// GetTestResults retrieves the results of a test run. Nil is
+		// returned if no results have been uploaded.
+		GetTestResults(ctx context.Context, runID string) (*TestResults, error)
+		// UploadTestResults parses the machine-readable output of terraform
+		// test and persists the results.
+		UploadTestResults(ctx context.Context, runID string, output []byte) error
+	}
+)
+
+func (s *service) GetTestResults(ctx context.Context, runID string) (*TestResults, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetTestResultsAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.db.GetTestResults(ctx, runID)
+	if err != nil {
+		s.Error(err, "retrieving test results", "id", runID, "subject", subject)
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var results TestResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+func (s *service) UploadTestResults(ctx context.Context, runID string, output []byte) error {
+	subject, err := s.CanAccess(ctx, rbac.UploadTestResultsAction, runID)
+	if err != nil {
+		return err
+	}
+
+	results, err := ParseTestOutput(output)
+	if err != nil {
+		s.Error(err, "parsing test output", "id", runID, "subject", subject)
+		return err
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	if err := s.db.SetTestResults(ctx, runID, data); err != nil {
+		s.Error(err, "uploading test results", "id", runID, "subject", subject)
+		return err
+	}
+	s.V(1).Info("uploaded test results", "id", runID, "summary", results.Summary, "subject", subject)
+	return nil
+}
+
+// ParseTestOutput parses the machine-readable output of terraform test, one
+// event per line, into results for each test file and for each run block
+// within each file. Lines that are not events are skipped.
+func ParseTestOutput(data []byte) (*TestResults, error) {
+	var (
+		results TestResults
+		files   = make(map[string]*TestFileResult)
+	)
+	getFile := func(path string) *TestFileResult {
+		f, ok := files[path]
+		if !ok {
+			f = &TestFileResult{Path: path, Status: TestPending}
+			files[path] = f
+			results.Files = append(results.Files, f)
+		}
+		return f
+	}
+	getRun := func(path, name string) *TestRunResult {
+		f := getFile(path)
+		for _, r := range f.Runs {
+			if r.Name == name {
+				return r
+			}
+		}
+		r := &TestRunResult{Name: name, Status: TestPending}
+		f.Runs = append(f.Runs, r)
+		return r
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var ev Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		switch ev.Type {
+		case TestAbstractEvent:
+			// the abstract lists the files and their run blocks up front,
+			// which establishes the order in which they're reported.
+			paths := maps.Keys(ev.TestAbstract)
+			slices.Sort(paths)
+			for _, path := range paths {
+				for _, name := range ev.TestAbstract[path] {
+					getRun(path, name)
+				}
+			}
+		case TestFileEvent:
+			if ev.TestFileStatus == nil || ev.TestFileStatus.Status == "" {
+				// progress update without a status
+				continue
+			}
+			getFile(ev.TestFileStatus.Path).Status = ev.TestFileStatus.Status
+		case TestRunEvent:
+			if ev.TestRunStatus == nil || ev.TestRunStatus.Status == "" {
+				continue
+			}
+			getRun(ev.TestRunStatus.Path, ev.TestRunStatus.Run).Status = ev.TestRunStatus.Status
+		case TestSummaryEvent:
+			results.Summary = ev.TestSummary
+		case DiagnosticEvent:
+			if ev.Diagnostic == nil || ev.TestFile == "" {
+				continue
+			}
+			if ev.TestRun != "" {
+				run := getRun(ev.TestFile, ev.TestRun)
+				run.Diagnostics = append(run.Diagnostics, ev.Diagnostic)
+			} else {
+				file := getFile(ev.TestFile)
+				file.Diagnostics = append(file.Diagnostics, ev.Diagnostic)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// Passed determines whether the tests passed.
+func (r *TestResults) Passed() bool {
+	return r.Summary != nil && r.Summary.Status == TestPass
+}
@@ -0,0 +1,62 @@
+package run
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTestOutput(t *testing.T) {
+	data, err := os.ReadFile("testdata/test.jsonl")
+	require.NoError(t, err)
+
+	got, err := ParseTestOutput(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, &TestResults{
+		Files: []*TestFileResult{
+			{
+				Path:   "tests/invalid.tftest.hcl",
+				Status: TestFail,
+				Runs: []*TestRunResult{
+					{
+						Name:   "prefix",
+						Status: TestFail,
+						Diagnostics: []*Diagnostic{
+							{Severity: "error", Summary: "Test assertion failed", Detail: "pet name has wrong prefix"},
+						},
+					},
+				},
+			},
+			{
+				Path:   "tests/main.tftest.hcl",
+				Status: TestPass,
+				Runs: []*TestRunResult{
+					{Name: "setup", Status: TestPass},
+					{Name: "pet_length", Status: TestPass},
+				},
+			},
+		},
+		Summary: &TestSummary{Status: TestFail, Passed: 2, Failed: 1},
+	}, got)
+	assert.False(t, got.Passed())
+}
+
+func TestParseTestOutput_Interrupted(t *testing.T) {
+	data := []byte(`{"@message":"Found 1 file and 1 run block","test_abstract":{"main.tftest.hcl":["setup"]},"type":"test_abstract"}`)
+
+	got, err := ParseTestOutput(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*TestFileResult{
+		{
+			Path:   "main.tftest.hcl",
+			Status: TestPending,
+			Runs:   []*TestRunResult{{Name: "setup", Status: TestPending}},
+		},
+	}, got.Files)
+	assert.Nil(t, got.Summary)
+	assert.False(t, got.Passed())
+}
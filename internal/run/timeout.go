@@ -0,0 +1,32 @@
+package run
+
+import (
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+const (
+	DefaultPlanTimeout  = 2 * time.Hour
+	DefaultApplyTimeout = 24 * time.Hour
+)
+
+// PhaseTimeouts are the site-wide maximum durations of the plan and apply
+// phases, unless overridden by a workspace. They are configured on otfd
+// alone: otfd resolves the timeout of a phase when an agent starts the phase,
+// and the reaper uses the same timeouts, so the two always agree.
+type PhaseTimeouts struct {
+	Plan  time.Duration
+	Apply time.Duration
+}
+
+// forWorkspace returns the maximum duration of the given phase of the
+// workspace's runs.
+func (t PhaseTimeouts) forWorkspace(ws *workspace.Workspace, phase internal.PhaseType) time.Duration {
+	siteDefault := t.Plan
+	if phase == internal.ApplyPhase {
+		siteDefault = t.Apply
+	}
+	return ws.PhaseTimeout(phase, siteDefault)
+}
@@ -0,0 +1,22 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseTimeouts_forWorkspace(t *testing.T) {
+	timeouts := PhaseTimeouts{Plan: 2 * time.Hour, Apply: 24 * time.Hour}
+
+	ws := &workspace.Workspace{}
+	assert.Equal(t, 2*time.Hour, timeouts.forWorkspace(ws, internal.PlanPhase))
+	assert.Equal(t, 24*time.Hour, timeouts.forWorkspace(ws, internal.ApplyPhase))
+
+	ws = &workspace.Workspace{ApplyTimeout: time.Hour}
+	assert.Equal(t, 2*time.Hour, timeouts.forWorkspace(ws, internal.PlanPhase))
+	assert.Equal(t, time.Hour, timeouts.forWorkspace(ws, internal.ApplyPhase))
+}
@@ -3,6 +3,7 @@ package run
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/go-logr/logr"
@@ -38,13 +39,19 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/workspaces/{workspace_id}/runs", h.list).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/start-run", h.createRun).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/destroy-and-delete", h.destroyAndDelete).Methods("POST")
 	r.HandleFunc("/runs/{run_id}", h.get).Methods("GET")
 	r.HandleFunc("/runs/{run_id}/widget", h.getWidget).Methods("GET")
+	r.HandleFunc("/runs/{run_id}/plan-diff", h.planDiff).Methods("GET")
 	r.HandleFunc("/runs/{run_id}/delete", h.delete).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/cancel", h.cancel).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/apply", h.apply).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/discard", h.discard).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/retry", h.retry).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/approve", h.approve).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/reject", h.reject).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/comment", h.comment).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/watch-comments", h.watchComments).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/watch", h.watch).Methods("GET")
 
 	// this handles the link the terraform CLI shows during a plan/apply.
@@ -75,6 +82,24 @@ func (h *webHandlers) createRun(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.Run(run.ID), http.StatusFound)
 }
 
+func (h *webHandlers) destroyAndDelete(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	run, err := h.svc.DestroyAndDeleteWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(workspaceID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "queued destroy run: workspace will be deleted once it has been applied")
+	http.Redirect(w, r, paths.Run(run.ID), http.StatusFound)
+}
+
 func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		WorkspaceID string `schema:"workspace_id,required"`
@@ -162,19 +187,172 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get structured output for each phase, which is only present if the
+	// workspace has structured run output enabled.
+	planOutput, err := h.getStructuredOutput(r.Context(), run.ID, internal.PlanPhase)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	applyOutput, err := h.getStructuredOutput(r.Context(), run.ID, internal.ApplyPhase)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var testResults *TestResults
+	if run.Test {
+		testResults, err = h.svc.GetTestResults(r.Context(), run.ID)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Only offer the user the chance to approve or reject the run if it is
+	// awaiting approval and the user is permitted to do so.
+	var canApprove bool
+	if run.AwaitingApproval() {
+		canApprove, err = h.canApprove(r.Context(), run)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	comments, err := h.svc.ListComments(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	canComment, err := h.canComment(r.Context(), run)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	h.Render("run_get.tmpl", w, struct {
 		workspace.WorkspacePage
-		Run       *Run
-		PlanLogs  internal.Chunk
-		ApplyLogs internal.Chunk
+		Run         *Run
+		PlanLogs    internal.Chunk
+		ApplyLogs   internal.Chunk
+		PlanOutput  *StructuredOutput
+		ApplyOutput *StructuredOutput
+		TestResults *TestResults
+		CanApprove  bool
+		Comments    []*Comment
+		CanComment  bool
 	}{
 		WorkspacePage: workspace.NewPage(r, run.ID, ws),
 		Run:           run,
 		PlanLogs:      internal.Chunk{Data: planLogs},
 		ApplyLogs:     internal.Chunk{Data: applyLogs},
+		PlanOutput:    planOutput,
+		ApplyOutput:   applyOutput,
+		TestResults:   testResults,
+		CanApprove:    canApprove,
+		Comments:      comments,
+		CanComment:    canComment,
 	})
 }
 
+// canComment determines whether the subject in the context can comment on the
+// run.
+func (h *webHandlers) canComment(ctx context.Context, run *Run) (bool, error) {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	policy, err := h.GetPolicy(ctx, run.WorkspaceID)
+	if err != nil {
+		return false, err
+	}
+	return subject.CanAccessWorkspace(rbac.CommentRunAction, policy), nil
+}
+
+// canApprove determines whether the user in the context can approve or reject
+// the run.
+func (h *webHandlers) canApprove(ctx context.Context, run *Run) (bool, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	policy, err := h.GetPolicy(ctx, run.WorkspaceID)
+	if err != nil {
+		return false, err
+	}
+	if !user.CanAccessWorkspace(rbac.ApproveRunAction, policy) {
+		return false, nil
+	}
+	approvalPolicy, err := h.GetApprovalPolicy(ctx, run.WorkspaceID)
+	if err != nil {
+		return false, err
+	}
+	return checkApprover(run, approvalPolicy, user) == nil, nil
+}
+
+// planDiff renders a resource-level diff of the run's plan, filtered by the
+// query parameters.
+func (h *webHandlers) planDiff(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"run_id,required"`
+		PlanDiffFilter
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	run, err := h.svc.GetRun(r.Context(), params.RunID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ws, err := h.GetWorkspace(r.Context(), run.WorkspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	diff, err := h.svc.GetPlanDiff(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// list all modules for the filter, not just those matching the filter
+	var modules []string
+	for _, mod := range diff.Modules {
+		modules = append(modules, mod.Name())
+	}
+
+	h.Render("run_plan_diff.tmpl", w, struct {
+		workspace.WorkspacePage
+		Run     *Run
+		Diff    *PlanDiff
+		Filter  PlanDiffFilter
+		Modules []string
+		Actions []DiffAction
+	}{
+		WorkspacePage: workspace.NewPage(r, "plan diff | "+run.ID, ws),
+		Run:           run,
+		Diff:          diff.Filter(params.PlanDiffFilter),
+		Filter:        params.PlanDiffFilter,
+		Modules:       modules,
+		Actions:       []DiffAction{DiffCreate, DiffUpdate, DiffReplace, DiffDelete, DiffRead},
+	})
+}
+
+// getStructuredOutput retrieves and parses the structured output for a run
+// phase, returning nil if there is none.
+func (h *webHandlers) getStructuredOutput(ctx context.Context, runID string, phase internal.PhaseType) (*StructuredOutput, error) {
+	data, err := h.svc.GetStructuredOutput(ctx, runID, phase)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return ParseStructuredOutput(data)
+}
+
 // getWidget renders a run "widget", i.e. the container that
 // contains info about a run. Intended for use with an ajax request.
 func (h *webHandlers) getWidget(w http.ResponseWriter, r *http.Request) {
@@ -244,7 +422,11 @@ func (h *webHandlers) apply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.svc.Apply(r.Context(), runID)
-	if err != nil {
+	if errors.Is(err, internal.ErrSavedPlanStale) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -266,6 +448,100 @@ func (h *webHandlers) discard(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.Run(runID), http.StatusFound)
 }
 
+func (h *webHandlers) approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, ApprovedDecision)
+}
+
+func (h *webHandlers) reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, RejectedDecision)
+}
+
+func (h *webHandlers) decide(w http.ResponseWriter, r *http.Request, decision ApprovalDecision) {
+	var params struct {
+		RunID   string `schema:"run_id,required"`
+		Comment string `schema:"comment"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	var err error
+	if decision == ApprovedDecision {
+		_, err = h.svc.ApproveRun(r.Context(), params.RunID, params.Comment)
+	} else {
+		_, err = h.svc.RejectRun(r.Context(), params.RunID, params.Comment)
+	}
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(params.RunID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, string(decision)+" run")
+	http.Redirect(w, r, paths.Run(params.RunID)+"#approvals", http.StatusFound)
+}
+
+func (h *webHandlers) comment(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"run_id,required"`
+		Body  string `schema:"body"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if _, err := h.svc.AddComment(r.Context(), params.RunID, params.Body); err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(params.RunID), http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, paths.Run(params.RunID)+"#comments", http.StatusFound)
+}
+
+// watchComments streams comments as they are added to a run, rendering each
+// as an HTML snippet to be appended to the run's comment thread.
+func (h *webHandlers) watchComments(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	comments, err := h.svc.WatchComments(r.Context(), runID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	rc.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case comment, ok := <-comments:
+			if !ok {
+				return
+			}
+			buf := new(bytes.Buffer)
+			if err := h.RenderTemplate("run_comment.tmpl", buf, comment); err != nil {
+				h.logger.Error(err, "rendering template for run comment")
+				continue
+			}
+			pubsub.WriteSSEEvent(w, buf.Bytes(), "run-comment", false)
+			rc.Flush()
+		}
+	}
+}
+
 func (h *webHandlers) retry(w http.ResponseWriter, r *http.Request) {
 	runID, err := decode.Param("run_id", r)
 	if err != nil {
@@ -3,6 +3,7 @@ package run
 import (
 	"fmt"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/leg100/otf/internal"
@@ -11,6 +12,7 @@ import (
 	"github.com/leg100/otf/internal/testutils"
 	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListRunsHandler(t *testing.T) {
@@ -61,11 +63,102 @@ func TestWeb_GetHandler(t *testing.T) {
 	)
 
 	r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
 	w := httptest.NewRecorder()
 	h.get(w, r)
 	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
 }
 
+func TestWeb_GetHandler_Comments(t *testing.T) {
+	h := newTestWebHandlers(t,
+		withWorkspace(&workspace.Workspace{ID: "ws-123"}),
+		withRuns(&Run{ID: "run-123", WorkspaceID: "ws-1"}),
+		withComments(&Comment{ID: "wsc-123", RunID: "run-123", Author: "bobby", Body: "looks good to me"}),
+	)
+
+	t.Run("can comment", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+		r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor", SiteAdmin: true}))
+		w := httptest.NewRecorder()
+		h.get(w, r)
+		assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+		assert.Contains(t, w.Body.String(), "looks good to me")
+		assert.Contains(t, w.Body.String(), `id="add-comment-button"`)
+	})
+
+	t.Run("cannot comment", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+		r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
+		w := httptest.NewRecorder()
+		h.get(w, r)
+		assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+		assert.Contains(t, w.Body.String(), "looks good to me")
+		assert.NotContains(t, w.Body.String(), `id="add-comment-button"`)
+	})
+}
+
+func TestWeb_GetHandler_StructuredOutput(t *testing.T) {
+	output, err := os.ReadFile("testdata/apply.jsonl")
+	require.NoError(t, err)
+
+	h := newTestWebHandlers(t,
+		withWorkspace(&workspace.Workspace{ID: "ws-123"}),
+		withRuns(&Run{ID: "run-123", WorkspaceID: "ws-1"}),
+		withStructuredOutput(output),
+	)
+
+	r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+	assert.Contains(t, w.Body.String(), "random_pet.pet")
+	assert.Contains(t, w.Body.String(), "local-exec provisioner error")
+}
+
+func TestWeb_GetHandler_TestResults(t *testing.T) {
+	output, err := os.ReadFile("testdata/test.jsonl")
+	require.NoError(t, err)
+	results, err := ParseTestOutput(output)
+	require.NoError(t, err)
+
+	h := newTestWebHandlers(t,
+		withWorkspace(&workspace.Workspace{ID: "ws-123"}),
+		withRuns(&Run{ID: "run-123", WorkspaceID: "ws-1", Test: true, PlanOnly: true}),
+		withTestResults(results),
+	)
+
+	r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+	assert.Contains(t, w.Body.String(), "tests/main.tftest.hcl")
+	assert.Contains(t, w.Body.String(), "pet_length")
+	assert.Contains(t, w.Body.String(), "pet name has wrong prefix")
+	assert.NotContains(t, w.Body.String(), `id="plan-diff-link"`)
+}
+
+func TestWeb_PlanDiffHandler(t *testing.T) {
+	planJSON, err := os.ReadFile("testdata/plan_diff.json")
+	require.NoError(t, err)
+	diff, err := NewPlanDiff(planJSON)
+	require.NoError(t, err)
+
+	h := newTestWebHandlers(t,
+		withWorkspace(&workspace.Workspace{ID: "ws-123"}),
+		withRuns(&Run{ID: "run-123", WorkspaceID: "ws-123"}),
+		withPlanDiff(diff),
+	)
+
+	r := httptest.NewRequest("GET", "/?run_id=run-123&module=module.net", nil)
+	w := httptest.NewRecorder()
+	h.planDiff(w, r)
+	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+	assert.Contains(t, w.Body.String(), "module.net.aws_subnet.private[0]")
+	assert.NotContains(t, w.Body.String(), "random_pet.pet")
+}
+
 func TestRuns_CancelHandler(t *testing.T) {
 	h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1", WorkspaceID: "ws-1"}))
 
@@ -0,0 +1,111 @@
+package runtask
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/run"
+)
+
+type (
+	api struct {
+		internal.Verifier // for verifying signed plan URLs
+
+		svc *service
+	}
+
+	// callbackRequest is the JSON:API document sent by a run task when
+	// reporting its outcome.
+	callbackRequest struct {
+		Data struct {
+			Type       string `json:"type"`
+			Attributes struct {
+				Status  TaskResultStatus `json:"status"`
+				Message *string          `json:"message"`
+				URL     *string          `json:"url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+)
+
+func callbackPath(resultID string) string {
+	return fmt.Sprintf("/run-tasks/results/%s/callback", resultID)
+}
+
+func planJSONPath(runID string) string {
+	return fmt.Sprintf("/runs/%s/plan.json", runID)
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	// run tasks authenticate using the access token they were sent
+	r.HandleFunc("/run-tasks/results/{task_result_id}/callback", a.callback).Methods("PATCH")
+
+	// run tasks retrieve the plan using a signed URL they were sent
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(a.Verifier))
+	signed.HandleFunc("/runs/{run_id}/plan.json", a.getPlanJSON).Methods("GET")
+}
+
+func (a *api) callback(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("task_result_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return
+	}
+	var req callbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// the task is not an authenticated subject so use superuser privileges
+	// once the token has been verified by the service.
+	ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "run-task-callback"})
+	err = a.svc.callback(ctx, id, token, CallbackOptions{
+		Status:  req.Data.Attributes.Status,
+		Message: req.Data.Attributes.Message,
+		URL:     req.Data.Attributes.URL,
+	})
+	switch {
+	case errors.Is(err, internal.ErrUnauthorized):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, internal.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidCallbackStatus):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, ErrTaskResultCompleted):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (a *api) getPlanJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("run_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	// the signature authorizes access to the plan
+	ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "run-task"})
+	plan, err := a.svc.GetPlanFile(ctx, id, run.PlanFormatJSON)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(plan)
+}
@@ -0,0 +1,301 @@
+package runtask
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a run task database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// taskRow is a database row for a run task
+	taskRow struct {
+		RunTaskID        pgtype.Text        `json:"run_task_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		Name             pgtype.Text        `json:"name"`
+		Description      pgtype.Text        `json:"description"`
+		URL              pgtype.Text        `json:"url"`
+		HmacKey          pgtype.Text        `json:"hmac_key"`
+		Enabled          bool               `json:"enabled"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+
+	// workspaceTaskRow is a database row for a workspace run task
+	workspaceTaskRow struct {
+		WorkspaceRunTaskID pgtype.Text     `json:"workspace_run_task_id"`
+		Stage              pgtype.Text     `json:"stage"`
+		EnforcementLevel   pgtype.Text     `json:"enforcement_level"`
+		WorkspaceID        pgtype.Text     `json:"workspace_id"`
+		RunTask            *pggen.RunTasks `json:"run_task"`
+	}
+
+	// resultRow is a database row for a task result
+	resultRow struct {
+		TaskResultID     pgtype.Text        `json:"task_result_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		RunTaskID        pgtype.Text        `json:"run_task_id"`
+		TaskName         pgtype.Text        `json:"task_name"`
+		TaskURL          pgtype.Text        `json:"task_url"`
+		Stage            pgtype.Text        `json:"stage"`
+		EnforcementLevel pgtype.Text        `json:"enforcement_level"`
+		Status           pgtype.Text        `json:"status"`
+		Message          pgtype.Text        `json:"message"`
+		URL              pgtype.Text        `json:"url"`
+		AccessToken      pgtype.Text        `json:"access_token"`
+		RunID            pgtype.Text        `json:"run_id"`
+	}
+)
+
+func (r taskRow) toRunTask() *RunTask {
+	return &RunTask{
+		ID:           r.RunTaskID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		Organization: r.OrganizationName.String,
+		Name:         r.Name.String,
+		Description:  r.Description.String,
+		URL:          r.URL.String,
+		HMACKey:      r.HmacKey.String,
+		Enabled:      r.Enabled,
+	}
+}
+
+func (r workspaceTaskRow) toWorkspaceRunTask() *WorkspaceRunTask {
+	return &WorkspaceRunTask{
+		ID:               r.WorkspaceRunTaskID.String,
+		WorkspaceID:      r.WorkspaceID.String,
+		Stage:            run.TaskStage(r.Stage.String),
+		EnforcementLevel: EnforcementLevel(r.EnforcementLevel.String),
+		RunTask:          taskRow(*r.RunTask).toRunTask(),
+	}
+}
+
+func (r resultRow) toTaskResult() *TaskResult {
+	return &TaskResult{
+		ID:               r.TaskResultID.String,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		UpdatedAt:        r.UpdatedAt.Time.UTC(),
+		RunID:            r.RunID.String,
+		TaskID:           r.RunTaskID.String,
+		TaskName:         r.TaskName.String,
+		TaskURL:          r.TaskURL.String,
+		Stage:            run.TaskStage(r.Stage.String),
+		EnforcementLevel: EnforcementLevel(r.EnforcementLevel.String),
+		Status:           TaskResultStatus(r.Status.String),
+		Message:          r.Message.String,
+		URL:              r.URL.String,
+		AccessToken:      r.AccessToken.String,
+	}
+}
+
+func (db *pgdb) createTask(ctx context.Context, task *RunTask) error {
+	_, err := db.Conn(ctx).InsertRunTask(ctx, pggen.InsertRunTaskParams{
+		RunTaskID:        sql.String(task.ID),
+		CreatedAt:        sql.Timestamptz(task.CreatedAt),
+		Name:             sql.String(task.Name),
+		Description:      sql.String(task.Description),
+		URL:              sql.String(task.URL),
+		HmacKey:          sql.String(task.HMACKey),
+		Enabled:          task.Enabled,
+		OrganizationName: sql.String(task.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) updateTask(ctx context.Context, id string, fn func(*RunTask) error) (*RunTask, error) {
+	var task *RunTask
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindRunTaskByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		task = taskRow(row).toRunTask()
+		if err := fn(task); err != nil {
+			return err
+		}
+		_, err = q.UpdateRunTaskByID(ctx, pggen.UpdateRunTaskByIDParams{
+			Name:        sql.String(task.Name),
+			Description: sql.String(task.Description),
+			URL:         sql.String(task.URL),
+			HmacKey:     sql.String(task.HMACKey),
+			Enabled:     task.Enabled,
+			RunTaskID:   sql.String(task.ID),
+		})
+		return sql.Error(err)
+	})
+	return task, err
+}
+
+func (db *pgdb) getTask(ctx context.Context, id string) (*RunTask, error) {
+	row, err := db.Conn(ctx).FindRunTaskByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return taskRow(row).toRunTask(), nil
+}
+
+func (db *pgdb) listTasks(ctx context.Context, organization string) ([]*RunTask, error) {
+	rows, err := db.Conn(ctx).FindRunTasksByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	tasks := make([]*RunTask, len(rows))
+	for i, r := range rows {
+		tasks[i] = taskRow(r).toRunTask()
+	}
+	return tasks, nil
+}
+
+func (db *pgdb) deleteTask(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRunTaskByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createWorkspaceTask(ctx context.Context, wrt *WorkspaceRunTask) error {
+	_, err := db.Conn(ctx).InsertWorkspaceRunTask(ctx, pggen.InsertWorkspaceRunTaskParams{
+		WorkspaceRunTaskID: sql.String(wrt.ID),
+		Stage:              sql.String(string(wrt.Stage)),
+		EnforcementLevel:   sql.String(string(wrt.EnforcementLevel)),
+		RunTaskID:          sql.String(wrt.RunTask.ID),
+		WorkspaceID:        sql.String(wrt.WorkspaceID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) updateWorkspaceTask(ctx context.Context, id string, fn func(*WorkspaceRunTask) error) (*WorkspaceRunTask, error) {
+	var wrt *WorkspaceRunTask
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindWorkspaceRunTaskByID(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		wrt = workspaceTaskRow(row).toWorkspaceRunTask()
+		if err := fn(wrt); err != nil {
+			return err
+		}
+		_, err = q.UpdateWorkspaceRunTaskByID(ctx, pggen.UpdateWorkspaceRunTaskByIDParams{
+			Stage:              sql.String(string(wrt.Stage)),
+			EnforcementLevel:   sql.String(string(wrt.EnforcementLevel)),
+			WorkspaceRunTaskID: sql.String(wrt.ID),
+		})
+		return sql.Error(err)
+	})
+	return wrt, err
+}
+
+func (db *pgdb) getWorkspaceTask(ctx context.Context, id string) (*WorkspaceRunTask, error) {
+	row, err := db.Conn(ctx).FindWorkspaceRunTaskByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return workspaceTaskRow(row).toWorkspaceRunTask(), nil
+}
+
+func (db *pgdb) listWorkspaceTasks(ctx context.Context, workspaceID string) ([]*WorkspaceRunTask, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceRunTasksByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	tasks := make([]*WorkspaceRunTask, len(rows))
+	for i, r := range rows {
+		tasks[i] = workspaceTaskRow(r).toWorkspaceRunTask()
+	}
+	return tasks, nil
+}
+
+func (db *pgdb) deleteWorkspaceTask(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteWorkspaceRunTaskByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+// createResults creates task results in a single transaction.
+func (db *pgdb) createResults(ctx context.Context, results []*TaskResult) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, r := range results {
+			_, err := q.InsertTaskResult(ctx, pggen.InsertTaskResultParams{
+				TaskResultID:     sql.String(r.ID),
+				CreatedAt:        sql.Timestamptz(r.CreatedAt),
+				UpdatedAt:        sql.Timestamptz(r.UpdatedAt),
+				RunTaskID:        sql.String(r.TaskID),
+				TaskName:         sql.String(r.TaskName),
+				TaskURL:          sql.String(r.TaskURL),
+				Stage:            sql.String(string(r.Stage)),
+				EnforcementLevel: sql.String(string(r.EnforcementLevel)),
+				Status:           sql.String(string(r.Status)),
+				Message:          sql.String(r.Message),
+				URL:              sql.String(r.URL),
+				AccessToken:      sql.String(r.AccessToken),
+				RunID:            sql.String(r.RunID),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) updateResult(ctx context.Context, id string, fn func(*TaskResult) error) (*TaskResult, error) {
+	var result *TaskResult
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindTaskResultByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		result = resultRow(row).toTaskResult()
+		if err := fn(result); err != nil {
+			return err
+		}
+		_, err = q.UpdateTaskResultByID(ctx, pggen.UpdateTaskResultByIDParams{
+			UpdatedAt:    sql.Timestamptz(result.UpdatedAt),
+			Status:       sql.String(string(result.Status)),
+			Message:      sql.String(result.Message),
+			URL:          sql.String(result.URL),
+			TaskResultID: sql.String(result.ID),
+		})
+		return sql.Error(err)
+	})
+	return result, err
+}
+
+func (db *pgdb) getResult(ctx context.Context, id string) (*TaskResult, error) {
+	row, err := db.Conn(ctx).FindTaskResultByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return resultRow(row).toTaskResult(), nil
+}
+
+func (db *pgdb) listResults(ctx context.Context, runID string) ([]*TaskResult, error) {
+	rows, err := db.Conn(ctx).FindTaskResultsByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	results := make([]*TaskResult, len(rows))
+	for i, r := range rows {
+		results[i] = resultRow(r).toTaskResult()
+	}
+	return results, nil
+}
+
+// listIncompleteResults lists results that were created before the given time
+// and have yet to complete.
+func (db *pgdb) listIncompleteResults(ctx context.Context, before time.Time) ([]*TaskResult, error) {
+	rows, err := db.Conn(ctx).FindIncompleteTaskResultsCreatedBefore(ctx, sql.Timestamptz(before))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	results := make([]*TaskResult, len(rows))
+	for i, r := range rows {
+		results[i] = resultRow(r).toTaskResult()
+	}
+	return results, nil
+}
@@ -0,0 +1,339 @@
+package runtask
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+const (
+	// LockID guarantees only one dispatcher on a cluster is running at any
+	// time.
+	LockID int64 = 5577006791947779412
+
+	// signatureHeader is the header containing the HMAC signature of the
+	// request body, sent only when the task has an HMAC key.
+	signatureHeader = "X-TFC-Task-Signature"
+
+	// defaultTimeout is how long a task has to report its outcome before its
+	// result is errored.
+	defaultTimeout = 10 * time.Minute
+)
+
+type (
+	// Dispatcher sends requests to run tasks whenever a run reaches a stage
+	// to which tasks are attached, and errors tasks that fail to respond in
+	// time.
+	Dispatcher struct {
+		logr.Logger
+		pubsub.Subscriber
+		run.RunService
+		workspace.WorkspaceService
+		internal.HostnameService
+		internal.Signer // for signing plan JSON URL
+
+		db      *pgdb
+		client  *http.Client
+		timeout time.Duration
+	}
+
+	DispatcherOptions struct {
+		logr.Logger
+		pubsub.Subscriber
+		run.RunService
+		workspace.WorkspaceService
+		internal.HostnameService
+		internal.Signer
+		*sql.DB
+	}
+
+	// payload is the request body sent to a run task
+	payload struct {
+		PayloadVersion             int              `json:"payload_version"`
+		AccessToken                string           `json:"access_token"`
+		Stage                      run.TaskStage    `json:"stage"`
+		IsSpeculative              bool             `json:"is_speculative"`
+		TaskResultID               string           `json:"task_result_id"`
+		TaskResultEnforcementLevel EnforcementLevel `json:"task_result_enforcement_level"`
+		TaskResultCallbackURL      string           `json:"task_result_callback_url"`
+		RunAppURL                  string           `json:"run_app_url"`
+		RunID                      string           `json:"run_id"`
+		RunMessage                 string           `json:"run_message"`
+		RunCreatedAt               time.Time        `json:"run_created_at"`
+		RunCreatedBy               *string          `json:"run_created_by"`
+		WorkspaceID                string           `json:"workspace_id"`
+		WorkspaceName              string           `json:"workspace_name"`
+		WorkspaceAppURL            string           `json:"workspace_app_url"`
+		WorkspaceWorkingDirectory  string           `json:"workspace_working_directory"`
+		OrganizationName           string           `json:"organization_name"`
+		PlanJSONAPIURL             *string          `json:"plan_json_api_url"`
+		ConfigurationVersionID     string           `json:"configuration_version_id"`
+		VCSRepoURL                 *string          `json:"vcs_repo_url"`
+		VCSBranch                  *string          `json:"vcs_branch"`
+		VCSPullRequestURL          *string          `json:"vcs_pull_request_url"`
+		VCSCommitURL               *string          `json:"vcs_commit_url"`
+	}
+)
+
+func NewDispatcher(opts DispatcherOptions) *Dispatcher {
+	return &Dispatcher{
+		Logger:           opts.Logger.WithValues("component", "run-task-dispatcher"),
+		Subscriber:       opts.Subscriber,
+		RunService:       opts.RunService,
+		WorkspaceService: opts.WorkspaceService,
+		HostnameService:  opts.HostnameService,
+		Signer:           opts.Signer,
+		db:               &pgdb{opts.DB},
+		client:           &http.Client{Timeout: 10 * time.Second},
+		timeout:          defaultTimeout,
+	}
+}
+
+// Start the dispatcher. Should be started in a go-routine.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	// Unsubscribe Subscribe() whenever exiting this routine.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub, err := d.Subscribe(ctx, "run-task-dispatcher-")
+	if err != nil {
+		return err
+	}
+
+	// handle runs that reached a task stage whilst the dispatcher was not
+	// running
+	existing, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*run.Run], error) {
+		return d.ListRuns(ctx, run.ListOptions{
+			PageOptions: opts,
+			Statuses: []internal.RunStatus{
+				internal.RunPrePlanRunning,
+				internal.RunPostPlanRunning,
+				internal.RunPreApplyRunning,
+			},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("retrieving runs awaiting tasks: %w", err)
+	}
+	for _, r := range existing {
+		if err := d.handleRun(ctx, r); err != nil {
+			d.Error(err, "handling run", "run_id", r.ID)
+		}
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return nil
+			}
+			r, ok := event.Payload.(*run.Run)
+			if !ok || event.Type == pubsub.DeletedEvent {
+				continue
+			}
+			if err := d.handleRun(ctx, r); err != nil {
+				d.Error(err, "handling run", "run_id", r.ID)
+			}
+		case <-ticker.C:
+			if err := d.expire(ctx); err != nil {
+				d.Error(err, "expiring task results")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleRun dispatches requests to the tasks for the stage the run is awaiting,
+// if any.
+func (d *Dispatcher) handleRun(ctx context.Context, r *run.Run) error {
+	stage, ok := r.TaskStage()
+	if !ok {
+		return nil
+	}
+
+	// skip stage if results have already been created for it, which occurs
+	// when the dispatcher is restarted.
+	existing, err := d.db.listResults(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	for _, result := range existing {
+		if result.Stage == stage {
+			return finishStage(ctx, d.db, d.RunService, r.ID, stage)
+		}
+	}
+
+	attached, err := d.db.listWorkspaceTasks(ctx, r.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	var results []*TaskResult
+	for _, wrt := range attached {
+		if wrt.Stage == stage && wrt.RunTask.Enabled {
+			results = append(results, newTaskResult(r.ID, wrt))
+		}
+	}
+	if len(results) == 0 {
+		// tasks were detached or disabled since the run entered the stage
+		_, err := d.FinishTaskStage(ctx, r.ID, stage, true)
+		return err
+	}
+	if err := d.db.createResults(ctx, results); err != nil {
+		return err
+	}
+
+	ws, err := d.GetWorkspace(ctx, r.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		go func(result *TaskResult) {
+			if err := d.send(ctx, r, ws, result); err != nil {
+				d.Error(err, "sending run task request", "task", result.TaskName, "run_id", r.ID)
+			}
+		}(result)
+	}
+	return nil
+}
+
+// send sends a request to a run task, updating its result accordingly.
+func (d *Dispatcher) send(ctx context.Context, r *run.Run, ws *workspace.Workspace, result *TaskResult) error {
+	task, err := d.db.getTask(ctx, result.TaskID)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(d.newPayload(r, ws, result))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", task.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if task.HMACKey != "" {
+		req.Header.Set(signatureHeader, sign(body, task.HMACKey))
+	}
+
+	status, message := TaskResultRunning, ""
+	resp, err := d.client.Do(req)
+	if err != nil {
+		status, message = TaskResultUnreachable, err.Error()
+	} else {
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			status, message = TaskResultErrored, fmt.Sprintf("task responded with status: %s", resp.Status)
+		}
+	}
+	return d.update(ctx, result.ID, status, message)
+}
+
+// update the status of a result, finishing the stage if all its results have
+// completed.
+func (d *Dispatcher) update(ctx context.Context, id string, status TaskResultStatus, message string) error {
+	result, err := d.db.updateResult(ctx, id, func(result *TaskResult) error {
+		if result.Done() {
+			// task has already reported its outcome
+			return nil
+		}
+		// a task may have called back before responding
+		if result.Status == TaskResultRunning && status == TaskResultRunning {
+			return nil
+		}
+		result.Status = status
+		result.Message = message
+		result.UpdatedAt = internal.CurrentTimestamp()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Done() {
+		return nil
+	}
+	return finishStage(ctx, d.db, d.RunService, result.RunID, result.Stage)
+}
+
+// expire errors results that have not completed within the timeout.
+func (d *Dispatcher) expire(ctx context.Context) error {
+	results, err := d.db.listIncompleteResults(ctx, internal.CurrentTimestamp().Add(-d.timeout))
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		d.V(1).Info("task timed out", "task", result.TaskName, "run_id", result.RunID)
+		if err := d.update(ctx, result.ID, TaskResultErrored, "task timed out"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) newPayload(r *run.Run, ws *workspace.Workspace, result *TaskResult) payload {
+	p := payload{
+		PayloadVersion:             1,
+		AccessToken:                result.AccessToken,
+		Stage:                      result.Stage,
+		IsSpeculative:              r.PlanOnly,
+		TaskResultID:               result.ID,
+		TaskResultEnforcementLevel: result.EnforcementLevel,
+		TaskResultCallbackURL:      d.absolute(callbackPath(result.ID)),
+		RunAppURL:                  d.absolute(paths.Run(r.ID)),
+		RunID:                      r.ID,
+		RunMessage:                 r.Message,
+		RunCreatedAt:               r.CreatedAt,
+		RunCreatedBy:               r.CreatedBy,
+		WorkspaceID:                ws.ID,
+		WorkspaceName:              ws.Name,
+		WorkspaceAppURL:            d.absolute(paths.Workspace(ws.ID)),
+		WorkspaceWorkingDirectory:  ws.WorkingDirectory,
+		OrganizationName:           r.Organization,
+		ConfigurationVersionID:     r.ConfigurationVersionID,
+	}
+	if result.Stage != run.PrePlanTaskStage {
+		// plan is only available after it has finished
+		if signed, err := d.Sign(planJSONPath(r.ID), d.timeout); err == nil {
+			p.PlanJSONAPIURL = internal.String(d.absolute(signed))
+		}
+	}
+	if ia := r.IngressAttributes; ia != nil {
+		p.VCSBranch = internal.String(ia.Branch)
+		p.VCSCommitURL = internal.String(ia.CommitURL)
+		if ia.IsPullRequest {
+			p.VCSPullRequestURL = internal.String(ia.PullRequestURL)
+		}
+	}
+	return p
+}
+
+func (d *Dispatcher) absolute(path string) string {
+	return (&url.URL{Scheme: "https", Host: d.Hostname(), Path: path}).String()
+}
+
+// sign returns the hex-encoded HMAC-SHA512 signature of the body using the
+// given key.
+func sign(body []byte, key string) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
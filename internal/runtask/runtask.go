@@ -0,0 +1,310 @@
+// Package runtask provides run tasks, external services that are invoked at
+// various stages of a run and which report back whether the run should
+// proceed.
+package runtask
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// AdvisoryEnforcement permits a run to proceed regardless of the outcome
+	// of a task.
+	AdvisoryEnforcement EnforcementLevel = "advisory"
+	// MandatoryEnforcement errors a run if a task does not pass.
+	MandatoryEnforcement EnforcementLevel = "mandatory"
+
+	TaskResultPending     TaskResultStatus = "pending"
+	TaskResultRunning     TaskResultStatus = "running"
+	TaskResultPassed      TaskResultStatus = "passed"
+	TaskResultFailed      TaskResultStatus = "failed"
+	TaskResultErrored     TaskResultStatus = "errored"
+	TaskResultUnreachable TaskResultStatus = "unreachable"
+)
+
+var (
+	ErrInvalidStage            = errors.New("invalid run task stage")
+	ErrInvalidEnforcementLevel = errors.New("invalid run task enforcement level")
+	ErrInvalidCallbackStatus   = errors.New("invalid run task callback status")
+	ErrTaskResultCompleted     = errors.New("run task result has already completed")
+)
+
+type (
+	// RunTask is an organization-level definition of an external service to
+	// be invoked during runs.
+	RunTask struct {
+		ID           string
+		CreatedAt    time.Time
+		Organization string
+		Name         string
+		Description  string
+		URL          string
+		// HMACKey is used to sign requests sent to the URL. Optional.
+		HMACKey string
+		Enabled bool
+	}
+
+	// WorkspaceRunTask attaches a run task to a workspace at a stage of a run.
+	WorkspaceRunTask struct {
+		ID               string
+		WorkspaceID      string
+		Stage            run.TaskStage
+		EnforcementLevel EnforcementLevel
+		RunTask          *RunTask
+	}
+
+	// TaskResult is the outcome of invoking a run task for a run at a
+	// particular stage.
+	TaskResult struct {
+		ID               string
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+		RunID            string
+		TaskID           string
+		TaskName         string
+		TaskURL          string
+		Stage            run.TaskStage
+		EnforcementLevel EnforcementLevel
+		Status           TaskResultStatus
+		// Message and URL are optionally provided by the task in its callback
+		Message string
+		URL     string
+		// AccessToken authenticates the task's callback
+		AccessToken string
+	}
+
+	// EnforcementLevel determines whether a task failure prevents a run from
+	// proceeding.
+	EnforcementLevel string
+
+	// TaskResultStatus is the status of a task result.
+	TaskResultStatus string
+
+	CreateOptions struct {
+		Organization string
+		Name         string
+		URL          string
+		Description  *string
+		HMACKey      *string
+		Enabled      *bool
+	}
+
+	UpdateOptions struct {
+		Name        *string
+		URL         *string
+		Description *string
+		HMACKey     *string
+		Enabled     *bool
+	}
+
+	AttachOptions struct {
+		RunTaskID        string
+		Stage            run.TaskStage
+		EnforcementLevel EnforcementLevel
+	}
+
+	UpdateAttachmentOptions struct {
+		Stage            *run.TaskStage
+		EnforcementLevel *EnforcementLevel
+	}
+
+	// CallbackOptions are the options sent by a run task when reporting its
+	// outcome.
+	CallbackOptions struct {
+		Status  TaskResultStatus
+		Message *string
+		URL     *string
+	}
+)
+
+func newRunTask(opts CreateOptions) (*RunTask, error) {
+	if opts.Organization == "" {
+		return nil, &internal.MissingParameterError{Parameter: "organization"}
+	}
+	task := &RunTask{
+		ID:           internal.NewID("task"),
+		CreatedAt:    internal.CurrentTimestamp(),
+		Organization: opts.Organization,
+		Enabled:      true,
+	}
+	err := task.update(UpdateOptions{
+		Name:        &opts.Name,
+		URL:         &opts.URL,
+		Description: opts.Description,
+		HMACKey:     opts.HMACKey,
+		Enabled:     opts.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (t *RunTask) String() string { return t.Name }
+
+// LogValue implements slog.LogValuer.
+func (t *RunTask) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", t.ID),
+		slog.String("organization", t.Organization),
+		slog.String("name", t.Name),
+		slog.String("url", t.URL),
+		slog.Bool("enabled", t.Enabled),
+	)
+}
+
+func (t *RunTask) update(opts UpdateOptions) error {
+	if opts.Name != nil {
+		if *opts.Name == "" {
+			return fmt.Errorf("name cannot be an empty string")
+		}
+		t.Name = *opts.Name
+	}
+	if opts.URL != nil {
+		u, err := url.Parse(*opts.URL)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid url: must be http or https")
+		}
+		t.URL = *opts.URL
+	}
+	if opts.Description != nil {
+		t.Description = *opts.Description
+	}
+	if opts.HMACKey != nil {
+		t.HMACKey = *opts.HMACKey
+	}
+	if opts.Enabled != nil {
+		t.Enabled = *opts.Enabled
+	}
+	return nil
+}
+
+func newWorkspaceRunTask(workspaceID string, task *RunTask, opts AttachOptions) (*WorkspaceRunTask, error) {
+	wrt := &WorkspaceRunTask{
+		ID:               internal.NewID("wstask"),
+		WorkspaceID:      workspaceID,
+		Stage:            run.PostPlanTaskStage,
+		EnforcementLevel: AdvisoryEnforcement,
+		RunTask:          task,
+	}
+	var stage *run.TaskStage
+	if opts.Stage != "" {
+		stage = &opts.Stage
+	}
+	var level *EnforcementLevel
+	if opts.EnforcementLevel != "" {
+		level = &opts.EnforcementLevel
+	}
+	if err := wrt.update(UpdateAttachmentOptions{Stage: stage, EnforcementLevel: level}); err != nil {
+		return nil, err
+	}
+	return wrt, nil
+}
+
+// LogValue implements slog.LogValuer.
+func (t *WorkspaceRunTask) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", t.ID),
+		slog.String("workspace_id", t.WorkspaceID),
+		slog.String("task", t.RunTask.Name),
+		slog.String("stage", string(t.Stage)),
+		slog.String("enforcement_level", string(t.EnforcementLevel)),
+	)
+}
+
+func (t *WorkspaceRunTask) update(opts UpdateAttachmentOptions) error {
+	if opts.Stage != nil {
+		switch *opts.Stage {
+		case run.PrePlanTaskStage, run.PostPlanTaskStage, run.PreApplyTaskStage:
+			t.Stage = *opts.Stage
+		default:
+			return ErrInvalidStage
+		}
+	}
+	if opts.EnforcementLevel != nil {
+		switch *opts.EnforcementLevel {
+		case AdvisoryEnforcement, MandatoryEnforcement:
+			t.EnforcementLevel = *opts.EnforcementLevel
+		default:
+			return ErrInvalidEnforcementLevel
+		}
+	}
+	return nil
+}
+
+func newTaskResult(runID string, wrt *WorkspaceRunTask) *TaskResult {
+	return &TaskResult{
+		ID:               internal.NewID("taskrs"),
+		CreatedAt:        internal.CurrentTimestamp(),
+		UpdatedAt:        internal.CurrentTimestamp(),
+		RunID:            runID,
+		TaskID:           wrt.RunTask.ID,
+		TaskName:         wrt.RunTask.Name,
+		TaskURL:          wrt.RunTask.URL,
+		Stage:            wrt.Stage,
+		EnforcementLevel: wrt.EnforcementLevel,
+		Status:           TaskResultPending,
+		AccessToken:      internal.GenerateRandomString(32),
+	}
+}
+
+// Done determines whether the task has reported its final outcome.
+func (r *TaskResult) Done() bool {
+	switch r.Status {
+	case TaskResultPending, TaskResultRunning:
+		return false
+	default:
+		return true
+	}
+}
+
+// Blocking determines whether the result prevents the run from proceeding.
+func (r *TaskResult) Blocking() bool {
+	return r.EnforcementLevel == MandatoryEnforcement && r.Status != TaskResultPassed
+}
+
+// callback updates the result with the outcome reported by the task.
+func (r *TaskResult) callback(opts CallbackOptions) error {
+	if r.Done() {
+		return fmt.Errorf("%w: %s", ErrTaskResultCompleted, r.Status)
+	}
+	switch opts.Status {
+	case TaskResultRunning, TaskResultPassed, TaskResultFailed:
+	default:
+		return ErrInvalidCallbackStatus
+	}
+	r.Status = opts.Status
+	if opts.Message != nil {
+		r.Message = *opts.Message
+	}
+	if opts.URL != nil {
+		r.URL = *opts.URL
+	}
+	r.UpdatedAt = internal.CurrentTimestamp()
+	return nil
+}
+
+// stagePassed determines whether all results for a stage have completed and,
+// if so, whether the run may proceed.
+func stagePassed(results []*TaskResult) (done, passed bool) {
+	passed = true
+	for _, r := range results {
+		if !r.Done() {
+			return false, false
+		}
+		if r.Blocking() {
+			passed = false
+		}
+	}
+	return true, passed
+}
@@ -0,0 +1,94 @@
+package runtask
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateOptions
+		wantErr bool
+	}{
+		{"valid", CreateOptions{Organization: "acme", Name: "checkov", URL: "https://checkov.example.com"}, false},
+		{"missing organization", CreateOptions{Name: "checkov", URL: "https://checkov.example.com"}, true},
+		{"missing name", CreateOptions{Organization: "acme", URL: "https://checkov.example.com"}, true},
+		{"invalid url", CreateOptions{Organization: "acme", Name: "checkov", URL: "ftp://checkov.example.com"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRunTask(tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTaskResult_Callback(t *testing.T) {
+	t.Run("passed", func(t *testing.T) {
+		result := &TaskResult{Status: TaskResultRunning}
+		err := result.callback(CallbackOptions{Status: TaskResultPassed, Message: internal.String("all good")})
+		require.NoError(t, err)
+		assert.Equal(t, TaskResultPassed, result.Status)
+		assert.Equal(t, "all good", result.Message)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		result := &TaskResult{Status: TaskResultRunning}
+		err := result.callback(CallbackOptions{Status: TaskResultErrored})
+		assert.ErrorIs(t, err, ErrInvalidCallbackStatus)
+	})
+
+	t.Run("already completed", func(t *testing.T) {
+		result := &TaskResult{Status: TaskResultFailed}
+		err := result.callback(CallbackOptions{Status: TaskResultPassed})
+		assert.ErrorIs(t, err, ErrTaskResultCompleted)
+	})
+}
+
+func TestStagePassed(t *testing.T) {
+	tests := []struct {
+		name       string
+		results    []*TaskResult
+		wantDone   bool
+		wantPassed bool
+	}{
+		{
+			name: "incomplete",
+			results: []*TaskResult{
+				{Status: TaskResultPassed, EnforcementLevel: MandatoryEnforcement},
+				{Status: TaskResultRunning, EnforcementLevel: MandatoryEnforcement},
+			},
+		},
+		{
+			name: "advisory failure",
+			results: []*TaskResult{
+				{Status: TaskResultPassed, EnforcementLevel: MandatoryEnforcement},
+				{Status: TaskResultFailed, EnforcementLevel: AdvisoryEnforcement},
+			},
+			wantDone:   true,
+			wantPassed: true,
+		},
+		{
+			name: "mandatory failure",
+			results: []*TaskResult{
+				{Status: TaskResultUnreachable, EnforcementLevel: MandatoryEnforcement},
+			},
+			wantDone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, passed := stagePassed(tt.results)
+			assert.Equal(t, tt.wantDone, done)
+			assert.Equal(t, tt.wantPassed, passed)
+		})
+	}
+}
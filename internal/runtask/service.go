@@ -0,0 +1,362 @@
+package runtask
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	RunTaskService = Service
+
+	Service interface {
+		CreateRunTask(ctx context.Context, opts CreateOptions) (*RunTask, error)
+		UpdateRunTask(ctx context.Context, id string, opts UpdateOptions) (*RunTask, error)
+		GetRunTask(ctx context.Context, id string) (*RunTask, error)
+		ListRunTasks(ctx context.Context, organization string) ([]*RunTask, error)
+		DeleteRunTask(ctx context.Context, id string) (*RunTask, error)
+
+		// AttachRunTask attaches a run task to a workspace.
+		AttachRunTask(ctx context.Context, workspaceID string, opts AttachOptions) (*WorkspaceRunTask, error)
+		UpdateWorkspaceRunTask(ctx context.Context, id string, opts UpdateAttachmentOptions) (*WorkspaceRunTask, error)
+		GetWorkspaceRunTask(ctx context.Context, id string) (*WorkspaceRunTask, error)
+		ListWorkspaceRunTasks(ctx context.Context, workspaceID string) ([]*WorkspaceRunTask, error)
+		// DetachRunTask detaches a run task from a workspace.
+		DetachRunTask(ctx context.Context, id string) (*WorkspaceRunTask, error)
+
+		GetTaskResult(ctx context.Context, id string) (*TaskResult, error)
+		ListTaskResults(ctx context.Context, runID string) ([]*TaskResult, error)
+	}
+
+	service struct {
+		logr.Logger
+		run.RunService
+		workspace.WorkspaceService
+
+		organization internal.Authorizer
+		workspace    internal.Authorizer
+		db           *pgdb
+		web          *webHandlers
+		api          *api
+	}
+
+	Options struct {
+		*sql.DB
+		html.Renderer
+		logr.Logger
+		internal.Verifier // for verifying signed plan URLs
+
+		WorkspaceAuthorizer internal.Authorizer
+		run.RunService
+		workspace.WorkspaceService
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:           opts.Logger,
+		RunService:       opts.RunService,
+		WorkspaceService: opts.WorkspaceService,
+		organization:     &organization.Authorizer{Logger: opts.Logger},
+		workspace:        opts.WorkspaceAuthorizer,
+		db:               &pgdb{opts.DB},
+	}
+	svc.web = &webHandlers{
+		Renderer:         opts.Renderer,
+		WorkspaceService: opts.WorkspaceService,
+		svc:              &svc,
+	}
+	svc.api = &api{
+		Verifier: opts.Verifier,
+		svc:      &svc,
+	}
+	// copy run tasks to cloned workspaces
+	opts.WorkspaceService.AfterCloneWorkspace(svc.cloneWorkspaceTasks)
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+	s.api.addHandlers(r)
+}
+
+func (s *service) CreateRunTask(ctx context.Context, opts CreateOptions) (*RunTask, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRunTaskAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	task, err := newRunTask(opts)
+	if err != nil {
+		s.Error(err, "constructing run task", "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createTask(ctx, task); err != nil {
+		s.Error(err, "creating run task", "task", task, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+func (s *service) UpdateRunTask(ctx context.Context, id string, opts UpdateOptions) (*RunTask, error) {
+	var subject internal.Subject
+	task, err := s.db.updateTask(ctx, id, func(task *RunTask) (err error) {
+		subject, err = s.organization.CanAccess(ctx, rbac.UpdateRunTaskAction, task.Organization)
+		if err != nil {
+			return err
+		}
+		return task.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating run task", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+func (s *service) GetRunTask(ctx context.Context, id string) (*RunTask, error) {
+	task, err := s.db.getTask(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving run task", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetRunTaskAction, task.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+func (s *service) ListRunTasks(ctx context.Context, organization string) ([]*RunTask, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListRunTasksAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.db.listTasks(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing run tasks", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run tasks", "organization", organization, "total", len(tasks), "subject", subject)
+	return tasks, nil
+}
+
+func (s *service) DeleteRunTask(ctx context.Context, id string) (*RunTask, error) {
+	task, err := s.db.getTask(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving run task", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRunTaskAction, task.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.deleteTask(ctx, id); err != nil {
+		s.Error(err, "deleting run task", "task", task, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("deleted run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+func (s *service) AttachRunTask(ctx context.Context, workspaceID string, opts AttachOptions) (*WorkspaceRunTask, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.CreateWorkspaceRunTaskAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.db.getTask(ctx, opts.RunTaskID)
+	if err != nil {
+		s.Error(err, "retrieving run task", "id", opts.RunTaskID)
+		return nil, err
+	}
+	// a workspace can only use run tasks belonging to its own organization
+	if task.Organization != ws.Organization {
+		return nil, internal.ErrResourceNotFound
+	}
+	wrt, err := newWorkspaceRunTask(workspaceID, task, opts)
+	if err != nil {
+		s.Error(err, "constructing workspace run task", "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createWorkspaceTask(ctx, wrt); err != nil {
+		s.Error(err, "attaching run task", "workspace_task", wrt, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("attached run task", "workspace_task", wrt, "subject", subject)
+	return wrt, nil
+}
+
+func (s *service) UpdateWorkspaceRunTask(ctx context.Context, id string, opts UpdateAttachmentOptions) (*WorkspaceRunTask, error) {
+	var subject internal.Subject
+	wrt, err := s.db.updateWorkspaceTask(ctx, id, func(wrt *WorkspaceRunTask) (err error) {
+		subject, err = s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceRunTaskAction, wrt.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		return wrt.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating workspace run task", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated workspace run task", "workspace_task", wrt, "subject", subject)
+	return wrt, nil
+}
+
+func (s *service) GetWorkspaceRunTask(ctx context.Context, id string) (*WorkspaceRunTask, error) {
+	wrt, err := s.db.getWorkspaceTask(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving workspace run task", "id", id)
+		return nil, err
+	}
+	subject, err := s.workspace.CanAccess(ctx, rbac.GetWorkspaceRunTaskAction, wrt.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved workspace run task", "workspace_task", wrt, "subject", subject)
+	return wrt, nil
+}
+
+func (s *service) ListWorkspaceRunTasks(ctx context.Context, workspaceID string) ([]*WorkspaceRunTask, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.ListWorkspaceRunTasksAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.db.listWorkspaceTasks(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "listing workspace run tasks", "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed workspace run tasks", "workspace_id", workspaceID, "total", len(tasks), "subject", subject)
+	return tasks, nil
+}
+
+func (s *service) DetachRunTask(ctx context.Context, id string) (*WorkspaceRunTask, error) {
+	wrt, err := s.db.getWorkspaceTask(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving workspace run task", "id", id)
+		return nil, err
+	}
+	subject, err := s.workspace.CanAccess(ctx, rbac.DeleteWorkspaceRunTaskAction, wrt.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.deleteWorkspaceTask(ctx, id); err != nil {
+		s.Error(err, "detaching run task", "workspace_task", wrt, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("detached run task", "workspace_task", wrt, "subject", subject)
+	return wrt, nil
+}
+
+func (s *service) GetTaskResult(ctx context.Context, id string) (*TaskResult, error) {
+	result, err := s.db.getResult(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving task result", "id", id)
+		return nil, err
+	}
+	// authorize access to the result by authorizing access to its run
+	if _, err := s.GetRun(ctx, result.RunID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *service) ListTaskResults(ctx context.Context, runID string) ([]*TaskResult, error) {
+	// authorize access to the results by authorizing access to their run
+	if _, err := s.GetRun(ctx, runID); err != nil {
+		return nil, err
+	}
+	results, err := s.db.listResults(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing task results", "run_id", runID)
+		return nil, err
+	}
+	return results, nil
+}
+
+// callback handles a task reporting its outcome. The caller is authenticated
+// using the access token that was sent to the task in its request.
+func (s *service) callback(ctx context.Context, id, token string, opts CallbackOptions) error {
+	result, err := s.db.updateResult(ctx, id, func(result *TaskResult) error {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(result.AccessToken)) != 1 {
+			return internal.ErrUnauthorized
+		}
+		return result.callback(opts)
+	})
+	if err != nil {
+		s.Error(err, "handling task callback", "id", id)
+		return err
+	}
+	s.V(1).Info("received task callback", "id", id, "run_id", result.RunID, "status", result.Status)
+
+	return finishStage(ctx, s.db, s.RunService, result.RunID, result.Stage)
+}
+
+// finishStage finishes a run's task stage if all the results for the stage
+// have completed.
+func finishStage(ctx context.Context, db *pgdb, runs run.Service, runID string, stage run.TaskStage) error {
+	results, err := db.listResults(ctx, runID)
+	if err != nil {
+		return err
+	}
+	var stageResults []*TaskResult
+	for _, r := range results {
+		if r.Stage == stage {
+			stageResults = append(stageResults, r)
+		}
+	}
+	done, passed := stagePassed(stageResults)
+	if !done {
+		return nil
+	}
+	_, err = runs.FinishTaskStage(ctx, runID, stage, passed)
+	if errors.Is(err, run.ErrInvalidRunStateTransition) {
+		// another caller has already finished the stage
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("finishing task stage: %w", err)
+	}
+	return nil
+}
+
+// cloneWorkspaceTasks attaches the run tasks attached to a workspace to its
+// clone. Run tasks gate runs and are therefore always copied, regardless of
+// the clone options.
+func (s *service) cloneWorkspaceTasks(ctx context.Context, event *workspace.CloneEvent) error {
+	tasks, err := s.db.listWorkspaceTasks(ctx, event.Source.ID)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		wrt, err := newWorkspaceRunTask(event.Clone.ID, task.RunTask, AttachOptions{
+			Stage:            task.Stage,
+			EnforcementLevel: task.EnforcementLevel,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.db.createWorkspaceTask(ctx, wrt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
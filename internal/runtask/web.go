@@ -0,0 +1,321 @@
+package runtask
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type webHandlers struct {
+	html.Renderer
+	workspace.WorkspaceService
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/run-tasks", h.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/run-tasks/new", h.new).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/run-tasks/create", h.create).Methods("POST")
+	r.HandleFunc("/run-tasks/{run_task_id}/edit", h.edit).Methods("GET")
+	r.HandleFunc("/run-tasks/{run_task_id}/update", h.update).Methods("POST")
+	r.HandleFunc("/run-tasks/{run_task_id}/delete", h.delete).Methods("POST")
+
+	r.HandleFunc("/workspaces/{workspace_id}/workspace-run-tasks", h.listWorkspaceTasks).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/workspace-run-tasks/create", h.attach).Methods("POST")
+	r.HandleFunc("/workspace-run-tasks/{workspace_run_task_id}/delete", h.detach).Methods("POST")
+
+	r.HandleFunc("/runs/{run_id}/task-results", h.listResults).Methods("GET")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	tasks, err := h.svc.ListRunTasks(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("run_task_list.tmpl", w, struct {
+		organization.OrganizationPage
+		// list template expects pagination object but we don't paginate run
+		// tasks
+		*resource.Pagination
+		Items []*RunTask
+	}{
+		OrganizationPage: organization.NewPage(r, "run tasks", org),
+		Pagination:       &resource.Pagination{},
+		Items:            tasks,
+	})
+}
+
+func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.Render("run_task_new.tmpl", w, struct {
+		organization.OrganizationPage
+		RunTask    *RunTask
+		EditMode   bool
+		FormAction string
+	}{
+		OrganizationPage: organization.NewPage(r, "new run task", org),
+		RunTask:          &RunTask{Enabled: true},
+		EditMode:         false,
+		FormAction:       paths.CreateRunTask(org),
+	})
+}
+
+func (h *webHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		Name         string `schema:"name,required"`
+		URL          string `schema:"url,required"`
+		Description  *string
+		HMACKey      *string `schema:"hmac_key"`
+		Enabled      bool
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	task, err := h.svc.CreateRunTask(r.Context(), CreateOptions{
+		Organization: params.Organization,
+		Name:         params.Name,
+		URL:          params.URL,
+		Description:  params.Description,
+		HMACKey:      params.HMACKey,
+		Enabled:      &params.Enabled,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "created run task: "+task.Name)
+	http.Redirect(w, r, paths.RunTasks(task.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) edit(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("run_task_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	task, err := h.svc.GetRunTask(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("run_task_edit.tmpl", w, struct {
+		organization.OrganizationPage
+		RunTask    *RunTask
+		EditMode   bool
+		FormAction string
+	}{
+		OrganizationPage: organization.NewPage(r, "edit | "+task.Name, task.Organization),
+		RunTask:          task,
+		EditMode:         true,
+		FormAction:       paths.UpdateRunTask(task.ID),
+	})
+}
+
+func (h *webHandlers) update(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID          string `schema:"run_task_id,required"`
+		Name        *string
+		URL         *string
+		Description *string
+		HMACKey     string `schema:"hmac_key"`
+		Enabled     bool   // form checkbox can only be true/false, not nil
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	opts := UpdateOptions{
+		Name:        params.Name,
+		URL:         params.URL,
+		Description: params.Description,
+		Enabled:     &params.Enabled,
+	}
+	// an empty key leaves the existing key in place
+	if params.HMACKey != "" {
+		opts.HMACKey = &params.HMACKey
+	}
+	task, err := h.svc.UpdateRunTask(r.Context(), params.ID, opts)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "updated run task: "+task.Name)
+	http.Redirect(w, r, paths.RunTasks(task.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("run_task_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	task, err := h.svc.DeleteRunTask(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted run task: "+task.Name)
+	http.Redirect(w, r, paths.RunTasks(task.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) listWorkspaceTasks(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	attached, err := h.svc.ListWorkspaceRunTasks(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ws, err := h.GetWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	policy, err := h.GetPolicy(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// only offer tasks that have yet to be attached
+	var available []*RunTask
+	if subject.CanAccessWorkspace(rbac.CreateWorkspaceRunTaskAction, policy) {
+		tasks, err := h.svc.ListRunTasks(r.Context(), ws.Organization)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	tasks:
+		for _, task := range tasks {
+			for _, wrt := range attached {
+				if wrt.RunTask.ID == task.ID {
+					continue tasks
+				}
+			}
+			available = append(available, task)
+		}
+	}
+
+	h.Render("workspace_run_task_list.tmpl", w, struct {
+		workspace.WorkspacePage
+		Items              []*WorkspaceRunTask
+		RunTasks           []*RunTask
+		CanAttach          bool
+		CanDetach          bool
+		CanUpdateWorkspace bool
+	}{
+		WorkspacePage:      workspace.NewPage(r, "run tasks", ws),
+		Items:              attached,
+		RunTasks:           available,
+		CanAttach:          subject.CanAccessWorkspace(rbac.CreateWorkspaceRunTaskAction, policy),
+		CanDetach:          subject.CanAccessWorkspace(rbac.DeleteWorkspaceRunTaskAction, policy),
+		CanUpdateWorkspace: subject.CanAccessWorkspace(rbac.UpdateWorkspaceAction, policy),
+	})
+}
+
+func (h *webHandlers) attach(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID      string           `schema:"workspace_id,required"`
+		RunTaskID        string           `schema:"run_task_id,required"`
+		Stage            run.TaskStage    `schema:"stage,required"`
+		EnforcementLevel EnforcementLevel `schema:"enforcement_level,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	wrt, err := h.svc.AttachRunTask(r.Context(), params.WorkspaceID, AttachOptions{
+		RunTaskID:        params.RunTaskID,
+		Stage:            params.Stage,
+		EnforcementLevel: params.EnforcementLevel,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "attached run task: "+wrt.RunTask.Name)
+	http.Redirect(w, r, paths.WorkspaceRunTasks(wrt.WorkspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) detach(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_run_task_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	wrt, err := h.svc.DetachRunTask(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "detached run task: "+wrt.RunTask.Name)
+	http.Redirect(w, r, paths.WorkspaceRunTasks(wrt.WorkspaceID), http.StatusFound)
+}
+
+// listResults renders the task results for a run, for embedding in the run
+// page.
+func (h *webHandlers) listResults(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	results, err := h.svc.ListTaskResults(r.Context(), runID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.RenderTemplate("run_task_results.tmpl", w, results); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -29,6 +29,7 @@ func newTestScheduler(workspaces []*workspace.Workspace, runs []*run.Run, events
 		RunService:       services,
 		Subscriber:       services,
 		queues:           make(map[string]eventHandler),
+		limiter:          &limiter{Logger: logr.Discard(), RunService: services},
 	}
 	// handled chan receives events relayed to handlers
 	handled := make(chan pubsub.Event)
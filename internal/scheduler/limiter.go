@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+)
+
+// limiter releases runs that have been held back by their organization's
+// concurrency limits, once the organization has the capacity to run them.
+type limiter struct {
+	logr.Logger
+	RunService
+}
+
+// handleEvent releases an organization's held runs upon an event that might
+// mean the organization now has capacity for more runs: a run ceasing to be
+// in flight, or a change to the organization's limits.
+func (l *limiter) handleEvent(ctx context.Context, event pubsub.Event) error {
+	switch payload := event.Payload.(type) {
+	case *organization.Organization:
+		if event.Type == pubsub.DeletedEvent {
+			return nil
+		}
+		return l.release(ctx, payload.Name)
+	case *run.Run:
+		if payload.InFlight() {
+			return nil
+		}
+		return l.release(ctx, payload.Organization)
+	}
+	return nil
+}
+
+// release attempts to enqueue each of the organization's held runs. Held
+// applies are released before held plans, so that runs already underway are
+// finished before new runs are started; otherwise runs are released in the
+// order in which they were created. Any run for which there is still no
+// capacity remains held.
+func (l *limiter) release(ctx context.Context, organization string) error {
+	runs, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*run.Run], error) {
+		return l.ListRuns(ctx, run.ListOptions{
+			PageOptions:  opts,
+			Organization: &organization,
+			Statuses: []internal.RunStatus{
+				internal.RunPending,
+				internal.RunPlanned,
+				internal.RunCostEstimated,
+				internal.RunConfirmed,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	var held []*run.Run
+	// ListRuns returns runs newest first, whereas we want oldest first.
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].QueuedByLimit {
+			held = append(held, runs[i])
+		}
+	}
+	sort.SliceStable(held, func(i, j int) bool {
+		return held[i].Status != internal.RunPending && held[j].Status == internal.RunPending
+	})
+	for _, r := range held {
+		if r.Status == internal.RunPending {
+			_, err = l.EnqueuePlan(ctx, r.ID)
+		} else {
+			err = l.Apply(ctx, r.ID)
+		}
+		if err != nil {
+			// the run may have since moved on, e.g. it has been discarded,
+			// so log the error and carry on releasing other runs.
+			l.Error(err, "releasing run queued by limit", "run", r.ID)
+		}
+	}
+	return nil
+}
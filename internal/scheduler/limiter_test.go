@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	// runs are listed newest first
+	runs := []*run.Run{
+		{ID: "run-4", Organization: "acme-corp", Status: internal.RunPending},
+		{ID: "run-3", Organization: "acme-corp", Status: internal.RunPlanned, QueuedByLimit: true},
+		{ID: "run-2", Organization: "acme-corp", Status: internal.RunPending, QueuedByLimit: true},
+		{ID: "run-1", Organization: "acme-corp", Status: internal.RunPending, QueuedByLimit: true},
+	}
+
+	tests := []struct {
+		name  string
+		event pubsub.Event
+		want  []string
+	}{
+		{
+			name:  "run finished",
+			event: pubsub.Event{Payload: &run.Run{Organization: "acme-corp", Status: internal.RunApplied}},
+			want:  []string{"apply:run-3", "plan:run-1", "plan:run-2"},
+		},
+		{
+			name:  "run paused awaiting confirmation",
+			event: pubsub.Event{Payload: &run.Run{Organization: "acme-corp", Status: internal.RunPlanned}},
+			want:  []string{"apply:run-3", "plan:run-1", "plan:run-2"},
+		},
+		{
+			name:  "organization updated",
+			event: pubsub.Event{Type: pubsub.UpdatedEvent, Payload: &organization.Organization{Name: "acme-corp"}},
+			want:  []string{"apply:run-3", "plan:run-1", "plan:run-2"},
+		},
+		{
+			name:  "run in flight",
+			event: pubsub.Event{Payload: &run.Run{Organization: "acme-corp", Status: internal.RunPlanning}},
+			want:  nil,
+		},
+		{
+			name:  "organization deleted",
+			event: pubsub.Event{Type: pubsub.DeletedEvent, Payload: &organization.Organization{ID: "org-123"}},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := &fakeLimiterServices{runs: runs}
+			l := &limiter{Logger: logr.Discard(), RunService: services}
+
+			err := l.handleEvent(ctx, tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, services.released)
+		})
+	}
+}
+
+type fakeLimiterServices struct {
+	runs     []*run.Run
+	released []string
+
+	RunService
+}
+
+func (f *fakeLimiterServices) ListRuns(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error) {
+	return resource.NewPage(f.runs, opts.PageOptions, nil), nil
+}
+
+func (f *fakeLimiterServices) EnqueuePlan(ctx context.Context, runID string) (*run.Run, error) {
+	f.released = append(f.released, "plan:"+runID)
+	return &run.Run{ID: runID}, nil
+}
+
+func (f *fakeLimiterServices) Apply(ctx context.Context, runID string) error {
+	f.released = append(f.released, "apply:"+runID)
+	return nil
+}
@@ -58,7 +58,7 @@ func (q *queue) handleEvent(ctx context.Context, event pubsub.Event) error {
 			}
 		}
 	case *run.Run:
-		if payload.PlanOnly {
+		if !payload.UsesWorkspaceQueue() {
 			if payload.Status == internal.RunPending {
 				// immediately enqueue onto global queue
 				_, err := q.EnqueuePlan(ctx, payload.ID)
@@ -131,8 +131,10 @@ func (q *queue) setCurrentRun(ctx context.Context, run *run.Run) error {
 }
 
 func (q *queue) scheduleRun(ctx context.Context, run *run.Run) error {
-	if run.Status != internal.RunPending {
-		// run has already been scheduled
+	if (run.Status != internal.RunPending && run.Status != internal.RunConfirmed) || run.QueuedByLimit {
+		// run has already been scheduled, or it is being held back by
+		// concurrency limits, in which case it is the limiter's job to
+		// release it.
 		return nil
 	}
 
@@ -143,7 +145,7 @@ func (q *queue) scheduleRun(ctx context.Context, run *run.Run) error {
 		return nil
 	}
 
-	ws, err := q.LockWorkspace(ctx, q.ws.ID, &run.ID)
+	ws, err := q.LockWorkspace(ctx, q.ws.ID, &run.ID, workspace.LockOptions{})
 	if err != nil {
 		if errors.Is(err, internal.ErrWorkspaceAlreadyLocked) {
 			// User has locked workspace in the small window of time between
@@ -155,6 +157,12 @@ func (q *queue) scheduleRun(ctx context.Context, run *run.Run) error {
 	}
 	q.ws = ws
 
+	if run.Status == internal.RunConfirmed {
+		// a confirmed saved plan has already been planned so enqueue its
+		// apply
+		return q.applySavedPlan(ctx, run)
+	}
+
 	// schedule the run
 	current, err := q.EnqueuePlan(ctx, run.ID)
 	if err != nil {
@@ -163,3 +171,21 @@ func (q *queue) scheduleRun(ctx context.Context, run *run.Run) error {
 	q.current = current
 	return nil
 }
+
+func (q *queue) applySavedPlan(ctx context.Context, run *run.Run) error {
+	if err := q.Apply(ctx, run.ID); err != nil {
+		if errors.Is(err, internal.ErrSavedPlanStale) {
+			// the run has been errored, and its event will arrive shortly,
+			// which'll make way for the next run.
+			q.V(0).Info("saved plan is stale; cannot apply", "run", run.ID)
+			return nil
+		}
+		return err
+	}
+	current, err := q.GetRun(ctx, run.ID)
+	if err != nil {
+		return err
+	}
+	q.current = current
+	return nil
+}
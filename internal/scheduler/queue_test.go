@@ -90,6 +90,42 @@ func TestQueue(t *testing.T) {
 		assert.Equal(t, 0, len(q.queue))
 	})
 
+	t.Run("saved plan", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		run := &run.Run{ID: "run-123", Status: internal.RunPending, WorkspaceID: "ws-123", SavePlan: true}
+		app := newFakeQueueApp(ws, run)
+		q := newTestQueue(app, ws)
+
+		// should be scheduled but not enqueued onto workspace q
+		err := q.handleEvent(ctx, pubsub.Event{Payload: run})
+		require.NoError(t, err)
+		assert.Equal(t, internal.RunPlanQueued, run.Status)
+		assert.Nil(t, q.current)
+		assert.False(t, q.ws.Locked())
+	})
+
+	t.Run("confirmed saved plan", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		run := &run.Run{
+			ID:          "run-123",
+			Status:      internal.RunConfirmed,
+			WorkspaceID: "ws-123",
+			SavePlan:    true,
+			StatusTimestamps: []run.StatusTimestamp{
+				{Status: internal.RunConfirmed},
+			},
+		}
+		app := newFakeQueueApp(ws, run)
+		q := newTestQueue(app, ws)
+
+		// should lock the workspace and then be applied
+		err := q.handleEvent(ctx, pubsub.Event{Payload: run})
+		require.NoError(t, err)
+		assert.Equal(t, internal.RunApplyQueued, run.Status)
+		assert.Equal(t, run.ID, q.current.ID)
+		assert.True(t, q.ws.Locked())
+	})
+
 	t.Run("user locked", func(t *testing.T) {
 		ws := &workspace.Workspace{ID: "ws-123"}
 		run := &run.Run{ID: "run-123", WorkspaceID: "ws-123", Status: internal.RunPending}
@@ -98,7 +134,7 @@ func TestQueue(t *testing.T) {
 
 		// user locks workspace; new run should be made the current run but should not
 		// be scheduled nor replace the user lock
-		err := ws.Enlock("bobby", workspace.UserLock)
+		err := ws.Enlock("bobby", workspace.UserLock, workspace.LockOptions{})
 		require.NoError(t, err)
 		err = q.handleEvent(ctx, pubsub.Event{Payload: run})
 		require.NoError(t, err)
@@ -127,6 +163,19 @@ func TestQueue(t *testing.T) {
 		assert.Equal(t, internal.RunPlanning, run.Status)
 	})
 
+	t.Run("do not schedule run queued by limit", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		run := &run.Run{WorkspaceID: "ws-123", Status: internal.RunPending, QueuedByLimit: true}
+		app := newFakeQueueApp(ws, run)
+		q := newTestQueue(app, ws)
+
+		err := q.handleEvent(ctx, pubsub.Event{Payload: run})
+		require.NoError(t, err)
+		assert.Equal(t, run.ID, q.current.ID)
+		// it is the limiter's job to enqueue the run
+		assert.Equal(t, internal.RunPending, run.Status)
+	})
+
 	t.Run("do not set current run if already latest run on workspace", func(t *testing.T) {
 		run := &run.Run{WorkspaceID: "ws-123"}
 		ws := &workspace.Workspace{ID: "ws-123", LatestRun: &workspace.LatestRun{ID: run.ID}}
@@ -171,8 +220,17 @@ func (f *fakeQueueServices) EnqueuePlan(ctx context.Context, runID string) (*run
 	return f.runs[runID], nil
 }
 
-func (f *fakeQueueServices) LockWorkspace(ctx context.Context, workspaceID string, runID *string) (*workspace.Workspace, error) {
-	if err := f.ws.Enlock(*runID, workspace.RunLock); err != nil {
+func (f *fakeQueueServices) Apply(ctx context.Context, runID string) error {
+	f.runs[runID].Status = internal.RunApplyQueued
+	return nil
+}
+
+func (f *fakeQueueServices) GetRun(ctx context.Context, runID string) (*run.Run, error) {
+	return f.runs[runID], nil
+}
+
+func (f *fakeQueueServices) LockWorkspace(ctx context.Context, workspaceID string, runID *string, opts workspace.LockOptions) (*workspace.Workspace, error) {
+	if err := f.ws.Enlock(*runID, workspace.RunLock, workspace.LockOptions{}); err != nil {
 		return nil, err
 	}
 	return f.ws, nil
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
@@ -18,9 +19,11 @@ import (
 const LockID int64 = 5577006791947779410
 
 type (
-	// scheduler performs two principle tasks :
+	// scheduler performs three principle tasks :
 	// (a) manages lifecycle of workspace queues, creating/destroying them
 	// (b) relays run and workspace events onto queues.
+	// (c) relays run and organization events onto the limiter, which releases
+	// runs held back by concurrency limits.
 	scheduler struct {
 		logr.Logger
 
@@ -28,7 +31,8 @@ type (
 		WorkspaceService
 		RunService
 
-		queues map[string]eventHandler
+		queues  map[string]eventHandler
+		limiter eventHandler
 		queueFactory
 	}
 
@@ -45,12 +49,17 @@ type (
 )
 
 func NewScheduler(opts Options) *scheduler {
+	logger := opts.Logger.WithValues("component", "scheduler")
 	return &scheduler{
-		Logger:           opts.Logger.WithValues("component", "scheduler"),
+		Logger:           logger,
 		WorkspaceService: opts.WorkspaceService,
 		RunService:       opts.RunService,
 		Subscriber:       opts.Subscriber,
 		queueFactory:     queueMaker{},
+		limiter: &limiter{
+			Logger:     logger,
+			RunService: opts.RunService,
+		},
 	}
 }
 
@@ -143,6 +152,13 @@ func (s *scheduler) Start(ctx context.Context) error {
 			if err := q.handleEvent(ctx, event); err != nil {
 				return err
 			}
+			if err := s.limiter.handleEvent(ctx, event); err != nil {
+				return err
+			}
+		case *organization.Organization:
+			if err := s.limiter.handleEvent(ctx, event); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,62 @@
+-- +goose Up
+INSERT INTO run_statuses (status) VALUES
+    ('pre_plan_running'),
+    ('pre_plan_completed'),
+    ('post_plan_running'),
+    ('post_plan_completed'),
+    ('pre_apply_running'),
+    ('pre_apply_completed');
+
+CREATE TABLE IF NOT EXISTS run_tasks (
+    run_task_id       TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    name              TEXT        NOT NULL,
+    description       TEXT,
+    url               TEXT        NOT NULL,
+    hmac_key          TEXT,
+    enabled           BOOLEAN     NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (run_task_id),
+                      UNIQUE (organization_name, name)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_run_tasks (
+    workspace_run_task_id TEXT,
+    stage                 TEXT NOT NULL,
+    enforcement_level     TEXT NOT NULL,
+    run_task_id           TEXT REFERENCES run_tasks ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    workspace_id          TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                          PRIMARY KEY (workspace_run_task_id),
+                          UNIQUE (workspace_id, run_task_id)
+);
+
+CREATE TABLE IF NOT EXISTS task_results (
+    task_result_id    TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    run_task_id       TEXT        NOT NULL,
+    task_name         TEXT        NOT NULL,
+    task_url          TEXT        NOT NULL,
+    stage             TEXT        NOT NULL,
+    enforcement_level TEXT        NOT NULL,
+    status            TEXT        NOT NULL,
+    message           TEXT,
+    url               TEXT,
+    access_token      TEXT        NOT NULL,
+    run_id            TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (task_result_id),
+                      UNIQUE (run_id, stage, run_task_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_results;
+DROP TABLE IF EXISTS workspace_run_tasks;
+DROP TABLE IF EXISTS run_tasks;
+DELETE FROM run_statuses WHERE status IN (
+    'pre_plan_running',
+    'pre_plan_completed',
+    'post_plan_running',
+    'post_plan_completed',
+    'pre_apply_running',
+    'pre_apply_completed'
+);
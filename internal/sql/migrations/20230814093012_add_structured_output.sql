@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE plans ADD COLUMN structured_output BYTEA;
+ALTER TABLE applies ADD COLUMN structured_output BYTEA;
+
+-- +goose Down
+ALTER TABLE plans DROP COLUMN structured_output;
+ALTER TABLE applies DROP COLUMN structured_output;
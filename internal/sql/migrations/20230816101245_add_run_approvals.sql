@@ -0,0 +1,56 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_approval_policies (
+    workspace_id       TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    approvals_required INTEGER NOT NULL,
+    exclude_author     BOOLEAN NOT NULL,
+                       PRIMARY KEY (workspace_id)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_approval_teams (
+    workspace_id TEXT REFERENCES workspace_approval_policies ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    team_id      TEXT REFERENCES teams ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                 PRIMARY KEY (workspace_id, team_id)
+);
+
+CREATE TABLE IF NOT EXISTS run_approvals (
+    run_approval_id TEXT,
+    created_at      TIMESTAMPTZ NOT NULL,
+    username        TEXT        NOT NULL,
+    decision        TEXT        NOT NULL,
+    comment         TEXT,
+    run_id          TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                    PRIMARY KEY (run_approval_id),
+                    UNIQUE (run_id, username)
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION run_approvals_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.run_approval_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER notify_event
+AFTER INSERT ON run_approvals
+    FOR EACH ROW EXECUTE PROCEDURE run_approvals_notify_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notify_event ON run_approvals;
+DROP FUNCTION IF EXISTS run_approvals_notify_event;
+DROP TABLE IF EXISTS run_approvals;
+DROP TABLE IF EXISTS workspace_approval_teams;
+DROP TABLE IF EXISTS workspace_approval_policies;
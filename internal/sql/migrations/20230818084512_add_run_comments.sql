@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_comments (
+    run_comment_id TEXT,
+    created_at     TIMESTAMPTZ NOT NULL,
+    author         TEXT        NOT NULL,
+    body           TEXT        NOT NULL,
+    run_id         TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                   PRIMARY KEY (run_comment_id)
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION run_comments_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.run_comment_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER notify_event
+AFTER INSERT ON run_comments
+    FOR EACH ROW EXECUTE PROCEDURE run_comments_notify_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notify_event ON run_comments;
+DROP FUNCTION IF EXISTS run_comments_notify_event;
+DROP TABLE IF EXISTS run_comments;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE organizations ADD COLUMN max_concurrent_runs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE organizations ADD COLUMN max_concurrent_speculative_runs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN queued_by_limit BOOL NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE runs DROP COLUMN queued_by_limit;
+ALTER TABLE organizations DROP COLUMN max_concurrent_speculative_runs;
+ALTER TABLE organizations DROP COLUMN max_concurrent_runs;
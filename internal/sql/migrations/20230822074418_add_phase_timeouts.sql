@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN plan_timeout INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workspaces ADD COLUMN apply_timeout INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN apply_timeout;
+ALTER TABLE workspaces DROP COLUMN plan_timeout;
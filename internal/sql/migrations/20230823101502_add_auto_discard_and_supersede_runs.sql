@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN auto_discard_ttl INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workspaces ADD COLUMN supersede_runs BOOL NOT NULL DEFAULT false;
+ALTER TABLE run_status_timestamps ADD COLUMN reason TEXT;
+
+-- +goose Down
+ALTER TABLE run_status_timestamps DROP COLUMN reason;
+ALTER TABLE workspaces DROP COLUMN supersede_runs;
+ALTER TABLE workspaces DROP COLUMN auto_discard_ttl;
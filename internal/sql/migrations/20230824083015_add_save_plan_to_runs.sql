@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE runs ADD COLUMN save_plan BOOL NOT NULL DEFAULT false;
+INSERT INTO run_statuses (status) VALUES ('planned_and_saved');
+
+-- +goose Down
+DELETE FROM run_statuses WHERE status = 'planned_and_saved';
+ALTER TABLE runs DROP COLUMN save_plan;
@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE runs ADD COLUMN test BOOL NOT NULL DEFAULT false;
+ALTER TABLE plans ADD COLUMN test_results BYTEA;
+ALTER TABLE modules ADD COLUMN test_workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE SET NULL;
+ALTER TABLE modules ADD COLUMN tests_required BOOL NOT NULL DEFAULT false;
+ALTER TABLE module_versions ADD COLUMN test_run_id TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE SET NULL;
+INSERT INTO module_version_statuses (status) VALUES
+	('testing'),
+	('tests_failed');
+
+-- +goose Down
+UPDATE module_versions SET status = 'ok' WHERE status IN ('testing', 'tests_failed');
+DELETE FROM module_version_statuses WHERE status IN ('testing', 'tests_failed');
+ALTER TABLE module_versions DROP COLUMN test_run_id;
+ALTER TABLE modules DROP COLUMN tests_required;
+ALTER TABLE modules DROP COLUMN test_workspace_id;
+ALTER TABLE plans DROP COLUMN test_results;
+ALTER TABLE runs DROP COLUMN test;
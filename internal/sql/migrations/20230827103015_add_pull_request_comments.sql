@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN pr_comments BOOL NOT NULL DEFAULT false;
+CREATE TABLE IF NOT EXISTS pull_request_comments (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    repo TEXT NOT NULL,
+    pull_request_number INTEGER NOT NULL,
+    comment_id TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, repo, pull_request_number)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS pull_request_comments;
+ALTER TABLE workspaces DROP COLUMN pr_comments;
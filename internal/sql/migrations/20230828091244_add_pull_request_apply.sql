@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN pr_apply BOOL NOT NULL DEFAULT false;
+CREATE TABLE IF NOT EXISTS pull_request_locks (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    repo TEXT NOT NULL,
+    pull_request_number INTEGER NOT NULL,
+    username TEXT NOT NULL,
+    PRIMARY KEY (workspace_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS pull_request_locks;
+ALTER TABLE workspaces DROP COLUMN pr_apply;
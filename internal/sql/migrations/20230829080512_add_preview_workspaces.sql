@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE workspaces
+    ADD COLUMN preview_template BOOL NOT NULL DEFAULT false,
+    ADD COLUMN max_previews INTEGER NOT NULL DEFAULT 5,
+    ADD COLUMN preview_template_id TEXT REFERENCES workspaces (workspace_id) ON UPDATE CASCADE ON DELETE SET NULL,
+    ADD COLUMN preview_pull_request INTEGER;
+
+-- +goose Down
+ALTER TABLE workspaces
+    DROP COLUMN preview_pull_request,
+    DROP COLUMN preview_template_id,
+    DROP COLUMN max_previews,
+    DROP COLUMN preview_template;
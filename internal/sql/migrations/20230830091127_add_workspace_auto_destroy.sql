@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE workspaces
+    ADD COLUMN auto_destroy_at TIMESTAMPTZ,
+    ADD COLUMN auto_destroy_activity_duration INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN auto_apply_destroy BOOL NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE workspaces
+    DROP COLUMN auto_apply_destroy,
+    DROP COLUMN auto_destroy_activity_duration,
+    DROP COLUMN auto_destroy_at;
@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS projects (
+    project_id        TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    name              TEXT        NOT NULL,
+    is_default        BOOLEAN     NOT NULL DEFAULT false,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (project_id),
+                      UNIQUE (organization_name, name)
+);
+
+CREATE TABLE IF NOT EXISTS project_permissions (
+    project_id TEXT REFERENCES projects ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    team_id    TEXT REFERENCES teams ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    role       TEXT REFERENCES workspace_roles ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+               UNIQUE (project_id, team_id)
+);
+
+-- every existing organization gets a default project, to which its existing
+-- workspaces are assigned.
+INSERT INTO projects (project_id, created_at, updated_at, name, is_default, organization_name)
+SELECT 'prj-' || substr(md5(random()::text), 1, 16), now(), now(), 'Default Project', true, name
+FROM organizations;
+
+ALTER TABLE workspaces ADD COLUMN project_id TEXT REFERENCES projects ON UPDATE CASCADE;
+
+UPDATE workspaces w
+SET project_id = p.project_id
+FROM projects p
+WHERE p.organization_name = w.organization_name
+AND   p.is_default;
+
+ALTER TABLE workspaces ALTER COLUMN project_id SET NOT NULL;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN project_id;
+DROP TABLE IF EXISTS project_permissions;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE state_versions ADD COLUMN run_id TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE state_versions DROP COLUMN run_id;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS inventory_resources (
+    inventory_resource_id TEXT,
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    state_version_id TEXT REFERENCES state_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    address TEXT NOT NULL,
+    module TEXT NOT NULL,
+    type TEXT NOT NULL,
+    name TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    provider_version TEXT,
+    remote_id TEXT,
+    attribute_values TEXT[] NOT NULL,
+    UNIQUE (workspace_id, address),
+    PRIMARY KEY (inventory_resource_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS inventory_resources;
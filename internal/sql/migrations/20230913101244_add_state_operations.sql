@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS state_operations (
+    state_operation_id        TEXT,
+    created_at                TIMESTAMPTZ NOT NULL,
+    workspace_id              TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    state_version_id          TEXT REFERENCES state_versions ON UPDATE CASCADE ON DELETE SET NULL,
+    previous_state_version_id TEXT REFERENCES state_versions (state_version_id) ON UPDATE CASCADE ON DELETE SET NULL,
+    kind                      TEXT        NOT NULL,
+    address                   TEXT        NOT NULL,
+    destination               TEXT,
+    reason                    TEXT        NOT NULL,
+    author                    TEXT        NOT NULL,
+                              PRIMARY KEY (state_operation_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS state_operations;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE state_versions ADD COLUMN IF NOT EXISTS pinned BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS organization_state_retention_policies (
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    keep_last         INTEGER,
+    keep_days         INTEGER,
+                      PRIMARY KEY (organization_name)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_state_retention_policies (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    keep_last    INTEGER,
+    keep_days    INTEGER,
+                 PRIMARY KEY (workspace_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_state_retention_policies;
+DROP TABLE IF EXISTS organization_state_retention_policies;
+ALTER TABLE state_versions DROP COLUMN IF EXISTS pinned;
@@ -0,0 +1,51 @@
+-- +goose Up
+ALTER TABLE workspaces
+    ADD COLUMN lock_reason TEXT,
+    ADD COLUMN locked_at TIMESTAMPTZ,
+    ADD COLUMN lock_expires_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS workspace_lock_events (
+    workspace_lock_event_id TEXT,
+    created_at              TIMESTAMPTZ NOT NULL,
+    action                  TEXT        NOT NULL,
+    lock_kind               TEXT        NOT NULL,
+    holder                  TEXT        NOT NULL,
+    actor                   TEXT,
+    reason                  TEXT,
+    workspace_id            TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                            PRIMARY KEY (workspace_lock_event_id)
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION workspace_lock_events_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.workspace_lock_event_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER notify_event
+AFTER INSERT ON workspace_lock_events
+    FOR EACH ROW EXECUTE PROCEDURE workspace_lock_events_notify_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notify_event ON workspace_lock_events;
+DROP FUNCTION IF EXISTS workspace_lock_events_notify_event;
+DROP TABLE IF EXISTS workspace_lock_events;
+ALTER TABLE workspaces
+    DROP COLUMN lock_reason,
+    DROP COLUMN locked_at,
+    DROP COLUMN lock_expires_at;
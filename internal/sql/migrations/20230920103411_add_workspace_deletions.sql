@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_deletions (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    run_id       TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+                 PRIMARY KEY (workspace_id),
+                 UNIQUE (run_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_deletions;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN template BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN template;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vcs_user_mappings (
+    vcs_user_mapping_id TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    vcs_provider_id TEXT REFERENCES vcs_providers ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    vcs_username TEXT NOT NULL,
+    username TEXT REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE,
+    team_id TEXT REFERENCES teams ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (vcs_user_mapping_id),
+    CHECK ((username IS NULL) != (team_id IS NULL))
+);
+-- VCS usernames are case-insensitive
+CREATE UNIQUE INDEX IF NOT EXISTS vcs_user_mappings_vcs_username_idx ON vcs_user_mappings (vcs_provider_id, lower(vcs_username));
+
+-- +goose Down
+DROP TABLE IF EXISTS vcs_user_mappings;
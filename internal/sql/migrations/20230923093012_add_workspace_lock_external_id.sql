@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN lock_external_id TEXT;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN IF EXISTS lock_external_id;
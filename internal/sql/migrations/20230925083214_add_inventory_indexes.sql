@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS inventory_indexes (
+    workspace_id     TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    state_version_id TEXT REFERENCES state_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                     PRIMARY KEY (workspace_id)
+);
+
+-- record the state versions already indexed
+INSERT INTO inventory_indexes (workspace_id, state_version_id)
+SELECT DISTINCT workspace_id, state_version_id
+FROM inventory_resources;
+
+-- +goose Down
+DROP TABLE IF EXISTS inventory_indexes;
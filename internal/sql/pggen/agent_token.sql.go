@@ -66,6 +66,20 @@ type Querier interface {
 	// UpdateApplyStatusByIDScan scans the result of an executed UpdateApplyStatusByIDBatch query.
 	UpdateApplyStatusByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	GetApplyStructuredOutputByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
+	// GetApplyStructuredOutputByIDBatch enqueues a GetApplyStructuredOutputByID query into batch to be executed
+	// later by the batch.
+	GetApplyStructuredOutputByIDBatch(batch genericBatch, runID pgtype.Text)
+	// GetApplyStructuredOutputByIDScan scans the result of an executed GetApplyStructuredOutputByIDBatch query.
+	GetApplyStructuredOutputByIDScan(results pgx.BatchResults) ([]byte, error)
+
+	UpdateApplyStructuredOutputByID(ctx context.Context, structuredOutput []byte, runID pgtype.Text) (pgtype.Text, error)
+	// UpdateApplyStructuredOutputByIDBatch enqueues a UpdateApplyStructuredOutputByID query into batch to be executed
+	// later by the batch.
+	UpdateApplyStructuredOutputByIDBatch(batch genericBatch, structuredOutput []byte, runID pgtype.Text)
+	// UpdateApplyStructuredOutputByIDScan scans the result of an executed UpdateApplyStructuredOutputByIDBatch query.
+	UpdateApplyStructuredOutputByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertConfigurationVersion(ctx context.Context, params InsertConfigurationVersionParams) (pgconn.CommandTag, error)
 	// InsertConfigurationVersionBatch enqueues a InsertConfigurationVersion query into batch to be executed
 	// later by the batch.
@@ -158,6 +172,48 @@ type Querier interface {
 	// InsertIngressAttributesScan scans the result of an executed InsertIngressAttributesBatch query.
 	InsertIngressAttributesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	InsertInventoryResource(ctx context.Context, params InsertInventoryResourceParams) (pgconn.CommandTag, error)
+	// InsertInventoryResourceBatch enqueues a InsertInventoryResource query into batch to be executed
+	// later by the batch.
+	InsertInventoryResourceBatch(batch genericBatch, params InsertInventoryResourceParams)
+	// InsertInventoryResourceScan scans the result of an executed InsertInventoryResourceBatch query.
+	InsertInventoryResourceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindInventoryResources(ctx context.Context, params FindInventoryResourcesParams) ([]FindInventoryResourcesRow, error)
+	// FindInventoryResourcesBatch enqueues a FindInventoryResources query into batch to be executed
+	// later by the batch.
+	FindInventoryResourcesBatch(batch genericBatch, params FindInventoryResourcesParams)
+	// FindInventoryResourcesScan scans the result of an executed FindInventoryResourcesBatch query.
+	FindInventoryResourcesScan(results pgx.BatchResults) ([]FindInventoryResourcesRow, error)
+
+	CountInventoryResources(ctx context.Context, params CountInventoryResourcesParams) (pgtype.Int8, error)
+	// CountInventoryResourcesBatch enqueues a CountInventoryResources query into batch to be executed
+	// later by the batch.
+	CountInventoryResourcesBatch(batch genericBatch, params CountInventoryResourcesParams)
+	// CountInventoryResourcesScan scans the result of an executed CountInventoryResourcesBatch query.
+	CountInventoryResourcesScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	DeleteInventoryResourcesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteInventoryResourcesByWorkspaceIDBatch enqueues a DeleteInventoryResourcesByWorkspaceID query into batch to be executed
+	// later by the batch.
+	DeleteInventoryResourcesByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteInventoryResourcesByWorkspaceIDScan scans the result of an executed DeleteInventoryResourcesByWorkspaceIDBatch query.
+	DeleteInventoryResourcesByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpsertInventoryIndex(ctx context.Context, workspaceID pgtype.Text, stateVersionID pgtype.Text) (pgconn.CommandTag, error)
+	// UpsertInventoryIndexBatch enqueues a UpsertInventoryIndex query into batch to be executed
+	// later by the batch.
+	UpsertInventoryIndexBatch(batch genericBatch, workspaceID pgtype.Text, stateVersionID pgtype.Text)
+	// UpsertInventoryIndexScan scans the result of an executed UpsertInventoryIndexBatch query.
+	UpsertInventoryIndexScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindUnindexedStateVersionIDs(ctx context.Context) ([]pgtype.Text, error)
+	// FindUnindexedStateVersionIDsBatch enqueues a FindUnindexedStateVersionIDs query into batch to be executed
+	// later by the batch.
+	FindUnindexedStateVersionIDsBatch(batch genericBatch)
+	// FindUnindexedStateVersionIDsScan scans the result of an executed FindUnindexedStateVersionIDsBatch query.
+	FindUnindexedStateVersionIDsScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
 	InsertModule(ctx context.Context, params InsertModuleParams) (pgconn.CommandTag, error)
 	// InsertModuleBatch enqueues a InsertModule query into batch to be executed
 	// later by the batch.
@@ -207,6 +263,13 @@ type Querier interface {
 	// FindModuleByModuleVersionIDScan scans the result of an executed FindModuleByModuleVersionIDBatch query.
 	FindModuleByModuleVersionIDScan(results pgx.BatchResults) (FindModuleByModuleVersionIDRow, error)
 
+	FindModuleByTestRunID(ctx context.Context, testRunID pgtype.Text) (FindModuleByTestRunIDRow, error)
+	// FindModuleByTestRunIDBatch enqueues a FindModuleByTestRunID query into batch to be executed
+	// later by the batch.
+	FindModuleByTestRunIDBatch(batch genericBatch, testRunID pgtype.Text)
+	// FindModuleByTestRunIDScan scans the result of an executed FindModuleByTestRunIDBatch query.
+	FindModuleByTestRunIDScan(results pgx.BatchResults) (FindModuleByTestRunIDRow, error)
+
 	UpdateModuleStatusByID(ctx context.Context, status pgtype.Text, moduleID pgtype.Text) (pgtype.Text, error)
 	// UpdateModuleStatusByIDBatch enqueues a UpdateModuleStatusByID query into batch to be executed
 	// later by the batch.
@@ -214,6 +277,13 @@ type Querier interface {
 	// UpdateModuleStatusByIDScan scans the result of an executed UpdateModuleStatusByIDBatch query.
 	UpdateModuleStatusByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	UpdateModuleTestSettingsByID(ctx context.Context, params UpdateModuleTestSettingsByIDParams) (pgtype.Text, error)
+	// UpdateModuleTestSettingsByIDBatch enqueues a UpdateModuleTestSettingsByID query into batch to be executed
+	// later by the batch.
+	UpdateModuleTestSettingsByIDBatch(batch genericBatch, params UpdateModuleTestSettingsByIDParams)
+	// UpdateModuleTestSettingsByIDScan scans the result of an executed UpdateModuleTestSettingsByIDBatch query.
+	UpdateModuleTestSettingsByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertModuleTarball(ctx context.Context, tarball []byte, moduleVersionID pgtype.Text) (pgtype.Text, error)
 	// InsertModuleTarballBatch enqueues a InsertModuleTarball query into batch to be executed
 	// later by the batch.
@@ -235,6 +305,13 @@ type Querier interface {
 	// UpdateModuleVersionStatusByIDScan scans the result of an executed UpdateModuleVersionStatusByIDBatch query.
 	UpdateModuleVersionStatusByIDScan(results pgx.BatchResults) (UpdateModuleVersionStatusByIDRow, error)
 
+	UpdateModuleVersionTestRunByID(ctx context.Context, testRunID pgtype.Text, moduleVersionID pgtype.Text) (pgtype.Text, error)
+	// UpdateModuleVersionTestRunByIDBatch enqueues a UpdateModuleVersionTestRunByID query into batch to be executed
+	// later by the batch.
+	UpdateModuleVersionTestRunByIDBatch(batch genericBatch, testRunID pgtype.Text, moduleVersionID pgtype.Text)
+	// UpdateModuleVersionTestRunByIDScan scans the result of an executed UpdateModuleVersionTestRunByIDBatch query.
+	UpdateModuleVersionTestRunByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	DeleteModuleByID(ctx context.Context, moduleID pgtype.Text) (pgtype.Text, error)
 	// DeleteModuleByIDBatch enqueues a DeleteModuleByID query into batch to be executed
 	// later by the batch.
@@ -468,6 +545,188 @@ type Querier interface {
 	// UpdatePlanJSONByIDScan scans the result of an executed UpdatePlanJSONByIDBatch query.
 	UpdatePlanJSONByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	GetPlanStructuredOutputByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
+	// GetPlanStructuredOutputByIDBatch enqueues a GetPlanStructuredOutputByID query into batch to be executed
+	// later by the batch.
+	GetPlanStructuredOutputByIDBatch(batch genericBatch, runID pgtype.Text)
+	// GetPlanStructuredOutputByIDScan scans the result of an executed GetPlanStructuredOutputByIDBatch query.
+	GetPlanStructuredOutputByIDScan(results pgx.BatchResults) ([]byte, error)
+
+	UpdatePlanStructuredOutputByID(ctx context.Context, structuredOutput []byte, runID pgtype.Text) (pgtype.Text, error)
+	// UpdatePlanStructuredOutputByIDBatch enqueues a UpdatePlanStructuredOutputByID query into batch to be executed
+	// later by the batch.
+	UpdatePlanStructuredOutputByIDBatch(batch genericBatch, structuredOutput []byte, runID pgtype.Text)
+	// UpdatePlanStructuredOutputByIDScan scans the result of an executed UpdatePlanStructuredOutputByIDBatch query.
+	UpdatePlanStructuredOutputByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	GetPlanTestResultsByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
+	// GetPlanTestResultsByIDBatch enqueues a GetPlanTestResultsByID query into batch to be executed
+	// later by the batch.
+	GetPlanTestResultsByIDBatch(batch genericBatch, runID pgtype.Text)
+	// GetPlanTestResultsByIDScan scans the result of an executed GetPlanTestResultsByIDBatch query.
+	GetPlanTestResultsByIDScan(results pgx.BatchResults) ([]byte, error)
+
+	UpdatePlanTestResultsByID(ctx context.Context, testResults []byte, runID pgtype.Text) (pgtype.Text, error)
+	// UpdatePlanTestResultsByIDBatch enqueues a UpdatePlanTestResultsByID query into batch to be executed
+	// later by the batch.
+	UpdatePlanTestResultsByIDBatch(batch genericBatch, testResults []byte, runID pgtype.Text)
+	// UpdatePlanTestResultsByIDScan scans the result of an executed UpdatePlanTestResultsByIDBatch query.
+	UpdatePlanTestResultsByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertProject(ctx context.Context, params InsertProjectParams) (pgconn.CommandTag, error)
+	// InsertProjectBatch enqueues a InsertProject query into batch to be executed
+	// later by the batch.
+	InsertProjectBatch(batch genericBatch, params InsertProjectParams)
+	// InsertProjectScan scans the result of an executed InsertProjectBatch query.
+	InsertProjectScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindProjects(ctx context.Context, params FindProjectsParams) ([]FindProjectsRow, error)
+	// FindProjectsBatch enqueues a FindProjects query into batch to be executed
+	// later by the batch.
+	FindProjectsBatch(batch genericBatch, params FindProjectsParams)
+	// FindProjectsScan scans the result of an executed FindProjectsBatch query.
+	FindProjectsScan(results pgx.BatchResults) ([]FindProjectsRow, error)
+
+	CountProjects(ctx context.Context, organizationName pgtype.Text, search pgtype.Text) (pgtype.Int8, error)
+	// CountProjectsBatch enqueues a CountProjects query into batch to be executed
+	// later by the batch.
+	CountProjectsBatch(batch genericBatch, organizationName pgtype.Text, search pgtype.Text)
+	// CountProjectsScan scans the result of an executed CountProjectsBatch query.
+	CountProjectsScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	FindProjectByID(ctx context.Context, projectID pgtype.Text) (FindProjectByIDRow, error)
+	// FindProjectByIDBatch enqueues a FindProjectByID query into batch to be executed
+	// later by the batch.
+	FindProjectByIDBatch(batch genericBatch, projectID pgtype.Text)
+	// FindProjectByIDScan scans the result of an executed FindProjectByIDBatch query.
+	FindProjectByIDScan(results pgx.BatchResults) (FindProjectByIDRow, error)
+
+	FindProjectByIDForUpdate(ctx context.Context, projectID pgtype.Text) (FindProjectByIDForUpdateRow, error)
+	// FindProjectByIDForUpdateBatch enqueues a FindProjectByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindProjectByIDForUpdateBatch(batch genericBatch, projectID pgtype.Text)
+	// FindProjectByIDForUpdateScan scans the result of an executed FindProjectByIDForUpdateBatch query.
+	FindProjectByIDForUpdateScan(results pgx.BatchResults) (FindProjectByIDForUpdateRow, error)
+
+	FindDefaultProject(ctx context.Context, organizationName pgtype.Text) (FindDefaultProjectRow, error)
+	// FindDefaultProjectBatch enqueues a FindDefaultProject query into batch to be executed
+	// later by the batch.
+	FindDefaultProjectBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindDefaultProjectScan scans the result of an executed FindDefaultProjectBatch query.
+	FindDefaultProjectScan(results pgx.BatchResults) (FindDefaultProjectRow, error)
+
+	UpdateProjectByID(ctx context.Context, params UpdateProjectByIDParams) (pgtype.Text, error)
+	// UpdateProjectByIDBatch enqueues a UpdateProjectByID query into batch to be executed
+	// later by the batch.
+	UpdateProjectByIDBatch(batch genericBatch, params UpdateProjectByIDParams)
+	// UpdateProjectByIDScan scans the result of an executed UpdateProjectByIDBatch query.
+	UpdateProjectByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeleteProjectByID(ctx context.Context, projectID pgtype.Text) (pgtype.Text, error)
+	// DeleteProjectByIDBatch enqueues a DeleteProjectByID query into batch to be executed
+	// later by the batch.
+	DeleteProjectByIDBatch(batch genericBatch, projectID pgtype.Text)
+	// DeleteProjectByIDScan scans the result of an executed DeleteProjectByIDBatch query.
+	DeleteProjectByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpsertProjectPermission(ctx context.Context, params UpsertProjectPermissionParams) (pgconn.CommandTag, error)
+	// UpsertProjectPermissionBatch enqueues a UpsertProjectPermission query into batch to be executed
+	// later by the batch.
+	UpsertProjectPermissionBatch(batch genericBatch, params UpsertProjectPermissionParams)
+	// UpsertProjectPermissionScan scans the result of an executed UpsertProjectPermissionBatch query.
+	UpsertProjectPermissionScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindProjectPermissionsByProjectID(ctx context.Context, projectID pgtype.Text) ([]FindProjectPermissionsByProjectIDRow, error)
+	// FindProjectPermissionsByProjectIDBatch enqueues a FindProjectPermissionsByProjectID query into batch to be executed
+	// later by the batch.
+	FindProjectPermissionsByProjectIDBatch(batch genericBatch, projectID pgtype.Text)
+	// FindProjectPermissionsByProjectIDScan scans the result of an executed FindProjectPermissionsByProjectIDBatch query.
+	FindProjectPermissionsByProjectIDScan(results pgx.BatchResults) ([]FindProjectPermissionsByProjectIDRow, error)
+
+	FindProjectPermissionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindProjectPermissionsByWorkspaceIDRow, error)
+	// FindProjectPermissionsByWorkspaceIDBatch enqueues a FindProjectPermissionsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindProjectPermissionsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindProjectPermissionsByWorkspaceIDScan scans the result of an executed FindProjectPermissionsByWorkspaceIDBatch query.
+	FindProjectPermissionsByWorkspaceIDScan(results pgx.BatchResults) ([]FindProjectPermissionsByWorkspaceIDRow, error)
+
+	DeleteProjectPermissionByID(ctx context.Context, projectID pgtype.Text, teamName pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteProjectPermissionByIDBatch enqueues a DeleteProjectPermissionByID query into batch to be executed
+	// later by the batch.
+	DeleteProjectPermissionByIDBatch(batch genericBatch, projectID pgtype.Text, teamName pgtype.Text)
+	// DeleteProjectPermissionByIDScan scans the result of an executed DeleteProjectPermissionByIDBatch query.
+	DeleteProjectPermissionByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpsertPullRequestComment(ctx context.Context, params UpsertPullRequestCommentParams) (pgconn.CommandTag, error)
+	// UpsertPullRequestCommentBatch enqueues a UpsertPullRequestComment query into batch to be executed
+	// later by the batch.
+	UpsertPullRequestCommentBatch(batch genericBatch, params UpsertPullRequestCommentParams)
+	// UpsertPullRequestCommentScan scans the result of an executed UpsertPullRequestCommentBatch query.
+	UpsertPullRequestCommentScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindPullRequestComment(ctx context.Context, params FindPullRequestCommentParams) (pgtype.Text, error)
+	// FindPullRequestCommentBatch enqueues a FindPullRequestComment query into batch to be executed
+	// later by the batch.
+	FindPullRequestCommentBatch(batch genericBatch, params FindPullRequestCommentParams)
+	// FindPullRequestCommentScan scans the result of an executed FindPullRequestCommentBatch query.
+	FindPullRequestCommentScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertPullRequestLock(ctx context.Context, params InsertPullRequestLockParams) (pgconn.CommandTag, error)
+	// InsertPullRequestLockBatch enqueues a InsertPullRequestLock query into batch to be executed
+	// later by the batch.
+	InsertPullRequestLockBatch(batch genericBatch, params InsertPullRequestLockParams)
+	// InsertPullRequestLockScan scans the result of an executed InsertPullRequestLockBatch query.
+	InsertPullRequestLockScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindPullRequestLockByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (FindPullRequestLockByWorkspaceIDRow, error)
+	// FindPullRequestLockByWorkspaceIDBatch enqueues a FindPullRequestLockByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindPullRequestLockByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindPullRequestLockByWorkspaceIDScan scans the result of an executed FindPullRequestLockByWorkspaceIDBatch query.
+	FindPullRequestLockByWorkspaceIDScan(results pgx.BatchResults) (FindPullRequestLockByWorkspaceIDRow, error)
+
+	DeletePullRequestLockByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeletePullRequestLockByWorkspaceIDBatch enqueues a DeletePullRequestLockByWorkspaceID query into batch to be executed
+	// later by the batch.
+	DeletePullRequestLockByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeletePullRequestLockByWorkspaceIDScan scans the result of an executed DeletePullRequestLockByWorkspaceIDBatch query.
+	DeletePullRequestLockByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeletePullRequestLocks(ctx context.Context, repo pgtype.Text, pullRequestNumber pgtype.Int4) (pgconn.CommandTag, error)
+	// DeletePullRequestLocksBatch enqueues a DeletePullRequestLocks query into batch to be executed
+	// later by the batch.
+	DeletePullRequestLocksBatch(batch genericBatch, repo pgtype.Text, pullRequestNumber pgtype.Int4)
+	// DeletePullRequestLocksScan scans the result of an executed DeletePullRequestLocksBatch query.
+	DeletePullRequestLocksScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertRemoteStateConsumer(ctx context.Context, workspaceID pgtype.Text, consumerID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertRemoteStateConsumerBatch enqueues a InsertRemoteStateConsumer query into batch to be executed
+	// later by the batch.
+	InsertRemoteStateConsumerBatch(batch genericBatch, workspaceID pgtype.Text, consumerID pgtype.Text)
+	// InsertRemoteStateConsumerScan scans the result of an executed InsertRemoteStateConsumerBatch query.
+	InsertRemoteStateConsumerScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRemoteStateConsumerIDs(ctx context.Context, workspaceID pgtype.Text) ([]pgtype.Text, error)
+	// FindRemoteStateConsumerIDsBatch enqueues a FindRemoteStateConsumerIDs query into batch to be executed
+	// later by the batch.
+	FindRemoteStateConsumerIDsBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindRemoteStateConsumerIDsScan scans the result of an executed FindRemoteStateConsumerIDsBatch query.
+	FindRemoteStateConsumerIDsScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	DeleteRemoteStateConsumer(ctx context.Context, workspaceID pgtype.Text, consumerID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteRemoteStateConsumerBatch enqueues a DeleteRemoteStateConsumer query into batch to be executed
+	// later by the batch.
+	DeleteRemoteStateConsumerBatch(batch genericBatch, workspaceID pgtype.Text, consumerID pgtype.Text)
+	// DeleteRemoteStateConsumerScan scans the result of an executed DeleteRemoteStateConsumerBatch query.
+	DeleteRemoteStateConsumerScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteRemoteStateConsumers(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteRemoteStateConsumersBatch enqueues a DeleteRemoteStateConsumers query into batch to be executed
+	// later by the batch.
+	DeleteRemoteStateConsumersBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteRemoteStateConsumersScan scans the result of an executed DeleteRemoteStateConsumersBatch query.
+	DeleteRemoteStateConsumersScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertRepoConnection(ctx context.Context, params InsertRepoConnectionParams) (pgconn.CommandTag, error)
 	// InsertRepoConnectionBatch enqueues a InsertRepoConnection query into batch to be executed
 	// later by the batch.
@@ -566,6 +825,20 @@ type Querier interface {
 	// UpdateRunForceCancelAvailableAtScan scans the result of an executed UpdateRunForceCancelAvailableAtBatch query.
 	UpdateRunForceCancelAvailableAtScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	UpdateRunQueuedByLimit(ctx context.Context, queuedByLimit bool, id pgtype.Text) (pgtype.Text, error)
+	// UpdateRunQueuedByLimitBatch enqueues a UpdateRunQueuedByLimit query into batch to be executed
+	// later by the batch.
+	UpdateRunQueuedByLimitBatch(batch genericBatch, queuedByLimit bool, id pgtype.Text)
+	// UpdateRunQueuedByLimitScan scans the result of an executed UpdateRunQueuedByLimitBatch query.
+	UpdateRunQueuedByLimitScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	CountInFlightRuns(ctx context.Context, params CountInFlightRunsParams) (pgtype.Int8, error)
+	// CountInFlightRunsBatch enqueues a CountInFlightRuns query into batch to be executed
+	// later by the batch.
+	CountInFlightRunsBatch(batch genericBatch, params CountInFlightRunsParams)
+	// CountInFlightRunsScan scans the result of an executed CountInFlightRunsBatch query.
+	CountInFlightRunsScan(results pgx.BatchResults) (pgtype.Int8, error)
+
 	DeleteRunByID(ctx context.Context, runID pgtype.Text) (pgtype.Text, error)
 	// DeleteRunByIDBatch enqueues a DeleteRunByID query into batch to be executed
 	// later by the batch.
@@ -573,6 +846,237 @@ type Querier interface {
 	// DeleteRunByIDScan scans the result of an executed DeleteRunByIDBatch query.
 	DeleteRunByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertRunApproval(ctx context.Context, params InsertRunApprovalParams) (pgconn.CommandTag, error)
+	// InsertRunApprovalBatch enqueues a InsertRunApproval query into batch to be executed
+	// later by the batch.
+	InsertRunApprovalBatch(batch genericBatch, params InsertRunApprovalParams)
+	// InsertRunApprovalScan scans the result of an executed InsertRunApprovalBatch query.
+	InsertRunApprovalScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunApprovalByID(ctx context.Context, runApprovalID pgtype.Text) (FindRunApprovalByIDRow, error)
+	// FindRunApprovalByIDBatch enqueues a FindRunApprovalByID query into batch to be executed
+	// later by the batch.
+	FindRunApprovalByIDBatch(batch genericBatch, runApprovalID pgtype.Text)
+	// FindRunApprovalByIDScan scans the result of an executed FindRunApprovalByIDBatch query.
+	FindRunApprovalByIDScan(results pgx.BatchResults) (FindRunApprovalByIDRow, error)
+
+	FindRunApprovalsByRunID(ctx context.Context, runID pgtype.Text) ([]FindRunApprovalsByRunIDRow, error)
+	// FindRunApprovalsByRunIDBatch enqueues a FindRunApprovalsByRunID query into batch to be executed
+	// later by the batch.
+	FindRunApprovalsByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunApprovalsByRunIDScan scans the result of an executed FindRunApprovalsByRunIDBatch query.
+	FindRunApprovalsByRunIDScan(results pgx.BatchResults) ([]FindRunApprovalsByRunIDRow, error)
+
+	InsertRunComment(ctx context.Context, params InsertRunCommentParams) (pgconn.CommandTag, error)
+	// InsertRunCommentBatch enqueues a InsertRunComment query into batch to be executed
+	// later by the batch.
+	InsertRunCommentBatch(batch genericBatch, params InsertRunCommentParams)
+	// InsertRunCommentScan scans the result of an executed InsertRunCommentBatch query.
+	InsertRunCommentScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunCommentByID(ctx context.Context, runCommentID pgtype.Text) (FindRunCommentByIDRow, error)
+	// FindRunCommentByIDBatch enqueues a FindRunCommentByID query into batch to be executed
+	// later by the batch.
+	FindRunCommentByIDBatch(batch genericBatch, runCommentID pgtype.Text)
+	// FindRunCommentByIDScan scans the result of an executed FindRunCommentByIDBatch query.
+	FindRunCommentByIDScan(results pgx.BatchResults) (FindRunCommentByIDRow, error)
+
+	FindRunCommentsByRunID(ctx context.Context, runID pgtype.Text) ([]FindRunCommentsByRunIDRow, error)
+	// FindRunCommentsByRunIDBatch enqueues a FindRunCommentsByRunID query into batch to be executed
+	// later by the batch.
+	FindRunCommentsByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunCommentsByRunIDScan scans the result of an executed FindRunCommentsByRunIDBatch query.
+	FindRunCommentsByRunIDScan(results pgx.BatchResults) ([]FindRunCommentsByRunIDRow, error)
+
+	InsertRunTask(ctx context.Context, params InsertRunTaskParams) (pgconn.CommandTag, error)
+	// InsertRunTaskBatch enqueues a InsertRunTask query into batch to be executed
+	// later by the batch.
+	InsertRunTaskBatch(batch genericBatch, params InsertRunTaskParams)
+	// InsertRunTaskScan scans the result of an executed InsertRunTaskBatch query.
+	InsertRunTaskScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunTasksByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindRunTasksByOrganizationRow, error)
+	// FindRunTasksByOrganizationBatch enqueues a FindRunTasksByOrganization query into batch to be executed
+	// later by the batch.
+	FindRunTasksByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindRunTasksByOrganizationScan scans the result of an executed FindRunTasksByOrganizationBatch query.
+	FindRunTasksByOrganizationScan(results pgx.BatchResults) ([]FindRunTasksByOrganizationRow, error)
+
+	FindRunTaskByID(ctx context.Context, runTaskID pgtype.Text) (FindRunTaskByIDRow, error)
+	// FindRunTaskByIDBatch enqueues a FindRunTaskByID query into batch to be executed
+	// later by the batch.
+	FindRunTaskByIDBatch(batch genericBatch, runTaskID pgtype.Text)
+	// FindRunTaskByIDScan scans the result of an executed FindRunTaskByIDBatch query.
+	FindRunTaskByIDScan(results pgx.BatchResults) (FindRunTaskByIDRow, error)
+
+	FindRunTaskByIDForUpdate(ctx context.Context, runTaskID pgtype.Text) (FindRunTaskByIDForUpdateRow, error)
+	// FindRunTaskByIDForUpdateBatch enqueues a FindRunTaskByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindRunTaskByIDForUpdateBatch(batch genericBatch, runTaskID pgtype.Text)
+	// FindRunTaskByIDForUpdateScan scans the result of an executed FindRunTaskByIDForUpdateBatch query.
+	FindRunTaskByIDForUpdateScan(results pgx.BatchResults) (FindRunTaskByIDForUpdateRow, error)
+
+	UpdateRunTaskByID(ctx context.Context, params UpdateRunTaskByIDParams) (pgtype.Text, error)
+	// UpdateRunTaskByIDBatch enqueues a UpdateRunTaskByID query into batch to be executed
+	// later by the batch.
+	UpdateRunTaskByIDBatch(batch genericBatch, params UpdateRunTaskByIDParams)
+	// UpdateRunTaskByIDScan scans the result of an executed UpdateRunTaskByIDBatch query.
+	UpdateRunTaskByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeleteRunTaskByID(ctx context.Context, runTaskID pgtype.Text) (pgtype.Text, error)
+	// DeleteRunTaskByIDBatch enqueues a DeleteRunTaskByID query into batch to be executed
+	// later by the batch.
+	DeleteRunTaskByIDBatch(batch genericBatch, runTaskID pgtype.Text)
+	// DeleteRunTaskByIDScan scans the result of an executed DeleteRunTaskByIDBatch query.
+	DeleteRunTaskByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertWorkspaceRunTask(ctx context.Context, params InsertWorkspaceRunTaskParams) (pgconn.CommandTag, error)
+	// InsertWorkspaceRunTaskBatch enqueues a InsertWorkspaceRunTask query into batch to be executed
+	// later by the batch.
+	InsertWorkspaceRunTaskBatch(batch genericBatch, params InsertWorkspaceRunTaskParams)
+	// InsertWorkspaceRunTaskScan scans the result of an executed InsertWorkspaceRunTaskBatch query.
+	InsertWorkspaceRunTaskScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceRunTasksByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindWorkspaceRunTasksByWorkspaceIDRow, error)
+	// FindWorkspaceRunTasksByWorkspaceIDBatch enqueues a FindWorkspaceRunTasksByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceRunTasksByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceRunTasksByWorkspaceIDScan scans the result of an executed FindWorkspaceRunTasksByWorkspaceIDBatch query.
+	FindWorkspaceRunTasksByWorkspaceIDScan(results pgx.BatchResults) ([]FindWorkspaceRunTasksByWorkspaceIDRow, error)
+
+	FindWorkspaceRunTaskByID(ctx context.Context, workspaceRunTaskID pgtype.Text) (FindWorkspaceRunTaskByIDRow, error)
+	// FindWorkspaceRunTaskByIDBatch enqueues a FindWorkspaceRunTaskByID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceRunTaskByIDBatch(batch genericBatch, workspaceRunTaskID pgtype.Text)
+	// FindWorkspaceRunTaskByIDScan scans the result of an executed FindWorkspaceRunTaskByIDBatch query.
+	FindWorkspaceRunTaskByIDScan(results pgx.BatchResults) (FindWorkspaceRunTaskByIDRow, error)
+
+	UpdateWorkspaceRunTaskByID(ctx context.Context, params UpdateWorkspaceRunTaskByIDParams) (pgtype.Text, error)
+	// UpdateWorkspaceRunTaskByIDBatch enqueues a UpdateWorkspaceRunTaskByID query into batch to be executed
+	// later by the batch.
+	UpdateWorkspaceRunTaskByIDBatch(batch genericBatch, params UpdateWorkspaceRunTaskByIDParams)
+	// UpdateWorkspaceRunTaskByIDScan scans the result of an executed UpdateWorkspaceRunTaskByIDBatch query.
+	UpdateWorkspaceRunTaskByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeleteWorkspaceRunTaskByID(ctx context.Context, workspaceRunTaskID pgtype.Text) (pgtype.Text, error)
+	// DeleteWorkspaceRunTaskByIDBatch enqueues a DeleteWorkspaceRunTaskByID query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceRunTaskByIDBatch(batch genericBatch, workspaceRunTaskID pgtype.Text)
+	// DeleteWorkspaceRunTaskByIDScan scans the result of an executed DeleteWorkspaceRunTaskByIDBatch query.
+	DeleteWorkspaceRunTaskByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertTaskResult(ctx context.Context, params InsertTaskResultParams) (pgconn.CommandTag, error)
+	// InsertTaskResultBatch enqueues a InsertTaskResult query into batch to be executed
+	// later by the batch.
+	InsertTaskResultBatch(batch genericBatch, params InsertTaskResultParams)
+	// InsertTaskResultScan scans the result of an executed InsertTaskResultBatch query.
+	InsertTaskResultScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindTaskResultsByRunID(ctx context.Context, runID pgtype.Text) ([]FindTaskResultsByRunIDRow, error)
+	// FindTaskResultsByRunIDBatch enqueues a FindTaskResultsByRunID query into batch to be executed
+	// later by the batch.
+	FindTaskResultsByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindTaskResultsByRunIDScan scans the result of an executed FindTaskResultsByRunIDBatch query.
+	FindTaskResultsByRunIDScan(results pgx.BatchResults) ([]FindTaskResultsByRunIDRow, error)
+
+	FindTaskResultByID(ctx context.Context, taskResultID pgtype.Text) (FindTaskResultByIDRow, error)
+	// FindTaskResultByIDBatch enqueues a FindTaskResultByID query into batch to be executed
+	// later by the batch.
+	FindTaskResultByIDBatch(batch genericBatch, taskResultID pgtype.Text)
+	// FindTaskResultByIDScan scans the result of an executed FindTaskResultByIDBatch query.
+	FindTaskResultByIDScan(results pgx.BatchResults) (FindTaskResultByIDRow, error)
+
+	FindTaskResultByIDForUpdate(ctx context.Context, taskResultID pgtype.Text) (FindTaskResultByIDForUpdateRow, error)
+	// FindTaskResultByIDForUpdateBatch enqueues a FindTaskResultByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindTaskResultByIDForUpdateBatch(batch genericBatch, taskResultID pgtype.Text)
+	// FindTaskResultByIDForUpdateScan scans the result of an executed FindTaskResultByIDForUpdateBatch query.
+	FindTaskResultByIDForUpdateScan(results pgx.BatchResults) (FindTaskResultByIDForUpdateRow, error)
+
+	FindIncompleteTaskResultsCreatedBefore(ctx context.Context, createdBefore pgtype.Timestamptz) ([]FindIncompleteTaskResultsCreatedBeforeRow, error)
+	// FindIncompleteTaskResultsCreatedBeforeBatch enqueues a FindIncompleteTaskResultsCreatedBefore query into batch to be executed
+	// later by the batch.
+	FindIncompleteTaskResultsCreatedBeforeBatch(batch genericBatch, createdBefore pgtype.Timestamptz)
+	// FindIncompleteTaskResultsCreatedBeforeScan scans the result of an executed FindIncompleteTaskResultsCreatedBeforeBatch query.
+	FindIncompleteTaskResultsCreatedBeforeScan(results pgx.BatchResults) ([]FindIncompleteTaskResultsCreatedBeforeRow, error)
+
+	UpdateTaskResultByID(ctx context.Context, params UpdateTaskResultByIDParams) (pgtype.Text, error)
+	// UpdateTaskResultByIDBatch enqueues a UpdateTaskResultByID query into batch to be executed
+	// later by the batch.
+	UpdateTaskResultByIDBatch(batch genericBatch, params UpdateTaskResultByIDParams)
+	// UpdateTaskResultByIDScan scans the result of an executed UpdateTaskResultByIDBatch query.
+	UpdateTaskResultByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertStateOperation(ctx context.Context, params InsertStateOperationParams) (pgconn.CommandTag, error)
+	// InsertStateOperationBatch enqueues a InsertStateOperation query into batch to be executed
+	// later by the batch.
+	InsertStateOperationBatch(batch genericBatch, params InsertStateOperationParams)
+	// InsertStateOperationScan scans the result of an executed InsertStateOperationBatch query.
+	InsertStateOperationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindStateOperationsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindStateOperationsByWorkspaceIDRow, error)
+	// FindStateOperationsByWorkspaceIDBatch enqueues a FindStateOperationsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindStateOperationsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindStateOperationsByWorkspaceIDScan scans the result of an executed FindStateOperationsByWorkspaceIDBatch query.
+	FindStateOperationsByWorkspaceIDScan(results pgx.BatchResults) ([]FindStateOperationsByWorkspaceIDRow, error)
+
+	UpsertOrganizationStateRetentionPolicy(ctx context.Context, params UpsertOrganizationStateRetentionPolicyParams) (pgconn.CommandTag, error)
+	// UpsertOrganizationStateRetentionPolicyBatch enqueues a UpsertOrganizationStateRetentionPolicy query into batch to be executed
+	// later by the batch.
+	UpsertOrganizationStateRetentionPolicyBatch(batch genericBatch, params UpsertOrganizationStateRetentionPolicyParams)
+	// UpsertOrganizationStateRetentionPolicyScan scans the result of an executed UpsertOrganizationStateRetentionPolicyBatch query.
+	UpsertOrganizationStateRetentionPolicyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindOrganizationStateRetentionPolicy(ctx context.Context, organizationName pgtype.Text) (FindOrganizationStateRetentionPolicyRow, error)
+	// FindOrganizationStateRetentionPolicyBatch enqueues a FindOrganizationStateRetentionPolicy query into batch to be executed
+	// later by the batch.
+	FindOrganizationStateRetentionPolicyBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindOrganizationStateRetentionPolicyScan scans the result of an executed FindOrganizationStateRetentionPolicyBatch query.
+	FindOrganizationStateRetentionPolicyScan(results pgx.BatchResults) (FindOrganizationStateRetentionPolicyRow, error)
+
+	DeleteOrganizationStateRetentionPolicy(ctx context.Context, organizationName pgtype.Text) (pgtype.Text, error)
+	// DeleteOrganizationStateRetentionPolicyBatch enqueues a DeleteOrganizationStateRetentionPolicy query into batch to be executed
+	// later by the batch.
+	DeleteOrganizationStateRetentionPolicyBatch(batch genericBatch, organizationName pgtype.Text)
+	// DeleteOrganizationStateRetentionPolicyScan scans the result of an executed DeleteOrganizationStateRetentionPolicyBatch query.
+	DeleteOrganizationStateRetentionPolicyScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpsertWorkspaceStateRetentionPolicy(ctx context.Context, params UpsertWorkspaceStateRetentionPolicyParams) (pgconn.CommandTag, error)
+	// UpsertWorkspaceStateRetentionPolicyBatch enqueues a UpsertWorkspaceStateRetentionPolicy query into batch to be executed
+	// later by the batch.
+	UpsertWorkspaceStateRetentionPolicyBatch(batch genericBatch, params UpsertWorkspaceStateRetentionPolicyParams)
+	// UpsertWorkspaceStateRetentionPolicyScan scans the result of an executed UpsertWorkspaceStateRetentionPolicyBatch query.
+	UpsertWorkspaceStateRetentionPolicyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID pgtype.Text) (FindWorkspaceStateRetentionPolicyRow, error)
+	// FindWorkspaceStateRetentionPolicyBatch enqueues a FindWorkspaceStateRetentionPolicy query into batch to be executed
+	// later by the batch.
+	FindWorkspaceStateRetentionPolicyBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceStateRetentionPolicyScan scans the result of an executed FindWorkspaceStateRetentionPolicyBatch query.
+	FindWorkspaceStateRetentionPolicyScan(results pgx.BatchResults) (FindWorkspaceStateRetentionPolicyRow, error)
+
+	DeleteWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID pgtype.Text) (pgtype.Text, error)
+	// DeleteWorkspaceStateRetentionPolicyBatch enqueues a DeleteWorkspaceStateRetentionPolicy query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceStateRetentionPolicyBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteWorkspaceStateRetentionPolicyScan scans the result of an executed DeleteWorkspaceStateRetentionPolicyBatch query.
+	DeleteWorkspaceStateRetentionPolicyScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	FindEffectiveStateRetentionPolicies(ctx context.Context) ([]FindEffectiveStateRetentionPoliciesRow, error)
+	// FindEffectiveStateRetentionPoliciesBatch enqueues a FindEffectiveStateRetentionPolicies query into batch to be executed
+	// later by the batch.
+	FindEffectiveStateRetentionPoliciesBatch(batch genericBatch)
+	// FindEffectiveStateRetentionPoliciesScan scans the result of an executed FindEffectiveStateRetentionPoliciesBatch query.
+	FindEffectiveStateRetentionPoliciesScan(results pgx.BatchResults) ([]FindEffectiveStateRetentionPoliciesRow, error)
+
+	FindEffectiveStateRetentionPolicyByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (FindEffectiveStateRetentionPolicyByWorkspaceIDRow, error)
+	// FindEffectiveStateRetentionPolicyByWorkspaceIDBatch enqueues a FindEffectiveStateRetentionPolicyByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindEffectiveStateRetentionPolicyByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindEffectiveStateRetentionPolicyByWorkspaceIDScan scans the result of an executed FindEffectiveStateRetentionPolicyByWorkspaceIDBatch query.
+	FindEffectiveStateRetentionPolicyByWorkspaceIDScan(results pgx.BatchResults) (FindEffectiveStateRetentionPolicyByWorkspaceIDRow, error)
+
 	InsertStateVersion(ctx context.Context, params InsertStateVersionParams) (pgconn.CommandTag, error)
 	// InsertStateVersionBatch enqueues a InsertStateVersion query into batch to be executed
 	// later by the batch.
@@ -622,6 +1126,13 @@ type Querier interface {
 	// DeleteStateVersionByIDScan scans the result of an executed DeleteStateVersionByIDBatch query.
 	DeleteStateVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	UpdateStateVersionPinned(ctx context.Context, pinned bool, stateVersionID pgtype.Text) (pgtype.Text, error)
+	// UpdateStateVersionPinnedBatch enqueues a UpdateStateVersionPinned query into batch to be executed
+	// later by the batch.
+	UpdateStateVersionPinnedBatch(batch genericBatch, pinned bool, stateVersionID pgtype.Text)
+	// UpdateStateVersionPinnedScan scans the result of an executed UpdateStateVersionPinnedBatch query.
+	UpdateStateVersionPinnedScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertStateVersionOutput(ctx context.Context, params InsertStateVersionOutputParams) (pgconn.CommandTag, error)
 	// InsertStateVersionOutputBatch enqueues a InsertStateVersionOutput query into batch to be executed
 	// later by the batch.
@@ -958,6 +1469,41 @@ type Querier interface {
 	// DeleteVCSProviderByIDScan scans the result of an executed DeleteVCSProviderByIDBatch query.
 	DeleteVCSProviderByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertVCSUserMapping(ctx context.Context, params InsertVCSUserMappingParams) (pgtype.Text, error)
+	// InsertVCSUserMappingBatch enqueues a InsertVCSUserMapping query into batch to be executed
+	// later by the batch.
+	InsertVCSUserMappingBatch(batch genericBatch, params InsertVCSUserMappingParams)
+	// InsertVCSUserMappingScan scans the result of an executed InsertVCSUserMappingBatch query.
+	InsertVCSUserMappingScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	FindVCSUserMappingByID(ctx context.Context, vcsUserMappingID pgtype.Text) (FindVCSUserMappingByIDRow, error)
+	// FindVCSUserMappingByIDBatch enqueues a FindVCSUserMappingByID query into batch to be executed
+	// later by the batch.
+	FindVCSUserMappingByIDBatch(batch genericBatch, vcsUserMappingID pgtype.Text)
+	// FindVCSUserMappingByIDScan scans the result of an executed FindVCSUserMappingByIDBatch query.
+	FindVCSUserMappingByIDScan(results pgx.BatchResults) (FindVCSUserMappingByIDRow, error)
+
+	FindVCSUserMappingByVCSUsername(ctx context.Context, vcsProviderID pgtype.Text, vcsUsername pgtype.Text) (FindVCSUserMappingByVCSUsernameRow, error)
+	// FindVCSUserMappingByVCSUsernameBatch enqueues a FindVCSUserMappingByVCSUsername query into batch to be executed
+	// later by the batch.
+	FindVCSUserMappingByVCSUsernameBatch(batch genericBatch, vcsProviderID pgtype.Text, vcsUsername pgtype.Text)
+	// FindVCSUserMappingByVCSUsernameScan scans the result of an executed FindVCSUserMappingByVCSUsernameBatch query.
+	FindVCSUserMappingByVCSUsernameScan(results pgx.BatchResults) (FindVCSUserMappingByVCSUsernameRow, error)
+
+	FindVCSUserMappings(ctx context.Context, vcsProviderID pgtype.Text) ([]FindVCSUserMappingsRow, error)
+	// FindVCSUserMappingsBatch enqueues a FindVCSUserMappings query into batch to be executed
+	// later by the batch.
+	FindVCSUserMappingsBatch(batch genericBatch, vcsProviderID pgtype.Text)
+	// FindVCSUserMappingsScan scans the result of an executed FindVCSUserMappingsBatch query.
+	FindVCSUserMappingsScan(results pgx.BatchResults) ([]FindVCSUserMappingsRow, error)
+
+	DeleteVCSUserMappingByID(ctx context.Context, vcsUserMappingID pgtype.Text) (pgtype.Text, error)
+	// DeleteVCSUserMappingByIDBatch enqueues a DeleteVCSUserMappingByID query into batch to be executed
+	// later by the batch.
+	DeleteVCSUserMappingByIDBatch(batch genericBatch, vcsUserMappingID pgtype.Text)
+	// DeleteVCSUserMappingByIDScan scans the result of an executed DeleteVCSUserMappingByIDBatch query.
+	DeleteVCSUserMappingByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertWebhook(ctx context.Context, params InsertWebhookParams) (InsertWebhookRow, error)
 	// InsertWebhookBatch enqueues a InsertWebhook query into batch to be executed
 	// later by the batch.
@@ -1042,10 +1588,10 @@ type Querier interface {
 	// FindWorkspacesByUsernameScan scans the result of an executed FindWorkspacesByUsernameBatch query.
 	FindWorkspacesByUsernameScan(results pgx.BatchResults) ([]FindWorkspacesByUsernameRow, error)
 
-	CountWorkspacesByUsername(ctx context.Context, organizationName pgtype.Text, username pgtype.Text) (pgtype.Int8, error)
+	CountWorkspacesByUsername(ctx context.Context, params CountWorkspacesByUsernameParams) (pgtype.Int8, error)
 	// CountWorkspacesByUsernameBatch enqueues a CountWorkspacesByUsername query into batch to be executed
 	// later by the batch.
-	CountWorkspacesByUsernameBatch(batch genericBatch, organizationName pgtype.Text, username pgtype.Text)
+	CountWorkspacesByUsernameBatch(batch genericBatch, params CountWorkspacesByUsernameParams)
 	// CountWorkspacesByUsernameScan scans the result of an executed CountWorkspacesByUsernameBatch query.
 	CountWorkspacesByUsernameScan(results pgx.BatchResults) (pgtype.Int8, error)
 
@@ -1084,6 +1630,13 @@ type Querier interface {
 	// UpdateWorkspaceLockByIDScan scans the result of an executed UpdateWorkspaceLockByIDBatch query.
 	UpdateWorkspaceLockByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	FindWorkspaceIDsWithExpiredLocks(ctx context.Context, now pgtype.Timestamptz) ([]pgtype.Text, error)
+	// FindWorkspaceIDsWithExpiredLocksBatch enqueues a FindWorkspaceIDsWithExpiredLocks query into batch to be executed
+	// later by the batch.
+	FindWorkspaceIDsWithExpiredLocksBatch(batch genericBatch, now pgtype.Timestamptz)
+	// FindWorkspaceIDsWithExpiredLocksScan scans the result of an executed FindWorkspaceIDsWithExpiredLocksBatch query.
+	FindWorkspaceIDsWithExpiredLocksScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
 	UpdateWorkspaceLatestRun(ctx context.Context, runID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
 	// UpdateWorkspaceLatestRunBatch enqueues a UpdateWorkspaceLatestRun query into batch to be executed
 	// later by the batch.
@@ -1105,6 +1658,97 @@ type Querier interface {
 	// DeleteWorkspaceByIDScan scans the result of an executed DeleteWorkspaceByIDBatch query.
 	DeleteWorkspaceByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	FindRemoteStateConsumers(ctx context.Context, workspaceID pgtype.Text) ([]FindRemoteStateConsumersRow, error)
+	// FindRemoteStateConsumersBatch enqueues a FindRemoteStateConsumers query into batch to be executed
+	// later by the batch.
+	FindRemoteStateConsumersBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindRemoteStateConsumersScan scans the result of an executed FindRemoteStateConsumersBatch query.
+	FindRemoteStateConsumersScan(results pgx.BatchResults) ([]FindRemoteStateConsumersRow, error)
+
+	UpsertWorkspaceApprovalPolicy(ctx context.Context, params UpsertWorkspaceApprovalPolicyParams) (pgconn.CommandTag, error)
+	// UpsertWorkspaceApprovalPolicyBatch enqueues a UpsertWorkspaceApprovalPolicy query into batch to be executed
+	// later by the batch.
+	UpsertWorkspaceApprovalPolicyBatch(batch genericBatch, params UpsertWorkspaceApprovalPolicyParams)
+	// UpsertWorkspaceApprovalPolicyScan scans the result of an executed UpsertWorkspaceApprovalPolicyBatch query.
+	UpsertWorkspaceApprovalPolicyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteWorkspaceApprovalTeams(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteWorkspaceApprovalTeamsBatch enqueues a DeleteWorkspaceApprovalTeams query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceApprovalTeamsBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteWorkspaceApprovalTeamsScan scans the result of an executed DeleteWorkspaceApprovalTeamsBatch query.
+	DeleteWorkspaceApprovalTeamsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertWorkspaceApprovalTeam(ctx context.Context, teamName pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertWorkspaceApprovalTeamBatch enqueues a InsertWorkspaceApprovalTeam query into batch to be executed
+	// later by the batch.
+	InsertWorkspaceApprovalTeamBatch(batch genericBatch, teamName pgtype.Text, workspaceID pgtype.Text)
+	// InsertWorkspaceApprovalTeamScan scans the result of an executed InsertWorkspaceApprovalTeamBatch query.
+	InsertWorkspaceApprovalTeamScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceApprovalPolicy(ctx context.Context, workspaceID pgtype.Text) (FindWorkspaceApprovalPolicyRow, error)
+	// FindWorkspaceApprovalPolicyBatch enqueues a FindWorkspaceApprovalPolicy query into batch to be executed
+	// later by the batch.
+	FindWorkspaceApprovalPolicyBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceApprovalPolicyScan scans the result of an executed FindWorkspaceApprovalPolicyBatch query.
+	FindWorkspaceApprovalPolicyScan(results pgx.BatchResults) (FindWorkspaceApprovalPolicyRow, error)
+
+	InsertWorkspaceDeletion(ctx context.Context, params InsertWorkspaceDeletionParams) (pgconn.CommandTag, error)
+	// InsertWorkspaceDeletionBatch enqueues a InsertWorkspaceDeletion query into batch to be executed
+	// later by the batch.
+	InsertWorkspaceDeletionBatch(batch genericBatch, params InsertWorkspaceDeletionParams)
+	// InsertWorkspaceDeletionScan scans the result of an executed InsertWorkspaceDeletionBatch query.
+	InsertWorkspaceDeletionScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceDeletionByRunID(ctx context.Context, runID pgtype.Text) (FindWorkspaceDeletionByRunIDRow, error)
+	// FindWorkspaceDeletionByRunIDBatch enqueues a FindWorkspaceDeletionByRunID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceDeletionByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindWorkspaceDeletionByRunIDScan scans the result of an executed FindWorkspaceDeletionByRunIDBatch query.
+	FindWorkspaceDeletionByRunIDScan(results pgx.BatchResults) (FindWorkspaceDeletionByRunIDRow, error)
+
+	FindWorkspaceDeletions(ctx context.Context) ([]FindWorkspaceDeletionsRow, error)
+	// FindWorkspaceDeletionsBatch enqueues a FindWorkspaceDeletions query into batch to be executed
+	// later by the batch.
+	FindWorkspaceDeletionsBatch(batch genericBatch)
+	// FindWorkspaceDeletionsScan scans the result of an executed FindWorkspaceDeletionsBatch query.
+	FindWorkspaceDeletionsScan(results pgx.BatchResults) ([]FindWorkspaceDeletionsRow, error)
+
+	DeleteWorkspaceDeletionByRunID(ctx context.Context, runID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteWorkspaceDeletionByRunIDBatch enqueues a DeleteWorkspaceDeletionByRunID query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceDeletionByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// DeleteWorkspaceDeletionByRunIDScan scans the result of an executed DeleteWorkspaceDeletionByRunIDBatch query.
+	DeleteWorkspaceDeletionByRunIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertWorkspaceLockEvent(ctx context.Context, params InsertWorkspaceLockEventParams) (pgconn.CommandTag, error)
+	// InsertWorkspaceLockEventBatch enqueues a InsertWorkspaceLockEvent query into batch to be executed
+	// later by the batch.
+	InsertWorkspaceLockEventBatch(batch genericBatch, params InsertWorkspaceLockEventParams)
+	// InsertWorkspaceLockEventScan scans the result of an executed InsertWorkspaceLockEventBatch query.
+	InsertWorkspaceLockEventScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceLockEventByID(ctx context.Context, workspaceLockEventID pgtype.Text) (FindWorkspaceLockEventByIDRow, error)
+	// FindWorkspaceLockEventByIDBatch enqueues a FindWorkspaceLockEventByID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceLockEventByIDBatch(batch genericBatch, workspaceLockEventID pgtype.Text)
+	// FindWorkspaceLockEventByIDScan scans the result of an executed FindWorkspaceLockEventByIDBatch query.
+	FindWorkspaceLockEventByIDScan(results pgx.BatchResults) (FindWorkspaceLockEventByIDRow, error)
+
+	FindWorkspaceLockEventsByWorkspaceID(ctx context.Context, params FindWorkspaceLockEventsByWorkspaceIDParams) ([]FindWorkspaceLockEventsByWorkspaceIDRow, error)
+	// FindWorkspaceLockEventsByWorkspaceIDBatch enqueues a FindWorkspaceLockEventsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceLockEventsByWorkspaceIDBatch(batch genericBatch, params FindWorkspaceLockEventsByWorkspaceIDParams)
+	// FindWorkspaceLockEventsByWorkspaceIDScan scans the result of an executed FindWorkspaceLockEventsByWorkspaceIDBatch query.
+	FindWorkspaceLockEventsByWorkspaceIDScan(results pgx.BatchResults) ([]FindWorkspaceLockEventsByWorkspaceIDRow, error)
+
+	CountWorkspaceLockEventsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgtype.Int8, error)
+	// CountWorkspaceLockEventsByWorkspaceIDBatch enqueues a CountWorkspaceLockEventsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	CountWorkspaceLockEventsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// CountWorkspaceLockEventsByWorkspaceIDScan scans the result of an executed CountWorkspaceLockEventsByWorkspaceIDBatch query.
+	CountWorkspaceLockEventsByWorkspaceIDScan(results pgx.BatchResults) (pgtype.Int8, error)
+
 	UpsertWorkspacePermission(ctx context.Context, params UpsertWorkspacePermissionParams) (pgconn.CommandTag, error)
 	// UpsertWorkspacePermissionBatch enqueues a UpsertWorkspacePermission query into batch to be executed
 	// later by the batch.
@@ -1223,6 +1867,12 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updateApplyStatusByIDSQL, updateApplyStatusByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateApplyStatusByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, getApplyStructuredOutputByIDSQL, getApplyStructuredOutputByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetApplyStructuredOutputByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateApplyStructuredOutputByIDSQL, updateApplyStructuredOutputByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateApplyStructuredOutputByID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertConfigurationVersionSQL, insertConfigurationVersionSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertConfigurationVersion': %w", err)
 	}
@@ -1259,6 +1909,24 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, insertIngressAttributesSQL, insertIngressAttributesSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertIngressAttributes': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertInventoryResourceSQL, insertInventoryResourceSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertInventoryResource': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findInventoryResourcesSQL, findInventoryResourcesSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindInventoryResources': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countInventoryResourcesSQL, countInventoryResourcesSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountInventoryResources': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteInventoryResourcesByWorkspaceIDSQL, deleteInventoryResourcesByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteInventoryResourcesByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertInventoryIndexSQL, upsertInventoryIndexSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertInventoryIndex': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findUnindexedStateVersionIDsSQL, findUnindexedStateVersionIDsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindUnindexedStateVersionIDs': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertModuleSQL, insertModuleSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertModule': %w", err)
 	}
@@ -1280,9 +1948,15 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, findModuleByModuleVersionIDSQL, findModuleByModuleVersionIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'FindModuleByModuleVersionID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, findModuleByTestRunIDSQL, findModuleByTestRunIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindModuleByTestRunID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, updateModuleStatusByIDSQL, updateModuleStatusByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateModuleStatusByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateModuleTestSettingsByIDSQL, updateModuleTestSettingsByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleTestSettingsByID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertModuleTarballSQL, insertModuleTarballSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertModuleTarball': %w", err)
 	}
@@ -1292,6 +1966,9 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updateModuleVersionStatusByIDSQL, updateModuleVersionStatusByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateModuleVersionStatusByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateModuleVersionTestRunByIDSQL, updateModuleVersionTestRunByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateModuleVersionTestRunByID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, deleteModuleByIDSQL, deleteModuleByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteModuleByID': %w", err)
 	}
@@ -1391,6 +2068,84 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updatePlanJSONByIDSQL, updatePlanJSONByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdatePlanJSONByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, getPlanStructuredOutputByIDSQL, getPlanStructuredOutputByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetPlanStructuredOutputByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updatePlanStructuredOutputByIDSQL, updatePlanStructuredOutputByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdatePlanStructuredOutputByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getPlanTestResultsByIDSQL, getPlanTestResultsByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetPlanTestResultsByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updatePlanTestResultsByIDSQL, updatePlanTestResultsByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdatePlanTestResultsByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertProjectSQL, insertProjectSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertProject': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectsSQL, findProjectsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjects': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countProjectsSQL, countProjectsSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountProjects': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectByIDSQL, findProjectByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectByIDForUpdateSQL, findProjectByIDForUpdateSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectByIDForUpdate': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findDefaultProjectSQL, findDefaultProjectSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindDefaultProject': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateProjectByIDSQL, updateProjectByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateProjectByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteProjectByIDSQL, deleteProjectByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteProjectByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertProjectPermissionSQL, upsertProjectPermissionSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertProjectPermission': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectPermissionsByProjectIDSQL, findProjectPermissionsByProjectIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectPermissionsByProjectID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findProjectPermissionsByWorkspaceIDSQL, findProjectPermissionsByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindProjectPermissionsByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteProjectPermissionByIDSQL, deleteProjectPermissionByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteProjectPermissionByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertPullRequestCommentSQL, upsertPullRequestCommentSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertPullRequestComment': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findPullRequestCommentSQL, findPullRequestCommentSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindPullRequestComment': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertPullRequestLockSQL, insertPullRequestLockSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertPullRequestLock': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findPullRequestLockByWorkspaceIDSQL, findPullRequestLockByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindPullRequestLockByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deletePullRequestLockByWorkspaceIDSQL, deletePullRequestLockByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeletePullRequestLockByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deletePullRequestLocksSQL, deletePullRequestLocksSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeletePullRequestLocks': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRemoteStateConsumerSQL, insertRemoteStateConsumerSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRemoteStateConsumer': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRemoteStateConsumerIDsSQL, findRemoteStateConsumerIDsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRemoteStateConsumerIDs': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRemoteStateConsumerSQL, deleteRemoteStateConsumerSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRemoteStateConsumer': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRemoteStateConsumersSQL, deleteRemoteStateConsumersSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRemoteStateConsumers': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertRepoConnectionSQL, insertRepoConnectionSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertRepoConnection': %w", err)
 	}
@@ -1433,9 +2188,114 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updateRunForceCancelAvailableAtSQL, updateRunForceCancelAvailableAtSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateRunForceCancelAvailableAt': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateRunQueuedByLimitSQL, updateRunQueuedByLimitSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateRunQueuedByLimit': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countInFlightRunsSQL, countInFlightRunsSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountInFlightRuns': %w", err)
+	}
 	if _, err := p.Prepare(ctx, deleteRunByIDSQL, deleteRunByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteRunByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertRunApprovalSQL, insertRunApprovalSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRunApproval': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunApprovalByIDSQL, findRunApprovalByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunApprovalByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunApprovalsByRunIDSQL, findRunApprovalsByRunIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunApprovalsByRunID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRunCommentSQL, insertRunCommentSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRunComment': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunCommentByIDSQL, findRunCommentByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunCommentByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunCommentsByRunIDSQL, findRunCommentsByRunIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunCommentsByRunID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertRunTaskSQL, insertRunTaskSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertRunTask': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunTasksByOrganizationSQL, findRunTasksByOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunTasksByOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunTaskByIDSQL, findRunTaskByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunTaskByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findRunTaskByIDForUpdateSQL, findRunTaskByIDForUpdateSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRunTaskByIDForUpdate': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateRunTaskByIDSQL, updateRunTaskByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateRunTaskByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteRunTaskByIDSQL, deleteRunTaskByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteRunTaskByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertWorkspaceRunTaskSQL, insertWorkspaceRunTaskSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertWorkspaceRunTask': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceRunTasksByWorkspaceIDSQL, findWorkspaceRunTasksByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceRunTasksByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceRunTaskByIDSQL, findWorkspaceRunTaskByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceRunTaskByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateWorkspaceRunTaskByIDSQL, updateWorkspaceRunTaskByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateWorkspaceRunTaskByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteWorkspaceRunTaskByIDSQL, deleteWorkspaceRunTaskByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteWorkspaceRunTaskByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertTaskResultSQL, insertTaskResultSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertTaskResult': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findTaskResultsByRunIDSQL, findTaskResultsByRunIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindTaskResultsByRunID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findTaskResultByIDSQL, findTaskResultByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindTaskResultByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findTaskResultByIDForUpdateSQL, findTaskResultByIDForUpdateSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindTaskResultByIDForUpdate': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findIncompleteTaskResultsCreatedBeforeSQL, findIncompleteTaskResultsCreatedBeforeSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindIncompleteTaskResultsCreatedBefore': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateTaskResultByIDSQL, updateTaskResultByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateTaskResultByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertStateOperationSQL, insertStateOperationSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertStateOperation': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findStateOperationsByWorkspaceIDSQL, findStateOperationsByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindStateOperationsByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertOrganizationStateRetentionPolicySQL, upsertOrganizationStateRetentionPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertOrganizationStateRetentionPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findOrganizationStateRetentionPolicySQL, findOrganizationStateRetentionPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'FindOrganizationStateRetentionPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteOrganizationStateRetentionPolicySQL, deleteOrganizationStateRetentionPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteOrganizationStateRetentionPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertWorkspaceStateRetentionPolicySQL, upsertWorkspaceStateRetentionPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertWorkspaceStateRetentionPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceStateRetentionPolicySQL, findWorkspaceStateRetentionPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceStateRetentionPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteWorkspaceStateRetentionPolicySQL, deleteWorkspaceStateRetentionPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteWorkspaceStateRetentionPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findEffectiveStateRetentionPoliciesSQL, findEffectiveStateRetentionPoliciesSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindEffectiveStateRetentionPolicies': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findEffectiveStateRetentionPolicyByWorkspaceIDSQL, findEffectiveStateRetentionPolicyByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindEffectiveStateRetentionPolicyByWorkspaceID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertStateVersionSQL, insertStateVersionSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertStateVersion': %w", err)
 	}
@@ -1457,6 +2317,9 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, deleteStateVersionByIDSQL, deleteStateVersionByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteStateVersionByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, updateStateVersionPinnedSQL, updateStateVersionPinnedSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateStateVersionPinned': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertStateVersionOutputSQL, insertStateVersionOutputSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertStateVersionOutput': %w", err)
 	}
@@ -1601,6 +2464,21 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, deleteVCSProviderByIDSQL, deleteVCSProviderByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteVCSProviderByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertVCSUserMappingSQL, insertVCSUserMappingSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertVCSUserMapping': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findVCSUserMappingByIDSQL, findVCSUserMappingByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindVCSUserMappingByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findVCSUserMappingByVCSUsernameSQL, findVCSUserMappingByVCSUsernameSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindVCSUserMappingByVCSUsername': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findVCSUserMappingsSQL, findVCSUserMappingsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindVCSUserMappings': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteVCSUserMappingByIDSQL, deleteVCSUserMappingByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteVCSUserMappingByID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, insertWebhookSQL, insertWebhookSQL); err != nil {
 		return fmt.Errorf("prepare query 'InsertWebhook': %w", err)
 	}
@@ -1655,6 +2533,9 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, updateWorkspaceLockByIDSQL, updateWorkspaceLockByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateWorkspaceLockByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, findWorkspaceIDsWithExpiredLocksSQL, findWorkspaceIDsWithExpiredLocksSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceIDsWithExpiredLocks': %w", err)
+	}
 	if _, err := p.Prepare(ctx, updateWorkspaceLatestRunSQL, updateWorkspaceLatestRunSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpdateWorkspaceLatestRun': %w", err)
 	}
@@ -1664,6 +2545,45 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, deleteWorkspaceByIDSQL, deleteWorkspaceByIDSQL); err != nil {
 		return fmt.Errorf("prepare query 'DeleteWorkspaceByID': %w", err)
 	}
+	if _, err := p.Prepare(ctx, findRemoteStateConsumersSQL, findRemoteStateConsumersSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindRemoteStateConsumers': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertWorkspaceApprovalPolicySQL, upsertWorkspaceApprovalPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertWorkspaceApprovalPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteWorkspaceApprovalTeamsSQL, deleteWorkspaceApprovalTeamsSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteWorkspaceApprovalTeams': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertWorkspaceApprovalTeamSQL, insertWorkspaceApprovalTeamSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertWorkspaceApprovalTeam': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceApprovalPolicySQL, findWorkspaceApprovalPolicySQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceApprovalPolicy': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertWorkspaceDeletionSQL, insertWorkspaceDeletionSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertWorkspaceDeletion': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceDeletionByRunIDSQL, findWorkspaceDeletionByRunIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceDeletionByRunID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceDeletionsSQL, findWorkspaceDeletionsSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceDeletions': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteWorkspaceDeletionByRunIDSQL, deleteWorkspaceDeletionByRunIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteWorkspaceDeletionByRunID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertWorkspaceLockEventSQL, insertWorkspaceLockEventSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertWorkspaceLockEvent': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceLockEventByIDSQL, findWorkspaceLockEventByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceLockEventByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, findWorkspaceLockEventsByWorkspaceIDSQL, findWorkspaceLockEventsByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'FindWorkspaceLockEventsByWorkspaceID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countWorkspaceLockEventsByWorkspaceIDSQL, countWorkspaceLockEventsByWorkspaceIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountWorkspaceLockEventsByWorkspaceID': %w", err)
+	}
 	if _, err := p.Prepare(ctx, upsertWorkspacePermissionSQL, upsertWorkspacePermissionSQL); err != nil {
 		return fmt.Errorf("prepare query 'UpsertWorkspacePermission': %w", err)
 	}
@@ -1710,6 +2630,7 @@ type ModuleVersions struct {
 	Status          pgtype.Text        `json:"status"`
 	StatusError     pgtype.Text        `json:"status_error"`
 	ModuleID        pgtype.Text        `json:"module_id"`
+	TestRunID       pgtype.Text        `json:"test_run_id"`
 }
 
 // PhaseStatusTimestamps represents the Postgres composite type "phase_status_timestamps".
@@ -1734,11 +2655,34 @@ type Report struct {
 	Destructions pgtype.Int4 `json:"destructions"`
 }
 
+// RunApprovals represents the Postgres composite type "run_approvals".
+type RunApprovals struct {
+	RunApprovalID pgtype.Text        `json:"run_approval_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	Username      pgtype.Text        `json:"username"`
+	Decision      pgtype.Text        `json:"decision"`
+	Comment       pgtype.Text        `json:"comment"`
+	RunID         pgtype.Text        `json:"run_id"`
+}
+
 // RunStatusTimestamps represents the Postgres composite type "run_status_timestamps".
 type RunStatusTimestamps struct {
 	RunID     pgtype.Text        `json:"run_id"`
 	Status    pgtype.Text        `json:"status"`
 	Timestamp pgtype.Timestamptz `json:"timestamp"`
+	Reason    pgtype.Text        `json:"reason"`
+}
+
+// RunTasks represents the Postgres composite type "run_tasks".
+type RunTasks struct {
+	RunTaskID        pgtype.Text        `json:"run_task_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	Name             pgtype.Text        `json:"name"`
+	Description      pgtype.Text        `json:"description"`
+	URL              pgtype.Text        `json:"url"`
+	HmacKey          pgtype.Text        `json:"hmac_key"`
+	Enabled          bool               `json:"enabled"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
 }
 
 // RunVariables represents the Postgres composite type "run_variables".
@@ -1946,6 +2890,7 @@ func (tr *typeResolver) newModuleVersions() pgtype.ValueTranscoder {
 		compositeField{"status", "text", &pgtype.Text{}},
 		compositeField{"status_error", "text", &pgtype.Text{}},
 		compositeField{"module_id", "text", &pgtype.Text{}},
+		compositeField{"test_run_id", "text", &pgtype.Text{}},
 	)
 }
 
@@ -1983,6 +2928,20 @@ func (tr *typeResolver) newReport() pgtype.ValueTranscoder {
 	)
 }
 
+// newRunApprovals creates a new pgtype.ValueTranscoder for the Postgres
+// composite type 'run_approvals'.
+func (tr *typeResolver) newRunApprovals() pgtype.ValueTranscoder {
+	return tr.newCompositeValue(
+		"run_approvals",
+		compositeField{"run_approval_id", "text", &pgtype.Text{}},
+		compositeField{"created_at", "timestamptz", &pgtype.Timestamptz{}},
+		compositeField{"username", "text", &pgtype.Text{}},
+		compositeField{"decision", "text", &pgtype.Text{}},
+		compositeField{"comment", "text", &pgtype.Text{}},
+		compositeField{"run_id", "text", &pgtype.Text{}},
+	)
+}
+
 // newRunStatusTimestamps creates a new pgtype.ValueTranscoder for the Postgres
 // composite type 'run_status_timestamps'.
 func (tr *typeResolver) newRunStatusTimestamps() pgtype.ValueTranscoder {
@@ -1991,6 +2950,23 @@ func (tr *typeResolver) newRunStatusTimestamps() pgtype.ValueTranscoder {
 		compositeField{"run_id", "text", &pgtype.Text{}},
 		compositeField{"status", "text", &pgtype.Text{}},
 		compositeField{"timestamp", "timestamptz", &pgtype.Timestamptz{}},
+		compositeField{"reason", "text", &pgtype.Text{}},
+	)
+}
+
+// newRunTasks creates a new pgtype.ValueTranscoder for the Postgres
+// composite type 'run_tasks'.
+func (tr *typeResolver) newRunTasks() pgtype.ValueTranscoder {
+	return tr.newCompositeValue(
+		"run_tasks",
+		compositeField{"run_task_id", "text", &pgtype.Text{}},
+		compositeField{"created_at", "timestamptz", &pgtype.Timestamptz{}},
+		compositeField{"name", "text", &pgtype.Text{}},
+		compositeField{"description", "text", &pgtype.Text{}},
+		compositeField{"url", "text", &pgtype.Text{}},
+		compositeField{"hmac_key", "text", &pgtype.Text{}},
+		compositeField{"enabled", "bool", &pgtype.Bool{}},
+		compositeField{"organization_name", "text", &pgtype.Text{}},
 	)
 }
 
@@ -2110,6 +3086,12 @@ func (tr *typeResolver) newPhaseStatusTimestampsArray() pgtype.ValueTranscoder {
 	return tr.newArrayValue("_phase_status_timestamps", "phase_status_timestamps", tr.newPhaseStatusTimestamps)
 }
 
+// newRunApprovalsArray creates a new pgtype.ValueTranscoder for the Postgres
+// '_run_approvals' array type.
+func (tr *typeResolver) newRunApprovalsArray() pgtype.ValueTranscoder {
+	return tr.newArrayValue("_run_approvals", "run_approvals", tr.newRunApprovals)
+}
+
 // newRunStatusTimestampsArray creates a new pgtype.ValueTranscoder for the Postgres
 // '_run_status_timestamps' array type.
 func (tr *typeResolver) newRunStatusTimestampsArray() pgtype.ValueTranscoder {
@@ -117,3 +117,66 @@ func (q *DBQuerier) UpdateApplyStatusByIDScan(results pgx.BatchResults) (pgtype.
 	}
 	return item, nil
 }
+
+const getApplyStructuredOutputByIDSQL = `SELECT structured_output
+FROM applies
+WHERE run_id = $1
+;`
+
+// GetApplyStructuredOutputByID implements Querier.GetApplyStructuredOutputByID.
+func (q *DBQuerier) GetApplyStructuredOutputByID(ctx context.Context, runID pgtype.Text) ([]byte, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetApplyStructuredOutputByID")
+	row := q.conn.QueryRow(ctx, getApplyStructuredOutputByIDSQL, runID)
+	var item []byte
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query GetApplyStructuredOutputByID: %w", err)
+	}
+	return item, nil
+}
+
+// GetApplyStructuredOutputByIDBatch implements Querier.GetApplyStructuredOutputByIDBatch.
+func (q *DBQuerier) GetApplyStructuredOutputByIDBatch(batch genericBatch, runID pgtype.Text) {
+	batch.Queue(getApplyStructuredOutputByIDSQL, runID)
+}
+
+// GetApplyStructuredOutputByIDScan implements Querier.GetApplyStructuredOutputByIDScan.
+func (q *DBQuerier) GetApplyStructuredOutputByIDScan(results pgx.BatchResults) ([]byte, error) {
+	row := results.QueryRow()
+	var item []byte
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetApplyStructuredOutputByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateApplyStructuredOutputByIDSQL = `UPDATE applies
+SET structured_output = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdateApplyStructuredOutputByID implements Querier.UpdateApplyStructuredOutputByID.
+func (q *DBQuerier) UpdateApplyStructuredOutputByID(ctx context.Context, structuredOutput []byte, runID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateApplyStructuredOutputByID")
+	row := q.conn.QueryRow(ctx, updateApplyStructuredOutputByIDSQL, structuredOutput, runID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateApplyStructuredOutputByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateApplyStructuredOutputByIDBatch implements Querier.UpdateApplyStructuredOutputByIDBatch.
+func (q *DBQuerier) UpdateApplyStructuredOutputByIDBatch(batch genericBatch, structuredOutput []byte, runID pgtype.Text) {
+	batch.Queue(updateApplyStructuredOutputByIDSQL, structuredOutput, runID)
+}
+
+// UpdateApplyStructuredOutputByIDScan implements Querier.UpdateApplyStructuredOutputByIDScan.
+func (q *DBQuerier) UpdateApplyStructuredOutputByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateApplyStructuredOutputByIDBatch row: %w", err)
+	}
+	return item, nil
+}
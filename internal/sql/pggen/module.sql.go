@@ -97,6 +97,7 @@ type InsertModuleVersionRow struct {
 	Status          pgtype.Text        `json:"status"`
 	StatusError     pgtype.Text        `json:"status_error"`
 	ModuleID        pgtype.Text        `json:"module_id"`
+	TestRunID       pgtype.Text        `json:"test_run_id"`
 }
 
 // InsertModuleVersion implements Querier.InsertModuleVersion.
@@ -104,7 +105,7 @@ func (q *DBQuerier) InsertModuleVersion(ctx context.Context, params InsertModule
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertModuleVersion")
 	row := q.conn.QueryRow(ctx, insertModuleVersionSQL, params.ModuleVersionID, params.Version, params.CreatedAt, params.UpdatedAt, params.ModuleID, params.Status)
 	var item InsertModuleVersionRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.TestRunID); err != nil {
 		return item, fmt.Errorf("query InsertModuleVersion: %w", err)
 	}
 	return item, nil
@@ -119,7 +120,7 @@ func (q *DBQuerier) InsertModuleVersionBatch(batch genericBatch, params InsertMo
 func (q *DBQuerier) InsertModuleVersionScan(results pgx.BatchResults) (InsertModuleVersionRow, error) {
 	row := results.QueryRow()
 	var item InsertModuleVersionRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.TestRunID); err != nil {
 		return item, fmt.Errorf("scan InsertModuleVersionBatch row: %w", err)
 	}
 	return item, nil
@@ -133,6 +134,8 @@ const listModulesByOrganizationSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -153,6 +156,8 @@ type ListModulesByOrganizationRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	TestWorkspaceID  pgtype.Text        `json:"test_workspace_id"`
+	TestsRequired    bool               `json:"tests_required"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Webhook          *Webhooks          `json:"webhook"`
 	Versions         []ModuleVersions   `json:"versions"`
@@ -172,7 +177,7 @@ func (q *DBQuerier) ListModulesByOrganization(ctx context.Context, organizationN
 	versionsArray := q.types.newModuleVersionsArray()
 	for rows.Next() {
 		var item ListModulesByOrganizationRow
-		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 			return nil, fmt.Errorf("scan ListModulesByOrganization row: %w", err)
 		}
 		if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -210,7 +215,7 @@ func (q *DBQuerier) ListModulesByOrganizationScan(results pgx.BatchResults) ([]L
 	versionsArray := q.types.newModuleVersionsArray()
 	for rows.Next() {
 		var item ListModulesByOrganizationRow
-		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+		if err := rows.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 			return nil, fmt.Errorf("scan ListModulesByOrganizationBatch row: %w", err)
 		}
 		if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -238,6 +243,8 @@ const findModuleByNameSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -266,6 +273,8 @@ type FindModuleByNameRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	TestWorkspaceID  pgtype.Text        `json:"test_workspace_id"`
+	TestsRequired    bool               `json:"tests_required"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Webhook          *Webhooks          `json:"webhook"`
 	Versions         []ModuleVersions   `json:"versions"`
@@ -279,7 +288,7 @@ func (q *DBQuerier) FindModuleByName(ctx context.Context, params FindModuleByNam
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByName: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -306,7 +315,7 @@ func (q *DBQuerier) FindModuleByNameScan(results pgx.BatchResults) (FindModuleBy
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByNameBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -329,6 +338,8 @@ const findModuleByIDSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -349,6 +360,8 @@ type FindModuleByIDRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	TestWorkspaceID  pgtype.Text        `json:"test_workspace_id"`
+	TestsRequired    bool               `json:"tests_required"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Webhook          *Webhooks          `json:"webhook"`
 	Versions         []ModuleVersions   `json:"versions"`
@@ -362,7 +375,7 @@ func (q *DBQuerier) FindModuleByID(ctx context.Context, id pgtype.Text) (FindMod
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByID: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -389,7 +402,7 @@ func (q *DBQuerier) FindModuleByIDScan(results pgx.BatchResults) (FindModuleByID
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByIDBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -412,6 +425,8 @@ const findModuleByWebhookIDSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -432,6 +447,8 @@ type FindModuleByWebhookIDRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	TestWorkspaceID  pgtype.Text        `json:"test_workspace_id"`
+	TestsRequired    bool               `json:"tests_required"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Webhook          *Webhooks          `json:"webhook"`
 	Versions         []ModuleVersions   `json:"versions"`
@@ -445,7 +462,7 @@ func (q *DBQuerier) FindModuleByWebhookID(ctx context.Context, webhookID pgtype.
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByWebhookID: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -472,7 +489,7 @@ func (q *DBQuerier) FindModuleByWebhookIDScan(results pgx.BatchResults) (FindMod
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByWebhookIDBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -495,6 +512,8 @@ const findModuleByModuleVersionIDSQL = `SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -516,6 +535,8 @@ type FindModuleByModuleVersionIDRow struct {
 	Provider         pgtype.Text        `json:"provider"`
 	Status           pgtype.Text        `json:"status"`
 	OrganizationName pgtype.Text        `json:"organization_name"`
+	TestWorkspaceID  pgtype.Text        `json:"test_workspace_id"`
+	TestsRequired    bool               `json:"tests_required"`
 	ModuleConnection *RepoConnections   `json:"module_connection"`
 	Webhook          *Webhooks          `json:"webhook"`
 	Versions         []ModuleVersions   `json:"versions"`
@@ -529,7 +550,7 @@ func (q *DBQuerier) FindModuleByModuleVersionID(ctx context.Context, moduleVersi
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("query FindModuleByModuleVersionID: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -556,7 +577,7 @@ func (q *DBQuerier) FindModuleByModuleVersionIDScan(results pgx.BatchResults) (F
 	moduleConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
 	versionsArray := q.types.newModuleVersionsArray()
-	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
 		return item, fmt.Errorf("scan FindModuleByModuleVersionIDBatch row: %w", err)
 	}
 	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
@@ -571,6 +592,94 @@ func (q *DBQuerier) FindModuleByModuleVersionIDScan(results pgx.BatchResults) (F
 	return item, nil
 }
 
+const findModuleByTestRunIDSQL = `SELECT
+    m.module_id,
+    m.created_at,
+    m.updated_at,
+    m.name,
+    m.provider,
+    m.status,
+    m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
+    (r.*)::"repo_connections" AS module_connection,
+    (h.*)::"webhooks" AS webhook,
+    (
+        SELECT array_agg(v.*) AS versions
+        FROM module_versions v
+        WHERE v.module_id = m.module_id
+    ) AS versions
+FROM modules m
+JOIN module_versions mv USING (module_id)
+LEFT JOIN (repo_connections r JOIN webhooks h USING (webhook_id)) USING (module_id)
+WHERE mv.test_run_id = $1
+;`
+
+type FindModuleByTestRunIDRow struct {
+	ModuleID         pgtype.Text        `json:"module_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	Name             pgtype.Text        `json:"name"`
+	Provider         pgtype.Text        `json:"provider"`
+	Status           pgtype.Text        `json:"status"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TestWorkspaceID  pgtype.Text        `json:"test_workspace_id"`
+	TestsRequired    bool               `json:"tests_required"`
+	ModuleConnection *RepoConnections   `json:"module_connection"`
+	Webhook          *Webhooks          `json:"webhook"`
+	Versions         []ModuleVersions   `json:"versions"`
+}
+
+// FindModuleByTestRunID implements Querier.FindModuleByTestRunID.
+func (q *DBQuerier) FindModuleByTestRunID(ctx context.Context, testRunID pgtype.Text) (FindModuleByTestRunIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindModuleByTestRunID")
+	row := q.conn.QueryRow(ctx, findModuleByTestRunIDSQL, testRunID)
+	var item FindModuleByTestRunIDRow
+	moduleConnectionRow := q.types.newRepoConnections()
+	webhookRow := q.types.newWebhooks()
+	versionsArray := q.types.newModuleVersionsArray()
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+		return item, fmt.Errorf("query FindModuleByTestRunID: %w", err)
+	}
+	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
+		return item, fmt.Errorf("assign FindModuleByTestRunID row: %w", err)
+	}
+	if err := webhookRow.AssignTo(&item.Webhook); err != nil {
+		return item, fmt.Errorf("assign FindModuleByTestRunID row: %w", err)
+	}
+	if err := versionsArray.AssignTo(&item.Versions); err != nil {
+		return item, fmt.Errorf("assign FindModuleByTestRunID row: %w", err)
+	}
+	return item, nil
+}
+
+// FindModuleByTestRunIDBatch implements Querier.FindModuleByTestRunIDBatch.
+func (q *DBQuerier) FindModuleByTestRunIDBatch(batch genericBatch, testRunID pgtype.Text) {
+	batch.Queue(findModuleByTestRunIDSQL, testRunID)
+}
+
+// FindModuleByTestRunIDScan implements Querier.FindModuleByTestRunIDScan.
+func (q *DBQuerier) FindModuleByTestRunIDScan(results pgx.BatchResults) (FindModuleByTestRunIDRow, error) {
+	row := results.QueryRow()
+	var item FindModuleByTestRunIDRow
+	moduleConnectionRow := q.types.newRepoConnections()
+	webhookRow := q.types.newWebhooks()
+	versionsArray := q.types.newModuleVersionsArray()
+	if err := row.Scan(&item.ModuleID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.Provider, &item.Status, &item.OrganizationName, &item.TestWorkspaceID, &item.TestsRequired, moduleConnectionRow, webhookRow, versionsArray); err != nil {
+		return item, fmt.Errorf("scan FindModuleByTestRunIDBatch row: %w", err)
+	}
+	if err := moduleConnectionRow.AssignTo(&item.ModuleConnection); err != nil {
+		return item, fmt.Errorf("assign FindModuleByTestRunID row: %w", err)
+	}
+	if err := webhookRow.AssignTo(&item.Webhook); err != nil {
+		return item, fmt.Errorf("assign FindModuleByTestRunID row: %w", err)
+	}
+	if err := versionsArray.AssignTo(&item.Versions); err != nil {
+		return item, fmt.Errorf("assign FindModuleByTestRunID row: %w", err)
+	}
+	return item, nil
+}
+
 const updateModuleStatusByIDSQL = `UPDATE modules
 SET status = $1
 WHERE module_id = $2
@@ -603,6 +712,46 @@ func (q *DBQuerier) UpdateModuleStatusByIDScan(results pgx.BatchResults) (pgtype
 	return item, nil
 }
 
+const updateModuleTestSettingsByIDSQL = `UPDATE modules
+SET
+    test_workspace_id = $1,
+    tests_required = $2
+WHERE module_id = $3
+RETURNING module_id
+;`
+
+type UpdateModuleTestSettingsByIDParams struct {
+	TestWorkspaceID pgtype.Text
+	TestsRequired   bool
+	ModuleID        pgtype.Text
+}
+
+// UpdateModuleTestSettingsByID implements Querier.UpdateModuleTestSettingsByID.
+func (q *DBQuerier) UpdateModuleTestSettingsByID(ctx context.Context, params UpdateModuleTestSettingsByIDParams) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleTestSettingsByID")
+	row := q.conn.QueryRow(ctx, updateModuleTestSettingsByIDSQL, params.TestWorkspaceID, params.TestsRequired, params.ModuleID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleTestSettingsByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleTestSettingsByIDBatch implements Querier.UpdateModuleTestSettingsByIDBatch.
+func (q *DBQuerier) UpdateModuleTestSettingsByIDBatch(batch genericBatch, params UpdateModuleTestSettingsByIDParams) {
+	batch.Queue(updateModuleTestSettingsByIDSQL, params.TestWorkspaceID, params.TestsRequired, params.ModuleID)
+}
+
+// UpdateModuleTestSettingsByIDScan implements Querier.UpdateModuleTestSettingsByIDScan.
+func (q *DBQuerier) UpdateModuleTestSettingsByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleTestSettingsByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
 const insertModuleTarballSQL = `INSERT INTO module_tarballs (
     tarball,
     module_version_id
@@ -691,6 +840,7 @@ type UpdateModuleVersionStatusByIDRow struct {
 	Status          pgtype.Text        `json:"status"`
 	StatusError     pgtype.Text        `json:"status_error"`
 	ModuleID        pgtype.Text        `json:"module_id"`
+	TestRunID       pgtype.Text        `json:"test_run_id"`
 }
 
 // UpdateModuleVersionStatusByID implements Querier.UpdateModuleVersionStatusByID.
@@ -698,7 +848,7 @@ func (q *DBQuerier) UpdateModuleVersionStatusByID(ctx context.Context, params Up
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionStatusByID")
 	row := q.conn.QueryRow(ctx, updateModuleVersionStatusByIDSQL, params.Status, params.StatusError, params.ModuleVersionID)
 	var item UpdateModuleVersionStatusByIDRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.TestRunID); err != nil {
 		return item, fmt.Errorf("query UpdateModuleVersionStatusByID: %w", err)
 	}
 	return item, nil
@@ -713,12 +863,44 @@ func (q *DBQuerier) UpdateModuleVersionStatusByIDBatch(batch genericBatch, param
 func (q *DBQuerier) UpdateModuleVersionStatusByIDScan(results pgx.BatchResults) (UpdateModuleVersionStatusByIDRow, error) {
 	row := results.QueryRow()
 	var item UpdateModuleVersionStatusByIDRow
-	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID); err != nil {
+	if err := row.Scan(&item.ModuleVersionID, &item.Version, &item.CreatedAt, &item.UpdatedAt, &item.Status, &item.StatusError, &item.ModuleID, &item.TestRunID); err != nil {
 		return item, fmt.Errorf("scan UpdateModuleVersionStatusByIDBatch row: %w", err)
 	}
 	return item, nil
 }
 
+const updateModuleVersionTestRunByIDSQL = `UPDATE module_versions
+SET test_run_id = $1
+WHERE module_version_id = $2
+RETURNING module_version_id
+;`
+
+// UpdateModuleVersionTestRunByID implements Querier.UpdateModuleVersionTestRunByID.
+func (q *DBQuerier) UpdateModuleVersionTestRunByID(ctx context.Context, testRunID pgtype.Text, moduleVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateModuleVersionTestRunByID")
+	row := q.conn.QueryRow(ctx, updateModuleVersionTestRunByIDSQL, testRunID, moduleVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateModuleVersionTestRunByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateModuleVersionTestRunByIDBatch implements Querier.UpdateModuleVersionTestRunByIDBatch.
+func (q *DBQuerier) UpdateModuleVersionTestRunByIDBatch(batch genericBatch, testRunID pgtype.Text, moduleVersionID pgtype.Text) {
+	batch.Queue(updateModuleVersionTestRunByIDSQL, testRunID, moduleVersionID)
+}
+
+// UpdateModuleVersionTestRunByIDScan implements Querier.UpdateModuleVersionTestRunByIDScan.
+func (q *DBQuerier) UpdateModuleVersionTestRunByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateModuleVersionTestRunByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
 const deleteModuleByIDSQL = `DELETE
 FROM modules
 WHERE module_id = $1
@@ -21,7 +21,9 @@ const insertOrganizationSQL = `INSERT INTO organizations (
     cost_estimation_enabled,
     session_remember,
     session_timeout,
-    allow_force_delete_workspaces
+    allow_force_delete_workspaces,
+    max_concurrent_runs,
+    max_concurrent_speculative_runs
 ) VALUES (
     $1,
     $2,
@@ -32,26 +34,30 @@ const insertOrganizationSQL = `INSERT INTO organizations (
     $7,
     $8,
     $9,
-    $10
+    $10,
+    $11,
+    $12
 );`
 
 type InsertOrganizationParams struct {
-	ID                         pgtype.Text
-	CreatedAt                  pgtype.Timestamptz
-	UpdatedAt                  pgtype.Timestamptz
-	Name                       pgtype.Text
-	Email                      pgtype.Text
-	CollaboratorAuthPolicy     pgtype.Text
-	CostEstimationEnabled      bool
-	SessionRemember            pgtype.Int4
-	SessionTimeout             pgtype.Int4
-	AllowForceDeleteWorkspaces bool
+	ID                           pgtype.Text
+	CreatedAt                    pgtype.Timestamptz
+	UpdatedAt                    pgtype.Timestamptz
+	Name                         pgtype.Text
+	Email                        pgtype.Text
+	CollaboratorAuthPolicy       pgtype.Text
+	CostEstimationEnabled        bool
+	SessionRemember              pgtype.Int4
+	SessionTimeout               pgtype.Int4
+	AllowForceDeleteWorkspaces   bool
+	MaxConcurrentRuns            pgtype.Int4
+	MaxConcurrentSpeculativeRuns pgtype.Int4
 }
 
 // InsertOrganization implements Querier.InsertOrganization.
 func (q *DBQuerier) InsertOrganization(ctx context.Context, params InsertOrganizationParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertOrganization")
-	cmdTag, err := q.conn.Exec(ctx, insertOrganizationSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces)
+	cmdTag, err := q.conn.Exec(ctx, insertOrganizationSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.MaxConcurrentRuns, params.MaxConcurrentSpeculativeRuns)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertOrganization: %w", err)
 	}
@@ -60,7 +66,7 @@ func (q *DBQuerier) InsertOrganization(ctx context.Context, params InsertOrganiz
 
 // InsertOrganizationBatch implements Querier.InsertOrganizationBatch.
 func (q *DBQuerier) InsertOrganizationBatch(batch genericBatch, params InsertOrganizationParams) {
-	batch.Queue(insertOrganizationSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces)
+	batch.Queue(insertOrganizationSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.MaxConcurrentRuns, params.MaxConcurrentSpeculativeRuns)
 }
 
 // InsertOrganizationScan implements Querier.InsertOrganizationScan.
@@ -106,16 +112,18 @@ func (q *DBQuerier) FindOrganizationNameByWorkspaceIDScan(results pgx.BatchResul
 const findOrganizationByNameSQL = `SELECT * FROM organizations WHERE name = $1;`
 
 type FindOrganizationByNameRow struct {
-	OrganizationID             pgtype.Text        `json:"organization_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	Name                       pgtype.Text        `json:"name"`
-	SessionRemember            pgtype.Int4        `json:"session_remember"`
-	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
-	Email                      pgtype.Text        `json:"email"`
-	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
-	AllowForceDeleteWorkspaces bool               `json:"allow_force_delete_workspaces"`
-	CostEstimationEnabled      bool               `json:"cost_estimation_enabled"`
+	OrganizationID               pgtype.Text        `json:"organization_id"`
+	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
+	Name                         pgtype.Text        `json:"name"`
+	SessionRemember              pgtype.Int4        `json:"session_remember"`
+	SessionTimeout               pgtype.Int4        `json:"session_timeout"`
+	Email                        pgtype.Text        `json:"email"`
+	CollaboratorAuthPolicy       pgtype.Text        `json:"collaborator_auth_policy"`
+	AllowForceDeleteWorkspaces   bool               `json:"allow_force_delete_workspaces"`
+	CostEstimationEnabled        bool               `json:"cost_estimation_enabled"`
+	MaxConcurrentRuns            pgtype.Int4        `json:"max_concurrent_runs"`
+	MaxConcurrentSpeculativeRuns pgtype.Int4        `json:"max_concurrent_speculative_runs"`
 }
 
 // FindOrganizationByName implements Querier.FindOrganizationByName.
@@ -123,7 +131,7 @@ func (q *DBQuerier) FindOrganizationByName(ctx context.Context, name pgtype.Text
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationByName")
 	row := q.conn.QueryRow(ctx, findOrganizationByNameSQL, name)
 	var item FindOrganizationByNameRow
-	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 		return item, fmt.Errorf("query FindOrganizationByName: %w", err)
 	}
 	return item, nil
@@ -138,7 +146,7 @@ func (q *DBQuerier) FindOrganizationByNameBatch(batch genericBatch, name pgtype.
 func (q *DBQuerier) FindOrganizationByNameScan(results pgx.BatchResults) (FindOrganizationByNameRow, error) {
 	row := results.QueryRow()
 	var item FindOrganizationByNameRow
-	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 		return item, fmt.Errorf("scan FindOrganizationByNameBatch row: %w", err)
 	}
 	return item, nil
@@ -147,16 +155,18 @@ func (q *DBQuerier) FindOrganizationByNameScan(results pgx.BatchResults) (FindOr
 const findOrganizationByIDSQL = `SELECT * FROM organizations WHERE organization_id = $1;`
 
 type FindOrganizationByIDRow struct {
-	OrganizationID             pgtype.Text        `json:"organization_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	Name                       pgtype.Text        `json:"name"`
-	SessionRemember            pgtype.Int4        `json:"session_remember"`
-	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
-	Email                      pgtype.Text        `json:"email"`
-	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
-	AllowForceDeleteWorkspaces bool               `json:"allow_force_delete_workspaces"`
-	CostEstimationEnabled      bool               `json:"cost_estimation_enabled"`
+	OrganizationID               pgtype.Text        `json:"organization_id"`
+	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
+	Name                         pgtype.Text        `json:"name"`
+	SessionRemember              pgtype.Int4        `json:"session_remember"`
+	SessionTimeout               pgtype.Int4        `json:"session_timeout"`
+	Email                        pgtype.Text        `json:"email"`
+	CollaboratorAuthPolicy       pgtype.Text        `json:"collaborator_auth_policy"`
+	AllowForceDeleteWorkspaces   bool               `json:"allow_force_delete_workspaces"`
+	CostEstimationEnabled        bool               `json:"cost_estimation_enabled"`
+	MaxConcurrentRuns            pgtype.Int4        `json:"max_concurrent_runs"`
+	MaxConcurrentSpeculativeRuns pgtype.Int4        `json:"max_concurrent_speculative_runs"`
 }
 
 // FindOrganizationByID implements Querier.FindOrganizationByID.
@@ -164,7 +174,7 @@ func (q *DBQuerier) FindOrganizationByID(ctx context.Context, organizationID pgt
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationByID")
 	row := q.conn.QueryRow(ctx, findOrganizationByIDSQL, organizationID)
 	var item FindOrganizationByIDRow
-	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 		return item, fmt.Errorf("query FindOrganizationByID: %w", err)
 	}
 	return item, nil
@@ -179,7 +189,7 @@ func (q *DBQuerier) FindOrganizationByIDBatch(batch genericBatch, organizationID
 func (q *DBQuerier) FindOrganizationByIDScan(results pgx.BatchResults) (FindOrganizationByIDRow, error) {
 	row := results.QueryRow()
 	var item FindOrganizationByIDRow
-	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 		return item, fmt.Errorf("scan FindOrganizationByIDBatch row: %w", err)
 	}
 	return item, nil
@@ -192,16 +202,18 @@ FOR UPDATE
 ;`
 
 type FindOrganizationByNameForUpdateRow struct {
-	OrganizationID             pgtype.Text        `json:"organization_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	Name                       pgtype.Text        `json:"name"`
-	SessionRemember            pgtype.Int4        `json:"session_remember"`
-	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
-	Email                      pgtype.Text        `json:"email"`
-	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
-	AllowForceDeleteWorkspaces bool               `json:"allow_force_delete_workspaces"`
-	CostEstimationEnabled      bool               `json:"cost_estimation_enabled"`
+	OrganizationID               pgtype.Text        `json:"organization_id"`
+	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
+	Name                         pgtype.Text        `json:"name"`
+	SessionRemember              pgtype.Int4        `json:"session_remember"`
+	SessionTimeout               pgtype.Int4        `json:"session_timeout"`
+	Email                        pgtype.Text        `json:"email"`
+	CollaboratorAuthPolicy       pgtype.Text        `json:"collaborator_auth_policy"`
+	AllowForceDeleteWorkspaces   bool               `json:"allow_force_delete_workspaces"`
+	CostEstimationEnabled        bool               `json:"cost_estimation_enabled"`
+	MaxConcurrentRuns            pgtype.Int4        `json:"max_concurrent_runs"`
+	MaxConcurrentSpeculativeRuns pgtype.Int4        `json:"max_concurrent_speculative_runs"`
 }
 
 // FindOrganizationByNameForUpdate implements Querier.FindOrganizationByNameForUpdate.
@@ -209,7 +221,7 @@ func (q *DBQuerier) FindOrganizationByNameForUpdate(ctx context.Context, name pg
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationByNameForUpdate")
 	row := q.conn.QueryRow(ctx, findOrganizationByNameForUpdateSQL, name)
 	var item FindOrganizationByNameForUpdateRow
-	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 		return item, fmt.Errorf("query FindOrganizationByNameForUpdate: %w", err)
 	}
 	return item, nil
@@ -224,7 +236,7 @@ func (q *DBQuerier) FindOrganizationByNameForUpdateBatch(batch genericBatch, nam
 func (q *DBQuerier) FindOrganizationByNameForUpdateScan(results pgx.BatchResults) (FindOrganizationByNameForUpdateRow, error) {
 	row := results.QueryRow()
 	var item FindOrganizationByNameForUpdateRow
-	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+	if err := row.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 		return item, fmt.Errorf("scan FindOrganizationByNameForUpdateBatch row: %w", err)
 	}
 	return item, nil
@@ -244,16 +256,18 @@ type FindOrganizationsParams struct {
 }
 
 type FindOrganizationsRow struct {
-	OrganizationID             pgtype.Text        `json:"organization_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	Name                       pgtype.Text        `json:"name"`
-	SessionRemember            pgtype.Int4        `json:"session_remember"`
-	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
-	Email                      pgtype.Text        `json:"email"`
-	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
-	AllowForceDeleteWorkspaces bool               `json:"allow_force_delete_workspaces"`
-	CostEstimationEnabled      bool               `json:"cost_estimation_enabled"`
+	OrganizationID               pgtype.Text        `json:"organization_id"`
+	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
+	Name                         pgtype.Text        `json:"name"`
+	SessionRemember              pgtype.Int4        `json:"session_remember"`
+	SessionTimeout               pgtype.Int4        `json:"session_timeout"`
+	Email                        pgtype.Text        `json:"email"`
+	CollaboratorAuthPolicy       pgtype.Text        `json:"collaborator_auth_policy"`
+	AllowForceDeleteWorkspaces   bool               `json:"allow_force_delete_workspaces"`
+	CostEstimationEnabled        bool               `json:"cost_estimation_enabled"`
+	MaxConcurrentRuns            pgtype.Int4        `json:"max_concurrent_runs"`
+	MaxConcurrentSpeculativeRuns pgtype.Int4        `json:"max_concurrent_speculative_runs"`
 }
 
 // FindOrganizations implements Querier.FindOrganizations.
@@ -267,7 +281,7 @@ func (q *DBQuerier) FindOrganizations(ctx context.Context, params FindOrganizati
 	items := []FindOrganizationsRow{}
 	for rows.Next() {
 		var item FindOrganizationsRow
-		if err := rows.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+		if err := rows.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 			return nil, fmt.Errorf("scan FindOrganizations row: %w", err)
 		}
 		items = append(items, item)
@@ -293,7 +307,7 @@ func (q *DBQuerier) FindOrganizationsScan(results pgx.BatchResults) ([]FindOrgan
 	items := []FindOrganizationsRow{}
 	for rows.Next() {
 		var item FindOrganizationsRow
-		if err := rows.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled); err != nil {
+		if err := rows.Scan(&item.OrganizationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.SessionRemember, &item.SessionTimeout, &item.Email, &item.CollaboratorAuthPolicy, &item.AllowForceDeleteWorkspaces, &item.CostEstimationEnabled, &item.MaxConcurrentRuns, &item.MaxConcurrentSpeculativeRuns); err != nil {
 			return nil, fmt.Errorf("scan FindOrganizationsBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -344,26 +358,30 @@ SET
     session_remember = $5,
     session_timeout = $6,
     allow_force_delete_workspaces = $7,
-    updated_at = $8
-WHERE name = $9
+    max_concurrent_runs = $8,
+    max_concurrent_speculative_runs = $9,
+    updated_at = $10
+WHERE name = $11
 RETURNING organization_id;`
 
 type UpdateOrganizationByNameParams struct {
-	NewName                    pgtype.Text
-	Email                      pgtype.Text
-	CollaboratorAuthPolicy     pgtype.Text
-	CostEstimationEnabled      bool
-	SessionRemember            pgtype.Int4
-	SessionTimeout             pgtype.Int4
-	AllowForceDeleteWorkspaces bool
-	UpdatedAt                  pgtype.Timestamptz
-	Name                       pgtype.Text
+	NewName                      pgtype.Text
+	Email                        pgtype.Text
+	CollaboratorAuthPolicy       pgtype.Text
+	CostEstimationEnabled        bool
+	SessionRemember              pgtype.Int4
+	SessionTimeout               pgtype.Int4
+	AllowForceDeleteWorkspaces   bool
+	MaxConcurrentRuns            pgtype.Int4
+	MaxConcurrentSpeculativeRuns pgtype.Int4
+	UpdatedAt                    pgtype.Timestamptz
+	Name                         pgtype.Text
 }
 
 // UpdateOrganizationByName implements Querier.UpdateOrganizationByName.
 func (q *DBQuerier) UpdateOrganizationByName(ctx context.Context, params UpdateOrganizationByNameParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateOrganizationByName")
-	row := q.conn.QueryRow(ctx, updateOrganizationByNameSQL, params.NewName, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.UpdatedAt, params.Name)
+	row := q.conn.QueryRow(ctx, updateOrganizationByNameSQL, params.NewName, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.MaxConcurrentRuns, params.MaxConcurrentSpeculativeRuns, params.UpdatedAt, params.Name)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateOrganizationByName: %w", err)
@@ -373,7 +391,7 @@ func (q *DBQuerier) UpdateOrganizationByName(ctx context.Context, params UpdateO
 
 // UpdateOrganizationByNameBatch implements Querier.UpdateOrganizationByNameBatch.
 func (q *DBQuerier) UpdateOrganizationByNameBatch(batch genericBatch, params UpdateOrganizationByNameParams) {
-	batch.Queue(updateOrganizationByNameSQL, params.NewName, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.UpdatedAt, params.Name)
+	batch.Queue(updateOrganizationByNameSQL, params.NewName, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.MaxConcurrentRuns, params.MaxConcurrentSpeculativeRuns, params.UpdatedAt, params.Name)
 }
 
 // UpdateOrganizationByNameScan implements Querier.UpdateOrganizationByNameScan.
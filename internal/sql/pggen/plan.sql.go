@@ -251,3 +251,129 @@ func (q *DBQuerier) UpdatePlanJSONByIDScan(results pgx.BatchResults) (pgtype.Tex
 	}
 	return item, nil
 }
+
+const getPlanStructuredOutputByIDSQL = `SELECT structured_output
+FROM plans
+WHERE run_id = $1
+;`
+
+// GetPlanStructuredOutputByID implements Querier.GetPlanStructuredOutputByID.
+func (q *DBQuerier) GetPlanStructuredOutputByID(ctx context.Context, runID pgtype.Text) ([]byte, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetPlanStructuredOutputByID")
+	row := q.conn.QueryRow(ctx, getPlanStructuredOutputByIDSQL, runID)
+	var item []byte
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query GetPlanStructuredOutputByID: %w", err)
+	}
+	return item, nil
+}
+
+// GetPlanStructuredOutputByIDBatch implements Querier.GetPlanStructuredOutputByIDBatch.
+func (q *DBQuerier) GetPlanStructuredOutputByIDBatch(batch genericBatch, runID pgtype.Text) {
+	batch.Queue(getPlanStructuredOutputByIDSQL, runID)
+}
+
+// GetPlanStructuredOutputByIDScan implements Querier.GetPlanStructuredOutputByIDScan.
+func (q *DBQuerier) GetPlanStructuredOutputByIDScan(results pgx.BatchResults) ([]byte, error) {
+	row := results.QueryRow()
+	var item []byte
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetPlanStructuredOutputByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updatePlanStructuredOutputByIDSQL = `UPDATE plans
+SET structured_output = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdatePlanStructuredOutputByID implements Querier.UpdatePlanStructuredOutputByID.
+func (q *DBQuerier) UpdatePlanStructuredOutputByID(ctx context.Context, structuredOutput []byte, runID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdatePlanStructuredOutputByID")
+	row := q.conn.QueryRow(ctx, updatePlanStructuredOutputByIDSQL, structuredOutput, runID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdatePlanStructuredOutputByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdatePlanStructuredOutputByIDBatch implements Querier.UpdatePlanStructuredOutputByIDBatch.
+func (q *DBQuerier) UpdatePlanStructuredOutputByIDBatch(batch genericBatch, structuredOutput []byte, runID pgtype.Text) {
+	batch.Queue(updatePlanStructuredOutputByIDSQL, structuredOutput, runID)
+}
+
+// UpdatePlanStructuredOutputByIDScan implements Querier.UpdatePlanStructuredOutputByIDScan.
+func (q *DBQuerier) UpdatePlanStructuredOutputByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdatePlanStructuredOutputByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const getPlanTestResultsByIDSQL = `SELECT test_results
+FROM plans
+WHERE run_id = $1
+;`
+
+// GetPlanTestResultsByID implements Querier.GetPlanTestResultsByID.
+func (q *DBQuerier) GetPlanTestResultsByID(ctx context.Context, runID pgtype.Text) ([]byte, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetPlanTestResultsByID")
+	row := q.conn.QueryRow(ctx, getPlanTestResultsByIDSQL, runID)
+	var item []byte
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query GetPlanTestResultsByID: %w", err)
+	}
+	return item, nil
+}
+
+// GetPlanTestResultsByIDBatch implements Querier.GetPlanTestResultsByIDBatch.
+func (q *DBQuerier) GetPlanTestResultsByIDBatch(batch genericBatch, runID pgtype.Text) {
+	batch.Queue(getPlanTestResultsByIDSQL, runID)
+}
+
+// GetPlanTestResultsByIDScan implements Querier.GetPlanTestResultsByIDScan.
+func (q *DBQuerier) GetPlanTestResultsByIDScan(results pgx.BatchResults) ([]byte, error) {
+	row := results.QueryRow()
+	var item []byte
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetPlanTestResultsByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updatePlanTestResultsByIDSQL = `UPDATE plans
+SET test_results = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdatePlanTestResultsByID implements Querier.UpdatePlanTestResultsByID.
+func (q *DBQuerier) UpdatePlanTestResultsByID(ctx context.Context, testResults []byte, runID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdatePlanTestResultsByID")
+	row := q.conn.QueryRow(ctx, updatePlanTestResultsByIDSQL, testResults, runID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdatePlanTestResultsByID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdatePlanTestResultsByIDBatch implements Querier.UpdatePlanTestResultsByIDBatch.
+func (q *DBQuerier) UpdatePlanTestResultsByIDBatch(batch genericBatch, testResults []byte, runID pgtype.Text) {
+	batch.Queue(updatePlanTestResultsByIDSQL, testResults, runID)
+}
+
+// UpdatePlanTestResultsByIDScan implements Querier.UpdatePlanTestResultsByIDScan.
+func (q *DBQuerier) UpdatePlanTestResultsByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdatePlanTestResultsByIDBatch row: %w", err)
+	}
+	return item, nil
+}
@@ -28,7 +28,9 @@ const insertRunSQL = `INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    save_plan,
+    test
 ) VALUES (
     $1,
     $2,
@@ -46,7 +48,9 @@ const insertRunSQL = `INSERT INTO runs (
     $14,
     $15,
     $16,
-    $17
+    $17,
+    $18,
+    $19
 );`
 
 type InsertRunParams struct {
@@ -67,12 +71,14 @@ type InsertRunParams struct {
 	CreatedBy              pgtype.Text
 	TerraformVersion       pgtype.Text
 	AllowEmptyApply        bool
+	SavePlan               bool
+	Test                   bool
 }
 
 // InsertRun implements Querier.InsertRun.
 func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRun")
-	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.SavePlan, params.Test)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertRun: %w", err)
 	}
@@ -81,7 +87,7 @@ func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgco
 
 // InsertRunBatch implements Querier.InsertRunBatch.
 func (q *DBQuerier) InsertRunBatch(batch genericBatch, params InsertRunParams) {
-	batch.Queue(insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	batch.Queue(insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.SavePlan, params.Test)
 }
 
 // InsertRunScan implements Querier.InsertRunScan.
@@ -96,23 +102,26 @@ func (q *DBQuerier) InsertRunScan(results pgx.BatchResults) (pgconn.CommandTag,
 const insertRunStatusTimestampSQL = `INSERT INTO run_status_timestamps (
     run_id,
     status,
-    timestamp
+    timestamp,
+    reason
 ) VALUES (
     $1,
     $2,
-    $3
+    $3,
+    $4
 );`
 
 type InsertRunStatusTimestampParams struct {
 	ID        pgtype.Text
 	Status    pgtype.Text
 	Timestamp pgtype.Timestamptz
+	Reason    pgtype.Text
 }
 
 // InsertRunStatusTimestamp implements Querier.InsertRunStatusTimestamp.
 func (q *DBQuerier) InsertRunStatusTimestamp(ctx context.Context, params InsertRunStatusTimestampParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRunStatusTimestamp")
-	cmdTag, err := q.conn.Exec(ctx, insertRunStatusTimestampSQL, params.ID, params.Status, params.Timestamp)
+	cmdTag, err := q.conn.Exec(ctx, insertRunStatusTimestampSQL, params.ID, params.Status, params.Timestamp, params.Reason)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertRunStatusTimestamp: %w", err)
 	}
@@ -121,7 +130,7 @@ func (q *DBQuerier) InsertRunStatusTimestamp(ctx context.Context, params InsertR
 
 // InsertRunStatusTimestampBatch implements Querier.InsertRunStatusTimestampBatch.
 func (q *DBQuerier) InsertRunStatusTimestampBatch(batch genericBatch, params InsertRunStatusTimestampParams) {
-	batch.Queue(insertRunStatusTimestampSQL, params.ID, params.Status, params.Timestamp)
+	batch.Queue(insertRunStatusTimestampSQL, params.ID, params.Status, params.Timestamp, params.Reason)
 }
 
 // InsertRunStatusTimestampScan implements Querier.InsertRunStatusTimestampScan.
@@ -197,6 +206,9 @@ const findRunsSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.queued_by_limit,
+    runs.save_plan,
+    runs.test,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -229,7 +241,24 @@ const findRunsSQL = `SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(DISTINCT wrt.stage) AS task_stages
+        FROM workspace_run_tasks wrt
+        JOIN run_tasks rt USING (run_task_id)
+        WHERE wrt.workspace_id = runs.workspace_id
+        AND   rt.enabled
+    ) AS task_stages,
+    COALESCE((
+        SELECT wap.approvals_required
+        FROM workspace_approval_policies wap
+        WHERE wap.workspace_id = runs.workspace_id
+    ), 0) AS approvals_required,
+    (
+        SELECT array_agg(ra.* ORDER BY ra.created_at) AS run_approvals
+        FROM run_approvals ra
+        WHERE ra.run_id = runs.run_id
+    ) AS run_approvals
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -286,6 +315,9 @@ type FindRunsRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        bool                    `json:"allow_empty_apply"`
+	QueuedByLimit          bool                    `json:"queued_by_limit"`
+	SavePlan               bool                    `json:"save_plan"`
+	Test                   bool                    `json:"test"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 bool                    `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -295,6 +327,9 @@ type FindRunsRow struct {
 	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
 	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
 	RunVariables           []RunVariables          `json:"run_variables"`
+	TaskStages             []string                `json:"task_stages"`
+	ApprovalsRequired      pgtype.Int4             `json:"approvals_required"`
+	RunApprovals           []RunApprovals          `json:"run_approvals"`
 }
 
 // FindRuns implements Querier.FindRuns.
@@ -314,9 +349,10 @@ func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]Find
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
+	runApprovalsArray := q.types.newRunApprovalsArray()
 	for rows.Next() {
 		var item FindRunsRow
-		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.QueuedByLimit, &item.SavePlan, &item.Test, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray, &item.TaskStages, &item.ApprovalsRequired, runApprovalsArray); err != nil {
 			return nil, fmt.Errorf("scan FindRuns row: %w", err)
 		}
 		if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -343,6 +379,9 @@ func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]Find
 		if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 			return nil, fmt.Errorf("assign FindRuns row: %w", err)
 		}
+		if err := runApprovalsArray.AssignTo(&item.RunApprovals); err != nil {
+			return nil, fmt.Errorf("assign FindRuns row: %w", err)
+		}
 		items = append(items, item)
 	}
 	if err := rows.Err(); err != nil {
@@ -372,9 +411,10 @@ func (q *DBQuerier) FindRunsScan(results pgx.BatchResults) ([]FindRunsRow, error
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
+	runApprovalsArray := q.types.newRunApprovalsArray()
 	for rows.Next() {
 		var item FindRunsRow
-		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.QueuedByLimit, &item.SavePlan, &item.Test, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray, &item.TaskStages, &item.ApprovalsRequired, runApprovalsArray); err != nil {
 			return nil, fmt.Errorf("scan FindRunsBatch row: %w", err)
 		}
 		if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -401,6 +441,9 @@ func (q *DBQuerier) FindRunsScan(results pgx.BatchResults) ([]FindRunsRow, error
 		if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 			return nil, fmt.Errorf("assign FindRuns row: %w", err)
 		}
+		if err := runApprovalsArray.AssignTo(&item.RunApprovals); err != nil {
+			return nil, fmt.Errorf("assign FindRuns row: %w", err)
+		}
 		items = append(items, item)
 	}
 	if err := rows.Err(); err != nil {
@@ -485,6 +528,9 @@ const findRunByIDSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.queued_by_limit,
+    runs.save_plan,
+    runs.test,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -517,7 +563,24 @@ const findRunByIDSQL = `SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(DISTINCT wrt.stage) AS task_stages
+        FROM workspace_run_tasks wrt
+        JOIN run_tasks rt USING (run_task_id)
+        WHERE wrt.workspace_id = runs.workspace_id
+        AND   rt.enabled
+    ) AS task_stages,
+    COALESCE((
+        SELECT wap.approvals_required
+        FROM workspace_approval_policies wap
+        WHERE wap.workspace_id = runs.workspace_id
+    ), 0) AS approvals_required,
+    (
+        SELECT array_agg(ra.* ORDER BY ra.created_at) AS run_approvals
+        FROM run_approvals ra
+        WHERE ra.run_id = runs.run_id
+    ) AS run_approvals
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -551,6 +614,9 @@ type FindRunByIDRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        bool                    `json:"allow_empty_apply"`
+	QueuedByLimit          bool                    `json:"queued_by_limit"`
+	SavePlan               bool                    `json:"save_plan"`
+	Test                   bool                    `json:"test"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 bool                    `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -560,6 +626,9 @@ type FindRunByIDRow struct {
 	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
 	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
 	RunVariables           []RunVariables          `json:"run_variables"`
+	TaskStages             []string                `json:"task_stages"`
+	ApprovalsRequired      pgtype.Int4             `json:"approvals_required"`
+	RunApprovals           []RunApprovals          `json:"run_approvals"`
 }
 
 // FindRunByID implements Querier.FindRunByID.
@@ -575,7 +644,8 @@ func (q *DBQuerier) FindRunByID(ctx context.Context, runID pgtype.Text) (FindRun
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	runApprovalsArray := q.types.newRunApprovalsArray()
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.QueuedByLimit, &item.SavePlan, &item.Test, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray, &item.TaskStages, &item.ApprovalsRequired, runApprovalsArray); err != nil {
 		return item, fmt.Errorf("query FindRunByID: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -602,6 +672,9 @@ func (q *DBQuerier) FindRunByID(ctx context.Context, runID pgtype.Text) (FindRun
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByID row: %w", err)
 	}
+	if err := runApprovalsArray.AssignTo(&item.RunApprovals); err != nil {
+		return item, fmt.Errorf("assign FindRunByID row: %w", err)
+	}
 	return item, nil
 }
 
@@ -622,7 +695,8 @@ func (q *DBQuerier) FindRunByIDScan(results pgx.BatchResults) (FindRunByIDRow, e
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	runApprovalsArray := q.types.newRunApprovalsArray()
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.QueuedByLimit, &item.SavePlan, &item.Test, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray, &item.TaskStages, &item.ApprovalsRequired, runApprovalsArray); err != nil {
 		return item, fmt.Errorf("scan FindRunByIDBatch row: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -649,6 +723,9 @@ func (q *DBQuerier) FindRunByIDScan(results pgx.BatchResults) (FindRunByIDRow, e
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByID row: %w", err)
 	}
+	if err := runApprovalsArray.AssignTo(&item.RunApprovals); err != nil {
+		return item, fmt.Errorf("assign FindRunByID row: %w", err)
+	}
 	return item, nil
 }
 
@@ -676,6 +753,9 @@ const findRunByIDForUpdateSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.queued_by_limit,
+    runs.save_plan,
+    runs.test,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -708,7 +788,24 @@ const findRunByIDForUpdateSQL = `SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(DISTINCT wrt.stage) AS task_stages
+        FROM workspace_run_tasks wrt
+        JOIN run_tasks rt USING (run_task_id)
+        WHERE wrt.workspace_id = runs.workspace_id
+        AND   rt.enabled
+    ) AS task_stages,
+    COALESCE((
+        SELECT wap.approvals_required
+        FROM workspace_approval_policies wap
+        WHERE wap.workspace_id = runs.workspace_id
+    ), 0) AS approvals_required,
+    (
+        SELECT array_agg(ra.* ORDER BY ra.created_at) AS run_approvals
+        FROM run_approvals ra
+        WHERE ra.run_id = runs.run_id
+    ) AS run_approvals
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -743,6 +840,9 @@ type FindRunByIDForUpdateRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        bool                    `json:"allow_empty_apply"`
+	QueuedByLimit          bool                    `json:"queued_by_limit"`
+	SavePlan               bool                    `json:"save_plan"`
+	Test                   bool                    `json:"test"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 bool                    `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -752,6 +852,9 @@ type FindRunByIDForUpdateRow struct {
 	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
 	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
 	RunVariables           []RunVariables          `json:"run_variables"`
+	TaskStages             []string                `json:"task_stages"`
+	ApprovalsRequired      pgtype.Int4             `json:"approvals_required"`
+	RunApprovals           []RunApprovals          `json:"run_approvals"`
 }
 
 // FindRunByIDForUpdate implements Querier.FindRunByIDForUpdate.
@@ -767,7 +870,8 @@ func (q *DBQuerier) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text)
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	runApprovalsArray := q.types.newRunApprovalsArray()
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.QueuedByLimit, &item.SavePlan, &item.Test, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray, &item.TaskStages, &item.ApprovalsRequired, runApprovalsArray); err != nil {
 		return item, fmt.Errorf("query FindRunByIDForUpdate: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -794,6 +898,9 @@ func (q *DBQuerier) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text)
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
 	}
+	if err := runApprovalsArray.AssignTo(&item.RunApprovals); err != nil {
+		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
+	}
 	return item, nil
 }
 
@@ -814,7 +921,8 @@ func (q *DBQuerier) FindRunByIDForUpdateScan(results pgx.BatchResults) (FindRunB
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	runApprovalsArray := q.types.newRunApprovalsArray()
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.ForceCancelAvailableAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.QueuedByLimit, &item.SavePlan, &item.Test, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray, &item.TaskStages, &item.ApprovalsRequired, runApprovalsArray); err != nil {
 		return item, fmt.Errorf("scan FindRunByIDForUpdateBatch row: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -841,6 +949,9 @@ func (q *DBQuerier) FindRunByIDForUpdateScan(results pgx.BatchResults) (FindRunB
 	if err := runVariablesArray.AssignTo(&item.RunVariables); err != nil {
 		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
 	}
+	if err := runApprovalsArray.AssignTo(&item.RunApprovals); err != nil {
+		return item, fmt.Errorf("assign FindRunByIDForUpdate row: %w", err)
+	}
 	return item, nil
 }
 
@@ -973,6 +1084,79 @@ func (q *DBQuerier) UpdateRunForceCancelAvailableAtScan(results pgx.BatchResults
 	return item, nil
 }
 
+const updateRunQueuedByLimitSQL = `UPDATE runs
+SET
+    queued_by_limit = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdateRunQueuedByLimit implements Querier.UpdateRunQueuedByLimit.
+func (q *DBQuerier) UpdateRunQueuedByLimit(ctx context.Context, queuedByLimit bool, id pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateRunQueuedByLimit")
+	row := q.conn.QueryRow(ctx, updateRunQueuedByLimitSQL, queuedByLimit, id)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateRunQueuedByLimit: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateRunQueuedByLimitBatch implements Querier.UpdateRunQueuedByLimitBatch.
+func (q *DBQuerier) UpdateRunQueuedByLimitBatch(batch genericBatch, queuedByLimit bool, id pgtype.Text) {
+	batch.Queue(updateRunQueuedByLimitSQL, queuedByLimit, id)
+}
+
+// UpdateRunQueuedByLimitScan implements Querier.UpdateRunQueuedByLimitScan.
+func (q *DBQuerier) UpdateRunQueuedByLimitScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateRunQueuedByLimitBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const countInFlightRunsSQL = `SELECT count(*)
+FROM runs
+JOIN workspaces USING (workspace_id)
+WHERE workspaces.organization_name = $1
+AND   runs.status = ANY($2)
+AND   runs.plan_only = $3
+;`
+
+type CountInFlightRunsParams struct {
+	OrganizationName pgtype.Text
+	Statuses         []string
+	PlanOnly         bool
+}
+
+// CountInFlightRuns implements Querier.CountInFlightRuns.
+func (q *DBQuerier) CountInFlightRuns(ctx context.Context, params CountInFlightRunsParams) (pgtype.Int8, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountInFlightRuns")
+	row := q.conn.QueryRow(ctx, countInFlightRunsSQL, params.OrganizationName, params.Statuses, params.PlanOnly)
+	var item pgtype.Int8
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query CountInFlightRuns: %w", err)
+	}
+	return item, nil
+}
+
+// CountInFlightRunsBatch implements Querier.CountInFlightRunsBatch.
+func (q *DBQuerier) CountInFlightRunsBatch(batch genericBatch, params CountInFlightRunsParams) {
+	batch.Queue(countInFlightRunsSQL, params.OrganizationName, params.Statuses, params.PlanOnly)
+}
+
+// CountInFlightRunsScan implements Querier.CountInFlightRunsScan.
+func (q *DBQuerier) CountInFlightRunsScan(results pgx.BatchResults) (pgtype.Int8, error) {
+	row := results.QueryRow()
+	var item pgtype.Int8
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CountInFlightRunsBatch row: %w", err)
+	}
+	return item, nil
+}
+
 const deleteRunByIDSQL = `DELETE
 FROM runs
 WHERE run_id = $1
@@ -16,13 +16,15 @@ const insertStateVersionSQL = `INSERT INTO state_versions (
     created_at,
     serial,
     state,
-    workspace_id
+    workspace_id,
+    run_id
 ) VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 );`
 
 type InsertStateVersionParams struct {
@@ -31,12 +33,13 @@ type InsertStateVersionParams struct {
 	Serial      pgtype.Int4
 	State       []byte
 	WorkspaceID pgtype.Text
+	RunID       pgtype.Text
 }
 
 // InsertStateVersion implements Querier.InsertStateVersion.
 func (q *DBQuerier) InsertStateVersion(ctx context.Context, params InsertStateVersionParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertStateVersion")
-	cmdTag, err := q.conn.Exec(ctx, insertStateVersionSQL, params.ID, params.CreatedAt, params.Serial, params.State, params.WorkspaceID)
+	cmdTag, err := q.conn.Exec(ctx, insertStateVersionSQL, params.ID, params.CreatedAt, params.Serial, params.State, params.WorkspaceID, params.RunID)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertStateVersion: %w", err)
 	}
@@ -45,7 +48,7 @@ func (q *DBQuerier) InsertStateVersion(ctx context.Context, params InsertStateVe
 
 // InsertStateVersionBatch implements Querier.InsertStateVersionBatch.
 func (q *DBQuerier) InsertStateVersionBatch(batch genericBatch, params InsertStateVersionParams) {
-	batch.Queue(insertStateVersionSQL, params.ID, params.CreatedAt, params.Serial, params.State, params.WorkspaceID)
+	batch.Queue(insertStateVersionSQL, params.ID, params.CreatedAt, params.Serial, params.State, params.WorkspaceID, params.RunID)
 }
 
 // InsertStateVersionScan implements Querier.InsertStateVersionScan.
@@ -81,6 +84,8 @@ type FindStateVersionsByWorkspaceIDRow struct {
 	Serial              pgtype.Int4           `json:"serial"`
 	State               []byte                `json:"state"`
 	WorkspaceID         pgtype.Text           `json:"workspace_id"`
+	RunID               pgtype.Text           `json:"run_id"`
+	Pinned              bool                  `json:"pinned"`
 	StateVersionOutputs []StateVersionOutputs `json:"state_version_outputs"`
 }
 
@@ -96,7 +101,7 @@ func (q *DBQuerier) FindStateVersionsByWorkspaceID(ctx context.Context, params F
 	stateVersionOutputsArray := q.types.newStateVersionOutputsArray()
 	for rows.Next() {
 		var item FindStateVersionsByWorkspaceIDRow
-		if err := rows.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, stateVersionOutputsArray); err != nil {
+		if err := rows.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, &item.RunID, &item.Pinned, stateVersionOutputsArray); err != nil {
 			return nil, fmt.Errorf("scan FindStateVersionsByWorkspaceID row: %w", err)
 		}
 		if err := stateVersionOutputsArray.AssignTo(&item.StateVersionOutputs); err != nil {
@@ -126,7 +131,7 @@ func (q *DBQuerier) FindStateVersionsByWorkspaceIDScan(results pgx.BatchResults)
 	stateVersionOutputsArray := q.types.newStateVersionOutputsArray()
 	for rows.Next() {
 		var item FindStateVersionsByWorkspaceIDRow
-		if err := rows.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, stateVersionOutputsArray); err != nil {
+		if err := rows.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, &item.RunID, &item.Pinned, stateVersionOutputsArray); err != nil {
 			return nil, fmt.Errorf("scan FindStateVersionsByWorkspaceIDBatch row: %w", err)
 		}
 		if err := stateVersionOutputsArray.AssignTo(&item.StateVersionOutputs); err != nil {
@@ -186,6 +191,8 @@ type FindStateVersionByIDRow struct {
 	Serial              pgtype.Int4           `json:"serial"`
 	State               []byte                `json:"state"`
 	WorkspaceID         pgtype.Text           `json:"workspace_id"`
+	RunID               pgtype.Text           `json:"run_id"`
+	Pinned              bool                  `json:"pinned"`
 	StateVersionOutputs []StateVersionOutputs `json:"state_version_outputs"`
 }
 
@@ -195,7 +202,7 @@ func (q *DBQuerier) FindStateVersionByID(ctx context.Context, id pgtype.Text) (F
 	row := q.conn.QueryRow(ctx, findStateVersionByIDSQL, id)
 	var item FindStateVersionByIDRow
 	stateVersionOutputsArray := q.types.newStateVersionOutputsArray()
-	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, stateVersionOutputsArray); err != nil {
+	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, &item.RunID, &item.Pinned, stateVersionOutputsArray); err != nil {
 		return item, fmt.Errorf("query FindStateVersionByID: %w", err)
 	}
 	if err := stateVersionOutputsArray.AssignTo(&item.StateVersionOutputs); err != nil {
@@ -214,7 +221,7 @@ func (q *DBQuerier) FindStateVersionByIDScan(results pgx.BatchResults) (FindStat
 	row := results.QueryRow()
 	var item FindStateVersionByIDRow
 	stateVersionOutputsArray := q.types.newStateVersionOutputsArray()
-	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, stateVersionOutputsArray); err != nil {
+	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, &item.RunID, &item.Pinned, stateVersionOutputsArray); err != nil {
 		return item, fmt.Errorf("scan FindStateVersionByIDBatch row: %w", err)
 	}
 	if err := stateVersionOutputsArray.AssignTo(&item.StateVersionOutputs); err != nil {
@@ -239,6 +246,8 @@ type FindCurrentStateVersionByWorkspaceIDRow struct {
 	Serial              pgtype.Int4           `json:"serial"`
 	State               []byte                `json:"state"`
 	WorkspaceID         pgtype.Text           `json:"workspace_id"`
+	RunID               pgtype.Text           `json:"run_id"`
+	Pinned              bool                  `json:"pinned"`
 	StateVersionOutputs []StateVersionOutputs `json:"state_version_outputs"`
 }
 
@@ -248,7 +257,7 @@ func (q *DBQuerier) FindCurrentStateVersionByWorkspaceID(ctx context.Context, wo
 	row := q.conn.QueryRow(ctx, findCurrentStateVersionByWorkspaceIDSQL, workspaceID)
 	var item FindCurrentStateVersionByWorkspaceIDRow
 	stateVersionOutputsArray := q.types.newStateVersionOutputsArray()
-	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, stateVersionOutputsArray); err != nil {
+	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, &item.RunID, &item.Pinned, stateVersionOutputsArray); err != nil {
 		return item, fmt.Errorf("query FindCurrentStateVersionByWorkspaceID: %w", err)
 	}
 	if err := stateVersionOutputsArray.AssignTo(&item.StateVersionOutputs); err != nil {
@@ -267,7 +276,7 @@ func (q *DBQuerier) FindCurrentStateVersionByWorkspaceIDScan(results pgx.BatchRe
 	row := results.QueryRow()
 	var item FindCurrentStateVersionByWorkspaceIDRow
 	stateVersionOutputsArray := q.types.newStateVersionOutputsArray()
-	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, stateVersionOutputsArray); err != nil {
+	if err := row.Scan(&item.StateVersionID, &item.CreatedAt, &item.Serial, &item.State, &item.WorkspaceID, &item.RunID, &item.Pinned, stateVersionOutputsArray); err != nil {
 		return item, fmt.Errorf("scan FindCurrentStateVersionByWorkspaceIDBatch row: %w", err)
 	}
 	if err := stateVersionOutputsArray.AssignTo(&item.StateVersionOutputs); err != nil {
@@ -285,7 +294,7 @@ WHERE state_version_id = $1
 func (q *DBQuerier) FindStateVersionStateByID(ctx context.Context, id pgtype.Text) ([]byte, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindStateVersionStateByID")
 	row := q.conn.QueryRow(ctx, findStateVersionStateByIDSQL, id)
-	item := []byte{}
+	var item []byte
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query FindStateVersionStateByID: %w", err)
 	}
@@ -300,7 +309,7 @@ func (q *DBQuerier) FindStateVersionStateByIDBatch(batch genericBatch, id pgtype
 // FindStateVersionStateByIDScan implements Querier.FindStateVersionStateByIDScan.
 func (q *DBQuerier) FindStateVersionStateByIDScan(results pgx.BatchResults) ([]byte, error) {
 	row := results.QueryRow()
-	item := []byte{}
+	var item []byte
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("scan FindStateVersionStateByIDBatch row: %w", err)
 	}
@@ -338,3 +347,35 @@ func (q *DBQuerier) DeleteStateVersionByIDScan(results pgx.BatchResults) (pgtype
 	}
 	return item, nil
 }
+
+const updateStateVersionPinnedSQL = `UPDATE state_versions
+SET pinned = $1
+WHERE state_version_id = $2
+RETURNING state_version_id
+;`
+
+// UpdateStateVersionPinned implements Querier.UpdateStateVersionPinned.
+func (q *DBQuerier) UpdateStateVersionPinned(ctx context.Context, pinned bool, stateVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateStateVersionPinned")
+	row := q.conn.QueryRow(ctx, updateStateVersionPinnedSQL, pinned, stateVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query UpdateStateVersionPinned: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateStateVersionPinnedBatch implements Querier.UpdateStateVersionPinnedBatch.
+func (q *DBQuerier) UpdateStateVersionPinnedBatch(batch genericBatch, pinned bool, stateVersionID pgtype.Text) {
+	batch.Queue(updateStateVersionPinnedSQL, pinned, stateVersionID)
+}
+
+// UpdateStateVersionPinnedScan implements Querier.UpdateStateVersionPinnedScan.
+func (q *DBQuerier) UpdateStateVersionPinnedScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan UpdateStateVersionPinnedBatch row: %w", err)
+	}
+	return item, nil
+}
@@ -36,7 +36,22 @@ const insertWorkspaceSQL = `INSERT INTO workspaces (
     trigger_patterns,
     vcs_tags_regex,
     working_directory,
-    organization_name
+    organization_name,
+    plan_timeout,
+    apply_timeout,
+    auto_discard_ttl,
+    supersede_runs,
+    pr_comments,
+    pr_apply,
+    preview_template,
+    max_previews,
+    preview_template_id,
+    preview_pull_request,
+    auto_destroy_at,
+    auto_destroy_activity_duration,
+    auto_apply_destroy,
+    project_id,
+    template
 ) VALUES (
     $1,
     $2,
@@ -62,41 +77,71 @@ const insertWorkspaceSQL = `INSERT INTO workspaces (
     $22,
     $23,
     $24,
-    $25
+    $25,
+    $26,
+    $27,
+    $28,
+    $29,
+    $30,
+    $31,
+    $32,
+    $33,
+    $34,
+    $35,
+    $36,
+    $37,
+    $38,
+    $39,
+    $40
 );`
 
 type InsertWorkspaceParams struct {
-	ID                         pgtype.Text
-	CreatedAt                  pgtype.Timestamptz
-	UpdatedAt                  pgtype.Timestamptz
-	AllowCLIApply              bool
-	AllowDestroyPlan           bool
-	AutoApply                  bool
-	Branch                     pgtype.Text
-	CanQueueDestroyPlan        bool
-	Description                pgtype.Text
-	Environment                pgtype.Text
-	ExecutionMode              pgtype.Text
-	GlobalRemoteState          bool
-	MigrationEnvironment       pgtype.Text
-	Name                       pgtype.Text
-	QueueAllRuns               bool
-	SpeculativeEnabled         bool
-	SourceName                 pgtype.Text
-	SourceURL                  pgtype.Text
-	StructuredRunOutputEnabled bool
-	TerraformVersion           pgtype.Text
-	TriggerPrefixes            []string
-	TriggerPatterns            []string
-	VCSTagsRegex               pgtype.Text
-	WorkingDirectory           pgtype.Text
-	OrganizationName           pgtype.Text
+	ID                          pgtype.Text
+	CreatedAt                   pgtype.Timestamptz
+	UpdatedAt                   pgtype.Timestamptz
+	AllowCLIApply               bool
+	AllowDestroyPlan            bool
+	AutoApply                   bool
+	Branch                      pgtype.Text
+	CanQueueDestroyPlan         bool
+	Description                 pgtype.Text
+	Environment                 pgtype.Text
+	ExecutionMode               pgtype.Text
+	GlobalRemoteState           bool
+	MigrationEnvironment        pgtype.Text
+	Name                        pgtype.Text
+	QueueAllRuns                bool
+	SpeculativeEnabled          bool
+	SourceName                  pgtype.Text
+	SourceURL                   pgtype.Text
+	StructuredRunOutputEnabled  bool
+	TerraformVersion            pgtype.Text
+	TriggerPrefixes             []string
+	TriggerPatterns             []string
+	VCSTagsRegex                pgtype.Text
+	WorkingDirectory            pgtype.Text
+	OrganizationName            pgtype.Text
+	PlanTimeout                 pgtype.Int4
+	ApplyTimeout                pgtype.Int4
+	AutoDiscardTTL              pgtype.Int4
+	SupersedeRuns               bool
+	PRComments                  bool
+	PRApply                     bool
+	PreviewTemplate             bool
+	MaxPreviews                 pgtype.Int4
+	PreviewTemplateID           pgtype.Text
+	PreviewPullRequest          pgtype.Int4
+	AutoDestroyAt               pgtype.Timestamptz
+	AutoDestroyActivityDuration pgtype.Int4
+	AutoApplyDestroy            bool
+	ProjectID                   pgtype.Text
+	Template                    bool
 }
 
 // InsertWorkspace implements Querier.InsertWorkspace.
 func (q *DBQuerier) InsertWorkspace(ctx context.Context, params InsertWorkspaceParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertWorkspace")
-	cmdTag, err := q.conn.Exec(ctx, insertWorkspaceSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.AllowCLIApply, params.AllowDestroyPlan, params.AutoApply, params.Branch, params.CanQueueDestroyPlan, params.Description, params.Environment, params.ExecutionMode, params.GlobalRemoteState, params.MigrationEnvironment, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.SourceName, params.SourceURL, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.OrganizationName)
+	cmdTag, err := q.conn.Exec(ctx, insertWorkspaceSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.AllowCLIApply, params.AllowDestroyPlan, params.AutoApply, params.Branch, params.CanQueueDestroyPlan, params.Description, params.Environment, params.ExecutionMode, params.GlobalRemoteState, params.MigrationEnvironment, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.SourceName, params.SourceURL, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.OrganizationName, params.PlanTimeout, params.ApplyTimeout, params.AutoDiscardTTL, params.SupersedeRuns, params.PRComments, params.PRApply, params.PreviewTemplate, params.MaxPreviews, params.PreviewTemplateID, params.PreviewPullRequest, params.AutoDestroyAt, params.AutoDestroyActivityDuration, params.AutoApplyDestroy, params.ProjectID, params.Template)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertWorkspace: %w", err)
 	}
@@ -105,7 +150,7 @@ func (q *DBQuerier) InsertWorkspace(ctx context.Context, params InsertWorkspaceP
 
 // InsertWorkspaceBatch implements Querier.InsertWorkspaceBatch.
 func (q *DBQuerier) InsertWorkspaceBatch(batch genericBatch, params InsertWorkspaceParams) {
-	batch.Queue(insertWorkspaceSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.AllowCLIApply, params.AllowDestroyPlan, params.AutoApply, params.Branch, params.CanQueueDestroyPlan, params.Description, params.Environment, params.ExecutionMode, params.GlobalRemoteState, params.MigrationEnvironment, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.SourceName, params.SourceURL, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.OrganizationName)
+	batch.Queue(insertWorkspaceSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.AllowCLIApply, params.AllowDestroyPlan, params.AutoApply, params.Branch, params.CanQueueDestroyPlan, params.Description, params.Environment, params.ExecutionMode, params.GlobalRemoteState, params.MigrationEnvironment, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.SourceName, params.SourceURL, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.OrganizationName, params.PlanTimeout, params.ApplyTimeout, params.AutoDiscardTTL, params.SupersedeRuns, params.PRComments, params.PRApply, params.PreviewTemplate, params.MaxPreviews, params.PreviewTemplateID, params.PreviewPullRequest, params.AutoDestroyAt, params.AutoDestroyActivityDuration, params.AutoApplyDestroy, params.ProjectID, params.Template)
 }
 
 // InsertWorkspaceScan implements Querier.InsertWorkspaceScan.
@@ -152,63 +197,86 @@ LEFT JOIN runs r ON w.latest_run_id = r.run_id
 LEFT JOIN (workspace_tags wt JOIN tags t USING (tag_id)) ON wt.workspace_id = w.workspace_id
 WHERE w.name                LIKE '%' || $1 || '%'
 AND   w.organization_name   LIKE ANY($2)
+AND   w.project_id          LIKE $3
+AND   (w.template OR NOT $4)
 GROUP BY w.workspace_id, r.status
-HAVING array_agg(t.name) @> $3
+HAVING array_agg(t.name) @> $5
 ORDER BY w.updated_at DESC
-LIMIT $4
-OFFSET $5
+LIMIT $6
+OFFSET $7
 ;`
 
 type FindWorkspacesParams struct {
 	Search            pgtype.Text
 	OrganizationNames []string
+	ProjectID         pgtype.Text
+	TemplatesOnly     bool
 	Tags              []string
 	Limit             pgtype.Int8
 	Offset            pgtype.Int8
 }
 
 type FindWorkspacesRow struct {
-	WorkspaceID                pgtype.Text        `json:"workspace_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	AllowDestroyPlan           bool               `json:"allow_destroy_plan"`
-	AutoApply                  bool               `json:"auto_apply"`
-	CanQueueDestroyPlan        bool               `json:"can_queue_destroy_plan"`
-	Description                pgtype.Text        `json:"description"`
-	Environment                pgtype.Text        `json:"environment"`
-	ExecutionMode              pgtype.Text        `json:"execution_mode"`
-	GlobalRemoteState          bool               `json:"global_remote_state"`
-	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
-	Name                       pgtype.Text        `json:"name"`
-	QueueAllRuns               bool               `json:"queue_all_runs"`
-	SpeculativeEnabled         bool               `json:"speculative_enabled"`
-	SourceName                 pgtype.Text        `json:"source_name"`
-	SourceURL                  pgtype.Text        `json:"source_url"`
-	StructuredRunOutputEnabled bool               `json:"structured_run_output_enabled"`
-	TerraformVersion           pgtype.Text        `json:"terraform_version"`
-	TriggerPrefixes            []string           `json:"trigger_prefixes"`
-	WorkingDirectory           pgtype.Text        `json:"working_directory"`
-	LockRunID                  pgtype.Text        `json:"lock_run_id"`
-	LatestRunID                pgtype.Text        `json:"latest_run_id"`
-	OrganizationName           pgtype.Text        `json:"organization_name"`
-	Branch                     pgtype.Text        `json:"branch"`
-	LockUsername               pgtype.Text        `json:"lock_username"`
-	CurrentStateVersionID      pgtype.Text        `json:"current_state_version_id"`
-	TriggerPatterns            []string           `json:"trigger_patterns"`
-	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
-	AllowCLIApply              bool               `json:"allow_cli_apply"`
-	Tags                       []string           `json:"tags"`
-	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
-	UserLock                   *Users             `json:"user_lock"`
-	RunLock                    *Runs              `json:"run_lock"`
-	WorkspaceConnection        *RepoConnections   `json:"workspace_connection"`
-	Webhook                    *Webhooks          `json:"webhook"`
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
 }
 
 // FindWorkspaces implements Querier.FindWorkspaces.
 func (q *DBQuerier) FindWorkspaces(ctx context.Context, params FindWorkspacesParams) ([]FindWorkspacesRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindWorkspaces")
-	rows, err := q.conn.Query(ctx, findWorkspacesSQL, params.Search, params.OrganizationNames, params.Tags, params.Limit, params.Offset)
+	rows, err := q.conn.Query(ctx, findWorkspacesSQL, params.Search, params.OrganizationNames, params.ProjectID, params.TemplatesOnly, params.Tags, params.Limit, params.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("query FindWorkspaces: %w", err)
 	}
@@ -220,7 +288,7 @@ func (q *DBQuerier) FindWorkspaces(ctx context.Context, params FindWorkspacesPar
 	webhookRow := q.types.newWebhooks()
 	for rows.Next() {
 		var item FindWorkspacesRow
-		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspaces row: %w", err)
 		}
 		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -245,7 +313,7 @@ func (q *DBQuerier) FindWorkspaces(ctx context.Context, params FindWorkspacesPar
 
 // FindWorkspacesBatch implements Querier.FindWorkspacesBatch.
 func (q *DBQuerier) FindWorkspacesBatch(batch genericBatch, params FindWorkspacesParams) {
-	batch.Queue(findWorkspacesSQL, params.Search, params.OrganizationNames, params.Tags, params.Limit, params.Offset)
+	batch.Queue(findWorkspacesSQL, params.Search, params.OrganizationNames, params.ProjectID, params.TemplatesOnly, params.Tags, params.Limit, params.Offset)
 }
 
 // FindWorkspacesScan implements Querier.FindWorkspacesScan.
@@ -262,7 +330,7 @@ func (q *DBQuerier) FindWorkspacesScan(results pgx.BatchResults) ([]FindWorkspac
 	webhookRow := q.types.newWebhooks()
 	for rows.Next() {
 		var item FindWorkspacesRow
-		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacesBatch row: %w", err)
 		}
 		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -292,8 +360,10 @@ const countWorkspacesSQL = `WITH
         LEFT JOIN (workspace_tags wt JOIN tags t USING (tag_id)) ON w.workspace_id = wt.workspace_id
         WHERE w.name              LIKE '%' || $1 || '%'
         AND   w.organization_name LIKE ANY($2)
+        AND   w.project_id        LIKE $3
+        AND   (w.template OR NOT $4)
         GROUP BY w.workspace_id
-        HAVING array_agg(t.name) @> $3
+        HAVING array_agg(t.name) @> $5
     )
 SELECT count(*)
 FROM workspaces
@@ -302,13 +372,15 @@ FROM workspaces
 type CountWorkspacesParams struct {
 	Search            pgtype.Text
 	OrganizationNames []string
+	ProjectID         pgtype.Text
+	TemplatesOnly     bool
 	Tags              []string
 }
 
 // CountWorkspaces implements Querier.CountWorkspaces.
 func (q *DBQuerier) CountWorkspaces(ctx context.Context, params CountWorkspacesParams) (pgtype.Int8, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "CountWorkspaces")
-	row := q.conn.QueryRow(ctx, countWorkspacesSQL, params.Search, params.OrganizationNames, params.Tags)
+	row := q.conn.QueryRow(ctx, countWorkspacesSQL, params.Search, params.OrganizationNames, params.ProjectID, params.TemplatesOnly, params.Tags)
 	var item pgtype.Int8
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query CountWorkspaces: %w", err)
@@ -318,7 +390,7 @@ func (q *DBQuerier) CountWorkspaces(ctx context.Context, params CountWorkspacesP
 
 // CountWorkspacesBatch implements Querier.CountWorkspacesBatch.
 func (q *DBQuerier) CountWorkspacesBatch(batch genericBatch, params CountWorkspacesParams) {
-	batch.Queue(countWorkspacesSQL, params.Search, params.OrganizationNames, params.Tags)
+	batch.Queue(countWorkspacesSQL, params.Search, params.OrganizationNames, params.ProjectID, params.TemplatesOnly, params.Tags)
 }
 
 // CountWorkspacesScan implements Querier.CountWorkspacesScan.
@@ -353,41 +425,60 @@ WHERE h.webhook_id = $1
 ;`
 
 type FindWorkspacesByWebhookIDRow struct {
-	WorkspaceID                pgtype.Text        `json:"workspace_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	AllowDestroyPlan           bool               `json:"allow_destroy_plan"`
-	AutoApply                  bool               `json:"auto_apply"`
-	CanQueueDestroyPlan        bool               `json:"can_queue_destroy_plan"`
-	Description                pgtype.Text        `json:"description"`
-	Environment                pgtype.Text        `json:"environment"`
-	ExecutionMode              pgtype.Text        `json:"execution_mode"`
-	GlobalRemoteState          bool               `json:"global_remote_state"`
-	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
-	Name                       pgtype.Text        `json:"name"`
-	QueueAllRuns               bool               `json:"queue_all_runs"`
-	SpeculativeEnabled         bool               `json:"speculative_enabled"`
-	SourceName                 pgtype.Text        `json:"source_name"`
-	SourceURL                  pgtype.Text        `json:"source_url"`
-	StructuredRunOutputEnabled bool               `json:"structured_run_output_enabled"`
-	TerraformVersion           pgtype.Text        `json:"terraform_version"`
-	TriggerPrefixes            []string           `json:"trigger_prefixes"`
-	WorkingDirectory           pgtype.Text        `json:"working_directory"`
-	LockRunID                  pgtype.Text        `json:"lock_run_id"`
-	LatestRunID                pgtype.Text        `json:"latest_run_id"`
-	OrganizationName           pgtype.Text        `json:"organization_name"`
-	Branch                     pgtype.Text        `json:"branch"`
-	LockUsername               pgtype.Text        `json:"lock_username"`
-	CurrentStateVersionID      pgtype.Text        `json:"current_state_version_id"`
-	TriggerPatterns            []string           `json:"trigger_patterns"`
-	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
-	AllowCLIApply              bool               `json:"allow_cli_apply"`
-	Tags                       []string           `json:"tags"`
-	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
-	UserLock                   *Users             `json:"user_lock"`
-	RunLock                    *Runs              `json:"run_lock"`
-	WorkspaceConnection        *RepoConnections   `json:"workspace_connection"`
-	Webhook                    *Webhooks          `json:"webhook"`
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
 }
 
 // FindWorkspacesByWebhookID implements Querier.FindWorkspacesByWebhookID.
@@ -405,7 +496,7 @@ func (q *DBQuerier) FindWorkspacesByWebhookID(ctx context.Context, webhookID pgt
 	webhookRow := q.types.newWebhooks()
 	for rows.Next() {
 		var item FindWorkspacesByWebhookIDRow
-		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacesByWebhookID row: %w", err)
 		}
 		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -447,7 +538,7 @@ func (q *DBQuerier) FindWorkspacesByWebhookIDScan(results pgx.BatchResults) ([]F
 	webhookRow := q.types.newWebhooks()
 	for rows.Next() {
 		var item FindWorkspacesByWebhookIDRow
-		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacesByWebhookIDBatch row: %w", err)
 		}
 		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -484,70 +575,100 @@ const findWorkspacesByUsernameSQL = `SELECT
     (vr.*)::"repo_connections" AS workspace_connection,
     (h.*)::"webhooks" AS webhook
 FROM workspaces w
-JOIN workspace_permissions p USING (workspace_id)
 LEFT JOIN users ul ON w.lock_username = ul.username
 LEFT JOIN runs rl ON w.lock_run_id = rl.run_id
 LEFT JOIN runs r ON w.latest_run_id = r.run_id
 LEFT JOIN (repo_connections vr JOIN webhooks h USING (webhook_id)) ON w.workspace_id = vr.workspace_id
-JOIN teams t USING (team_id)
-JOIN team_memberships tm USING (team_id)
-JOIN users u ON tm.username = u.username
 WHERE w.organization_name  = $1
-AND   u.username           = $2
+AND   w.project_id         LIKE $2
+AND   (
+    w.workspace_id IN (
+        SELECT p.workspace_id
+        FROM workspace_permissions p
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = $3
+    )
+    OR w.project_id IN (
+        SELECT pp.project_id
+        FROM project_permissions pp
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = $3
+    )
+)
 ORDER BY w.updated_at DESC
-LIMIT $3
-OFFSET $4
+LIMIT $4
+OFFSET $5
 ;`
 
 type FindWorkspacesByUsernameParams struct {
 	OrganizationName pgtype.Text
+	ProjectID        pgtype.Text
 	Username         pgtype.Text
 	Limit            pgtype.Int8
 	Offset           pgtype.Int8
 }
 
 type FindWorkspacesByUsernameRow struct {
-	WorkspaceID                pgtype.Text        `json:"workspace_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	AllowDestroyPlan           bool               `json:"allow_destroy_plan"`
-	AutoApply                  bool               `json:"auto_apply"`
-	CanQueueDestroyPlan        bool               `json:"can_queue_destroy_plan"`
-	Description                pgtype.Text        `json:"description"`
-	Environment                pgtype.Text        `json:"environment"`
-	ExecutionMode              pgtype.Text        `json:"execution_mode"`
-	GlobalRemoteState          bool               `json:"global_remote_state"`
-	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
-	Name                       pgtype.Text        `json:"name"`
-	QueueAllRuns               bool               `json:"queue_all_runs"`
-	SpeculativeEnabled         bool               `json:"speculative_enabled"`
-	SourceName                 pgtype.Text        `json:"source_name"`
-	SourceURL                  pgtype.Text        `json:"source_url"`
-	StructuredRunOutputEnabled bool               `json:"structured_run_output_enabled"`
-	TerraformVersion           pgtype.Text        `json:"terraform_version"`
-	TriggerPrefixes            []string           `json:"trigger_prefixes"`
-	WorkingDirectory           pgtype.Text        `json:"working_directory"`
-	LockRunID                  pgtype.Text        `json:"lock_run_id"`
-	LatestRunID                pgtype.Text        `json:"latest_run_id"`
-	OrganizationName           pgtype.Text        `json:"organization_name"`
-	Branch                     pgtype.Text        `json:"branch"`
-	LockUsername               pgtype.Text        `json:"lock_username"`
-	CurrentStateVersionID      pgtype.Text        `json:"current_state_version_id"`
-	TriggerPatterns            []string           `json:"trigger_patterns"`
-	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
-	AllowCLIApply              bool               `json:"allow_cli_apply"`
-	Tags                       []string           `json:"tags"`
-	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
-	UserLock                   *Users             `json:"user_lock"`
-	RunLock                    *Runs              `json:"run_lock"`
-	WorkspaceConnection        *RepoConnections   `json:"workspace_connection"`
-	Webhook                    *Webhooks          `json:"webhook"`
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
 }
 
 // FindWorkspacesByUsername implements Querier.FindWorkspacesByUsername.
 func (q *DBQuerier) FindWorkspacesByUsername(ctx context.Context, params FindWorkspacesByUsernameParams) ([]FindWorkspacesByUsernameRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindWorkspacesByUsername")
-	rows, err := q.conn.Query(ctx, findWorkspacesByUsernameSQL, params.OrganizationName, params.Username, params.Limit, params.Offset)
+	rows, err := q.conn.Query(ctx, findWorkspacesByUsernameSQL, params.OrganizationName, params.ProjectID, params.Username, params.Limit, params.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("query FindWorkspacesByUsername: %w", err)
 	}
@@ -559,7 +680,7 @@ func (q *DBQuerier) FindWorkspacesByUsername(ctx context.Context, params FindWor
 	webhookRow := q.types.newWebhooks()
 	for rows.Next() {
 		var item FindWorkspacesByUsernameRow
-		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacesByUsername row: %w", err)
 		}
 		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -584,7 +705,7 @@ func (q *DBQuerier) FindWorkspacesByUsername(ctx context.Context, params FindWor
 
 // FindWorkspacesByUsernameBatch implements Querier.FindWorkspacesByUsernameBatch.
 func (q *DBQuerier) FindWorkspacesByUsernameBatch(batch genericBatch, params FindWorkspacesByUsernameParams) {
-	batch.Queue(findWorkspacesByUsernameSQL, params.OrganizationName, params.Username, params.Limit, params.Offset)
+	batch.Queue(findWorkspacesByUsernameSQL, params.OrganizationName, params.ProjectID, params.Username, params.Limit, params.Offset)
 }
 
 // FindWorkspacesByUsernameScan implements Querier.FindWorkspacesByUsernameScan.
@@ -601,7 +722,7 @@ func (q *DBQuerier) FindWorkspacesByUsernameScan(results pgx.BatchResults) ([]Fi
 	webhookRow := q.types.newWebhooks()
 	for rows.Next() {
 		var item FindWorkspacesByUsernameRow
-		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 			return nil, fmt.Errorf("scan FindWorkspacesByUsernameBatch row: %w", err)
 		}
 		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -626,18 +747,34 @@ func (q *DBQuerier) FindWorkspacesByUsernameScan(results pgx.BatchResults) ([]Fi
 
 const countWorkspacesByUsernameSQL = `SELECT count(*)
 FROM workspaces w
-JOIN workspace_permissions p USING (workspace_id)
-JOIN teams t USING (team_id)
-JOIN team_memberships tm USING (team_id)
-JOIN users u USING (username)
 WHERE w.organization_name = $1
-AND   u.username          = $2
+AND   w.project_id        LIKE $2
+AND   (
+    w.workspace_id IN (
+        SELECT p.workspace_id
+        FROM workspace_permissions p
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = $3
+    )
+    OR w.project_id IN (
+        SELECT pp.project_id
+        FROM project_permissions pp
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = $3
+    )
+)
 ;`
 
+type CountWorkspacesByUsernameParams struct {
+	OrganizationName pgtype.Text
+	ProjectID        pgtype.Text
+	Username         pgtype.Text
+}
+
 // CountWorkspacesByUsername implements Querier.CountWorkspacesByUsername.
-func (q *DBQuerier) CountWorkspacesByUsername(ctx context.Context, organizationName pgtype.Text, username pgtype.Text) (pgtype.Int8, error) {
+func (q *DBQuerier) CountWorkspacesByUsername(ctx context.Context, params CountWorkspacesByUsernameParams) (pgtype.Int8, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "CountWorkspacesByUsername")
-	row := q.conn.QueryRow(ctx, countWorkspacesByUsernameSQL, organizationName, username)
+	row := q.conn.QueryRow(ctx, countWorkspacesByUsernameSQL, params.OrganizationName, params.ProjectID, params.Username)
 	var item pgtype.Int8
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query CountWorkspacesByUsername: %w", err)
@@ -646,8 +783,8 @@ func (q *DBQuerier) CountWorkspacesByUsername(ctx context.Context, organizationN
 }
 
 // CountWorkspacesByUsernameBatch implements Querier.CountWorkspacesByUsernameBatch.
-func (q *DBQuerier) CountWorkspacesByUsernameBatch(batch genericBatch, organizationName pgtype.Text, username pgtype.Text) {
-	batch.Queue(countWorkspacesByUsernameSQL, organizationName, username)
+func (q *DBQuerier) CountWorkspacesByUsernameBatch(batch genericBatch, params CountWorkspacesByUsernameParams) {
+	batch.Queue(countWorkspacesByUsernameSQL, params.OrganizationName, params.ProjectID, params.Username)
 }
 
 // CountWorkspacesByUsernameScan implements Querier.CountWorkspacesByUsernameScan.
@@ -682,41 +819,60 @@ AND   w.organization_name = $2
 ;`
 
 type FindWorkspaceByNameRow struct {
-	WorkspaceID                pgtype.Text        `json:"workspace_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	AllowDestroyPlan           bool               `json:"allow_destroy_plan"`
-	AutoApply                  bool               `json:"auto_apply"`
-	CanQueueDestroyPlan        bool               `json:"can_queue_destroy_plan"`
-	Description                pgtype.Text        `json:"description"`
-	Environment                pgtype.Text        `json:"environment"`
-	ExecutionMode              pgtype.Text        `json:"execution_mode"`
-	GlobalRemoteState          bool               `json:"global_remote_state"`
-	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
-	Name                       pgtype.Text        `json:"name"`
-	QueueAllRuns               bool               `json:"queue_all_runs"`
-	SpeculativeEnabled         bool               `json:"speculative_enabled"`
-	SourceName                 pgtype.Text        `json:"source_name"`
-	SourceURL                  pgtype.Text        `json:"source_url"`
-	StructuredRunOutputEnabled bool               `json:"structured_run_output_enabled"`
-	TerraformVersion           pgtype.Text        `json:"terraform_version"`
-	TriggerPrefixes            []string           `json:"trigger_prefixes"`
-	WorkingDirectory           pgtype.Text        `json:"working_directory"`
-	LockRunID                  pgtype.Text        `json:"lock_run_id"`
-	LatestRunID                pgtype.Text        `json:"latest_run_id"`
-	OrganizationName           pgtype.Text        `json:"organization_name"`
-	Branch                     pgtype.Text        `json:"branch"`
-	LockUsername               pgtype.Text        `json:"lock_username"`
-	CurrentStateVersionID      pgtype.Text        `json:"current_state_version_id"`
-	TriggerPatterns            []string           `json:"trigger_patterns"`
-	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
-	AllowCLIApply              bool               `json:"allow_cli_apply"`
-	Tags                       []string           `json:"tags"`
-	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
-	UserLock                   *Users             `json:"user_lock"`
-	RunLock                    *Runs              `json:"run_lock"`
-	WorkspaceConnection        *RepoConnections   `json:"workspace_connection"`
-	Webhook                    *Webhooks          `json:"webhook"`
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
 }
 
 // FindWorkspaceByName implements Querier.FindWorkspaceByName.
@@ -728,7 +884,7 @@ func (q *DBQuerier) FindWorkspaceByName(ctx context.Context, name pgtype.Text, o
 	runLockRow := q.types.newRuns()
 	workspaceConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
-	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 		return item, fmt.Errorf("query FindWorkspaceByName: %w", err)
 	}
 	if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -759,7 +915,7 @@ func (q *DBQuerier) FindWorkspaceByNameScan(results pgx.BatchResults) (FindWorks
 	runLockRow := q.types.newRuns()
 	workspaceConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
-	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 		return item, fmt.Errorf("scan FindWorkspaceByNameBatch row: %w", err)
 	}
 	if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -798,41 +954,60 @@ WHERE w.workspace_id = $1
 ;`
 
 type FindWorkspaceByIDRow struct {
-	WorkspaceID                pgtype.Text        `json:"workspace_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	AllowDestroyPlan           bool               `json:"allow_destroy_plan"`
-	AutoApply                  bool               `json:"auto_apply"`
-	CanQueueDestroyPlan        bool               `json:"can_queue_destroy_plan"`
-	Description                pgtype.Text        `json:"description"`
-	Environment                pgtype.Text        `json:"environment"`
-	ExecutionMode              pgtype.Text        `json:"execution_mode"`
-	GlobalRemoteState          bool               `json:"global_remote_state"`
-	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
-	Name                       pgtype.Text        `json:"name"`
-	QueueAllRuns               bool               `json:"queue_all_runs"`
-	SpeculativeEnabled         bool               `json:"speculative_enabled"`
-	SourceName                 pgtype.Text        `json:"source_name"`
-	SourceURL                  pgtype.Text        `json:"source_url"`
-	StructuredRunOutputEnabled bool               `json:"structured_run_output_enabled"`
-	TerraformVersion           pgtype.Text        `json:"terraform_version"`
-	TriggerPrefixes            []string           `json:"trigger_prefixes"`
-	WorkingDirectory           pgtype.Text        `json:"working_directory"`
-	LockRunID                  pgtype.Text        `json:"lock_run_id"`
-	LatestRunID                pgtype.Text        `json:"latest_run_id"`
-	OrganizationName           pgtype.Text        `json:"organization_name"`
-	Branch                     pgtype.Text        `json:"branch"`
-	LockUsername               pgtype.Text        `json:"lock_username"`
-	CurrentStateVersionID      pgtype.Text        `json:"current_state_version_id"`
-	TriggerPatterns            []string           `json:"trigger_patterns"`
-	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
-	AllowCLIApply              bool               `json:"allow_cli_apply"`
-	Tags                       []string           `json:"tags"`
-	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
-	UserLock                   *Users             `json:"user_lock"`
-	RunLock                    *Runs              `json:"run_lock"`
-	WorkspaceConnection        *RepoConnections   `json:"workspace_connection"`
-	Webhook                    *Webhooks          `json:"webhook"`
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
 }
 
 // FindWorkspaceByID implements Querier.FindWorkspaceByID.
@@ -844,7 +1019,7 @@ func (q *DBQuerier) FindWorkspaceByID(ctx context.Context, id pgtype.Text) (Find
 	runLockRow := q.types.newRuns()
 	workspaceConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
-	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 		return item, fmt.Errorf("query FindWorkspaceByID: %w", err)
 	}
 	if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -875,7 +1050,7 @@ func (q *DBQuerier) FindWorkspaceByIDScan(results pgx.BatchResults) (FindWorkspa
 	runLockRow := q.types.newRuns()
 	workspaceConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
-	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 		return item, fmt.Errorf("scan FindWorkspaceByIDBatch row: %w", err)
 	}
 	if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -914,41 +1089,60 @@ WHERE w.workspace_id = $1
 FOR UPDATE OF w;`
 
 type FindWorkspaceByIDForUpdateRow struct {
-	WorkspaceID                pgtype.Text        `json:"workspace_id"`
-	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
-	AllowDestroyPlan           bool               `json:"allow_destroy_plan"`
-	AutoApply                  bool               `json:"auto_apply"`
-	CanQueueDestroyPlan        bool               `json:"can_queue_destroy_plan"`
-	Description                pgtype.Text        `json:"description"`
-	Environment                pgtype.Text        `json:"environment"`
-	ExecutionMode              pgtype.Text        `json:"execution_mode"`
-	GlobalRemoteState          bool               `json:"global_remote_state"`
-	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
-	Name                       pgtype.Text        `json:"name"`
-	QueueAllRuns               bool               `json:"queue_all_runs"`
-	SpeculativeEnabled         bool               `json:"speculative_enabled"`
-	SourceName                 pgtype.Text        `json:"source_name"`
-	SourceURL                  pgtype.Text        `json:"source_url"`
-	StructuredRunOutputEnabled bool               `json:"structured_run_output_enabled"`
-	TerraformVersion           pgtype.Text        `json:"terraform_version"`
-	TriggerPrefixes            []string           `json:"trigger_prefixes"`
-	WorkingDirectory           pgtype.Text        `json:"working_directory"`
-	LockRunID                  pgtype.Text        `json:"lock_run_id"`
-	LatestRunID                pgtype.Text        `json:"latest_run_id"`
-	OrganizationName           pgtype.Text        `json:"organization_name"`
-	Branch                     pgtype.Text        `json:"branch"`
-	LockUsername               pgtype.Text        `json:"lock_username"`
-	CurrentStateVersionID      pgtype.Text        `json:"current_state_version_id"`
-	TriggerPatterns            []string           `json:"trigger_patterns"`
-	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
-	AllowCLIApply              bool               `json:"allow_cli_apply"`
-	Tags                       []string           `json:"tags"`
-	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
-	UserLock                   *Users             `json:"user_lock"`
-	RunLock                    *Runs              `json:"run_lock"`
-	WorkspaceConnection        *RepoConnections   `json:"workspace_connection"`
-	Webhook                    *Webhooks          `json:"webhook"`
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
 }
 
 // FindWorkspaceByIDForUpdate implements Querier.FindWorkspaceByIDForUpdate.
@@ -960,7 +1154,7 @@ func (q *DBQuerier) FindWorkspaceByIDForUpdate(ctx context.Context, id pgtype.Te
 	runLockRow := q.types.newRuns()
 	workspaceConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
-	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 		return item, fmt.Errorf("query FindWorkspaceByIDForUpdate: %w", err)
 	}
 	if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -991,7 +1185,7 @@ func (q *DBQuerier) FindWorkspaceByIDForUpdateScan(results pgx.BatchResults) (Fi
 	runLockRow := q.types.newRuns()
 	workspaceConnectionRow := q.types.newRepoConnections()
 	webhookRow := q.types.newWebhooks()
-	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+	if err := row.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
 		return item, fmt.Errorf("scan FindWorkspaceByIDForUpdateBatch row: %w", err)
 	}
 	if err := userLockRow.AssignTo(&item.UserLock); err != nil {
@@ -1027,35 +1221,61 @@ SET
     trigger_patterns              = $14,
     vcs_tags_regex                = $15,
     working_directory             = $16,
-    updated_at                    = $17
-WHERE workspace_id = $18
+    plan_timeout                  = $17,
+    apply_timeout                 = $18,
+    auto_discard_ttl              = $19,
+    supersede_runs                = $20,
+    pr_comments                   = $21,
+    pr_apply                      = $22,
+    preview_template              = $23,
+    max_previews                  = $24,
+    auto_destroy_at               = $25,
+    auto_destroy_activity_duration = $26,
+    auto_apply_destroy            = $27,
+    project_id                    = $28,
+    template                      = $29,
+    updated_at                    = $30
+WHERE workspace_id = $31
 RETURNING workspace_id;`
 
 type UpdateWorkspaceByIDParams struct {
-	AllowDestroyPlan           bool
-	AllowCLIApply              bool
-	AutoApply                  bool
-	Branch                     pgtype.Text
-	Description                pgtype.Text
-	ExecutionMode              pgtype.Text
-	GlobalRemoteState          bool
-	Name                       pgtype.Text
-	QueueAllRuns               bool
-	SpeculativeEnabled         bool
-	StructuredRunOutputEnabled bool
-	TerraformVersion           pgtype.Text
-	TriggerPrefixes            []string
-	TriggerPatterns            []string
-	VCSTagsRegex               pgtype.Text
-	WorkingDirectory           pgtype.Text
-	UpdatedAt                  pgtype.Timestamptz
-	ID                         pgtype.Text
+	AllowDestroyPlan            bool
+	AllowCLIApply               bool
+	AutoApply                   bool
+	Branch                      pgtype.Text
+	Description                 pgtype.Text
+	ExecutionMode               pgtype.Text
+	GlobalRemoteState           bool
+	Name                        pgtype.Text
+	QueueAllRuns                bool
+	SpeculativeEnabled          bool
+	StructuredRunOutputEnabled  bool
+	TerraformVersion            pgtype.Text
+	TriggerPrefixes             []string
+	TriggerPatterns             []string
+	VCSTagsRegex                pgtype.Text
+	WorkingDirectory            pgtype.Text
+	PlanTimeout                 pgtype.Int4
+	ApplyTimeout                pgtype.Int4
+	AutoDiscardTTL              pgtype.Int4
+	SupersedeRuns               bool
+	PRComments                  bool
+	PRApply                     bool
+	PreviewTemplate             bool
+	MaxPreviews                 pgtype.Int4
+	AutoDestroyAt               pgtype.Timestamptz
+	AutoDestroyActivityDuration pgtype.Int4
+	AutoApplyDestroy            bool
+	ProjectID                   pgtype.Text
+	Template                    bool
+	UpdatedAt                   pgtype.Timestamptz
+	ID                          pgtype.Text
 }
 
 // UpdateWorkspaceByID implements Querier.UpdateWorkspaceByID.
 func (q *DBQuerier) UpdateWorkspaceByID(ctx context.Context, params UpdateWorkspaceByIDParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWorkspaceByID")
-	row := q.conn.QueryRow(ctx, updateWorkspaceByIDSQL, params.AllowDestroyPlan, params.AllowCLIApply, params.AutoApply, params.Branch, params.Description, params.ExecutionMode, params.GlobalRemoteState, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.UpdatedAt, params.ID)
+	row := q.conn.QueryRow(ctx, updateWorkspaceByIDSQL, params.AllowDestroyPlan, params.AllowCLIApply, params.AutoApply, params.Branch, params.Description, params.ExecutionMode, params.GlobalRemoteState, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.PlanTimeout, params.ApplyTimeout, params.AutoDiscardTTL, params.SupersedeRuns, params.PRComments, params.PRApply, params.PreviewTemplate, params.MaxPreviews, params.AutoDestroyAt, params.AutoDestroyActivityDuration, params.AutoApplyDestroy, params.ProjectID, params.Template, params.UpdatedAt, params.ID)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateWorkspaceByID: %w", err)
@@ -1065,7 +1285,7 @@ func (q *DBQuerier) UpdateWorkspaceByID(ctx context.Context, params UpdateWorksp
 
 // UpdateWorkspaceByIDBatch implements Querier.UpdateWorkspaceByIDBatch.
 func (q *DBQuerier) UpdateWorkspaceByIDBatch(batch genericBatch, params UpdateWorkspaceByIDParams) {
-	batch.Queue(updateWorkspaceByIDSQL, params.AllowDestroyPlan, params.AllowCLIApply, params.AutoApply, params.Branch, params.Description, params.ExecutionMode, params.GlobalRemoteState, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.UpdatedAt, params.ID)
+	batch.Queue(updateWorkspaceByIDSQL, params.AllowDestroyPlan, params.AllowCLIApply, params.AutoApply, params.Branch, params.Description, params.ExecutionMode, params.GlobalRemoteState, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.PlanTimeout, params.ApplyTimeout, params.AutoDiscardTTL, params.SupersedeRuns, params.PRComments, params.PRApply, params.PreviewTemplate, params.MaxPreviews, params.AutoDestroyAt, params.AutoDestroyActivityDuration, params.AutoApplyDestroy, params.ProjectID, params.Template, params.UpdatedAt, params.ID)
 }
 
 // UpdateWorkspaceByIDScan implements Querier.UpdateWorkspaceByIDScan.
@@ -1081,19 +1301,27 @@ func (q *DBQuerier) UpdateWorkspaceByIDScan(results pgx.BatchResults) (pgtype.Te
 const updateWorkspaceLockByIDSQL = `UPDATE workspaces
 SET
     lock_username = $1,
-    lock_run_id = $2
-WHERE workspace_id = $3;`
+    lock_run_id = $2,
+    lock_reason = $3,
+    locked_at = $4,
+    lock_expires_at = $5,
+    lock_external_id = $6
+WHERE workspace_id = $7;`
 
 type UpdateWorkspaceLockByIDParams struct {
 	Username    pgtype.Text
 	RunID       pgtype.Text
+	Reason      pgtype.Text
+	LockedAt    pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+	ExternalID  pgtype.Text
 	WorkspaceID pgtype.Text
 }
 
 // UpdateWorkspaceLockByID implements Querier.UpdateWorkspaceLockByID.
 func (q *DBQuerier) UpdateWorkspaceLockByID(ctx context.Context, params UpdateWorkspaceLockByIDParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWorkspaceLockByID")
-	cmdTag, err := q.conn.Exec(ctx, updateWorkspaceLockByIDSQL, params.Username, params.RunID, params.WorkspaceID)
+	cmdTag, err := q.conn.Exec(ctx, updateWorkspaceLockByIDSQL, params.Username, params.RunID, params.Reason, params.LockedAt, params.ExpiresAt, params.ExternalID, params.WorkspaceID)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query UpdateWorkspaceLockByID: %w", err)
 	}
@@ -1102,7 +1330,7 @@ func (q *DBQuerier) UpdateWorkspaceLockByID(ctx context.Context, params UpdateWo
 
 // UpdateWorkspaceLockByIDBatch implements Querier.UpdateWorkspaceLockByIDBatch.
 func (q *DBQuerier) UpdateWorkspaceLockByIDBatch(batch genericBatch, params UpdateWorkspaceLockByIDParams) {
-	batch.Queue(updateWorkspaceLockByIDSQL, params.Username, params.RunID, params.WorkspaceID)
+	batch.Queue(updateWorkspaceLockByIDSQL, params.Username, params.RunID, params.Reason, params.LockedAt, params.ExpiresAt, params.ExternalID, params.WorkspaceID)
 }
 
 // UpdateWorkspaceLockByIDScan implements Querier.UpdateWorkspaceLockByIDScan.
@@ -1114,6 +1342,59 @@ func (q *DBQuerier) UpdateWorkspaceLockByIDScan(results pgx.BatchResults) (pgcon
 	return cmdTag, err
 }
 
+const findWorkspaceIDsWithExpiredLocksSQL = `SELECT workspace_id
+FROM workspaces
+WHERE lock_expires_at <= $1
+;`
+
+// FindWorkspaceIDsWithExpiredLocks implements Querier.FindWorkspaceIDsWithExpiredLocks.
+func (q *DBQuerier) FindWorkspaceIDsWithExpiredLocks(ctx context.Context, now pgtype.Timestamptz) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindWorkspaceIDsWithExpiredLocks")
+	rows, err := q.conn.Query(ctx, findWorkspaceIDsWithExpiredLocksSQL, now)
+	if err != nil {
+		return nil, fmt.Errorf("query FindWorkspaceIDsWithExpiredLocks: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindWorkspaceIDsWithExpiredLocks row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindWorkspaceIDsWithExpiredLocks rows: %w", err)
+	}
+	return items, err
+}
+
+// FindWorkspaceIDsWithExpiredLocksBatch implements Querier.FindWorkspaceIDsWithExpiredLocksBatch.
+func (q *DBQuerier) FindWorkspaceIDsWithExpiredLocksBatch(batch genericBatch, now pgtype.Timestamptz) {
+	batch.Queue(findWorkspaceIDsWithExpiredLocksSQL, now)
+}
+
+// FindWorkspaceIDsWithExpiredLocksScan implements Querier.FindWorkspaceIDsWithExpiredLocksScan.
+func (q *DBQuerier) FindWorkspaceIDsWithExpiredLocksScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindWorkspaceIDsWithExpiredLocksBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindWorkspaceIDsWithExpiredLocksBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindWorkspaceIDsWithExpiredLocksBatch rows: %w", err)
+	}
+	return items, err
+}
+
 const updateWorkspaceLatestRunSQL = `UPDATE workspaces
 SET latest_run_id = $1
 WHERE workspace_id = $2;`
@@ -1200,3 +1481,162 @@ func (q *DBQuerier) DeleteWorkspaceByIDScan(results pgx.BatchResults) (pgconn.Co
 	}
 	return cmdTag, err
 }
+
+const findRemoteStateConsumersSQL = `SELECT w.*,
+    (
+        SELECT array_agg(name)
+        FROM tags
+        JOIN workspace_tags wt USING (tag_id)
+        WHERE wt.workspace_id = w.workspace_id
+    ) AS tags,
+    r.status AS latest_run_status,
+    (ul.*)::"users" AS user_lock,
+    (rl.*)::"runs" AS run_lock,
+    (vr.*)::"repo_connections" AS workspace_connection,
+    (h.*)::"webhooks" AS webhook
+FROM workspaces w
+JOIN remote_state_consumers rsc ON w.workspace_id = rsc.consumer_id
+LEFT JOIN users ul ON w.lock_username = ul.username
+LEFT JOIN runs rl ON w.lock_run_id = rl.run_id
+LEFT JOIN runs r ON w.latest_run_id = r.run_id
+LEFT JOIN (repo_connections vr JOIN webhooks h USING (webhook_id)) ON w.workspace_id = vr.workspace_id
+WHERE rsc.workspace_id = $1
+ORDER BY w.name ASC
+;`
+
+type FindRemoteStateConsumersRow struct {
+	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
+	CreatedAt                   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updated_at"`
+	AllowDestroyPlan            bool               `json:"allow_destroy_plan"`
+	AutoApply                   bool               `json:"auto_apply"`
+	CanQueueDestroyPlan         bool               `json:"can_queue_destroy_plan"`
+	Description                 pgtype.Text        `json:"description"`
+	Environment                 pgtype.Text        `json:"environment"`
+	ExecutionMode               pgtype.Text        `json:"execution_mode"`
+	GlobalRemoteState           bool               `json:"global_remote_state"`
+	MigrationEnvironment        pgtype.Text        `json:"migration_environment"`
+	Name                        pgtype.Text        `json:"name"`
+	QueueAllRuns                bool               `json:"queue_all_runs"`
+	SpeculativeEnabled          bool               `json:"speculative_enabled"`
+	SourceName                  pgtype.Text        `json:"source_name"`
+	SourceURL                   pgtype.Text        `json:"source_url"`
+	StructuredRunOutputEnabled  bool               `json:"structured_run_output_enabled"`
+	TerraformVersion            pgtype.Text        `json:"terraform_version"`
+	TriggerPrefixes             []string           `json:"trigger_prefixes"`
+	WorkingDirectory            pgtype.Text        `json:"working_directory"`
+	LockRunID                   pgtype.Text        `json:"lock_run_id"`
+	LatestRunID                 pgtype.Text        `json:"latest_run_id"`
+	OrganizationName            pgtype.Text        `json:"organization_name"`
+	Branch                      pgtype.Text        `json:"branch"`
+	LockUsername                pgtype.Text        `json:"lock_username"`
+	CurrentStateVersionID       pgtype.Text        `json:"current_state_version_id"`
+	TriggerPatterns             []string           `json:"trigger_patterns"`
+	VCSTagsRegex                pgtype.Text        `json:"vcs_tags_regex"`
+	AllowCLIApply               bool               `json:"allow_cli_apply"`
+	PlanTimeout                 pgtype.Int4        `json:"plan_timeout"`
+	ApplyTimeout                pgtype.Int4        `json:"apply_timeout"`
+	AutoDiscardTTL              pgtype.Int4        `json:"auto_discard_ttl"`
+	SupersedeRuns               bool               `json:"supersede_runs"`
+	PRComments                  bool               `json:"pr_comments"`
+	PRApply                     bool               `json:"pr_apply"`
+	PreviewTemplate             bool               `json:"preview_template"`
+	MaxPreviews                 pgtype.Int4        `json:"max_previews"`
+	PreviewTemplateID           pgtype.Text        `json:"preview_template_id"`
+	PreviewPullRequest          pgtype.Int4        `json:"preview_pull_request"`
+	AutoDestroyAt               pgtype.Timestamptz `json:"auto_destroy_at"`
+	AutoDestroyActivityDuration pgtype.Int4        `json:"auto_destroy_activity_duration"`
+	AutoApplyDestroy            bool               `json:"auto_apply_destroy"`
+	ProjectID                   pgtype.Text        `json:"project_id"`
+	LockReason                  pgtype.Text        `json:"lock_reason"`
+	LockedAt                    pgtype.Timestamptz `json:"locked_at"`
+	LockExpiresAt               pgtype.Timestamptz `json:"lock_expires_at"`
+	Template                    bool               `json:"template"`
+	LockExternalID              pgtype.Text        `json:"lock_external_id"`
+	Tags                        []string           `json:"tags"`
+	LatestRunStatus             pgtype.Text        `json:"latest_run_status"`
+	UserLock                    *Users             `json:"user_lock"`
+	RunLock                     *Runs              `json:"run_lock"`
+	WorkspaceConnection         *RepoConnections   `json:"workspace_connection"`
+	Webhook                     *Webhooks          `json:"webhook"`
+}
+
+// FindRemoteStateConsumers implements Querier.FindRemoteStateConsumers.
+func (q *DBQuerier) FindRemoteStateConsumers(ctx context.Context, workspaceID pgtype.Text) ([]FindRemoteStateConsumersRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindRemoteStateConsumers")
+	rows, err := q.conn.Query(ctx, findRemoteStateConsumersSQL, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindRemoteStateConsumers: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRemoteStateConsumersRow{}
+	userLockRow := q.types.newUsers()
+	runLockRow := q.types.newRuns()
+	workspaceConnectionRow := q.types.newRepoConnections()
+	webhookRow := q.types.newWebhooks()
+	for rows.Next() {
+		var item FindRemoteStateConsumersRow
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+			return nil, fmt.Errorf("scan FindRemoteStateConsumers row: %w", err)
+		}
+		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		if err := runLockRow.AssignTo(&item.RunLock); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		if err := workspaceConnectionRow.AssignTo(&item.WorkspaceConnection); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		if err := webhookRow.AssignTo(&item.Webhook); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRemoteStateConsumers rows: %w", err)
+	}
+	return items, err
+}
+
+// FindRemoteStateConsumersBatch implements Querier.FindRemoteStateConsumersBatch.
+func (q *DBQuerier) FindRemoteStateConsumersBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(findRemoteStateConsumersSQL, workspaceID)
+}
+
+// FindRemoteStateConsumersScan implements Querier.FindRemoteStateConsumersScan.
+func (q *DBQuerier) FindRemoteStateConsumersScan(results pgx.BatchResults) ([]FindRemoteStateConsumersRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindRemoteStateConsumersBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRemoteStateConsumersRow{}
+	userLockRow := q.types.newUsers()
+	runLockRow := q.types.newRuns()
+	workspaceConnectionRow := q.types.newRepoConnections()
+	webhookRow := q.types.newWebhooks()
+	for rows.Next() {
+		var item FindRemoteStateConsumersRow
+		if err := rows.Scan(&item.WorkspaceID, &item.CreatedAt, &item.UpdatedAt, &item.AllowDestroyPlan, &item.AutoApply, &item.CanQueueDestroyPlan, &item.Description, &item.Environment, &item.ExecutionMode, &item.GlobalRemoteState, &item.MigrationEnvironment, &item.Name, &item.QueueAllRuns, &item.SpeculativeEnabled, &item.SourceName, &item.SourceURL, &item.StructuredRunOutputEnabled, &item.TerraformVersion, &item.TriggerPrefixes, &item.WorkingDirectory, &item.LockRunID, &item.LatestRunID, &item.OrganizationName, &item.Branch, &item.LockUsername, &item.CurrentStateVersionID, &item.TriggerPatterns, &item.VCSTagsRegex, &item.AllowCLIApply, &item.PlanTimeout, &item.ApplyTimeout, &item.AutoDiscardTTL, &item.SupersedeRuns, &item.PRComments, &item.PRApply, &item.PreviewTemplate, &item.MaxPreviews, &item.PreviewTemplateID, &item.PreviewPullRequest, &item.AutoDestroyAt, &item.AutoDestroyActivityDuration, &item.AutoApplyDestroy, &item.ProjectID, &item.LockReason, &item.LockedAt, &item.LockExpiresAt, &item.Template, &item.LockExternalID, &item.Tags, &item.LatestRunStatus, userLockRow, runLockRow, workspaceConnectionRow, webhookRow); err != nil {
+			return nil, fmt.Errorf("scan FindRemoteStateConsumersBatch row: %w", err)
+		}
+		if err := userLockRow.AssignTo(&item.UserLock); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		if err := runLockRow.AssignTo(&item.RunLock); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		if err := workspaceConnectionRow.AssignTo(&item.WorkspaceConnection); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		if err := webhookRow.AssignTo(&item.Webhook); err != nil {
+			return nil, fmt.Errorf("assign FindRemoteStateConsumers row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRemoteStateConsumersBatch rows: %w", err)
+	}
+	return items, err
+}
@@ -24,3 +24,16 @@ SET status = pggen.arg('status')
 WHERE run_id = pggen.arg('run_id')
 RETURNING run_id
 ;
+
+-- name: GetApplyStructuredOutputByID :one
+SELECT structured_output
+FROM applies
+WHERE run_id = pggen.arg('run_id')
+;
+
+-- name: UpdateApplyStructuredOutputByID :one
+UPDATE applies
+SET structured_output = pggen.arg('structured_output')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
@@ -0,0 +1,112 @@
+-- name: InsertInventoryResource :exec
+INSERT INTO inventory_resources (
+    inventory_resource_id,
+    workspace_id,
+    state_version_id,
+    address,
+    module,
+    type,
+    name,
+    provider,
+    provider_version,
+    remote_id,
+    attribute_values
+) VALUES (
+    pggen.arg('inventory_resource_id'),
+    pggen.arg('workspace_id'),
+    pggen.arg('state_version_id'),
+    pggen.arg('address'),
+    pggen.arg('module'),
+    pggen.arg('type'),
+    pggen.arg('name'),
+    pggen.arg('provider'),
+    pggen.arg('provider_version'),
+    pggen.arg('remote_id'),
+    pggen.arg('attribute_values')
+);
+
+-- name: FindInventoryResources :many
+SELECT
+    r.*,
+    w.name AS workspace_name,
+    w.organization_name
+FROM inventory_resources r
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   r.type              LIKE pggen.arg('type')
+AND   r.module            LIKE pggen.arg('module')
+AND   (r.provider LIKE pggen.arg('provider') OR r.provider LIKE '%/' || pggen.arg('provider'))
+AND   (
+    r.address LIKE '%' || pggen.arg('search') || '%'
+    OR EXISTS (
+        SELECT FROM unnest(r.attribute_values) AS v
+        WHERE v LIKE '%' || pggen.arg('search') || '%'
+    )
+)
+AND   (
+    pggen.arg('provider_version_below')::text IS NULL
+    OR string_to_array(substring(r.provider_version FROM '^[0-9]+(?:\.[0-9]+)*'), '.')::numeric[] < string_to_array(pggen.arg('provider_version_below'), '.')::numeric[]
+)
+AND   (
+    SELECT coalesce(array_agg(t.name), '{}')
+    FROM workspace_tags wt
+    JOIN tags t USING (tag_id)
+    WHERE wt.workspace_id = r.workspace_id
+) @> pggen.arg('tags')
+ORDER BY w.name ASC, r.address ASC
+LIMIT pggen.arg('limit')
+OFFSET pggen.arg('offset')
+;
+
+-- name: CountInventoryResources :one
+SELECT count(*)
+FROM inventory_resources r
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   r.type              LIKE pggen.arg('type')
+AND   r.module            LIKE pggen.arg('module')
+AND   (r.provider LIKE pggen.arg('provider') OR r.provider LIKE '%/' || pggen.arg('provider'))
+AND   (
+    r.address LIKE '%' || pggen.arg('search') || '%'
+    OR EXISTS (
+        SELECT FROM unnest(r.attribute_values) AS v
+        WHERE v LIKE '%' || pggen.arg('search') || '%'
+    )
+)
+AND   (
+    pggen.arg('provider_version_below')::text IS NULL
+    OR string_to_array(substring(r.provider_version FROM '^[0-9]+(?:\.[0-9]+)*'), '.')::numeric[] < string_to_array(pggen.arg('provider_version_below'), '.')::numeric[]
+)
+AND   (
+    SELECT coalesce(array_agg(t.name), '{}')
+    FROM workspace_tags wt
+    JOIN tags t USING (tag_id)
+    WHERE wt.workspace_id = r.workspace_id
+) @> pggen.arg('tags')
+;
+
+-- name: DeleteInventoryResourcesByWorkspaceID :exec
+DELETE
+FROM inventory_resources
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: UpsertInventoryIndex :exec
+INSERT INTO inventory_indexes (
+    workspace_id,
+    state_version_id
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('state_version_id')
+)
+ON CONFLICT (workspace_id) DO UPDATE
+SET state_version_id = pggen.arg('state_version_id')
+;
+
+-- name: FindUnindexedStateVersionIDs :many
+SELECT w.current_state_version_id
+FROM workspaces w
+LEFT JOIN inventory_indexes i USING (workspace_id)
+WHERE w.current_state_version_id IS NOT NULL
+AND (i.state_version_id IS NULL OR i.state_version_id != w.current_state_version_id)
+;
@@ -44,6 +44,8 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -65,6 +67,8 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -88,6 +92,8 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -109,6 +115,8 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -130,6 +138,8 @@ SELECT
     m.provider,
     m.status,
     m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
     (r.*)::"repo_connections" AS module_connection,
     (h.*)::"webhooks" AS webhook,
     (
@@ -143,6 +153,30 @@ LEFT JOIN (repo_connections r JOIN webhooks h USING (webhook_id)) USING (module_
 WHERE mv.module_version_id = pggen.arg('module_version_id')
 ;
 
+-- name: FindModuleByTestRunID :one
+SELECT
+    m.module_id,
+    m.created_at,
+    m.updated_at,
+    m.name,
+    m.provider,
+    m.status,
+    m.organization_name,
+    m.test_workspace_id,
+    m.tests_required,
+    (r.*)::"repo_connections" AS module_connection,
+    (h.*)::"webhooks" AS webhook,
+    (
+        SELECT array_agg(v.*) AS versions
+        FROM module_versions v
+        WHERE v.module_id = m.module_id
+    ) AS versions
+FROM modules m
+JOIN module_versions mv USING (module_id)
+LEFT JOIN (repo_connections r JOIN webhooks h USING (webhook_id)) USING (module_id)
+WHERE mv.test_run_id = pggen.arg('test_run_id')
+;
+
 -- name: UpdateModuleStatusByID :one
 UPDATE modules
 SET status = pggen.arg('status')
@@ -150,6 +184,15 @@ WHERE module_id = pggen.arg('module_id')
 RETURNING module_id
 ;
 
+-- name: UpdateModuleTestSettingsByID :one
+UPDATE modules
+SET
+    test_workspace_id = pggen.arg('test_workspace_id'),
+    tests_required = pggen.arg('tests_required')
+WHERE module_id = pggen.arg('module_id')
+RETURNING module_id
+;
+
 -- name: InsertModuleTarball :one
 INSERT INTO module_tarballs (
     tarball,
@@ -175,6 +218,13 @@ WHERE module_version_id = pggen.arg('module_version_id')
 RETURNING *
 ;
 
+-- name: UpdateModuleVersionTestRunByID :one
+UPDATE module_versions
+SET test_run_id = pggen.arg('test_run_id')
+WHERE module_version_id = pggen.arg('module_version_id')
+RETURNING module_version_id
+;
+
 -- name: DeleteModuleByID :one
 DELETE
 FROM modules
@@ -9,7 +9,9 @@ INSERT INTO organizations (
     cost_estimation_enabled,
     session_remember,
     session_timeout,
-    allow_force_delete_workspaces
+    allow_force_delete_workspaces,
+    max_concurrent_runs,
+    max_concurrent_speculative_runs
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -20,7 +22,9 @@ INSERT INTO organizations (
     pggen.arg('cost_estimation_enabled'),
     pggen.arg('session_remember'),
     pggen.arg('session_timeout'),
-    pggen.arg('allow_force_delete_workspaces')
+    pggen.arg('allow_force_delete_workspaces'),
+    pggen.arg('max_concurrent_runs'),
+    pggen.arg('max_concurrent_speculative_runs')
 );
 
 -- name: FindOrganizationNameByWorkspaceID :one
@@ -66,6 +70,8 @@ SET
     session_remember = pggen.arg('session_remember'),
     session_timeout = pggen.arg('session_timeout'),
     allow_force_delete_workspaces = pggen.arg('allow_force_delete_workspaces'),
+    max_concurrent_runs = pggen.arg('max_concurrent_runs'),
+    max_concurrent_speculative_runs = pggen.arg('max_concurrent_speculative_runs'),
     updated_at = pggen.arg('updated_at')
 WHERE name = pggen.arg('name')
 RETURNING organization_id;
@@ -55,3 +55,29 @@ SET plan_json = pggen.arg('plan_json')
 WHERE run_id = pggen.arg('run_id')
 RETURNING run_id
 ;
+
+-- name: GetPlanStructuredOutputByID :one
+SELECT structured_output
+FROM plans
+WHERE run_id = pggen.arg('run_id')
+;
+
+-- name: UpdatePlanStructuredOutputByID :one
+UPDATE plans
+SET structured_output = pggen.arg('structured_output')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
+
+-- name: GetPlanTestResultsByID :one
+SELECT test_results
+FROM plans
+WHERE run_id = pggen.arg('run_id')
+;
+
+-- name: UpdatePlanTestResultsByID :one
+UPDATE plans
+SET test_results = pggen.arg('test_results')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
@@ -0,0 +1,36 @@
+-- InsertPullRequestLock locks a workspace on behalf of a pull request. No row
+-- is inserted if the workspace is already locked by a pull request.
+--
+-- name: InsertPullRequestLock :exec
+INSERT INTO pull_request_locks (
+    workspace_id,
+    repo,
+    pull_request_number,
+    username
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('repo'),
+    pggen.arg('pull_request_number'),
+    pggen.arg('username')
+)
+ON CONFLICT (workspace_id) DO NOTHING
+;
+
+-- name: FindPullRequestLockByWorkspaceID :one
+SELECT repo, pull_request_number
+FROM pull_request_locks
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: DeletePullRequestLockByWorkspaceID :exec
+DELETE
+FROM pull_request_locks
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: DeletePullRequestLocks :exec
+DELETE
+FROM pull_request_locks
+WHERE repo = pggen.arg('repo')
+AND   pull_request_number = pggen.arg('pull_request_number')
+;
@@ -0,0 +1,27 @@
+-- name: InsertRemoteStateConsumer :exec
+INSERT INTO remote_state_consumers (
+    workspace_id,
+    consumer_id
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('consumer_id')
+) ON CONFLICT DO NOTHING;
+
+-- name: FindRemoteStateConsumerIDs :many
+SELECT consumer_id
+FROM remote_state_consumers
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: DeleteRemoteStateConsumer :exec
+DELETE
+FROM remote_state_consumers
+WHERE workspace_id = pggen.arg('workspace_id')
+AND   consumer_id = pggen.arg('consumer_id')
+;
+
+-- name: DeleteRemoteStateConsumers :exec
+DELETE
+FROM remote_state_consumers
+WHERE workspace_id = pggen.arg('workspace_id')
+;
@@ -16,7 +16,9 @@ INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    save_plan,
+    test
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -34,18 +36,22 @@ INSERT INTO runs (
     pggen.arg('workspace_id'),
     pggen.arg('created_by'),
     pggen.arg('terraform_version'),
-    pggen.arg('allow_empty_apply')
+    pggen.arg('allow_empty_apply'),
+    pggen.arg('save_plan'),
+    pggen.arg('test')
 );
 
 -- name: InsertRunStatusTimestamp :exec
 INSERT INTO run_status_timestamps (
     run_id,
     status,
-    timestamp
+    timestamp,
+    reason
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('status'),
-    pggen.arg('timestamp')
+    pggen.arg('timestamp'),
+    pggen.arg('reason')
 );
 
 -- name: InsertRunVariable :exec
@@ -84,6 +90,9 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.queued_by_limit,
+    runs.save_plan,
+    runs.test,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -116,7 +125,24 @@ SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(DISTINCT wrt.stage) AS task_stages
+        FROM workspace_run_tasks wrt
+        JOIN run_tasks rt USING (run_task_id)
+        WHERE wrt.workspace_id = runs.workspace_id
+        AND   rt.enabled
+    ) AS task_stages,
+    COALESCE((
+        SELECT wap.approvals_required
+        FROM workspace_approval_policies wap
+        WHERE wap.workspace_id = runs.workspace_id
+    ), 0) AS approvals_required,
+    (
+        SELECT array_agg(ra.* ORDER BY ra.created_at) AS run_approvals
+        FROM run_approvals ra
+        WHERE ra.run_id = runs.run_id
+    ) AS run_approvals
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -177,6 +203,9 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.queued_by_limit,
+    runs.save_plan,
+    runs.test,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -209,7 +238,24 @@ SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(DISTINCT wrt.stage) AS task_stages
+        FROM workspace_run_tasks wrt
+        JOIN run_tasks rt USING (run_task_id)
+        WHERE wrt.workspace_id = runs.workspace_id
+        AND   rt.enabled
+    ) AS task_stages,
+    COALESCE((
+        SELECT wap.approvals_required
+        FROM workspace_approval_policies wap
+        WHERE wap.workspace_id = runs.workspace_id
+    ), 0) AS approvals_required,
+    (
+        SELECT array_agg(ra.* ORDER BY ra.created_at) AS run_approvals
+        FROM run_approvals ra
+        WHERE ra.run_id = runs.run_id
+    ) AS run_approvals
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -244,6 +290,9 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.queued_by_limit,
+    runs.save_plan,
+    runs.test,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -276,7 +325,24 @@ SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(DISTINCT wrt.stage) AS task_stages
+        FROM workspace_run_tasks wrt
+        JOIN run_tasks rt USING (run_task_id)
+        WHERE wrt.workspace_id = runs.workspace_id
+        AND   rt.enabled
+    ) AS task_stages,
+    COALESCE((
+        SELECT wap.approvals_required
+        FROM workspace_approval_policies wap
+        WHERE wap.workspace_id = runs.workspace_id
+    ), 0) AS approvals_required,
+    (
+        SELECT array_agg(ra.* ORDER BY ra.created_at) AS run_approvals
+        FROM run_approvals ra
+        WHERE ra.run_id = runs.run_id
+    ) AS run_approvals
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -316,6 +382,23 @@ WHERE run_id = pggen.arg('id')
 RETURNING run_id
 ;
 
+-- name: UpdateRunQueuedByLimit :one
+UPDATE runs
+SET
+    queued_by_limit = pggen.arg('queued_by_limit')
+WHERE run_id = pggen.arg('id')
+RETURNING run_id
+;
+
+-- name: CountInFlightRuns :one
+SELECT count(*)
+FROM runs
+JOIN workspaces USING (workspace_id)
+WHERE workspaces.organization_name = pggen.arg('organization_name')
+AND   runs.status = ANY(pggen.arg('statuses'))
+AND   runs.plan_only = pggen.arg('plan_only')
+;
+
 -- name: DeleteRunByID :one
 DELETE
 FROM runs
@@ -0,0 +1,29 @@
+-- name: InsertRunApproval :exec
+INSERT INTO run_approvals (
+    run_approval_id,
+    created_at,
+    username,
+    decision,
+    comment,
+    run_id
+) VALUES (
+    pggen.arg('run_approval_id'),
+    pggen.arg('created_at'),
+    pggen.arg('username'),
+    pggen.arg('decision'),
+    pggen.arg('comment'),
+    pggen.arg('run_id')
+);
+
+-- name: FindRunApprovalByID :one
+SELECT *
+FROM run_approvals
+WHERE run_approval_id = pggen.arg('run_approval_id')
+;
+
+-- name: FindRunApprovalsByRunID :many
+SELECT *
+FROM run_approvals
+WHERE run_id = pggen.arg('run_id')
+ORDER BY created_at
+;
@@ -0,0 +1,27 @@
+-- name: InsertRunComment :exec
+INSERT INTO run_comments (
+    run_comment_id,
+    created_at,
+    author,
+    body,
+    run_id
+) VALUES (
+    pggen.arg('run_comment_id'),
+    pggen.arg('created_at'),
+    pggen.arg('author'),
+    pggen.arg('body'),
+    pggen.arg('run_id')
+);
+
+-- name: FindRunCommentByID :one
+SELECT *
+FROM run_comments
+WHERE run_comment_id = pggen.arg('run_comment_id')
+;
+
+-- name: FindRunCommentsByRunID :many
+SELECT *
+FROM run_comments
+WHERE run_id = pggen.arg('run_id')
+ORDER BY created_at
+;
@@ -0,0 +1,182 @@
+-- name: InsertRunTask :exec
+INSERT INTO run_tasks (
+    run_task_id,
+    created_at,
+    name,
+    description,
+    url,
+    hmac_key,
+    enabled,
+    organization_name
+) VALUES (
+    pggen.arg('run_task_id'),
+    pggen.arg('created_at'),
+    pggen.arg('name'),
+    pggen.arg('description'),
+    pggen.arg('url'),
+    pggen.arg('hmac_key'),
+    pggen.arg('enabled'),
+    pggen.arg('organization_name')
+);
+
+-- name: FindRunTasksByOrganization :many
+SELECT *
+FROM run_tasks
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY name ASC
+;
+
+-- name: FindRunTaskByID :one
+SELECT *
+FROM run_tasks
+WHERE run_task_id = pggen.arg('run_task_id')
+;
+
+-- name: FindRunTaskByIDForUpdate :one
+SELECT *
+FROM run_tasks
+WHERE run_task_id = pggen.arg('run_task_id')
+FOR UPDATE
+;
+
+-- name: UpdateRunTaskByID :one
+UPDATE run_tasks
+SET
+    name        = pggen.arg('name'),
+    description = pggen.arg('description'),
+    url         = pggen.arg('url'),
+    hmac_key    = pggen.arg('hmac_key'),
+    enabled     = pggen.arg('enabled')
+WHERE run_task_id = pggen.arg('run_task_id')
+RETURNING run_task_id
+;
+
+-- name: DeleteRunTaskByID :one
+DELETE FROM run_tasks
+WHERE run_task_id = pggen.arg('run_task_id')
+RETURNING run_task_id
+;
+
+-- name: InsertWorkspaceRunTask :exec
+INSERT INTO workspace_run_tasks (
+    workspace_run_task_id,
+    stage,
+    enforcement_level,
+    run_task_id,
+    workspace_id
+) VALUES (
+    pggen.arg('workspace_run_task_id'),
+    pggen.arg('stage'),
+    pggen.arg('enforcement_level'),
+    pggen.arg('run_task_id'),
+    pggen.arg('workspace_id')
+);
+
+-- name: FindWorkspaceRunTasksByWorkspaceID :many
+SELECT
+    wrt.workspace_run_task_id,
+    wrt.stage,
+    wrt.enforcement_level,
+    wrt.workspace_id,
+    (rt.*)::"run_tasks" AS run_task
+FROM workspace_run_tasks wrt
+JOIN run_tasks rt USING (run_task_id)
+WHERE wrt.workspace_id = pggen.arg('workspace_id')
+ORDER BY rt.name ASC
+;
+
+-- name: FindWorkspaceRunTaskByID :one
+SELECT
+    wrt.workspace_run_task_id,
+    wrt.stage,
+    wrt.enforcement_level,
+    wrt.workspace_id,
+    (rt.*)::"run_tasks" AS run_task
+FROM workspace_run_tasks wrt
+JOIN run_tasks rt USING (run_task_id)
+WHERE wrt.workspace_run_task_id = pggen.arg('workspace_run_task_id')
+;
+
+-- name: UpdateWorkspaceRunTaskByID :one
+UPDATE workspace_run_tasks
+SET
+    stage             = pggen.arg('stage'),
+    enforcement_level = pggen.arg('enforcement_level')
+WHERE workspace_run_task_id = pggen.arg('workspace_run_task_id')
+RETURNING workspace_run_task_id
+;
+
+-- name: DeleteWorkspaceRunTaskByID :one
+DELETE FROM workspace_run_tasks
+WHERE workspace_run_task_id = pggen.arg('workspace_run_task_id')
+RETURNING workspace_run_task_id
+;
+
+-- name: InsertTaskResult :exec
+INSERT INTO task_results (
+    task_result_id,
+    created_at,
+    updated_at,
+    run_task_id,
+    task_name,
+    task_url,
+    stage,
+    enforcement_level,
+    status,
+    message,
+    url,
+    access_token,
+    run_id
+) VALUES (
+    pggen.arg('task_result_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('run_task_id'),
+    pggen.arg('task_name'),
+    pggen.arg('task_url'),
+    pggen.arg('stage'),
+    pggen.arg('enforcement_level'),
+    pggen.arg('status'),
+    pggen.arg('message'),
+    pggen.arg('url'),
+    pggen.arg('access_token'),
+    pggen.arg('run_id')
+);
+
+-- name: FindTaskResultsByRunID :many
+SELECT *
+FROM task_results
+WHERE run_id = pggen.arg('run_id')
+ORDER BY created_at ASC
+;
+
+-- name: FindTaskResultByID :one
+SELECT *
+FROM task_results
+WHERE task_result_id = pggen.arg('task_result_id')
+;
+
+-- name: FindTaskResultByIDForUpdate :one
+SELECT *
+FROM task_results
+WHERE task_result_id = pggen.arg('task_result_id')
+FOR UPDATE
+;
+
+-- name: FindIncompleteTaskResultsCreatedBefore :many
+SELECT *
+FROM task_results
+WHERE status IN ('pending', 'running')
+AND created_at < pggen.arg('created_before')
+;
+
+-- name: UpdateTaskResultByID :one
+UPDATE task_results
+SET
+    updated_at = pggen.arg('updated_at'),
+    status     = pggen.arg('status'),
+    message    = pggen.arg('message'),
+    url        = pggen.arg('url')
+WHERE task_result_id = pggen.arg('task_result_id')
+RETURNING task_result_id
+;
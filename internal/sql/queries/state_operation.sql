@@ -0,0 +1,31 @@
+-- name: InsertStateOperation :exec
+INSERT INTO state_operations (
+    state_operation_id,
+    created_at,
+    workspace_id,
+    state_version_id,
+    previous_state_version_id,
+    kind,
+    address,
+    destination,
+    reason,
+    author
+) VALUES (
+    pggen.arg('state_operation_id'),
+    pggen.arg('created_at'),
+    pggen.arg('workspace_id'),
+    pggen.arg('state_version_id'),
+    pggen.arg('previous_state_version_id'),
+    pggen.arg('kind'),
+    pggen.arg('address'),
+    pggen.arg('destination'),
+    pggen.arg('reason'),
+    pggen.arg('author')
+);
+
+-- name: FindStateOperationsByWorkspaceID :many
+SELECT *
+FROM state_operations
+WHERE workspace_id = pggen.arg('workspace_id')
+ORDER BY created_at DESC
+;
@@ -0,0 +1,85 @@
+-- name: UpsertOrganizationStateRetentionPolicy :exec
+INSERT INTO organization_state_retention_policies (
+    organization_name,
+    keep_last,
+    keep_days
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('keep_last'),
+    pggen.arg('keep_days')
+)
+ON CONFLICT (organization_name) DO UPDATE
+SET keep_last = pggen.arg('keep_last'),
+    keep_days = pggen.arg('keep_days')
+;
+
+-- name: FindOrganizationStateRetentionPolicy :one
+SELECT *
+FROM organization_state_retention_policies
+WHERE organization_name = pggen.arg('organization_name')
+;
+
+-- name: DeleteOrganizationStateRetentionPolicy :one
+DELETE
+FROM organization_state_retention_policies
+WHERE organization_name = pggen.arg('organization_name')
+RETURNING organization_name
+;
+
+-- name: UpsertWorkspaceStateRetentionPolicy :exec
+INSERT INTO workspace_state_retention_policies (
+    workspace_id,
+    keep_last,
+    keep_days
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('keep_last'),
+    pggen.arg('keep_days')
+)
+ON CONFLICT (workspace_id) DO UPDATE
+SET keep_last = pggen.arg('keep_last'),
+    keep_days = pggen.arg('keep_days')
+;
+
+-- name: FindWorkspaceStateRetentionPolicy :one
+SELECT *
+FROM workspace_state_retention_policies
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: DeleteWorkspaceStateRetentionPolicy :one
+DELETE
+FROM workspace_state_retention_policies
+WHERE workspace_id = pggen.arg('workspace_id')
+RETURNING workspace_id
+;
+
+-- FindEffectiveStateRetentionPolicies finds the retention policy in effect for
+-- each workspace that has one, with a workspace's own policy taking precedence
+-- over its organization's policy.
+--
+-- name: FindEffectiveStateRetentionPolicies :many
+SELECT
+    w.workspace_id,
+    w.current_state_version_id,
+    CASE WHEN wp.workspace_id IS NOT NULL THEN wp.keep_last ELSE op.keep_last END AS keep_last,
+    CASE WHEN wp.workspace_id IS NOT NULL THEN wp.keep_days ELSE op.keep_days END AS keep_days
+FROM workspaces w
+LEFT JOIN workspace_state_retention_policies wp USING (workspace_id)
+LEFT JOIN organization_state_retention_policies op USING (organization_name)
+WHERE wp.workspace_id IS NOT NULL
+OR op.organization_name IS NOT NULL
+;
+
+-- name: FindEffectiveStateRetentionPolicyByWorkspaceID :one
+SELECT
+    w.workspace_id,
+    w.current_state_version_id,
+    CASE WHEN wp.workspace_id IS NOT NULL THEN wp.keep_last ELSE op.keep_last END AS keep_last,
+    CASE WHEN wp.workspace_id IS NOT NULL THEN wp.keep_days ELSE op.keep_days END AS keep_days
+FROM workspaces w
+LEFT JOIN workspace_state_retention_policies wp USING (workspace_id)
+LEFT JOIN organization_state_retention_policies op USING (organization_name)
+WHERE w.workspace_id = pggen.arg('workspace_id')
+AND (wp.workspace_id IS NOT NULL OR op.organization_name IS NOT NULL)
+;
@@ -4,13 +4,15 @@ INSERT INTO state_versions (
     created_at,
     serial,
     state,
-    workspace_id
+    workspace_id,
+    run_id
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
     pggen.arg('serial'),
     pggen.arg('state'),
-    pggen.arg('workspace_id')
+    pggen.arg('workspace_id'),
+    pggen.arg('run_id')
 );
 
 -- name: FindStateVersionsByWorkspaceID :many
@@ -65,3 +67,10 @@ FROM state_versions
 WHERE state_version_id = pggen.arg('state_version_id')
 RETURNING state_version_id
 ;
+
+-- name: UpdateStateVersionPinned :one
+UPDATE state_versions
+SET pinned = pggen.arg('pinned')
+WHERE state_version_id = pggen.arg('state_version_id')
+RETURNING state_version_id
+;
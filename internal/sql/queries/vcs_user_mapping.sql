@@ -0,0 +1,78 @@
+-- InsertVCSUserMapping maps a vcs user to either a user or to a team
+-- belonging to the vcs provider's organization. No row is inserted if the user
+-- or team does not exist.
+--
+-- name: InsertVCSUserMapping :one
+INSERT INTO vcs_user_mappings (
+    vcs_user_mapping_id,
+    created_at,
+    vcs_provider_id,
+    vcs_username,
+    username,
+    team_id
+)
+SELECT
+    pggen.arg('vcs_user_mapping_id'),
+    pggen.arg('created_at'),
+    v.vcs_provider_id,
+    pggen.arg('vcs_username'),
+    u.username,
+    t.team_id
+FROM vcs_providers v
+LEFT JOIN users u ON u.username = pggen.arg('username')
+LEFT JOIN teams t ON t.organization_name = v.organization_name AND t.name = pggen.arg('team_name')
+WHERE v.vcs_provider_id = pggen.arg('vcs_provider_id')
+AND (u.username IS NOT NULL OR t.team_id IS NOT NULL)
+RETURNING vcs_user_mapping_id
+;
+
+-- name: FindVCSUserMappingByID :one
+SELECT
+    m.vcs_user_mapping_id,
+    m.created_at,
+    m.vcs_provider_id,
+    m.vcs_username,
+    m.username,
+    m.team_id,
+    t.name AS team_name
+FROM vcs_user_mappings m
+LEFT JOIN teams t USING (team_id)
+WHERE m.vcs_user_mapping_id = pggen.arg('vcs_user_mapping_id')
+;
+
+-- name: FindVCSUserMappingByVCSUsername :one
+SELECT
+    m.vcs_user_mapping_id,
+    m.created_at,
+    m.vcs_provider_id,
+    m.vcs_username,
+    m.username,
+    m.team_id,
+    t.name AS team_name
+FROM vcs_user_mappings m
+LEFT JOIN teams t USING (team_id)
+WHERE m.vcs_provider_id = pggen.arg('vcs_provider_id')
+AND   lower(m.vcs_username) = lower(pggen.arg('vcs_username'))
+;
+
+-- name: FindVCSUserMappings :many
+SELECT
+    m.vcs_user_mapping_id,
+    m.created_at,
+    m.vcs_provider_id,
+    m.vcs_username,
+    m.username,
+    m.team_id,
+    t.name AS team_name
+FROM vcs_user_mappings m
+LEFT JOIN teams t USING (team_id)
+WHERE m.vcs_provider_id = pggen.arg('vcs_provider_id')
+ORDER BY m.vcs_username
+;
+
+-- name: DeleteVCSUserMappingByID :one
+DELETE
+FROM vcs_user_mappings
+WHERE vcs_user_mapping_id = pggen.arg('vcs_user_mapping_id')
+RETURNING vcs_user_mapping_id
+;
@@ -24,7 +24,22 @@ INSERT INTO workspaces (
     trigger_patterns,
     vcs_tags_regex,
     working_directory,
-    organization_name
+    organization_name,
+    plan_timeout,
+    apply_timeout,
+    auto_discard_ttl,
+    supersede_runs,
+    pr_comments,
+    pr_apply,
+    preview_template,
+    max_previews,
+    preview_template_id,
+    preview_pull_request,
+    auto_destroy_at,
+    auto_destroy_activity_duration,
+    auto_apply_destroy,
+    project_id,
+    template
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -50,7 +65,22 @@ INSERT INTO workspaces (
     pggen.arg('trigger_patterns'),
     pggen.arg('vcs_tags_regex'),
     pggen.arg('working_directory'),
-    pggen.arg('organization_name')
+    pggen.arg('organization_name'),
+    pggen.arg('plan_timeout'),
+    pggen.arg('apply_timeout'),
+    pggen.arg('auto_discard_ttl'),
+    pggen.arg('supersede_runs'),
+    pggen.arg('pr_comments'),
+    pggen.arg('pr_apply'),
+    pggen.arg('preview_template'),
+    pggen.arg('max_previews'),
+    pggen.arg('preview_template_id'),
+    pggen.arg('preview_pull_request'),
+    pggen.arg('auto_destroy_at'),
+    pggen.arg('auto_destroy_activity_duration'),
+    pggen.arg('auto_apply_destroy'),
+    pggen.arg('project_id'),
+    pggen.arg('template')
 );
 
 -- name: FindWorkspaces :many
@@ -89,6 +119,8 @@ LEFT JOIN runs r ON w.latest_run_id = r.run_id
 LEFT JOIN (workspace_tags wt JOIN tags t USING (tag_id)) ON wt.workspace_id = w.workspace_id
 WHERE w.name                LIKE '%' || pggen.arg('search') || '%'
 AND   w.organization_name   LIKE ANY(pggen.arg('organization_names'))
+AND   w.project_id          LIKE pggen.arg('project_id')
+AND   (w.template OR NOT pggen.arg('templates_only'))
 GROUP BY w.workspace_id, r.status
 HAVING array_agg(t.name) @> pggen.arg('tags')
 ORDER BY w.updated_at DESC
@@ -104,6 +136,8 @@ WITH
         LEFT JOIN (workspace_tags wt JOIN tags t USING (tag_id)) ON w.workspace_id = wt.workspace_id
         WHERE w.name              LIKE '%' || pggen.arg('search') || '%'
         AND   w.organization_name LIKE ANY(pggen.arg('organization_names'))
+        AND   w.project_id        LIKE pggen.arg('project_id')
+        AND   (w.template OR NOT pggen.arg('templates_only'))
         GROUP BY w.workspace_id
         HAVING array_agg(t.name) @> pggen.arg('tags')
     )
@@ -148,16 +182,26 @@ SELECT
     (vr.*)::"repo_connections" AS workspace_connection,
     (h.*)::"webhooks" AS webhook
 FROM workspaces w
-JOIN workspace_permissions p USING (workspace_id)
 LEFT JOIN users ul ON w.lock_username = ul.username
 LEFT JOIN runs rl ON w.lock_run_id = rl.run_id
 LEFT JOIN runs r ON w.latest_run_id = r.run_id
 LEFT JOIN (repo_connections vr JOIN webhooks h USING (webhook_id)) ON w.workspace_id = vr.workspace_id
-JOIN teams t USING (team_id)
-JOIN team_memberships tm USING (team_id)
-JOIN users u ON tm.username = u.username
 WHERE w.organization_name  = pggen.arg('organization_name')
-AND   u.username           = pggen.arg('username')
+AND   w.project_id         LIKE pggen.arg('project_id')
+AND   (
+    w.workspace_id IN (
+        SELECT p.workspace_id
+        FROM workspace_permissions p
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = pggen.arg('username')
+    )
+    OR w.project_id IN (
+        SELECT pp.project_id
+        FROM project_permissions pp
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = pggen.arg('username')
+    )
+)
 ORDER BY w.updated_at DESC
 LIMIT pggen.arg('limit')
 OFFSET pggen.arg('offset')
@@ -166,12 +210,22 @@ OFFSET pggen.arg('offset')
 -- name: CountWorkspacesByUsername :one
 SELECT count(*)
 FROM workspaces w
-JOIN workspace_permissions p USING (workspace_id)
-JOIN teams t USING (team_id)
-JOIN team_memberships tm USING (team_id)
-JOIN users u USING (username)
 WHERE w.organization_name = pggen.arg('organization_name')
-AND   u.username          = pggen.arg('username')
+AND   w.project_id        LIKE pggen.arg('project_id')
+AND   (
+    w.workspace_id IN (
+        SELECT p.workspace_id
+        FROM workspace_permissions p
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = pggen.arg('username')
+    )
+    OR w.project_id IN (
+        SELECT pp.project_id
+        FROM project_permissions pp
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = pggen.arg('username')
+    )
+)
 ;
 
 -- name: FindWorkspaceByName :one
@@ -257,6 +311,19 @@ SET
     trigger_patterns              = pggen.arg('trigger_patterns'),
     vcs_tags_regex                = pggen.arg('vcs_tags_regex'),
     working_directory             = pggen.arg('working_directory'),
+    plan_timeout                  = pggen.arg('plan_timeout'),
+    apply_timeout                 = pggen.arg('apply_timeout'),
+    auto_discard_ttl              = pggen.arg('auto_discard_ttl'),
+    supersede_runs                = pggen.arg('supersede_runs'),
+    pr_comments                   = pggen.arg('pr_comments'),
+    pr_apply                      = pggen.arg('pr_apply'),
+    preview_template              = pggen.arg('preview_template'),
+    max_previews                  = pggen.arg('max_previews'),
+    auto_destroy_at               = pggen.arg('auto_destroy_at'),
+    auto_destroy_activity_duration = pggen.arg('auto_destroy_activity_duration'),
+    auto_apply_destroy            = pggen.arg('auto_apply_destroy'),
+    project_id                    = pggen.arg('project_id'),
+    template                      = pggen.arg('template'),
     updated_at                    = pggen.arg('updated_at')
 WHERE workspace_id = pggen.arg('id')
 RETURNING workspace_id;
@@ -265,9 +332,19 @@ RETURNING workspace_id;
 UPDATE workspaces
 SET
     lock_username = pggen.arg('username'),
-    lock_run_id = pggen.arg('run_id')
+    lock_run_id = pggen.arg('run_id'),
+    lock_reason = pggen.arg('reason'),
+    locked_at = pggen.arg('locked_at'),
+    lock_expires_at = pggen.arg('expires_at'),
+    lock_external_id = pggen.arg('external_id')
 WHERE workspace_id = pggen.arg('workspace_id');
 
+-- name: FindWorkspaceIDsWithExpiredLocks :many
+SELECT workspace_id
+FROM workspaces
+WHERE lock_expires_at <= pggen.arg('now')
+;
+
 -- name: UpdateWorkspaceLatestRun :exec
 UPDATE workspaces
 SET latest_run_id = pggen.arg('run_id')
@@ -283,3 +360,26 @@ RETURNING workspace_id;
 DELETE
 FROM workspaces
 WHERE workspace_id = pggen.arg('workspace_id');
+
+-- name: FindRemoteStateConsumers :many
+SELECT w.*,
+    (
+        SELECT array_agg(name)
+        FROM tags
+        JOIN workspace_tags wt USING (tag_id)
+        WHERE wt.workspace_id = w.workspace_id
+    ) AS tags,
+    r.status AS latest_run_status,
+    (ul.*)::"users" AS user_lock,
+    (rl.*)::"runs" AS run_lock,
+    (vr.*)::"repo_connections" AS workspace_connection,
+    (h.*)::"webhooks" AS webhook
+FROM workspaces w
+JOIN remote_state_consumers rsc ON w.workspace_id = rsc.consumer_id
+LEFT JOIN users ul ON w.lock_username = ul.username
+LEFT JOIN runs rl ON w.lock_run_id = rl.run_id
+LEFT JOIN runs r ON w.latest_run_id = r.run_id
+LEFT JOIN (repo_connections vr JOIN webhooks h USING (webhook_id)) ON w.workspace_id = vr.workspace_id
+WHERE rsc.workspace_id = pggen.arg('workspace_id')
+ORDER BY w.name ASC
+;
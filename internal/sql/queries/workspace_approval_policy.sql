@@ -0,0 +1,46 @@
+-- name: UpsertWorkspaceApprovalPolicy :exec
+INSERT INTO workspace_approval_policies (
+    workspace_id,
+    approvals_required,
+    exclude_author
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('approvals_required'),
+    pggen.arg('exclude_author')
+)
+ON CONFLICT (workspace_id) DO UPDATE
+SET approvals_required = pggen.arg('approvals_required'),
+    exclude_author     = pggen.arg('exclude_author')
+;
+
+-- name: DeleteWorkspaceApprovalTeams :exec
+DELETE
+FROM workspace_approval_teams
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: InsertWorkspaceApprovalTeam :exec
+INSERT INTO workspace_approval_teams (
+    workspace_id,
+    team_id
+) SELECT w.workspace_id, t.team_id
+    FROM teams t
+    JOIN workspaces w ON w.organization_name = t.organization_name
+    WHERE t.name = pggen.arg('team_name')
+    AND w.workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: FindWorkspaceApprovalPolicy :one
+SELECT
+    wap.workspace_id,
+    wap.approvals_required,
+    wap.exclude_author,
+    (
+        SELECT array_agg(t.name ORDER BY t.name)
+        FROM workspace_approval_teams wat
+        JOIN teams t USING (team_id)
+        WHERE wat.workspace_id = wap.workspace_id
+    ) AS team_names
+FROM workspace_approval_policies wap
+WHERE wap.workspace_id = pggen.arg('workspace_id')
+;
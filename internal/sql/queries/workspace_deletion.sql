@@ -0,0 +1,34 @@
+-- InsertWorkspaceDeletion schedules the deletion of a workspace once its
+-- destroy run has been applied, replacing any previously scheduled deletion.
+--
+-- name: InsertWorkspaceDeletion :exec
+INSERT INTO workspace_deletions (
+    workspace_id,
+    run_id,
+    created_at
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('run_id'),
+    pggen.arg('created_at')
+)
+ON CONFLICT (workspace_id) DO UPDATE
+SET run_id     = EXCLUDED.run_id,
+    created_at = EXCLUDED.created_at
+;
+
+-- name: FindWorkspaceDeletionByRunID :one
+SELECT *
+FROM workspace_deletions
+WHERE run_id = pggen.arg('run_id')
+;
+
+-- name: FindWorkspaceDeletions :many
+SELECT *
+FROM workspace_deletions
+;
+
+-- name: DeleteWorkspaceDeletionByRunID :exec
+DELETE
+FROM workspace_deletions
+WHERE run_id = pggen.arg('run_id')
+;
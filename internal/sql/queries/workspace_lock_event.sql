@@ -0,0 +1,40 @@
+-- name: InsertWorkspaceLockEvent :exec
+INSERT INTO workspace_lock_events (
+    workspace_lock_event_id,
+    created_at,
+    action,
+    lock_kind,
+    holder,
+    actor,
+    reason,
+    workspace_id
+) VALUES (
+    pggen.arg('workspace_lock_event_id'),
+    pggen.arg('created_at'),
+    pggen.arg('action'),
+    pggen.arg('lock_kind'),
+    pggen.arg('holder'),
+    pggen.arg('actor'),
+    pggen.arg('reason'),
+    pggen.arg('workspace_id')
+);
+
+-- name: FindWorkspaceLockEventByID :one
+SELECT *
+FROM workspace_lock_events
+WHERE workspace_lock_event_id = pggen.arg('workspace_lock_event_id')
+;
+
+-- name: FindWorkspaceLockEventsByWorkspaceID :many
+SELECT *
+FROM workspace_lock_events
+WHERE workspace_id = pggen.arg('workspace_id')
+ORDER BY created_at DESC
+LIMIT pggen.arg('limit') OFFSET pggen.arg('offset')
+;
+
+-- name: CountWorkspaceLockEventsByWorkspaceID :one
+SELECT count(*)
+FROM workspace_lock_events
+WHERE workspace_id = pggen.arg('workspace_id')
+;
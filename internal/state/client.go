@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/DataDog/jsonapi"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/api/types"
 	"github.com/leg100/otf/internal/resource"
@@ -31,12 +32,16 @@ func (c *Client) CreateStateVersion(ctx context.Context, opts CreateStateVersion
 	}
 
 	u := fmt.Sprintf("workspaces/%s/state-versions", url.QueryEscape(*opts.WorkspaceID))
-	req, err := c.NewRequest("POST", u, &types.StateVersionCreateVersionOptions{
+	createOpts := types.StateVersionCreateVersionOptions{
 		Lineage: &state.Lineage,
 		MD5:     internal.String(fmt.Sprintf("%x", md5.Sum(opts.State))),
 		Serial:  internal.Int64(state.Serial),
 		State:   internal.String(base64.StdEncoding.EncodeToString(opts.State)),
-	})
+	}
+	if opts.RunID != nil {
+		createOpts.Run = &types.Run{ID: *opts.RunID}
+	}
+	req, err := c.NewRequest("POST", u, &createOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +122,27 @@ func (c *Client) DownloadState(ctx context.Context, svID string) ([]byte, error)
 	return buf.Bytes(), nil
 }
 
+func (c *Client) DiffStateVersions(ctx context.Context, fromID, toID string) (*Diff, error) {
+	u := fmt.Sprintf("state-versions/%s/diff", url.QueryEscape(toID))
+	req, err := c.NewRequest("GET", u, &struct {
+		From string `schema:"from,omitempty"`
+	}{From: fromID})
+	if err != nil {
+		return nil, err
+	}
+
+	// the diff is plain JSON rather than JSON:API
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+	var diff Diff
+	if err := json.Unmarshal(buf.Bytes(), &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
 func (c *Client) RollbackStateVersion(ctx context.Context, svID string) (*Version, error) {
 	// The OTF JSON:API rollback endpoint matches the TFC endpoint for
 	// compatibilty purposes, and takes both a workspace ID and a state version
@@ -138,11 +164,65 @@ func (c *Client) RollbackStateVersion(ctx context.Context, svID string) (*Versio
 	return newFromJSONAPI(&sv), nil
 }
 
+func (c *Client) PinStateVersion(ctx context.Context, svID string) (*Version, error) {
+	return c.setPinned(ctx, svID, "pin")
+}
+
+func (c *Client) UnpinStateVersion(ctx context.Context, svID string) (*Version, error) {
+	return c.setPinned(ctx, svID, "unpin")
+}
+
+func (c *Client) setPinned(ctx context.Context, svID, action string) (*Version, error) {
+	u := fmt.Sprintf("state-versions/%s/actions/%s", url.QueryEscape(svID), action)
+	req, err := c.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := types.StateVersion{}
+	if err = c.Do(ctx, req, &sv); err != nil {
+		return nil, err
+	}
+
+	return newFromJSONAPI(&sv), nil
+}
+
+func (c *Client) ListPrunableStateVersions(ctx context.Context, workspaceID string) ([]*Version, error) {
+	u := fmt.Sprintf("workspaces/%s/state-versions/prunable", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the response is an unpaginated list, which the client cannot decode,
+	// so decode it here instead.
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+	var list []*types.StateVersion
+	if err := jsonapi.Unmarshal(buf.Bytes(), &list); err != nil {
+		return nil, err
+	}
+
+	versions := make([]*Version, len(list))
+	for i, sv := range list {
+		versions[i] = newFromJSONAPI(sv)
+	}
+	return versions, nil
+}
+
 func newFromJSONAPI(from *types.StateVersion) *Version {
-	return &Version{
-		ID:     from.ID,
-		Serial: from.Serial,
+	to := &Version{
+		ID:        from.ID,
+		CreatedAt: from.CreatedAt,
+		Serial:    from.Serial,
+		Pinned:    from.Pinned,
+	}
+	if from.Run != nil {
+		to.RunID = &from.Run.ID
 	}
+	return to
 }
 
 // newListFromJSONAPI constructs a state version list from a json:api struct
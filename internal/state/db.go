@@ -25,6 +25,8 @@ type (
 		Serial              pgtype.Int4                 `json:"serial"`
 		State               []byte                      `json:"state"`
 		WorkspaceID         pgtype.Text                 `json:"workspace_id"`
+		RunID               pgtype.Text                 `json:"run_id"`
+		Pinned              bool                        `json:"pinned"`
 		StateVersionOutputs []pggen.StateVersionOutputs `json:"state_version_outputs"`
 	}
 )
@@ -37,6 +39,7 @@ func (db *pgdb) createVersion(ctx context.Context, v *Version) error {
 			Serial:      sql.Int4(int(v.Serial)),
 			State:       v.State,
 			WorkspaceID: sql.String(v.WorkspaceID),
+			RunID:       sql.StringPtr(v.RunID),
 		})
 		if err != nil {
 			return err
@@ -112,16 +115,33 @@ func (db *pgdb) getState(ctx context.Context, id string) ([]byte, error) {
 
 // deleteVersion deletes a state version from the DB
 func (db *pgdb) deleteVersion(ctx context.Context, id string) error {
-	_, err := db.Conn(ctx).DeleteStateVersionByID(ctx, sql.String(id))
-	if err != nil {
-		err = sql.Error(err)
-		var fkerr *internal.ForeignKeyError
-		if errors.As(err, &fkerr) {
-			if fkerr.ConstraintName == "current_state_version_id_fk" && fkerr.TableName == "workspaces" {
-				return ErrCurrentVersionDeletionAttempt
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		sv, err := db.getVersion(ctx, id)
+		if err != nil {
+			return err
+		}
+		if sv.Pinned {
+			return ErrPinnedVersionDeletionAttempt
+		}
+		_, err = q.DeleteStateVersionByID(ctx, sql.String(id))
+		if err != nil {
+			err = sql.Error(err)
+			var fkerr *internal.ForeignKeyError
+			if errors.As(err, &fkerr) {
+				if fkerr.ConstraintName == "current_state_version_id_fk" && fkerr.TableName == "workspaces" {
+					return ErrCurrentVersionDeletionAttempt
+				}
 			}
+			return err
 		}
-		return err
+		return nil
+	})
+}
+
+func (db *pgdb) updatePinned(ctx context.Context, id string, pinned bool) error {
+	_, err := db.Conn(ctx).UpdateStateVersionPinned(ctx, pinned, sql.String(id))
+	if err != nil {
+		return sql.Error(err)
 	}
 	return nil
 }
@@ -141,8 +161,12 @@ func (row pgRow) toVersion() *Version {
 		Serial:      int64(row.Serial.Int),
 		State:       row.State,
 		WorkspaceID: row.WorkspaceID.String,
+		Pinned:      row.Pinned,
 		Outputs:     make(map[string]*Output, len(row.StateVersionOutputs)),
 	}
+	if row.RunID.Status == pgtype.Present {
+		sv.RunID = &row.RunID.String
+	}
 	for _, r := range row.StateVersionOutputs {
 		sv.Outputs[r.Name.String] = outputRow(r).toOutput()
 	}
@@ -0,0 +1,328 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DiffAdded   DiffAction = "added"
+	DiffRemoved DiffAction = "removed"
+	DiffChanged DiffAction = "changed"
+
+	sensitiveValue = "(sensitive value)"
+)
+
+var ErrDiffWorkspaceMismatch = errors.New("cannot compare state versions belonging to different workspaces")
+
+type (
+	DiffAction string
+
+	// Diff is the difference between two state versions of a workspace.
+	Diff struct {
+		// ID of the earlier state version; empty if the later state version
+		// is the workspace's first state version.
+		FromID    string          `json:"from_id,omitempty"`
+		ToID      string          `json:"to_id"`
+		Resources []*ResourceDiff `json:"resources"`
+		Outputs   []*OutputDiff   `json:"outputs"`
+	}
+
+	// ResourceDiff is the difference to a resource instance between two state
+	// versions.
+	ResourceDiff struct {
+		Address    string           `json:"address"`
+		Action     DiffAction       `json:"action"`
+		Attributes []*AttributeDiff `json:"attributes"`
+	}
+
+	// AttributeDiff is the difference to a resource attribute. Sensitive
+	// values are masked.
+	AttributeDiff struct {
+		Path   string `json:"path"`
+		Before string `json:"before,omitempty"`
+		After  string `json:"after,omitempty"`
+	}
+
+	// OutputDiff is the difference to an output. Sensitive values are masked.
+	OutputDiff struct {
+		Name   string     `json:"name"`
+		Action DiffAction `json:"action"`
+		Before string     `json:"before,omitempty"`
+		After  string     `json:"after,omitempty"`
+	}
+
+	// ResourceChange is a change made to a resource instance by a state
+	// version.
+	ResourceChange struct {
+		StateVersionID string    `json:"state_version_id"`
+		Serial         int64     `json:"serial"`
+		CreatedAt      time.Time `json:"created_at"`
+		// ID of the run that created the state version; nil if it was not
+		// created by a run.
+		RunID *string `json:"run_id,omitempty"`
+
+		*ResourceDiff
+	}
+
+	// attributeValue is a leaf value within a resource instance's attributes.
+	attributeValue struct {
+		path  []any
+		value any
+	}
+)
+
+// Empty determines whether there are no differences.
+func (d *Diff) Empty() bool {
+	return len(d.Resources) == 0 && len(d.Outputs) == 0
+}
+
+// Count returns the number of resource instances with the given action.
+func (d *Diff) Count(action DiffAction) (n int) {
+	for _, rd := range d.Resources {
+		if rd.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// diffFiles compares two state files. A nil from file is treated as an empty
+// state file.
+func diffFiles(from, to *File) *Diff {
+	if from == nil {
+		from = &File{}
+	}
+	var diff Diff
+
+	before := from.instances()
+	after := to.instances()
+	for _, addr := range unionKeys(before, after) {
+		if rd := diffInstance(addr, before, after); rd != nil {
+			diff.Resources = append(diff.Resources, rd)
+		}
+	}
+
+	for _, name := range unionKeys(from.Outputs, to.Outputs) {
+		b, inBefore := from.Outputs[name]
+		a, inAfter := to.Outputs[name]
+		// mask both values if the output is sensitive in either version
+		sensitive := b.Sensitive || a.Sensitive
+		od := &OutputDiff{Name: name}
+		switch {
+		case !inBefore:
+			od.Action = DiffAdded
+			od.After = renderOutput(a, sensitive)
+		case !inAfter:
+			od.Action = DiffRemoved
+			od.Before = renderOutput(b, sensitive)
+		case renderOutput(b, false) != renderOutput(a, false):
+			od.Action = DiffChanged
+			od.Before = renderOutput(b, sensitive)
+			od.After = renderOutput(a, sensitive)
+		default:
+			continue
+		}
+		diff.Outputs = append(diff.Outputs, od)
+	}
+	return &diff
+}
+
+// resourceHistory returns the changes made to instances of the resource at
+// the given address by each of the state versions, which must be in
+// chronological order. The address is either that of an instance, or of a
+// resource, in which case changes to all of its instances are returned.
+// Changes are returned newest first.
+func resourceHistory(address string, versions []*Version) ([]*ResourceChange, error) {
+	var (
+		history []*ResourceChange
+		before  = make(map[string]ResourceInstance)
+	)
+	for _, sv := range versions {
+		f, err := sv.File()
+		if err != nil {
+			return nil, fmt.Errorf("parsing state version %s: %w", sv.ID, err)
+		}
+		after := f.instances()
+		for _, addr := range unionKeys(before, after) {
+			if addr != address && !strings.HasPrefix(addr, address+"[") {
+				continue
+			}
+			if rd := diffInstance(addr, before, after); rd != nil {
+				history = append(history, &ResourceChange{
+					StateVersionID: sv.ID,
+					Serial:         sv.Serial,
+					CreatedAt:      sv.CreatedAt,
+					RunID:          sv.RunID,
+					ResourceDiff:   rd,
+				})
+			}
+		}
+		before = after
+	}
+	// reverse to put newest first
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+// instances returns the file's resource instances keyed by address.
+func (f *File) instances() map[string]ResourceInstance {
+	m := make(map[string]ResourceInstance)
+	for _, res := range f.Resources {
+		for _, inst := range res.Instances {
+			m[res.InstanceAddress(inst)] = inst
+		}
+	}
+	return m
+}
+
+// diffInstance compares the instance at the address before and after,
+// returning nil if it is unchanged.
+func diffInstance(addr string, before, after map[string]ResourceInstance) *ResourceDiff {
+	b, inBefore := before[addr]
+	a, inAfter := after[addr]
+	rd := &ResourceDiff{Address: addr}
+	switch {
+	case !inBefore:
+		rd.Action = DiffAdded
+	case !inAfter:
+		rd.Action = DiffRemoved
+	default:
+		rd.Action = DiffChanged
+	}
+
+	var beforeAttrs, afterAttrs map[string]attributeValue
+	if inBefore {
+		beforeAttrs = b.attributeValues()
+	}
+	if inAfter {
+		afterAttrs = a.attributeValues()
+	}
+	for _, path := range unionKeys(beforeAttrs, afterAttrs) {
+		bv, inBefore := beforeAttrs[path]
+		av, inAfter := afterAttrs[path]
+		if inBefore && inAfter && renderValue(bv.value, false) == renderValue(av.value, false) {
+			continue
+		}
+		// mask both sides if either marks the attribute as sensitive, lest
+		// the other side reveal the value
+		var sensitive bool
+		if inBefore {
+			sensitive = b.Sensitive(bv.path...) || a.Sensitive(bv.path...)
+		} else {
+			sensitive = b.Sensitive(av.path...) || a.Sensitive(av.path...)
+		}
+		ad := &AttributeDiff{Path: path}
+		if inBefore {
+			ad.Before = renderValue(bv.value, sensitive)
+		}
+		if inAfter {
+			ad.After = renderValue(av.value, sensitive)
+		}
+		rd.Attributes = append(rd.Attributes, ad)
+	}
+	if rd.Action == DiffChanged && len(rd.Attributes) == 0 {
+		return nil
+	}
+	return rd
+}
+
+// attributeValues returns the leaf values of the instance's attributes, keyed
+// by path.
+func (i ResourceInstance) attributeValues() map[string]attributeValue {
+	m := make(map[string]attributeValue)
+	for k, v := range i.Attributes {
+		for _, av := range leafValues([]any{k}, v) {
+			m[pathString(av.path)] = av
+		}
+	}
+	return m
+}
+
+// leafValues walks a value decoded from JSON, returning its leaf values. Empty
+// objects and arrays are considered leaves; null values are omitted.
+func leafValues(path []any, v any) []attributeValue {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			break
+		}
+		var values []attributeValue
+		for k, child := range v {
+			values = append(values, leafValues(append(path[:len(path):len(path)], k), child)...)
+		}
+		return values
+	case []any:
+		if len(v) == 0 {
+			break
+		}
+		var values []attributeValue
+		for i, child := range v {
+			values = append(values, leafValues(append(path[:len(path):len(path)], i), child)...)
+		}
+		return values
+	case nil:
+		return nil
+	}
+	return []attributeValue{{path: path, value: v}}
+}
+
+func renderOutput(out FileOutput, sensitive bool) string {
+	var v any
+	if err := json.Unmarshal(out.Value, &v); err != nil {
+		return string(out.Value)
+	}
+	return renderValue(v, sensitive)
+}
+
+func renderValue(v any, sensitive bool) string {
+	if sensitive {
+		return sensitiveValue
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// pathString renders an attribute path, e.g. tags.Name or ingress[0].cidr
+func pathString(path []any) string {
+	var b strings.Builder
+	for _, elem := range path {
+		switch elem := elem.(type) {
+		case string:
+			if b.Len() > 0 {
+				b.WriteRune('.')
+			}
+			b.WriteString(elem)
+		case int:
+			fmt.Fprintf(&b, "[%d]", elem)
+		}
+	}
+	return b.String()
+}
+
+// unionKeys returns the sorted union of the keys of two maps.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want *Diff
+	}{
+		{
+			name: "no differences",
+			from: `{"resources":[{"type":"random_pet","name":"pet","instances":[{"attributes":{"length":2}}]}]}`,
+			to:   `{"resources":[{"type":"random_pet","name":"pet","instances":[{"attributes":{"length":2}}]}]}`,
+			want: &Diff{},
+		},
+		{
+			name: "added resource",
+			to:   `{"resources":[{"type":"random_pet","name":"pet","instances":[{"attributes":{"length":2}}]}]}`,
+			want: &Diff{
+				Resources: []*ResourceDiff{
+					{
+						Address:    "random_pet.pet",
+						Action:     DiffAdded,
+						Attributes: []*AttributeDiff{{Path: "length", After: "2"}},
+					},
+				},
+			},
+		},
+		{
+			name: "removed data resource",
+			from: `{"resources":[{"mode":"data","type":"aws_ami","name":"ubuntu","instances":[{"attributes":{"id":"ami-123"}}]}]}`,
+			to:   `{}`,
+			want: &Diff{
+				Resources: []*ResourceDiff{
+					{
+						Address:    "data.aws_ami.ubuntu",
+						Action:     DiffRemoved,
+						Attributes: []*AttributeDiff{{Path: "id", Before: "ami-123"}},
+					},
+				},
+			},
+		},
+		{
+			name: "changed nested attributes",
+			from: `{"resources":[{"type":"aws_instance","name":"web","instances":[{"attributes":{"ami":"ami-1","tags":{"Name":"web"},"ingress":[{"port":80}]}}]}]}`,
+			to:   `{"resources":[{"type":"aws_instance","name":"web","instances":[{"attributes":{"ami":"ami-2","tags":{"Name":"web"},"ingress":[{"port":443}]}}]}]}`,
+			want: &Diff{
+				Resources: []*ResourceDiff{
+					{
+						Address: "aws_instance.web",
+						Action:  DiffChanged,
+						Attributes: []*AttributeDiff{
+							{Path: "ami", Before: "ami-1", After: "ami-2"},
+							{Path: "ingress[0].port", Before: "80", After: "443"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "sensitive attributes are masked",
+			from: `{"resources":[{"type":"aws_db_instance","name":"db","instances":[{"attributes":{"password":"foo","settings":{"key":"a"}},"sensitive_attributes":[[{"type":"get_attr","value":"password"}],[{"type":"get_attr","value":"settings"}]]}]}]}`,
+			to:   `{"resources":[{"type":"aws_db_instance","name":"db","instances":[{"attributes":{"password":"bar","settings":{"key":"b"}},"sensitive_attributes":[[{"type":"get_attr","value":"password"}],[{"type":"get_attr","value":"settings"}]]}]}]}`,
+			want: &Diff{
+				Resources: []*ResourceDiff{
+					{
+						Address: "aws_db_instance.db",
+						Action:  DiffChanged,
+						Attributes: []*AttributeDiff{
+							{Path: "password", Before: sensitiveValue, After: sensitiveValue},
+							{Path: "settings.key", Before: sensitiveValue, After: sensitiveValue},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "attribute made sensitive is masked on both sides",
+			from: `{"resources":[{"type":"aws_db_instance","name":"db","instances":[{"attributes":{"password":"foo"}}]}]}`,
+			to:   `{"resources":[{"type":"aws_db_instance","name":"db","instances":[{"attributes":{"password":"bar"},"sensitive_attributes":[[{"type":"get_attr","value":"password"}]]}]}]}`,
+			want: &Diff{
+				Resources: []*ResourceDiff{
+					{
+						Address: "aws_db_instance.db",
+						Action:  DiffChanged,
+						Attributes: []*AttributeDiff{
+							{Path: "password", Before: sensitiveValue, After: sensitiveValue},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "indexed instances",
+			from: `{"resources":[{"module":"module.vpc","type":"aws_subnet","name":"private","instances":[{"index_key":0,"attributes":{"cidr":"10.0.0.0/24"}},{"index_key":1,"attributes":{"cidr":"10.0.1.0/24"}}]}]}`,
+			to:   `{"resources":[{"module":"module.vpc","type":"aws_subnet","name":"private","instances":[{"index_key":0,"attributes":{"cidr":"10.0.0.0/24"}}]}]}`,
+			want: &Diff{
+				Resources: []*ResourceDiff{
+					{
+						Address:    "module.vpc.aws_subnet.private[1]",
+						Action:     DiffRemoved,
+						Attributes: []*AttributeDiff{{Path: "cidr", Before: "10.0.1.0/24"}},
+					},
+				},
+			},
+		},
+		{
+			name: "outputs",
+			from: `{"outputs":{"removed":{"value":"a"},"changed":{"value":1},"secret":{"value":"foo","sensitive":true}}}`,
+			to:   `{"outputs":{"added":{"value":["a"]},"changed":{"value":2},"secret":{"value":"bar","sensitive":true}}}`,
+			want: &Diff{
+				Outputs: []*OutputDiff{
+					{Name: "added", Action: DiffAdded, After: `["a"]`},
+					{Name: "changed", Action: DiffChanged, Before: "1", After: "2"},
+					{Name: "removed", Action: DiffRemoved, Before: "a"},
+					{Name: "secret", Action: DiffChanged, Before: sensitiveValue, After: sensitiveValue},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var from *File
+			if tt.from != "" {
+				from = parseFile(t, tt.from)
+			}
+			got := diffFiles(from, parseFile(t, tt.to))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResourceHistory(t *testing.T) {
+	runID := "run-123"
+	versions := []*Version{
+		{
+			ID:        "sv-1",
+			Serial:    1,
+			CreatedAt: time.Unix(1, 0),
+			State:     []byte(`{"resources":[{"type":"aws_iam_role","name":"x","instances":[{"attributes":{"name":"a"}}]}]}`),
+		},
+		{
+			// does not touch the role
+			ID:        "sv-2",
+			Serial:    2,
+			CreatedAt: time.Unix(2, 0),
+			State:     []byte(`{"resources":[{"type":"aws_iam_role","name":"x","instances":[{"attributes":{"name":"a"}}]},{"type":"aws_iam_role","name":"y","instances":[{"attributes":{"name":"y"}}]}]}`),
+		},
+		{
+			ID:        "sv-3",
+			Serial:    3,
+			CreatedAt: time.Unix(3, 0),
+			RunID:     &runID,
+			State:     []byte(`{"resources":[{"type":"aws_iam_role","name":"x","instances":[{"attributes":{"name":"b"}}]}]}`),
+		},
+		{
+			ID:        "sv-4",
+			Serial:    4,
+			CreatedAt: time.Unix(4, 0),
+			State:     []byte(`{}`),
+		},
+	}
+
+	got, err := resourceHistory("aws_iam_role.x", versions)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, len(got))
+	assert.Equal(t, "sv-4", got[0].StateVersionID)
+	assert.Equal(t, DiffRemoved, got[0].Action)
+	assert.Equal(t, "sv-3", got[1].StateVersionID)
+	assert.Equal(t, DiffChanged, got[1].Action)
+	assert.Equal(t, &runID, got[1].RunID)
+	assert.Equal(t, []*AttributeDiff{{Path: "name", Before: "a", After: "b"}}, got[1].Attributes)
+	assert.Equal(t, "sv-1", got[2].StateVersionID)
+	assert.Equal(t, DiffAdded, got[2].Action)
+}
+
+func parseFile(t *testing.T, s string) *File {
+	var f File
+	require.NoError(t, json.Unmarshal([]byte(s), &f))
+	return &f
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/hooks"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql/pggen"
 )
@@ -21,7 +22,8 @@ type (
 	// factory creates state versions - creation requires pre-requisite checking
 	// with the db, hence necessity for a factory.
 	factory struct {
-		db factoryDB
+		db   factoryDB
+		hook *hooks.Hook[*Version]
 	}
 
 	factoryDB interface {
@@ -43,6 +45,7 @@ type (
 		state       []byte
 		workspaceID string
 		serial      int64
+		runID       *string
 	}
 )
 
@@ -92,6 +95,7 @@ func (f *factory) create(ctx context.Context, opts CreateStateVersionOptions) (*
 		state:       opts.State,
 		workspaceID: *opts.WorkspaceID,
 		serial:      serial,
+		runID:       opts.RunID,
 	})
 	if err != nil {
 		return nil, err
@@ -105,7 +109,7 @@ func (f *factory) create(ctx context.Context, opts CreateStateVersionOptions) (*
 
 // Create a state version and update workspace's current state version.
 func (f *factory) createCurrent(ctx context.Context, sv *Version) error {
-	return f.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+	return f.hook.Dispatch(ctx, sv, func(ctx context.Context) error {
 		if err := f.db.createVersion(ctx, sv); err != nil {
 			return err
 		}
@@ -138,6 +142,7 @@ func newVersion(opts newVersionOptions) (Version, error) {
 		Serial:      opts.serial,
 		State:       opts.state,
 		WorkspaceID: opts.workspaceID,
+		RunID:       opts.runID,
 	}
 
 	var f File
@@ -16,7 +16,7 @@ func TestFactory(t *testing.T) {
 	state := testutils.ReadFile(t, "testdata/terraform.tfstate")
 
 	t.Run("first state version", func(t *testing.T) {
-		f := factory{&fakeDB{}}
+		f := newTestFactory(&fakeDB{})
 
 		got, err := f.create(ctx, CreateStateVersionOptions{
 			Serial:      internal.Int64(0),
@@ -64,7 +64,7 @@ func TestFactory(t *testing.T) {
 	})
 
 	t.Run("second state version", func(t *testing.T) {
-		f := factory{&fakeDB{current: &Version{Serial: 0}}}
+		f := newTestFactory(&fakeDB{current: &Version{Serial: 0}})
 
 		got, err := f.create(ctx, CreateStateVersionOptions{
 			Serial:      internal.Int64(1),
@@ -79,7 +79,7 @@ func TestFactory(t *testing.T) {
 	})
 
 	t.Run("same serial, matching state", func(t *testing.T) {
-		f := factory{&fakeDB{current: &Version{Serial: 42, State: state}}}
+		f := newTestFactory(&fakeDB{current: &Version{Serial: 42, State: state}})
 
 		_, err := f.create(ctx, CreateStateVersionOptions{
 			Serial:      internal.Int64(42),
@@ -98,7 +98,7 @@ func TestFactory(t *testing.T) {
 		state2, err := json.Marshal(diffState)
 		require.NoError(t, err)
 
-		f := factory{&fakeDB{current: &Version{Serial: 42, State: state}}}
+		f := newTestFactory(&fakeDB{current: &Version{Serial: 42, State: state}})
 
 		_, err = f.create(ctx, CreateStateVersionOptions{
 			Serial:      internal.Int64(42),
@@ -109,7 +109,7 @@ func TestFactory(t *testing.T) {
 	})
 
 	t.Run("serial less than current", func(t *testing.T) {
-		f := factory{&fakeDB{current: &Version{Serial: 99}}}
+		f := newTestFactory(&fakeDB{current: &Version{Serial: 99}})
 
 		_, err := f.create(ctx, CreateStateVersionOptions{
 			Serial:      internal.Int64(1),
@@ -120,11 +120,11 @@ func TestFactory(t *testing.T) {
 	})
 
 	t.Run("rollback", func(t *testing.T) {
-		f := factory{&fakeDB{version: &Version{
+		f := newTestFactory(&fakeDB{version: &Version{
 			Serial:      4,
 			State:       state,
 			WorkspaceID: "ws-123",
-		}}}
+		}})
 
 		got, err := f.rollback(ctx, "sv-123")
 		require.NoError(t, err)
@@ -6,7 +6,10 @@ import (
 	"strings"
 )
 
-var providerPathRegex = regexp.MustCompile(`provider\[".*?/([^"]+)"\]`)
+var (
+	providerPathRegex   = regexp.MustCompile(`provider\[".*?/([^"]+)"\]`)
+	providerSourceRegex = regexp.MustCompile(`provider\["([^"]+)"\]`)
+)
 
 type (
 	// File is the terraform state file contents
@@ -27,12 +30,41 @@ type (
 
 	Resource struct {
 		Name        string
+		Mode        string
 		ProviderURI string `json:"provider"`
 		Type        string
 		Module      string
+		Instances   []ResourceInstance
+	}
+
+	// ResourceInstance is an instance of a resource in the terraform state
+	// file. A resource using count or for_each has an instance for each key.
+	ResourceInstance struct {
+		IndexKey            json.RawMessage `json:"index_key"`
+		Attributes          map[string]any
+		SensitiveAttributes [][]AttributePathStep `json:"sensitive_attributes"`
+	}
+
+	// AttributePathStep is a step in a path to a nested attribute, either
+	// the name of an attribute or an index into a collection.
+	AttributePathStep struct {
+		Type  string
+		Value json.RawMessage
 	}
 )
 
+// ManagedResourceCount returns the number of instances of managed resources,
+// i.e. excluding data sources, in the state file.
+func (f *File) ManagedResourceCount() (n int) {
+	for _, res := range f.Resources {
+		if res.Mode == "data" {
+			continue
+		}
+		n += len(res.Instances)
+	}
+	return n
+}
+
 // Provider extracts the provider from the provider URI
 func (r Resource) Provider() string {
 	matches := providerPathRegex.FindStringSubmatch(r.ProviderURI)
@@ -42,6 +74,90 @@ func (r Resource) Provider() string {
 	return matches[1]
 }
 
+// ProviderSource extracts the provider's source address from the provider
+// URI, e.g. registry.terraform.io/hashicorp/aws
+func (r Resource) ProviderSource() string {
+	matches := providerSourceRegex.FindStringSubmatch(r.ProviderURI)
+	if matches == nil || len(matches) < 2 {
+		return r.ProviderURI
+	}
+	return matches[1]
+}
+
+// Address returns the address of the resource, e.g. module.vpc.aws_subnet.private
+func (r Resource) Address() string {
+	var b strings.Builder
+	if r.Module != "" {
+		b.WriteString(r.Module)
+		b.WriteRune('.')
+	}
+	if r.Mode == "data" {
+		b.WriteString("data.")
+	}
+	b.WriteString(r.Type)
+	b.WriteRune('.')
+	b.WriteString(r.Name)
+	return b.String()
+}
+
+// InstanceAddress returns the address of an instance of the resource, e.g.
+// aws_subnet.private[0] or aws_subnet.private["a"]
+func (r Resource) InstanceAddress(inst ResourceInstance) string {
+	if len(inst.IndexKey) == 0 || string(inst.IndexKey) == "null" {
+		return r.Address()
+	}
+	return r.Address() + "[" + string(inst.IndexKey) + "]"
+}
+
+// Sensitive determines whether the attribute at the path is marked as
+// sensitive, either directly or because a parent attribute is sensitive. Each
+// element of the path is either an attribute name or a collection index.
+func (i ResourceInstance) Sensitive(path ...any) bool {
+	for _, steps := range i.SensitiveAttributes {
+		if len(steps) > len(path) {
+			continue
+		}
+		match := true
+		for j, step := range steps {
+			if step.key() != path[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the attribute name or collection index referred to by the step,
+// in the same form as the elements of an attribute path.
+func (s AttributePathStep) key() any {
+	switch s.Type {
+	case "get_attr":
+		var name string
+		if err := json.Unmarshal(s.Value, &name); err != nil {
+			return nil
+		}
+		return name
+	case "index":
+		// index values are typed, e.g. {"value":0,"type":"number"}
+		var index struct {
+			Value any
+			Type  string
+		}
+		if err := json.Unmarshal(s.Value, &index); err != nil {
+			return nil
+		}
+		if f, ok := index.Value.(float64); ok {
+			return int(f)
+		}
+		return index.Value
+	}
+	return nil
+}
+
 func (r Resource) ModuleName() string {
 	if r.Module == "" {
 		return "root"
@@ -30,6 +30,18 @@ func TestFile_Unmarshal(t *testing.T) {
 	// skip testing output values because they're not unmarshaled
 }
 
+func TestFile_ManagedResourceCount(t *testing.T) {
+	f := File{
+		Resources: []Resource{
+			{Mode: "managed", Type: "null_resource", Name: "single", Instances: make([]ResourceInstance, 1)},
+			{Mode: "managed", Type: "null_resource", Name: "counted", Instances: make([]ResourceInstance, 3)},
+			{Mode: "data", Type: "null_data_source", Name: "lookup", Instances: make([]ResourceInstance, 1)},
+		},
+	}
+	assert.Equal(t, 4, f.ManagedResourceCount())
+	assert.Equal(t, 0, (&File{}).ManagedResourceCount())
+}
+
 func TestFile_Provider(t *testing.T) {
 	got := Resource{
 		ProviderURI: `provider": "provider["registry.terraform.io/hashicorp/null"]`,
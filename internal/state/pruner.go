@@ -0,0 +1,104 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+)
+
+// PrunerLockID is a unique ID guaranteeing only one pruner on a cluster is
+// running at any time.
+const PrunerLockID int64 = 179366396344335603
+
+// DefaultPruneInterval is the default interval between prunings of state
+// versions.
+const DefaultPruneInterval = time.Hour
+
+type (
+	// Pruner deletes the state versions of workspaces that their retention
+	// policies no longer keep.
+	Pruner struct {
+		logr.Logger
+
+		db *pgdb
+		// Interval between prunings.
+		interval time.Duration
+	}
+
+	PrunerOptions struct {
+		logr.Logger
+		*sql.DB
+
+		// Interval between prunings. Defaults to DefaultPruneInterval.
+		Interval time.Duration
+	}
+)
+
+func NewPruner(opts PrunerOptions) *Pruner {
+	p := &Pruner{
+		Logger:   opts.Logger,
+		db:       &pgdb{opts.DB},
+		interval: opts.Interval,
+	}
+	if p.interval == 0 {
+		p.interval = DefaultPruneInterval
+	}
+	return p
+}
+
+// Start starts the pruner daemon. Should be invoked in a go routine.
+func (p *Pruner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.prune(ctx, time.Now()); err != nil {
+			p.Error(err, "pruning state versions")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// prune deletes the state versions that each workspace's retention policy
+// does not keep as of now.
+func (p *Pruner) prune(ctx context.Context, now time.Time) error {
+	policies, err := p.db.listEffectiveRetentionPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if err := p.pruneWorkspace(ctx, policy, now); err != nil {
+			// carry on with other workspaces
+			p.Error(err, "pruning state versions", "workspace", policy.workspaceID)
+		}
+	}
+	return nil
+}
+
+func (p *Pruner) pruneWorkspace(ctx context.Context, policy effectiveRetentionPolicy, now time.Time) error {
+	versions, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Version], error) {
+		return p.db.listVersions(ctx, policy.workspaceID, opts)
+	})
+	if err != nil {
+		return err
+	}
+	for _, sv := range policy.prunable(versions, now) {
+		err := p.db.deleteVersion(ctx, sv.ID)
+		if errors.Is(err, ErrPinnedVersionDeletionAttempt) || errors.Is(err, ErrCurrentVersionDeletionAttempt) {
+			// version has been pinned or made current since it was listed
+			continue
+		} else if err != nil {
+			return err
+		}
+		p.V(1).Info("pruned state version", "workspace", policy.workspaceID, "id", sv.ID, "serial", sv.Serial)
+	}
+	return nil
+}
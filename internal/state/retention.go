@@ -0,0 +1,243 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+var (
+	ErrPinnedVersionDeletionAttempt = errors.New("deleting a pinned state version is not allowed")
+	ErrInvalidRetentionPolicy       = errors.New("retention policy values must be at least 1")
+)
+
+type (
+	// RetentionPolicy determines which of a workspace's state versions are
+	// kept, with the remainder pruned. A state version is kept if it satisfies
+	// any of the policy's criteria. The current state version and pinned state
+	// versions are always kept.
+	//
+	// A policy belongs to either an organization, applying to all of its
+	// workspaces, or to a workspace, in which case it overrides its
+	// organization's policy.
+	RetentionPolicy struct {
+		// Organization is the name of the organization the policy belongs
+		// to; nil if it belongs to a workspace.
+		Organization *string
+		// WorkspaceID is the ID of the workspace the policy belongs to; nil if
+		// it belongs to an organization.
+		WorkspaceID *string
+		// KeepLast is the number of most recent state versions to keep; nil
+		// to not keep versions by number.
+		KeepLast *int
+		// KeepDays is the number of days for which state versions are kept;
+		// nil to not keep versions by age.
+		KeepDays *int
+	}
+
+	// SetRetentionPolicyOptions are options for setting a retention policy.
+	// A policy that specifies neither option keeps all state versions, which
+	// permits a workspace to opt out of its organization's policy.
+	SetRetentionPolicyOptions struct {
+		KeepLast *int
+		KeepDays *int
+	}
+
+	// effectiveRetentionPolicy is the retention policy in effect for a
+	// workspace.
+	effectiveRetentionPolicy struct {
+		SetRetentionPolicyOptions
+
+		workspaceID      string
+		currentVersionID string
+	}
+)
+
+func (opts SetRetentionPolicyOptions) validate() error {
+	if opts.KeepLast != nil && *opts.KeepLast < 1 {
+		return ErrInvalidRetentionPolicy
+	}
+	if opts.KeepDays != nil && *opts.KeepDays < 1 {
+		return ErrInvalidRetentionPolicy
+	}
+	return nil
+}
+
+// prunable returns those state versions that the policy does not keep as of
+// now. The versions are expected to be ordered newest first.
+func (p effectiveRetentionPolicy) prunable(versions []*Version, now time.Time) []*Version {
+	if p.KeepLast == nil && p.KeepDays == nil {
+		return nil
+	}
+	var pruned []*Version
+	for i, sv := range versions {
+		switch {
+		case sv.ID == p.currentVersionID, sv.Pinned:
+		case p.KeepLast != nil && i < *p.KeepLast:
+		case p.KeepDays != nil && sv.CreatedAt.After(now.AddDate(0, 0, -*p.KeepDays)):
+		default:
+			pruned = append(pruned, sv)
+		}
+	}
+	return pruned
+}
+
+func (a *service) PinStateVersion(ctx context.Context, versionID string) (*Version, error) {
+	return a.setPinned(ctx, rbac.PinStateVersionAction, versionID, true)
+}
+
+func (a *service) UnpinStateVersion(ctx context.Context, versionID string) (*Version, error) {
+	return a.setPinned(ctx, rbac.UnpinStateVersionAction, versionID, false)
+}
+
+func (a *service) setPinned(ctx context.Context, action rbac.Action, versionID string, pinned bool) (*Version, error) {
+	subject, err := a.CanAccessStateVersion(ctx, action, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.db.updatePinned(ctx, versionID, pinned); err != nil {
+		a.Error(err, "updating state version pin", "id", versionID, "pinned", pinned, "subject", subject)
+		return nil, err
+	}
+	sv, err := a.db.getVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	a.V(0).Info("updated state version pin", "id", versionID, "pinned", pinned, "subject", subject)
+	return sv, nil
+}
+
+func (a *service) ListPrunableStateVersions(ctx context.Context, workspaceID string) ([]*Version, error) {
+	subject, err := a.workspace.CanAccess(ctx, rbac.ListStateVersionsAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := a.db.getEffectiveRetentionPolicy(ctx, workspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// workspace is not subject to a retention policy
+		return nil, nil
+	} else if err != nil {
+		a.Error(err, "retrieving state retention policy", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	versions, err := a.listAllVersions(ctx, workspaceID)
+	if err != nil {
+		a.Error(err, "listing state versions", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("listed prunable state versions", "workspace", workspaceID, "subject", subject)
+	return policy.prunable(versions, time.Now()), nil
+}
+
+func (a *service) GetOrganizationStateRetentionPolicy(ctx context.Context, organization string) (*RetentionPolicy, error) {
+	subject, err := a.organization.CanAccess(ctx, rbac.GetOrganizationAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := a.db.getOrganizationRetentionPolicy(ctx, organization)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// a organization legitimately has no policy, so log at low level
+		a.V(3).Info("retrieving state retention policy: organization has no policy", "organization", organization, "subject", subject)
+		return nil, err
+	} else if err != nil {
+		a.Error(err, "retrieving state retention policy", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("retrieved state retention policy", "organization", organization, "subject", subject)
+	return policy, nil
+}
+
+func (a *service) SetOrganizationStateRetentionPolicy(ctx context.Context, organization string, opts SetRetentionPolicyOptions) (*RetentionPolicy, error) {
+	subject, err := a.organization.CanAccess(ctx, rbac.UpdateOrganizationAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := a.db.setOrganizationRetentionPolicy(ctx, organization, opts); err != nil {
+		a.Error(err, "setting state retention policy", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	a.V(0).Info("set state retention policy", "organization", organization, "subject", subject)
+	return &RetentionPolicy{
+		Organization: &organization,
+		KeepLast:     opts.KeepLast,
+		KeepDays:     opts.KeepDays,
+	}, nil
+}
+
+func (a *service) DeleteOrganizationStateRetentionPolicy(ctx context.Context, organization string) error {
+	subject, err := a.organization.CanAccess(ctx, rbac.UpdateOrganizationAction, organization)
+	if err != nil {
+		return err
+	}
+
+	if err := a.db.deleteOrganizationRetentionPolicy(ctx, organization); err != nil {
+		a.Error(err, "deleting state retention policy", "organization", organization, "subject", subject)
+		return err
+	}
+	a.V(0).Info("deleted state retention policy", "organization", organization, "subject", subject)
+	return nil
+}
+
+func (a *service) GetWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID string) (*RetentionPolicy, error) {
+	subject, err := a.workspace.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := a.db.getWorkspaceRetentionPolicy(ctx, workspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// a workspace legitimately has no policy, so log at low level
+		a.V(3).Info("retrieving state retention policy: workspace has no policy", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	} else if err != nil {
+		a.Error(err, "retrieving state retention policy", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("retrieved state retention policy", "workspace", workspaceID, "subject", subject)
+	return policy, nil
+}
+
+func (a *service) SetWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID string, opts SetRetentionPolicyOptions) (*RetentionPolicy, error) {
+	subject, err := a.workspace.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := a.db.setWorkspaceRetentionPolicy(ctx, workspaceID, opts); err != nil {
+		a.Error(err, "setting state retention policy", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	a.V(0).Info("set state retention policy", "workspace", workspaceID, "subject", subject)
+	return &RetentionPolicy{
+		WorkspaceID: &workspaceID,
+		KeepLast:    opts.KeepLast,
+		KeepDays:    opts.KeepDays,
+	}, nil
+}
+
+func (a *service) DeleteWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID string) error {
+	subject, err := a.workspace.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.db.deleteWorkspaceRetentionPolicy(ctx, workspaceID); err != nil {
+		a.Error(err, "deleting state retention policy", "workspace", workspaceID, "subject", subject)
+		return err
+	}
+	a.V(0).Info("deleted state retention policy", "workspace", workspaceID, "subject", subject)
+	return nil
+}
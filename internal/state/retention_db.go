@@ -0,0 +1,114 @@
+package state
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// effectivePolicyRow is a row from a postgres query for the retention policy
+// in effect for a workspace.
+type effectivePolicyRow struct {
+	WorkspaceID           pgtype.Text `json:"workspace_id"`
+	CurrentStateVersionID pgtype.Text `json:"current_state_version_id"`
+	KeepLast              pgtype.Int4 `json:"keep_last"`
+	KeepDays              pgtype.Int4 `json:"keep_days"`
+}
+
+func (row effectivePolicyRow) toPolicy() effectiveRetentionPolicy {
+	return effectiveRetentionPolicy{
+		SetRetentionPolicyOptions: SetRetentionPolicyOptions{
+			KeepLast: int4Ptr(row.KeepLast),
+			KeepDays: int4Ptr(row.KeepDays),
+		},
+		workspaceID:      row.WorkspaceID.String,
+		currentVersionID: row.CurrentStateVersionID.String,
+	}
+}
+
+func (db *pgdb) setOrganizationRetentionPolicy(ctx context.Context, organization string, opts SetRetentionPolicyOptions) error {
+	_, err := db.Conn(ctx).UpsertOrganizationStateRetentionPolicy(ctx, pggen.UpsertOrganizationStateRetentionPolicyParams{
+		OrganizationName: sql.String(organization),
+		KeepLast:         sql.Int4Ptr(opts.KeepLast),
+		KeepDays:         sql.Int4Ptr(opts.KeepDays),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getOrganizationRetentionPolicy(ctx context.Context, organization string) (*RetentionPolicy, error) {
+	row, err := db.Conn(ctx).FindOrganizationStateRetentionPolicy(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return &RetentionPolicy{
+		Organization: &row.OrganizationName.String,
+		KeepLast:     int4Ptr(row.KeepLast),
+		KeepDays:     int4Ptr(row.KeepDays),
+	}, nil
+}
+
+func (db *pgdb) deleteOrganizationRetentionPolicy(ctx context.Context, organization string) error {
+	_, err := db.Conn(ctx).DeleteOrganizationStateRetentionPolicy(ctx, sql.String(organization))
+	return sql.Error(err)
+}
+
+func (db *pgdb) setWorkspaceRetentionPolicy(ctx context.Context, workspaceID string, opts SetRetentionPolicyOptions) error {
+	_, err := db.Conn(ctx).UpsertWorkspaceStateRetentionPolicy(ctx, pggen.UpsertWorkspaceStateRetentionPolicyParams{
+		WorkspaceID: sql.String(workspaceID),
+		KeepLast:    sql.Int4Ptr(opts.KeepLast),
+		KeepDays:    sql.Int4Ptr(opts.KeepDays),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getWorkspaceRetentionPolicy(ctx context.Context, workspaceID string) (*RetentionPolicy, error) {
+	row, err := db.Conn(ctx).FindWorkspaceStateRetentionPolicy(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return &RetentionPolicy{
+		WorkspaceID: &row.WorkspaceID.String,
+		KeepLast:    int4Ptr(row.KeepLast),
+		KeepDays:    int4Ptr(row.KeepDays),
+	}, nil
+}
+
+func (db *pgdb) deleteWorkspaceRetentionPolicy(ctx context.Context, workspaceID string) error {
+	_, err := db.Conn(ctx).DeleteWorkspaceStateRetentionPolicy(ctx, sql.String(workspaceID))
+	return sql.Error(err)
+}
+
+// listEffectiveRetentionPolicies lists the retention policy in effect for
+// each workspace that is subject to one.
+func (db *pgdb) listEffectiveRetentionPolicies(ctx context.Context) ([]effectiveRetentionPolicy, error) {
+	rows, err := db.Conn(ctx).FindEffectiveStateRetentionPolicies(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	policies := make([]effectiveRetentionPolicy, len(rows))
+	for i, r := range rows {
+		policies[i] = effectivePolicyRow(r).toPolicy()
+	}
+	return policies, nil
+}
+
+// getEffectiveRetentionPolicy retrieves the retention policy in effect for
+// a workspace, returning internal.ErrResourceNotFound if the workspace is not
+// subject to a policy.
+func (db *pgdb) getEffectiveRetentionPolicy(ctx context.Context, workspaceID string) (effectiveRetentionPolicy, error) {
+	row, err := db.Conn(ctx).FindEffectiveStateRetentionPolicyByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return effectiveRetentionPolicy{}, sql.Error(err)
+	}
+	return effectivePolicyRow(row).toPolicy(), nil
+}
+
+func int4Ptr(v pgtype.Int4) *int {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+	return internal.Int(int(v.Int))
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicy_Prunable(t *testing.T) {
+	now := time.Date(2023, 9, 15, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	// versions are listed newest first
+	versions := []*Version{
+		{ID: "sv-5", CreatedAt: daysAgo(1)},
+		{ID: "sv-4", CreatedAt: daysAgo(5)},
+		{ID: "sv-3", CreatedAt: daysAgo(10), Pinned: true},
+		{ID: "sv-2", CreatedAt: daysAgo(20)},
+		{ID: "sv-1", CreatedAt: daysAgo(30)},
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		opts    SetRetentionPolicyOptions
+		want    []string
+	}{
+		{
+			name:    "keep everything",
+			current: "sv-5",
+			want:    nil,
+		},
+		{
+			name:    "keep last two",
+			current: "sv-5",
+			opts:    SetRetentionPolicyOptions{KeepLast: internal.Int(2)},
+			want:    []string{"sv-2", "sv-1"},
+		},
+		{
+			name:    "keep last week",
+			current: "sv-5",
+			opts:    SetRetentionPolicyOptions{KeepDays: internal.Int(7)},
+			want:    []string{"sv-2", "sv-1"},
+		},
+		{
+			name:    "keep last one or last week",
+			current: "sv-5",
+			opts:    SetRetentionPolicyOptions{KeepLast: internal.Int(1), KeepDays: internal.Int(7)},
+			want:    []string{"sv-2", "sv-1"},
+		},
+		{
+			name:    "keep last four or last week",
+			current: "sv-5",
+			opts:    SetRetentionPolicyOptions{KeepLast: internal.Int(4), KeepDays: internal.Int(7)},
+			want:    []string{"sv-1"},
+		},
+		{
+			name:    "keep current version after rollback",
+			current: "sv-1",
+			opts:    SetRetentionPolicyOptions{KeepLast: internal.Int(1)},
+			want:    []string{"sv-4", "sv-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := effectiveRetentionPolicy{
+				SetRetentionPolicyOptions: tt.opts,
+				currentVersionID:          tt.current,
+			}
+			var got []string
+			for _, sv := range policy.prunable(versions, now) {
+				got = append(got, sv.ID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetRetentionPolicyOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SetRetentionPolicyOptions
+		want error
+	}{
+		{"keep everything", SetRetentionPolicyOptions{}, nil},
+		{"keep last", SetRetentionPolicyOptions{KeepLast: internal.Int(10)}, nil},
+		{"keep days", SetRetentionPolicyOptions{KeepDays: internal.Int(30)}, nil},
+		{"zero keep last", SetRetentionPolicyOptions{KeepLast: internal.Int(0)}, ErrInvalidRetentionPolicy},
+		{"negative keep days", SetRetentionPolicyOptions{KeepDays: internal.Int(-1)}, ErrInvalidRetentionPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.validate())
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/hooks"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
@@ -41,16 +43,43 @@ type (
 		RollbackStateVersion(ctx context.Context, versionID string) (*Version, error)
 		DownloadState(ctx context.Context, versionID string) ([]byte, error)
 		GetStateVersionOutput(ctx context.Context, outputID string) (*Output, error)
+		// DiffStateVersions compares two state versions belonging to the same
+		// workspace. If fromID is empty then the state version is compared
+		// with the workspace's preceding state version.
+		DiffStateVersions(ctx context.Context, fromID, toID string) (*Diff, error)
+		// ListResourceChanges lists the changes made to a resource by the
+		// workspace's state versions, newest first.
+		ListResourceChanges(ctx context.Context, workspaceID, address string) ([]*ResourceChange, error)
+		// AfterCreateStateVersion registers a listener to be invoked whenever
+		// a workspace's current state version is created, which includes
+		// rolling back to a previous state version.
+		AfterCreateStateVersion(l hooks.Listener[*Version])
+		// PinStateVersion pins a state version, exempting it from retention
+		// policies and preventing its deletion.
+		PinStateVersion(ctx context.Context, versionID string) (*Version, error)
+		UnpinStateVersion(ctx context.Context, versionID string) (*Version, error)
+		// ListPrunableStateVersions lists the state versions of a workspace
+		// that its retention policy would prune, newest first, without
+		// pruning them.
+		ListPrunableStateVersions(ctx context.Context, workspaceID string) ([]*Version, error)
+
+		GetOrganizationStateRetentionPolicy(ctx context.Context, organization string) (*RetentionPolicy, error)
+		SetOrganizationStateRetentionPolicy(ctx context.Context, organization string, opts SetRetentionPolicyOptions) (*RetentionPolicy, error)
+		DeleteOrganizationStateRetentionPolicy(ctx context.Context, organization string) error
+		GetWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID string) (*RetentionPolicy, error)
+		SetWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID string, opts SetRetentionPolicyOptions) (*RetentionPolicy, error)
+		DeleteWorkspaceStateRetentionPolicy(ctx context.Context, workspaceID string) error
 	}
 
 	// service provides access to state and state versions
 	service struct {
 		logr.Logger
 
-		db        *pgdb
-		cache     internal.Cache // cache state file
-		workspace internal.Authorizer
-		web       *webHandlers
+		db           *pgdb
+		cache        internal.Cache // cache state file
+		workspace    internal.Authorizer
+		organization internal.Authorizer
+		web          *webHandlers
 
 		*factory // for creating state versions
 	}
@@ -76,11 +105,12 @@ type (
 func NewService(opts Options) *service {
 	db := &pgdb{opts.DB}
 	svc := service{
-		Logger:    opts.Logger,
-		cache:     opts.Cache,
-		db:        db,
-		workspace: opts.WorkspaceAuthorizer,
-		factory:   &factory{db},
+		Logger:       opts.Logger,
+		cache:        opts.Cache,
+		db:           db,
+		workspace:    opts.WorkspaceAuthorizer,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		factory:      &factory{db: db, hook: hooks.NewHook[*Version](opts.DB)},
 	}
 	svc.web = &webHandlers{
 		Renderer: opts.Renderer,
@@ -93,6 +123,10 @@ func (a *service) AddHandlers(r *mux.Router) {
 	a.web.addHandlers(r)
 }
 
+func (a *service) AfterCreateStateVersion(l hooks.Listener[*Version]) {
+	a.hook.After(l)
+}
+
 func (a *service) CreateStateVersion(ctx context.Context, opts CreateStateVersionOptions) (*Version, error) {
 	if opts.WorkspaceID == nil {
 		return nil, errors.New("workspace ID is required")
@@ -242,6 +276,94 @@ func (a *service) GetStateVersionOutput(ctx context.Context, outputID string) (*
 	return sv, nil
 }
 
+func (a *service) DiffStateVersions(ctx context.Context, fromID, toID string) (*Diff, error) {
+	to, err := a.db.getVersion(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+	// a diff reveals the contents of state, so the subject needs permission
+	// to download state.
+	subject, err := a.workspace.CanAccess(ctx, rbac.DownloadStateAction, to.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var from *Version
+	if fromID != "" {
+		from, err = a.db.getVersion(ctx, fromID)
+		if err != nil {
+			a.Error(err, "retrieving state version", "id", fromID, "subject", subject)
+			return nil, err
+		}
+		if from.WorkspaceID != to.WorkspaceID {
+			return nil, ErrDiffWorkspaceMismatch
+		}
+	} else {
+		versions, err := a.listAllVersions(ctx, to.WorkspaceID)
+		if err != nil {
+			a.Error(err, "listing state versions", "workspace", to.WorkspaceID, "subject", subject)
+			return nil, err
+		}
+		// versions are listed newest first, so the preceding version follows
+		// the version.
+		for i, sv := range versions {
+			if sv.ID == to.ID && i+1 < len(versions) {
+				from = versions[i+1]
+				break
+			}
+		}
+	}
+
+	var fromFile *File
+	if from != nil {
+		if fromFile, err = from.File(); err != nil {
+			return nil, err
+		}
+	}
+	toFile, err := to.File()
+	if err != nil {
+		return nil, err
+	}
+	diff := diffFiles(fromFile, toFile)
+	diff.ToID = to.ID
+	if from != nil {
+		diff.FromID = from.ID
+	}
+	a.V(9).Info("compared state versions", "from", diff.FromID, "to", diff.ToID, "subject", subject)
+	return diff, nil
+}
+
+func (a *service) ListResourceChanges(ctx context.Context, workspaceID, address string) ([]*ResourceChange, error) {
+	subject, err := a.workspace.CanAccess(ctx, rbac.DownloadStateAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := a.listAllVersions(ctx, workspaceID)
+	if err != nil {
+		a.Error(err, "listing state versions", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	// history is built in chronological order
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+	changes, err := resourceHistory(address, versions)
+	if err != nil {
+		a.Error(err, "listing resource changes", "workspace", workspaceID, "address", address, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("listed resource changes", "workspace", workspaceID, "address", address, "subject", subject)
+	return changes, nil
+}
+
+// listAllVersions lists all of a workspace's state versions, newest first.
+func (a *service) listAllVersions(ctx context.Context, workspaceID string) ([]*Version, error) {
+	return resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Version], error) {
+		return a.db.listVersions(ctx, workspaceID, opts)
+	})
+}
+
 func (a *service) CanAccessStateVersion(ctx context.Context, action rbac.Action, svID string) (internal.Subject, error) {
 	sv, err := a.db.getVersion(ctx, svID)
 	if err != nil {
@@ -4,9 +4,14 @@ import (
 	"context"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/hooks"
 	"github.com/leg100/otf/internal/sql/pggen"
 )
 
+func newTestFactory(db *fakeDB) *factory {
+	return &factory{db: db, hook: hooks.NewHook[*Version](db)}
+}
+
 type fakeDB struct {
 	current *Version // returned by getCurrentVersion
 	version *Version // returned by getVersion
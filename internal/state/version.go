@@ -19,6 +19,8 @@ type (
 		State       []byte             // state file
 		Outputs     map[string]*Output // state version has many outputs
 		WorkspaceID string             // state version belongs to a workspace
+		RunID       *string            // ID of run that created the state version; nil if not created by a run
+		Pinned      bool               // pinned state versions are exempt from retention policies and cannot be deleted
 	}
 
 	// VersionList represents a list of state versions.
@@ -41,6 +43,7 @@ type (
 		State       []byte  // Terraform state file. Required.
 		WorkspaceID *string // ID of state version's workspace. Required.
 		Serial      *int64  // State serial number. If not provided then it is extracted from the state.
+		RunID       *string // ID of run creating the state version. Optional.
 	}
 )
 
@@ -33,7 +33,13 @@ func (h *webHandlers) getState(w http.ResponseWriter, r *http.Request) {
 		f, _ = sv.File()
 	}
 
-	if err := h.RenderTemplate("state_get.tmpl", w, f); err != nil {
+	if err := h.RenderTemplate("state_get.tmpl", w, struct {
+		*File
+		WorkspaceID string
+	}{
+		File:        f,
+		WorkspaceID: id,
+	}); err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
@@ -0,0 +1,209 @@
+package stateop
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// address is a parsed resource address, e.g.
+// module.vpc.aws_subnet.private[0]. An address without an index key refers to
+// a resource along with all of its instances, whereas an address with an
+// index key refers to a single instance.
+type address struct {
+	Module string
+	Mode   string
+	Type   string
+	Name   string
+	// Key is the instance index key in JSON form, e.g. 0 or "a"; nil if the
+	// address has no index key.
+	Key json.RawMessage
+}
+
+// parseAddress parses a resource address.
+func parseAddress(s string) (address, error) {
+	segments, err := splitAddress(s)
+	if err != nil {
+		return address{}, err
+	}
+	invalid := fmt.Errorf("%w: %s", ErrInvalidAddress, s)
+
+	addr := address{Mode: "managed"}
+	var modules []string
+	for len(segments) > 0 && segments[0].name == "module" && segments[0].key == nil {
+		if len(segments) < 2 {
+			return address{}, invalid
+		}
+		modules = append(modules, "module."+segments[1].String())
+		segments = segments[2:]
+	}
+	addr.Module = strings.Join(modules, ".")
+
+	if len(segments) > 0 && segments[0].name == "data" && segments[0].key == nil {
+		addr.Mode = "data"
+		segments = segments[1:]
+	}
+	if len(segments) != 2 || segments[0].key != nil {
+		return address{}, invalid
+	}
+	addr.Type = segments[0].name
+	addr.Name = segments[1].name
+	addr.Key = segments[1].key
+	return addr, nil
+}
+
+// Resource returns the address of the resource, without any index key.
+func (a address) Resource() string {
+	var parts []string
+	if a.Module != "" {
+		parts = append(parts, a.Module)
+	}
+	if a.Mode == "data" {
+		parts = append(parts, "data")
+	}
+	parts = append(parts, a.Type, a.Name)
+	return strings.Join(parts, ".")
+}
+
+func (a address) String() string {
+	if a.Key == nil {
+		return a.Resource()
+	}
+	return a.Resource() + "[" + string(a.Key) + "]"
+}
+
+// matchesResource determines whether the resource in the state file is the
+// resource referred to by the address.
+func (a address) matchesResource(r map[string]any) bool {
+	module, _ := r["module"].(string)
+	mode, _ := r["mode"].(string)
+	if mode == "" {
+		mode = "managed"
+	}
+	return module == a.Module && mode == a.Mode && r["type"] == a.Type && r["name"] == a.Name
+}
+
+// matchesInstance determines whether the resource instance in the state file
+// has the address's index key.
+func (a address) matchesInstance(inst map[string]any) bool {
+	key, ok := inst["index_key"]
+	if !ok || key == nil {
+		return a.Key == nil
+	}
+	if a.Key == nil {
+		return false
+	}
+	b, err := json.Marshal(key)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(b, a.Key)
+}
+
+// addressSegment is a dot-separated segment of an address, with an optional
+// index key, e.g. private[0]
+type addressSegment struct {
+	name string
+	key  json.RawMessage
+}
+
+func (s addressSegment) String() string {
+	if s.key == nil {
+		return s.name
+	}
+	return s.name + "[" + string(s.key) + "]"
+}
+
+// splitAddress splits an address into its segments, ignoring dots within
+// index keys.
+func splitAddress(s string) ([]addressSegment, error) {
+	invalid := fmt.Errorf("%w: %s", ErrInvalidAddress, s)
+
+	var (
+		segments []addressSegment
+		current  addressSegment
+		name     strings.Builder
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '.':
+			if name.Len() == 0 {
+				return nil, invalid
+			}
+			current.name = name.String()
+			segments = append(segments, current)
+			current = addressSegment{}
+			name.Reset()
+		case '[':
+			if name.Len() == 0 {
+				return nil, invalid
+			}
+			end := indexKeyEnd(s, i+1)
+			if end < 0 {
+				return nil, invalid
+			}
+			key, err := parseIndexKey(s[i+1 : end])
+			if err != nil {
+				return nil, invalid
+			}
+			current.key = key
+			i = end
+			// an index key must end the segment
+			if i+1 < len(s) && s[i+1] != '.' {
+				return nil, invalid
+			}
+		default:
+			if current.key != nil {
+				return nil, invalid
+			}
+			name.WriteByte(s[i])
+		}
+	}
+	if name.Len() == 0 {
+		return nil, invalid
+	}
+	current.name = name.String()
+	return append(segments, current), nil
+}
+
+// indexKeyEnd returns the position of the closing bracket of an index key
+// starting at position start, or -1 if there is no closing bracket.
+func indexKeyEnd(s string, start int) int {
+	quoted := false
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quoted:
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case s[i] == ']' && !quoted:
+			return i
+		}
+	}
+	return -1
+}
+
+// parseIndexKey parses an index key, which is either a whole number or a
+// quoted string, returning it in canonical JSON form.
+func parseIndexKey(s string) (json.RawMessage, error) {
+	var key any
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(&key); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, ErrInvalidAddress
+	}
+	switch key := key.(type) {
+	case json.Number:
+		if _, err := key.Int64(); err != nil {
+			return nil, ErrInvalidAddress
+		}
+	case string:
+	default:
+		return nil, ErrInvalidAddress
+	}
+	return json.Marshal(key)
+}
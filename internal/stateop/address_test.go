@@ -0,0 +1,65 @@
+package stateop
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    address
+	}{
+		{
+			"aws_instance.web",
+			address{Mode: "managed", Type: "aws_instance", Name: "web"},
+		},
+		{
+			"aws_instance.web[0]",
+			address{Mode: "managed", Type: "aws_instance", Name: "web", Key: json.RawMessage(`0`)},
+		},
+		{
+			`aws_instance.web["a.b"]`,
+			address{Mode: "managed", Type: "aws_instance", Name: "web", Key: json.RawMessage(`"a.b"`)},
+		},
+		{
+			"data.aws_ami.ubuntu",
+			address{Mode: "data", Type: "aws_ami", Name: "ubuntu"},
+		},
+		{
+			`module.vpc["eu"].module.subnets.aws_subnet.private[1]`,
+			address{Module: `module.vpc["eu"].module.subnets`, Mode: "managed", Type: "aws_subnet", Name: "private", Key: json.RawMessage(`1`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			got, err := parseAddress(tt.address)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.address, got.String())
+		})
+	}
+}
+
+func TestParseAddress_Invalid(t *testing.T) {
+	for _, addr := range []string{
+		"",
+		"aws_instance",
+		"aws_instance.web.extra",
+		"aws_instance[0].web",
+		"aws_instance.web[0",
+		"aws_instance.web[0]x",
+		"aws_instance.web[1.5]",
+		"aws_instance.web[true]",
+		"module.vpc",
+		"aws_instance..web",
+	} {
+		t.Run(addr, func(t *testing.T) {
+			_, err := parseAddress(addr)
+			assert.ErrorIs(t, err, ErrInvalidAddress)
+		})
+	}
+}
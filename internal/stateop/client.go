@@ -0,0 +1,53 @@
+package stateop
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/api/types"
+)
+
+type Client struct {
+	internal.JSONAPIClient
+}
+
+func (c *Client) CreateStateOperation(ctx context.Context, workspaceID string, opts CreateOptions) (*Operation, error) {
+	u := fmt.Sprintf("workspaces/%s/state-operations", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("POST", u, &types.StateOperationCreateOptions{
+		Kind:        string(opts.Kind),
+		Address:     opts.Address,
+		Destination: opts.Destination,
+		Reason:      opts.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	op := types.StateOperation{}
+	if err := c.Do(ctx, req, &op); err != nil {
+		return nil, err
+	}
+	return newFromJSONAPI(&op), nil
+}
+
+func newFromJSONAPI(from *types.StateOperation) *Operation {
+	to := &Operation{
+		ID:          from.ID,
+		CreatedAt:   from.CreatedAt,
+		WorkspaceID: from.Workspace.ID,
+		Kind:        Kind(from.Kind),
+		Address:     from.Address,
+		Destination: from.Destination,
+		Reason:      from.Reason,
+		Author:      from.Author,
+	}
+	if from.StateVersion != nil {
+		to.StateVersionID = &from.StateVersion.ID
+	}
+	if from.PreviousStateVersion != nil {
+		to.PreviousStateVersionID = &from.PreviousStateVersion.ID
+	}
+	return to
+}
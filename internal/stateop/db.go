@@ -0,0 +1,80 @@
+package stateop
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a state operations database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// pgrow is a database row for a state operation
+	pgrow struct {
+		StateOperationID       pgtype.Text        `json:"state_operation_id"`
+		CreatedAt              pgtype.Timestamptz `json:"created_at"`
+		WorkspaceID            pgtype.Text        `json:"workspace_id"`
+		StateVersionID         pgtype.Text        `json:"state_version_id"`
+		PreviousStateVersionID pgtype.Text        `json:"previous_state_version_id"`
+		Kind                   pgtype.Text        `json:"kind"`
+		Address                pgtype.Text        `json:"address"`
+		Destination            pgtype.Text        `json:"destination"`
+		Reason                 pgtype.Text        `json:"reason"`
+		Author                 pgtype.Text        `json:"author"`
+	}
+)
+
+func (r pgrow) toOperation() *Operation {
+	op := &Operation{
+		ID:          r.StateOperationID.String,
+		CreatedAt:   r.CreatedAt.Time.UTC(),
+		WorkspaceID: r.WorkspaceID.String,
+		Kind:        Kind(r.Kind.String),
+		Address:     r.Address.String,
+		Reason:      r.Reason.String,
+		Author:      r.Author.String,
+	}
+	if r.Destination.Status == pgtype.Present {
+		op.Destination = &r.Destination.String
+	}
+	if r.StateVersionID.Status == pgtype.Present {
+		op.StateVersionID = &r.StateVersionID.String
+	}
+	if r.PreviousStateVersionID.Status == pgtype.Present {
+		op.PreviousStateVersionID = &r.PreviousStateVersionID.String
+	}
+	return op
+}
+
+func (db *pgdb) createOperation(ctx context.Context, op *Operation) error {
+	_, err := db.Conn(ctx).InsertStateOperation(ctx, pggen.InsertStateOperationParams{
+		StateOperationID:       sql.String(op.ID),
+		CreatedAt:              sql.Timestamptz(op.CreatedAt),
+		WorkspaceID:            sql.String(op.WorkspaceID),
+		StateVersionID:         sql.StringPtr(op.StateVersionID),
+		PreviousStateVersionID: sql.StringPtr(op.PreviousStateVersionID),
+		Kind:                   sql.String(string(op.Kind)),
+		Address:                sql.String(op.Address),
+		Destination:            sql.StringPtr(op.Destination),
+		Reason:                 sql.String(op.Reason),
+		Author:                 sql.String(op.Author),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listOperations(ctx context.Context, workspaceID string) ([]*Operation, error) {
+	rows, err := db.Conn(ctx).FindStateOperationsByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ops := make([]*Operation, len(rows))
+	for i, r := range rows {
+		ops[i] = pgrow(r).toOperation()
+	}
+	return ops, nil
+}
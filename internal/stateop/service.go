@@ -0,0 +1,170 @@
+package stateop
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	StateOperationService = Service
+
+	Service interface {
+		// CreateStateOperation performs an operation on a workspace's current
+		// state, creating a new state version. The workspace is locked for the
+		// duration of the operation.
+		CreateStateOperation(ctx context.Context, workspaceID string, opts CreateOptions) (*Operation, error)
+		// ListStateOperations lists the operations performed on a workspace's
+		// state, newest first.
+		ListStateOperations(ctx context.Context, workspaceID string) ([]*Operation, error)
+	}
+
+	service struct {
+		logr.Logger
+
+		workspace internal.Authorizer
+		db        *pgdb
+		web       *webHandlers
+
+		workspaces workspace.Service
+		states     state.Service
+	}
+
+	Options struct {
+		*sql.DB
+		html.Renderer
+		logr.Logger
+
+		WorkspaceAuthorizer internal.Authorizer
+
+		WorkspaceService workspace.Service
+		StateService     state.Service
+	}
+)
+
+func NewService(opts Options) *service {
+	svc := service{
+		Logger:     opts.Logger,
+		workspace:  opts.WorkspaceAuthorizer,
+		db:         &pgdb{opts.DB},
+		workspaces: opts.WorkspaceService,
+		states:     opts.StateService,
+	}
+	svc.web = &webHandlers{
+		Renderer:         opts.Renderer,
+		WorkspaceService: opts.WorkspaceService,
+		svc:              &svc,
+	}
+	return &svc
+}
+
+func (s *service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+}
+
+func (s *service) CreateStateOperation(ctx context.Context, workspaceID string, opts CreateOptions) (*Operation, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.CreateStateOperationAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	// The workspace is locked on behalf of the subject, and only a user can
+	// lock a workspace.
+	if _, ok := subject.(*auth.User); !ok {
+		return nil, ErrUserRequired
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	// Lock the workspace to prevent runs and other users from altering state
+	// whilst the operation is performed, unless the user has already locked
+	// it themselves.
+	ws, err := s.workspaces.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !ws.LockedBy(subject) {
+		if _, err := s.workspaces.LockWorkspace(ctx, workspaceID, nil, workspace.LockOptions{
+			Reason: "performing state operation",
+		}); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if _, err := s.workspaces.UnlockWorkspace(ctx, workspaceID, nil, false); err != nil {
+				s.Error(err, "unlocking workspace after state operation", "workspace", workspaceID, "subject", subject)
+			}
+		}()
+	}
+
+	op, err := s.create(ctx, workspaceID, subject, opts)
+	if err != nil {
+		s.Error(err, "performing state operation", "workspace", workspaceID, "kind", opts.Kind, "address", opts.Address, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("performed state operation", "workspace", workspaceID, "kind", op.Kind, "address", op.Address, "state_version", *op.StateVersionID, "subject", subject)
+	return op, nil
+}
+
+func (s *service) create(ctx context.Context, workspaceID string, subject internal.Subject, opts CreateOptions) (*Operation, error) {
+	op := newOperation(workspaceID, subject.String(), opts)
+
+	// The subject has been authorized to perform the operation, which entails
+	// creating a state version, a privilege the subject may otherwise lack.
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "state-operator"})
+
+	current, err := s.states.GetCurrentStateVersion(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	src, err := s.states.DownloadState(ctx, current.ID)
+	if err != nil {
+		return nil, err
+	}
+	serial := current.Serial + 1
+	dst, err := apply(src, serial, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		sv, err := s.states.CreateStateVersion(ctx, state.CreateStateVersionOptions{
+			State:       dst,
+			WorkspaceID: &workspaceID,
+			Serial:      &serial,
+		})
+		if err != nil {
+			return err
+		}
+		op.StateVersionID = &sv.ID
+		op.PreviousStateVersionID = &current.ID
+		return s.db.createOperation(ctx, op)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (s *service) ListStateOperations(ctx context.Context, workspaceID string) ([]*Operation, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.ListStateOperationsAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := s.db.listOperations(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "listing state operations", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed state operations", "workspace", workspaceID, "subject", subject)
+	return ops, nil
+}
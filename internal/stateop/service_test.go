@@ -0,0 +1,34 @@
+package stateop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/tokens"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateStateOperation_UserRequired(t *testing.T) {
+	svc := &service{
+		Logger:    logr.Discard(),
+		workspace: &fakeAuthorizer{subject: &tokens.OrganizationToken{Organization: "acme-corp"}},
+	}
+
+	_, err := svc.CreateStateOperation(context.Background(), "ws-123", CreateOptions{
+		Kind:    RemoveKind,
+		Address: "aws_s3_bucket.logs",
+		Reason:  "no longer managed",
+	})
+	assert.ErrorIs(t, err, ErrUserRequired)
+}
+
+type fakeAuthorizer struct {
+	subject internal.Subject
+}
+
+func (f *fakeAuthorizer) CanAccess(context.Context, rbac.Action, string) (internal.Subject, error) {
+	return f.subject, nil
+}
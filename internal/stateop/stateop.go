@@ -0,0 +1,331 @@
+// Package stateop performs operations on a workspace's current state on the
+// server, such as moving and removing resources, in place of pulling the state,
+// editing it locally, and pushing it back.
+package stateop
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leg100/otf/internal"
+)
+
+const (
+	MoveKind   Kind = "move"
+	RemoveKind Kind = "remove"
+	TaintKind  Kind = "taint"
+)
+
+var (
+	ErrInvalidAddress         = errors.New("invalid resource address")
+	ErrAddressNotFound        = errors.New("resource address not found in state")
+	ErrAddressAlreadyExists   = errors.New("destination address already exists in state")
+	ErrMoveTypeMismatch       = errors.New("cannot move a resource to an address of a different type")
+	ErrTaintDataResource      = errors.New("cannot taint a data resource")
+	ErrDestinationRequired    = errors.New("a destination address is required to move a resource")
+	ErrReasonRequired         = errors.New("a reason is required")
+	ErrInvalidOperationKind   = errors.New("invalid state operation kind")
+	ErrMoveToSameAddress      = errors.New("source and destination addresses are the same")
+	ErrMoveResourceToInstance = errors.New("cannot move a resource and all its instances to an instance address")
+	ErrUserRequired           = errors.New("only a user can perform a state operation")
+)
+
+type (
+	// Kind is the kind of state operation
+	Kind string
+
+	// Operation is an operation performed on a workspace's state, resulting
+	// in a new state version.
+	Operation struct {
+		ID          string
+		CreatedAt   time.Time
+		WorkspaceID string
+		Kind        Kind
+		// Address of the resource, or resource instance, operated upon.
+		Address string
+		// Destination is the address to which the resource is moved; only
+		// set for move operations.
+		Destination *string
+		// Reason the operation was performed.
+		Reason string
+		// Author is the user that performed the operation.
+		Author string
+		// StateVersionID is the ID of the state version created by the
+		// operation; nil if the state version has since been deleted.
+		StateVersionID *string
+		// PreviousStateVersionID is the ID of the state version that was
+		// current before the operation, which can be rolled back to in order
+		// to revert the operation; nil if the state version has since been
+		// deleted.
+		PreviousStateVersionID *string
+	}
+
+	// CreateOptions are options for performing a state operation.
+	CreateOptions struct {
+		Kind        Kind
+		Address     string
+		Destination *string
+		Reason      string
+	}
+)
+
+func (opts CreateOptions) validate() error {
+	switch opts.Kind {
+	case MoveKind:
+		if opts.Destination == nil || *opts.Destination == "" {
+			return ErrDestinationRequired
+		}
+	case RemoveKind, TaintKind:
+	default:
+		return ErrInvalidOperationKind
+	}
+	if opts.Reason == "" {
+		return ErrReasonRequired
+	}
+	return nil
+}
+
+// apply applies the operation to the state, returning the modified state with
+// the given serial. Fields in the state that are not modified are preserved
+// as-is.
+func apply(state []byte, serial int64, opts CreateOptions) ([]byte, error) {
+	var file map[string]any
+	dec := json.NewDecoder(bytes.NewReader(state))
+	// preserve numbers, e.g. large integer attribute values, as they are.
+	dec.UseNumber()
+	if err := dec.Decode(&file); err != nil {
+		return nil, err
+	}
+	resources, err := stateResources(file)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := parseAddress(opts.Address)
+	if err != nil {
+		return nil, err
+	}
+	switch opts.Kind {
+	case MoveKind:
+		dst, err := parseAddress(*opts.Destination)
+		if err != nil {
+			return nil, err
+		}
+		resources, err = move(resources, src, dst)
+		if err != nil {
+			return nil, err
+		}
+	case RemoveKind:
+		resources, err = remove(resources, src)
+		if err != nil {
+			return nil, err
+		}
+	case TaintKind:
+		if err := taint(resources, src); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidOperationKind
+	}
+
+	file["resources"] = resources
+	file["serial"] = serial
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// stateResources retrieves the resources from a state file.
+func stateResources(file map[string]any) ([]map[string]any, error) {
+	raw, _ := file["resources"].([]any)
+	resources := make([]map[string]any, len(raw))
+	for i, r := range raw {
+		res, ok := r.(map[string]any)
+		if !ok {
+			return nil, errors.New("malformed resource in state")
+		}
+		resources[i] = res
+	}
+	return resources, nil
+}
+
+// instances retrieves the instances of a resource in a state file.
+func instances(res map[string]any) []map[string]any {
+	raw, _ := res["instances"].([]any)
+	instances := make([]map[string]any, 0, len(raw))
+	for _, i := range raw {
+		if inst, ok := i.(map[string]any); ok {
+			instances = append(instances, inst)
+		}
+	}
+	return instances
+}
+
+func setInstances(res map[string]any, instances []map[string]any) {
+	raw := make([]any, len(instances))
+	for i, inst := range instances {
+		raw[i] = inst
+	}
+	res["instances"] = raw
+}
+
+// find returns the index of the resource with the address, or -1 if not
+// found.
+func find(resources []map[string]any, addr address) int {
+	for i, r := range resources {
+		if addr.matchesResource(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findInstance returns the index of the resource instance with the address'
+// index key, or -1 if not found.
+func findInstance(instances []map[string]any, addr address) int {
+	for i, inst := range instances {
+		if addr.matchesInstance(inst) {
+			return i
+		}
+	}
+	return -1
+}
+
+func move(resources []map[string]any, src, dst address) ([]map[string]any, error) {
+	if src.String() == dst.String() {
+		return nil, ErrMoveToSameAddress
+	}
+	if src.Type != dst.Type || src.Mode != dst.Mode {
+		return nil, ErrMoveTypeMismatch
+	}
+	i := find(resources, src)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAddressNotFound, src)
+	}
+
+	if src.Key == nil {
+		// move resource along with all of its instances
+		if dst.Key != nil {
+			return nil, ErrMoveResourceToInstance
+		}
+		if find(resources, dst) >= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrAddressAlreadyExists, dst)
+		}
+		setResourceAddress(resources[i], dst)
+		return resources, nil
+	}
+
+	// move a single instance, to either an instance of another resource or an
+	// instance of the same resource with a different key.
+	srcInstances := instances(resources[i])
+	j := findInstance(srcInstances, src)
+	if j < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAddressNotFound, src)
+	}
+	inst := srcInstances[j]
+
+	k := find(resources, dst)
+	if k >= 0 && findInstance(instances(resources[k]), dst) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAddressAlreadyExists, dst)
+	}
+
+	if dst.Key != nil {
+		inst["index_key"] = dst.Key
+	} else {
+		delete(inst, "index_key")
+	}
+	if k == i {
+		// re-keying an instance of the same resource
+		return resources, nil
+	}
+	if k >= 0 {
+		setInstances(resources[k], append(instances(resources[k]), inst))
+	} else {
+		// create destination resource, copying the provider etc from the
+		// source resource
+		res := make(map[string]any, len(resources[i]))
+		for field, v := range resources[i] {
+			res[field] = v
+		}
+		// older state files record whether a resource uses count or
+		// for_each, which no longer holds for the new resource.
+		delete(res, "each")
+		setResourceAddress(res, dst)
+		setInstances(res, []map[string]any{inst})
+		resources = append(resources, res)
+	}
+	return removeInstance(resources, i, j), nil
+}
+
+func setResourceAddress(res map[string]any, addr address) {
+	if addr.Module != "" {
+		res["module"] = addr.Module
+	} else {
+		delete(res, "module")
+	}
+	res["name"] = addr.Name
+}
+
+func remove(resources []map[string]any, addr address) ([]map[string]any, error) {
+	i := find(resources, addr)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAddressNotFound, addr)
+	}
+	if addr.Key == nil {
+		return append(resources[:i], resources[i+1:]...), nil
+	}
+	insts := instances(resources[i])
+	j := findInstance(insts, addr)
+	if j < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAddressNotFound, addr)
+	}
+	return removeInstance(resources, i, j), nil
+}
+
+func taint(resources []map[string]any, addr address) error {
+	if addr.Mode == "data" {
+		return ErrTaintDataResource
+	}
+	i := find(resources, addr)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrAddressNotFound, addr)
+	}
+	insts := instances(resources[i])
+	if addr.Key != nil {
+		j := findInstance(insts, addr)
+		if j < 0 {
+			return fmt.Errorf("%w: %s", ErrAddressNotFound, addr)
+		}
+		insts = insts[j : j+1]
+	}
+	for _, inst := range insts {
+		inst["status"] = "tainted"
+	}
+	return nil
+}
+
+// removeInstance removes the jth instance of the ith resource, removing the
+// resource too if it is left without any instances.
+func removeInstance(resources []map[string]any, i, j int) []map[string]any {
+	insts := instances(resources[i])
+	insts = append(insts[:j], insts[j+1:]...)
+	if len(insts) == 0 {
+		return append(resources[:i], resources[i+1:]...)
+	}
+	setInstances(resources[i], insts)
+	return resources
+}
+
+func newOperation(workspaceID, author string, opts CreateOptions) *Operation {
+	return &Operation{
+		ID:          internal.NewID("sop"),
+		CreatedAt:   internal.CurrentTimestamp(),
+		WorkspaceID: workspaceID,
+		Kind:        opts.Kind,
+		Address:     opts.Address,
+		Destination: opts.Destination,
+		Reason:      opts.Reason,
+		Author:      author,
+	}
+}
@@ -0,0 +1,190 @@
+package stateop
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testState = `{
+	"version": 4,
+	"serial": 3,
+	"lineage": "b2b54b23-e7ea-5500-7b15-fcb68c1c3e66",
+	"resources": [
+		{
+			"mode": "managed",
+			"type": "aws_instance",
+			"name": "web",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [
+				{"index_key": 0, "attributes": {"id": "i-0", "cpu_credits": 12345678901234567890}},
+				{"index_key": 1, "attributes": {"id": "i-1"}}
+			]
+		},
+		{
+			"mode": "managed",
+			"module": "module.db",
+			"type": "aws_db_instance",
+			"name": "main",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "db-1"}}]
+		},
+		{
+			"mode": "data",
+			"type": "aws_ami",
+			"name": "ubuntu",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "ami-1"}}]
+		}
+	]
+}`
+
+// testResource is the subset of a resource in a state file checked by tests.
+type testResource struct {
+	Mode      string
+	Module    string
+	Type      string
+	Name      string
+	Provider  string
+	Instances []struct {
+		IndexKey   any            `json:"index_key"`
+		Status     string         `json:"status"`
+		Attributes map[string]any `json:"attributes"`
+	}
+}
+
+func applyTestState(t *testing.T, opts CreateOptions) (serial int64, lineage string, resources map[string]testResource) {
+	t.Helper()
+
+	got, err := apply([]byte(testState), 4, opts)
+	require.NoError(t, err)
+
+	var file struct {
+		Serial    int64
+		Lineage   string
+		Resources []testResource
+	}
+	require.NoError(t, json.Unmarshal(got, &file))
+
+	resources = make(map[string]testResource, len(file.Resources))
+	for _, r := range file.Resources {
+		addr := address{Module: r.Module, Mode: r.Mode, Type: r.Type, Name: r.Name}
+		resources[addr.Resource()] = r
+	}
+	return file.Serial, file.Lineage, resources
+}
+
+func TestApply(t *testing.T) {
+	t.Run("move resource", func(t *testing.T) {
+		serial, lineage, got := applyTestState(t, CreateOptions{
+			Kind:        MoveKind,
+			Address:     "module.db.aws_db_instance.main",
+			Destination: internal.String("aws_db_instance.primary"),
+		})
+		assert.Equal(t, int64(4), serial)
+		assert.Equal(t, "b2b54b23-e7ea-5500-7b15-fcb68c1c3e66", lineage)
+		assert.NotContains(t, got, "module.db.aws_db_instance.main")
+		if assert.Contains(t, got, "aws_db_instance.primary") {
+			assert.Equal(t, "db-1", got["aws_db_instance.primary"].Instances[0].Attributes["id"])
+		}
+	})
+
+	t.Run("move instance to new resource", func(t *testing.T) {
+		_, _, got := applyTestState(t, CreateOptions{
+			Kind:        MoveKind,
+			Address:     "aws_instance.web[1]",
+			Destination: internal.String("aws_instance.app"),
+		})
+		require.Equal(t, 1, len(got["aws_instance.web"].Instances))
+		require.Equal(t, 1, len(got["aws_instance.app"].Instances))
+		assert.Nil(t, got["aws_instance.app"].Instances[0].IndexKey)
+		assert.Equal(t, "i-1", got["aws_instance.app"].Instances[0].Attributes["id"])
+		assert.Equal(t, `provider["registry.terraform.io/hashicorp/aws"]`, got["aws_instance.app"].Provider)
+	})
+
+	t.Run("re-key instance", func(t *testing.T) {
+		_, _, got := applyTestState(t, CreateOptions{
+			Kind:        MoveKind,
+			Address:     "aws_instance.web[1]",
+			Destination: internal.String(`aws_instance.web["b"]`),
+		})
+		require.Equal(t, 2, len(got["aws_instance.web"].Instances))
+		assert.Equal(t, "b", got["aws_instance.web"].Instances[1].IndexKey)
+	})
+
+	t.Run("remove instance", func(t *testing.T) {
+		_, _, got := applyTestState(t, CreateOptions{
+			Kind:    RemoveKind,
+			Address: "aws_instance.web[0]",
+		})
+		require.Equal(t, 1, len(got["aws_instance.web"].Instances))
+		assert.Equal(t, "i-1", got["aws_instance.web"].Instances[0].Attributes["id"])
+	})
+
+	t.Run("remove last instance removes resource", func(t *testing.T) {
+		_, _, got := applyTestState(t, CreateOptions{
+			Kind:    RemoveKind,
+			Address: "data.aws_ami.ubuntu",
+		})
+		assert.NotContains(t, got, "data.aws_ami.ubuntu")
+		assert.Equal(t, 2, len(got))
+	})
+
+	t.Run("taint resource", func(t *testing.T) {
+		_, _, got := applyTestState(t, CreateOptions{
+			Kind:    TaintKind,
+			Address: "aws_instance.web",
+		})
+		for _, inst := range got["aws_instance.web"].Instances {
+			assert.Equal(t, "tainted", inst.Status)
+		}
+	})
+
+	t.Run("taint instance", func(t *testing.T) {
+		_, _, got := applyTestState(t, CreateOptions{
+			Kind:    TaintKind,
+			Address: "aws_instance.web[1]",
+		})
+		assert.Equal(t, "", got["aws_instance.web"].Instances[0].Status)
+		assert.Equal(t, "tainted", got["aws_instance.web"].Instances[1].Status)
+	})
+
+	t.Run("preserve large numbers", func(t *testing.T) {
+		got, err := apply([]byte(testState), 4, CreateOptions{Kind: TaintKind, Address: "aws_instance.web"})
+		require.NoError(t, err)
+		assert.Contains(t, string(got), "12345678901234567890")
+	})
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CreateOptions
+		want error
+	}{
+		{"address not found", CreateOptions{Kind: RemoveKind, Address: "aws_instance.app"}, ErrAddressNotFound},
+		{"instance not found", CreateOptions{Kind: RemoveKind, Address: "aws_instance.web[2]"}, ErrAddressNotFound},
+		{"destination exists", CreateOptions{Kind: MoveKind, Address: "aws_instance.web[0]", Destination: internal.String("aws_instance.web[1]")}, ErrAddressAlreadyExists},
+		{"different type", CreateOptions{Kind: MoveKind, Address: "aws_instance.web", Destination: internal.String("aws_db_instance.web")}, ErrMoveTypeMismatch},
+		{"same address", CreateOptions{Kind: MoveKind, Address: "aws_instance.web", Destination: internal.String("aws_instance.web")}, ErrMoveToSameAddress},
+		{"resource to instance", CreateOptions{Kind: MoveKind, Address: "aws_instance.web", Destination: internal.String("aws_instance.app[0]")}, ErrMoveResourceToInstance},
+		{"taint data resource", CreateOptions{Kind: TaintKind, Address: "data.aws_ami.ubuntu"}, ErrTaintDataResource},
+		{"invalid address", CreateOptions{Kind: TaintKind, Address: "aws_instance"}, ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := apply([]byte(testState), 4, tt.opts)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestCreateOptions_Validate(t *testing.T) {
+	assert.NoError(t, CreateOptions{Kind: RemoveKind, Address: "a.b", Reason: "r"}.validate())
+	assert.Equal(t, ErrReasonRequired, CreateOptions{Kind: RemoveKind, Address: "a.b"}.validate())
+	assert.Equal(t, ErrDestinationRequired, CreateOptions{Kind: MoveKind, Address: "a.b", Reason: "r"}.validate())
+	assert.Equal(t, ErrInvalidOperationKind, CreateOptions{Kind: "import", Address: "a.b", Reason: "r"}.validate())
+}
@@ -0,0 +1,116 @@
+package stateop
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type webHandlers struct {
+	html.Renderer
+	workspace.WorkspaceService
+
+	svc Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/state-operations", h.list).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/state-operations", h.create).Methods("POST")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.GetWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ops, err := h.svc.ListStateOperations(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("state_operation_list.tmpl", w, struct {
+		workspace.WorkspacePage
+		Operations []*Operation
+		Kinds      []Kind
+	}{
+		WorkspacePage: workspace.NewPage(r, "state operations", ws),
+		Operations:    ops,
+		Kinds:         []Kind{MoveKind, RemoveKind, TaintKind},
+	})
+}
+
+func (h *webHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string  `schema:"workspace_id,required"`
+		Kind        Kind    `schema:"kind,required"`
+		Address     string  `schema:"address,required"`
+		Destination *string `schema:"destination"`
+		Reason      string  `schema:"reason"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	// the destination field is only relevant to moves
+	if params.Kind != MoveKind {
+		params.Destination = nil
+	}
+
+	op, err := h.svc.CreateStateOperation(r.Context(), params.WorkspaceID, CreateOptions{
+		Kind:        params.Kind,
+		Address:     params.Address,
+		Destination: params.Destination,
+		Reason:      params.Reason,
+	})
+	if isUserError(err) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.StateOperationsWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "created state version "+*op.StateVersionID)
+	http.Redirect(w, r, paths.StateOperationsWorkspace(params.WorkspaceID), http.StatusFound)
+}
+
+// isUserError determines whether the error is one the user can remedy, i.e.
+// invalid input or the workspace being locked.
+func isUserError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidAddress,
+		ErrAddressNotFound,
+		ErrAddressAlreadyExists,
+		ErrMoveTypeMismatch,
+		ErrMoveToSameAddress,
+		ErrMoveResourceToInstance,
+		ErrTaintDataResource,
+		ErrDestinationRequired,
+		ErrReasonRequired,
+		ErrInvalidOperationKind,
+		internal.ErrWorkspaceAlreadyLocked,
+		internal.ErrWorkspaceLockedByRun,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,7 @@ func (c *Client) CreateRunToken(ctx context.Context, opts CreateRunTokenOptions)
 	req, err := c.NewRequest("POST", "tokens/run/create", &types.CreateRunTokenOptions{
 		Organization: opts.Organization,
 		RunID:        opts.RunID,
+		WorkspaceID:  opts.WorkspaceID,
 	})
 	if err != nil {
 		return nil, err
@@ -52,6 +52,9 @@ type (
 // 2. If Google IAP header is present then authenticate its token and allow or deny
 // accordingly.
 // 3. If Bearer token is present then authenticate it and allow or deny accordingly.
+// Basic authentication is also accepted on http backend paths, because
+// terraform's http backend supports no other scheme, in which case the password
+// is authenticated as the token and the username is ignored.
 // 4. If requested path is for a UI endpoint then check for session cookie. If
 // present then authenticate its token. If cookie is missing or authentication fails
 // then redirect user to login page.
@@ -86,6 +89,12 @@ func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 					http.Error(w, err.Error(), http.StatusUnauthorized)
 					return
 				}
+			} else if _, password, ok := r.BasicAuth(); ok && isHTTPBackendPath(r.URL.Path) {
+				subject, err = mw.validateToken(ctx, password)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
 			} else if bearer := r.Header.Get("Authorization"); bearer != "" {
 				subject, err = mw.validateBearer(ctx, bearer)
 				if err != nil {
@@ -126,8 +135,10 @@ func (m *middleware) validateBearer(ctx context.Context, bearer string) (interna
 	if len(splitToken) != 2 {
 		return nil, fmt.Errorf("malformed bearer token")
 	}
-	token := splitToken[1]
+	return m.validateToken(ctx, splitToken[1])
+}
 
+func (m *middleware) validateToken(ctx context.Context, token string) (internal.Subject, error) {
 	if m.SiteToken != "" && m.SiteToken == token {
 		return &auth.SiteAdmin, nil
 	}
@@ -187,6 +198,10 @@ func (m *middleware) getOrCreateUser(ctx context.Context, username string) (inte
 	return user, err
 }
 
+func isHTTPBackendPath(path string) bool {
+	return strings.HasPrefix(path, otfhttp.APIPrefixV2) && strings.HasSuffix(path, otfhttp.HTTPBackendSuffix)
+}
+
 func isProtectedPath(path string) bool {
 	for _, prefix := range otfhttp.AuthenticatedPrefixes {
 		if strings.HasPrefix(path, prefix) {
@@ -71,6 +71,32 @@ func TestMiddleware(t *testing.T) {
 		assert.Equal(t, 200, w.Code, w.Body.String())
 	})
 
+	t.Run("valid user token using basic auth", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/workspaces/ws-123/http-backend", nil)
+		token := NewTestJWT(t, secret, userTokenKind, time.Hour)
+		r.SetBasicAuth("bobby", token)
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(wantSubjectHandler(t, &auth.User{})).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
+
+	t.Run("basic auth only accepted on http backend paths", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := NewTestJWT(t, secret, userTokenKind, time.Hour)
+		r.SetBasicAuth("bobby", token)
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+	})
+
+	t.Run("invalid basic auth", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/workspaces/ws-123/http-backend", nil)
+		r.SetBasicAuth("bobby", "incorrect")
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+	})
+
 	t.Run("valid agent token", func(t *testing.T) {
 		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
 		token := NewTestJWT(t, secret, agentTokenKind, time.Hour)
@@ -8,6 +8,7 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -20,11 +21,15 @@ type (
 	// retrieve the state of other workspaces when using `terraform_remote_state`.
 	RunToken struct {
 		Organization string
+		// WorkspaceID is the ID of the run's workspace; empty for tokens
+		// created without one.
+		WorkspaceID string
 	}
 
 	CreateRunTokenOptions struct {
 		Organization *string    // Organization of run. Required.
 		RunID        *string    // ID of run. Required.
+		WorkspaceID  *string    // ID of run's workspace. Optional.
 		Expiry       *time.Time // Override expiry. Optional.
 	}
 
@@ -38,7 +43,11 @@ func NewRunTokenFromJWT(token jwt.Token) (*RunToken, error) {
 	if !ok {
 		return nil, fmt.Errorf("missing claim: organization")
 	}
-	return &RunToken{Organization: org.(string)}, nil
+	rt := RunToken{Organization: org.(string)}
+	if workspaceID, ok := token.Get("workspace_id"); ok {
+		rt.WorkspaceID = workspaceID.(string)
+	}
+	return &rt, nil
 }
 
 func (t *RunToken) String() string { return "run-token" }
@@ -66,12 +75,17 @@ func (t *RunToken) CanAccessWorkspace(action rbac.Action, policy internal.Worksp
 	// run token is allowed the retrieve the state of the workspace only if:
 	// (a) workspace is in the same organization as run token
 	// (b) workspace has enabled global remote state (permitting organization-wide
-	// state sharing).
+	// state sharing), or it has explicitly permitted the run's workspace to
+	// consume its state.
 	switch action {
-	case rbac.GetWorkspaceAction, rbac.GetStateVersionAction, rbac.DownloadStateAction:
-		if t.Organization == policy.Organization && policy.GlobalRemoteState {
+	case rbac.GetWorkspaceAction, rbac.GetStateVersionAction, rbac.DownloadStateAction, rbac.GetStateVersionOutputAction:
+		if t.Organization != policy.Organization {
+			return false
+		}
+		if policy.GlobalRemoteState {
 			return true
 		}
+		return t.WorkspaceID != "" && slices.Contains(policy.RemoteStateConsumers, t.WorkspaceID)
 	}
 	return false
 }
@@ -94,14 +108,19 @@ func (a *service) CreateRunToken(ctx context.Context, opts CreateRunTokenOptions
 		expiry = *opts.Expiry
 	}
 
+	claims := map[string]string{
+		"organization": *opts.Organization,
+	}
+	if opts.WorkspaceID != nil {
+		claims["workspace_id"] = *opts.WorkspaceID
+	}
+
 	token, err := NewToken(NewTokenOptions{
 		key:     a.key,
 		Subject: *opts.RunID,
 		Kind:    runTokenKind,
 		Expiry:  &expiry,
-		Claims: map[string]string{
-			"organization": *opts.Organization,
-		},
+		Claims:  claims,
 	})
 	if err != nil {
 		return nil, err
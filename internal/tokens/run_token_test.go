@@ -0,0 +1,74 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunToken_CanAccessWorkspace(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  RunToken
+		action rbac.Action
+		policy internal.WorkspacePolicy
+		want   bool
+	}{
+		{
+			name:   "global remote state",
+			token:  RunToken{Organization: "acme-corp", WorkspaceID: "ws-consumer"},
+			action: rbac.DownloadStateAction,
+			policy: internal.WorkspacePolicy{Organization: "acme-corp", GlobalRemoteState: true},
+			want:   true,
+		},
+		{
+			name:   "permitted consumer",
+			token:  RunToken{Organization: "acme-corp", WorkspaceID: "ws-consumer"},
+			action: rbac.DownloadStateAction,
+			policy: internal.WorkspacePolicy{Organization: "acme-corp", RemoteStateConsumers: []string{"ws-consumer"}},
+			want:   true,
+		},
+		{
+			name:   "permitted consumer retrieving outputs",
+			token:  RunToken{Organization: "acme-corp", WorkspaceID: "ws-consumer"},
+			action: rbac.GetStateVersionOutputAction,
+			policy: internal.WorkspacePolicy{Organization: "acme-corp", RemoteStateConsumers: []string{"ws-consumer"}},
+			want:   true,
+		},
+		{
+			name:   "unpermitted consumer",
+			token:  RunToken{Organization: "acme-corp", WorkspaceID: "ws-other"},
+			action: rbac.DownloadStateAction,
+			policy: internal.WorkspacePolicy{Organization: "acme-corp", RemoteStateConsumers: []string{"ws-consumer"}},
+			want:   false,
+		},
+		{
+			name:   "token without workspace",
+			token:  RunToken{Organization: "acme-corp"},
+			action: rbac.DownloadStateAction,
+			policy: internal.WorkspacePolicy{Organization: "acme-corp", RemoteStateConsumers: []string{"ws-consumer"}},
+			want:   false,
+		},
+		{
+			name:   "different organization",
+			token:  RunToken{Organization: "acme-corp", WorkspaceID: "ws-consumer"},
+			action: rbac.DownloadStateAction,
+			policy: internal.WorkspacePolicy{Organization: "other-corp", GlobalRemoteState: true},
+			want:   false,
+		},
+		{
+			name:   "disallowed action",
+			token:  RunToken{Organization: "acme-corp", WorkspaceID: "ws-consumer"},
+			action: rbac.UpdateWorkspaceAction,
+			policy: internal.WorkspacePolicy{Organization: "acme-corp", GlobalRemoteState: true},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.token.CanAccessWorkspace(tt.action, tt.policy))
+		})
+	}
+}
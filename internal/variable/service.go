@@ -58,6 +58,9 @@ func NewService(opts Options) *service {
 		svc:      &svc,
 	}
 
+	// Copy variables to cloned workspaces
+	opts.WorkspaceService.AfterCloneWorkspace(svc.cloneVariables)
+
 	return &svc
 }
 
@@ -167,3 +170,32 @@ func (s *service) DeleteVariable(ctx context.Context, variableID string) (*Varia
 
 	return deleted, nil
 }
+
+// cloneVariables copies variables from a workspace to its clone. Sensitive and
+// non-sensitive variables are each only copied if requested.
+func (s *service) cloneVariables(ctx context.Context, event *workspace.CloneEvent) error {
+	if !event.Variables && !event.SensitiveVariables {
+		return nil
+	}
+	variables, err := s.ListVariables(ctx, event.Source.ID)
+	if err != nil {
+		return err
+	}
+	for _, v := range variables {
+		if v.Sensitive && !event.SensitiveVariables || !v.Sensitive && !event.Variables {
+			continue
+		}
+		_, err := s.CreateVariable(ctx, event.Clone.ID, CreateVariableOptions{
+			Key:         &v.Key,
+			Value:       &v.Value,
+			Description: &v.Description,
+			Category:    &v.Category,
+			Sensitive:   &v.Sensitive,
+			HCL:         &v.HCL,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
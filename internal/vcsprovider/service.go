@@ -34,6 +34,16 @@ type (
 		GetVCSClient(ctx context.Context, providerID string) (cloud.Client, error)
 
 		BeforeDeleteVCSProvider(l hooks.Listener[*VCSProvider])
+
+		// CreateUserMapping maps a user of the VCS provider to an OTF user or
+		// team.
+		CreateUserMapping(ctx context.Context, providerID string, opts CreateUserMappingOptions) (*UserMapping, error)
+		ListUserMappings(ctx context.Context, providerID string) ([]*UserMapping, error)
+		// GetUserMapping retrieves the mapping for the VCS user with the given
+		// username. Returns internal.ErrResourceNotFound if the VCS user is not
+		// mapped.
+		GetUserMapping(ctx context.Context, providerID, vcsUsername string) (*UserMapping, error)
+		DeleteUserMapping(ctx context.Context, mappingID string) (*UserMapping, error)
 	}
 
 	service struct {
@@ -183,3 +193,97 @@ func (a *service) DeleteVCSProvider(ctx context.Context, id string) (*VCSProvide
 	a.V(0).Info("deleted vcs provider", "provider", provider, "subject", subject)
 	return provider, nil
 }
+
+func (a *service) CreateUserMapping(ctx context.Context, providerID string, opts CreateUserMappingOptions) (*UserMapping, error) {
+	provider, err := a.db.get(ctx, providerID)
+	if err != nil {
+		a.Error(err, "retrieving vcs provider", "id", providerID)
+		return nil, err
+	}
+
+	subject, err := a.organization.CanAccess(ctx, rbac.CreateVCSUserMappingAction, provider.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := newUserMapping(providerID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.db.createUserMapping(ctx, mapping); err != nil {
+		a.Error(err, "creating vcs user mapping", "provider", provider, "vcs_user", opts.VCSUsername, "subject", subject)
+		return nil, err
+	}
+	a.V(0).Info("created vcs user mapping", "provider", provider, "vcs_user", mapping.VCSUsername, "mapped_to", mapping.MappedTo(), "subject", subject)
+	return mapping, nil
+}
+
+func (a *service) ListUserMappings(ctx context.Context, providerID string) ([]*UserMapping, error) {
+	provider, err := a.db.get(ctx, providerID)
+	if err != nil {
+		a.Error(err, "retrieving vcs provider", "id", providerID)
+		return nil, err
+	}
+
+	subject, err := a.organization.CanAccess(ctx, rbac.ListVCSUserMappingsAction, provider.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := a.db.listUserMappings(ctx, providerID)
+	if err != nil {
+		a.Error(err, "listing vcs user mappings", "provider", provider, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("listed vcs user mappings", "provider", provider, "subject", subject)
+	return mappings, nil
+}
+
+func (a *service) GetUserMapping(ctx context.Context, providerID, vcsUsername string) (*UserMapping, error) {
+	provider, err := a.db.get(ctx, providerID)
+	if err != nil {
+		a.Error(err, "retrieving vcs provider", "id", providerID)
+		return nil, err
+	}
+
+	subject, err := a.organization.CanAccess(ctx, rbac.ListVCSUserMappingsAction, provider.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := a.db.getUserMappingByVCSUsername(ctx, providerID, vcsUsername)
+	if err != nil {
+		a.Error(err, "retrieving vcs user mapping", "provider", provider, "vcs_user", vcsUsername, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("retrieved vcs user mapping", "provider", provider, "vcs_user", vcsUsername, "subject", subject)
+	return mapping, nil
+}
+
+func (a *service) DeleteUserMapping(ctx context.Context, mappingID string) (*UserMapping, error) {
+	// retrieve mapping and its provider first in order to get organization
+	// for authorization
+	mapping, err := a.db.getUserMapping(ctx, mappingID)
+	if err != nil {
+		a.Error(err, "retrieving vcs user mapping", "id", mappingID)
+		return nil, err
+	}
+	provider, err := a.db.get(ctx, mapping.VCSProviderID)
+	if err != nil {
+		a.Error(err, "retrieving vcs provider", "id", mapping.VCSProviderID)
+		return nil, err
+	}
+
+	subject, err := a.organization.CanAccess(ctx, rbac.DeleteVCSUserMappingAction, provider.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.db.deleteUserMapping(ctx, mappingID); err != nil {
+		a.Error(err, "deleting vcs user mapping", "mapping", mapping, "subject", subject)
+		return nil, err
+	}
+	a.V(0).Info("deleted vcs user mapping", "provider", provider, "vcs_user", mapping.VCSUsername, "subject", subject)
+	return mapping, nil
+}
@@ -41,3 +41,18 @@ func (f *fakeService) ListVCSProviders(context.Context, string) ([]*VCSProvider,
 func (f *fakeService) DeleteVCSProvider(context.Context, string) (*VCSProvider, error) {
 	return f.provider, nil
 }
+
+func (f *fakeService) GetVCSProvider(context.Context, string) (*VCSProvider, error) {
+	return f.provider, nil
+}
+
+func (f *fakeService) CreateUserMapping(ctx context.Context, providerID string, opts CreateUserMappingOptions) (*UserMapping, error) {
+	return newUserMapping(providerID, opts)
+}
+
+func (f *fakeService) ListUserMappings(ctx context.Context, providerID string) ([]*UserMapping, error) {
+	username := "bobby"
+	return []*UserMapping{
+		{ID: "vum-123", VCSProviderID: providerID, VCSUsername: "bob", Username: &username},
+	}, nil
+}
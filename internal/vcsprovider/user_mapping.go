@@ -0,0 +1,61 @@
+package vcsprovider
+
+import (
+	"errors"
+	"time"
+
+	"github.com/leg100/otf/internal"
+)
+
+var ErrInvalidUserMapping = errors.New("vcs user must be mapped to either a user or a team")
+
+type (
+	// UserMapping maps a user of a VCS provider to an OTF user or team. Actions
+	// that the VCS user triggers via the VCS provider, such as applying a pull
+	// request from a comment, are authorized as the OTF user, or as a member
+	// of the OTF team.
+	UserMapping struct {
+		ID            string
+		CreatedAt     time.Time
+		VCSProviderID string
+		VCSUsername   string
+
+		// Exactly one of Username or TeamID is non-nil.
+		Username *string
+		TeamID   *string
+		Team     *string // name of team
+	}
+
+	CreateUserMappingOptions struct {
+		VCSUsername string
+		// Map to either a user or to a team belonging to the VCS provider's
+		// organization.
+		Username *string
+		Team     *string // name of team
+	}
+)
+
+func newUserMapping(providerID string, opts CreateUserMappingOptions) (*UserMapping, error) {
+	if opts.VCSUsername == "" {
+		return nil, &internal.MissingParameterError{Parameter: "vcs_username"}
+	}
+	if (opts.Username == nil) == (opts.Team == nil) {
+		return nil, ErrInvalidUserMapping
+	}
+	return &UserMapping{
+		ID:            internal.NewID("vum"),
+		CreatedAt:     internal.CurrentTimestamp(),
+		VCSProviderID: providerID,
+		VCSUsername:   opts.VCSUsername,
+		Username:      opts.Username,
+		Team:          opts.Team,
+	}, nil
+}
+
+// MappedTo describes the user or team to which the VCS user is mapped.
+func (m *UserMapping) MappedTo() string {
+	if m.Username != nil {
+		return "user " + *m.Username
+	}
+	return "team " + *m.Team
+}
@@ -0,0 +1,84 @@
+package vcsprovider
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// userMappingRow is a database row for a vcs user mapping
+type userMappingRow struct {
+	VCSUserMappingID pgtype.Text        `json:"vcs_user_mapping_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	VCSProviderID    pgtype.Text        `json:"vcs_provider_id"`
+	VCSUsername      pgtype.Text        `json:"vcs_username"`
+	Username         pgtype.Text        `json:"username"`
+	TeamID           pgtype.Text        `json:"team_id"`
+	TeamName         pgtype.Text        `json:"team_name"`
+}
+
+func (r userMappingRow) toUserMapping() *UserMapping {
+	mapping := &UserMapping{
+		ID:            r.VCSUserMappingID.String,
+		CreatedAt:     r.CreatedAt.Time.UTC(),
+		VCSProviderID: r.VCSProviderID.String,
+		VCSUsername:   r.VCSUsername.String,
+	}
+	if r.Username.Status == pgtype.Present {
+		mapping.Username = &r.Username.String
+	}
+	if r.TeamID.Status == pgtype.Present {
+		mapping.TeamID = &r.TeamID.String
+		mapping.Team = &r.TeamName.String
+	}
+	return mapping
+}
+
+// createUserMapping persists the mapping, returning ErrResourceNotFound if
+// the user or team does not exist.
+func (db *pgdb) createUserMapping(ctx context.Context, mapping *UserMapping) error {
+	_, err := db.Conn(ctx).InsertVCSUserMapping(ctx, pggen.InsertVCSUserMappingParams{
+		VCSUserMappingID: sql.String(mapping.ID),
+		CreatedAt:        sql.Timestamptz(mapping.CreatedAt),
+		VCSProviderID:    sql.String(mapping.VCSProviderID),
+		VCSUsername:      sql.String(mapping.VCSUsername),
+		Username:         sql.StringPtr(mapping.Username),
+		TeamName:         sql.StringPtr(mapping.Team),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getUserMapping(ctx context.Context, mappingID string) (*UserMapping, error) {
+	row, err := db.Conn(ctx).FindVCSUserMappingByID(ctx, sql.String(mappingID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return userMappingRow(row).toUserMapping(), nil
+}
+
+func (db *pgdb) getUserMappingByVCSUsername(ctx context.Context, providerID, vcsUsername string) (*UserMapping, error) {
+	row, err := db.Conn(ctx).FindVCSUserMappingByVCSUsername(ctx, sql.String(providerID), sql.String(vcsUsername))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return userMappingRow(row).toUserMapping(), nil
+}
+
+func (db *pgdb) listUserMappings(ctx context.Context, providerID string) ([]*UserMapping, error) {
+	rows, err := db.Conn(ctx).FindVCSUserMappings(ctx, sql.String(providerID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	mappings := make([]*UserMapping, len(rows))
+	for i, r := range rows {
+		mappings[i] = userMappingRow(r).toUserMapping()
+	}
+	return mappings, nil
+}
+
+func (db *pgdb) deleteUserMapping(ctx context.Context, mappingID string) error {
+	_, err := db.Conn(ctx).DeleteVCSUserMappingByID(ctx, sql.String(mappingID))
+	return sql.Error(err)
+}
@@ -0,0 +1,50 @@
+package vcsprovider
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateUserMappingOptions
+		want    string
+		wantErr error
+	}{
+		{
+			name: "map to user",
+			opts: CreateUserMappingOptions{VCSUsername: "bob", Username: internal.String("bobby")},
+			want: "user bobby",
+		},
+		{
+			name: "map to team",
+			opts: CreateUserMappingOptions{VCSUsername: "bob", Team: internal.String("devops")},
+			want: "team devops",
+		},
+		{
+			name:    "map to both user and team",
+			opts:    CreateUserMappingOptions{VCSUsername: "bob", Username: internal.String("bobby"), Team: internal.String("devops")},
+			wantErr: ErrInvalidUserMapping,
+		},
+		{
+			name:    "map to neither user nor team",
+			opts:    CreateUserMappingOptions{VCSUsername: "bob"},
+			wantErr: ErrInvalidUserMapping,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newUserMapping("vcs-123", tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.MappedTo())
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package vcsprovider
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/cloud"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/http/html"
@@ -26,6 +28,10 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/organizations/{organization_name}/vcs-providers/new", h.new)
 	r.HandleFunc("/organizations/{organization_name}/vcs-providers/create", h.create)
 	r.HandleFunc("/vcs-providers/{vcs_provider_id}/delete", h.delete)
+
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/vcs-user-mappings", h.listUserMappings)
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/vcs-user-mappings/create", h.createUserMapping)
+	r.HandleFunc("/vcs-user-mappings/{vcs_user_mapping_id}/delete", h.deleteUserMapping)
 }
 
 func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
@@ -113,3 +119,88 @@ func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
 	html.FlashSuccess(w, "deleted provider: "+provider.Name)
 	http.Redirect(w, r, paths.VCSProviders(provider.Organization), http.StatusFound)
 }
+
+func (h *webHandlers) listUserMappings(w http.ResponseWriter, r *http.Request) {
+	providerID, err := decode.Param("vcs_provider_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	provider, err := h.svc.GetVCSProvider(r.Context(), providerID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	mappings, err := h.svc.ListUserMappings(r.Context(), providerID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("vcs_user_mapping_list.tmpl", w, struct {
+		organization.OrganizationPage
+		VCSProvider *VCSProvider
+		Items       []*UserMapping
+	}{
+		OrganizationPage: organization.NewPage(r, "vcs user mappings", provider.Organization),
+		VCSProvider:      provider,
+		Items:            mappings,
+	})
+}
+
+func (h *webHandlers) createUserMapping(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ProviderID  string `schema:"vcs_provider_id,required"`
+		VCSUsername string `schema:"vcs_username,required"`
+		Kind        string `schema:"kind,required"`
+		Name        string `schema:"name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	opts := CreateUserMappingOptions{VCSUsername: params.VCSUsername}
+	switch params.Kind {
+	case "user":
+		opts.Username = &params.Name
+	case "team":
+		opts.Team = &params.Name
+	default:
+		h.Error(w, "kind must be either user or team", http.StatusUnprocessableEntity)
+		return
+	}
+
+	mapping, err := h.svc.CreateUserMapping(r.Context(), params.ProviderID, opts)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		html.FlashError(w, fmt.Sprintf("no such %s: %s", params.Kind, params.Name))
+		http.Redirect(w, r, paths.VCSUserMappings(params.ProviderID), http.StatusFound)
+		return
+	} else if errors.Is(err, internal.ErrResourceAlreadyExists) {
+		html.FlashError(w, "vcs user is already mapped: "+params.VCSUsername)
+		http.Redirect(w, r, paths.VCSUserMappings(params.ProviderID), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, fmt.Sprintf("mapped vcs user %s to %s", mapping.VCSUsername, mapping.MappedTo()))
+	http.Redirect(w, r, paths.VCSUserMappings(params.ProviderID), http.StatusFound)
+}
+
+func (h *webHandlers) deleteUserMapping(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("vcs_user_mapping_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	mapping, err := h.svc.DeleteUserMapping(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "deleted mapping for vcs user: "+mapping.VCSUsername)
+	http.Redirect(w, r, paths.VCSUserMappings(mapping.VCSProviderID), http.StatusFound)
+}
@@ -83,6 +83,56 @@ func TestDeleteVCSProvidersHandler(t *testing.T) {
 	assert.Equal(t, 302, w.Code)
 }
 
+func TestListVCSUserMappingsHandler(t *testing.T) {
+	org := organization.NewTestOrganization(t)
+	provider := newTestVCSProvider(t, org)
+	app := fakeWebServices(t, provider)
+
+	r := httptest.NewRequest("GET", "/?vcs_provider_id="+provider.ID, nil)
+	w := httptest.NewRecorder()
+	app.listUserMappings(w, r)
+
+	assert.Equal(t, 200, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "user bobby")
+}
+
+func TestCreateVCSUserMappingHandler(t *testing.T) {
+	org := organization.NewTestOrganization(t)
+	provider := newTestVCSProvider(t, org)
+	app := fakeWebServices(t, provider)
+
+	tests := []struct {
+		name string
+		kind string
+		want int
+	}{
+		{"map to user", "user", 302},
+		{"map to team", "team", 302},
+		{"invalid kind", "robot", 422},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := strings.NewReader(url.Values{
+				"vcs_provider_id": {provider.ID},
+				"vcs_username":    {"bob"},
+				"kind":            {tt.kind},
+				"name":            {"bobby"},
+			}.Encode())
+
+			r := httptest.NewRequest("POST", "/?", form)
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			app.createUserMapping(w, r)
+
+			if assert.Equal(t, tt.want, w.Code, w.Body.String()) && tt.want == 302 {
+				redirect, err := w.Result().Location()
+				require.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("/app/vcs-providers/%s/vcs-user-mappings", provider.ID), redirect.Path)
+			}
+		})
+	}
+}
+
 func fakeWebServices(t *testing.T, provider *VCSProvider) *webHandlers {
 	renderer, err := html.NewRenderer(false)
 	require.NoError(t, err)
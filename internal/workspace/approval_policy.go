@@ -0,0 +1,106 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/rbac"
+	"golang.org/x/exp/slices"
+)
+
+var ErrInvalidApprovalsRequired = errors.New("number of required approvals cannot be negative")
+
+type (
+	// ApprovalPolicy determines the approvals a run requires before it can be
+	// applied.
+	ApprovalPolicy struct {
+		WorkspaceID string
+		// Number of approvals required before a run can be applied. Zero means
+		// no approvals are required.
+		Required int
+		// Names of teams whose members are permitted to approve runs. If empty
+		// then anyone permitted to apply runs can approve runs.
+		Teams []string
+		// Prohibit the user that created a run from approving it.
+		ExcludeAuthor bool
+	}
+
+	SetApprovalPolicyOptions struct {
+		Required      *int
+		Teams         []string
+		ExcludeAuthor *bool
+	}
+
+	ApprovalPolicyService interface {
+		// GetApprovalPolicy retrieves a workspace's approval policy. If no
+		// policy has been set then a policy requiring no approvals is
+		// returned.
+		GetApprovalPolicy(ctx context.Context, workspaceID string) (*ApprovalPolicy, error)
+		// SetApprovalPolicy sets a workspace's approval policy. Teams, if
+		// non-nil, replaces the teams permitted to approve runs.
+		SetApprovalPolicy(ctx context.Context, workspaceID string, opts SetApprovalPolicyOptions) (*ApprovalPolicy, error)
+	}
+)
+
+func (p *ApprovalPolicy) update(opts SetApprovalPolicyOptions) error {
+	if opts.Required != nil {
+		if *opts.Required < 0 {
+			return ErrInvalidApprovalsRequired
+		}
+		p.Required = *opts.Required
+	}
+	if opts.Teams != nil {
+		p.Teams = opts.Teams
+	}
+	if opts.ExcludeAuthor != nil {
+		p.ExcludeAuthor = *opts.ExcludeAuthor
+	}
+	return nil
+}
+
+// IsApprover determines whether the user is permitted by the policy to approve
+// a run in the given organization.
+func (p *ApprovalPolicy) IsApprover(user *auth.User, organization string) bool {
+	if len(p.Teams) == 0 {
+		return true
+	}
+	for _, team := range user.Teams {
+		if team.Organization == organization && slices.Contains(p.Teams, team.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) GetApprovalPolicy(ctx context.Context, workspaceID string) (*ApprovalPolicy, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.db.getApprovalPolicy(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "retrieving approval policy", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved approval policy", "workspace", workspaceID, "subject", subject)
+	return policy, nil
+}
+
+func (s *service) SetApprovalPolicy(ctx context.Context, workspaceID string, opts SetApprovalPolicyOptions) (*ApprovalPolicy, error) {
+	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.db.setApprovalPolicy(ctx, workspaceID, func(policy *ApprovalPolicy) error {
+		return policy.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "setting approval policy", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("set approval policy", "workspace", workspaceID, "required", policy.Required, "teams", policy.Teams, "subject", subject)
+	return policy, nil
+}
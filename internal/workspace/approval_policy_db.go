@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+func (db *pgdb) getApprovalPolicy(ctx context.Context, workspaceID string) (*ApprovalPolicy, error) {
+	// ensure workspace exists, returning not found if not
+	if _, err := db.Conn(ctx).FindWorkspaceByID(ctx, sql.String(workspaceID)); err != nil {
+		return nil, sql.Error(err)
+	}
+	return findApprovalPolicy(ctx, db.Conn(ctx), workspaceID)
+}
+
+func (db *pgdb) setApprovalPolicy(ctx context.Context, workspaceID string, fn func(*ApprovalPolicy) error) (*ApprovalPolicy, error) {
+	var policy *ApprovalPolicy
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.FindWorkspaceByIDForUpdate(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		var err error
+		policy, err = findApprovalPolicy(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		if err := fn(policy); err != nil {
+			return err
+		}
+		_, err = q.UpsertWorkspaceApprovalPolicy(ctx, pggen.UpsertWorkspaceApprovalPolicyParams{
+			WorkspaceID:       sql.String(workspaceID),
+			ApprovalsRequired: sql.Int4(policy.Required),
+			ExcludeAuthor:     policy.ExcludeAuthor,
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.DeleteWorkspaceApprovalTeams(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		for _, team := range policy.Teams {
+			result, err := q.InsertWorkspaceApprovalTeam(ctx, sql.String(team), sql.String(workspaceID))
+			if err != nil {
+				return sql.Error(err)
+			}
+			if result.RowsAffected() == 0 {
+				// team does not exist in workspace's organization
+				return internal.ErrResourceNotFound
+			}
+		}
+		return nil
+	})
+	return policy, err
+}
+
+func findApprovalPolicy(ctx context.Context, q pggen.Querier, workspaceID string) (*ApprovalPolicy, error) {
+	result, err := q.FindWorkspaceApprovalPolicy(ctx, sql.String(workspaceID))
+	if err != nil {
+		if sql.NoRowsInResultError(err) {
+			return &ApprovalPolicy{WorkspaceID: workspaceID}, nil
+		}
+		return nil, sql.Error(err)
+	}
+	return &ApprovalPolicy{
+		WorkspaceID:   workspaceID,
+		Required:      int(result.ApprovalsRequired.Int),
+		Teams:         result.TeamNames,
+		ExcludeAuthor: result.ExcludeAuthor,
+	}, nil
+}
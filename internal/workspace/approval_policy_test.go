@@ -0,0 +1,38 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalPolicy_Update(t *testing.T) {
+	policy := &ApprovalPolicy{
+		Required: 1,
+		Teams:    []string{"devops"},
+	}
+
+	t.Run("negative approvals", func(t *testing.T) {
+		err := policy.update(SetApprovalPolicyOptions{Required: internal.Int(-1)})
+		assert.Equal(t, ErrInvalidApprovalsRequired, err)
+	})
+
+	t.Run("nil teams leaves teams untouched", func(t *testing.T) {
+		err := policy.update(SetApprovalPolicyOptions{Required: internal.Int(2)})
+		require.NoError(t, err)
+		assert.Equal(t, 2, policy.Required)
+		assert.Equal(t, []string{"devops"}, policy.Teams)
+	})
+
+	t.Run("empty teams clears teams", func(t *testing.T) {
+		err := policy.update(SetApprovalPolicyOptions{
+			Teams:         []string{},
+			ExcludeAuthor: internal.Bool(true),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, policy.Teams)
+		assert.True(t, policy.ExcludeAuthor)
+	})
+}
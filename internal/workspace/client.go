@@ -97,9 +97,38 @@ func (c *Client) UpdateWorkspace(ctx context.Context, workspaceID string, option
 	return unmarshalJSONAPI(w), nil
 }
 
-func (c *Client) LockWorkspace(ctx context.Context, workspaceID string, runID *string) (*Workspace, error) {
+// CloneWorkspace creates a new workspace from an existing workspace.
+func (c *Client) CloneWorkspace(ctx context.Context, workspaceID string, opts CloneOptions) (*Workspace, error) {
+	path := fmt.Sprintf("workspaces/%s/actions/clone", workspaceID)
+	params := types.WorkspaceCloneOptions{
+		Name:               opts.Name,
+		Variables:          opts.Variables,
+		SensitiveVariables: opts.SensitiveVariables,
+		Permissions:        opts.Permissions,
+		Notifications:      opts.Notifications,
+		Tags:               opts.Tags,
+	}
+	req, err := c.NewRequest("POST", path, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &types.Workspace{}
+	err = c.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONAPI(w), nil
+}
+
+func (c *Client) LockWorkspace(ctx context.Context, workspaceID string, runID *string, opts LockOptions) (*Workspace, error) {
 	path := fmt.Sprintf("workspaces/%s/actions/lock", workspaceID)
-	req, err := c.NewRequest("POST", path, nil)
+	params := types.WorkspaceLockOptions{ExpiresAt: opts.ExpiresAt}
+	if opts.Reason != "" {
+		params.Reason = &opts.Reason
+	}
+	req, err := c.NewRequest("POST", path, &params)
 	if err != nil {
 		return nil, err
 	}
@@ -133,3 +162,37 @@ func (c *Client) UnlockWorkspace(ctx context.Context, workspaceID string, runID
 
 	return unmarshalJSONAPI(w), nil
 }
+
+func (c *Client) ListWorkspaceLockEvents(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*LockEvent], error) {
+	u := fmt.Sprintf("workspaces/%s/lock-events", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("GET", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &types.WorkspaceLockEventList{}
+	err = c.Do(ctx, req, list)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &resource.Page[*LockEvent]{
+		Pagination: (*resource.Pagination)(list.Pagination),
+	}
+	for _, from := range list.Items {
+		event := &LockEvent{
+			ID:          from.ID,
+			CreatedAt:   from.CreatedAt,
+			WorkspaceID: workspaceID,
+			Action:      LockEventAction(from.Action),
+			Holder:      from.Holder,
+			Actor:       from.Actor,
+			Reason:      from.Reason,
+		}
+		if from.LockKind == RunLock.String() {
+			event.Kind = RunLock
+		}
+		page.Items = append(page.Items, event)
+	}
+	return page, nil
+}
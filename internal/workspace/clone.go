@@ -0,0 +1,200 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/hooks"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+type (
+	// CloneOptions are options for cloning a workspace. A clone always
+	// inherits the settings, VCS connection, approval policy and run tasks
+	// of the workspace from which it is cloned; the remaining options select
+	// what else is copied.
+	// Copying sensitive variables, team permissions or notification
+	// configurations, which contain secrets such as tokens, requires admin
+	// access to the workspace from which the clone is cloned.
+	CloneOptions struct {
+		// Name of the new workspace. Required.
+		Name *string
+		// Variables copies non-sensitive variables.
+		Variables bool
+		// SensitiveVariables copies sensitive variables.
+		SensitiveVariables bool
+		// Permissions copies team permissions.
+		Permissions bool
+		// Notifications copies notification configurations.
+		Notifications bool
+		// Tags copies tags.
+		Tags bool
+		// Description overrides the description of the workspace from which
+		// the clone is cloned.
+		Description *string
+		// Branch overrides the branch tracked by the clone's VCS connection.
+		Branch *string
+		// Preview, if non-nil, makes the clone a preview of a pull request.
+		// A preview automatically applies changes and permits destroy plans,
+		// regardless of the settings of the workspace from which it is
+		// cloned.
+		Preview *Preview
+	}
+
+	// CloneEvent is dispatched to listeners when a workspace is cloned, so
+	// that resources belonging to other services can be copied to the clone.
+	CloneEvent struct {
+		Source *Workspace
+		Clone  *Workspace
+
+		CloneOptions
+	}
+
+	CloneService interface {
+		// CloneWorkspace creates a new workspace from an existing workspace.
+		CloneWorkspace(ctx context.Context, workspaceID string, opts CloneOptions) (*Workspace, error)
+		// AfterCloneWorkspace registers a listener that is invoked after a
+		// workspace is cloned, within the same transaction.
+		AfterCloneWorkspace(l hooks.Listener[*CloneEvent])
+	}
+)
+
+func (s *service) AfterCloneWorkspace(l hooks.Listener[*CloneEvent]) {
+	s.cloneHook.After(l)
+}
+
+func (s *service) CloneWorkspace(ctx context.Context, workspaceID string, opts CloneOptions) (*Workspace, error) {
+	var subject internal.Subject
+	for _, action := range opts.actions() {
+		var err error
+		subject, err = s.CanAccess(ctx, action, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := s.db.get(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "retrieving workspace to clone", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+
+	event := &CloneEvent{Source: source, CloneOptions: opts}
+	err = s.cloneHook.Dispatch(ctx, event, func(ctx context.Context) error {
+		clone, err := s.CreateWorkspace(ctx, source.cloneCreateOptions(opts))
+		if err != nil {
+			return err
+		}
+		// the approval policy gates applies, so the clone is always subject
+		// to the same policy.
+		policy, err := s.db.getApprovalPolicy(ctx, source.ID)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.setApprovalPolicy(ctx, clone.ID, func(p *ApprovalPolicy) error {
+			return p.update(SetApprovalPolicyOptions{
+				Required:      &policy.Required,
+				Teams:         policy.Teams,
+				ExcludeAuthor: &policy.ExcludeAuthor,
+			})
+		})
+		if err != nil {
+			return err
+		}
+		if opts.Permissions {
+			policy, err := s.GetPolicy(ctx, source.ID)
+			if err != nil {
+				return err
+			}
+			for _, perm := range policy.Permissions {
+				if err := s.SetPermission(ctx, clone.ID, perm.Team, perm.Role); err != nil {
+					return err
+				}
+			}
+		}
+		event.Clone = clone
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "cloning workspace", "source", source.ID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("cloned workspace", "source", source.ID, "workspace", event.Clone.ID, "subject", subject)
+	return event.Clone, nil
+}
+
+// actions returns the actions a subject must be permitted to carry out on the
+// workspace from which a clone is cloned in order to copy what the options
+// select.
+func (opts CloneOptions) actions() []rbac.Action {
+	actions := []rbac.Action{rbac.GetWorkspaceAction}
+	if opts.Variables || opts.SensitiveVariables {
+		actions = append(actions, rbac.ListVariablesAction)
+	}
+	if opts.Notifications {
+		actions = append(actions, rbac.ListNotificationConfigurationsAction)
+	}
+	if opts.SensitiveVariables || opts.Permissions || opts.Notifications {
+		actions = append(actions, rbac.UpdateWorkspaceAction)
+	}
+	return actions
+}
+
+// cloneCreateOptions returns options for creating a clone of the workspace.
+// Settings specific to the workspace, such as a scheduled destroy or its use
+// as a template, are not copied.
+func (ws *Workspace) cloneCreateOptions(opts CloneOptions) CreateOptions {
+	createOpts := CreateOptions{
+		Name:                        opts.Name,
+		Organization:                &ws.Organization,
+		ProjectID:                   &ws.ProjectID,
+		Description:                 &ws.Description,
+		AllowDestroyPlan:            &ws.AllowDestroyPlan,
+		AutoApply:                   &ws.AutoApply,
+		ExecutionMode:               &ws.ExecutionMode,
+		GlobalRemoteState:           &ws.GlobalRemoteState,
+		QueueAllRuns:                &ws.QueueAllRuns,
+		SpeculativeEnabled:          &ws.SpeculativeEnabled,
+		StructuredRunOutputEnabled:  &ws.StructuredRunOutputEnabled,
+		TerraformVersion:            &ws.TerraformVersion,
+		TriggerPatterns:             ws.TriggerPatterns,
+		WorkingDirectory:            &ws.WorkingDirectory,
+		PlanTimeout:                 &ws.PlanTimeout,
+		ApplyTimeout:                &ws.ApplyTimeout,
+		AutoDiscardTTL:              &ws.AutoDiscardTTL,
+		SupersedeRuns:               &ws.SupersedeRuns,
+		AutoDestroyActivityDuration: &ws.AutoDestroyActivityDuration,
+		AutoApplyDestroy:            &ws.AutoApplyDestroy,
+	}
+	if opts.Description != nil {
+		createOpts.Description = opts.Description
+	}
+	if opts.Preview != nil {
+		createOpts.Preview = opts.Preview
+		createOpts.AutoApply = internal.Bool(true)
+		createOpts.AllowDestroyPlan = internal.Bool(true)
+	}
+	if opts.Tags {
+		createOpts.Tags = make([]TagSpec, len(ws.Tags))
+		for i, name := range ws.Tags {
+			createOpts.Tags[i] = TagSpec{Name: name}
+		}
+	}
+	if ws.Connection != nil {
+		createOpts.ConnectOptions = &ConnectOptions{
+			RepoPath:      &ws.Connection.Repo,
+			VCSProviderID: &ws.Connection.VCSProviderID,
+			Branch:        &ws.Connection.Branch,
+			AllowCLIApply: &ws.Connection.AllowCLIApply,
+			PRComments:    &ws.Connection.PRComments,
+			PRApply:       &ws.Connection.PRApply,
+		}
+		if ws.Connection.TagsRegex != "" {
+			createOpts.ConnectOptions.TagsRegex = &ws.Connection.TagsRegex
+		}
+		if opts.Branch != nil {
+			createOpts.ConnectOptions.Branch = opts.Branch
+		}
+	}
+	return createOpts
+}
@@ -0,0 +1,108 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspace_cloneCreateOptions(t *testing.T) {
+	source, err := NewWorkspace(CreateOptions{
+		Name:             internal.String("dev"),
+		Organization:     internal.String("acme-corp"),
+		AutoApply:        internal.Bool(true),
+		WorkingDirectory: internal.String("envs/dev"),
+		Template:         internal.Bool(true),
+	})
+	require.NoError(t, err)
+	source.Tags = []string{"foo", "bar"}
+	source.Connection = &Connection{
+		VCSProviderID: "vcs-123",
+		Repo:          "leg100/otf",
+		Branch:        "main",
+	}
+
+	t.Run("settings and connection", func(t *testing.T) {
+		clone, err := NewWorkspace(source.cloneCreateOptions(CloneOptions{
+			Name: internal.String("staging"),
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "staging", clone.Name)
+		assert.Equal(t, "acme-corp", clone.Organization)
+		assert.True(t, clone.AutoApply)
+		assert.Equal(t, "envs/dev", clone.WorkingDirectory)
+		assert.False(t, clone.Template)
+		assert.Empty(t, clone.Tags)
+		assert.NotEqual(t, source.ID, clone.ID)
+	})
+
+	t.Run("with tags", func(t *testing.T) {
+		opts := source.cloneCreateOptions(CloneOptions{
+			Name: internal.String("staging"),
+			Tags: true,
+		})
+		assert.Equal(t, []TagSpec{{Name: "foo"}, {Name: "bar"}}, opts.Tags)
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		opts := source.cloneCreateOptions(CloneOptions{
+			Name:        internal.String("dev-pr-7"),
+			Description: internal.String("Preview of pull request #7"),
+			Branch:      internal.String("feature"),
+			Preview:     &Preview{TemplateID: source.ID, PullRequest: 7},
+		})
+		assert.Equal(t, "Preview of pull request #7", *opts.Description)
+		assert.Equal(t, "feature", *opts.ConnectOptions.Branch)
+		assert.Equal(t, "leg100/otf", *opts.ConnectOptions.RepoPath)
+		assert.Equal(t, &Preview{TemplateID: source.ID, PullRequest: 7}, opts.Preview)
+		assert.True(t, *opts.AutoApply)
+		assert.True(t, *opts.AllowDestroyPlan)
+	})
+}
+
+func TestCloneOptions_actions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CloneOptions
+		want []rbac.Action
+	}{
+		{
+			name: "settings only",
+			want: []rbac.Action{rbac.GetWorkspaceAction},
+		},
+		{
+			name: "tags",
+			opts: CloneOptions{Tags: true},
+			want: []rbac.Action{rbac.GetWorkspaceAction},
+		},
+		{
+			name: "variables",
+			opts: CloneOptions{Variables: true},
+			want: []rbac.Action{rbac.GetWorkspaceAction, rbac.ListVariablesAction},
+		},
+		{
+			name: "sensitive variables",
+			opts: CloneOptions{SensitiveVariables: true},
+			want: []rbac.Action{rbac.GetWorkspaceAction, rbac.ListVariablesAction, rbac.UpdateWorkspaceAction},
+		},
+		{
+			name: "permissions",
+			opts: CloneOptions{Permissions: true},
+			want: []rbac.Action{rbac.GetWorkspaceAction, rbac.UpdateWorkspaceAction},
+		},
+		{
+			name: "notifications",
+			opts: CloneOptions{Notifications: true},
+			want: []rbac.Action{rbac.GetWorkspaceAction, rbac.ListNotificationConfigurationsAction, rbac.UpdateWorkspaceAction},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.actions())
+		})
+	}
+}
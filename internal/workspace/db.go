@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
@@ -20,74 +21,116 @@ type (
 
 	// pgresult represents the result of a database query for a workspace.
 	pgresult struct {
-		WorkspaceID                pgtype.Text            `json:"workspace_id"`
-		CreatedAt                  pgtype.Timestamptz     `json:"created_at"`
-		UpdatedAt                  pgtype.Timestamptz     `json:"updated_at"`
-		AllowDestroyPlan           bool                   `json:"allow_destroy_plan"`
-		AutoApply                  bool                   `json:"auto_apply"`
-		CanQueueDestroyPlan        bool                   `json:"can_queue_destroy_plan"`
-		Description                pgtype.Text            `json:"description"`
-		Environment                pgtype.Text            `json:"environment"`
-		ExecutionMode              pgtype.Text            `json:"execution_mode"`
-		GlobalRemoteState          bool                   `json:"global_remote_state"`
-		MigrationEnvironment       pgtype.Text            `json:"migration_environment"`
-		Name                       pgtype.Text            `json:"name"`
-		QueueAllRuns               bool                   `json:"queue_all_runs"`
-		SpeculativeEnabled         bool                   `json:"speculative_enabled"`
-		SourceName                 pgtype.Text            `json:"source_name"`
-		SourceURL                  pgtype.Text            `json:"source_url"`
-		StructuredRunOutputEnabled bool                   `json:"structured_run_output_enabled"`
-		TerraformVersion           pgtype.Text            `json:"terraform_version"`
-		TriggerPrefixes            []string               `json:"trigger_prefixes"`
-		WorkingDirectory           pgtype.Text            `json:"working_directory"`
-		LockRunID                  pgtype.Text            `json:"lock_run_id"`
-		LatestRunID                pgtype.Text            `json:"latest_run_id"`
-		OrganizationName           pgtype.Text            `json:"organization_name"`
-		Branch                     pgtype.Text            `json:"branch"`
-		LockUsername               pgtype.Text            `json:"lock_username"`
-		CurrentStateVersionID      pgtype.Text            `json:"current_state_version_id"`
-		TriggerPatterns            []string               `json:"trigger_patterns"`
-		VCSTagsRegex               pgtype.Text            `json:"vcs_tags_regex"`
-		AllowCLIApply              bool                   `json:"allow_cli_apply"`
-		Tags                       []string               `json:"tags"`
-		LatestRunStatus            pgtype.Text            `json:"latest_run_status"`
-		UserLock                   *pggen.Users           `json:"user_lock"`
-		RunLock                    *pggen.Runs            `json:"run_lock"`
-		WorkspaceConnection        *pggen.RepoConnections `json:"workspace_connection"`
-		Webhook                    *pggen.Webhooks        `json:"webhook"`
+		WorkspaceID                 pgtype.Text            `json:"workspace_id"`
+		CreatedAt                   pgtype.Timestamptz     `json:"created_at"`
+		UpdatedAt                   pgtype.Timestamptz     `json:"updated_at"`
+		AllowDestroyPlan            bool                   `json:"allow_destroy_plan"`
+		AutoApply                   bool                   `json:"auto_apply"`
+		CanQueueDestroyPlan         bool                   `json:"can_queue_destroy_plan"`
+		Description                 pgtype.Text            `json:"description"`
+		Environment                 pgtype.Text            `json:"environment"`
+		ExecutionMode               pgtype.Text            `json:"execution_mode"`
+		GlobalRemoteState           bool                   `json:"global_remote_state"`
+		MigrationEnvironment        pgtype.Text            `json:"migration_environment"`
+		Name                        pgtype.Text            `json:"name"`
+		QueueAllRuns                bool                   `json:"queue_all_runs"`
+		SpeculativeEnabled          bool                   `json:"speculative_enabled"`
+		SourceName                  pgtype.Text            `json:"source_name"`
+		SourceURL                   pgtype.Text            `json:"source_url"`
+		StructuredRunOutputEnabled  bool                   `json:"structured_run_output_enabled"`
+		TerraformVersion            pgtype.Text            `json:"terraform_version"`
+		TriggerPrefixes             []string               `json:"trigger_prefixes"`
+		WorkingDirectory            pgtype.Text            `json:"working_directory"`
+		LockRunID                   pgtype.Text            `json:"lock_run_id"`
+		LatestRunID                 pgtype.Text            `json:"latest_run_id"`
+		OrganizationName            pgtype.Text            `json:"organization_name"`
+		Branch                      pgtype.Text            `json:"branch"`
+		LockUsername                pgtype.Text            `json:"lock_username"`
+		CurrentStateVersionID       pgtype.Text            `json:"current_state_version_id"`
+		TriggerPatterns             []string               `json:"trigger_patterns"`
+		VCSTagsRegex                pgtype.Text            `json:"vcs_tags_regex"`
+		AllowCLIApply               bool                   `json:"allow_cli_apply"`
+		PlanTimeout                 pgtype.Int4            `json:"plan_timeout"`
+		ApplyTimeout                pgtype.Int4            `json:"apply_timeout"`
+		AutoDiscardTTL              pgtype.Int4            `json:"auto_discard_ttl"`
+		SupersedeRuns               bool                   `json:"supersede_runs"`
+		PRComments                  bool                   `json:"pr_comments"`
+		PRApply                     bool                   `json:"pr_apply"`
+		PreviewTemplate             bool                   `json:"preview_template"`
+		MaxPreviews                 pgtype.Int4            `json:"max_previews"`
+		PreviewTemplateID           pgtype.Text            `json:"preview_template_id"`
+		PreviewPullRequest          pgtype.Int4            `json:"preview_pull_request"`
+		AutoDestroyAt               pgtype.Timestamptz     `json:"auto_destroy_at"`
+		AutoDestroyActivityDuration pgtype.Int4            `json:"auto_destroy_activity_duration"`
+		AutoApplyDestroy            bool                   `json:"auto_apply_destroy"`
+		ProjectID                   pgtype.Text            `json:"project_id"`
+		LockReason                  pgtype.Text            `json:"lock_reason"`
+		LockedAt                    pgtype.Timestamptz     `json:"locked_at"`
+		LockExpiresAt               pgtype.Timestamptz     `json:"lock_expires_at"`
+		Template                    bool                   `json:"template"`
+		LockExternalID              pgtype.Text            `json:"lock_external_id"`
+		Tags                        []string               `json:"tags"`
+		LatestRunStatus             pgtype.Text            `json:"latest_run_status"`
+		UserLock                    *pggen.Users           `json:"user_lock"`
+		RunLock                     *pggen.Runs            `json:"run_lock"`
+		WorkspaceConnection         *pggen.RepoConnections `json:"workspace_connection"`
+		Webhook                     *pggen.Webhooks        `json:"webhook"`
 	}
 )
 
 func (r pgresult) toWorkspace() (*Workspace, error) {
 	ws := Workspace{
-		ID:                         r.WorkspaceID.String,
-		CreatedAt:                  r.CreatedAt.Time.UTC(),
-		UpdatedAt:                  r.UpdatedAt.Time.UTC(),
-		AllowDestroyPlan:           r.AllowDestroyPlan,
-		AutoApply:                  r.AutoApply,
-		CanQueueDestroyPlan:        r.CanQueueDestroyPlan,
-		Description:                r.Description.String,
-		Environment:                r.Environment.String,
-		ExecutionMode:              ExecutionMode(r.ExecutionMode.String),
-		GlobalRemoteState:          r.GlobalRemoteState,
-		MigrationEnvironment:       r.MigrationEnvironment.String,
-		Name:                       r.Name.String,
-		QueueAllRuns:               r.QueueAllRuns,
-		SpeculativeEnabled:         r.SpeculativeEnabled,
-		StructuredRunOutputEnabled: r.StructuredRunOutputEnabled,
-		SourceName:                 r.SourceName.String,
-		SourceURL:                  r.SourceURL.String,
-		TerraformVersion:           r.TerraformVersion.String,
-		TriggerPrefixes:            r.TriggerPrefixes,
-		TriggerPatterns:            r.TriggerPatterns,
-		WorkingDirectory:           r.WorkingDirectory.String,
-		Organization:               r.OrganizationName.String,
-		Tags:                       r.Tags,
+		ID:                          r.WorkspaceID.String,
+		CreatedAt:                   r.CreatedAt.Time.UTC(),
+		UpdatedAt:                   r.UpdatedAt.Time.UTC(),
+		AllowDestroyPlan:            r.AllowDestroyPlan,
+		AutoApply:                   r.AutoApply,
+		CanQueueDestroyPlan:         r.CanQueueDestroyPlan,
+		Description:                 r.Description.String,
+		Environment:                 r.Environment.String,
+		ExecutionMode:               ExecutionMode(r.ExecutionMode.String),
+		GlobalRemoteState:           r.GlobalRemoteState,
+		MigrationEnvironment:        r.MigrationEnvironment.String,
+		Name:                        r.Name.String,
+		QueueAllRuns:                r.QueueAllRuns,
+		SpeculativeEnabled:          r.SpeculativeEnabled,
+		StructuredRunOutputEnabled:  r.StructuredRunOutputEnabled,
+		SourceName:                  r.SourceName.String,
+		SourceURL:                   r.SourceURL.String,
+		TerraformVersion:            r.TerraformVersion.String,
+		TriggerPrefixes:             r.TriggerPrefixes,
+		TriggerPatterns:             r.TriggerPatterns,
+		WorkingDirectory:            r.WorkingDirectory.String,
+		Organization:                r.OrganizationName.String,
+		ProjectID:                   r.ProjectID.String,
+		Tags:                        r.Tags,
+		PlanTimeout:                 time.Duration(r.PlanTimeout.Int) * time.Second,
+		ApplyTimeout:                time.Duration(r.ApplyTimeout.Int) * time.Second,
+		AutoDiscardTTL:              time.Duration(r.AutoDiscardTTL.Int) * time.Second,
+		SupersedeRuns:               r.SupersedeRuns,
+		PreviewTemplate:             r.PreviewTemplate,
+		MaxPreviews:                 int(r.MaxPreviews.Int),
+		AutoDestroyActivityDuration: time.Duration(r.AutoDestroyActivityDuration.Int) * time.Second,
+		AutoApplyDestroy:            r.AutoApplyDestroy,
+		Template:                    r.Template,
+	}
+
+	if r.AutoDestroyAt.Status == pgtype.Present {
+		ws.AutoDestroyAt = internal.Time(r.AutoDestroyAt.Time.UTC())
+	}
+
+	if r.PreviewPullRequest.Status == pgtype.Present {
+		ws.Preview = &Preview{
+			TemplateID:  r.PreviewTemplateID.String,
+			PullRequest: int(r.PreviewPullRequest.Int),
+		}
 	}
 
 	if r.WorkspaceConnection != nil {
 		ws.Connection = &Connection{
 			AllowCLIApply: r.AllowCLIApply,
+			PRComments:    r.PRComments,
+			PRApply:       r.PRApply,
 			VCSProviderID: r.Webhook.VCSProviderID.String,
 			Repo:          r.Webhook.Identifier.String,
 			Branch:        r.Branch.String,
@@ -115,40 +158,70 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 			LockKind: RunLock,
 		}
 	}
+	if ws.Lock != nil {
+		ws.Lock.Reason = r.LockReason.String
+		ws.Lock.ExternalID = r.LockExternalID.String
+		ws.Lock.LockedAt = r.LockedAt.Time.UTC()
+		if r.LockExpiresAt.Status == pgtype.Present {
+			ws.Lock.ExpiresAt = internal.Time(r.LockExpiresAt.Time.UTC())
+		}
+	}
 
 	return &ws, nil
 }
 
 func (db *pgdb) create(ctx context.Context, ws *Workspace) error {
 	q := db.Conn(ctx)
+	if err := setProject(ctx, q, ws); err != nil {
+		return err
+	}
 	params := pggen.InsertWorkspaceParams{
-		ID:                         sql.String(ws.ID),
-		CreatedAt:                  sql.Timestamptz(ws.CreatedAt),
-		UpdatedAt:                  sql.Timestamptz(ws.UpdatedAt),
-		Name:                       sql.String(ws.Name),
-		AllowDestroyPlan:           ws.AllowDestroyPlan,
-		AutoApply:                  ws.AutoApply,
-		CanQueueDestroyPlan:        ws.CanQueueDestroyPlan,
-		Environment:                sql.String(ws.Environment),
-		Description:                sql.String(ws.Description),
-		ExecutionMode:              sql.String(string(ws.ExecutionMode)),
-		GlobalRemoteState:          ws.GlobalRemoteState,
-		MigrationEnvironment:       sql.String(ws.MigrationEnvironment),
-		SourceName:                 sql.String(ws.SourceName),
-		SourceURL:                  sql.String(ws.SourceURL),
-		SpeculativeEnabled:         ws.SpeculativeEnabled,
-		StructuredRunOutputEnabled: ws.StructuredRunOutputEnabled,
-		TerraformVersion:           sql.String(ws.TerraformVersion),
-		TriggerPrefixes:            ws.TriggerPrefixes,
-		TriggerPatterns:            ws.TriggerPatterns,
-		QueueAllRuns:               ws.QueueAllRuns,
-		WorkingDirectory:           sql.String(ws.WorkingDirectory),
-		OrganizationName:           sql.String(ws.Organization),
-		Branch:                     sql.String(""),
-		VCSTagsRegex:               sql.StringPtr(nil),
+		ID:                          sql.String(ws.ID),
+		CreatedAt:                   sql.Timestamptz(ws.CreatedAt),
+		UpdatedAt:                   sql.Timestamptz(ws.UpdatedAt),
+		Name:                        sql.String(ws.Name),
+		AllowDestroyPlan:            ws.AllowDestroyPlan,
+		AutoApply:                   ws.AutoApply,
+		CanQueueDestroyPlan:         ws.CanQueueDestroyPlan,
+		Environment:                 sql.String(ws.Environment),
+		Description:                 sql.String(ws.Description),
+		ExecutionMode:               sql.String(string(ws.ExecutionMode)),
+		GlobalRemoteState:           ws.GlobalRemoteState,
+		MigrationEnvironment:        sql.String(ws.MigrationEnvironment),
+		SourceName:                  sql.String(ws.SourceName),
+		SourceURL:                   sql.String(ws.SourceURL),
+		SpeculativeEnabled:          ws.SpeculativeEnabled,
+		StructuredRunOutputEnabled:  ws.StructuredRunOutputEnabled,
+		TerraformVersion:            sql.String(ws.TerraformVersion),
+		TriggerPrefixes:             ws.TriggerPrefixes,
+		TriggerPatterns:             ws.TriggerPatterns,
+		QueueAllRuns:                ws.QueueAllRuns,
+		WorkingDirectory:            sql.String(ws.WorkingDirectory),
+		OrganizationName:            sql.String(ws.Organization),
+		PlanTimeout:                 sql.Int4(int(ws.PlanTimeout.Seconds())),
+		ApplyTimeout:                sql.Int4(int(ws.ApplyTimeout.Seconds())),
+		AutoDiscardTTL:              sql.Int4(int(ws.AutoDiscardTTL.Seconds())),
+		SupersedeRuns:               ws.SupersedeRuns,
+		PreviewTemplate:             ws.PreviewTemplate,
+		MaxPreviews:                 sql.Int4(ws.MaxPreviews),
+		AutoDestroyAt:               sql.TimestamptzPtr(ws.AutoDestroyAt),
+		AutoDestroyActivityDuration: sql.Int4(int(ws.AutoDestroyActivityDuration.Seconds())),
+		AutoApplyDestroy:            ws.AutoApplyDestroy,
+		ProjectID:                   sql.String(ws.ProjectID),
+		Template:                    ws.Template,
+		PreviewTemplateID:           sql.NullString(),
+		PreviewPullRequest:          sql.Int4Ptr(nil),
+		Branch:                      sql.String(""),
+		VCSTagsRegex:                sql.StringPtr(nil),
+	}
+	if ws.Preview != nil {
+		params.PreviewTemplateID = sql.String(ws.Preview.TemplateID)
+		params.PreviewPullRequest = sql.Int4(ws.Preview.PullRequest)
 	}
 	if ws.Connection != nil {
 		params.AllowCLIApply = ws.Connection.AllowCLIApply
+		params.PRComments = ws.Connection.PRComments
+		params.PRApply = ws.Connection.PRApply
 		params.Branch = sql.String(ws.Connection.Branch)
 		params.VCSTagsRegex = sql.String(ws.Connection.TagsRegex)
 	}
@@ -170,31 +243,50 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 			return err
 		}
 		// update workspace
+		project := ws.ProjectID
 		if err := fn(ws); err != nil {
 			return err
 		}
+		if ws.ProjectID != project {
+			if err := setProject(ctx, q, ws); err != nil {
+				return err
+			}
+		}
 		// persist update
 		params := pggen.UpdateWorkspaceByIDParams{
-			ID:                         sql.String(ws.ID),
-			UpdatedAt:                  sql.Timestamptz(ws.UpdatedAt),
-			AllowDestroyPlan:           ws.AllowDestroyPlan,
-			AutoApply:                  ws.AutoApply,
-			Description:                sql.String(ws.Description),
-			ExecutionMode:              sql.String(string(ws.ExecutionMode)),
-			GlobalRemoteState:          ws.GlobalRemoteState,
-			Name:                       sql.String(ws.Name),
-			QueueAllRuns:               ws.QueueAllRuns,
-			SpeculativeEnabled:         ws.SpeculativeEnabled,
-			StructuredRunOutputEnabled: ws.StructuredRunOutputEnabled,
-			TerraformVersion:           sql.String(ws.TerraformVersion),
-			TriggerPrefixes:            ws.TriggerPrefixes,
-			TriggerPatterns:            ws.TriggerPatterns,
-			WorkingDirectory:           sql.String(ws.WorkingDirectory),
-			Branch:                     sql.String(""),
-			VCSTagsRegex:               sql.StringPtr(nil),
+			ID:                          sql.String(ws.ID),
+			UpdatedAt:                   sql.Timestamptz(ws.UpdatedAt),
+			AllowDestroyPlan:            ws.AllowDestroyPlan,
+			AutoApply:                   ws.AutoApply,
+			Description:                 sql.String(ws.Description),
+			ExecutionMode:               sql.String(string(ws.ExecutionMode)),
+			GlobalRemoteState:           ws.GlobalRemoteState,
+			Name:                        sql.String(ws.Name),
+			QueueAllRuns:                ws.QueueAllRuns,
+			SpeculativeEnabled:          ws.SpeculativeEnabled,
+			StructuredRunOutputEnabled:  ws.StructuredRunOutputEnabled,
+			TerraformVersion:            sql.String(ws.TerraformVersion),
+			TriggerPrefixes:             ws.TriggerPrefixes,
+			TriggerPatterns:             ws.TriggerPatterns,
+			WorkingDirectory:            sql.String(ws.WorkingDirectory),
+			PlanTimeout:                 sql.Int4(int(ws.PlanTimeout.Seconds())),
+			ApplyTimeout:                sql.Int4(int(ws.ApplyTimeout.Seconds())),
+			AutoDiscardTTL:              sql.Int4(int(ws.AutoDiscardTTL.Seconds())),
+			SupersedeRuns:               ws.SupersedeRuns,
+			PreviewTemplate:             ws.PreviewTemplate,
+			MaxPreviews:                 sql.Int4(ws.MaxPreviews),
+			AutoDestroyAt:               sql.TimestamptzPtr(ws.AutoDestroyAt),
+			AutoDestroyActivityDuration: sql.Int4(int(ws.AutoDestroyActivityDuration.Seconds())),
+			AutoApplyDestroy:            ws.AutoApplyDestroy,
+			ProjectID:                   sql.String(ws.ProjectID),
+			Template:                    ws.Template,
+			Branch:                      sql.String(""),
+			VCSTagsRegex:                sql.StringPtr(nil),
 		}
 		if ws.Connection != nil {
 			params.AllowCLIApply = ws.Connection.AllowCLIApply
+			params.PRComments = ws.Connection.PRComments
+			params.PRApply = ws.Connection.PRApply
 			params.Branch = sql.String(ws.Connection.Branch)
 			params.VCSTagsRegex = sql.String(ws.Connection.TagsRegex)
 		}
@@ -204,6 +296,28 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 	return ws, err
 }
 
+// setProject assigns the workspace to its organization's default project if it
+// has not been assigned one, otherwise it checks the assigned project belongs
+// to the workspace's organization.
+func setProject(ctx context.Context, q pggen.Querier, ws *Workspace) error {
+	if ws.ProjectID == "" {
+		project, err := q.FindDefaultProject(ctx, sql.String(ws.Organization))
+		if err != nil {
+			return sql.Error(err)
+		}
+		ws.ProjectID = project.ProjectID.String
+		return nil
+	}
+	project, err := q.FindProjectByID(ctx, sql.String(ws.ProjectID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	if project.OrganizationName.String != ws.Organization {
+		return internal.ErrResourceNotFound
+	}
+	return nil
+}
+
 // setCurrentRun sets the ID of the current run for the specified workspace.
 func (db *pgdb) setCurrentRun(ctx context.Context, workspaceID, runID string) (*Workspace, error) {
 	q := db.Conn(ctx)
@@ -224,6 +338,11 @@ func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Wor
 	if opts.Organization != nil {
 		organization = *opts.Organization
 	}
+	// Likewise, the project filter is optional.
+	project := "%"
+	if opts.ProjectID != nil {
+		project = *opts.ProjectID
+	}
 	tags := []string{}
 	if len(opts.Tags) > 0 {
 		tags = opts.Tags
@@ -231,15 +350,19 @@ func (db *pgdb) list(ctx context.Context, opts ListOptions) (*resource.Page[*Wor
 
 	q.FindWorkspacesBatch(batch, pggen.FindWorkspacesParams{
 		OrganizationNames: []string{organization},
+		ProjectID:         sql.String(project),
 		Search:            sql.String(opts.Search),
 		Tags:              tags,
+		TemplatesOnly:     opts.TemplatesOnly,
 		Limit:             opts.GetLimit(),
 		Offset:            opts.GetOffset(),
 	})
 	q.CountWorkspacesBatch(batch, pggen.CountWorkspacesParams{
 		Search:            sql.String(opts.Search),
 		OrganizationNames: []string{organization},
+		ProjectID:         sql.String(project),
 		Tags:              tags,
+		TemplatesOnly:     opts.TemplatesOnly,
 	})
 	results := db.SendBatch(ctx, batch)
 	defer results.Close()
@@ -284,17 +407,27 @@ func (db *pgdb) listByWebhookID(ctx context.Context, id uuid.UUID) ([]*Workspace
 	return items, nil
 }
 
-func (db *pgdb) listByUsername(ctx context.Context, username string, organization string, opts resource.PageOptions) (*resource.Page[*Workspace], error) {
+func (db *pgdb) listByUsername(ctx context.Context, username string, organization string, opts ListOptions) (*resource.Page[*Workspace], error) {
 	q := db.Conn(ctx)
 	batch := &pgx.Batch{}
 
+	project := "%"
+	if opts.ProjectID != nil {
+		project = *opts.ProjectID
+	}
+
 	q.FindWorkspacesByUsernameBatch(batch, pggen.FindWorkspacesByUsernameParams{
 		OrganizationName: sql.String(organization),
+		ProjectID:        sql.String(project),
 		Username:         sql.String(username),
 		Limit:            opts.GetLimit(),
 		Offset:           opts.GetOffset(),
 	})
-	q.CountWorkspacesByUsernameBatch(batch, sql.String(organization), sql.String(username))
+	q.CountWorkspacesByUsernameBatch(batch, pggen.CountWorkspacesByUsernameParams{
+		OrganizationName: sql.String(organization),
+		ProjectID:        sql.String(project),
+		Username:         sql.String(username),
+	})
 	results := db.SendBatch(ctx, batch)
 	defer results.Close()
 
@@ -316,7 +449,7 @@ func (db *pgdb) listByUsername(ctx context.Context, username string, organizatio
 		items = append(items, ws)
 	}
 
-	return resource.NewPage(items, opts, internal.Int64(count.Int)), nil
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
 }
 
 func (db *pgdb) get(ctx context.Context, workspaceID string) (*Workspace, error) {
@@ -1,6 +1,8 @@
 package workspace
 
 import (
+	"time"
+
 	"github.com/leg100/otf/internal/api/types"
 	"github.com/leg100/otf/internal/resource"
 )
@@ -29,6 +31,17 @@ func unmarshalJSONAPI(w *types.Workspace) *Workspace {
 		TriggerPrefixes:            w.TriggerPrefixes,
 		TriggerPatterns:            w.TriggerPatterns,
 		Organization:               w.Organization.Name,
+		PlanTimeout:                time.Duration(w.PlanTimeout) * time.Second,
+		ApplyTimeout:               time.Duration(w.ApplyTimeout) * time.Second,
+		AutoDiscardTTL:             time.Duration(w.AutoDiscardTTL) * time.Second,
+		SupersedeRuns:              w.SupersedeRuns,
+		AutoDestroyAt:              w.AutoDestroyAt,
+		AutoApplyDestroy:           w.AutoApplyDestroy,
+		Template:                   w.Template,
+	}
+
+	if w.Project != nil {
+		domain.ProjectID = w.Project.ID
 	}
 
 	// The DTO only encodes whether lock is unlocked or locked, whereas our
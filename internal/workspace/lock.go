@@ -1,6 +1,9 @@
 package workspace
 
 import (
+	"errors"
+	"time"
+
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/rbac"
@@ -11,6 +14,8 @@ const (
 	RunLock
 )
 
+var ErrInvalidLockExpiry = errors.New("lock expiry must be in the future and can only be set on a user lock")
+
 type (
 	// Lock is a workspace Lock, which blocks runs from running and prevents state from being
 	// uploaded.
@@ -19,6 +24,30 @@ type (
 	Lock struct {
 		id       string // ID of entity holding lock
 		LockKind        // kind of entity holding lock
+
+		// Reason is an optional explanation for the lock.
+		Reason string
+		// LockedAt is the time at which the lock was acquired.
+		LockedAt time.Time
+		// ExpiresAt is the time after which a user lock is released
+		// automatically; nil if the lock does not expire.
+		ExpiresAt *time.Time
+		// ExternalID is an optional identifier assigned to the lock by the
+		// client that acquired it, e.g. the ID terraform assigns to a state
+		// lock.
+		ExternalID string
+	}
+
+	// LockOptions are options for locking a workspace.
+	LockOptions struct {
+		// Reason is an optional explanation for the lock.
+		Reason string
+		// ExpiresAt optionally sets a time after which the lock is released
+		// automatically. Only applicable to user locks.
+		ExpiresAt *time.Time
+		// ExternalID optionally sets an identifier assigned to the lock by
+		// the client acquiring it.
+		ExternalID string
 	}
 
 	// kind of entity holding a lock
@@ -34,24 +63,61 @@ type (
 	}
 )
 
+func (k LockKind) String() string {
+	switch k {
+	case UserLock:
+		return "user"
+	case RunLock:
+		return "run"
+	default:
+		return "unknown"
+	}
+}
+
+// Holder is the ID of the entity holding the lock, i.e. a username or a run
+// ID.
+func (l *Lock) Holder() string {
+	return l.id
+}
+
+// Expired determines whether the lock has expired as of now.
+func (l *Lock) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !l.ExpiresAt.After(now)
+}
+
 // Locked determines whether workspace is locked.
 func (ws *Workspace) Locked() bool {
 	// a nil receiver means the lock is unlocked
 	return ws.Lock != nil
 }
 
+// LockedBy determines whether workspace is locked by the given subject.
+func (ws *Workspace) LockedBy(subject internal.Subject) bool {
+	return ws.Locked() && ws.Lock.LockKind == UserLock && ws.Lock.id == subject.String()
+}
+
 // Enlock locks the workspace
-func (ws *Workspace) Enlock(id string, kind LockKind) error {
-	if ws.Lock == nil {
-		ws.Lock = &Lock{
-			id:       id,
-			LockKind: kind,
+func (ws *Workspace) Enlock(id string, kind LockKind, opts LockOptions) error {
+	if opts.ExpiresAt != nil {
+		if kind != UserLock || !opts.ExpiresAt.After(internal.CurrentTimestamp()) {
+			return ErrInvalidLockExpiry
 		}
+	}
+	lock := &Lock{
+		id:         id,
+		LockKind:   kind,
+		Reason:     opts.Reason,
+		LockedAt:   internal.CurrentTimestamp(),
+		ExpiresAt:  opts.ExpiresAt,
+		ExternalID: opts.ExternalID,
+	}
+	if ws.Lock == nil {
+		ws.Lock = lock
 		return nil
 	}
 	// a run can replace another run holding a lock
 	if kind == RunLock {
-		ws.Lock.id = id
+		ws.Lock = lock
 		return nil
 	}
 	return internal.ErrWorkspaceAlreadyLocked
@@ -106,10 +172,13 @@ func lockButtonHelper(ws *Workspace, policy internal.WorkspacePolicy, user inter
 		default:
 			btn.Message = "locked by unknown entity: " + ws.Lock.id
 		}
+		if ws.Lock.Reason != "" {
+			btn.Message += " (" + ws.Lock.Reason + ")"
+		}
 		// also show message as button tooltip
 		btn.Tooltip = btn.Message
 		// A user can unlock their own lock
-		if ws.Lock.LockKind == UserLock && ws.Lock.id == user.String() {
+		if ws.LockedBy(user) {
 			return btn
 		}
 		// User is going to need the force unlock permission
@@ -2,15 +2,49 @@ package workspace
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
 )
 
-// toggleLock toggles the workspace lock state in the DB.
-func (db *pgdb) toggleLock(ctx context.Context, workspaceID string, togglefn func(*Workspace) error) (*Workspace, error) {
+// lockEventResult is the result of a database query for workspace lock events
+type lockEventResult struct {
+	WorkspaceLockEventID pgtype.Text        `json:"workspace_lock_event_id"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	Action               pgtype.Text        `json:"action"`
+	LockKind             pgtype.Text        `json:"lock_kind"`
+	Holder               pgtype.Text        `json:"holder"`
+	Actor                pgtype.Text        `json:"actor"`
+	Reason               pgtype.Text        `json:"reason"`
+	WorkspaceID          pgtype.Text        `json:"workspace_id"`
+}
+
+func (r lockEventResult) toLockEvent() *LockEvent {
+	event := &LockEvent{
+		ID:          r.WorkspaceLockEventID.String,
+		CreatedAt:   r.CreatedAt.Time.UTC(),
+		WorkspaceID: r.WorkspaceID.String,
+		Action:      LockEventAction(r.Action.String),
+		Holder:      r.Holder.String,
+		Reason:      r.Reason.String,
+	}
+	if r.LockKind.String == RunLock.String() {
+		event.Kind = RunLock
+	}
+	if r.Actor.Status == pgtype.Present {
+		event.Actor = &r.Actor.String
+	}
+	return event
+}
+
+// toggleLock toggles the workspace lock state in the DB, recording the action
+// performed by the actor in the workspace's lock history.
+func (db *pgdb) toggleLock(ctx context.Context, workspaceID string, actor *string, action LockEventAction, togglefn func(*Workspace) error) (*Workspace, error) {
 	var ws *Workspace
 	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		// retrieve workspace
@@ -22,30 +56,115 @@ func (db *pgdb) toggleLock(ctx context.Context, workspaceID string, togglefn fun
 		if err != nil {
 			return err
 		}
+		before := ws.Lock
 		if err := togglefn(ws); err != nil {
 			return err
 		}
 		// persist to db
 		params := pggen.UpdateWorkspaceLockByIDParams{
 			WorkspaceID: pgtype.Text{String: ws.ID, Status: pgtype.Present},
+			RunID:       pgtype.Text{Status: pgtype.Null},
+			Username:    pgtype.Text{Status: pgtype.Null},
+			Reason:      pgtype.Text{Status: pgtype.Null},
+			LockedAt:    pgtype.Timestamptz{Status: pgtype.Null},
+			ExpiresAt:   pgtype.Timestamptz{Status: pgtype.Null},
+			ExternalID:  pgtype.Text{Status: pgtype.Null},
 		}
-		if ws.Lock == nil {
-			params.RunID = pgtype.Text{Status: pgtype.Null}
-			params.Username = pgtype.Text{Status: pgtype.Null}
-		} else if ws.Lock.LockKind == RunLock {
-			params.RunID = pgtype.Text{String: ws.Lock.id, Status: pgtype.Present}
-			params.Username = pgtype.Text{Status: pgtype.Null}
-		} else if ws.Lock.LockKind == UserLock {
-			params.Username = pgtype.Text{String: ws.Lock.id, Status: pgtype.Present}
-			params.RunID = pgtype.Text{Status: pgtype.Null}
-		} else {
-			return internal.ErrWorkspaceInvalidLock
+		if ws.Lock != nil {
+			switch ws.Lock.LockKind {
+			case RunLock:
+				params.RunID = sql.String(ws.Lock.id)
+			case UserLock:
+				params.Username = sql.String(ws.Lock.id)
+			default:
+				return internal.ErrWorkspaceInvalidLock
+			}
+			if ws.Lock.Reason != "" {
+				params.Reason = sql.String(ws.Lock.Reason)
+			}
+			if ws.Lock.ExternalID != "" {
+				params.ExternalID = sql.String(ws.Lock.ExternalID)
+			}
+			params.LockedAt = sql.Timestamptz(ws.Lock.LockedAt)
+			params.ExpiresAt = sql.TimestamptzPtr(ws.Lock.ExpiresAt)
 		}
 		_, err = q.UpdateWorkspaceLockByID(ctx, params)
 		if err != nil {
 			return sql.Error(err)
 		}
-		return nil
+		// record event, describing the lock acquired or, when unlocking, the
+		// lock released.
+		lock := ws.Lock
+		if lock == nil {
+			lock = before
+		}
+		reason := sql.NullString()
+		if lock.Reason != "" {
+			reason = sql.String(lock.Reason)
+		}
+		_, err = q.InsertWorkspaceLockEvent(ctx, pggen.InsertWorkspaceLockEventParams{
+			WorkspaceLockEventID: sql.String(internal.NewID("wle")),
+			CreatedAt:            sql.Timestamptz(internal.CurrentTimestamp()),
+			Action:               sql.String(string(action)),
+			LockKind:             sql.String(lock.LockKind.String()),
+			Holder:               sql.String(lock.id),
+			Actor:                sql.StringPtr(actor),
+			Reason:               reason,
+			WorkspaceID:          sql.String(ws.ID),
+		})
+		return sql.Error(err)
 	})
 	return ws, err
 }
+
+// listExpiredLocks lists the IDs of workspaces with locks that have expired
+// as of now.
+func (db *pgdb) listExpiredLocks(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceIDsWithExpiredLocks(ctx, sql.Timestamptz(now))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
+
+func (db *pgdb) getLockEvent(ctx context.Context, eventID string) (*LockEvent, error) {
+	result, err := db.Conn(ctx).FindWorkspaceLockEventByID(ctx, sql.String(eventID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return lockEventResult(result).toLockEvent(), nil
+}
+
+func (db *pgdb) listLockEvents(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*LockEvent], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	q.FindWorkspaceLockEventsByWorkspaceIDBatch(batch, pggen.FindWorkspaceLockEventsByWorkspaceIDParams{
+		WorkspaceID: sql.String(workspaceID),
+		Limit:       opts.GetLimit(),
+		Offset:      opts.GetOffset(),
+	})
+	q.CountWorkspaceLockEventsByWorkspaceIDBatch(batch, sql.String(workspaceID))
+
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindWorkspaceLockEventsByWorkspaceIDScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountWorkspaceLockEventsByWorkspaceIDScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*LockEvent, len(rows))
+	for i, r := range rows {
+		items[i] = lockEventResult(r).toLockEvent()
+	}
+	return resource.NewPage(items, opts, internal.Int64(count.Int)), nil
+}
@@ -0,0 +1,53 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/leg100/otf/internal/pubsub"
+)
+
+const (
+	LockEventLocked        LockEventAction = "locked"
+	LockEventUnlocked      LockEventAction = "unlocked"
+	LockEventForceUnlocked LockEventAction = "force_unlocked"
+	// LockEventExpired is recorded when a user lock is released automatically
+	// upon reaching its expiry.
+	LockEventExpired LockEventAction = "expired"
+)
+
+type (
+	// LockEvent records a change to a workspace's lock.
+	LockEvent struct {
+		ID          string
+		CreatedAt   time.Time
+		WorkspaceID string
+		Action      LockEventAction
+		// Kind is the kind of entity holding the lock.
+		Kind LockKind
+		// Holder is the ID of the entity holding the lock, i.e. a username or a
+		// run ID.
+		Holder string
+		// Actor is the ID of the entity that performed the action; nil if the
+		// lock expired.
+		Actor *string
+		// Reason is the reason given for the lock.
+		Reason string
+	}
+
+	// LockEventAction is the action performed on a workspace lock.
+	LockEventAction string
+)
+
+// ByRun determines whether the event is a run acquiring or releasing its own
+// lock, as opposed to an action performed by a user.
+func (e *LockEvent) ByRun() bool {
+	return e.Kind == RunLock && (e.Action == LockEventLocked || e.Action == LockEventUnlocked)
+}
+
+func (s *service) getLockEventByID(ctx context.Context, id string, action pubsub.DBAction) (any, error) {
+	if action == pubsub.DeleteDBAction {
+		return &LockEvent{ID: id}, nil
+	}
+	return s.db.getLockEvent(ctx, id)
+}
@@ -0,0 +1,85 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/sql"
+)
+
+// LockExpirerLockID is a unique ID guaranteeing only one lock expirer on a
+// cluster is running at any time.
+const LockExpirerLockID int64 = 179366396344335604
+
+// lockExpiryInterval is the interval between checks for expired locks.
+const lockExpiryInterval = time.Minute
+
+// errLockNotExpired is returned when attempting to expire a lock that has not
+// expired, i.e. it has since been released, replaced or extended.
+var errLockNotExpired = errors.New("lock has not expired")
+
+type (
+	// LockExpirer releases user locks that have reached their expiry.
+	LockExpirer struct {
+		logr.Logger
+
+		db *pgdb
+	}
+
+	LockExpirerOptions struct {
+		logr.Logger
+		*sql.DB
+	}
+)
+
+func NewLockExpirer(opts LockExpirerOptions) *LockExpirer {
+	return &LockExpirer{
+		Logger: opts.Logger,
+		db:     &pgdb{opts.DB},
+	}
+}
+
+// Start starts the lock expirer daemon. Should be invoked in a go routine.
+func (e *LockExpirer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(lockExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.expire(ctx, time.Now()); err != nil {
+			e.Error(err, "expiring workspace locks")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// expire releases those locks that have expired as of now.
+func (e *LockExpirer) expire(ctx context.Context, now time.Time) error {
+	workspaceIDs, err := e.db.listExpiredLocks(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, id := range workspaceIDs {
+		_, err := e.db.toggleLock(ctx, id, nil, LockEventExpired, func(ws *Workspace) error {
+			if ws.Lock == nil || !ws.Lock.Expired(now) {
+				return errLockNotExpired
+			}
+			ws.Lock = nil
+			return nil
+		})
+		if errors.Is(err, errLockNotExpired) {
+			continue
+		} else if err != nil {
+			// carry on with other workspaces
+			e.Error(err, "expiring workspace lock", "workspace", id)
+			continue
+		}
+		e.V(1).Info("expired workspace lock", "workspace", id)
+	}
+	return nil
+}
@@ -6,17 +6,21 @@ import (
 
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 )
 
 type LockService interface {
-	LockWorkspace(ctx context.Context, workspaceID string, runID *string) (*Workspace, error)
+	LockWorkspace(ctx context.Context, workspaceID string, runID *string, opts LockOptions) (*Workspace, error)
 	UnlockWorkspace(ctx context.Context, workspaceID string, runID *string, force bool) (*Workspace, error)
+	// ListWorkspaceLockEvents lists the history of a workspace's lock, newest
+	// first.
+	ListWorkspaceLockEvents(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*LockEvent], error)
 }
 
 // lock the workspace. A workspace can only be locked on behalf of a run or a
 // user. If the former then runID must be populated. Otherwise a user is
 // extracted from the context.
-func (s *service) LockWorkspace(ctx context.Context, workspaceID string, runID *string) (*Workspace, error) {
+func (s *service) LockWorkspace(ctx context.Context, workspaceID string, runID *string, opts LockOptions) (*Workspace, error) {
 	var (
 		id   string
 		kind LockKind
@@ -37,14 +41,14 @@ func (s *service) LockWorkspace(ctx context.Context, workspaceID string, runID *
 		kind = UserLock
 	}
 
-	ws, err := s.db.toggleLock(ctx, workspaceID, func(ws *Workspace) error {
-		return ws.Enlock(id, kind)
+	ws, err := s.db.toggleLock(ctx, workspaceID, &id, LockEventLocked, func(ws *Workspace) error {
+		return ws.Enlock(id, kind, opts)
 	})
 	if err != nil {
 		s.Error(err, "locking workspace", "subject", id, "workspace", workspaceID)
 		return nil, err
 	}
-	s.V(1).Info("locked workspace", "subject", id, "workspace", workspaceID)
+	s.V(1).Info("locked workspace", "subject", id, "workspace", workspaceID, "reason", opts.Reason)
 
 	return ws, nil
 }
@@ -79,7 +83,11 @@ func (s *service) UnlockWorkspace(ctx context.Context, workspaceID string, runID
 		kind = UserLock
 	}
 
-	ws, err := s.db.toggleLock(ctx, workspaceID, func(ws *Workspace) error {
+	action := LockEventUnlocked
+	if force {
+		action = LockEventForceUnlocked
+	}
+	ws, err := s.db.toggleLock(ctx, workspaceID, &id, action, func(ws *Workspace) error {
 		return ws.Unlock(id, kind, force)
 	})
 	if err != nil {
@@ -90,3 +98,18 @@ func (s *service) UnlockWorkspace(ctx context.Context, workspaceID string, runID
 
 	return ws, nil
 }
+
+func (s *service) ListWorkspaceLockEvents(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*LockEvent], error) {
+	subject, err := s.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.db.listLockEvents(ctx, workspaceID, opts)
+	if err != nil {
+		s.Error(err, "listing workspace lock events", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed workspace lock events", "workspace", workspaceID, "subject", subject)
+	return page, nil
+}
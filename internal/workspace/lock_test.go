@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
@@ -12,19 +13,43 @@ import (
 func TestWorkspace_Lock(t *testing.T) {
 	t.Run("lock an unlocked workspace", func(t *testing.T) {
 		ws := &Workspace{}
-		err := ws.Enlock("janitor", UserLock)
+		err := ws.Enlock("janitor", UserLock, LockOptions{})
 		require.NoError(t, err)
 		assert.True(t, ws.Locked())
 	})
 	t.Run("replace run lock with another run lock", func(t *testing.T) {
 		ws := &Workspace{Lock: &Lock{id: "run-123", LockKind: RunLock}}
-		err := ws.Enlock("run-456", RunLock)
+		err := ws.Enlock("run-456", RunLock, LockOptions{})
 		require.NoError(t, err)
 		assert.True(t, ws.Locked())
 	})
+	t.Run("lock with reason and expiry", func(t *testing.T) {
+		ws := &Workspace{}
+		expiry := time.Now().Add(time.Hour)
+		err := ws.Enlock("janitor", UserLock, LockOptions{Reason: "cleaning", ExpiresAt: &expiry})
+		require.NoError(t, err)
+		assert.Equal(t, "cleaning", ws.Lock.Reason)
+		assert.Equal(t, &expiry, ws.Lock.ExpiresAt)
+		assert.False(t, ws.Lock.LockedAt.IsZero())
+		assert.False(t, ws.Lock.Expired(time.Now()))
+		assert.True(t, ws.Lock.Expired(expiry))
+	})
+	t.Run("expiry must be in the future", func(t *testing.T) {
+		ws := &Workspace{}
+		expiry := time.Now().Add(-time.Hour)
+		err := ws.Enlock("janitor", UserLock, LockOptions{ExpiresAt: &expiry})
+		require.Equal(t, ErrInvalidLockExpiry, err)
+		assert.False(t, ws.Locked())
+	})
+	t.Run("run lock cannot expire", func(t *testing.T) {
+		ws := &Workspace{}
+		expiry := time.Now().Add(time.Hour)
+		err := ws.Enlock("run-123", RunLock, LockOptions{ExpiresAt: &expiry})
+		require.Equal(t, ErrInvalidLockExpiry, err)
+	})
 	t.Run("user cannot lock a locked workspace", func(t *testing.T) {
 		ws := &Workspace{Lock: &Lock{id: "run-123", LockKind: RunLock}}
-		err := ws.Enlock("janitor", UserLock)
+		err := ws.Enlock("janitor", UserLock, LockOptions{})
 		require.Equal(t, internal.ErrWorkspaceAlreadyLocked, err)
 	})
 }
@@ -137,6 +162,18 @@ func TestWorkspace_LockButtonHelper(t *testing.T) {
 				Tooltip: "locked by: janitor",
 			},
 		},
+		{
+			"lock with reason",
+			&Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock, Reason: "cleaning"}},
+			&fakeSubject{id: "janitor", canUnlock: true},
+			LockButton{
+				State:   "locked",
+				Text:    "Unlock",
+				Message: "locked by: janitor (cleaning)",
+				Tooltip: "locked by: janitor (cleaning)",
+				Action:  "/app/workspaces//unlock",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -31,6 +31,8 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 	// (2) we retrieve the name of the organization, which is part of a policy
 	q.FindWorkspaceByIDBatch(batch, sql.String(workspaceID))
 	q.FindWorkspacePermissionsByWorkspaceIDBatch(batch, sql.String(workspaceID))
+	q.FindProjectPermissionsByWorkspaceIDBatch(batch, sql.String(workspaceID))
+	q.FindRemoteStateConsumerIDsBatch(batch, sql.String(workspaceID))
 	results := db.SendBatch(ctx, batch)
 	defer results.Close()
 
@@ -42,12 +44,23 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 	if err != nil {
 		return internal.WorkspacePolicy{}, sql.Error(err)
 	}
+	projectPerms, err := q.FindProjectPermissionsByWorkspaceIDScan(results)
+	if err != nil {
+		return internal.WorkspacePolicy{}, sql.Error(err)
+	}
+	consumers, err := q.FindRemoteStateConsumerIDsScan(results)
+	if err != nil {
+		return internal.WorkspacePolicy{}, sql.Error(err)
+	}
 
 	policy := internal.WorkspacePolicy{
 		Organization:      ws.OrganizationName.String,
 		WorkspaceID:       workspaceID,
 		GlobalRemoteState: ws.GlobalRemoteState,
 	}
+	for _, id := range consumers {
+		policy.RemoteStateConsumers = append(policy.RemoteStateConsumers, id.String)
+	}
 	for _, perm := range perms {
 		role, err := rbac.WorkspaceRoleFromString(perm.Role.String)
 		if err != nil {
@@ -59,6 +72,17 @@ func (db *pgdb) GetWorkspacePolicy(ctx context.Context, workspaceID string) (int
 			Role:   role,
 		})
 	}
+	for _, perm := range projectPerms {
+		role, err := rbac.WorkspaceRoleFromString(perm.Role.String)
+		if err != nil {
+			return internal.WorkspacePolicy{}, err
+		}
+		policy.ProjectPermissions = append(policy.ProjectPermissions, internal.WorkspacePermission{
+			Team:   perm.Team.Name.String,
+			TeamID: perm.Team.TeamID.String,
+			Role:   role,
+		})
+	}
 	return policy, nil
 }
 
@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+func (db *pgdb) addRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		return insertRemoteStateConsumers(ctx, q, workspaceID, consumerIDs)
+	})
+}
+
+func (db *pgdb) removeRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, id := range consumerIDs {
+			if _, err := q.DeleteRemoteStateConsumer(ctx, sql.String(workspaceID), sql.String(id)); err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) replaceRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteRemoteStateConsumers(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		return insertRemoteStateConsumers(ctx, q, workspaceID, consumerIDs)
+	})
+}
+
+func (db *pgdb) listRemoteStateConsumers(ctx context.Context, workspaceID string) ([]*Workspace, error) {
+	rows, err := db.Conn(ctx).FindRemoteStateConsumers(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	consumers := make([]*Workspace, len(rows))
+	for i, r := range rows {
+		consumers[i], err = pgresult(r).toWorkspace()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return consumers, nil
+}
+
+// insertRemoteStateConsumers permits the consumers to read the workspace's
+// state. Each consumer must belong to the same organization as the
+// workspace.
+func insertRemoteStateConsumers(ctx context.Context, q pggen.Querier, workspaceID string, consumerIDs []string) error {
+	ws, err := q.FindWorkspaceByID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	for _, id := range consumerIDs {
+		if id == workspaceID {
+			return internal.ErrRemoteStateConsumerSelf
+		}
+		consumer, err := q.FindWorkspaceByID(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		if consumer.OrganizationName.String != ws.OrganizationName.String {
+			return internal.ErrResourceNotFound
+		}
+		if _, err := q.InsertRemoteStateConsumer(ctx, sql.String(workspaceID), sql.String(id)); err != nil {
+			return sql.Error(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal/rbac"
+)
+
+// RemoteStateConsumerService manages the workspaces that are permitted to
+// consume a workspace's state, i.e. via the terraform_remote_state data source
+// or the tfe_outputs data source.
+type RemoteStateConsumerService interface {
+	AddRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error
+	RemoveRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error
+	// ReplaceRemoteStateConsumers replaces a workspace's consumers with the
+	// given consumers.
+	ReplaceRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error
+	ListRemoteStateConsumers(ctx context.Context, workspaceID string) ([]*Workspace, error)
+}
+
+func (s *service) AddRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.addRemoteStateConsumers(ctx, workspaceID, consumerIDs); err != nil {
+		s.Error(err, "adding remote state consumers", "workspace", workspaceID, "consumers", consumerIDs, "subject", subject)
+		return err
+	}
+	s.V(0).Info("added remote state consumers", "workspace", workspaceID, "consumers", consumerIDs, "subject", subject)
+	return nil
+}
+
+func (s *service) RemoveRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.removeRemoteStateConsumers(ctx, workspaceID, consumerIDs); err != nil {
+		s.Error(err, "removing remote state consumers", "workspace", workspaceID, "consumers", consumerIDs, "subject", subject)
+		return err
+	}
+	s.V(0).Info("removed remote state consumers", "workspace", workspaceID, "consumers", consumerIDs, "subject", subject)
+	return nil
+}
+
+func (s *service) ReplaceRemoteStateConsumers(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.replaceRemoteStateConsumers(ctx, workspaceID, consumerIDs); err != nil {
+		s.Error(err, "replacing remote state consumers", "workspace", workspaceID, "consumers", consumerIDs, "subject", subject)
+		return err
+	}
+	s.V(0).Info("replaced remote state consumers", "workspace", workspaceID, "consumers", consumerIDs, "subject", subject)
+	return nil
+}
+
+func (s *service) ListRemoteStateConsumers(ctx context.Context, workspaceID string) ([]*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	consumers, err := s.db.listRemoteStateConsumers(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "listing remote state consumers", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed remote state consumers", "workspace", workspaceID, "count", len(consumers), "subject", subject)
+	return consumers, nil
+}
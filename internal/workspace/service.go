@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"github.com/leg100/otf/internal/hooks"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/pubsub"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/repo"
@@ -37,13 +39,20 @@ type (
 		// TODO: rename to ListConnectedWorkspaces
 		ListWorkspacesByRepoID(ctx context.Context, repoID uuid.UUID) ([]*Workspace, error)
 		DeleteWorkspace(ctx context.Context, workspaceID string) (*Workspace, error)
+		// SafeDeleteWorkspace deletes a workspace only if its current state
+		// contains no managed resources, otherwise ErrWorkspaceHasResources
+		// is returned.
+		SafeDeleteWorkspace(ctx context.Context, workspaceID string) (*Workspace, error)
 
 		SetCurrentRun(ctx context.Context, workspaceID, runID string) (*Workspace, error)
 
 		AfterCreateWorkspace(l hooks.Listener[*Workspace])
 
+		ApprovalPolicyService
+		CloneService
 		LockService
 		PermissionsService
+		RemoteStateConsumerService
 		TagService
 	}
 
@@ -55,11 +64,13 @@ type (
 		organization        internal.Authorizer
 		internal.Authorizer // workspace authorizer
 
-		db   *pgdb
-		repo repo.RepoService
-		web  *webHandlers
+		db    *pgdb
+		repo  repo.RepoService
+		state state.StateService
+		web   *webHandlers
 
 		createHook *hooks.Hook[*Workspace]
+		cloneHook  *hooks.Hook[*CloneEvent]
 	}
 
 	Options struct {
@@ -71,6 +82,7 @@ type (
 		state.StateService
 		repo.RepoService
 		auth.TeamService
+		project.ProjectService
 		logr.Logger
 	}
 )
@@ -86,25 +98,31 @@ func NewService(opts Options) *service {
 		},
 		db:           db,
 		repo:         opts.RepoService,
+		state:        opts.StateService,
 		organization: &organization.Authorizer{Logger: opts.Logger},
 		site:         &internal.SiteAuthorizer{Logger: opts.Logger},
 		createHook:   hooks.NewHook[*Workspace](opts.DB),
+		cloneHook:    hooks.NewHook[*CloneEvent](opts.DB),
 	}
 	svc.web = &webHandlers{
 		Renderer:           opts.Renderer,
 		TeamService:        opts.TeamService,
 		VCSProviderService: opts.VCSProviderService,
 		StateService:       opts.StateService,
+		ProjectService:     opts.ProjectService,
 		svc:                &svc,
 	}
 	// Register with broker so that it can relay workspace events
 	opts.Register("workspaces", &svc)
+	// ...and changes to workspace locks
+	opts.Register("workspace_lock_events", pubsub.GetterFunc(svc.getLockEventByID))
 	return &svc
 }
 
 func (s *service) AddHandlers(r *mux.Router) {
 	s.web.addHandlers(r)
 	s.web.addTagHandlers(r)
+	s.web.addStateHandlers(r)
 }
 
 func (s *service) AfterCreateWorkspace(l hooks.Listener[*Workspace]) {
@@ -215,7 +233,7 @@ func (s *service) ListWorkspaces(ctx context.Context, opts ListOptions) (*resour
 				return nil, err
 			}
 			if user, ok := subject.(*auth.User); ok {
-				return s.db.listByUsername(ctx, user.Username, *opts.Organization, opts.PageOptions)
+				return s.db.listByUsername(ctx, user.Username, *opts.Organization, opts)
 			}
 		} else if err != nil {
 			return nil, err
@@ -297,6 +315,40 @@ func (s *service) DeleteWorkspace(ctx context.Context, workspaceID string) (*Wor
 	return ws, nil
 }
 
+func (s *service) SafeDeleteWorkspace(ctx context.Context, workspaceID string) (*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.DeleteWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.managedResourceCount(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "checking workspace for resources", "id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	if count > 0 {
+		s.V(1).Info("refusing to delete workspace with resources", "id", workspaceID, "resources", count, "subject", subject)
+		return nil, ErrWorkspaceHasResources
+	}
+	return s.DeleteWorkspace(ctx, workspaceID)
+}
+
+// managedResourceCount returns the number of managed resources in the
+// workspace's current state, or zero if it has no state.
+func (s *service) managedResourceCount(ctx context.Context, workspaceID string) (int, error) {
+	sv, err := s.state.GetCurrentStateVersion(ctx, workspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	f, err := sv.File()
+	if err != nil {
+		return 0, err
+	}
+	return f.ManagedResourceCount(), nil
+}
+
 // connect connects the workspace to a repo.
 func (s *service) connect(ctx context.Context, workspaceID string, connection *Connection) error {
 	subject, err := internal.SubjectFromContext(ctx)
@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/state"
+)
+
+func (h *webHandlers) addStateHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/state-diff", h.diffStateVersions).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/resource-history", h.listResourceChanges).Methods("GET")
+}
+
+func (h *webHandlers) diffStateVersions(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		From        string `schema:"from"`
+		To          string `schema:"to"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.svc.GetWorkspace(r.Context(), params.WorkspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	versions, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*state.Version], error) {
+		return h.ListStateVersions(r.Context(), ws.ID, opts)
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// default to comparing the current state version with its predecessor
+	var diff *state.Diff
+	if params.To == "" && len(versions) > 0 {
+		params.To = versions[0].ID
+	}
+	if params.To != "" {
+		diff, err = h.DiffStateVersions(r.Context(), params.From, params.To)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Render("state_diff.tmpl", w, struct {
+		WorkspacePage
+		Versions []*state.Version
+		Diff     *state.Diff
+		From     string
+		To       string
+	}{
+		WorkspacePage: NewPage(r, "state diff", ws),
+		Versions:      versions,
+		Diff:          diff,
+		From:          params.From,
+		To:            params.To,
+	})
+}
+
+func (h *webHandlers) listResourceChanges(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Address     string `schema:"address,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.svc.GetWorkspace(r.Context(), params.WorkspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	changes, err := h.ListResourceChanges(r.Context(), ws.ID, params.Address)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("resource_history.tmpl", w, struct {
+		WorkspacePage
+		Address string
+		Changes []*state.ResourceChange
+	}{
+		WorkspacePage: NewPage(r, params.Address, ws),
+		Address:       params.Address,
+		Changes:       changes,
+	})
+}
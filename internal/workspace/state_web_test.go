@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/antchfx/htmlquery"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/auth"
+	"github.com/leg100/otf/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspace_DiffStateVersions(t *testing.T) {
+	ws := &Workspace{ID: "ws-123", Organization: "acme-corp"}
+	app := fakeWebHandlers(t,
+		withWorkspaces(ws),
+		withStateVersions(&state.Version{ID: "sv-2"}, &state.Version{ID: "sv-1"}),
+		withStateDiff(&state.Diff{
+			FromID: "sv-1",
+			ToID:   "sv-2",
+			Resources: []*state.ResourceDiff{
+				{
+					Address:    "aws_iam_role.x",
+					Action:     state.DiffChanged,
+					Attributes: []*state.AttributeDiff{{Path: "name", Before: "a", After: "b"}},
+				},
+			},
+			Outputs: []*state.OutputDiff{
+				{Name: "password", Action: state.DiffChanged, Before: "(sensitive value)", After: "(sensitive value)"},
+			},
+		}),
+	)
+
+	r := httptest.NewRequest("GET", "/?workspace_id=ws-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	app.diffStateVersions(w, r)
+	require.Equal(t, 200, w.Code, w.Body.String())
+
+	doc, err := htmlquery.Parse(w.Body)
+	require.NoError(t, err)
+	assert.NotNil(t, htmlquery.FindOne(doc, "//details[@id='resource-aws_iam_role.x']"))
+	assert.NotNil(t, htmlquery.FindOne(doc, "//table[@id='output-diffs']"))
+	findText(t, doc, "sv-2 (serial 0)", "//select[@id='to-select']/option[@selected]")
+}
+
+func TestWorkspace_ListResourceChanges(t *testing.T) {
+	ws := &Workspace{ID: "ws-123", Organization: "acme-corp"}
+	runID := "run-123"
+	app := fakeWebHandlers(t,
+		withWorkspaces(ws),
+		withResourceChanges(&state.ResourceChange{
+			StateVersionID: "sv-2",
+			RunID:          &runID,
+			ResourceDiff: &state.ResourceDiff{
+				Address: "aws_iam_role.x",
+				Action:  state.DiffChanged,
+			},
+		}),
+	)
+
+	r := httptest.NewRequest("GET", "/?workspace_id=ws-123&address=aws_iam_role.x", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &auth.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	app.listResourceChanges(w, r)
+	require.Equal(t, 200, w.Code, w.Body.String())
+
+	doc, err := htmlquery.Parse(w.Body)
+	require.NoError(t, err)
+	findText(t, doc, "run-123", "//div[@id='resource-history']//a[@href='/app/runs/run-123']")
+}
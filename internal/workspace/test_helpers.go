@@ -8,7 +8,9 @@ import (
 	"github.com/leg100/otf/internal/auth"
 	"github.com/leg100/otf/internal/cloud"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/stretchr/testify/require"
 )
@@ -20,11 +22,20 @@ type (
 		repos      []string
 		policy     internal.WorkspacePolicy
 		teams      []*auth.Team
+		projects   []*project.Project
+		// hasResources is true if the workspace's state contains resources
+		hasResources bool
+
+		stateVersions   []*state.Version
+		stateDiff       *state.Diff
+		resourceChanges []*state.ResourceChange
 
 		Service
+		state.StateService
 
 		auth.TeamService
 		VCSProviderService
+		project.ProjectService
 	}
 
 	fakeWebServiceOption func(*fakeWebService)
@@ -36,6 +47,12 @@ func withWorkspaces(workspaces ...*Workspace) fakeWebServiceOption {
 	}
 }
 
+func withResources() fakeWebServiceOption {
+	return func(svc *fakeWebService) {
+		svc.hasResources = true
+	}
+}
+
 func withVCSProviders(providers ...*vcsprovider.VCSProvider) fakeWebServiceOption {
 	return func(svc *fakeWebService) {
 		svc.providers = providers
@@ -60,6 +77,24 @@ func withTeams(teams ...*auth.Team) fakeWebServiceOption {
 	}
 }
 
+func withStateVersions(versions ...*state.Version) fakeWebServiceOption {
+	return func(svc *fakeWebService) {
+		svc.stateVersions = versions
+	}
+}
+
+func withStateDiff(diff *state.Diff) fakeWebServiceOption {
+	return func(svc *fakeWebService) {
+		svc.stateDiff = diff
+	}
+}
+
+func withResourceChanges(changes ...*state.ResourceChange) fakeWebServiceOption {
+	return func(svc *fakeWebService) {
+		svc.resourceChanges = changes
+	}
+}
+
 func fakeWebHandlers(t *testing.T, opts ...fakeWebServiceOption) *webHandlers {
 	renderer, err := html.NewRenderer(false)
 	require.NoError(t, err)
@@ -73,6 +108,8 @@ func fakeWebHandlers(t *testing.T, opts ...fakeWebServiceOption) *webHandlers {
 		Renderer:           renderer,
 		TeamService:        &svc,
 		VCSProviderService: &svc,
+		ProjectService:     &svc,
+		StateService:       &svc,
 		svc:                &svc,
 	}
 }
@@ -93,10 +130,18 @@ func (f *fakeWebService) GetPolicy(context.Context, string) (internal.WorkspaceP
 	return f.policy, nil
 }
 
+func (f *fakeWebService) GetApprovalPolicy(_ context.Context, workspaceID string) (*ApprovalPolicy, error) {
+	return &ApprovalPolicy{WorkspaceID: workspaceID}, nil
+}
+
 func (f *fakeWebService) ListTeams(context.Context, string) ([]*auth.Team, error) {
 	return f.teams, nil
 }
 
+func (f *fakeWebService) ListProjects(_ context.Context, opts project.ListOptions) (*resource.Page[*project.Project], error) {
+	return resource.NewPage(f.projects, opts.PageOptions, nil), nil
+}
+
 func (f *fakeWebService) GetVCSClient(ctx context.Context, providerID string) (cloud.Client, error) {
 	return &fakeWebCloudClient{repos: f.repos}, nil
 }
@@ -113,6 +158,10 @@ func (f *fakeWebService) ListWorkspaces(ctx context.Context, opts ListOptions) (
 	return resource.NewPage(f.workspaces, opts.PageOptions, nil), nil
 }
 
+func (f *fakeWebService) ListRemoteStateConsumers(context.Context, string) ([]*Workspace, error) {
+	return nil, nil
+}
+
 func (f *fakeWebService) GetWorkspace(context.Context, string) (*Workspace, error) {
 	return f.workspaces[0], nil
 }
@@ -125,7 +174,18 @@ func (f *fakeWebService) DeleteWorkspace(context.Context, string) (*Workspace, e
 	return f.workspaces[0], nil
 }
 
-func (f *fakeWebService) LockWorkspace(context.Context, string, *string) (*Workspace, error) {
+func (f *fakeWebService) SafeDeleteWorkspace(context.Context, string) (*Workspace, error) {
+	if f.hasResources {
+		return nil, ErrWorkspaceHasResources
+	}
+	return f.workspaces[0], nil
+}
+
+func (f *fakeWebService) CloneWorkspace(context.Context, string, CloneOptions) (*Workspace, error) {
+	return f.workspaces[0], nil
+}
+
+func (f *fakeWebService) LockWorkspace(context.Context, string, *string, LockOptions) (*Workspace, error) {
 	return f.workspaces[0], nil
 }
 
@@ -146,3 +206,15 @@ type fakeWebCloudClient struct {
 func (f *fakeWebCloudClient) ListRepositories(ctx context.Context, opts cloud.ListRepositoriesOptions) ([]string, error) {
 	return f.repos, nil
 }
+
+func (f *fakeWebService) ListStateVersions(_ context.Context, _ string, opts resource.PageOptions) (*resource.Page[*state.Version], error) {
+	return resource.NewPage(f.stateVersions, opts, nil), nil
+}
+
+func (f *fakeWebService) DiffStateVersions(context.Context, string, string) (*state.Diff, error) {
+	return f.stateDiff, nil
+}
+
+func (f *fakeWebService) ListResourceChanges(context.Context, string, string) ([]*state.ResourceChange, error) {
+	return f.resourceChanges, nil
+}
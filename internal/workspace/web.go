@@ -2,7 +2,10 @@ package workspace
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -12,6 +15,7 @@ import (
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/project"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/state"
@@ -27,6 +31,9 @@ const (
 	// supplied in another variable
 	vcsTagRegexCustom = `custom`
 
+	// layout of the value of a datetime-local form input
+	autoDestroyAtLayout = "2006-01-02T15:04"
+
 	//
 	// VCS trigger strategies to present to the user.
 	//
@@ -45,6 +52,7 @@ type (
 		auth.TeamService
 		VCSProviderService
 		state.StateService
+		project.ProjectService
 
 		svc Service
 	}
@@ -78,6 +86,9 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/workspaces/{workspace_id}/lock", h.lockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/unlock", h.unlockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/force-unlock", h.forceUnlockWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/lock-history", h.listLockEvents).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/clone", h.newClone).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/clone", h.cloneWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/setup-connection-provider", h.listWorkspaceVCSProviders).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/setup-connection-repo", h.listWorkspaceVCSRepos).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/connect", h.connect).Methods("POST")
@@ -85,6 +96,9 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/workspaces/{workspace_id}/set-permission", h.setWorkspacePermission).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/unset-permission", h.unsetWorkspacePermission).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/set-approval-policy", h.setApprovalPolicy).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/add-remote-state-consumer", h.addRemoteStateConsumer).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/remove-remote-state-consumer", h.removeRemoteStateConsumer).Methods("POST")
 }
 
 func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
@@ -92,17 +106,23 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		Search       string   `schema:"search[name],omitempty"`
 		Tags         []string `schema:"search[tags],omitempty"`
 		Organization *string  `schema:"organization_name,required"`
+		ProjectID    *string  `schema:"project_id,omitempty"`
 		PageNumber   int      `schema:"page[number]"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	// an empty project filter means list workspaces in all projects
+	if params.ProjectID != nil && *params.ProjectID == "" {
+		params.ProjectID = nil
+	}
 
 	workspaces, err := h.svc.ListWorkspaces(r.Context(), ListOptions{
 		Search:       params.Search,
 		Tags:         params.Tags,
 		Organization: params.Organization,
+		ProjectID:    params.ProjectID,
 		PageOptions: resource.PageOptions{
 			PageNumber: params.PageNumber,
 			PageSize:   html.PageSize,
@@ -113,6 +133,12 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	projects, err := h.listProjects(r, *params.Organization)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	// retrieve all tags and create map, with each entry determining whether
 	// listing is currently filtered by the tag or not.
 	tags, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Tag], error) {
@@ -149,6 +175,8 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		*resource.Page[*Workspace]
 		TagFilters         map[string]bool
 		Search             string
+		Projects           []*project.Project
+		ProjectID          string
 		CanCreateWorkspace bool
 	}{
 		OrganizationPage:   organization.NewPage(r, "workspaces", *params.Organization),
@@ -156,6 +184,10 @@ func (h *webHandlers) listWorkspaces(w http.ResponseWriter, r *http.Request) {
 		Page:               workspaces,
 		TagFilters:         tagfilters(),
 		Search:             params.Search,
+		Projects:           projects,
+	}
+	if params.ProjectID != nil {
+		response.ProjectID = *params.ProjectID
 	}
 
 	if isHTMX := r.Header.Get("HX-Request"); isHTMX == "true" {
@@ -175,10 +207,27 @@ func (h *webHandlers) newWorkspace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// list templates from which the new workspace can be cloned; a user
+	// without permission to list the organization's workspaces is offered no
+	// templates.
+	templates, err := h.svc.ListWorkspaces(r.Context(), ListOptions{
+		Organization:  &org,
+		TemplatesOnly: true,
+		PageOptions:   resource.PageOptions{PageSize: resource.MaxPageSize},
+	})
+	if errors.Is(err, internal.ErrAccessNotPermitted) {
+		templates = &resource.Page[*Workspace]{}
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	h.Render("workspace_new.tmpl", w, struct {
 		organization.OrganizationPage
+		Templates []*Workspace
 	}{
 		OrganizationPage: organization.NewPage(r, "new workspace", org),
+		Templates:        templates.Items,
 	})
 }
 
@@ -342,6 +391,12 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	approvalPolicy, err := h.svc.GetApprovalPolicy(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	var provider *vcsprovider.VCSProvider
 	if workspace.Connection != nil {
 		provider, err = h.GetVCSProvider(r.Context(), workspace.Connection.VCSProviderID)
@@ -367,13 +422,42 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	projects, err := h.listProjects(r, workspace.Organization)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	consumers, err := h.svc.ListRemoteStateConsumers(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// other workspaces in the organization are candidates for consuming this
+	// workspace's state
+	others, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Workspace], error) {
+		return h.svc.ListWorkspaces(r.Context(), ListOptions{
+			Organization: &workspace.Organization,
+			PageOptions:  opts,
+		})
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	h.Render("workspace_edit.tmpl", w, struct {
 		WorkspacePage
 		Policy             internal.WorkspacePolicy
 		Unassigned         []*auth.Team
+		Teams              []*auth.Team
+		Projects           []*project.Project
+		ApprovalPolicy     *ApprovalPolicy
 		Roles              []rbac.Role
 		VCSProvider        *vcsprovider.VCSProvider
 		UnassignedTags     []string
+		Consumers          []*Workspace
+		NonConsumers       []*Workspace
 		CanUpdateWorkspace bool
 		CanDeleteWorkspace bool
 		VCSTagRegexDefault string
@@ -384,9 +468,12 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		VCSTriggerPatterns string
 		VCSTriggerTags     string
 	}{
-		WorkspacePage: NewPage(r, "edit | "+workspace.ID, workspace),
-		Policy:        policy,
-		Unassigned:    filterUnassigned(policy, teams),
+		WorkspacePage:  NewPage(r, "edit | "+workspace.ID, workspace),
+		Policy:         policy,
+		Unassigned:     filterUnassigned(policy, teams),
+		Teams:          teams,
+		Projects:       projects,
+		ApprovalPolicy: approvalPolicy,
 		Roles: []rbac.Role{
 			rbac.WorkspaceReadRole,
 			rbac.WorkspacePlanRole,
@@ -395,6 +482,8 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		},
 		VCSProvider:        provider,
 		UnassignedTags:     internal.DiffStrings(getTagNames(), workspace.Tags),
+		Consumers:          consumers,
+		NonConsumers:       filterNonConsumers(workspace, consumers, others),
 		VCSTagRegexDefault: vcsTagRegexDefault,
 		VCSTagRegexPrefix:  vcsTagRegexPrefix,
 		VCSTagRegexSuffix:  vcsTagRegexSuffix,
@@ -412,12 +501,28 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		AutoApply         bool `schema:"auto_apply"`
 		Name              *string
 		Description       *string
+		ProjectID         *string        `schema:"project_id"`
 		ExecutionMode     *ExecutionMode `schema:"execution_mode"`
 		TerraformVersion  *string        `schema:"terraform_version"`
 		WorkingDirectory  *string        `schema:"working_directory"`
 		WorkspaceID       string         `schema:"workspace_id,required"`
 		GlobalRemoteState bool           `schema:"global_remote_state"`
 
+		StructuredRunOutputEnabled bool `schema:"structured_run_output_enabled"`
+
+		// Phase timeouts in minutes
+		PlanTimeout  *int `schema:"plan_timeout"`
+		ApplyTimeout *int `schema:"apply_timeout"`
+		// Auto-discard TTL in minutes
+		AutoDiscardTTL *int `schema:"auto_discard_ttl"`
+		// Auto-destroy time in UTC, in the format of a datetime-local input;
+		// empty cancels a scheduled destroy.
+		AutoDestroyAt *string `schema:"auto_destroy_at"`
+		// Auto-destroy inactivity period in hours
+		AutoDestroyActivityDuration *int `schema:"auto_destroy_activity_duration"`
+		AutoApplyDestroy            bool `schema:"auto_apply_destroy"`
+		Template                    bool `schema:"template"`
+
 		// VCS connection
 		VCSTriggerStrategy  string `schema:"vcs_trigger"`
 		TriggerPatternsJSON string `schema:"trigger_patterns"`
@@ -425,6 +530,11 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		PredefinedTagsRegex string `schema:"tags_regex"`
 		CustomTagsRegex     string `schema:"custom_tags_regex"`
 		AllowCLIApply       bool   `schema:"allow_cli_apply"`
+		PRComments          bool   `schema:"pr_comments"`
+		PRApply             bool   `schema:"pr_apply"`
+		SupersedeRuns       bool   `schema:"supersede_runs"`
+		PreviewTemplate     bool   `schema:"preview_template"`
+		MaxPreviews         *int   `schema:"max_previews"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -442,17 +552,50 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		AutoApply:         &params.AutoApply,
 		Name:              params.Name,
 		Description:       params.Description,
+		ProjectID:         params.ProjectID,
 		ExecutionMode:     params.ExecutionMode,
 		TerraformVersion:  params.TerraformVersion,
 		WorkingDirectory:  params.WorkingDirectory,
 		GlobalRemoteState: &params.GlobalRemoteState,
+
+		StructuredRunOutputEnabled: &params.StructuredRunOutputEnabled,
+		AutoApplyDestroy:           &params.AutoApplyDestroy,
+		Template:                   &params.Template,
+	}
+	if params.PlanTimeout != nil {
+		opts.PlanTimeout = internal.Duration(time.Duration(*params.PlanTimeout) * time.Minute)
+	}
+	if params.ApplyTimeout != nil {
+		opts.ApplyTimeout = internal.Duration(time.Duration(*params.ApplyTimeout) * time.Minute)
+	}
+	if params.AutoDiscardTTL != nil {
+		opts.AutoDiscardTTL = internal.Duration(time.Duration(*params.AutoDiscardTTL) * time.Minute)
+	}
+	if params.AutoDestroyAt != nil {
+		var at time.Time
+		if *params.AutoDestroyAt != "" {
+			at, err = time.Parse(autoDestroyAtLayout, *params.AutoDestroyAt)
+			if err != nil {
+				h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		opts.AutoDestroyAt = &at
+	}
+	if params.AutoDestroyActivityDuration != nil {
+		opts.AutoDestroyActivityDuration = internal.Duration(time.Duration(*params.AutoDestroyActivityDuration) * time.Hour)
 	}
 	if ws.Connection != nil {
 		// workspace is connected, so set connection fields
 		opts.ConnectOptions = &ConnectOptions{
 			AllowCLIApply: &params.AllowCLIApply,
+			PRComments:    &params.PRComments,
+			PRApply:       &params.PRApply,
 			Branch:        &params.VCSBranch,
 		}
+		opts.SupersedeRuns = &params.SupersedeRuns
+		opts.PreviewTemplate = &params.PreviewTemplate
+		opts.MaxPreviews = params.MaxPreviews
 		switch params.VCSTriggerStrategy {
 		case VCSTriggerAlways:
 			opts.AlwaysTrigger = internal.Bool(true)
@@ -472,7 +615,11 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ws, err = h.svc.UpdateWorkspace(r.Context(), params.WorkspaceID, opts)
-	if err != nil {
+	if errors.Is(err, internal.ErrInvalidPhaseTimeout) || errors.Is(err, internal.ErrInvalidAutoDiscardTTL) || errors.Is(err, ErrInvalidMaxPreviews) || errors.Is(err, internal.ErrInvalidAutoDestroyAt) || errors.Is(err, internal.ErrInvalidAutoDestroyActivityDuration) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -482,14 +629,37 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.EditWorkspace(ws.ID), http.StatusFound)
 }
 
+// listProjects lists all of an organization's projects
+func (h *webHandlers) listProjects(r *http.Request, organization string) ([]*project.Project, error) {
+	return resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*project.Project], error) {
+		return h.ListProjects(r.Context(), project.ListOptions{
+			Organization: organization,
+			PageOptions:  opts,
+		})
+	})
+}
+
 func (h *webHandlers) deleteWorkspace(w http.ResponseWriter, r *http.Request) {
-	workspaceID, err := decode.Param("workspace_id", r)
-	if err != nil {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		// Force deletes the workspace even if its state contains resources.
+		Force bool `schema:"force"`
+	}
+	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 
-	ws, err := h.svc.DeleteWorkspace(r.Context(), workspaceID)
+	deleteFunc := h.svc.SafeDeleteWorkspace
+	if params.Force {
+		deleteFunc = h.svc.DeleteWorkspace
+	}
+	ws, err := deleteFunc(r.Context(), params.WorkspaceID)
+	if errors.Is(err, ErrWorkspaceHasResources) {
+		html.FlashError(w, "cannot delete workspace: "+err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	}
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -499,14 +669,33 @@ func (h *webHandlers) deleteWorkspace(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *webHandlers) lockWorkspace(w http.ResponseWriter, r *http.Request) {
-	id, err := decode.Param("workspace_id", r)
-	if err != nil {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Reason      string `schema:"reason"`
+		// ExpiryHours is the optional number of hours after which the lock
+		// expires; a string because the form field may be left empty.
+		ExpiryHours string `schema:"expiry_hours"`
+	}
+	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 
-	ws, err := h.svc.LockWorkspace(r.Context(), id, nil)
-	if err != nil {
+	opts := LockOptions{Reason: params.Reason}
+	if params.ExpiryHours != "" {
+		hours, err := strconv.Atoi(params.ExpiryHours)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		opts.ExpiresAt = internal.Time(internal.CurrentTimestamp().Add(time.Duration(hours) * time.Hour))
+	}
+	ws, err := h.svc.LockWorkspace(r.Context(), params.WorkspaceID, nil, opts)
+	if errors.Is(err, ErrInvalidLockExpiry) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Workspace(params.WorkspaceID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -545,6 +734,100 @@ func (h *webHandlers) forceUnlockWorkspace(w http.ResponseWriter, r *http.Reques
 	http.Redirect(w, r, paths.Workspace(ws.ID), http.StatusFound)
 }
 
+func (h *webHandlers) listLockEvents(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		PageNumber  int    `schema:"page[number]"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.svc.GetWorkspace(r.Context(), params.WorkspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	events, err := h.svc.ListWorkspaceLockEvents(r.Context(), ws.ID, resource.PageOptions{
+		PageNumber: params.PageNumber,
+		PageSize:   html.PageSize,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("workspace_lock_history.tmpl", w, struct {
+		WorkspacePage
+		*resource.Page[*LockEvent]
+	}{
+		WorkspacePage: NewPage(r, "lock history", ws),
+		Page:          events,
+	})
+}
+
+func (h *webHandlers) newClone(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.svc.GetWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("workspace_clone.tmpl", w, struct {
+		WorkspacePage
+	}{
+		WorkspacePage: NewPage(r, "clone", ws),
+	})
+}
+
+func (h *webHandlers) cloneWorkspace(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID        string  `schema:"workspace_id,required"`
+		Name               *string `schema:"name,required"`
+		Variables          bool    `schema:"variables"`
+		SensitiveVariables bool    `schema:"sensitive_variables"`
+		Permissions        bool    `schema:"permissions"`
+		Notifications      bool    `schema:"notifications"`
+		Tags               bool    `schema:"tags"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.svc.CloneWorkspace(r.Context(), params.WorkspaceID, CloneOptions{
+		Name:               params.Name,
+		Variables:          params.Variables,
+		SensitiveVariables: params.SensitiveVariables,
+		Permissions:        params.Permissions,
+		Notifications:      params.Notifications,
+		Tags:               params.Tags,
+	})
+	if err == internal.ErrResourceAlreadyExists {
+		html.FlashError(w, "workspace already exists: "+*params.Name)
+		http.Redirect(w, r, paths.CloneWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	}
+	if errors.Is(err, internal.ErrAccessNotPermitted) {
+		html.FlashError(w, "insufficient permissions to clone workspace with the selected options")
+		http.Redirect(w, r, paths.CloneWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	}
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "created workspace: "+ws.Name)
+	http.Redirect(w, r, paths.Workspace(ws.ID), http.StatusFound)
+}
+
 func (h *webHandlers) listWorkspaceVCSProviders(w http.ResponseWriter, r *http.Request) {
 	workspaceID, err := decode.Param("workspace_id", r)
 	if err != nil {
@@ -702,6 +985,99 @@ func (h *webHandlers) unsetWorkspacePermission(w http.ResponseWriter, r *http.Re
 	http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
 }
 
+func (h *webHandlers) setApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID   string   `schema:"workspace_id,required"`
+		Required      *int     `schema:"approvals_required"`
+		Teams         []string `schema:"teams"`
+		ExcludeAuthor bool     `schema:"exclude_author"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// the form submits the complete set of teams, so an empty selection must
+	// clear any existing teams rather than leave them untouched.
+	teams := params.Teams
+	if teams == nil {
+		teams = []string{}
+	}
+	_, err := h.svc.SetApprovalPolicy(r.Context(), params.WorkspaceID, SetApprovalPolicyOptions{
+		Required:      params.Required,
+		Teams:         teams,
+		ExcludeAuthor: &params.ExcludeAuthor,
+	})
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	}
+	html.FlashSuccess(w, "updated approval policy")
+	http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) addRemoteStateConsumer(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		ConsumerID  string `schema:"consumer_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.svc.AddRemoteStateConsumers(r.Context(), params.WorkspaceID, []string{params.ConsumerID})
+	if errors.Is(err, internal.ErrRemoteStateConsumerSelf) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	}
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "added remote state consumer")
+	http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) removeRemoteStateConsumer(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		ConsumerID  string `schema:"consumer_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.svc.RemoveRemoteStateConsumers(r.Context(), params.WorkspaceID, []string{params.ConsumerID})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "removed remote state consumer")
+	http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+}
+
+// filterNonConsumers returns those workspaces that are neither the workspace
+// itself nor already a consumer of its state.
+func filterNonConsumers(ws *Workspace, consumers, workspaces []*Workspace) (nonConsumers []*Workspace) {
+	existing := make(map[string]struct{}, len(consumers))
+	for _, c := range consumers {
+		existing[c.ID] = struct{}{}
+	}
+	for _, other := range workspaces {
+		if other.ID == ws.ID {
+			continue
+		}
+		if _, ok := existing[other.ID]; !ok {
+			nonConsumers = append(nonConsumers, other)
+		}
+	}
+	return
+}
+
 // filterUnassigned removes from the list of teams those that are part of the
 // policy, i.e. those that have been assigned a permission.
 //
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/leg100/otf/internal"
@@ -163,6 +164,42 @@ func TestEditWorkspaceHandler(t *testing.T) {
 				assert.NotNil(t, got)
 			},
 		},
+		{
+			name: "with timeouts",
+			ws:   &Workspace{ID: "ws-123", PlanTimeout: 30 * time.Minute},
+			user: auth.SiteAdmin,
+			want: func(t *testing.T, doc *html.Node) {
+				plan := htmlquery.FindOne(doc, "//input[@id='plan-timeout']")
+				require.NotNil(t, plan)
+				assert.Equal(t, "30", testutils.AttrMap(plan)["value"])
+
+				apply := htmlquery.FindOne(doc, "//input[@id='apply-timeout']")
+				require.NotNil(t, apply)
+				assert.Equal(t, "0", testutils.AttrMap(apply)["value"])
+			},
+		},
+		{
+			name: "with auto-discard ttl",
+			ws:   &Workspace{ID: "ws-123", AutoDiscardTTL: 2 * time.Hour},
+			user: auth.SiteAdmin,
+			want: func(t *testing.T, doc *html.Node) {
+				ttl := htmlquery.FindOne(doc, "//input[@id='auto-discard-ttl']")
+				require.NotNil(t, ttl)
+				assert.Equal(t, "120", testutils.AttrMap(ttl)["value"])
+				// supersede option is only shown for connected workspaces
+				assert.Nil(t, htmlquery.FindOne(doc, "//input[@id='supersede-runs']"))
+			},
+		},
+		{
+			name: "connected with supersede runs",
+			ws:   &Workspace{ID: "ws-123", SupersedeRuns: true, Connection: &Connection{}},
+			user: auth.SiteAdmin,
+			want: func(t *testing.T, doc *html.Node) {
+				supersede := htmlquery.FindOne(doc, "//input[@id='supersede-runs']")
+				require.NotNil(t, supersede)
+				assert.Contains(t, testutils.AttrMap(supersede), "checked")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -248,6 +285,36 @@ func TestListWorkspacesHandler(t *testing.T) {
 	})
 }
 
+func TestWorkspace_NewClone(t *testing.T) {
+	ws := &Workspace{ID: "ws-123", Organization: "acme-corp"}
+	app := fakeWebHandlers(t, withWorkspaces(ws))
+
+	r := httptest.NewRequest("GET", "/?workspace_id=ws-123", nil)
+	w := httptest.NewRecorder()
+	app.newClone(w, r)
+	assert.Equal(t, 200, w.Code, w.Body.String())
+}
+
+func TestWorkspace_Clone(t *testing.T) {
+	ws := &Workspace{ID: "ws-456", Name: "staging", Organization: "acme-corp"}
+	app := fakeWebHandlers(t, withWorkspaces(ws))
+
+	form := strings.NewReader(url.Values{
+		"workspace_id": {"ws-123"},
+		"name":         {"staging"},
+		"variables":    {"true"},
+	}.Encode())
+	r := httptest.NewRequest("POST", "/", form)
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	app.cloneWorkspace(w, r)
+	if assert.Equal(t, 302, w.Code, "output: %s", w.Body.String()) {
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		assert.Equal(t, paths.Workspace("ws-456"), redirect.Path)
+	}
+}
+
 func TestDeleteWorkspace(t *testing.T) {
 	ws := &Workspace{ID: "ws-123", Organization: "acme-corp"}
 	app := fakeWebHandlers(t, withWorkspaces(ws))
@@ -261,6 +328,34 @@ func TestDeleteWorkspace(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, paths.Workspaces("acme-corp"), redirect.Path)
 	}
+
+	t.Run("refuse workspace with resources", func(t *testing.T) {
+		app := fakeWebHandlers(t, withWorkspaces(ws), withResources())
+
+		q := "/?workspace_id=ws-123"
+		r := httptest.NewRequest("GET", q, nil)
+		w := httptest.NewRecorder()
+		app.deleteWorkspace(w, r)
+		if assert.Equal(t, 302, w.Code) {
+			redirect, err := w.Result().Location()
+			require.NoError(t, err)
+			assert.Equal(t, paths.EditWorkspace("ws-123"), redirect.Path)
+		}
+	})
+
+	t.Run("force delete workspace with resources", func(t *testing.T) {
+		app := fakeWebHandlers(t, withWorkspaces(ws), withResources())
+
+		q := "/?workspace_id=ws-123&force=true"
+		r := httptest.NewRequest("GET", q, nil)
+		w := httptest.NewRecorder()
+		app.deleteWorkspace(w, r)
+		if assert.Equal(t, 302, w.Code) {
+			redirect, err := w.Result().Location()
+			require.NoError(t, err)
+			assert.Equal(t, paths.Workspaces("acme-corp"), redirect.Path)
+		}
+	})
 }
 
 func TestLockWorkspace(t *testing.T) {
@@ -21,6 +21,7 @@ const (
 	AgentExecutionMode  ExecutionMode = "agent"
 
 	DefaultAllowDestroyPlan = true
+	DefaultMaxPreviews      = 5
 
 	MinTerraformVersion     = "1.2.0"
 	DefaultTerraformVersion = "1.5.2"
@@ -32,6 +33,8 @@ var (
 	ErrTriggerPatternsAndAlwaysTrigger = errors.New("cannot specify both trigger-patterns and always-trigger")
 	ErrInvalidTriggerPattern           = errors.New("invalid trigger glob pattern")
 	ErrInvalidTagsRegex                = errors.New("invalid vcs tags regular expression")
+	ErrInvalidMaxPreviews              = errors.New("maximum number of preview workspaces cannot be negative")
+	ErrWorkspaceHasResources           = errors.New("workspace state contains resources; destroy them first or force the deletion")
 
 	apiTestTerraformVersions = []string{"0.10.0", "0.11.0", "0.11.1"}
 )
@@ -59,10 +62,50 @@ type (
 		TerraformVersion           string        `json:"terraform_version"`
 		WorkingDirectory           string        `json:"working_directory"`
 		Organization               string        `json:"organization"`
+		ProjectID                  string        `json:"project_id"`
 		LatestRun                  *LatestRun    `json:"latest_run"`
 		Tags                       []string      `json:"tags"`
 		Lock                       *Lock         `json:"lock"`
 
+		// PlanTimeout and ApplyTimeout override the site-wide maximum duration
+		// of the plan and apply phases respectively. Zero means the site-wide
+		// default applies.
+		PlanTimeout  time.Duration `json:"plan_timeout"`
+		ApplyTimeout time.Duration `json:"apply_timeout"`
+
+		// AutoDiscardTTL is the period after which a run awaiting
+		// confirmation is automatically discarded. Zero disables auto-discard.
+		AutoDiscardTTL time.Duration `json:"auto_discard_ttl"`
+		// SupersedeRuns, if true, permits a VCS-triggered run to supersede
+		// older pending runs for the same branch.
+		SupersedeRuns bool `json:"supersede_runs"`
+
+		// PreviewTemplate, if true, marks the workspace as a template from
+		// which a preview workspace is cloned for each pull request opened on
+		// the workspace's repo. A template does not itself run in response to
+		// VCS events.
+		PreviewTemplate bool `json:"preview_template"`
+		// MaxPreviews is the maximum number of preview workspaces that can
+		// exist for the template at any one time.
+		MaxPreviews int `json:"max_previews"`
+		// Preview is non-nil if the workspace is a preview workspace.
+		Preview *Preview `json:"preview"`
+
+		// AutoDestroyAt schedules a destroy run for the given time; nil means
+		// no destroy is scheduled.
+		AutoDestroyAt *time.Time `json:"auto_destroy_at"`
+		// AutoDestroyActivityDuration schedules a destroy run once the
+		// workspace has been inactive for the given period. Zero disables
+		// destroying inactive workspaces.
+		AutoDestroyActivityDuration time.Duration `json:"auto_destroy_activity_duration"`
+		// AutoApplyDestroy, if true, automatically applies scheduled destroy
+		// runs.
+		AutoApplyDestroy bool `json:"auto_apply_destroy"`
+
+		// Template, if true, offers the workspace as a template from which
+		// new workspaces can be cloned.
+		Template bool `json:"template"`
+
 		// VCS Connection; nil means the workspace is not connected.
 		Connection *Connection
 
@@ -94,6 +137,24 @@ type (
 		// possible to run a terraform apply via the CLI. Setting this to true
 		// overrides this behaviour.
 		AllowCLIApply bool
+
+		// PRComments, if true, posts a summary of each speculative plan
+		// triggered by a pull request as a comment on the pull request.
+		PRComments bool
+
+		// PRApply, if true, permits authorized users to plan and apply the
+		// head of a pull request by commenting `otf apply <workspace>` on
+		// the pull request.
+		PRApply bool
+	}
+
+	// Preview identifies the pull request for which a preview workspace was
+	// created.
+	Preview struct {
+		// ID of template workspace the preview was cloned from; empty if the
+		// template has since been deleted.
+		TemplateID  string
+		PullRequest int
 	}
 
 	ConnectOptions struct {
@@ -103,6 +164,8 @@ type (
 		Branch        *string
 		TagsRegex     *string
 		AllowCLIApply *bool
+		PRComments    *bool
+		PRApply       *bool
 	}
 
 	// LatestRun is a summary of the latest run for a workspace
@@ -115,29 +178,44 @@ type (
 
 	// CreateOptions represents the options for creating a new workspace.
 	CreateOptions struct {
-		AllowDestroyPlan           *bool
-		AutoApply                  *bool
-		Description                *string
-		ExecutionMode              *ExecutionMode
-		GlobalRemoteState          *bool
-		MigrationEnvironment       *string
-		Name                       *string
-		QueueAllRuns               *bool
-		SpeculativeEnabled         *bool
-		SourceName                 *string
-		SourceURL                  *string
-		StructuredRunOutputEnabled *bool
-		Tags                       []TagSpec
-		TerraformVersion           *string
-		TriggerPrefixes            []string
-		TriggerPatterns            []string
-		WorkingDirectory           *string
-		Organization               *string
+		AllowDestroyPlan            *bool
+		AutoApply                   *bool
+		Description                 *string
+		ExecutionMode               *ExecutionMode
+		GlobalRemoteState           *bool
+		MigrationEnvironment        *string
+		Name                        *string
+		QueueAllRuns                *bool
+		SpeculativeEnabled          *bool
+		SourceName                  *string
+		SourceURL                   *string
+		StructuredRunOutputEnabled  *bool
+		Tags                        []TagSpec
+		TerraformVersion            *string
+		TriggerPrefixes             []string
+		TriggerPatterns             []string
+		WorkingDirectory            *string
+		Organization                *string
+		ProjectID                   *string // nil means the default project
+		PlanTimeout                 *time.Duration
+		ApplyTimeout                *time.Duration
+		AutoDiscardTTL              *time.Duration
+		SupersedeRuns               *bool
+		PreviewTemplate             *bool
+		MaxPreviews                 *int
+		AutoDestroyAt               *time.Time
+		AutoDestroyActivityDuration *time.Duration
+		AutoApplyDestroy            *bool
+		Template                    *bool
 
 		// Always trigger runs. A value of true is mutually exclusive with
 		// setting TriggerPatterns or ConnectOptions.TagsRegex.
 		AlwaysTrigger *bool
 
+		// Preview, if non-nil, creates a preview workspace for a pull
+		// request.
+		Preview *Preview
+
 		*ConnectOptions
 	}
 
@@ -156,6 +234,21 @@ type (
 		TriggerPrefixes            []string
 		TriggerPatterns            []string
 		WorkingDirectory           *string
+		PlanTimeout                *time.Duration
+		ApplyTimeout               *time.Duration
+		AutoDiscardTTL             *time.Duration
+		SupersedeRuns              *bool
+		PreviewTemplate            *bool
+		MaxPreviews                *int
+		// ProjectID moves the workspace to another project in the same
+		// organization.
+		ProjectID *string
+		// AutoDestroyAt schedules a destroy run; a zero time cancels a
+		// scheduled destroy.
+		AutoDestroyAt               *time.Time
+		AutoDestroyActivityDuration *time.Duration
+		AutoApplyDestroy            *bool
+		Template                    *bool
 
 		// Always trigger runs. A value of true is mutually exclusive with
 		// setting TriggerPatterns or ConnectOptions.TagsRegex.
@@ -177,6 +270,10 @@ type (
 		Search       string
 		Tags         []string
 		Organization *string
+		// ProjectID filters workspaces by project.
+		ProjectID *string
+		// TemplatesOnly filters workspaces to those marked as templates.
+		TemplatesOnly bool
 
 		resource.PageOptions
 	}
@@ -202,7 +299,9 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 		ExecutionMode:      RemoteExecutionMode,
 		TerraformVersion:   DefaultTerraformVersion,
 		SpeculativeEnabled: true,
+		MaxPreviews:        DefaultMaxPreviews,
 		Organization:       *opts.Organization,
+		Preview:            opts.Preview,
 	}
 	if err := ws.setName(*opts.Name); err != nil {
 		return nil, err
@@ -213,6 +312,9 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 			return nil, err
 		}
 	}
+	if opts.ProjectID != nil {
+		ws.ProjectID = *opts.ProjectID
+	}
 	if opts.AllowDestroyPlan != nil {
 		ws.AllowDestroyPlan = *opts.AllowDestroyPlan
 	}
@@ -248,6 +350,41 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 	if opts.WorkingDirectory != nil {
 		ws.WorkingDirectory = *opts.WorkingDirectory
 	}
+	if err := ws.setTimeouts(opts.PlanTimeout, opts.ApplyTimeout); err != nil {
+		return nil, err
+	}
+	if opts.AutoDiscardTTL != nil {
+		if err := ws.setAutoDiscardTTL(*opts.AutoDiscardTTL); err != nil {
+			return nil, err
+		}
+	}
+	if opts.SupersedeRuns != nil {
+		ws.SupersedeRuns = *opts.SupersedeRuns
+	}
+	if opts.PreviewTemplate != nil {
+		ws.PreviewTemplate = *opts.PreviewTemplate
+	}
+	if opts.MaxPreviews != nil {
+		if err := ws.setMaxPreviews(*opts.MaxPreviews); err != nil {
+			return nil, err
+		}
+	}
+	if opts.AutoDestroyAt != nil {
+		if err := ws.setAutoDestroyAt(*opts.AutoDestroyAt); err != nil {
+			return nil, err
+		}
+	}
+	if opts.AutoDestroyActivityDuration != nil {
+		if err := ws.setAutoDestroyActivityDuration(*opts.AutoDestroyActivityDuration); err != nil {
+			return nil, err
+		}
+	}
+	if opts.AutoApplyDestroy != nil {
+		ws.AutoApplyDestroy = *opts.AutoApplyDestroy
+	}
+	if opts.Template != nil {
+		ws.Template = *opts.Template
+	}
 	// TriggerPrefixes are not used but OTF persists it in order to pass go-tfe
 	// integration tests.
 	if opts.TriggerPrefixes != nil {
@@ -312,6 +449,10 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		}
 		updated = true
 	}
+	if opts.ProjectID != nil {
+		ws.ProjectID = *opts.ProjectID
+		updated = true
+	}
 	if opts.AllowDestroyPlan != nil {
 		ws.AllowDestroyPlan = *opts.AllowDestroyPlan
 		updated = true
@@ -364,6 +505,52 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		ws.WorkingDirectory = *opts.WorkingDirectory
 		updated = true
 	}
+	if opts.PlanTimeout != nil || opts.ApplyTimeout != nil {
+		if err := ws.setTimeouts(opts.PlanTimeout, opts.ApplyTimeout); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if opts.AutoDiscardTTL != nil {
+		if err := ws.setAutoDiscardTTL(*opts.AutoDiscardTTL); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if opts.SupersedeRuns != nil {
+		ws.SupersedeRuns = *opts.SupersedeRuns
+		updated = true
+	}
+	if opts.PreviewTemplate != nil {
+		ws.PreviewTemplate = *opts.PreviewTemplate
+		updated = true
+	}
+	if opts.MaxPreviews != nil {
+		if err := ws.setMaxPreviews(*opts.MaxPreviews); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if opts.AutoDestroyAt != nil {
+		if err := ws.setAutoDestroyAt(*opts.AutoDestroyAt); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if opts.AutoDestroyActivityDuration != nil {
+		if err := ws.setAutoDestroyActivityDuration(*opts.AutoDestroyActivityDuration); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if opts.AutoApplyDestroy != nil {
+		ws.AutoApplyDestroy = *opts.AutoApplyDestroy
+		updated = true
+	}
+	if opts.Template != nil {
+		ws.Template = *opts.Template
+		updated = true
+	}
 	// TriggerPrefixes are not used but OTF persists it in order to pass go-tfe
 	// integration tests.
 	if opts.TriggerPrefixes != nil {
@@ -437,6 +624,14 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 				ws.Connection.AllowCLIApply = *opts.AllowCLIApply
 				updated = true
 			}
+			if opts.PRComments != nil {
+				ws.Connection.PRComments = *opts.PRComments
+				updated = true
+			}
+			if opts.PRApply != nil {
+				ws.Connection.PRApply = *opts.PRApply
+				updated = true
+			}
 		}
 	}
 	if updated {
@@ -460,6 +655,12 @@ func (ws *Workspace) addConnection(opts *ConnectOptions) error {
 	if opts.AllowCLIApply != nil {
 		ws.Connection.AllowCLIApply = *opts.AllowCLIApply
 	}
+	if opts.PRComments != nil {
+		ws.Connection.PRComments = *opts.PRComments
+	}
+	if opts.PRApply != nil {
+		ws.Connection.PRApply = *opts.PRApply
+	}
 	if opts.TagsRegex != nil {
 		if err := ws.setTagsRegex(*opts.TagsRegex); err != nil {
 			return fmt.Errorf("invalid tags-regex: %w", err)
@@ -505,6 +706,95 @@ func (ws *Workspace) setTerraformVersion(v string) error {
 	return nil
 }
 
+// PhaseTimeout returns the maximum duration of the given phase of the
+// workspace's runs: the workspace's own timeout if it has one, otherwise the
+// given site-wide default.
+func (ws *Workspace) PhaseTimeout(phase internal.PhaseType, siteDefault time.Duration) time.Duration {
+	var timeout time.Duration
+	switch phase {
+	case internal.PlanPhase:
+		timeout = ws.PlanTimeout
+	case internal.ApplyPhase:
+		timeout = ws.ApplyTimeout
+	}
+	if timeout == 0 {
+		return siteDefault
+	}
+	return timeout
+}
+
+// setTimeouts sets the phase timeouts, leaving a timeout unchanged if nil.
+// Negative timeouts are rejected.
+func (ws *Workspace) setTimeouts(plan, apply *time.Duration) error {
+	if (plan != nil && *plan < 0) || (apply != nil && *apply < 0) {
+		return internal.ErrInvalidPhaseTimeout
+	}
+	if plan != nil {
+		ws.PlanTimeout = *plan
+	}
+	if apply != nil {
+		ws.ApplyTimeout = *apply
+	}
+	return nil
+}
+
+func (ws *Workspace) setAutoDiscardTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return internal.ErrInvalidAutoDiscardTTL
+	}
+	ws.AutoDiscardTTL = ttl
+	return nil
+}
+
+func (ws *Workspace) setMaxPreviews(max int) error {
+	if max < 0 {
+		return ErrInvalidMaxPreviews
+	}
+	ws.MaxPreviews = max
+	return nil
+}
+
+func (ws *Workspace) setAutoDestroyAt(at time.Time) error {
+	if at.IsZero() {
+		ws.AutoDestroyAt = nil
+		return nil
+	}
+	if !at.After(internal.CurrentTimestamp()) {
+		return internal.ErrInvalidAutoDestroyAt
+	}
+	at = at.UTC()
+	ws.AutoDestroyAt = &at
+	return nil
+}
+
+func (ws *Workspace) setAutoDestroyActivityDuration(d time.Duration) error {
+	if d < 0 {
+		return internal.ErrInvalidAutoDestroyActivityDuration
+	}
+	ws.AutoDestroyActivityDuration = d
+	return nil
+}
+
+// AutoDestroyDeadline returns the time at which the workspace is due to be
+// destroyed, given the time of its most recent activity. False is returned if
+// no destroy is scheduled.
+func (ws *Workspace) AutoDestroyDeadline(lastActivity time.Time) (time.Time, bool) {
+	var (
+		deadline time.Time
+		ok       bool
+	)
+	if ws.AutoDestroyAt != nil {
+		deadline, ok = *ws.AutoDestroyAt, true
+	}
+	if ws.AutoDestroyActivityDuration > 0 {
+		inactive := lastActivity.Add(ws.AutoDestroyActivityDuration)
+		if !ok || inactive.Before(deadline) {
+			deadline, ok = inactive, true
+		}
+	}
+	return deadline, ok
+}
+
 func (ws *Workspace) setTagsRegex(regex string) error {
 	if _, err := regexp.Compile(regex); err != nil {
 		return ErrInvalidTagsRegex
@@ -3,6 +3,7 @@ package workspace
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/stretchr/testify/assert"
@@ -215,6 +216,38 @@ func TestWorkspace_UpdateError(t *testing.T) {
 			},
 			want: ErrInvalidTagsRegex,
 		},
+		{
+			name: "negative plan timeout",
+			ws:   &Workspace{Name: "dev", Organization: "acme"},
+			opts: UpdateOptions{
+				PlanTimeout: internal.Duration(-time.Minute),
+			},
+			want: internal.ErrInvalidPhaseTimeout,
+		},
+		{
+			name: "negative auto-discard ttl",
+			ws:   &Workspace{Name: "dev", Organization: "acme"},
+			opts: UpdateOptions{
+				AutoDiscardTTL: internal.Duration(-time.Hour),
+			},
+			want: internal.ErrInvalidAutoDiscardTTL,
+		},
+		{
+			name: "auto-destroy time in the past",
+			ws:   &Workspace{Name: "dev", Organization: "acme"},
+			opts: UpdateOptions{
+				AutoDestroyAt: internal.Time(time.Now().Add(-time.Hour)),
+			},
+			want: internal.ErrInvalidAutoDestroyAt,
+		},
+		{
+			name: "negative auto-destroy activity duration",
+			ws:   &Workspace{Name: "dev", Organization: "acme"},
+			opts: UpdateOptions{
+				AutoDestroyActivityDuration: internal.Duration(-time.Hour),
+			},
+			want: internal.ErrInvalidAutoDestroyActivityDuration,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -269,6 +302,51 @@ func TestWorkspace_Update(t *testing.T) {
 				assert.Equal(t, "\\d+", got.Connection.TagsRegex)
 			},
 		},
+		{
+			name: "set timeouts",
+			ws:   &Workspace{Name: "dev", Organization: "acme", ApplyTimeout: time.Hour},
+			opts: UpdateOptions{
+				PlanTimeout: internal.Duration(10 * time.Minute),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.Equal(t, 10*time.Minute, got.PlanTimeout)
+				assert.Equal(t, time.Hour, got.ApplyTimeout)
+			},
+		},
+		{
+			name: "auto-discard and supersede runs",
+			ws:   &Workspace{Name: "dev", Organization: "acme"},
+			opts: UpdateOptions{
+				AutoDiscardTTL: internal.Duration(24 * time.Hour),
+				SupersedeRuns:  internal.Bool(true),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.Equal(t, 24*time.Hour, got.AutoDiscardTTL)
+				assert.True(t, got.SupersedeRuns)
+			},
+		},
+		{
+			name: "cancel scheduled auto-destroy",
+			ws:   &Workspace{Name: "dev", Organization: "acme", AutoDestroyAt: internal.Time(time.Now().Add(time.Hour))},
+			opts: UpdateOptions{
+				AutoDestroyAt:    &time.Time{},
+				AutoApplyDestroy: internal.Bool(true),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.Nil(t, got.AutoDestroyAt)
+				assert.True(t, got.AutoApplyDestroy)
+			},
+		},
+		{
+			name: "move to another project",
+			ws:   &Workspace{Name: "dev", Organization: "acme", ProjectID: "prj-default"},
+			opts: UpdateOptions{
+				ProjectID: internal.String("prj-networking"),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.Equal(t, "prj-networking", got.ProjectID)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -279,6 +357,37 @@ func TestWorkspace_Update(t *testing.T) {
 	}
 }
 
+func TestWorkspace_PhaseTimeout(t *testing.T) {
+	ws := &Workspace{ApplyTimeout: time.Hour}
+
+	assert.Equal(t, 2*time.Hour, ws.PhaseTimeout(internal.PlanPhase, 2*time.Hour))
+	assert.Equal(t, time.Hour, ws.PhaseTimeout(internal.ApplyPhase, 24*time.Hour))
+}
+
+func TestWorkspace_AutoDestroyDeadline(t *testing.T) {
+	lastActivity := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := lastActivity.Add(6 * time.Hour)
+
+	tests := []struct {
+		name   string
+		ws     *Workspace
+		want   time.Time
+		wantOK bool
+	}{
+		{"disabled", &Workspace{}, time.Time{}, false},
+		{"scheduled time", &Workspace{AutoDestroyAt: &at}, at, true},
+		{"inactivity", &Workspace{AutoDestroyActivityDuration: 2 * time.Hour}, lastActivity.Add(2 * time.Hour), true},
+		{"earliest of both", &Workspace{AutoDestroyAt: &at, AutoDestroyActivityDuration: 24 * time.Hour}, at, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.ws.AutoDestroyDeadline(lastActivity)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestWorkspace_UpdateConnection(t *testing.T) {
 	tests := []struct {
 		name string